@@ -55,6 +55,21 @@ type DigitalPinner interface {
 	DigitalPinOptionApplier
 }
 
+// DigitalPinGrouper is the interface for system gpio interactions with a group of pins at once. The values of the pins
+// are represented as bitmask, the value of the first pin of the group is bit 0.
+type DigitalPinGrouper interface {
+	// Export exports all pins of the group for use by the adaptor
+	Export() error
+	// Unexport releases all pins of the group from the adaptor, so they are free for the operating system
+	Unexport() error
+	// Read reads the current values of all pins of the group
+	Read() (uint64, error)
+	// Write writes the values to all pins of the group
+	Write(values uint64) error
+	// DigitalPinOptionApplier is the interface to change the behavior of all pins immediately
+	DigitalPinOptionApplier
+}
+
 // DigitalPinValuer is the interface to get pin behavior for the next usage. The interface is and should be rarely used.
 type DigitalPinValuer interface {
 	// DirectionBehavior gets the direction behavior when the pin is used the next time.
@@ -169,6 +184,47 @@ type I2cSystemDevicer interface {
 	Close() error
 }
 
+// I2cQuickWriter is the optional interface of an I2C system device, which supports the SMBus "quick write", which
+// transfers only the address and the write bit. If the bus does not support it, an error wrapping
+// errors.ErrUnsupported is returned.
+type I2cQuickWriter interface {
+	// WriteQuick must be implemented as the sequence:
+	// "S Addr Wr [A] P"
+	WriteQuick(address int) error
+}
+
+// SpiTransferSegment is one segment of a SPI message. All segments of a message are transferred without deselecting
+// the device in between, except "CsChange" is set. A zero value of the settings means the default of the connection is
+// used. See also "struct spi_ioc_transfer" in /usr/include/linux/spi/spidev.h.
+type SpiTransferSegment struct {
+	// Tx contains the data to write, can be nil for a read only segment (zeros will be written).
+	Tx []byte
+	// Rx is the buffer to fill with read data, can be nil for a write only segment. If "Tx" is also given, the length
+	// needs to be the same.
+	Rx []byte
+	// SpeedHz overrides the maximum speed of the connection for this segment.
+	SpeedHz uint32
+	// BitsPerWord overrides the bits per word of the connection for this segment.
+	BitsPerWord uint8
+	// DelayUsecs is the delay after the last bit of this segment, before optionally deselecting the device.
+	DelayUsecs uint16
+	// CsChange deselects the device after this segment, before the next segment starts. For the last segment of a
+	// message, the device keeps selected instead.
+	CsChange bool
+	// TxNbits is the count of data lines used for writing: 1 (single), 2 (dual) or 4 (quad).
+	TxNbits uint8
+	// RxNbits is the count of data lines used for reading: 1 (single), 2 (dual) or 4 (quad).
+	RxNbits uint8
+}
+
+// SpiTransferer is the optional interface of a SPI system device and of a SPI connection, which supports messages of
+// multiple segments. If the system device does not support it, the connection returns an error wrapping
+// errors.ErrUnsupported.
+type SpiTransferer interface {
+	// Transfer sends/receives all given segments as one message, so the device keeps selected between the segments.
+	Transfer(segments ...SpiTransferSegment) error
+}
+
 // SpiSystemDevicer is the interface to a SPI bus at system level.
 type SpiSystemDevicer interface {
 	TxRx(tx []byte, rx []byte) error
@@ -0,0 +1,84 @@
+package gobot
+
+import (
+	"time"
+)
+
+// Clock is the source of time for robots and drivers. The real clock is used by default, for tests a fake clock can be
+// injected, which time is advanced manually, see [NewFakeClock].
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// Since returns the time elapsed since the given time.
+	Since(t time.Time) time.Duration
+	// Sleep pauses the current goroutine for at least the given duration.
+	Sleep(d time.Duration)
+	// After waits for the duration to elapse and then sends the current time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+	// AfterFunc waits for the duration to elapse and then calls f in its own goroutine.
+	AfterFunc(d time.Duration, f func()) Timer
+	// NewTimer creates a new Timer that will send the current time on its channel after at least the given duration.
+	NewTimer(d time.Duration) Timer
+	// NewTicker returns a new Ticker, which sends the current time on its channel with the period of the given duration.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Timer is the interface of a single event, like time.Timer.
+type Timer interface {
+	// C returns the channel on which the time is delivered, it is nil for a timer created by AfterFunc.
+	C() <-chan time.Time
+	// Stop prevents the timer from firing. It returns false, if the timer has already expired or been stopped.
+	Stop() bool
+	// Reset changes the timer to expire after the given duration. It returns true, if the timer had been active.
+	Reset(d time.Duration) bool
+}
+
+// Ticker is the interface of a periodic event, like time.Ticker.
+type Ticker interface {
+	// C returns the channel on which the ticks are delivered.
+	C() <-chan time.Time
+	// Stop turns off the ticker, no more ticks will be sent.
+	Stop()
+	// Reset stops the ticker and resets its period to the given duration.
+	Reset(d time.Duration)
+}
+
+type realClock struct{}
+
+type realTimer struct {
+	timer *time.Timer
+}
+
+type realTicker struct {
+	ticker *time.Ticker
+}
+
+// NewRealClock returns a clock, which uses the functions of package "time".
+func NewRealClock() Clock {
+	return realClock{}
+}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) Since(t time.Time) time.Duration        { return time.Since(t) }
+func (realClock) Sleep(d time.Duration)                  { time.Sleep(d) }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return &realTimer{timer: time.AfterFunc(d, f)}
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return &realTimer{timer: time.NewTimer(d)}
+}
+
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return &realTicker{ticker: time.NewTicker(d)}
+}
+
+func (t *realTimer) C() <-chan time.Time        { return t.timer.C }
+func (t *realTimer) Stop() bool                 { return t.timer.Stop() }
+func (t *realTimer) Reset(d time.Duration) bool { return t.timer.Reset(d) }
+
+func (t *realTicker) C() <-chan time.Time   { return t.ticker.C }
+func (t *realTicker) Stop()                 { t.ticker.Stop() }
+func (t *realTicker) Reset(d time.Duration) { t.ticker.Reset(d) }
@@ -0,0 +1,251 @@
+package gobot
+
+import (
+	"sync"
+	"time"
+)
+
+// FakeClock is a clock for tests, which time is only advanced by calling [FakeClock.Advance] or [FakeClock.Set].
+// All timers, tickers and sleeps, which expire up to the new time, fire in the order of their expiration.
+type FakeClock struct {
+	mutex   sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+// fakeWaiter is a pending timer, ticker or sleep of the fake clock
+type fakeWaiter struct {
+	clock  *FakeClock
+	until  time.Time
+	period time.Duration // only for tickers
+	ch     chan time.Time
+	f      func() // only for AfterFunc
+}
+
+type fakeTimer struct {
+	*fakeWaiter
+}
+
+type fakeTicker struct {
+	*fakeWaiter
+}
+
+// NewFakeClock returns a fake clock, which starts at the given time.
+func NewFakeClock(start time.Time) *FakeClock {
+	c := &FakeClock{now: start}
+	c.cond = sync.NewCond(&c.mutex)
+
+	return c
+}
+
+// Now returns the current time of the fake clock.
+func (c *FakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.now
+}
+
+// Since returns the time elapsed since the given time, according to the fake clock.
+func (c *FakeClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// Sleep blocks until the fake clock is advanced by at least the given duration.
+func (c *FakeClock) Sleep(d time.Duration) {
+	<-c.After(d)
+}
+
+// After returns a channel, which receives the time, when the fake clock is advanced by at least the given duration.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+// AfterFunc calls f in its own goroutine, when the fake clock is advanced by at least the given duration.
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) Timer {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return &fakeTimer{fakeWaiter: c.addWaiter(d, 0, f)}
+}
+
+// NewTimer creates a new timer, which fires when the fake clock is advanced by at least the given duration.
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return &fakeTimer{fakeWaiter: c.addWaiter(d, 0, nil)}
+}
+
+// NewTicker creates a new ticker, which fires each time the fake clock is advanced by the given period.
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for FakeClock.NewTicker")
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return &fakeTicker{fakeWaiter: c.addWaiter(d, d, nil)}
+}
+
+// Advance moves the time of the fake clock forward by the given duration and fires all expired waiters.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+// Set moves the time of the fake clock to the given time and fires all expired waiters. A time before the current
+// time of the fake clock is ignored.
+func (c *FakeClock) Set(t time.Time) {
+	fired := true
+	for fired {
+		fired = c.fireNext(t)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if t.After(c.now) {
+		c.now = t
+	}
+}
+
+// WaiterCount returns the count of the pending timers, tickers and sleeps of the fake clock.
+func (c *FakeClock) WaiterCount() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return len(c.waiters)
+}
+
+// BlockUntil blocks until at least the given count of timers, tickers or sleeps are pending. This can be used to
+// wait for a goroutine to be ready, before the fake clock is advanced.
+func (c *FakeClock) BlockUntil(count int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for len(c.waiters) < count {
+		c.cond.Wait()
+	}
+}
+
+// fireNext fires the earliest waiter, which expires not after the given time and returns true, if a waiter was found
+func (c *FakeClock) fireNext(t time.Time) bool {
+	c.mutex.Lock()
+
+	var next *fakeWaiter
+	for _, w := range c.waiters {
+		if !w.until.After(t) && (next == nil || w.until.Before(next.until)) {
+			next = w
+		}
+	}
+	if next == nil {
+		c.mutex.Unlock()
+		return false
+	}
+
+	if next.until.After(c.now) {
+		c.now = next.until
+	}
+	now := c.now
+	if next.period > 0 {
+		next.until = next.until.Add(next.period)
+	} else {
+		c.removeWaiter(next)
+	}
+	c.mutex.Unlock()
+
+	if next.f != nil {
+		go next.f()
+		return true
+	}
+
+	// like time.Ticker, the tick is dropped for slow receivers
+	select {
+	case next.ch <- now:
+	default:
+	}
+
+	return true
+}
+
+func (c *FakeClock) addWaiter(d time.Duration, period time.Duration, f func()) *fakeWaiter {
+	w := &fakeWaiter{clock: c, until: c.now.Add(d), period: period, f: f}
+	if f == nil {
+		w.ch = make(chan time.Time, 1)
+	}
+	c.waiters = append(c.waiters, w)
+	c.cond.Broadcast()
+
+	return w
+}
+
+func (c *FakeClock) removeWaiter(w *fakeWaiter) bool {
+	for i, item := range c.waiters {
+		if item == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *FakeClock) isPending(w *fakeWaiter) bool {
+	for _, item := range c.waiters {
+		if item == w {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (w *fakeWaiter) C() <-chan time.Time {
+	return w.ch
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mutex.Lock()
+	defer t.clock.mutex.Unlock()
+
+	return t.clock.removeWaiter(t.fakeWaiter)
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mutex.Lock()
+	defer t.clock.mutex.Unlock()
+
+	active := t.clock.isPending(t.fakeWaiter)
+	t.until = t.clock.now.Add(d)
+	if !active {
+		t.clock.waiters = append(t.clock.waiters, t.fakeWaiter)
+		t.clock.cond.Broadcast()
+	}
+
+	return active
+}
+
+func (t *fakeTicker) Stop() {
+	t.clock.mutex.Lock()
+	defer t.clock.mutex.Unlock()
+
+	t.clock.removeWaiter(t.fakeWaiter)
+}
+
+func (t *fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("non-positive interval for Ticker.Reset")
+	}
+
+	t.clock.mutex.Lock()
+	defer t.clock.mutex.Unlock()
+
+	t.period = d
+	t.until = t.clock.now.Add(d)
+	if !t.clock.isPending(t.fakeWaiter) {
+		t.clock.waiters = append(t.clock.waiters, t.fakeWaiter)
+		t.clock.cond.Broadcast()
+	}
+}
@@ -0,0 +1,119 @@
+package gobot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ Clock = NewRealClock()
+	_ Clock = (*FakeClock)(nil)
+)
+
+func TestRealClock(t *testing.T) {
+	// arrange
+	c := NewRealClock()
+	start := c.Now()
+	// act
+	c.Sleep(time.Millisecond)
+	<-c.After(time.Millisecond)
+	timer := c.NewTimer(time.Millisecond)
+	<-timer.C()
+	ticker := c.NewTicker(time.Millisecond)
+	<-ticker.C()
+	ticker.Stop()
+	done := make(chan struct{})
+	c.AfterFunc(time.Millisecond, func() { close(done) })
+	<-done
+	// assert
+	assert.GreaterOrEqual(t, c.Since(start), 4*time.Millisecond)
+	assert.False(t, timer.Stop())
+	assert.False(t, timer.Reset(time.Hour))
+	assert.True(t, timer.Stop())
+}
+
+func TestFakeClockTimer(t *testing.T) {
+	// arrange
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+	timer := c.NewTimer(time.Second)
+	// act & assert: not expired
+	c.Advance(999 * time.Millisecond)
+	select {
+	case <-timer.C():
+		require.Fail(t, "timer fired too early")
+	default:
+	}
+	// act & assert: expired
+	c.Advance(time.Millisecond)
+	assert.Equal(t, start.Add(time.Second), <-timer.C())
+	assert.Equal(t, time.Second, c.Since(start))
+	assert.False(t, timer.Stop())
+	// act & assert: reset and stop
+	assert.False(t, timer.Reset(time.Second))
+	assert.Equal(t, 1, c.WaiterCount())
+	assert.True(t, timer.Reset(2*time.Second))
+	assert.True(t, timer.Stop())
+	assert.Equal(t, 0, c.WaiterCount())
+}
+
+func TestFakeClockTicker(t *testing.T) {
+	// arrange
+	c := NewFakeClock(time.Unix(0, 0))
+	ticker := c.NewTicker(10 * time.Millisecond)
+	// act & assert
+	for i := 1; i <= 3; i++ {
+		c.Advance(10 * time.Millisecond)
+		assert.Equal(t, time.Unix(0, int64(i)*int64(10*time.Millisecond)), <-ticker.C())
+	}
+	// act & assert: ticks are dropped for slow receivers, like for time.Ticker
+	c.Advance(30 * time.Millisecond)
+	assert.Equal(t, time.Unix(0, int64(40*time.Millisecond)), <-ticker.C())
+	// act & assert: reset and stop
+	ticker.Reset(time.Second)
+	c.Advance(time.Second)
+	<-ticker.C()
+	ticker.Stop()
+	assert.Equal(t, 0, c.WaiterCount())
+	assert.Panics(t, func() { c.NewTicker(0) })
+}
+
+func TestFakeClockAdvanceFiresInOrder(t *testing.T) {
+	// arrange
+	c := NewFakeClock(time.Unix(0, 0))
+	second := c.NewTimer(2 * time.Second)
+	first := c.NewTimer(time.Second)
+	stopped := c.AfterFunc(time.Second, func() { require.Fail(t, "stopped timer fired") })
+	require.True(t, stopped.Stop())
+	done := make(chan struct{})
+	c.AfterFunc(1500*time.Millisecond, func() { close(done) })
+	// act
+	c.Advance(3 * time.Second)
+	// assert: each waiter gets the time of its expiration
+	assert.Equal(t, time.Unix(1, 0), <-first.C())
+	assert.Equal(t, time.Unix(2, 0), <-second.C())
+	<-done
+	assert.Equal(t, time.Unix(3, 0), c.Now())
+	// act & assert: the time can not go backward
+	c.Set(time.Unix(0, 0))
+	assert.Equal(t, time.Unix(3, 0), c.Now())
+}
+
+func TestFakeClockSleepAndBlockUntil(t *testing.T) {
+	// arrange
+	c := NewFakeClock(time.Unix(0, 0))
+	done := make(chan struct{})
+	go func() {
+		c.Sleep(time.Minute)
+		close(done)
+	}()
+	// act
+	c.BlockUntil(1)
+	c.Advance(time.Minute)
+	// assert
+	<-done
+	assert.Equal(t, time.Unix(60, 0), c.Now())
+}
@@ -37,12 +37,18 @@ type optionApplier interface {
 
 // configuration contains all changeable attributes of the driver.
 type configuration struct {
-	name string
+	name  string
+	clock gobot.Clock
 }
 
 // nameOption is the type for applying another name to the configuration
 type nameOption string
 
+// clockOption is the type for applying another clock to the configuration
+type clockOption struct {
+	clock gobot.Clock
+}
+
 // Driver implements the interface gobot.Driver.
 type driver struct {
 	driverCfg  *configuration
@@ -56,7 +62,7 @@ type driver struct {
 // newDriver creates a new basic analog gobot driver.
 func newDriver(a interface{}, name string) *driver {
 	d := driver{
-		driverCfg:  &configuration{name: gobot.DefaultName(name)},
+		driverCfg:  &configuration{name: gobot.DefaultName(name), clock: gobot.NewRealClock()},
 		connection: a,
 		afterStart: func() error { return nil },
 		beforeHalt: func() error { return nil },
@@ -72,6 +78,12 @@ func WithName(name string) optionApplier {
 	return nameOption(name)
 }
 
+// WithClock is used to replace the real clock of the driver, e.g. by a fake clock for tests. The clock is used for
+// the cyclic reading.
+func WithClock(clock gobot.Clock) optionApplier {
+	return clockOption{clock: clock}
+}
+
 // Name returns the name of the driver.
 func (d *driver) Name() string {
 	return d.driverCfg.name
@@ -117,3 +129,12 @@ func (d *driver) Halt() error {
 func (o nameOption) apply(c *configuration) {
 	c.name = string(o)
 }
+
+func (o clockOption) String() string {
+	return "clock option for analog drivers"
+}
+
+// apply change the clock in the configuration.
+func (o clockOption) apply(c *configuration) {
+	c.clock = o.clock
+}
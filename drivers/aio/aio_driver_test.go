@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -46,6 +47,16 @@ func Test_applyWithName(t *testing.T) {
 	assert.Equal(t, name, cfg.name)
 }
 
+func Test_applyWithClock(t *testing.T) {
+	// arrange
+	clock := gobot.NewFakeClock(time.Unix(0, 0))
+	cfg := configuration{clock: gobot.NewRealClock()}
+	// act
+	WithClock(clock).apply(&cfg)
+	// assert
+	assert.Same(t, clock, cfg.clock)
+}
+
 func TestStart(t *testing.T) {
 	// arrange
 	d := initTestDriver()
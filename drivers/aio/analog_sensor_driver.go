@@ -45,6 +45,7 @@ type AnalogSensorDriver struct {
 // Supported options:
 //
 //	"WithName"
+//	"WithClock"
 //	"WithSensorCyclicRead"
 //	"WithSensorScaler"
 //
@@ -168,7 +169,7 @@ func (a *AnalogSensorDriver) initialize() error {
 	oldRawValue := 0
 	oldValue := 0.0
 	go func() {
-		timer := time.NewTimer(a.sensorCfg.readInterval)
+		timer := a.driverCfg.clock.NewTimer(a.sensorCfg.readInterval)
 		timer.Stop()
 
 		for {
@@ -189,7 +190,7 @@ func (a *AnalogSensorDriver) initialize() error {
 
 			timer.Reset(a.sensorCfg.readInterval) // ensure that after each read is a wait, independent of duration of read
 			select {
-			case <-timer.C:
+			case <-timer.C():
 			case <-a.halt:
 				timer.Stop()
 				return
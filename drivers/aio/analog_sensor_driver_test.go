@@ -234,6 +234,30 @@ func TestAnalogSensor_WithSensorCyclicRead(t *testing.T) {
 	}
 }
 
+func TestAnalogSensor_WithSensorCyclicReadAndClock(t *testing.T) {
+	// arrange
+	clock := gobot.NewFakeClock(time.Unix(0, 0))
+	a := newAioTestAdaptor()
+	var reads int
+	a.analogReadFunc = func() (int, error) {
+		reads++
+		return reads, nil
+	}
+	d := NewAnalogSensorDriver(a, "1", WithClock(clock), WithSensorCyclicRead(time.Minute))
+	data := make(chan int, 3)
+	_ = d.On(Data, func(val interface{}) { data <- val.(int) })
+	// act & assert: first read immediately
+	require.NoError(t, d.Start())
+	assert.Equal(t, 1, <-data)
+	// act & assert: next read after the interval
+	for want := 2; want <= 3; want++ {
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute)
+		assert.Equal(t, want, <-data)
+	}
+	require.NoError(t, d.Halt())
+}
+
 func TestAnalogSensorHalt_WithSensorCyclicRead(t *testing.T) {
 	// arrange
 	d := NewAnalogSensorDriver(newAioTestAdaptor(), "1", WithSensorCyclicRead(10*time.Millisecond))
@@ -39,6 +39,7 @@ type ButtonDriver struct {
 // Supported options:
 //
 //	"WithName"
+//	"WithClock"
 //	"WithButtonPollInterval"
 func NewButtonDriver(a DigitalReader, pin string, opts ...interface{}) *ButtonDriver {
 	//nolint:forcetypeassert // no error return value, so there is no better way
@@ -119,7 +120,7 @@ func (d *ButtonDriver) initialize() error {
 	go func() {
 		for {
 			select {
-			case <-time.After(d.buttonCfg.readInterval):
+			case <-d.driverCfg.clock.After(d.buttonCfg.readInterval):
 				newValue, err := d.digitalRead(d.driverCfg.pin)
 				if err != nil {
 					d.Publish(Error, err)
@@ -153,6 +153,36 @@ func TestButtonStart(t *testing.T) {
 	}
 }
 
+func TestButtonStart_WithClock(t *testing.T) {
+	// arrange
+	clock := gobot.NewFakeClock(time.Unix(0, 0))
+	a := newGpioTestAdaptor()
+	var reads int
+	a.digitalReadFunc = func(string) (int, error) {
+		reads++
+		return reads % 2, nil // push on first read, release on second read
+	}
+	d := NewButtonDriver(a, "1", WithClock(clock), WithButtonPollInterval(time.Hour))
+	events := make(chan string, 2)
+	require.NoError(t, d.Start())
+	_ = d.On(ButtonPush, func(interface{}) { events <- ButtonPush })
+	_ = d.On(ButtonRelease, func(interface{}) { events <- ButtonRelease })
+	// act & assert: no read before the interval is elapsed
+	clock.BlockUntil(1)
+	clock.Advance(time.Hour - time.Nanosecond)
+	a.mtx.Lock()
+	assert.Equal(t, 0, reads)
+	a.mtx.Unlock()
+	// act & assert: push after the interval
+	clock.Advance(time.Nanosecond)
+	assert.Equal(t, ButtonPush, <-events)
+	// act & assert: release after the next interval
+	clock.BlockUntil(1)
+	clock.Advance(time.Hour)
+	assert.Equal(t, ButtonRelease, <-events)
+	require.NoError(t, d.Halt())
+}
+
 func TestButtonStart_WithDefaultState(t *testing.T) {
 	// arrange
 	sem := make(chan bool)
@@ -57,6 +57,30 @@ type DigitalReader interface {
 	DigitalRead(pin string) (val int, err error)
 }
 
+// DigitalGroupWriter interface represents an Adaptor which can write a group of pins at once, the value of the first
+// pin is bit 0 of the given values
+type DigitalGroupWriter interface {
+	DigitalGroupWrite(pins []string, values uint64) error
+}
+
+// DigitalGroupReader interface represents an Adaptor which can read a group of pins at once, the value of the first pin
+// is bit 0 of the returned values
+type DigitalGroupReader interface {
+	DigitalGroupRead(pins []string) (values uint64, err error)
+}
+
+// DigitalPinClaimer interface represents an Adaptor which tracks the ownership of digital pins
+type DigitalPinClaimer interface {
+	ClaimDigitalPin(owner string, pin string) error
+	ReleaseDigitalPins(owner string)
+}
+
+// PWMPinClaimer interface represents an Adaptor which tracks the ownership of PWM pins
+type PWMPinClaimer interface {
+	ClaimPWMPin(owner string, pin string) error
+	ReleasePWMPins(owner string)
+}
+
 // optionApplier needs to be implemented by each configurable option type
 type optionApplier interface {
 	apply(cfg *configuration)
@@ -64,8 +88,11 @@ type optionApplier interface {
 
 // configuration contains all changeable attributes of the driver.
 type configuration struct {
-	name string
-	pin  string
+	name   string
+	pin    string
+	pinPWM bool     // the pin is used as PWM pin
+	pins   []string // all digital pins of drivers with more than one pin
+	clock  gobot.Clock
 }
 
 // nameOption is the type for applying another name to the configuration
@@ -74,6 +101,17 @@ type nameOption string
 // pinOption is the type for applying a pin to the configuration
 type pinOption string
 
+// pwmPinOption is the type for applying a PWM pin to the configuration
+type pwmPinOption string
+
+// pinsOption is the type for applying all digital pins of a driver with more than one pin to the configuration
+type pinsOption []string
+
+// clockOption is the type for applying another clock to the configuration
+type clockOption struct {
+	clock gobot.Clock
+}
+
 // Driver implements the interface gobot.Driver.
 type driver struct {
 	driverCfg  *configuration
@@ -92,7 +130,7 @@ type driver struct {
 //	"withPin"
 func newDriver(a gobot.Adaptor, name string, opts ...interface{}) *driver {
 	d := &driver{
-		driverCfg:  &configuration{name: gobot.DefaultName(name)},
+		driverCfg:  &configuration{name: gobot.DefaultName(name), clock: gobot.NewRealClock()},
 		connection: a,
 		afterStart: func() error { return nil },
 		beforeHalt: func() error { return nil },
@@ -117,12 +155,30 @@ func WithName(name string) optionApplier {
 	return nameOption(name)
 }
 
+// WithClock is used to replace the real clock of the driver, e.g. by a fake clock for tests. The clock is used by
+// drivers with timing, like poll intervals or step delays.
+func WithClock(clock gobot.Clock) optionApplier {
+	return clockOption{clock: clock}
+}
+
 // withPin is used to add a pin to the driver. Only one pin can be linked.
 // This option is not available outside gpio package.
 func withPin(pin string) optionApplier {
 	return pinOption(pin)
 }
 
+// withPWMPin is used to add a pin to the driver, which is used as PWM pin. Only one pin can be linked.
+// This option is not available outside gpio package.
+func withPWMPin(pin string) optionApplier {
+	return pwmPinOption(pin)
+}
+
+// withPins is used to add all digital pins of drivers with more than one pin, so that all pins are claimed on start.
+// This option is not available outside gpio package.
+func withPins(pins ...string) optionApplier {
+	return pinsOption(pins)
+}
+
 // Name returns the name of the gpio device.
 func (d *driver) Name() string {
 	return d.driverCfg.name
@@ -154,9 +210,16 @@ func (d *driver) Start() error {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
-	// currently there is nothing to do here for the driver
+	if err := d.claimPin(); err != nil {
+		return err
+	}
+
+	if err := d.afterStart(); err != nil {
+		d.releasePin()
+		return err
+	}
 
-	return d.afterStart()
+	return nil
 }
 
 // Halt halts the gpio device.
@@ -164,9 +227,59 @@ func (d *driver) Halt() error {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
-	// currently there is nothing to do after halt for the driver
+	err := d.beforeHalt()
+	d.releasePin()
+
+	return err
+}
+
+// claimPin registers the pin or all pins of the driver for the driver, if the connection tracks the ownership of pins
+func (d *driver) claimPin() error {
+	if len(d.driverCfg.pins) > 0 {
+		if claimer, ok := d.connection.(DigitalPinClaimer); ok {
+			for _, pin := range d.driverCfg.pins {
+				if pin == "" {
+					continue
+				}
+				if err := claimer.ClaimDigitalPin(d.driverCfg.name, pin); err != nil {
+					claimer.ReleaseDigitalPins(d.driverCfg.name)
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	if d.driverCfg.pin == "" {
+		return nil
+	}
+
+	if d.driverCfg.pinPWM {
+		if claimer, ok := d.connection.(PWMPinClaimer); ok {
+			return claimer.ClaimPWMPin(d.driverCfg.name, d.driverCfg.pin)
+		}
+		return nil
+	}
+
+	if claimer, ok := d.connection.(DigitalPinClaimer); ok {
+		return claimer.ClaimDigitalPin(d.driverCfg.name, d.driverCfg.pin)
+	}
+
+	return nil
+}
+
+// releasePin removes the ownership of the pin, if the connection tracks the ownership of pins
+func (d *driver) releasePin() {
+	if d.driverCfg.pinPWM {
+		if claimer, ok := d.connection.(PWMPinClaimer); ok {
+			claimer.ReleasePWMPins(d.driverCfg.name)
+		}
+		return
+	}
 
-	return d.beforeHalt()
+	if claimer, ok := d.connection.(DigitalPinClaimer); ok {
+		claimer.ReleaseDigitalPins(d.driverCfg.name)
+	}
 }
 
 // digitalRead is a helper function with check that the connection implements DigitalReader
@@ -187,6 +300,22 @@ func (d *driver) digitalWrite(pin string, val byte) error {
 	return ErrDigitalWriteUnsupported
 }
 
+// digitalGroupWrite is a helper function with check that the connection implements DigitalGroupWriter, otherwise the
+// pins are written one by one, if the connection implements DigitalWriter
+func (d *driver) digitalGroupWrite(pins []string, values uint64) error {
+	if writer, ok := d.connection.(DigitalGroupWriter); ok {
+		return writer.DigitalGroupWrite(pins, values)
+	}
+
+	for i, pin := range pins {
+		if err := d.digitalWrite(pin, byte((values>>i)&0x01)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // pwmWrite is a helper function with check that the connection implements PwmWriter
 func (d *driver) pwmWrite(pin string, level byte) error {
 	if writer, ok := d.connection.(PwmWriter); ok {
@@ -213,6 +342,18 @@ func (o pinOption) String() string {
 	return "pin option for digital drivers"
 }
 
+func (o pwmPinOption) String() string {
+	return "PWM pin option for digital drivers"
+}
+
+func (o pinsOption) String() string {
+	return "pins option for digital drivers with more than one pin"
+}
+
+func (o clockOption) String() string {
+	return "clock option for digital drivers"
+}
+
 // apply change the name in the configuration.
 func (o nameOption) apply(c *configuration) {
 	c.name = string(o)
@@ -222,3 +363,19 @@ func (o nameOption) apply(c *configuration) {
 func (o pinOption) apply(c *configuration) {
 	c.pin = string(o)
 }
+
+// apply change the pins list of the configuration to the PWM pin.
+func (o pwmPinOption) apply(c *configuration) {
+	c.pin = string(o)
+	c.pinPWM = true
+}
+
+// apply change the list of all digital pins of the configuration.
+func (o pinsOption) apply(c *configuration) {
+	c.pins = append([]string(nil), o...)
+}
+
+// apply change the clock of the configuration.
+func (o clockOption) apply(c *configuration) {
+	c.clock = o.clock
+}
@@ -3,6 +3,7 @@ package gpio
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -48,6 +49,16 @@ func Test_applyWithName(t *testing.T) {
 	assert.Equal(t, name, cfg.name)
 }
 
+func Test_applyWithClock(t *testing.T) {
+	// arrange
+	clock := gobot.NewFakeClock(time.Unix(0, 0))
+	cfg := configuration{clock: gobot.NewRealClock()}
+	// act
+	WithClock(clock).apply(&cfg)
+	// assert
+	assert.Same(t, clock, cfg.clock)
+}
+
 func Test_applywithPin(t *testing.T) {
 	// arrange
 	const pin = "36"
@@ -86,3 +97,99 @@ func TestHalt(t *testing.T) {
 	// act, assert
 	require.EqualError(t, d.Halt(), "before halt error")
 }
+
+// gpioTestClaimingAdaptor tracks the ownership of pins
+type gpioTestClaimingAdaptor struct {
+	*gpioTestAdaptor
+	owners map[string]string
+}
+
+func (a *gpioTestClaimingAdaptor) ClaimDigitalPin(owner string, pin string) error {
+	return a.claim(owner, "digital:"+pin)
+}
+
+func (a *gpioTestClaimingAdaptor) ReleaseDigitalPins(owner string) { a.release(owner) }
+
+func (a *gpioTestClaimingAdaptor) ClaimPWMPin(owner string, pin string) error {
+	return a.claim(owner, "pwm:"+pin)
+}
+
+func (a *gpioTestClaimingAdaptor) ReleasePWMPins(owner string) { a.release(owner) }
+
+func (a *gpioTestClaimingAdaptor) claim(owner string, key string) error {
+	if current, ok := a.owners[key]; ok && current != owner {
+		return fmt.Errorf("'%s' already owned by '%s'", key, current)
+	}
+	a.owners[key] = owner
+	return nil
+}
+
+func (a *gpioTestClaimingAdaptor) release(owner string) {
+	for key, current := range a.owners {
+		if current == owner {
+			delete(a.owners, key)
+		}
+	}
+}
+
+func TestStartHaltClaimsPin(t *testing.T) {
+	tests := map[string]struct {
+		option   optionApplier
+		wantKeys []string
+	}{
+		"digital_pin": {
+			option:   withPin("7"),
+			wantKeys: []string{"digital:7"},
+		},
+		"pwm_pin": {
+			option:   withPWMPin("7"),
+			wantKeys: []string{"pwm:7"},
+		},
+		"multiple_pins": {
+			option:   withPins("7", "", "8"),
+			wantKeys: []string{"digital:7", "digital:8"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := &gpioTestClaimingAdaptor{gpioTestAdaptor: newGpioTestAdaptor(), owners: make(map[string]string)}
+			d1 := newDriver(a, "first", tc.option)
+			d2 := newDriver(a, "second", tc.option)
+			want := make(map[string]string)
+			for _, key := range tc.wantKeys {
+				want[key] = d1.Name()
+			}
+			// act & assert
+			require.NoError(t, d1.Start())
+			assert.Equal(t, want, a.owners)
+			require.EqualError(t, d2.Start(), fmt.Sprintf("'%s' already owned by '%s'", tc.wantKeys[0], d1.Name()))
+			require.NoError(t, d1.Halt())
+			assert.Empty(t, a.owners)
+			require.NoError(t, d2.Start())
+		})
+	}
+}
+
+func TestStartReleasesPinOnError(t *testing.T) {
+	// arrange
+	a := &gpioTestClaimingAdaptor{gpioTestAdaptor: newGpioTestAdaptor(), owners: make(map[string]string)}
+	d := newDriver(a, "first", withPins("7", "8"))
+	d.afterStart = func() error { return fmt.Errorf("after start error") }
+	// act
+	err := d.Start()
+	// assert
+	require.EqualError(t, err, "after start error")
+	assert.Empty(t, a.owners)
+}
+
+func TestStartClaimsNoPinOnConflict(t *testing.T) {
+	// arrange
+	a := &gpioTestClaimingAdaptor{gpioTestAdaptor: newGpioTestAdaptor(), owners: map[string]string{"digital:8": "other"}}
+	d := newDriver(a, "first", withPins("7", "8"))
+	// act
+	err := d.Start()
+	// assert
+	require.EqualError(t, err, "'digital:8' already owned by 'other'")
+	assert.Equal(t, map[string]string{"digital:8": "other"}, a.owners)
+}
@@ -0,0 +1,117 @@
+package gpio
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/go-multierror"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// LineInfoChanged event
+const LineInfoChanged = "line-info-changed"
+
+// GpioInfoProvider interface represents an Adaptor which provides the information of the gpiochips and lines
+type GpioInfoProvider interface {
+	GpioChips() ([]gobot.GpioChipInfo, error)
+	GpioLineInfo(pin string) (gobot.GpioLineInfo, error)
+}
+
+// GpioInfoWatcher interface represents an Adaptor which reports the changes of the line information of a pin
+type GpioInfoWatcher interface {
+	WatchGpioLineInfo(pin string, handler func(gobot.GpioLineInfoEvent)) (io.Closer, error)
+}
+
+// GpioInfoDriver provides the information of the gpiochips and lines of the board, e.g. to find out, which process
+// holds a line, when the assignment of a pin fails. No pin is claimed by this driver.
+type GpioInfoDriver struct {
+	*driver
+	gobot.Eventer
+	watchers []io.Closer
+}
+
+// NewGpioInfoDriver returns a new driver for the information of gpiochips and lines.
+//
+// Supported options:
+//
+//	"WithName"
+//
+// Adds the following API Commands:
+//
+//	"Chips" - See GpioInfoDriver.Chips, returns "chips" and "err"
+//	"LineInfo" - See GpioInfoDriver.LineInfo, params "pin", returns "info" and "err"
+//
+// Publishes the event "line-info-changed" with a gobot.GpioLineInfoEvent for each watched pin, see
+// GpioInfoDriver.Watch.
+func NewGpioInfoDriver(a GpioInfoProvider, opts ...interface{}) *GpioInfoDriver {
+	//nolint:forcetypeassert // no error return value, so there is no better way
+	d := &GpioInfoDriver{
+		driver:  newDriver(a.(gobot.Connection), "GpioInfo", opts...),
+		Eventer: gobot.NewEventer(),
+	}
+	d.AddEvent(LineInfoChanged)
+	d.beforeHalt = d.unwatch
+
+	d.AddCommand("Chips", func(_ map[string]interface{}) interface{} {
+		chips, err := d.Chips()
+		return map[string]interface{}{"chips": chips, "err": err}
+	})
+
+	//nolint:forcetypeassert // ok here
+	d.AddCommand("LineInfo", func(params map[string]interface{}) interface{} {
+		info, err := d.LineInfo(params["pin"].(string))
+		return map[string]interface{}{"info": info, "err": err}
+	})
+
+	return d
+}
+
+// Chips returns the information of all gpiochips of the board including the information of each line.
+func (d *GpioInfoDriver) Chips() ([]gobot.GpioChipInfo, error) {
+	//nolint:forcetypeassert // ok here, checked by constructor
+	return d.connection.(GpioInfoProvider).GpioChips()
+}
+
+// LineInfo returns the information of the line of the given pin, e.g. the consumer, which holds the line.
+func (d *GpioInfoDriver) LineInfo(pin string) (gobot.GpioLineInfo, error) {
+	//nolint:forcetypeassert // ok here, checked by constructor
+	return d.connection.(GpioInfoProvider).GpioLineInfo(pin)
+}
+
+// Watch starts to publish the event "line-info-changed" for each change of the line information of the given pins,
+// e.g. when a line is requested by another process. The watch is stopped on halt of the driver.
+func (d *GpioInfoDriver) Watch(pins ...string) error {
+	watcher, ok := d.connection.(GpioInfoWatcher)
+	if !ok {
+		return fmt.Errorf("watching the line information is not supported by '%s'", d.connection.Name())
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for _, pin := range pins {
+		w, err := watcher.WatchGpioLineInfo(pin, func(evt gobot.GpioLineInfoEvent) {
+			d.Publish(LineInfoChanged, evt)
+		})
+		if err != nil {
+			return err
+		}
+		d.watchers = append(d.watchers, w)
+	}
+
+	return nil
+}
+
+// unwatch stops all watches of line information, it is called by Halt()
+func (d *GpioInfoDriver) unwatch() error {
+	var err error
+	for _, w := range d.watchers {
+		if e := w.Close(); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+	d.watchers = nil
+
+	return err
+}
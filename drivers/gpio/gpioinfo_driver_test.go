@@ -0,0 +1,149 @@
+//nolint:forcetypeassert // ok here
+package gpio
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+var _ gobot.Driver = (*GpioInfoDriver)(nil)
+
+type gpioInfoTestWatcher struct {
+	closed bool
+}
+
+func (w *gpioInfoTestWatcher) Close() error {
+	w.closed = true
+	return nil
+}
+
+type gpioInfoTestAdaptor struct {
+	gpioTestBareAdaptor
+	chips    []gobot.GpioChipInfo
+	handlers map[string]func(gobot.GpioLineInfoEvent)
+	watchers []*gpioInfoTestWatcher
+}
+
+func newGpioInfoTestAdaptor() *gpioInfoTestAdaptor {
+	return &gpioInfoTestAdaptor{
+		chips: []gobot.GpioChipInfo{{Name: "gpiochip0", Label: "pinctrl", Lines: []gobot.GpioLineInfo{
+			{Chip: "gpiochip0", Offset: 0, Name: "GPIO0"},
+			{Chip: "gpiochip0", Offset: 1, Name: "GPIO1", Consumer: "other", Used: true},
+		}}},
+		handlers: make(map[string]func(gobot.GpioLineInfoEvent)),
+	}
+}
+
+func (a *gpioInfoTestAdaptor) GpioChips() ([]gobot.GpioChipInfo, error) {
+	return a.chips, nil
+}
+
+func (a *gpioInfoTestAdaptor) GpioLineInfo(pin string) (gobot.GpioLineInfo, error) {
+	for _, line := range a.chips[0].Lines {
+		if line.Name == pin {
+			return line, nil
+		}
+	}
+
+	return gobot.GpioLineInfo{}, errors.New("unknown pin")
+}
+
+func (a *gpioInfoTestAdaptor) WatchGpioLineInfo(pin string, handler func(gobot.GpioLineInfoEvent)) (io.Closer, error) {
+	if _, err := a.GpioLineInfo(pin); err != nil {
+		return nil, err
+	}
+	a.handlers[pin] = handler
+	w := &gpioInfoTestWatcher{}
+	a.watchers = append(a.watchers, w)
+
+	return w, nil
+}
+
+type gpioInfoTestNoWatchAdaptor struct {
+	gpioTestBareAdaptor
+}
+
+func (a *gpioInfoTestNoWatchAdaptor) GpioChips() ([]gobot.GpioChipInfo, error) {
+	return nil, nil
+}
+
+func (a *gpioInfoTestNoWatchAdaptor) GpioLineInfo(string) (gobot.GpioLineInfo, error) {
+	return gobot.GpioLineInfo{}, nil
+}
+
+func TestNewGpioInfoDriver(t *testing.T) {
+	// arrange
+	a := newGpioInfoTestAdaptor()
+	// act
+	d := NewGpioInfoDriver(a)
+	// assert
+	assert.IsType(t, &GpioInfoDriver{}, d)
+	assert.True(t, strings.HasPrefix(d.Name(), "GpioInfo"))
+	assert.Equal(t, a, d.connection)
+	assert.Empty(t, d.Pin())
+	assert.NotNil(t, d.Eventer)
+	assert.NotNil(t, d.Command("Chips"))
+	assert.NotNil(t, d.Command("LineInfo"))
+}
+
+func TestGpioInfoDriverCommands(t *testing.T) {
+	// arrange
+	a := newGpioInfoTestAdaptor()
+	d := NewGpioInfoDriver(a)
+	require.NoError(t, d.Start())
+	// act
+	chipsResult := d.Command("Chips")(nil).(map[string]interface{})
+	infoResult := d.Command("LineInfo")(map[string]interface{}{"pin": "GPIO1"}).(map[string]interface{})
+	errResult := d.Command("LineInfo")(map[string]interface{}{"pin": "GPIO9"}).(map[string]interface{})
+	// assert
+	assert.Equal(t, a.chips, chipsResult["chips"])
+	assert.Nil(t, chipsResult["err"])
+	assert.Equal(t, "other", infoResult["info"].(gobot.GpioLineInfo).Consumer)
+	assert.Nil(t, infoResult["err"])
+	require.EqualError(t, errResult["err"].(error), "unknown pin")
+}
+
+func TestGpioInfoDriverWatch(t *testing.T) {
+	// arrange
+	a := newGpioInfoTestAdaptor()
+	d := NewGpioInfoDriver(a)
+	require.NoError(t, d.Start())
+	events := make(chan gobot.GpioLineInfoEvent, 1)
+	_ = d.On(LineInfoChanged, func(data interface{}) {
+		events <- data.(gobot.GpioLineInfoEvent)
+	})
+	// act
+	require.NoError(t, d.Watch("GPIO0"))
+	want := gobot.GpioLineInfoEvent{Type: "requested", Info: a.chips[0].Lines[0]}
+	a.handlers["GPIO0"](want)
+	// assert
+	select {
+	case got := <-events:
+		assert.Equal(t, want, got)
+	case <-time.After(time.Second):
+		require.Fail(t, "event was not published")
+	}
+	require.EqualError(t, d.Watch("GPIO9"), "unknown pin")
+	// act & assert: halt stops the watches
+	require.NoError(t, d.Halt())
+	require.Len(t, a.watchers, 1)
+	assert.True(t, a.watchers[0].closed)
+	assert.Empty(t, d.watchers)
+}
+
+func TestGpioInfoDriverWatchNotSupported(t *testing.T) {
+	// arrange
+	d := NewGpioInfoDriver(&gpioInfoTestNoWatchAdaptor{})
+	// act
+	err := d.Watch("GPIO0")
+	// assert
+	require.EqualError(t, err, "watching the line information is not supported by ''")
+}
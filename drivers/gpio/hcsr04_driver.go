@@ -62,6 +62,8 @@ type HCSR04Driver struct {
 // Supported options:
 //
 //	"WithName"
+//	"WithClock"
+//	"WithHCSR04UseEdgePolling"
 func NewHCSR04Driver(a gobot.Adaptor, triggerPinID, echoPinID string, opts ...interface{}) *HCSR04Driver {
 	d := HCSR04Driver{
 		driver:       newDriver(a, "HCSR04"),
@@ -178,7 +180,7 @@ func (d *HCSR04Driver) StartDistanceMonitor() error {
 				if err := d.measureDistance(); err != nil {
 					fmt.Printf("continuous measure distance skipped for '%s': %v\n", name, err)
 				}
-				time.Sleep(hcsr04MonitorUpdate)
+				d.driverCfg.clock.Sleep(hcsr04MonitorUpdate)
 			}
 		}
 	}(d.driverCfg.name)
@@ -235,7 +237,7 @@ func (d *HCSR04Driver) measureDistance() error {
 	// stop the loop if the measure is done or the timeout is elapsed
 	timeout := hcsr04StartTransmitTimeout + hcsr04ReceiveTimeout
 	select {
-	case <-time.After(timeout):
+	case <-d.driverCfg.clock.After(timeout):
 		return fmt.Errorf("timeout %s reached while waiting for value with echo pin %s", timeout, d.echoPinID)
 	case d.lastMeasureMicroSec = <-d.delayMicroSecChan:
 	}
@@ -247,7 +249,7 @@ func (d *HCSR04Driver) emitTrigger() error {
 	if err := d.triggerPin.Write(1); err != nil {
 		return err
 	}
-	time.Sleep(hcsr04EmitTriggerDuration)
+	d.driverCfg.clock.Sleep(hcsr04EmitTriggerDuration)
 	return d.triggerPin.Write(0)
 }
 
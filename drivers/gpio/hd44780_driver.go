@@ -163,6 +163,15 @@ func NewHD44780Driver(
 		d.pinRW = NewDirectPinDriver(d.connection, d.hd44780Cfg.pinRW)
 	}
 
+	pins := []string{pinRS, pinEN}
+	for _, bitPin := range d.pinDataBits {
+		pins = append(pins, bitPin.Pin())
+	}
+	if d.pinRW != nil {
+		pins = append(pins, d.pinRW.Pin())
+	}
+	withPins(pins...).apply(d.driverCfg)
+
 	d.rowOffsets[0] = 0x00
 	d.rowOffsets[1] = HD44780_2NDLINEOFFSET
 	d.rowOffsets[2] = 0x00 + cols
@@ -484,17 +493,15 @@ func (d *HD44780Driver) setCursor(col int, row int) error {
 	return d.sendCommand(HD44780_SETDDRAMADDR | col + d.rowOffsets[row])
 }
 
+// writeDataPins writes all data bits at once, if supported by the adaptor, otherwise one by one
 func (d *HD44780Driver) writeDataPins(data int) error {
+	pins := make([]string, len(d.pinDataBits))
 	for i, pin := range d.pinDataBits {
-		if ((data >> i) & 0x01) == 0x01 {
-			if err := pin.On(); err != nil {
-				return err
-			}
-		} else {
-			if err := pin.Off(); err != nil {
-				return err
-			}
-		}
+		pins[i] = pin.Pin()
+	}
+	mask := uint64(1)<<len(pins) - 1
+	if err := d.digitalGroupWrite(pins, uint64(data)&mask); err != nil { //nolint:gosec // data is not negative
+		return err
 	}
 	return d.fallingEdge()
 }
@@ -66,6 +66,7 @@ func TestNewHD44780Driver(t *testing.T) {
 	// assert: gpio.driver attributes
 	require.NotNil(t, d.driver)
 	assert.True(t, strings.HasPrefix(d.driverCfg.name, "HD44780"))
+	assert.Equal(t, []string{"13", "15", "22", "18", "16", "12"}, d.driverCfg.pins)
 	assert.Equal(t, a, d.connection)
 	assert.NotNil(t, d.afterStart)
 	assert.NotNil(t, d.beforeHalt)
@@ -114,6 +115,7 @@ func TestNewHD44780Driver_options(t *testing.T) {
 		WithHD44780RWPin(pinRW))
 	// assert
 	assert.Equal(t, pinRW, d.hd44780Cfg.pinRW)
+	assert.Contains(t, d.driverCfg.pins, pinRW)
 	assert.Equal(t, myName, d.Name())
 	assert.PanicsWithValue(t, "'scaler option for analog actuators' can not be applied on 'crazy'", panicFunc)
 }
@@ -166,6 +168,27 @@ func TestHD44780Write(t *testing.T) {
 	require.NoError(t, d.Write("hello gobot"))
 }
 
+func TestHD44780WriteDataPinsAtOnce(t *testing.T) {
+	// arrange
+	a := newGpioTestGroupAdaptor()
+	dataPins := HD44780DataPin{D4: "22", D5: "18", D6: "16", D7: "12"}
+	d := NewHD44780Driver(a, 2, 16, HD44780_4BITMODE, "13", "15", dataPins)
+	require.NoError(t, d.Start())
+	a.writtenGroups = nil
+	// act
+	err := d.WriteChar(0x41)
+	// assert
+	require.NoError(t, err)
+	want := []gpioTestWrittenGroup{
+		{pins: []string{"22", "18", "16", "12"}, values: 0x04},
+		{pins: []string{"22", "18", "16", "12"}, values: 0x01},
+	}
+	assert.Equal(t, want, a.writtenGroups)
+	for _, w := range a.written {
+		assert.NotContains(t, []string{"22", "18", "16", "12"}, w.pin)
+	}
+}
+
 func TestHD44780WriteError(t *testing.T) {
 	var d *HD44780Driver
 	var a *gpioTestAdaptor
@@ -137,3 +137,29 @@ func (t *gpioTestAdaptor) addDigitalPin(id string) *digitalPinMock {
 	t.pinMap[id] = dpm
 	return dpm
 }
+
+type gpioTestWrittenGroup struct {
+	pins   []string
+	values uint64
+}
+
+// gpioTestGroupAdaptor is a test adaptor, which supports writing of pin groups at once
+type gpioTestGroupAdaptor struct {
+	*gpioTestAdaptor
+	writtenGroups []gpioTestWrittenGroup
+}
+
+func newGpioTestGroupAdaptor() *gpioTestGroupAdaptor {
+	return &gpioTestGroupAdaptor{gpioTestAdaptor: newGpioTestAdaptor()}
+}
+
+// DigitalGroupWrite capabilities (interface DigitalGroupWriter)
+func (t *gpioTestGroupAdaptor) DigitalGroupWrite(pins []string, values uint64) error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if t.simulateWriteError {
+		return fmt.Errorf("write error")
+	}
+	t.writtenGroups = append(t.writtenGroups, gpioTestWrittenGroup{pins: pins, values: values})
+	return nil
+}
@@ -57,7 +57,7 @@ type MotorDriver struct {
 func NewMotorDriver(a DigitalWriter, speedPin string, opts ...interface{}) *MotorDriver {
 	//nolint:forcetypeassert // no error return value, so there is no better way
 	d := &MotorDriver{
-		driver:           newDriver(a.(gobot.Connection), "Motor", withPin(speedPin)),
+		driver:           newDriver(a.(gobot.Connection), "Motor", withPWMPin(speedPin)),
 		motorCfg:         &motorConfiguration{},
 		currentDirection: "forward",
 	}
@@ -27,7 +27,7 @@ type ServoDriver struct {
 func NewServoDriver(a ServoWriter, pin string, opts ...interface{}) *ServoDriver {
 	//nolint:forcetypeassert // no error return value, so there is no better way
 	d := &ServoDriver{
-		driver: newDriver(a.(gobot.Connection), "Servo", append(opts, withPin(pin))...),
+		driver: newDriver(a.(gobot.Connection), "Servo", append(opts, withPWMPin(pin))...),
 	}
 
 	//nolint:forcetypeassert // ok here
@@ -88,6 +88,7 @@ type StepperDriver struct {
 // Supported options:
 //
 //	"WithName"
+//	"WithClock"
 func NewStepperDriver(
 	a DigitalWriter,
 	pins [4]string,
@@ -100,7 +101,7 @@ func NewStepperDriver(
 	}
 	//nolint:forcetypeassert // no error return value, so there is no better way
 	d := &StepperDriver{
-		driver:         newDriver(a.(gobot.Connection), "Stepper", opts...),
+		driver:         newDriver(a.(gobot.Connection), "Stepper", append(opts, withPins(pins[:]...))...),
 		pins:           pins,
 		phase:          phase,
 		stepsPerRev:    float32(stepsPerRev),
@@ -367,7 +368,7 @@ func (d *StepperDriver) stepAsynch(stepsToMove float64) error {
 		select {
 		case err := <-runErrChan:
 			return err
-		case <-time.After(stopTimeout):
+		case <-d.driverCfg.clock.After(stopTimeout):
 			return fmt.Errorf("'%s' was not finished in %s", d.driverCfg.name, stopTimeout)
 		}
 	}
@@ -457,26 +458,26 @@ func (d *StepperDriver) phasedStepping() error {
 
 	r := int(math.Abs(float64(d.stepNum))) % len(d.phase)
 
+	// all pins are written at once, if supported by the adaptor
+	var values uint64
 	for i, v := range d.phase[r] {
-		if err := d.digitalWrite(d.pins[i], v); err != nil {
-			d.stepNum = oldStepNum
-			return err
+		if v != 0 {
+			values |= 1 << i
 		}
 	}
+	if err := d.digitalGroupWrite(d.pins[:], values); err != nil {
+		d.stepNum = oldStepNum
+		return err
+	}
 
 	delay := d.getDelayPerStep()
-	time.Sleep(delay)
+	d.driverCfg.clock.Sleep(delay)
 
 	return nil
 }
 
 func (d *StepperDriver) sleepOuputs() error {
-	for _, pin := range d.pins {
-		if err := d.digitalWrite(pin, 0); err != nil {
-			return err
-		}
-	}
-	return nil
+	return d.digitalGroupWrite(d.pins[:], 0)
 }
 
 // stopIfRunning stop the stepper if moving or running
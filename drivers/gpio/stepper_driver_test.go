@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"gobot.io/x/gobot/v2"
 	"gobot.io/x/gobot/v2/drivers/aio"
 )
 
@@ -32,6 +33,7 @@ func TestNewStepperDriver(t *testing.T) {
 	// assert: gpio.driver attributes
 	require.NotNil(t, d.driver)
 	assert.True(t, strings.HasPrefix(d.driverCfg.name, "Stepper"))
+	assert.Equal(t, []string{"7", "11", "13", "15"}, d.driverCfg.pins)
 	assert.Equal(t, a, d.connection)
 	require.NoError(t, d.afterStart())
 	require.NoError(t, d.beforeHalt())
@@ -176,6 +178,29 @@ func TestStepperMove_IsMoving(t *testing.T) {
 	}
 }
 
+func TestStepperMove_WithClock(t *testing.T) {
+	// arrange
+	clock := gobot.NewFakeClock(time.Unix(0, 0))
+	a := newGpioTestAdaptor()
+	d := NewStepperDriver(a, [4]string{"7", "11", "13", "15"}, StepperModes.DualPhaseStepping, 32, WithClock(clock))
+	delay := d.getDelayPerStep()
+	moveErr := make(chan error)
+	// act
+	go func() { moveErr <- d.Move(3) }()
+	// the first step sleeps, for all further steps the timeout for finishing the move is waiting additionally
+	clock.BlockUntil(1)
+	clock.Advance(delay)
+	for i := 1; i < 3; i++ {
+		clock.BlockUntil(2)
+		clock.Advance(delay)
+	}
+	// assert
+	require.NoError(t, <-moveErr)
+	assert.Equal(t, 3, d.stepNum)
+	assert.Equal(t, 3*delay, clock.Since(time.Unix(0, 0)))
+	assert.False(t, d.IsMoving())
+}
+
 func TestStepperRun_IsMoving(t *testing.T) {
 	tests := map[string]struct {
 		noAutoStopIfRunning    bool
@@ -326,6 +351,25 @@ func TestStepperHalt_IsMoving(t *testing.T) {
 	}
 }
 
+func TestStepperWritesPinsAtOnce(t *testing.T) {
+	// arrange
+	a := newGpioTestGroupAdaptor()
+	d := NewStepperDriver(a, [4]string{"7", "11", "13", "15"}, StepperModes.DualPhaseStepping, 32)
+	d.speedRpm = d.MaxSpeed()
+	// act
+	errStep := d.phasedStepping()
+	errSleep := d.sleepOuputs()
+	// assert
+	require.NoError(t, errStep)
+	require.NoError(t, errSleep)
+	want := []gpioTestWrittenGroup{
+		{pins: []string{"7", "11", "13", "15"}, values: 0x03}, // step 1 of dual phase stepping is {1, 1, 0, 0}
+		{pins: []string{"7", "11", "13", "15"}, values: 0x00},
+	}
+	assert.Equal(t, want, a.writtenGroups)
+	assert.Empty(t, a.written)
+}
+
 func TestStepperSetDirection(t *testing.T) {
 	tests := map[string]struct {
 		input   string
@@ -47,7 +47,7 @@ type TM1638Driver struct {
 //	"WithName"
 func NewTM1638Driver(a gobot.Connection, clockPin, dataPin, strobePin string, opts ...interface{}) *TM1638Driver {
 	d := &TM1638Driver{
-		driver:    newDriver(a, "TM1638", opts...),
+		driver:    newDriver(a, "TM1638", append(opts, withPins(clockPin, dataPin, strobePin))...),
 		pinClock:  NewDirectPinDriver(a, clockPin),
 		pinData:   NewDirectPinDriver(a, dataPin),
 		pinStrobe: NewDirectPinDriver(a, strobePin),
@@ -120,7 +120,7 @@ func (d *TM1638Driver) initialize() error {
 	if err := d.pinStrobe.On(); err != nil {
 		return err
 	}
-	if err := d.pinClock.On(); err != nil {
+	if err := d.clockOn(); err != nil {
 		return err
 	}
 
@@ -176,6 +176,17 @@ func (d *TM1638Driver) sendData(address byte, data byte) error {
 	return d.pinStrobe.On()
 }
 
+// clockOn sets the clock pin to high. If the pins are written at once, the clock pin is part of the group and can not
+// be written as single pin anymore, so the data pin is written together with it. The data pin is set to low, which
+// has no effect on the module, because the strobe is still high and the data is only taken over by a rising edge of
+// the clock while the strobe is low. Without support for groups, only the clock pin is written like before.
+func (d *TM1638Driver) clockOn() error {
+	if _, ok := d.connection.(DigitalGroupWriter); ok {
+		return d.digitalGroupWrite([]string{d.pinClock.Pin(), d.pinData.Pin()}, 0x01)
+	}
+	return d.pinClock.On()
+}
+
 // sendCommand is an auxiliary function to send commands to the TM1638 module
 func (d *TM1638Driver) sendCommand(cmd byte) error {
 	if err := d.pinStrobe.Off(); err != nil {
@@ -187,8 +198,24 @@ func (d *TM1638Driver) sendCommand(cmd byte) error {
 	return d.pinStrobe.On()
 }
 
-// send writes data on the module
+// send writes data on the module, if supported by the adaptor the clock and data pins are written at once
 func (d *TM1638Driver) send(data byte) error {
+	if _, ok := d.connection.(DigitalGroupWriter); ok {
+		pins := []string{d.pinClock.Pin(), d.pinData.Pin()}
+		for i := 0; i < 8; i++ {
+			bit := uint64(data&1) << 1
+			data >>= 1
+			// clock low together with the data bit, the data is taken over by the rising edge of the clock
+			if err := d.digitalGroupWrite(pins, bit); err != nil {
+				return err
+			}
+			if err := d.digitalGroupWrite(pins, bit|0x01); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
 	for i := 0; i < 8; i++ {
 		if err := d.pinClock.Off(); err != nil {
 			return err
@@ -33,6 +33,7 @@ func TestNewTM1638Driver(t *testing.T) {
 	// assert: gpio.driver attributes
 	require.NotNil(t, d.driver)
 	assert.True(t, strings.HasPrefix(d.driverCfg.name, "TM1638"))
+	assert.Equal(t, []string{"10", "20", "30"}, d.driverCfg.pins)
 	assert.Equal(t, a, d.connection)
 	assert.NotNil(t, d.afterStart)
 	assert.NotNil(t, d.beforeHalt)
@@ -71,6 +72,25 @@ func TestTM1638Start(t *testing.T) {
 	require.NoError(t, d.Start())
 }
 
+func TestTM1638SendAtOnce(t *testing.T) {
+	// arrange
+	a := newGpioTestGroupAdaptor()
+	d := NewTM1638Driver(a, "1", "2", "3")
+	// act
+	err := d.send(0x05)
+	// assert
+	require.NoError(t, err)
+	require.Len(t, a.writtenGroups, 16)
+	assert.Equal(t, []string{"1", "2"}, a.writtenGroups[0].pins)
+	var got []uint64
+	for _, w := range a.writtenGroups[:6] {
+		got = append(got, w.values)
+	}
+	// clock is bit 0, data is bit 1, LSB is sent first
+	assert.Equal(t, []uint64{0x02, 0x03, 0x00, 0x01, 0x02, 0x03}, got)
+	assert.Empty(t, a.written)
+}
+
 func TestTM1638FromStringToByteArray(t *testing.T) {
 	d := initTestTM1638Driver()
 	data := d.fromStringToByteArray("Hello World")
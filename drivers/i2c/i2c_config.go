@@ -1,13 +1,17 @@
 package i2c
 
+import "gobot.io/x/gobot/v2"
+
 type i2cConfig struct {
-	bus     int
-	address int
+	bus         int
+	address     int
+	retryPolicy RetryPolicy
+	clock       gobot.Clock
 }
 
 // NewConfig returns a new I2c Config.
 func NewConfig() Config {
-	return &i2cConfig{bus: BusNotInitialized, address: AddressNotInitialized}
+	return &i2cConfig{bus: BusNotInitialized, address: AddressNotInitialized, clock: gobot.NewRealClock()}
 }
 
 // WithBus sets which bus to use as a optional param.
@@ -24,6 +28,26 @@ func WithAddress(address int) func(Config) {
 	}
 }
 
+// WithRetryPolicy sets the retry policy for failed transfers as a optional param. The option is ignored for a Config,
+// which does not implement the RetryConfig.
+func WithRetryPolicy(policy RetryPolicy) func(Config) {
+	return func(i Config) {
+		if rc, ok := i.(RetryConfig); ok {
+			rc.SetRetryPolicy(policy)
+		}
+	}
+}
+
+// WithClock sets the clock as a optional param, e.g. a fake clock for tests. The clock is used for the wait time
+// between the retries of failed transfers.
+func WithClock(clock gobot.Clock) func(Config) {
+	return func(i Config) {
+		if rc, ok := i.(RetryConfig); ok {
+			rc.SetClock(clock)
+		}
+	}
+}
+
 // SetBus sets preferred bus to use.
 func (i *i2cConfig) SetBus(bus int) {
 	i.bus = bus
@@ -54,3 +78,23 @@ func (i *i2cConfig) GetAddressOrDefault(a int) int {
 
 	return i.address
 }
+
+// SetRetryPolicy sets the policy for retries of failed transfers.
+func (i *i2cConfig) SetRetryPolicy(policy RetryPolicy) {
+	i.retryPolicy = policy
+}
+
+// GetRetryPolicy returns the policy for retries of failed transfers. By default no retries are done.
+func (i *i2cConfig) GetRetryPolicy() RetryPolicy {
+	return i.retryPolicy
+}
+
+// SetClock sets the clock of the driver.
+func (i *i2cConfig) SetClock(clock gobot.Clock) {
+	i.clock = clock
+}
+
+// GetClock returns the clock of the driver. By default the real clock is used.
+func (i *i2cConfig) GetClock() gobot.Clock {
+	return i.clock
+}
@@ -3,9 +3,12 @@ package i2c
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
 )
 
 func TestNewConfig(t *testing.T) {
@@ -18,6 +21,7 @@ func TestNewConfig(t *testing.T) {
 	}
 	assert.Equal(t, BusNotInitialized, c.bus)
 	assert.Equal(t, AddressNotInitialized, c.address)
+	assert.Equal(t, gobot.NewRealClock(), c.clock)
 }
 
 func TestWithBus(t *testing.T) {
@@ -81,3 +85,25 @@ func TestGetAddressOrDefaultWithAddressOption(t *testing.T) {
 		})
 	}
 }
+
+func TestWithRetryPolicy(t *testing.T) {
+	// arrange
+	c := NewConfig()
+	policy := RetryPolicy{Count: 3, Backoff: 2 * time.Millisecond}
+	// act
+	WithRetryPolicy(policy)(c)
+	// assert
+	assert.Equal(t, policy, c.(*i2cConfig).retryPolicy)
+	assert.Equal(t, policy, c.(RetryConfig).GetRetryPolicy())
+}
+
+func TestWithClock(t *testing.T) {
+	// arrange
+	c := NewConfig()
+	clock := gobot.NewFakeClock(time.Now())
+	// act
+	WithClock(clock)(c)
+	// assert
+	assert.Equal(t, clock, c.(*i2cConfig).clock)
+	assert.Equal(t, clock, c.(RetryConfig).GetClock())
+}
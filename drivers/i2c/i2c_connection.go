@@ -1,6 +1,7 @@
 package i2c
 
 import (
+	"errors"
 	"fmt"
 
 	"gobot.io/x/gobot/v2"
@@ -60,6 +61,16 @@ func (c *i2cConnection) Write(data []byte) (int, error) {
 	return c.bus.Write(c.address, data)
 }
 
+// WriteQuick sends only the address to the i2c device (SMBus "quick write"), if supported by the bus. Otherwise an
+// error wrapping errors.ErrUnsupported is returned.
+func (c *i2cConnection) WriteQuick() error {
+	if qw, ok := c.bus.(gobot.I2cQuickWriter); ok {
+		return qw.WriteQuick(c.address)
+	}
+
+	return fmt.Errorf("%w: SMBus quick write", errors.ErrUnsupported)
+}
+
 // Close connection to i2c device. The bus was created by adaptor and will be closed there.
 func (c *i2cConnection) Close() error {
 	return nil
@@ -25,6 +25,22 @@ type Config interface {
 	GetAddressOrDefault(def int) int
 }
 
+// RetryConfig is the optional interface of a Config to set and get the parameters for retries of failed transfers. It
+// is implemented by the Config of NewConfig().
+type RetryConfig interface {
+	// SetRetryPolicy sets the policy for retries of failed transfers
+	SetRetryPolicy(policy RetryPolicy)
+
+	// GetRetryPolicy gets the policy for retries of failed transfers
+	GetRetryPolicy() RetryPolicy
+
+	// SetClock sets the clock, which is used for the wait time between retries
+	SetClock(clock gobot.Clock)
+
+	// GetClock gets the clock, which is used for the wait time between retries
+	GetClock() gobot.Clock
+}
+
 // Connector lets adaptors (platforms) provide the interface for Drivers to get access to the I2C buses on platforms
 // that support I2C. The "I2C" specifier is part of the name to differentiate to SPI at platform level.
 type Connector interface {
@@ -37,6 +53,16 @@ type Connector interface {
 	DefaultI2cBus() int
 }
 
+// AddressClaimer lets adaptors (platforms) track the ownership of I2C addresses, so that two drivers can not use the
+// same address on the same bus.
+type AddressClaimer interface {
+	// ClaimI2cAddress registers the address on the bus for the given owner
+	ClaimI2cAddress(owner string, busNr int, address int) error
+
+	// ReleaseI2cAddresses removes all addresses of the given owner
+	ReleaseI2cAddresses(owner string)
+}
+
 // Driver implements the interface gobot.Driver.
 type Driver struct {
 	name           string
@@ -80,6 +106,38 @@ func (d *Driver) SetName(name string) {
 	d.name = name
 }
 
+// SetRetryPolicy sets the policy for retries of failed transfers, if the Config of the driver implements the
+// RetryConfig. Implements the interface RetryConfig, so the options can be applied to the driver.
+func (d *Driver) SetRetryPolicy(policy RetryPolicy) {
+	if cfg, ok := d.Config.(RetryConfig); ok {
+		cfg.SetRetryPolicy(policy)
+	}
+}
+
+// GetRetryPolicy gets the policy for retries of failed transfers, by default no retries are done.
+func (d *Driver) GetRetryPolicy() RetryPolicy {
+	if cfg, ok := d.Config.(RetryConfig); ok {
+		return cfg.GetRetryPolicy()
+	}
+	return RetryPolicy{}
+}
+
+// SetClock sets the clock, which is used for the wait time between retries, if the Config of the driver implements
+// the RetryConfig.
+func (d *Driver) SetClock(clock gobot.Clock) {
+	if cfg, ok := d.Config.(RetryConfig); ok {
+		cfg.SetClock(clock)
+	}
+}
+
+// GetClock gets the clock, which is used for the wait time between retries, by default the real clock.
+func (d *Driver) GetClock() gobot.Clock {
+	if cfg, ok := d.Config.(RetryConfig); ok {
+		return cfg.GetClock()
+	}
+	return gobot.NewRealClock()
+}
+
 // Connection returns the connection of the i2c device.
 func (d *Driver) Connection() gobot.Connection {
 	if conn, ok := d.connector.(gobot.Connection); ok {
@@ -99,11 +157,28 @@ func (d *Driver) Start() error {
 	bus := d.GetBusOrDefault(d.connector.DefaultI2cBus())
 	address := d.GetAddressOrDefault(d.defaultAddress)
 
+	if claimer, ok := d.connector.(AddressClaimer); ok {
+		if err := claimer.ClaimI2cAddress(d.name, bus, address); err != nil {
+			return err
+		}
+	}
+
 	if d.connection, err = d.connector.GetI2cConnection(address, bus); err != nil {
+		d.releaseAddress()
 		return err
 	}
 
-	return d.afterStart()
+	if policy := d.GetRetryPolicy(); policy.Count > 0 {
+		recoverer, _ := d.connector.(BusRecoverer)
+		d.connection = newRetryConnection(d.connection, policy, d.GetClock(), recoverer, bus, address)
+	}
+
+	if err := d.afterStart(); err != nil {
+		d.releaseAddress()
+		return err
+	}
+
+	return nil
 }
 
 // Halt halts the i2c device.
@@ -111,12 +186,17 @@ func (d *Driver) Halt() error {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
-	if err := d.beforeHalt(); err != nil {
-		return err
-	}
+	err := d.beforeHalt()
+	d.releaseAddress()
 
-	// currently there is nothing to do here for the driver
-	return nil
+	return err
+}
+
+// releaseAddress removes the ownership of the address, if the connector tracks the ownership of addresses
+func (d *Driver) releaseAddress() {
+	if claimer, ok := d.connector.(AddressClaimer); ok {
+		claimer.ReleaseI2cAddresses(d.name)
+	}
 }
 
 // Write implements a simple write mechanism, starting from the given register of an i2c device.
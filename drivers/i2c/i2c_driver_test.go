@@ -1,6 +1,7 @@
 package i2c
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -9,7 +10,10 @@ import (
 	"gobot.io/x/gobot/v2"
 )
 
-var _ gobot.Driver = (*Driver)(nil)
+var (
+	_ gobot.Driver = (*Driver)(nil)
+	_ RetryConfig  = (*Driver)(nil)
+)
 
 func initDriverWithStubbedAdaptor() (*Driver, *i2cTestAdaptor) {
 	a := newI2cTestAdaptor()
@@ -137,3 +141,70 @@ func TestRead(t *testing.T) {
 	assert.Equal(t, wantAddress, a.written[0])
 	assert.Equal(t, 1, numCallsRead)
 }
+
+// i2cTestClaimingAdaptor tracks the ownership of addresses
+type i2cTestClaimingAdaptor struct {
+	*i2cTestAdaptor
+	owners map[int]string
+}
+
+func (a *i2cTestClaimingAdaptor) ClaimI2cAddress(owner string, _ int, address int) error {
+	if current, ok := a.owners[address]; ok && current != owner {
+		return fmt.Errorf("0x%02x already owned by '%s'", address, current)
+	}
+	a.owners[address] = owner
+	return nil
+}
+
+func (a *i2cTestClaimingAdaptor) ReleaseI2cAddresses(owner string) {
+	for address, current := range a.owners {
+		if current == owner {
+			delete(a.owners, address)
+		}
+	}
+}
+
+func TestStartHaltClaimsAddress(t *testing.T) {
+	// arrange
+	a := &i2cTestClaimingAdaptor{i2cTestAdaptor: newI2cTestAdaptor(), owners: make(map[int]string)}
+	d1 := NewDriver(a, "first", 0x15)
+	d2 := NewDriver(a, "second", 0x15)
+	// act & assert
+	require.NoError(t, d1.Start())
+	assert.Equal(t, map[int]string{0x15: d1.Name()}, a.owners)
+	require.EqualError(t, d2.Start(), fmt.Sprintf("0x15 already owned by '%s'", d1.Name()))
+	require.NoError(t, d1.Halt())
+	assert.Empty(t, a.owners)
+	require.NoError(t, d2.Start())
+}
+
+func TestStartReleasesAddressOnError(t *testing.T) {
+	tests := map[string]struct {
+		connectErr    bool
+		afterStartErr error
+		wantErr       string
+	}{
+		"connection_error": {
+			connectErr: true,
+			wantErr:    "Invalid i2c connection",
+		},
+		"after_start_error": {
+			afterStartErr: fmt.Errorf("after start error"),
+			wantErr:       "after start error",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := &i2cTestClaimingAdaptor{i2cTestAdaptor: newI2cTestAdaptor(), owners: make(map[int]string)}
+			a.Testi2cConnectErr(tc.connectErr)
+			d := NewDriver(a, "first", 0x15)
+			d.afterStart = func() error { return tc.afterStartErr }
+			// act
+			err := d.Start()
+			// assert
+			require.EqualError(t, err, tc.wantErr)
+			assert.Empty(t, a.owners)
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package i2c
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// RegisterNone is used as register of a BusError, if the failed transfer was not related to a register.
+const RegisterNone = -1
+
+// DefaultTransientErrnos are the Linux errno values, which are reported by the i2c-dev driver for transfers, which
+// can succeed when repeated. The values are given numerically, because some of them are not defined on all systems.
+var DefaultTransientErrnos = []syscall.Errno{
+	syscall.Errno(0x05), // EIO, e.g. a NACK on some adapters
+	syscall.Errno(0x06), // ENXIO, a NACK of the address on some adapters
+	syscall.Errno(0x0b), // EAGAIN, e.g. the arbitration was lost
+	syscall.Errno(0x6e), // ETIMEDOUT, e.g. SCL or SDA is stuck low
+	syscall.Errno(0x79), // EREMOTEIO, a NACK on most adapters
+}
+
+// RetryPolicy defines, how often and when a failed transfer of an i2c connection is repeated.
+type RetryPolicy struct {
+	// Count is the count of retries after the first failed attempt, 0 disables retries.
+	Count int
+	// Backoff is the wait time before the first retry, the wait time is doubled for each further retry.
+	Backoff time.Duration
+	// MaxBackoff limits the wait time between two retries, 0 means no limit.
+	MaxBackoff time.Duration
+	// TransientErrnos are the errno values, which cause a retry. If nil, the DefaultTransientErrnos are used.
+	TransientErrnos []syscall.Errno
+	// RecoverBus activates the recovery of the bus before each retry, if the connector implements the BusRecoverer.
+	RecoverBus bool
+}
+
+// ErrBusPinsNotRestored is returned by the recovery of a bus, if the lines were recovered by GPIO's, but the I2C function
+// of the pins could not be restored. The bus is not usable until the function is restored, so no retry is done.
+var ErrBusPinsNotRestored = errors.New("the I2C function of the bus pins is not restored")
+
+// BusRecoverer lets adaptors (platforms) provide the recovery of an I2C bus, e.g. when SDA is stuck low.
+type BusRecoverer interface {
+	// RecoverI2cBus recovers the given bus, so the next transfer can succeed
+	RecoverI2cBus(busNr int) error
+}
+
+// BusError is returned by a connection of an i2c driver with a retry policy and contains the information about the
+// failed transfer.
+type BusError struct {
+	Op       string
+	Bus      int
+	Address  int
+	Register int // RegisterNone, if the transfer was not related to a register
+	Attempts int
+	Err      error
+}
+
+// Error implements the error interface.
+func (e *BusError) Error() string {
+	reg := ""
+	if e.Register != RegisterNone {
+		reg = fmt.Sprintf(", register 0x%02x", e.Register)
+	}
+
+	return fmt.Sprintf("I2C %s on bus %d, address 0x%02x%s failed after %d attempt(s): %v", e.Op, e.Bus, e.Address, reg,
+		e.Attempts, e.Err)
+}
+
+// Unwrap returns the original error, e.g. the syscall.Errno.
+func (e *BusError) Unwrap() error {
+	return e.Err
+}
+
+// isTransient returns true, if the error contains one of the transient errno values of the policy.
+func (p RetryPolicy) isTransient(err error) bool {
+	var errno syscall.Errno
+	if !errors.As(err, &errno) {
+		return false
+	}
+
+	transientErrnos := p.TransientErrnos
+	if transientErrnos == nil {
+		transientErrnos = DefaultTransientErrnos
+	}
+	for _, transient := range transientErrnos {
+		if errno == transient {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff returns the wait time before the given retry, starting with 1.
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	d := p.Backoff
+	for i := 1; i < retry; i++ {
+		d *= 2
+		if p.MaxBackoff > 0 && d >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+
+	return d
+}
+
+// retryConnection wraps the connection of a driver, repeats failed transfers according to the policy and enriches the
+// errors with the bus, address and register. The wait time between the retries is measured by the given clock.
+type retryConnection struct {
+	Connection
+	policy    RetryPolicy
+	clock     gobot.Clock
+	recoverer BusRecoverer
+	bus       int
+	address   int
+}
+
+func newRetryConnection(con Connection, policy RetryPolicy, clock gobot.Clock, recoverer BusRecoverer, bus int,
+	address int,
+) *retryConnection {
+	return &retryConnection{
+		Connection: con,
+		policy:     policy,
+		clock:      clock,
+		recoverer:  recoverer,
+		bus:        bus,
+		address:    address,
+	}
+}
+
+// Read data from an i2c device.
+func (c *retryConnection) Read(data []byte) (int, error) {
+	var n int
+	err := c.do("Read", RegisterNone, func() error {
+		var err error
+		n, err = c.Connection.Read(data)
+		return err
+	})
+	return n, err
+}
+
+// Write data to an i2c device.
+func (c *retryConnection) Write(data []byte) (int, error) {
+	var n int
+	err := c.do("Write", RegisterNone, func() error {
+		var err error
+		n, err = c.Connection.Write(data)
+		return err
+	})
+	return n, err
+}
+
+// ReadByte reads a single byte from the i2c device.
+func (c *retryConnection) ReadByte() (byte, error) {
+	var val byte
+	err := c.do("ReadByte", RegisterNone, func() error {
+		var err error
+		val, err = c.Connection.ReadByte()
+		return err
+	})
+	return val, err
+}
+
+// ReadByteData reads a byte value for a register on the i2c device.
+func (c *retryConnection) ReadByteData(reg uint8) (uint8, error) {
+	var val uint8
+	err := c.do("ReadByteData", int(reg), func() error {
+		var err error
+		val, err = c.Connection.ReadByteData(reg)
+		return err
+	})
+	return val, err
+}
+
+// ReadWordData reads a word value for a register on the i2c device.
+func (c *retryConnection) ReadWordData(reg uint8) (uint16, error) {
+	var val uint16
+	err := c.do("ReadWordData", int(reg), func() error {
+		var err error
+		val, err = c.Connection.ReadWordData(reg)
+		return err
+	})
+	return val, err
+}
+
+// ReadBlockData reads a block of bytes from a register on the i2c device.
+func (c *retryConnection) ReadBlockData(reg uint8, b []byte) error {
+	return c.do("ReadBlockData", int(reg), func() error { return c.Connection.ReadBlockData(reg, b) })
+}
+
+// WriteByte writes a single byte to the i2c device.
+func (c *retryConnection) WriteByte(val byte) error {
+	return c.do("WriteByte", RegisterNone, func() error { return c.Connection.WriteByte(val) })
+}
+
+// WriteByteData writes a byte value to a register on the i2c device.
+func (c *retryConnection) WriteByteData(reg uint8, val uint8) error {
+	return c.do("WriteByteData", int(reg), func() error { return c.Connection.WriteByteData(reg, val) })
+}
+
+// WriteWordData writes a word value to a register on the i2c device.
+func (c *retryConnection) WriteWordData(reg uint8, val uint16) error {
+	return c.do("WriteWordData", int(reg), func() error { return c.Connection.WriteWordData(reg, val) })
+}
+
+// WriteBlockData writes a block of bytes to a register on the i2c device.
+func (c *retryConnection) WriteBlockData(reg uint8, b []byte) error {
+	return c.do("WriteBlockData", int(reg), func() error { return c.Connection.WriteBlockData(reg, b) })
+}
+
+// WriteBytes writes a block of bytes to the current register on the i2c device.
+func (c *retryConnection) WriteBytes(b []byte) error {
+	return c.do("WriteBytes", RegisterNone, func() error { return c.Connection.WriteBytes(b) })
+}
+
+// do calls the transfer function until it succeeds, the error is not transient or the retries are exhausted
+func (c *retryConnection) do(op string, reg int, transfer func() error) error {
+	attempts := 1
+	err := transfer()
+	for err != nil && attempts <= c.policy.Count && c.policy.isTransient(err) {
+		c.clock.Sleep(c.policy.backoff(attempts))
+
+		if c.policy.RecoverBus && c.recoverer != nil {
+			if rerr := c.recoverer.RecoverI2cBus(c.bus); rerr != nil {
+				err = multierror.Append(err, rerr)
+				break
+			}
+		}
+
+		attempts++
+		err = transfer()
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	return &BusError{Op: op, Bus: c.bus, Address: c.address, Register: reg, Attempts: attempts, Err: err}
+}
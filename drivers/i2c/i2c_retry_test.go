@@ -0,0 +1,208 @@
+package i2c
+
+import (
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+const testErrnoNack = syscall.Errno(0x79) // EREMOTEIO of Linux
+
+type i2cTestRecoveringAdaptor struct {
+	*i2cTestAdaptor
+	recoveredBuses []int
+	recoverErr     error
+}
+
+func (t *i2cTestRecoveringAdaptor) RecoverI2cBus(busNr int) error {
+	t.recoveredBuses = append(t.recoveredBuses, busNr)
+	return t.recoverErr
+}
+
+// failingReadImpl returns a read implementation, which fails with the given error for the given count of calls
+func failingReadImpl(failures int, err error) (func([]byte) (int, error), *int) {
+	calls := 0
+	return func(b []byte) (int, error) {
+		calls++
+		if calls <= failures {
+			return 0, err
+		}
+		b[0] = 0x42
+		return len(b), nil
+	}, &calls
+}
+
+func TestRetryConnection(t *testing.T) {
+	tests := map[string]struct {
+		policy        RetryPolicy
+		failures      int
+		err           error
+		wantCalls     int
+		wantRecovered []int
+		wantErr       string
+	}{
+		"no_retry_without_policy": {
+			failures:  1,
+			err:       testErrnoNack,
+			wantCalls: 1,
+			wantErr:   testErrnoNack.Error(),
+		},
+		"success_after_retries": {
+			policy:    RetryPolicy{Count: 3},
+			failures:  2,
+			err:       testErrnoNack,
+			wantCalls: 3,
+		},
+		"success_with_recovery": {
+			policy:        RetryPolicy{Count: 3, RecoverBus: true},
+			failures:      2,
+			err:           testErrnoNack,
+			wantCalls:     3,
+			wantRecovered: []int{2, 2},
+		},
+		"error_retries_exhausted": {
+			policy:    RetryPolicy{Count: 2, Backoff: time.Millisecond},
+			failures:  5,
+			err:       testErrnoNack,
+			wantCalls: 3,
+			wantErr: "I2C ReadByteData on bus 2, address 0x15, register 0x10 failed after 3 attempt(s): " +
+				testErrnoNack.Error(),
+		},
+		"error_not_transient": {
+			policy:    RetryPolicy{Count: 2},
+			failures:  5,
+			err:       errors.New("read error"),
+			wantCalls: 1,
+			wantErr:   "I2C ReadByteData on bus 2, address 0x15, register 0x10 failed after 1 attempt(s): read error",
+		},
+		"error_not_in_given_errnos": {
+			policy:    RetryPolicy{Count: 2, TransientErrnos: []syscall.Errno{syscall.Errno(0x6e)}},
+			failures:  5,
+			err:       testErrnoNack,
+			wantCalls: 1,
+			wantErr: "I2C ReadByteData on bus 2, address 0x15, register 0x10 failed after 1 attempt(s): " +
+				testErrnoNack.Error(),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := &i2cTestRecoveringAdaptor{i2cTestAdaptor: newI2cTestAdaptor()}
+			readImpl, calls := failingReadImpl(tc.failures, tc.err)
+			a.Testi2cReadImpl(readImpl)
+			d := NewDriver(a, "I2C_RETRY", 0x15, WithBus(2), WithRetryPolicy(tc.policy))
+			require.NoError(t, d.Start())
+			// act
+			got, err := d.connection.ReadByteData(0x10)
+			// assert
+			assert.Equal(t, tc.wantCalls, *calls)
+			assert.Equal(t, tc.wantRecovered, a.recoveredBuses)
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, uint8(0x42), got)
+		})
+	}
+}
+
+func TestRetryConnectionBusError(t *testing.T) {
+	// arrange
+	a := newI2cTestAdaptor()
+	a.Testi2cWriteImpl(func([]byte) (int, error) { return 0, testErrnoNack })
+	d := NewDriver(a, "I2C_RETRY", 0x15, WithRetryPolicy(RetryPolicy{Count: 1}))
+	require.NoError(t, d.Start())
+	// act
+	err := d.connection.WriteByte(0x01)
+	// assert
+	var busErr *BusError
+	require.ErrorAs(t, err, &busErr)
+	assert.Equal(t, "WriteByte", busErr.Op)
+	assert.Equal(t, 0, busErr.Bus)
+	assert.Equal(t, 0x15, busErr.Address)
+	assert.Equal(t, RegisterNone, busErr.Register)
+	assert.Equal(t, 2, busErr.Attempts)
+	require.ErrorIs(t, err, testErrnoNack)
+	require.EqualError(t, err, "I2C WriteByte on bus 0, address 0x15 failed after 2 attempt(s): "+testErrnoNack.Error())
+}
+
+func TestRetryConnectionBackoffByClock(t *testing.T) {
+	// arrange
+	clock := gobot.NewFakeClock(time.Now())
+	a := newI2cTestAdaptor()
+	readImpl, calls := failingReadImpl(2, testErrnoNack)
+	a.Testi2cReadImpl(readImpl)
+	policy := RetryPolicy{Count: 2, Backoff: 10 * time.Millisecond}
+	d := NewDriver(a, "I2C_RETRY", 0x15, WithRetryPolicy(policy), WithClock(clock))
+	require.NoError(t, d.Start())
+	done := make(chan error)
+	// act
+	go func() {
+		_, err := d.connection.ReadByte()
+		done <- err
+	}()
+	// assert
+	clock.BlockUntil(1)
+	clock.Advance(10 * time.Millisecond)
+	clock.BlockUntil(1)
+	clock.Advance(20 * time.Millisecond)
+	require.NoError(t, <-done)
+	assert.Equal(t, 3, *calls)
+}
+
+func TestRetryConnectionRecoveryError(t *testing.T) {
+	// arrange
+	a := &i2cTestRecoveringAdaptor{i2cTestAdaptor: newI2cTestAdaptor(), recoverErr: errors.New("recover error")}
+	readImpl, calls := failingReadImpl(5, testErrnoNack)
+	a.Testi2cReadImpl(readImpl)
+	d := NewDriver(a, "I2C_RETRY", 0x15, WithRetryPolicy(RetryPolicy{Count: 3, RecoverBus: true}))
+	require.NoError(t, d.Start())
+	// act
+	_, err := d.connection.ReadByte()
+	// assert
+	require.ErrorIs(t, err, testErrnoNack)
+	require.ErrorContains(t, err, "recover error")
+	assert.Equal(t, 1, *calls)
+	assert.Equal(t, []int{0}, a.recoveredBuses)
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	tests := map[string]struct {
+		policy RetryPolicy
+		retry  int
+		want   time.Duration
+	}{
+		"first_retry": {
+			policy: RetryPolicy{Backoff: 10 * time.Millisecond},
+			retry:  1,
+			want:   10 * time.Millisecond,
+		},
+		"third_retry_doubled": {
+			policy: RetryPolicy{Backoff: 10 * time.Millisecond},
+			retry:  3,
+			want:   40 * time.Millisecond,
+		},
+		"limited": {
+			policy: RetryPolicy{Backoff: 10 * time.Millisecond, MaxBackoff: 25 * time.Millisecond},
+			retry:  3,
+			want:   25 * time.Millisecond,
+		},
+		"no_backoff": {
+			retry: 3,
+			want:  0,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act & assert
+			assert.Equal(t, tc.want, tc.policy.backoff(tc.retry))
+		})
+	}
+}
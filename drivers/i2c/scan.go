@@ -0,0 +1,272 @@
+package i2c
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ScanProbe is the strategy to detect a device on an address.
+type ScanProbe string
+
+const (
+	// ScanProbeAuto uses "read byte" for the address ranges of EEPROMs (0x30-0x37, 0x50-0x5F) and "quick write" for
+	// all other addresses, like the default of i2cdetect. If the bus does not support the SMBus "quick write", "read
+	// byte" is used for all addresses.
+	ScanProbeAuto ScanProbe = "auto"
+	// ScanProbeQuickWrite uses the SMBus "quick write" (only the address, without data). The address range 0x50-0x5F
+	// is skipped, because this is known to corrupt some EEPROMs (e.g. Atmel AT24RF08). If the bus does not support the
+	// "quick write", "read byte" is used instead.
+	ScanProbeQuickWrite ScanProbe = "quick"
+	// ScanProbeReadByte uses "read byte" for all addresses. This can lock up some write-only chips.
+	ScanProbeReadByte ScanProbe = "read"
+)
+
+const (
+	// the addresses 0x00-0x07 and 0x78-0x7F are reserved by the I2C specification and never scanned
+	scanFirstAddress = 0x08
+	scanLastAddress  = 0x77
+)
+
+// ScanChip describes a chip and the related constructor of a gobot driver.
+type ScanChip struct {
+	Name        string `json:"name"`
+	Constructor string `json:"constructor"`
+}
+
+// ScanResult is the result for an address, where a device responds.
+type ScanResult struct {
+	Address int `json:"address"`
+	// Chips contains all chips, which are identified by reading ID registers.
+	Chips []ScanChip `json:"chips,omitempty"`
+	// Candidates contains all chips, which are known to use this address, if no chip was identified.
+	Candidates []ScanChip `json:"candidates,omitempty"`
+}
+
+// ScanOption is the type for applying options to the scan.
+type ScanOption func(*scanConfiguration)
+
+type scanConfiguration struct {
+	probe ScanProbe
+	first int
+	last  int
+}
+
+// scanIdentifier describes how to identify a chip by an ID register. Identifiers without a register are only used
+// to name candidates for an address.
+type scanIdentifier struct {
+	chip      ScanChip
+	addresses []int
+	register  uint8
+	word      bool   // the ID is a 16 bit value, MSB first
+	mask      uint16 // used bits of the ID, 0 means no ID register is available
+	value     uint16
+}
+
+var scanIdentifiers = []scanIdentifier{
+	{
+		chip:      ScanChip{Name: "BMP280", Constructor: "NewBMP280Driver"},
+		addresses: []int{0x76, 0x77}, register: 0xD0, mask: 0xFF, value: 0x58,
+	},
+	{
+		chip:      ScanChip{Name: "BME280", Constructor: "NewBME280Driver"},
+		addresses: []int{0x76, 0x77}, register: 0xD0, mask: 0xFF, value: 0x60,
+	},
+	{
+		chip:      ScanChip{Name: "BMP180", Constructor: "NewBMP180Driver"},
+		addresses: []int{0x77}, register: 0xD0, mask: 0xFF, value: 0x55,
+	},
+	{
+		chip:      ScanChip{Name: "BMP388", Constructor: "NewBMP388Driver"},
+		addresses: []int{0x76, 0x77}, register: 0x00, mask: 0xFF, value: 0x50,
+	},
+	{
+		chip:      ScanChip{Name: "MPU6050", Constructor: "NewMPU6050Driver"},
+		addresses: []int{0x68, 0x69}, register: 0x75, mask: 0x7E, value: 0x68, // WHO_AM_I
+	},
+	{
+		chip:      ScanChip{Name: "ADXL345", Constructor: "NewADXL345Driver"},
+		addresses: []int{0x1D, 0x53}, register: 0x00, mask: 0xFF, value: 0xE5, // DEVID
+	},
+	{
+		chip:      ScanChip{Name: "CCS811", Constructor: "NewCCS811Driver"},
+		addresses: []int{0x5A, 0x5B}, register: 0x20, mask: 0xFF, value: 0x81, // HW_ID
+	},
+	{
+		chip:      ScanChip{Name: "DRV2605L", Constructor: "NewDRV2605LDriver"},
+		addresses: []int{0x5A}, register: 0x00, mask: 0xE0, value: 0xE0, // STATUS, bits 7-5 are the device ID
+	},
+	{
+		chip:      ScanChip{Name: "HMC5883L", Constructor: "NewHMC5883LDriver"},
+		addresses: []int{0x1E}, register: 0x0A, mask: 0xFF, value: 'H', // identification register A
+	},
+	{
+		chip:      ScanChip{Name: "L3GD20H", Constructor: "NewL3GD20HDriver"},
+		addresses: []int{0x6A, 0x6B}, register: 0x0F, mask: 0xFF, value: 0xD7, // WHO_AM_I
+	},
+	{
+		chip:      ScanChip{Name: "INA3221", Constructor: "NewINA3221Driver"},
+		addresses: []int{0x40, 0x41, 0x42, 0x43}, register: 0xFF, word: true, mask: 0xFFFF, value: 0x3220, // die ID
+	},
+	{
+		chip:      ScanChip{Name: "TSL2561", Constructor: "NewTSL2561Driver"},
+		addresses: []int{0x29, 0x39, 0x49}, register: 0x8A, mask: 0xB0, value: 0x10, // command bit and ID register
+	},
+	// chips without an ID register, listed with the default and common alternative addresses
+	{chip: ScanChip{Name: "Adafruit 2327 (PCA9685)", Constructor: "NewAdafruit2327Driver"}, addresses: []int{0x40}},
+	{chip: ScanChip{Name: "Adafruit 2348 motor hat", Constructor: "NewAdafruit2348Driver"}, addresses: []int{0x60}},
+	{chip: ScanChip{Name: "ADS1015", Constructor: "NewADS1015Driver"}, addresses: []int{0x48, 0x49, 0x4A, 0x4B}},
+	{chip: ScanChip{Name: "ADS1115", Constructor: "NewADS1115Driver"}, addresses: []int{0x48, 0x49, 0x4A, 0x4B}},
+	{chip: ScanChip{Name: "BH1750", Constructor: "NewBH1750Driver"}, addresses: []int{0x23, 0x5C}},
+	{chip: ScanChip{Name: "BlinkM", Constructor: "NewBlinkMDriver"}, addresses: []int{0x09}},
+	{chip: ScanChip{Name: "GrovePi", Constructor: "NewGrovePiDriver"}, addresses: []int{0x04}},
+	{chip: ScanChip{Name: "HMC6352", Constructor: "NewHMC6352Driver"}, addresses: []int{0x21}},
+	{chip: ScanChip{Name: "JHD1313M1 (LCD)", Constructor: "NewJHD1313M1Driver"}, addresses: []int{0x3E}},
+	{chip: ScanChip{Name: "LIDAR-Lite", Constructor: "NewLIDARLiteDriver"}, addresses: []int{0x62}},
+	{chip: ScanChip{Name: "MCP23017", Constructor: "NewMCP23017Driver"}, addresses: []int{0x20}},
+	{chip: ScanChip{Name: "Adafruit 1109", Constructor: "NewAdafruit1109Driver"}, addresses: []int{0x20}},
+	{chip: ScanChip{Name: "MMA7660", Constructor: "NewMMA7660Driver"}, addresses: []int{0x4C}},
+	{chip: ScanChip{Name: "MPL115A2", Constructor: "NewMPL115A2Driver"}, addresses: []int{0x60}},
+	{chip: ScanChip{Name: "PCA9501", Constructor: "NewPCA9501Driver"}, addresses: []int{0x3F}},
+	{chip: ScanChip{Name: "PCA953x", Constructor: "NewPCA953xDriver"}, addresses: []int{0x63}},
+	{chip: ScanChip{Name: "PCA9685", Constructor: "NewPCA9685Driver"}, addresses: []int{0x40}},
+	{chip: ScanChip{Name: "PCF8583", Constructor: "NewPCF8583Driver"}, addresses: []int{0x50, 0x51}},
+	{chip: ScanChip{Name: "PCF8591", Constructor: "NewPCF8591Driver"}, addresses: []int{0x48}},
+	{chip: ScanChip{Name: "YL-40 (PCF8591)", Constructor: "NewYL40Driver"}, addresses: []int{0x48}},
+	{chip: ScanChip{Name: "SHT2x", Constructor: "NewSHT2xDriver"}, addresses: []int{0x40}},
+	{chip: ScanChip{Name: "SHT3x", Constructor: "NewSHT3xDriver"}, addresses: []int{0x44, 0x45}},
+	{chip: ScanChip{Name: "SSD1306", Constructor: "NewSSD1306Driver"}, addresses: []int{0x3C, 0x3D}},
+	{chip: ScanChip{Name: "TH02", Constructor: "NewTH02Driver"}, addresses: []int{0x40}},
+	{chip: ScanChip{Name: "Wiichuck", Constructor: "NewWiichuckDriver"}, addresses: []int{0x52}},
+}
+
+// WithScanProbe sets the strategy to detect a device, the default is "ScanProbeAuto".
+func WithScanProbe(probe ScanProbe) ScanOption {
+	return func(cfg *scanConfiguration) {
+		cfg.probe = probe
+	}
+}
+
+// WithScanRange limits the scanned addresses. Reserved addresses (0x00-0x07, 0x78-0x7F) are never scanned.
+func WithScanRange(first, last int) ScanOption {
+	return func(cfg *scanConfiguration) {
+		cfg.first = first
+		cfg.last = last
+	}
+}
+
+// Scan detects all devices on the given bus, similar to i2cdetect. For each responding address, the chip is identified
+// by reading ID registers. If the chip can not be identified, the chips known to use this address are listed as
+// candidates.
+func Scan(c Connector, bus int, opts ...ScanOption) ([]ScanResult, error) {
+	cfg := scanConfiguration{probe: ScanProbeAuto, first: scanFirstAddress, last: scanLastAddress}
+	for _, o := range opts {
+		o(&cfg)
+	}
+
+	switch cfg.probe {
+	case ScanProbeAuto, ScanProbeQuickWrite, ScanProbeReadByte:
+	default:
+		return nil, fmt.Errorf("unknown probe strategy '%s' for I2C scan", cfg.probe)
+	}
+
+	first := max(cfg.first, scanFirstAddress)
+	last := min(cfg.last, scanLastAddress)
+
+	var results []ScanResult
+	for address := first; address <= last; address++ {
+		useReadByte := cfg.probe == ScanProbeReadByte || (cfg.probe == ScanProbeAuto && isEEPROMAddress(address))
+		if cfg.probe == ScanProbeQuickWrite && address >= 0x50 && address <= 0x5F {
+			continue
+		}
+
+		con, err := c.GetI2cConnection(address, bus)
+		if err != nil {
+			return nil, err
+		}
+
+		if !probeAddress(con, useReadByte) {
+			continue
+		}
+
+		results = append(results, identifyChips(con, address))
+	}
+
+	return results, nil
+}
+
+// QuickWriter is the optional interface of a connection, which supports the SMBus "quick write"
+type QuickWriter interface {
+	WriteQuick() error
+}
+
+func probeAddress(con Connection, useReadByte bool) bool {
+	if !useReadByte {
+		if qw, ok := con.(QuickWriter); ok {
+			err := qw.WriteQuick()
+			if !errors.Is(err, errors.ErrUnsupported) {
+				return err == nil
+			}
+		}
+		// without support of the SMBus "quick write", "read byte" is used like i2cdetect does
+	}
+
+	_, err := con.ReadByte()
+	return err == nil
+}
+
+func identifyChips(con Connection, address int) ScanResult {
+	result := ScanResult{Address: address}
+	for _, id := range scanIdentifiers {
+		if id.mask == 0 || !containsAddress(id.addresses, address) {
+			continue
+		}
+
+		var val uint16
+		if id.word {
+			word, err := con.ReadWordData(id.register)
+			if err != nil {
+				continue
+			}
+			val = swapBytes(word)
+		} else {
+			b, err := con.ReadByteData(id.register)
+			if err != nil {
+				continue
+			}
+			val = uint16(b)
+		}
+
+		if val&id.mask == id.value {
+			result.Chips = append(result.Chips, id.chip)
+		}
+	}
+
+	if len(result.Chips) > 0 {
+		return result
+	}
+
+	for _, id := range scanIdentifiers {
+		if containsAddress(id.addresses, address) {
+			result.Candidates = append(result.Candidates, id.chip)
+		}
+	}
+	sort.Slice(result.Candidates, func(i, j int) bool { return result.Candidates[i].Name < result.Candidates[j].Name })
+
+	return result
+}
+
+// isEEPROMAddress returns true for address ranges, where "quick write" can corrupt EEPROMs or the write protection
+func isEEPROMAddress(address int) bool {
+	return (address >= 0x30 && address <= 0x37) || (address >= 0x50 && address <= 0x5F)
+}
+
+func containsAddress(addresses []int, address int) bool {
+	for _, a := range addresses {
+		if a == address {
+			return true
+		}
+	}
+
+	return false
+}
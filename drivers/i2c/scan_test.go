@@ -0,0 +1,202 @@
+package i2c
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scanTestBus simulates devices with their registers, all other addresses do not respond
+type scanTestBus struct {
+	devices      map[int]map[uint8]uint16
+	noQuickWrite bool
+	quickWrite   []int
+	readByte     []int
+	connectErr   error
+}
+
+type scanTestDevice struct {
+	*i2cTestAdaptor
+	bus       *scanTestBus
+	address   int
+	registers map[uint8]uint16
+}
+
+var errScanTestNoAck = errors.New("no ACK")
+
+func (b *scanTestBus) GetI2cConnection(address int, _ int) (Connection, error) {
+	if b.connectErr != nil {
+		return nil, b.connectErr
+	}
+	return &scanTestDevice{
+		i2cTestAdaptor: newI2cTestAdaptor(),
+		bus:            b,
+		address:        address,
+		registers:      b.devices[address],
+	}, nil
+}
+
+func (b *scanTestBus) DefaultI2cBus() int { return 1 }
+
+func (d *scanTestDevice) WriteQuick() error {
+	if d.bus.noQuickWrite {
+		return fmt.Errorf("%w: quick write", errors.ErrUnsupported)
+	}
+	d.bus.quickWrite = append(d.bus.quickWrite, d.address)
+	if d.registers == nil {
+		return errScanTestNoAck
+	}
+	return nil
+}
+
+func (d *scanTestDevice) ReadByte() (byte, error) {
+	d.bus.readByte = append(d.bus.readByte, d.address)
+	if d.registers == nil {
+		return 0, errScanTestNoAck
+	}
+	return 0, nil
+}
+
+func (d *scanTestDevice) ReadByteData(reg uint8) (uint8, error) {
+	val, ok := d.registers[reg]
+	if !ok {
+		return 0, errScanTestNoAck
+	}
+	return uint8(val), nil //nolint:gosec // ok for test
+}
+
+func (d *scanTestDevice) ReadWordData(reg uint8) (uint16, error) {
+	val, ok := d.registers[reg]
+	if !ok {
+		return 0, errScanTestNoAck
+	}
+	return swapBytes(val), nil
+}
+
+func TestScan(t *testing.T) {
+	bus := &scanTestBus{devices: map[int]map[uint8]uint16{
+		0x1E: {0x0A: 'H'},
+		0x3C: {},
+		0x40: {0xFF: 0x3220},
+		0x53: {0x00: 0xE5},
+		0x5A: {0x20: 0x81, 0x00: 0x01},
+		0x68: {0x75: 0x68},
+		0x76: {0xD0: 0x60},
+		0x77: {0xD0: 0x58},
+	}}
+	// act
+	got, err := Scan(bus, 1)
+	// assert
+	require.NoError(t, err)
+	want := []ScanResult{
+		{Address: 0x1E, Chips: []ScanChip{{Name: "HMC5883L", Constructor: "NewHMC5883LDriver"}}},
+		{Address: 0x3C, Candidates: []ScanChip{{Name: "SSD1306", Constructor: "NewSSD1306Driver"}}},
+		{Address: 0x40, Chips: []ScanChip{{Name: "INA3221", Constructor: "NewINA3221Driver"}}},
+		{Address: 0x53, Chips: []ScanChip{{Name: "ADXL345", Constructor: "NewADXL345Driver"}}},
+		{Address: 0x5A, Chips: []ScanChip{{Name: "CCS811", Constructor: "NewCCS811Driver"}}},
+		{Address: 0x68, Chips: []ScanChip{{Name: "MPU6050", Constructor: "NewMPU6050Driver"}}},
+		{Address: 0x76, Chips: []ScanChip{{Name: "BME280", Constructor: "NewBME280Driver"}}},
+		{Address: 0x77, Chips: []ScanChip{{Name: "BMP280", Constructor: "NewBMP280Driver"}}},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestScanCandidates(t *testing.T) {
+	// arrange
+	bus := &scanTestBus{devices: map[int]map[uint8]uint16{0x48: {}}}
+	// act
+	got, err := Scan(bus, 1, WithScanRange(0x48, 0x48))
+	// assert
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Empty(t, got[0].Chips)
+	var names []string
+	for _, c := range got[0].Candidates {
+		names = append(names, c.Name)
+	}
+	assert.Equal(t, []string{"ADS1015", "ADS1115", "PCF8591", "YL-40 (PCF8591)"}, names)
+}
+
+func TestScanProbes(t *testing.T) {
+	tests := map[string]struct {
+		probe          ScanProbe
+		noQuickWrite   bool
+		wantQuickWrite []int
+		wantReadByte   []int
+		wantErr        string
+	}{
+		"auto": {
+			probe:          ScanProbeAuto,
+			wantQuickWrite: []int{0x2F, 0x38, 0x4F, 0x60},
+			wantReadByte:   []int{0x30, 0x37, 0x50, 0x5F},
+		},
+		"quick_write_skips_eeprom": {
+			probe:          ScanProbeQuickWrite,
+			wantQuickWrite: []int{0x2F, 0x30, 0x37, 0x38, 0x4F, 0x60},
+		},
+		"quick_write_not_supported": {
+			probe:        ScanProbeQuickWrite,
+			noQuickWrite: true,
+			wantReadByte: []int{0x2F, 0x30, 0x37, 0x38, 0x4F, 0x60},
+		},
+		"auto_quick_write_not_supported": {
+			probe:        ScanProbeAuto,
+			noQuickWrite: true,
+			wantReadByte: []int{0x2F, 0x30, 0x37, 0x38, 0x4F, 0x50, 0x5F, 0x60},
+		},
+		"read_byte": {
+			probe:        ScanProbeReadByte,
+			wantReadByte: []int{0x2F, 0x30, 0x37, 0x38, 0x4F, 0x50, 0x5F, 0x60},
+		},
+		"error_unknown_probe": {
+			probe:   "write",
+			wantErr: "unknown probe strategy 'write' for I2C scan",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			bus := &scanTestBus{noQuickWrite: tc.noQuickWrite}
+			// act: scan only the borders of the EEPROM ranges
+			var err error
+			for _, r := range [][2]int{{0x2F, 0x30}, {0x37, 0x38}, {0x4F, 0x50}, {0x5F, 0x60}} {
+				if _, err = Scan(bus, 1, WithScanProbe(tc.probe), WithScanRange(r[0], r[1])); err != nil {
+					break
+				}
+			}
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantQuickWrite, bus.quickWrite)
+			assert.Equal(t, tc.wantReadByte, bus.readByte)
+		})
+	}
+}
+
+func TestScanSkipsReservedAddresses(t *testing.T) {
+	// arrange
+	bus := &scanTestBus{}
+	// act
+	_, err := Scan(bus, 1, WithScanProbe(ScanProbeReadByte), WithScanRange(0x00, 0x7F))
+	// assert
+	require.NoError(t, err)
+	require.Len(t, bus.readByte, 0x77-0x08+1)
+	assert.Equal(t, 0x08, bus.readByte[0])
+	assert.Equal(t, 0x77, bus.readByte[len(bus.readByte)-1])
+}
+
+func TestScanConnectionError(t *testing.T) {
+	// arrange
+	bus := &scanTestBus{connectErr: errors.New("bus not available")}
+	// act
+	got, err := Scan(bus, 1)
+	// assert
+	require.EqualError(t, err, "bus not available")
+	assert.Nil(t, got)
+}
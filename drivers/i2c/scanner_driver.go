@@ -0,0 +1,103 @@
+package i2c
+
+import (
+	"log"
+	"sync"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// ScannerDriver is a gobot driver to scan an I2C bus for devices, e.g. to verify the wiring in the field. In contrast
+// to other I2C drivers, no address is claimed and no connection is created on start.
+//
+// Supported commands:
+//
+//	"Scan" - params "bus" (optional, default is the bus of the driver), "probe" (optional, "auto", "quick" or "read")
+//	returns "results" ([]ScanResult) and "err"
+type ScannerDriver struct {
+	name      string
+	connector Connector
+	Config
+	gobot.Commander
+	mutex *sync.Mutex
+}
+
+// NewScannerDriver creates a new driver to scan an I2C bus.
+//
+// Params:
+//
+//	c Connector - the Adaptor to use with this Driver
+//
+// Optional params:
+//
+//	i2c.WithBus(int):	bus to use with this driver
+func NewScannerDriver(c Connector, options ...func(Config)) *ScannerDriver {
+	d := &ScannerDriver{
+		name:      gobot.DefaultName("I2CScanner"),
+		connector: c,
+		Config:    NewConfig(),
+		Commander: gobot.NewCommander(),
+		mutex:     &sync.Mutex{},
+	}
+
+	for _, option := range options {
+		option(d)
+	}
+
+	d.AddCommand("Scan", func(params map[string]interface{}) interface{} {
+		bus := d.GetBusOrDefault(d.connector.DefaultI2cBus())
+		if val, ok := params["bus"]; ok {
+			bus = int(val.(float64))
+		}
+		var opts []ScanOption
+		if val, ok := params["probe"]; ok {
+			opts = append(opts, WithScanProbe(ScanProbe(val.(string))))
+		}
+		results, err := d.scanBus(bus, opts...)
+		return map[string]interface{}{"results": results, "err": err}
+	})
+
+	return d
+}
+
+// Name returns the name of the device.
+func (d *ScannerDriver) Name() string {
+	return d.name
+}
+
+// SetName sets the name of the device.
+func (d *ScannerDriver) SetName(name string) {
+	d.name = name
+}
+
+// Connection returns the connection of the device.
+func (d *ScannerDriver) Connection() gobot.Connection {
+	if conn, ok := d.connector.(gobot.Connection); ok {
+		return conn
+	}
+
+	log.Printf("%s has no gobot connection\n", d.name)
+	return nil
+}
+
+// Start initializes the device, nothing to do here.
+func (d *ScannerDriver) Start() error {
+	return nil
+}
+
+// Halt halts the device, nothing to do here.
+func (d *ScannerDriver) Halt() error {
+	return nil
+}
+
+// Scan detects and identifies all devices on the bus of the driver, see Scan().
+func (d *ScannerDriver) Scan(opts ...ScanOption) ([]ScanResult, error) {
+	return d.scanBus(d.GetBusOrDefault(d.connector.DefaultI2cBus()), opts...)
+}
+
+func (d *ScannerDriver) scanBus(bus int, opts ...ScanOption) ([]ScanResult, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return Scan(d.connector, bus, opts...)
+}
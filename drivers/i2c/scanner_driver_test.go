@@ -0,0 +1,56 @@
+package i2c
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+var _ gobot.Driver = (*ScannerDriver)(nil)
+
+func TestNewScannerDriver(t *testing.T) {
+	// arrange
+	bus := &scanTestBus{}
+	// act
+	d := NewScannerDriver(bus, WithBus(2))
+	// assert
+	assert.True(t, strings.HasPrefix(d.Name(), "I2CScanner"))
+	assert.Equal(t, 2, d.GetBusOrDefault(1))
+	require.NoError(t, d.Start())
+	require.NoError(t, d.Halt())
+	assert.Nil(t, d.Connection())
+}
+
+func TestScannerDriverScan(t *testing.T) {
+	// arrange
+	bus := &scanTestBus{devices: map[int]map[uint8]uint16{0x77: {0xD0: 0x58}}}
+	d := NewScannerDriver(bus)
+	// act
+	got, err := d.Scan(WithScanRange(0x70, 0x77))
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []ScanResult{
+		{Address: 0x77, Chips: []ScanChip{{Name: "BMP280", Constructor: "NewBMP280Driver"}}},
+	}, got)
+}
+
+func TestScannerDriverCommandScan(t *testing.T) {
+	// arrange
+	bus := &scanTestBus{devices: map[int]map[uint8]uint16{0x68: {0x75: 0x68}}}
+	d := NewScannerDriver(bus)
+	// act
+	result := d.Command("Scan")(map[string]interface{}{"bus": 3.0, "probe": "read"})
+	// assert
+	m := result.(map[string]interface{})
+	assert.Nil(t, m["err"])
+	results := m["results"].([]ScanResult)
+	require.Len(t, results, 1)
+	assert.Equal(t, 0x68, results[0].Address)
+	assert.Equal(t, "NewMPU6050Driver", results[0].Chips[0].Constructor)
+	assert.Contains(t, bus.readByte, 0x20)
+	assert.Empty(t, bus.quickWrite)
+}
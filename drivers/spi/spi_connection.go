@@ -1,6 +1,7 @@
 package spi
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 
@@ -35,6 +36,21 @@ func (c *spiConnection) ReadCommandData(command []byte, data []byte) error {
 	return c.txRxAndCheckReadLength(command, data)
 }
 
+// Transfer sends/receives all given segments as one message, if supported by the system device. Otherwise an error
+// wrapping errors.ErrUnsupported is returned. Implements gobot.SpiTransferer.
+// In contrast to ReadCommandData(), the device keeps selected between the segments, e.g. for writing a command and
+// reading the response afterwards. Each segment can override the settings of the connection.
+func (c *spiConnection) Transfer(segments ...gobot.SpiTransferSegment) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if t, ok := c.spiSystem.(gobot.SpiTransferer); ok {
+		return t.Transfer(segments...)
+	}
+
+	return fmt.Errorf("%w: SPI transfer of segments", errors.ErrUnsupported)
+}
+
 // Close connection to underlying SPI device.
 func (c *spiConnection) Close() error {
 	c.mutex.Lock()
@@ -1,6 +1,7 @@
 package spi
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -10,7 +11,16 @@ import (
 	"gobot.io/x/gobot/v2/system"
 )
 
-var _ gobot.SpiOperations = (*spiConnection)(nil)
+var (
+	_ gobot.SpiOperations = (*spiConnection)(nil)
+	_ gobot.SpiTransferer = (*spiConnection)(nil)
+)
+
+// spiTxRxOnlyDevice is a system device without support of multi-segment transfers
+type spiTxRxOnlyDevice struct{}
+
+func (spiTxRxOnlyDevice) TxRx([]byte, []byte) error { return nil }
+func (spiTxRxOnlyDevice) Close() error              { return nil }
 
 func initTestConnectionWithMockedSystem() (Connection, *system.MockSpiAccess) {
 	a := system.NewAccesser()
@@ -45,6 +55,33 @@ func TestReadCommandData(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+func TestTransfer(t *testing.T) {
+	// arrange
+	command := []byte{0x42}
+	want := []byte{0x12, 0x34, 0x56}
+	c, sysdev := initTestConnectionWithMockedSystem()
+	sysdev.SetSimRead(want)
+	got := make([]byte, 3)
+	// act
+	err := c.(gobot.SpiTransferer).Transfer(gobot.SpiTransferSegment{Tx: command},
+		gobot.SpiTransferSegment{Rx: got, SpeedHz: 1000})
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, command, sysdev.Written())
+	assert.Equal(t, want, got)
+	require.Len(t, sysdev.Segments(), 2)
+	assert.Equal(t, uint32(1000), sysdev.Segments()[1].SpeedHz)
+}
+
+func TestTransferUnsupported(t *testing.T) {
+	// arrange
+	c := NewConnection(spiTxRxOnlyDevice{})
+	// act
+	err := c.Transfer(gobot.SpiTransferSegment{Tx: []byte{0x42}})
+	// assert
+	require.ErrorIs(t, err, errors.ErrUnsupported)
+}
+
 func TestReadByteData(t *testing.T) {
 	// arrange
 	const (
@@ -36,6 +36,16 @@ type Connector interface {
 	SpiDefaultMaxSpeed() int64
 }
 
+// ChipClaimer lets adaptors track the ownership of SPI chip selects, so that two drivers can not use the same chip
+// on the same bus.
+type ChipClaimer interface {
+	// ClaimSpiChip registers the chip on the bus for the given owner
+	ClaimSpiChip(owner string, busNum int, chip int) error
+
+	// ReleaseSpiChips removes all chips of the given owner
+	ReleaseSpiChips(owner string)
+}
+
 // Connection is a connection to a SPI device with a specific bus/chip.
 // Provided by an Adaptor, usually just by calling the spi package's GetSpiConnection() function.
 type Connection gobot.SpiOperations
@@ -129,12 +139,25 @@ func (d *Driver) Start() error {
 	bits := d.GetBitCountOrDefault(d.connector.SpiDefaultBitCount())
 	maxSpeed := d.GetSpeedOrDefault(d.connector.SpiDefaultMaxSpeed())
 
+	if claimer, ok := d.connector.(ChipClaimer); ok {
+		if err := claimer.ClaimSpiChip(d.name, bus, chip); err != nil {
+			return err
+		}
+	}
+
 	var err error
 	d.connection, err = d.connector.GetSpiConnection(bus, chip, mode, bits, maxSpeed)
 	if err != nil {
+		d.releaseChip()
 		return err
 	}
-	return d.afterStart()
+
+	if err := d.afterStart(); err != nil {
+		d.releaseChip()
+		return err
+	}
+
+	return nil
 }
 
 // Halt stops the driver.
@@ -142,11 +165,17 @@ func (d *Driver) Halt() error {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
-	if err := d.beforeHalt(); err != nil {
-		return err
-	}
+	err := d.beforeHalt()
 
-	// currently there is nothing to do here for the driver, the connection is cached on adaptor side
-	// and will be closed on adaptor Finalize()
-	return nil
+	// the connection is cached on adaptor side and will be closed on adaptor Finalize()
+	d.releaseChip()
+
+	return err
+}
+
+// releaseChip removes the ownership of the chip, if the connector tracks the ownership of chips
+func (d *Driver) releaseChip() {
+	if claimer, ok := d.connector.(ChipClaimer); ok {
+		claimer.ReleaseSpiChips(d.name)
+	}
 }
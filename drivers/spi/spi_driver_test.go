@@ -1,6 +1,7 @@
 package spi
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
@@ -53,3 +54,51 @@ func TestConnection(t *testing.T) {
 	d, _ := initTestDriverWithStubbedAdaptor()
 	assert.NotNil(t, d.Connection())
 }
+
+// spiTestClaimingAdaptor tracks the ownership of chips
+type spiTestClaimingAdaptor struct {
+	*spiTestAdaptor
+	owners map[int]string
+}
+
+func (a *spiTestClaimingAdaptor) ClaimSpiChip(owner string, _ int, chip int) error {
+	if current, ok := a.owners[chip]; ok && current != owner {
+		return fmt.Errorf("chip %d already owned by '%s'", chip, current)
+	}
+	a.owners[chip] = owner
+	return nil
+}
+
+func (a *spiTestClaimingAdaptor) ReleaseSpiChips(owner string) {
+	for chip, current := range a.owners {
+		if current == owner {
+			delete(a.owners, chip)
+		}
+	}
+}
+
+func TestStartHaltClaimsChip(t *testing.T) {
+	// arrange
+	a := &spiTestClaimingAdaptor{spiTestAdaptor: newSpiTestAdaptor(), owners: make(map[int]string)}
+	d1 := NewDriver(a, "first")
+	d2 := NewDriver(a, "second")
+	// act & assert
+	require.NoError(t, d1.Start())
+	assert.Equal(t, map[int]string{0: d1.Name()}, a.owners)
+	require.EqualError(t, d2.Start(), fmt.Sprintf("chip 0 already owned by '%s'", d1.Name()))
+	require.NoError(t, d1.Halt())
+	assert.Empty(t, a.owners)
+	require.NoError(t, d2.Start())
+}
+
+func TestStartReleasesChipOnError(t *testing.T) {
+	// arrange
+	a := &spiTestClaimingAdaptor{spiTestAdaptor: newSpiTestAdaptor(), owners: make(map[int]string)}
+	a.spiConnectErr = true
+	d := NewDriver(a, "first")
+	// act
+	err := d.Start()
+	// assert
+	require.EqualError(t, err, "Invalid SPI connection in helper")
+	assert.Empty(t, a.owners)
+}
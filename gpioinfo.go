@@ -0,0 +1,30 @@
+package gobot
+
+import "time"
+
+// GpioChipInfo contains the information of a gpiochip and its lines, like reported by "gpioinfo".
+type GpioChipInfo struct {
+	Name  string         `json:"name"`  // name of the character device, e.g. "gpiochip0"
+	Label string         `json:"label"` // label of the driver, e.g. "pinctrl-bcm2711"
+	Lines []GpioLineInfo `json:"lines"`
+}
+
+// GpioLineInfo contains the information of a line of a gpiochip.
+type GpioLineInfo struct {
+	Chip      string `json:"chip"`
+	Offset    int    `json:"offset"`
+	Name      string `json:"name"`
+	Consumer  string `json:"consumer"` // the requester of the line, empty if not requested
+	Used      bool   `json:"used"`
+	Direction string `json:"direction"` // "input", "output" or "unknown"
+	Drive     string `json:"drive"`     // "push-pull", "open-drain" or "open-source"
+	Bias      string `json:"bias"`      // "pull-up", "pull-down", "disabled" or "unknown"
+	ActiveLow bool   `json:"active_low"`
+}
+
+// GpioLineInfoEvent is the event for a change of the information of a line, e.g. when requested by another process.
+type GpioLineInfoEvent struct {
+	Type      string        `json:"type"` // "requested", "released" or "reconfigured"
+	Timestamp time.Duration `json:"timestamp"`
+	Info      GpioLineInfo  `json:"info"`
+}
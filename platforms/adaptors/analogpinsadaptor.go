@@ -10,22 +10,49 @@ import (
 
 type analogPinTranslator func(pin string) (path string, w bool, readBufLen uint16, err error)
 
+// AnalogPinsOptionApplier needs to be implemented by each configurable option type
+type AnalogPinsOptionApplier interface {
+	apply(cfg *analogPinsConfiguration)
+}
+
+// analogPinsConfiguration contains all changeable attributes of the adaptor.
+type analogPinsConfiguration struct {
+	classDevices *ClassDevicesAdaptor
+}
+
+// analogPinsClassDevicesOption is the type for applying the adaptor of the class devices, which is used for the pins
+// with the prefix "hwmon:".
+type analogPinsClassDevicesOption struct {
+	devices *ClassDevicesAdaptor
+}
+
 // AnalogPinsAdaptor is a adaptor for analog pins, normally used for composition in platforms.
 // It is also usable for general sysfs access.
 type AnalogPinsAdaptor struct {
-	sys       *system.Accesser
-	translate analogPinTranslator
-	pins      map[string]gobot.AnalogPinner
-	mutex     sync.Mutex
+	sys           *system.Accesser
+	translate     analogPinTranslator
+	analogPinsCfg *analogPinsConfiguration
+	pins          map[string]gobot.AnalogPinner
+	mutex         sync.Mutex
 }
 
 // NewAnalogPinsAdaptor provides the access to analog pins of the board. Usually sysfs system drivers are used.
 // The translator is used to adapt the pin header naming, which is given by user, to the internal file name
-// nomenclature. This varies by each platform.
-func NewAnalogPinsAdaptor(sys *system.Accesser, t analogPinTranslator) *AnalogPinsAdaptor {
+// nomenclature. This varies by each platform. Hardware monitoring sensors are supported by the option
+// "WithAnalogClassDevices()".
+func NewAnalogPinsAdaptor(
+	sys *system.Accesser,
+	t analogPinTranslator,
+	opts ...AnalogPinsOptionApplier,
+) *AnalogPinsAdaptor {
 	a := AnalogPinsAdaptor{
-		sys:       sys,
-		translate: t,
+		sys:           sys,
+		translate:     t,
+		analogPinsCfg: &analogPinsConfiguration{},
+	}
+
+	for _, o := range opts {
+		o.apply(a.analogPinsCfg)
 	}
 
 	sys.AddAnalogSupport()
@@ -33,6 +60,12 @@ func NewAnalogPinsAdaptor(sys *system.Accesser, t analogPinTranslator) *AnalogPi
 	return &a
 }
 
+// WithAnalogClassDevices sets the adaptor of the class devices, which is used for reading the pins with the prefix
+// "hwmon:". This is normally done by the platform, e.g. with its class devices adaptor.
+func WithAnalogClassDevices(devices *ClassDevicesAdaptor) analogPinsClassDevicesOption {
+	return analogPinsClassDevicesOption{devices: devices}
+}
+
 // Connect prepare new connection to analog pins.
 func (a *AnalogPinsAdaptor) Connect() error {
 	a.mutex.Lock()
@@ -51,8 +84,14 @@ func (a *AnalogPinsAdaptor) Finalize() error {
 	return nil
 }
 
-// AnalogRead returns an analog value from specified pin or identifier, defined by the translation function.
+// AnalogRead returns an analog value from specified pin or identifier, defined by the translation function. Hardware
+// monitoring sensors are supported by the prefix "hwmon:" and returned unscaled, if the class devices are given by the
+// option "WithAnalogClassDevices()".
 func (a *AnalogPinsAdaptor) AnalogRead(id string) (int, error) {
+	if ok, val, err := a.analogPinsCfg.classDevices.analogRead(id); ok {
+		return val, err
+	}
+
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
 
@@ -96,3 +135,11 @@ func (a *AnalogPinsAdaptor) analogPin(id string) (gobot.AnalogPinner, error) {
 
 	return pin, nil
 }
+
+func (o analogPinsClassDevicesOption) String() string {
+	return "class devices for hwmon pins option for analog pins"
+}
+
+func (o analogPinsClassDevicesOption) apply(cfg *analogPinsConfiguration) {
+	cfg.classDevices = o.devices
+}
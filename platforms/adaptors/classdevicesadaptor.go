@@ -0,0 +1,86 @@
+package adaptors
+
+import (
+	multierror "github.com/hashicorp/go-multierror"
+
+	"gobot.io/x/gobot/v2/system"
+)
+
+// ClassDevicesAdaptor is a adaptor for Linux class devices, which are accessed like pins, normally used for composition
+// in platforms. It combines the LEDsAdaptor for pins with the prefix "led:" and the HwmonAdaptor for pins with the
+// prefix "hwmon:". The access to these pins is dispatched by the DigitalPinsAdaptor, the PWMPinsAdaptor and the
+// AnalogPinsAdaptor of the platform, when they are created with the options "WithDigitalPinClassDevices()",
+// "WithPWMClassDevices()" and "WithAnalogClassDevices()".
+type ClassDevicesAdaptor struct {
+	*LEDsAdaptor
+	*HwmonAdaptor
+}
+
+// NewClassDevicesAdaptor provides the access to LED class devices and hardware monitoring devices of the board.
+func NewClassDevicesAdaptor(sys *system.Accesser) *ClassDevicesAdaptor {
+	return &ClassDevicesAdaptor{
+		LEDsAdaptor:  NewLEDsAdaptor(sys),
+		HwmonAdaptor: NewHwmonAdaptor(sys),
+	}
+}
+
+// Connect prepares the connection to LED class devices and hardware monitoring devices.
+func (a *ClassDevicesAdaptor) Connect() error {
+	if err := a.LEDsAdaptor.Connect(); err != nil {
+		return err
+	}
+
+	return a.HwmonAdaptor.Connect()
+}
+
+// Finalize restores the initial state of all used LED class devices and hardware monitoring devices.
+func (a *ClassDevicesAdaptor) Finalize() error {
+	var err error
+	if e := a.LEDsAdaptor.Finalize(); e != nil {
+		err = multierror.Append(err, e)
+	}
+
+	if e := a.HwmonAdaptor.Finalize(); e != nil {
+		err = multierror.Append(err, e)
+	}
+
+	return err
+}
+
+// digitalWrite writes the value to the given LED and returns true, if the pin is a LED. A nil adaptor handles no pin.
+func (a *ClassDevicesAdaptor) digitalWrite(id string, val byte) (bool, error) {
+	if a == nil || !a.IsLEDPin(id) {
+		return false, nil
+	}
+
+	return true, a.LEDsAdaptor.DigitalWrite(id, val)
+}
+
+// pwmWrite writes the value to the given LED or hwmon PWM attribute and returns true, if the pin is one of them. A nil
+// adaptor handles no pin.
+func (a *ClassDevicesAdaptor) pwmWrite(id string, val byte) (bool, error) {
+	if a == nil {
+		return false, nil
+	}
+
+	if a.IsLEDPin(id) {
+		return true, a.LEDsAdaptor.PwmWrite(id, val)
+	}
+
+	if a.IsHwmonPin(id) {
+		return true, a.HwmonAdaptor.PwmWrite(id, val)
+	}
+
+	return false, nil
+}
+
+// analogRead reads the value of the given hwmon attribute and returns true, if the pin is a hwmon attribute. A nil
+// adaptor handles no pin.
+func (a *ClassDevicesAdaptor) analogRead(id string) (bool, int, error) {
+	if a == nil || !a.IsHwmonPin(id) {
+		return false, 0, nil
+	}
+
+	val, err := a.HwmonAdaptor.AnalogRead(id)
+	return true, val, err
+}
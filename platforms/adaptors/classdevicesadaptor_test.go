@@ -0,0 +1,94 @@
+package adaptors
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2/system"
+)
+
+func initTestClassDevicesAdaptorWithMockedFilesystem() (*ClassDevicesAdaptor, *system.Accesser,
+	*system.MockFilesystem,
+) {
+	sys := system.NewAccesser()
+	fs := sys.UseMockFilesystem(append(append([]string{}, ledMockPaths...), hwmonMockPaths...))
+	a := NewClassDevicesAdaptor(sys)
+	fs.Files[ledTestPath+"/brightness"].Contents = "3"
+	fs.Files[ledTestPath+"/max_brightness"].Contents = "255"
+	fs.Files[ledTestPath+"/trigger"].Contents = "none [heartbeat] timer"
+	fs.Files[hwmonTestThermalPath+"/name"].Contents = "cpu_thermal"
+	fs.Files[hwmonTestThermalPath+"/temp1_input"].Contents = "51540"
+	fs.Files[hwmonTestFanPath+"/name"].Contents = "pwmfan"
+	fs.Files[hwmonTestFanPath+"/pwm1_enable"].Contents = "2"
+	if err := a.Connect(); err != nil {
+		panic(err)
+	}
+	return a, sys, fs
+}
+
+func TestClassDevicesConnectFinalize(t *testing.T) {
+	// arrange
+	a, _, fs := initTestClassDevicesAdaptorWithMockedFilesystem()
+	assert.NotNil(t, a.leds)
+	assert.NotNil(t, a.devices)
+	require.NoError(t, a.LEDsAdaptor.PwmWrite("led:ACT", 51))
+	require.NoError(t, a.HwmonAdaptor.PwmWrite("hwmon:pwmfan/pwm1", 150))
+	// act
+	err := a.Finalize()
+	// assert
+	require.NoError(t, err)
+	assert.Nil(t, a.leds)
+	assert.Nil(t, a.devices)
+	assert.Equal(t, "heartbeat", fs.Files[ledTestPath+"/trigger"].Contents)
+	assert.Equal(t, "2", fs.Files[hwmonTestFanPath+"/pwm1_enable"].Contents)
+}
+
+func TestClassDevicesDispatch(t *testing.T) {
+	// arrange
+	a, sys, fs := initTestClassDevicesAdaptorWithMockedFilesystem()
+	translateErr := fmt.Errorf("translated")
+	dpa := NewDigitalPinsAdaptor(sys, func(string) (string, int, error) { return "", 0, translateErr },
+		WithDigitalPinClassDevices(a))
+	ppa := NewPWMPinsAdaptor(sys, func(string) (string, int, error) { return "", 0, translateErr },
+		WithPWMClassDevices(a))
+	apa := NewAnalogPinsAdaptor(sys, func(string) (string, bool, uint16, error) { return "", false, 0, translateErr },
+		WithAnalogClassDevices(a))
+	require.NoError(t, dpa.Connect())
+	require.NoError(t, ppa.Connect())
+	require.NoError(t, apa.Connect())
+	// act & assert
+	require.NoError(t, dpa.DigitalWrite("led:ACT", 1))
+	assert.Equal(t, "255", fs.Files[ledTestPath+"/brightness"].Contents)
+	require.NoError(t, ppa.PwmWrite("led:ACT", 51))
+	assert.Equal(t, "51", fs.Files[ledTestPath+"/brightness"].Contents)
+	require.NoError(t, ppa.PwmWrite("hwmon:pwmfan/pwm1", 150))
+	assert.Equal(t, "150", fs.Files[hwmonTestFanPath+"/pwm1"].Contents)
+	got, err := apa.AnalogRead("hwmon:cpu_thermal/temp1_input")
+	require.NoError(t, err)
+	assert.Equal(t, 51540, got)
+	// act & assert, that all other pins are translated
+	require.ErrorIs(t, dpa.DigitalWrite("hwmon:pwmfan/pwm1", 1), translateErr)
+	require.ErrorIs(t, ppa.PwmWrite("7", 1), translateErr)
+	_, err = apa.AnalogRead("led:ACT")
+	require.ErrorIs(t, err, translateErr)
+}
+
+func TestClassDevicesDispatchWithoutOption(t *testing.T) {
+	// arrange
+	sys := system.NewAccesser()
+	translateErr := fmt.Errorf("translated")
+	dpa := NewDigitalPinsAdaptor(sys, func(string) (string, int, error) { return "", 0, translateErr })
+	ppa := NewPWMPinsAdaptor(sys, func(string) (string, int, error) { return "", 0, translateErr })
+	apa := NewAnalogPinsAdaptor(sys, func(string) (string, bool, uint16, error) { return "", false, 0, translateErr })
+	require.NoError(t, dpa.Connect())
+	require.NoError(t, ppa.Connect())
+	require.NoError(t, apa.Connect())
+	// act & assert
+	require.ErrorIs(t, dpa.DigitalWrite("led:ACT", 1), translateErr)
+	require.ErrorIs(t, ppa.PwmWrite("hwmon:pwmfan/pwm1", 1), translateErr)
+	_, err := apa.AnalogRead("hwmon:cpu_thermal/temp1_input")
+	require.ErrorIs(t, err, translateErr)
+}
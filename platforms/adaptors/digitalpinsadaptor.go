@@ -2,6 +2,8 @@ package adaptors
 
 import (
 	"fmt"
+	"io"
+	"strings"
 	"sync"
 	"time"
 
@@ -22,6 +24,7 @@ type digitalPinsConfiguration struct {
 	initialize    digitalPinInitializer
 	systemOptions []system.AccesserOptionApplier
 	pinOptions    map[string][]func(gobot.DigitalPinOptioner) bool
+	classDevices  *ClassDevicesAdaptor
 }
 
 // DigitalPinsAdaptor is a adaptor for digital pins, normally used for composition in platforms.
@@ -30,6 +33,8 @@ type DigitalPinsAdaptor struct {
 	digitalPinsCfg *digitalPinsConfiguration
 	translate      digitalPinTranslator
 	pins           map[string]gobot.DigitalPinner
+	pinGroups      map[string]gobot.DigitalPinGrouper
+	groupedPins    map[string]string // pin id => key of the group
 	mutex          sync.Mutex
 }
 
@@ -158,6 +163,20 @@ func WithGpioPollForEdgeDetection(
 	return digitalPinsPollForEdgeDetectionOption{id: pin, pollInterval: pollInterval, pollQuitChan: pollQuitChan}
 }
 
+// WithTracer activates the tracing of all digital pins, pin groups, PWM pins, I2C and SPI devices of the platform. The
+// records can be read from the given tracer or exported, e.g. as VCD file. The option is applied to the system accesser,
+// which is shared by all adaptors of the platform. Because it is an option of the digital pins adaptor, it can only be
+// given to platforms, which contain this adaptor (not e.g. the Intel Edison).
+func WithTracer(tracer *system.Tracer) digitalPinsTracerOption {
+	return digitalPinsTracerOption{tracer: tracer}
+}
+
+// WithDigitalPinClassDevices sets the adaptor of the class devices, which is used for writing the pins with the prefix
+// "led:". This is normally done by the platform, e.g. with its class devices adaptor.
+func WithDigitalPinClassDevices(devices *ClassDevicesAdaptor) digitalPinsClassDevicesOption {
+	return digitalPinsClassDevicesOption{devices: devices}
+}
+
 // Connect prepare new connection to digital pins.
 func (a *DigitalPinsAdaptor) Connect() error {
 	a.mutex.Lock()
@@ -172,6 +191,8 @@ func (a *DigitalPinsAdaptor) Connect() error {
 	}
 
 	a.pins = make(map[string]gobot.DigitalPinner)
+	a.pinGroups = make(map[string]gobot.DigitalPinGrouper)
+	a.groupedPins = make(map[string]string)
 
 	return nil
 }
@@ -193,7 +214,14 @@ func (a *DigitalPinsAdaptor) Finalize() error {
 			}
 		}
 	}
+	for _, group := range a.pinGroups {
+		if e := group.Unexport(); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
 	a.pins = nil
+	a.pinGroups = nil
+	a.groupedPins = nil
 
 	return err
 }
@@ -207,6 +235,22 @@ func (a *DigitalPinsAdaptor) DigitalPin(id string) (gobot.DigitalPinner, error)
 	return a.digitalPin(id)
 }
 
+// UnexportDigitalPin releases the given pin, so it is free for the operating system. On next usage the pin is acquired
+// again. Nothing is done for a pin, which is not in use.
+func (a *DigitalPinsAdaptor) UnexportDigitalPin(id string) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	pin := a.pins[id]
+	if pin == nil {
+		return nil
+	}
+
+	delete(a.pins, id)
+
+	return pin.Unexport()
+}
+
 // DigitalRead reads digital value from pin
 func (a *DigitalPinsAdaptor) DigitalRead(id string) (int, error) {
 	a.mutex.Lock()
@@ -219,8 +263,13 @@ func (a *DigitalPinsAdaptor) DigitalRead(id string) (int, error) {
 	return pin.Read()
 }
 
-// DigitalWrite writes digital value to specified pin
+// DigitalWrite writes digital value to specified pin. LED's are supported by the prefix "led:", if the class devices
+// are given by the option "WithDigitalPinClassDevices()".
 func (a *DigitalPinsAdaptor) DigitalWrite(id string, val byte) error {
+	if ok, err := a.digitalPinsCfg.classDevices.digitalWrite(id, val); ok {
+		return err
+	}
+
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
 
@@ -231,6 +280,181 @@ func (a *DigitalPinsAdaptor) DigitalWrite(id string, val byte) error {
 	return pin.Write(int(val))
 }
 
+// ClaimDigitalPin registers the given pin and the related line of the gpiochip for the given owner, usually the name
+// of the driver. An error is returned, if the pin or the line is already owned by another device, e.g. because the pin
+// is used by an active I2C bus.
+func (a *DigitalPinsAdaptor) ClaimDigitalPin(owner string, id string) error {
+	return a.sys.Ownerships().Claim(owner, digitalPinResources(a.translate, id)...)
+}
+
+// ReleaseDigitalPins removes all pins and lines of the given owner from the ownership registry.
+func (a *DigitalPinsAdaptor) ReleaseDigitalPins(owner string) {
+	a.sys.Ownerships().Release(owner, system.OwnershipKindPin, system.OwnershipKindDigitalPin)
+}
+
+// Ownerships returns the table of all owned resources of the platform, not only the digital pins. This can be used
+// for diagnostics.
+func (a *DigitalPinsAdaptor) Ownerships() []system.Ownership {
+	return a.sys.Ownerships().Table()
+}
+
+// GpioChips returns the information of all gpiochips of the board including the information of each line, e.g. the
+// consumer, which holds the line. This is similar to the output of "gpioinfo".
+func (a *DigitalPinsAdaptor) GpioChips() ([]gobot.GpioChipInfo, error) {
+	return a.sys.GpioChips()
+}
+
+// GpioLineInfo returns the information of the line of the given pin, e.g. the consumer, which holds the line. This
+// is only supported for the character device driver.
+func (a *DigitalPinsAdaptor) GpioLineInfo(id string) (gobot.GpioLineInfo, error) {
+	chip, line, err := a.translateForLineInfo(id)
+	if err != nil {
+		return gobot.GpioLineInfo{}, err
+	}
+
+	return a.sys.GpioLineInfo(chip, line)
+}
+
+// WatchGpioLineInfo calls the handler for each change of the line information of the given pin, e.g. when the line is
+// requested by another process. The watch is stopped by closing the returned watcher. This is only supported for the
+// character device driver.
+func (a *DigitalPinsAdaptor) WatchGpioLineInfo(id string, handler func(gobot.GpioLineInfoEvent)) (io.Closer, error) {
+	chip, line, err := a.translateForLineInfo(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.sys.WatchGpioLineInfo(chip, []int{line}, handler)
+}
+
+// DigitalPinGroup returns a group of digital pins, which can be read and written at once by a bitmask. The value of
+// the first pin is bit 0. With the character device driver and all pins on the same gpiochip, the pins are accessed
+// atomically by a single request. With the sysfs driver or for pins on different gpiochips, the pins are accessed one
+// by one in the given order. Options of each single pin are applied to the whole group. If the group is initially
+// acquired, all pins are inputs. The pins of a group can not be used as single pins.
+func (a *DigitalPinsAdaptor) DigitalPinGroup(ids ...string) (gobot.DigitalPinGrouper, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.digitalPinGroup(ids)
+}
+
+// DigitalGroupRead reads the values of the given pins at once as bitmask, the value of the first pin is bit 0.
+func (a *DigitalPinsAdaptor) DigitalGroupRead(ids []string) (uint64, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	group, err := a.digitalPinGroup(ids, system.WithPinDirectionInput())
+	if err != nil {
+		return 0, err
+	}
+	return group.Read()
+}
+
+// DigitalGroupWrite writes the given bitmask to the given pins at once, the value of the first pin is bit 0.
+func (a *DigitalPinsAdaptor) DigitalGroupWrite(ids []string, values uint64) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	group, err := a.digitalPinGroup(ids, system.WithPinGroupDirectionOutput(values))
+	if err != nil {
+		return err
+	}
+	return group.Write(values)
+}
+
+func (a *DigitalPinsAdaptor) digitalPinGroup(
+	ids []string,
+	opts ...func(gobot.DigitalPinOptioner) bool,
+) (gobot.DigitalPinGrouper, error) {
+	key := strings.Join(ids, ",")
+	if a.pinGroups == nil {
+		return nil, fmt.Errorf("not connected for pin group %s", key)
+	}
+
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("a pin group needs at least one pin")
+	}
+
+	var o []func(gobot.DigitalPinOptioner) bool
+	for _, id := range ids {
+		o = append(o, a.digitalPinsCfg.pinOptions[id]...)
+	}
+	o = append(o, opts...)
+
+	group := a.pinGroups[key]
+	if group != nil {
+		if err := group.ApplyOptions(o...); err != nil {
+			return nil, err
+		}
+		return group, nil
+	}
+
+	chips := make([]string, len(ids))
+	lines := make([]int, len(ids))
+	sameChip := true
+	for i, id := range ids {
+		if _, ok := a.pins[id]; ok {
+			return nil, fmt.Errorf("pin '%s' is already used as single pin, so it can not be used in group '%s'", id, key)
+		}
+		if otherKey, ok := a.groupedPins[id]; ok {
+			return nil, fmt.Errorf("pin '%s' is already used in group '%s', so it can not be used in group '%s'", id,
+				otherKey, key)
+		}
+		chip, line, err := a.translate(id)
+		if err != nil {
+			return nil, err
+		}
+		chips[i] = chip
+		lines[i] = line
+		sameChip = sameChip && chip == chips[0]
+	}
+
+	if sameChip || !a.sys.HasDigitalPinCdevAccess() {
+		group = a.sys.NewDigitalPinGroup(chips[0], lines, o...)
+	} else {
+		// the lines of different chips can not be requested together, so the pins are accessed one by one
+		group = a.sys.NewDigitalPinGroupSequential(chips, lines, o...)
+	}
+	if err := group.Export(); err != nil {
+		return nil, err
+	}
+	a.pinGroups[key] = group
+	for _, id := range ids {
+		a.groupedPins[id] = key
+	}
+
+	return group, nil
+}
+
+// digitalPinResources returns the pin and, if the pin can be translated, the related line of the gpiochip as resources
+// of the ownership registry. So a line is owned only once, also if it is claimed by different names, e.g. by the header
+// pin "3" and the line name "GPIO2".
+func digitalPinResources(translate digitalPinTranslator, id string) []system.OwnershipResource {
+	resources := []system.OwnershipResource{{Kind: system.OwnershipKindPin, Resource: id}}
+	if translate == nil {
+		return resources
+	}
+
+	if chip, line, err := translate(id); err == nil {
+		if chip == "" {
+			chip = "gpio"
+		}
+		resources = append(resources,
+			system.OwnershipResource{Kind: system.OwnershipKindDigitalPin, Resource: fmt.Sprintf("%s:%d", chip, line)})
+	}
+
+	return resources
+}
+
+func (a *DigitalPinsAdaptor) translateForLineInfo(id string) (string, int, error) {
+	if a.sys.HasDigitalPinSysfsAccess() {
+		return "", -1, fmt.Errorf("the line info of pin '%s' is not supported by the sysfs driver", id)
+	}
+
+	return a.translate(id)
+}
+
 func (a *DigitalPinsAdaptor) digitalPin(
 	id string,
 	opts ...func(gobot.DigitalPinOptioner) bool,
@@ -239,6 +463,10 @@ func (a *DigitalPinsAdaptor) digitalPin(
 		return nil, fmt.Errorf("not connected for pin %s", id)
 	}
 
+	if key, ok := a.groupedPins[id]; ok {
+		return nil, fmt.Errorf("pin '%s' is already used in group '%s', so it can not be used as single pin", id, key)
+	}
+
 	o := append(a.digitalPinsCfg.pinOptions[id], opts...)
 	pin := a.pins[id]
 
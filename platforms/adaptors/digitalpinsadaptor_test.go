@@ -21,6 +21,9 @@ var (
 	_ gobot.DigitalPinnerProvider = (*DigitalPinsAdaptor)(nil)
 	_ gpio.DigitalReader          = (*DigitalPinsAdaptor)(nil)
 	_ gpio.DigitalWriter          = (*DigitalPinsAdaptor)(nil)
+	_ gpio.DigitalGroupReader     = (*DigitalPinsAdaptor)(nil)
+	_ gpio.DigitalGroupWriter     = (*DigitalPinsAdaptor)(nil)
+	_ gpio.DigitalPinClaimer      = (*DigitalPinsAdaptor)(nil)
 )
 
 func initTestConnectedDigitalPinsAdaptorWithMockedFilesystem(
@@ -182,6 +185,107 @@ func TestDigitalRead(t *testing.T) {
 	require.ErrorContains(t, err, "write error")
 }
 
+func TestUnexportDigitalPin(t *testing.T) {
+	// arrange
+	mockedPaths := []string{
+		"/sys/class/gpio/export",
+		"/sys/class/gpio/unexport",
+		"/sys/class/gpio/gpio25/value",
+		"/sys/class/gpio/gpio25/direction",
+	}
+	a, fs := initTestConnectedDigitalPinsAdaptorWithMockedFilesystem(mockedPaths)
+	require.NoError(t, a.DigitalWrite("14", 1))
+	// act
+	err := a.UnexportDigitalPin("14")
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "25", fs.Files["/sys/class/gpio/unexport"].Contents)
+	assert.NotContains(t, a.pins, "14")
+	// a pin, which is not in use, is ignored
+	require.NoError(t, a.UnexportDigitalPin("15"))
+	// the pin is acquired again on next usage
+	require.NoError(t, a.DigitalWrite("14", 0))
+	assert.Contains(t, a.pins, "14")
+}
+
+func TestDigitalGroupWriteAndRead(t *testing.T) {
+	// arrange
+	mockedPaths := []string{
+		"/sys/class/gpio/export",
+		"/sys/class/gpio/unexport",
+		"/sys/class/gpio/gpio12/value",
+		"/sys/class/gpio/gpio12/direction",
+		"/sys/class/gpio/gpio13/value",
+		"/sys/class/gpio/gpio13/direction",
+	}
+	a, fs := initTestConnectedDigitalPinsAdaptorWithMockedFilesystem(mockedPaths)
+	// act
+	err := a.DigitalGroupWrite([]string{"1", "2"}, 0x02)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "out", fs.Files["/sys/class/gpio/gpio12/direction"].Contents)
+	assert.Equal(t, "0", fs.Files["/sys/class/gpio/gpio12/value"].Contents)
+	assert.Equal(t, "1", fs.Files["/sys/class/gpio/gpio13/value"].Contents)
+	// arrange
+	fs.Files["/sys/class/gpio/gpio12/value"].Contents = "1"
+	fs.Files["/sys/class/gpio/gpio13/value"].Contents = "0"
+	// act
+	got, err := a.DigitalGroupRead([]string{"1", "2"})
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0x01), got)
+	assert.Equal(t, "in", fs.Files["/sys/class/gpio/gpio13/direction"].Contents)
+	// act
+	err = a.Finalize()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "13", fs.Files["/sys/class/gpio/unexport"].Contents)
+}
+
+func TestDigitalPinGroupConflicts(t *testing.T) {
+	// arrange
+	a, _ := initTestConnectedDigitalPinsAdaptorWithMockedFilesystem([]string{})
+	dpa := a.sys.UseMockDigitalPinAccess()
+	_, err := a.DigitalPin("1")
+	require.NoError(t, err)
+	_, err = a.DigitalPinGroup("2", "3")
+	require.NoError(t, err)
+	// act & assert
+	_, err = a.DigitalPinGroup("1", "4")
+	require.EqualError(t, err, "pin '1' is already used as single pin, so it can not be used in group '1,4'")
+	_, err = a.DigitalPinGroup("4", "3")
+	require.EqualError(t, err, "pin '3' is already used in group '2,3', so it can not be used in group '4,3'")
+	_, err = a.DigitalPin("2")
+	require.EqualError(t, err, "pin '2' is already used in group '2,3', so it can not be used as single pin")
+	_, err = a.DigitalPinGroup()
+	require.EqualError(t, err, "a pin group needs at least one pin")
+	// the group is re-used
+	require.NoError(t, a.DigitalGroupWrite([]string{"2", "3"}, 0x03))
+	assert.Equal(t, []int{1}, dpa.Written("", "14"))
+	assert.Equal(t, 1, dpa.Exported("", "14"))
+}
+
+func TestDigitalPinGroupDifferentChips(t *testing.T) {
+	// arrange
+	translate := func(pin string) (string, int, error) { return "gpiochip" + pin, 1, nil }
+	sys := system.NewAccesser()
+	sys.UseMockFilesystem([]string{"/dev/gpiochip0"})
+	a := NewDigitalPinsAdaptor(sys, translate)
+	require.NoError(t, a.Connect())
+	dpa := sys.UseMockDigitalPinAccess()
+	// act
+	errWrite := a.DigitalGroupWrite([]string{"0", "1"}, 0x02)
+	dpa.UseValues("gpiochip0", "1", []int{1})
+	dpa.UseValues("gpiochip1", "1", []int{0})
+	got, errRead := a.DigitalGroupRead([]string{"0", "1"})
+	// assert
+	require.NoError(t, errWrite)
+	assert.Equal(t, []int{0}, dpa.Written("gpiochip0", "1"))
+	assert.Equal(t, []int{1}, dpa.Written("gpiochip1", "1"))
+	require.NoError(t, errRead)
+	assert.Equal(t, uint64(0x01), got)
+}
+
 func TestDigitalPinConcurrency(t *testing.T) {
 	oldProcs := runtime.GOMAXPROCS(0)
 	runtime.GOMAXPROCS(8)
@@ -208,3 +312,74 @@ func TestDigitalPinConcurrency(t *testing.T) {
 		wg.Wait()
 	}
 }
+
+func TestDigitalPinsClaimDigitalPin(t *testing.T) {
+	// arrange
+	a, _ := initTestDigitalPinsAdaptorWithMockedFilesystem(nil)
+	require.NoError(t, a.ClaimDigitalPin("LED", "4"))
+	// act: same line by another pin id
+	err := a.sys.Ownerships().Claim("Button", system.OwnershipResource{
+		Kind:     system.OwnershipKindDigitalPin,
+		Resource: "gpio:15",
+	})
+	// assert
+	require.EqualError(t, err, "'Button' can not claim gpio 'gpio:15', because it is already owned by 'LED'")
+	require.EqualError(t, a.ClaimDigitalPin("Button", "4"),
+		"'Button' can not claim pin '4', because it is already owned by 'LED'")
+	// the pin is kept, if the id can not be translated
+	require.NoError(t, a.ClaimDigitalPin("Relay", "x"))
+	want := []system.Ownership{
+		{OwnershipResource: system.OwnershipResource{Kind: system.OwnershipKindDigitalPin, Resource: "gpio:15"}, Owner: "LED"},
+		{OwnershipResource: system.OwnershipResource{Kind: system.OwnershipKindPin, Resource: "4"}, Owner: "LED"},
+		{OwnershipResource: system.OwnershipResource{Kind: system.OwnershipKindPin, Resource: "x"}, Owner: "Relay"},
+	}
+	assert.Equal(t, want, a.Ownerships())
+	// act
+	a.ReleaseDigitalPins("LED")
+	// assert
+	require.NoError(t, a.ClaimDigitalPin("Button", "4"))
+	assert.Len(t, a.Ownerships(), 3)
+}
+
+func TestDigitalPinsGpioLineInfo(t *testing.T) {
+	// arrange
+	a := NewDigitalPinsAdaptor(system.NewAccesser(), testDigitalPinTranslator)
+	require.NoError(t, a.Connect())
+	gia := a.sys.UseMockGpioInfo()
+	lines := make([]gobot.GpioLineInfo, 16)
+	for i := range lines {
+		lines[i] = gobot.GpioLineInfo{Chip: "gpiochip0", Offset: i, Direction: "input"}
+	}
+	lines[15] = gobot.GpioLineInfo{Chip: "gpiochip0", Offset: 15, Consumer: "other-process", Used: true}
+	gia.Chips = []gobot.GpioChipInfo{{Name: "gpiochip0", Label: "pinctrl", Lines: lines}}
+	// act
+	chips, err := a.GpioChips()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, gia.Chips, chips)
+	// act: pin 4 is translated to line 15
+	info, err := a.GpioLineInfo("4")
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "other-process", info.Consumer)
+	// act & assert
+	_, err = a.GpioLineInfo("x")
+	require.EqualError(t, err, "not a valid pin")
+	var events []gobot.GpioLineInfoEvent
+	watcher, err := a.WatchGpioLineInfo("4", func(evt gobot.GpioLineInfoEvent) { events = append(events, evt) })
+	require.NoError(t, err)
+	gia.Emit(gobot.GpioLineInfoEvent{Type: "released", Info: lines[15]})
+	assert.Len(t, events, 1)
+	require.NoError(t, watcher.Close())
+}
+
+func TestDigitalPinsGpioLineInfoSysfs(t *testing.T) {
+	// arrange
+	a, _ := initTestConnectedDigitalPinsAdaptorWithMockedFilesystem(nil)
+	// act
+	_, err := a.GpioLineInfo("4")
+	// assert
+	require.EqualError(t, err, "the line info of pin '4' is not supported by the sysfs driver")
+	_, err = a.WatchGpioLineInfo("4", func(gobot.GpioLineInfoEvent) {})
+	require.EqualError(t, err, "the line info of pin '4' is not supported by the sysfs driver")
+}
@@ -23,6 +23,17 @@ type digitalPinsInitializeOption digitalPinInitializer
 // sysfs Kernel ABI
 type digitalPinsSystemSysfsOption bool
 
+// digitalPinsTracerOption is the type to activate the tracing of all devices of the system accesser
+type digitalPinsTracerOption struct {
+	tracer *system.Tracer
+}
+
+// digitalPinsClassDevicesOption is the type for applying the adaptor of the class devices, which is used for the pins
+// with the prefix "led:"
+type digitalPinsClassDevicesOption struct {
+	devices *ClassDevicesAdaptor
+}
+
 // digitalPinsActiveLowOption is the type to prepare the given pins for inverse reaction on next initialize
 type digitalPinsActiveLowOption []string
 
@@ -129,6 +140,14 @@ func (o digitalPinsPollForEdgeDetectionOption) String() string {
 	return "discrete polling function for edge detection on digital pin option"
 }
 
+func (o digitalPinsTracerOption) String() string {
+	return "tracing of system devices option"
+}
+
+func (o digitalPinsClassDevicesOption) String() string {
+	return "class devices for LED pins option"
+}
+
 func (o digitalPinsDebugOption) apply(cfg *digitalPinsConfiguration) {
 	cfg.debug = bool(o)
 	cfg.systemOptions = append(cfg.systemOptions, system.WithDigitalPinDebug())
@@ -148,6 +167,14 @@ func (o digitalPinsSystemSysfsOption) apply(cfg *digitalPinsConfiguration) {
 	}
 }
 
+func (o digitalPinsTracerOption) apply(cfg *digitalPinsConfiguration) {
+	cfg.systemOptions = append(cfg.systemOptions, system.WithTracer(o.tracer))
+}
+
+func (o digitalPinsClassDevicesOption) apply(cfg *digitalPinsConfiguration) {
+	cfg.classDevices = o.devices
+}
+
 func (o digitalPinsActiveLowOption) apply(cfg *digitalPinsConfiguration) {
 	for _, pin := range o {
 		cfg.pinOptions[pin] = append(cfg.pinOptions[pin], system.WithPinActiveLow())
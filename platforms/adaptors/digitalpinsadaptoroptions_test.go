@@ -179,3 +179,23 @@ func gpioTestEventHandler(o int, t time.Duration, et string, sn uint32, lsn uint
 	// the handler should never execute, because used in outputs and not supported by sysfs
 	panic(fmt.Sprintf("event handler was called (%d, %d) unexpected for line %d with '%s' at %s!", sn, lsn, o, t, et))
 }
+
+func TestDigitalPinsWithTracer(t *testing.T) {
+	// arrange
+	tracer := system.NewTracer(0)
+	a := NewDigitalPinsAdaptor(system.NewAccesser(), testDigitalPinTranslator, WithTracer(tracer))
+	require.NoError(t, a.Connect())
+	dpa := a.sys.UseMockDigitalPinAccess()
+	// act
+	err := a.DigitalWrite("1", 1)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, dpa.Written("", "12"))
+	records := tracer.Records()
+	require.NotEmpty(t, records)
+	last := records[len(records)-1]
+	assert.Equal(t, system.TraceKindDigitalPin, last.Kind)
+	assert.Equal(t, "gpio:12", last.Device)
+	assert.Equal(t, "Write", last.Operation)
+	assert.Equal(t, int64(1), last.Value)
+}
@@ -2,6 +2,7 @@ package adaptors
 
 import (
 	"fmt"
+	"sync"
 
 	"gobot.io/x/gobot/v2/system"
 )
@@ -21,6 +22,15 @@ type DigitalPinDefinitions map[string]DigitalPinDefinition
 type DigitalPinTranslator struct {
 	sys            *system.Accesser
 	pinDefinitions DigitalPinDefinitions
+	mutex          sync.Mutex
+	chipLineNames  []chipLineNames // cached on first lookup of a line name
+	chipLineErr    error           // cached, if the line names can not be read on first lookup
+}
+
+// chipLineNames maps the line names of a gpiochip to the line
+type chipLineNames struct {
+	chip  string
+	lines map[string]int
 }
 
 // NewDigitalPinTranslator creates a new instance of a translator for digital GPIO pins, suitable for the most cases.
@@ -28,10 +38,17 @@ func NewDigitalPinTranslator(sys *system.Accesser, pinDefinitions DigitalPinDefi
 	return &DigitalPinTranslator{sys: sys, pinDefinitions: pinDefinitions}
 }
 
-// Translate returns the chip and the line or for legacy sysfs usage the pin number from the given id.
+// Translate returns the chip and the line or for legacy sysfs usage the pin number from the given id. For the
+// character device driver, an id which is not part of the pin definitions is looked up in the line names of all
+// gpiochips, e.g. "GPIO17", see [DigitalPinTranslator.TranslateLineName].
 func (pt *DigitalPinTranslator) Translate(id string) (string, int, error) {
 	pindef, ok := pt.pinDefinitions[id]
 	if !ok {
+		if !pt.sys.HasDigitalPinSysfsAccess() {
+			if chip, line, err := pt.TranslateLineName(id); err == nil {
+				return chip, line, nil
+			}
+		}
 		return "", -1, fmt.Errorf("'%s' is not a valid id for a digital pin", id)
 	}
 	if pt.sys.HasDigitalPinSysfsAccess() {
@@ -41,3 +58,43 @@ func (pt *DigitalPinTranslator) Translate(id string) (string, int, error) {
 	line := int(pindef.Cdev.Line)
 	return chip, line, nil
 }
+
+// TranslateLineName returns the chip and the line of the given line name, e.g. "GPIO17". If the name is used by
+// multiple lines, the first line of the gpiochip with the lowest number is returned. The line names of all gpiochips
+// are read once on the first call and cached afterwards. If the line names can not be read, the error is cached too,
+// so each later call fails without accessing the gpiochips again.
+func (pt *DigitalPinTranslator) TranslateLineName(name string) (string, int, error) {
+	pt.mutex.Lock()
+	defer pt.mutex.Unlock()
+
+	if pt.chipLineErr != nil {
+		return "", -1, pt.chipLineErr
+	}
+
+	if pt.chipLineNames == nil {
+		chips, err := pt.sys.GpioChips()
+		if err != nil {
+			pt.chipLineErr = err
+			return "", -1, err
+		}
+
+		pt.chipLineNames = make([]chipLineNames, 0, len(chips))
+		for _, chip := range chips {
+			lines := make(map[string]int, len(chip.Lines))
+			for _, line := range chip.Lines {
+				if _, ok := lines[line.Name]; !ok && line.Name != "" {
+					lines[line.Name] = line.Offset
+				}
+			}
+			pt.chipLineNames = append(pt.chipLineNames, chipLineNames{chip: chip.Name, lines: lines})
+		}
+	}
+
+	for _, names := range pt.chipLineNames {
+		if line, ok := names.lines[name]; ok {
+			return names.chip, line, nil
+		}
+	}
+
+	return "", -1, fmt.Errorf("no GPIO line found with name '%s'", name)
+}
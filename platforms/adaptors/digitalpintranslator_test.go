@@ -5,7 +5,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"gobot.io/x/gobot/v2"
 	"gobot.io/x/gobot/v2/system"
 )
 
@@ -91,3 +93,110 @@ func TestDigitalPinTranslatorTranslate(t *testing.T) {
 		})
 	}
 }
+
+func TestDigitalPinTranslatorTranslateLineName(t *testing.T) {
+	pinDefinitions := DigitalPinDefinitions{
+		"7": {Sysfs: 17, Cdev: CdevPin{Chip: 0, Line: 17}},
+	}
+	tests := map[string]struct {
+		access   system.AccesserOptionApplier
+		pin      string
+		wantChip string
+		wantLine int
+		wantErr  string
+	}{
+		"cdev_definition_first": {
+			pin:      "7",
+			wantChip: "gpiochip0",
+			wantLine: 17,
+		},
+		"cdev_line_name": {
+			pin:      "GPIO27",
+			wantChip: "gpiochip1",
+			wantLine: 1,
+		},
+		"sysfs_line_name_not_supported": {
+			access:   system.WithDigitalPinSysfsAccess(),
+			pin:      "GPIO27",
+			wantLine: -1,
+			wantErr:  "'GPIO27' is not a valid id for a digital pin",
+		},
+		"cdev_unknown_line_name": {
+			pin:      "GPIO99",
+			wantLine: -1,
+			wantErr:  "'GPIO99' is not a valid id for a digital pin",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			sys := system.NewAccesser()
+			sys.UseMockFilesystem([]string{"/dev/gpiochip"})
+			sys.AddDigitalPinSupport(tc.access)
+			sys.UseMockGpioInfo().Chips = []gobot.GpioChipInfo{
+				{Name: "gpiochip0", Lines: []gobot.GpioLineInfo{{Chip: "gpiochip0", Offset: 0, Name: "ID_SD"}}},
+				{Name: "gpiochip1", Lines: []gobot.GpioLineInfo{
+					{Chip: "gpiochip1", Offset: 0, Name: "GPIO26"},
+					{Chip: "gpiochip1", Offset: 1, Name: "GPIO27"},
+				}},
+			}
+			pt := NewDigitalPinTranslator(sys, pinDefinitions)
+			// act
+			chip, line, err := pt.Translate(tc.pin)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantChip, chip)
+			assert.Equal(t, tc.wantLine, line)
+		})
+	}
+}
+
+func TestDigitalPinTranslatorTranslateLineNameCached(t *testing.T) {
+	// arrange
+	sys := system.NewAccesser()
+	sys.UseMockFilesystem([]string{"/dev/gpiochip"})
+	sys.AddDigitalPinSupport()
+	gia := sys.UseMockGpioInfo()
+	gia.Chips = []gobot.GpioChipInfo{
+		{Name: "gpiochip0", Lines: []gobot.GpioLineInfo{{Chip: "gpiochip0", Offset: 17, Name: "GPIO17"}}},
+		{Name: "gpiochip4", Lines: []gobot.GpioLineInfo{{Chip: "gpiochip4", Offset: 3, Name: "GPIO17"}}},
+	}
+	pt := NewDigitalPinTranslator(sys, nil)
+	// act & assert
+	chip, line, err := pt.TranslateLineName("GPIO17")
+	require.NoError(t, err)
+	assert.Equal(t, "gpiochip0", chip)
+	assert.Equal(t, 17, line)
+	// act & assert: the line names are not read again
+	gia.ChipsError = true
+	chip, line, err = pt.TranslateLineName("GPIO17")
+	require.NoError(t, err)
+	assert.Equal(t, "gpiochip0", chip)
+	assert.Equal(t, 17, line)
+	_, _, err = pt.TranslateLineName("GPIO99")
+	require.EqualError(t, err, "no GPIO line found with name 'GPIO99'")
+}
+
+func TestDigitalPinTranslatorTranslateLineNameErrorCached(t *testing.T) {
+	// arrange
+	sys := system.NewAccesser()
+	sys.UseMockFilesystem([]string{"/dev/gpiochip"})
+	sys.AddDigitalPinSupport()
+	gia := sys.UseMockGpioInfo()
+	gia.ChipsError = true
+	pt := NewDigitalPinTranslator(sys, nil)
+	// act & assert
+	_, _, err := pt.TranslateLineName("GPIO17")
+	require.EqualError(t, err, "chips error")
+	// act & assert: the line names are not read again
+	gia.ChipsError = false
+	gia.Chips = []gobot.GpioChipInfo{
+		{Name: "gpiochip0", Lines: []gobot.GpioLineInfo{{Chip: "gpiochip0", Offset: 17, Name: "GPIO17"}}},
+	}
+	_, _, err = pt.TranslateLineName("GPIO17")
+	require.EqualError(t, err, "chips error")
+}
@@ -0,0 +1,167 @@
+package adaptors
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	"gobot.io/x/gobot/v2/system"
+)
+
+const (
+	// hwmonPinPrefix is used to identify the pins of hardware monitoring devices, e.g. "hwmon:cpu_thermal/temp1_input"
+	hwmonPinPrefix = "hwmon:"
+	// hwmonPwmManualMode is the value of "pwm<n>_enable" for manual fan speed control
+	hwmonPwmManualMode = 1
+)
+
+var hwmonPwmRegexp = regexp.MustCompile(`^pwm[0-9]+$`)
+
+type hwmonPwmState struct {
+	device     *system.HwmonDevice
+	attribute  string
+	initEnable int
+	hasEnable  bool
+}
+
+// HwmonAdaptor is a adaptor for Linux hardware monitoring devices (e.g. temperatures, voltages, fans), normally used
+// for composition in platforms. The sensor attributes are accessed like analog pins by the device name or id and the
+// attribute with prefix "hwmon:", e.g. "hwmon:cpu_thermal/temp1_input", so it can be used by the
+// aio.AnalogSensorDriver. The fan control attributes can be written like PWM pins, e.g. "hwmon:pwmfan/pwm1". On
+// finalize, the initial control mode (e.g. automatic) of each written PWM attribute is restored.
+type HwmonAdaptor struct {
+	sys     *system.Accesser
+	mutex   sync.Mutex
+	devices map[string]*system.HwmonDevice
+	pwms    map[string]*hwmonPwmState
+}
+
+// NewHwmonAdaptor provides the access to hardware monitoring devices of the board.
+func NewHwmonAdaptor(sys *system.Accesser) *HwmonAdaptor {
+	a := HwmonAdaptor{sys: sys}
+
+	sys.AddHwmonSupport()
+
+	return &a
+}
+
+// Connect prepares the connection to hardware monitoring devices.
+func (a *HwmonAdaptor) Connect() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.devices = make(map[string]*system.HwmonDevice)
+	a.pwms = make(map[string]*hwmonPwmState)
+	return nil
+}
+
+// Finalize restores the initial control mode of all written PWM attributes, which provide a control mode.
+func (a *HwmonAdaptor) Finalize() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	var err error
+	for _, state := range a.pwms {
+		if !state.hasEnable {
+			continue
+		}
+		if e := state.device.WriteInteger(state.attribute+"_enable", state.initEnable); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+	a.devices = nil
+	a.pwms = nil
+	return err
+}
+
+// IsHwmonPin returns true, if the given id has the prefix "hwmon:".
+func (a *HwmonAdaptor) IsHwmonPin(id string) bool {
+	return strings.HasPrefix(id, hwmonPinPrefix)
+}
+
+// HwmonDevices returns the identification of all hardware monitoring devices of the board.
+func (a *HwmonAdaptor) HwmonDevices() ([]system.HwmonDeviceInfo, error) {
+	return a.sys.HwmonDevices()
+}
+
+// AnalogRead reads the value of the given attribute, e.g. "hwmon:cpu_thermal/temp1_input". The value is returned
+// unscaled, e.g. in millidegree Celsius for temperatures.
+func (a *HwmonAdaptor) AnalogRead(id string) (int, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	device, attribute, err := a.hwmonAttribute(id)
+	if err != nil {
+		return 0, err
+	}
+
+	return device.ReadInteger(attribute)
+}
+
+// PwmWrite writes the value to the given PWM attribute, e.g. "hwmon:pwmfan/pwm1". The given value is between 0 and
+// 255, which is the range used by the Kernel. On first write, the manual control mode is activated, if the attribute
+// "pwm<n>_enable" exists.
+func (a *HwmonAdaptor) PwmWrite(id string, val byte) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	device, attribute, err := a.hwmonAttribute(id)
+	if err != nil {
+		return err
+	}
+
+	if !hwmonPwmRegexp.MatchString(attribute) {
+		return fmt.Errorf("'%s' is not a valid id for a hwmon PWM, the attribute must be like 'pwm1'", id)
+	}
+
+	if _, ok := a.pwms[id]; !ok {
+		state := &hwmonPwmState{device: device, attribute: attribute}
+		// the control mode is optional, e.g. the "pwm-fan" driver provides it, but some other drivers do not
+		if device.HasAttribute(attribute + "_enable") {
+			initEnable, err := device.ReadInteger(attribute + "_enable")
+			if err != nil {
+				return err
+			}
+			if err := device.WriteInteger(attribute+"_enable", hwmonPwmManualMode); err != nil {
+				return err
+			}
+			state.initEnable = initEnable
+			state.hasEnable = true
+		}
+		a.pwms[id] = state
+	}
+
+	return device.WriteInteger(attribute, int(val))
+}
+
+func (a *HwmonAdaptor) hwmonAttribute(id string) (*system.HwmonDevice, string, error) {
+	if a.devices == nil {
+		return nil, "", fmt.Errorf("not connected for hwmon %s", id)
+	}
+
+	if !a.IsHwmonPin(id) {
+		return nil, "", fmt.Errorf("'%s' is not a valid id for hwmon, the prefix '%s' is missing", id, hwmonPinPrefix)
+	}
+
+	idOrName, attribute := path.Split(strings.TrimPrefix(id, hwmonPinPrefix))
+	idOrName = strings.TrimSuffix(idOrName, "/")
+	if idOrName == "" || attribute == "" {
+		return nil, "", fmt.Errorf("'%s' is not a valid id for hwmon, the format is '%s<device>/<attribute>'", id,
+			hwmonPinPrefix)
+	}
+
+	device := a.devices[idOrName]
+	if device == nil {
+		var err error
+		if device, err = a.sys.NewHwmonDevice(idOrName); err != nil {
+			return nil, "", err
+		}
+		a.devices[idOrName] = device
+	}
+
+	return device, attribute, nil
+}
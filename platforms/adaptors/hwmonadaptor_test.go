@@ -0,0 +1,150 @@
+package adaptors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2/system"
+)
+
+const (
+	hwmonTestThermalPath = "/sys/class/hwmon/hwmon0"
+	hwmonTestFanPath     = "/sys/class/hwmon/hwmon1"
+)
+
+var hwmonMockPaths = []string{
+	hwmonTestThermalPath + "/name",
+	hwmonTestThermalPath + "/temp1_input",
+	hwmonTestFanPath + "/name",
+	hwmonTestFanPath + "/pwm1",
+	hwmonTestFanPath + "/pwm1_enable",
+}
+
+func initTestHwmonAdaptorWithMockedFilesystem() (*HwmonAdaptor, *system.MockFilesystem) {
+	sys := system.NewAccesser()
+	fs := sys.UseMockFilesystem(hwmonMockPaths)
+	a := NewHwmonAdaptor(sys)
+	fs.Files[hwmonTestThermalPath+"/name"].Contents = "cpu_thermal"
+	fs.Files[hwmonTestThermalPath+"/temp1_input"].Contents = "51540"
+	fs.Files[hwmonTestFanPath+"/name"].Contents = "pwmfan"
+	fs.Files[hwmonTestFanPath+"/pwm1"].Contents = "0"
+	fs.Files[hwmonTestFanPath+"/pwm1_enable"].Contents = "2"
+	if err := a.Connect(); err != nil {
+		panic(err)
+	}
+	return a, fs
+}
+
+func TestHwmonConnect(t *testing.T) {
+	a := NewHwmonAdaptor(system.NewAccesser())
+	assert.Nil(t, a.devices)
+
+	_, err := a.AnalogRead("hwmon:cpu_thermal/temp1_input")
+	require.ErrorContains(t, err, "not connected for hwmon hwmon:cpu_thermal/temp1_input")
+
+	err = a.Connect()
+	require.NoError(t, err)
+	assert.NotNil(t, a.devices)
+	assert.Empty(t, a.devices)
+}
+
+func TestHwmonFinalize(t *testing.T) {
+	// arrange
+	a, fs := initTestHwmonAdaptorWithMockedFilesystem()
+	require.NoError(t, a.PwmWrite("hwmon:pwmfan/pwm1", 180))
+	assert.Equal(t, "1", fs.Files[hwmonTestFanPath+"/pwm1_enable"].Contents)
+	// act
+	err := a.Finalize()
+	// assert
+	require.NoError(t, err)
+	assert.Nil(t, a.devices)
+	assert.Nil(t, a.pwms)
+	assert.Equal(t, "2", fs.Files[hwmonTestFanPath+"/pwm1_enable"].Contents)
+	// assert that finalize after finalize is working
+	require.NoError(t, a.Finalize())
+}
+
+func TestHwmonAnalogRead(t *testing.T) {
+	tests := map[string]struct {
+		id      string
+		want    int
+		wantErr string
+	}{
+		"by_name": {id: "hwmon:cpu_thermal/temp1_input", want: 51540},
+		"by_id":   {id: "hwmon:hwmon0/temp1_input", want: 51540},
+		"error_no_prefix": {
+			id:      "cpu_thermal/temp1_input",
+			wantErr: "'cpu_thermal/temp1_input' is not a valid id for hwmon, the prefix 'hwmon:' is missing",
+		},
+		"error_no_attribute": {
+			id:      "hwmon:cpu_thermal",
+			wantErr: "'hwmon:cpu_thermal' is not a valid id for hwmon, the format is 'hwmon:<device>/<attribute>'",
+		},
+		"error_unknown_device": {
+			id:      "hwmon:rpi_volt/in0_input",
+			wantErr: "hwmon device 'rpi_volt' not found in '/sys/class/hwmon'",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a, _ := initTestHwmonAdaptorWithMockedFilesystem()
+			// act
+			got, err := a.AnalogRead(tc.id)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestHwmonPwmWrite(t *testing.T) {
+	// arrange
+	a, fs := initTestHwmonAdaptorWithMockedFilesystem()
+	// act
+	err := a.PwmWrite("hwmon:pwmfan/pwm1", 200)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "200", fs.Files[hwmonTestFanPath+"/pwm1"].Contents)
+	assert.Equal(t, "1", fs.Files[hwmonTestFanPath+"/pwm1_enable"].Contents)
+	assert.Equal(t, 2, a.pwms["hwmon:pwmfan/pwm1"].initEnable)
+	// act
+	err = a.PwmWrite("hwmon:cpu_thermal/temp1_input", 200)
+	// assert
+	require.EqualError(t, err,
+		"'hwmon:cpu_thermal/temp1_input' is not a valid id for a hwmon PWM, the attribute must be like 'pwm1'")
+}
+
+func TestHwmonPwmWriteWithoutEnable(t *testing.T) {
+	// arrange
+	a, fs := initTestHwmonAdaptorWithMockedFilesystem()
+	delete(fs.Files, hwmonTestFanPath+"/pwm1_enable")
+	// act
+	err := a.PwmWrite("hwmon:pwmfan/pwm1", 120)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "120", fs.Files[hwmonTestFanPath+"/pwm1"].Contents)
+	assert.False(t, a.pwms["hwmon:pwmfan/pwm1"].hasEnable)
+	// act
+	err = a.Finalize()
+	// assert
+	require.NoError(t, err)
+	assert.NotContains(t, fs.Files, hwmonTestFanPath+"/pwm1_enable")
+}
+
+func TestHwmonDevices(t *testing.T) {
+	// arrange
+	a, _ := initTestHwmonAdaptorWithMockedFilesystem()
+	// act
+	got, err := a.HwmonDevices()
+	// assert
+	require.NoError(t, err)
+	assert.Len(t, got, 2)
+	assert.Equal(t, "pwmfan", got[1].Name)
+}
@@ -20,6 +20,9 @@ type I2cBusAdaptor struct {
 	defaultBusNumber int
 	mutex            sync.Mutex
 	buses            map[int]gobot.I2cSystemDevicer
+	busPins          map[int][]string
+	translateBusPin  digitalPinTranslator
+	recoveryPins     map[int]*i2cBusRecoveryPins
 }
 
 // NewI2cBusAdaptor provides the access to i2c buses of the board. The validator is used to check the bus number,
@@ -29,6 +32,8 @@ func NewI2cBusAdaptor(sys *system.Accesser, v i2cBusNumberValidator, defaultBusN
 		sys:              sys,
 		validateNumber:   v,
 		defaultBusNumber: defaultBusNr,
+		busPins:          make(map[int][]string),
+		recoveryPins:     make(map[int]*i2cBusRecoveryPins),
 	}
 
 	sys.AddI2CSupport()
@@ -77,16 +82,147 @@ func (a *I2cBusAdaptor) GetI2cConnection(address int, busNum int) (i2c.Connectio
 		if err != nil {
 			return nil, err
 		}
-		bus, err = a.sys.NewI2cDevice(fmt.Sprintf("/dev/i2c-%d", busNum))
+		location := i2cBusLocation(busNum)
+		dev, err := a.sys.NewI2cDevice(location)
 		if err != nil {
 			return nil, err
 		}
+		bus = a.sys.TraceI2cDevice(location, dev)
 		a.buses[busNum] = bus
 	}
 	return i2c.NewConnection(bus, address), nil
 }
 
+// SetI2cBusPins defines the pins, which are used by the given bus. The pins are named like for the digital pins of the
+// platform. As long as an address of the bus is claimed, the pins can not be claimed by other devices.
+func (a *I2cBusAdaptor) SetI2cBusPins(busNum int, pins ...string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.busPins[busNum] = pins
+}
+
+// SetI2cBusRecoveryPins activates the recovery of the given bus by GPIO's. The SCL and SDA pins are named like for the
+// digital pins of the platform and are acquired by the given provider, normally the platform adaptor itself. The pins
+// are released after each recovery, also on errors. On most platforms the pin multiplexer is switched to GPIO when the
+// line is requested and is not switched back on release (e.g. Raspberry Pi). So the given restore function is called
+// after the recovery to restore the I2C function of the pins, e.g. by "pinctrl set 2,3 a0". Without restore function
+// (nil), the recovery by GPIO's returns an error wrapping i2c.ErrBusPinsNotRestored, so the failed transfer is not
+// repeated on an unusable bus.
+func (a *I2cBusAdaptor) SetI2cBusRecoveryPins(
+	busNum int,
+	pinner gobot.DigitalPinnerProvider,
+	scl string,
+	sda string,
+	restore func() error,
+) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.recoveryPins[busNum] = &i2cBusRecoveryPins{pinner: pinner, scl: scl, sda: sda, restore: restore}
+}
+
+// RecoverI2cBus recovers the given bus, e.g. after a transfer was interrupted and the target device holds SDA low.
+// If recovery pins are defined for the bus, up to nine clock pulses are sent on SCL until SDA is released, followed
+// by a STOP condition, see SetI2cBusRecoveryPins(). In any case the character device of the bus is closed and opened
+// again on next access. Closing the character device does not touch the bus lines, so without recovery pins a stuck SDA
+// is not freed by this call. The Linux kernel provides no user space interface to trigger the recovery of its bus
+// drivers, those recover the bus by their own on timeouts, if the recovery GPIO's are defined in the device tree.
+func (a *I2cBusAdaptor) RecoverI2cBus(busNum int) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if err := a.validateNumber(busNum); err != nil {
+		return err
+	}
+
+	var err error
+	if pins := a.recoveryPins[busNum]; pins != nil {
+		if e := pins.recover(); e != nil {
+			err = multierror.Append(err, fmt.Errorf("recovery of I2C bus %d by GPIO failed: %w", busNum, e))
+		} else if e := pins.restoreFunction(); e != nil {
+			err = multierror.Append(err, fmt.Errorf("I2C bus %d recovered by GPIO: %w", busNum, e))
+		}
+	}
+
+	if bus := a.buses[busNum]; bus != nil {
+		if e := bus.Close(); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+
+	return err
+}
+
+// SetI2cBusPinTranslator defines the translator of the bus pins, normally the same as for the digital pins of the
+// platform. The lines of the bus pins are claimed together with the pins, so a pin of an active bus can not be claimed
+// by another name, e.g. by the line name "GPIO2" instead of the header pin "3".
+func (a *I2cBusAdaptor) SetI2cBusPinTranslator(translate func(id string) (string, int, error)) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.translateBusPin = translate
+}
+
+// ClaimI2cAddress registers the given address of the bus for the given owner, usually the name of the driver. The pins
+// of the bus are registered for the bus itself. An error is returned, if the address is already owned by another
+// device or a pin of the bus is in use, e.g. as digital pin.
+func (a *I2cBusAdaptor) ClaimI2cAddress(owner string, busNum int, address int) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if err := a.validateNumber(busNum); err != nil {
+		return err
+	}
+
+	location := i2cBusLocation(busNum)
+	registry := a.sys.Ownerships()
+	var busResources []system.OwnershipResource
+	for _, pin := range a.busPins[busNum] {
+		busResources = append(busResources, digitalPinResources(a.translateBusPin, pin)...)
+	}
+	if err := registry.Claim(location, busResources...); err != nil {
+		return fmt.Errorf("I2C bus %d can not be used by '%s': %w", busNum, owner, err)
+	}
+
+	resource := system.OwnershipResource{Kind: system.OwnershipKindI2c, Resource: i2cAddressResource(busNum, address)}
+	if err := registry.Claim(owner, resource); err != nil {
+		a.releaseUnusedBusPins(busNum)
+		return err
+	}
+
+	return nil
+}
+
+// ReleaseI2cAddresses removes all addresses of the given owner from the ownership registry. The pins of a bus are
+// released, if no address of the bus is owned anymore.
+func (a *I2cBusAdaptor) ReleaseI2cAddresses(owner string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.sys.Ownerships().Release(owner, system.OwnershipKindI2c)
+	for busNum := range a.busPins {
+		a.releaseUnusedBusPins(busNum)
+	}
+}
+
 // DefaultI2cBus returns the default i2c bus number for this platform.
 func (a *I2cBusAdaptor) DefaultI2cBus() int {
 	return a.defaultBusNumber
 }
+
+func (a *I2cBusAdaptor) releaseUnusedBusPins(busNum int) {
+	registry := a.sys.Ownerships()
+	location := i2cBusLocation(busNum)
+	if !registry.HasResourcePrefix(system.OwnershipKindI2c, location+":") {
+		registry.Release(location, system.OwnershipKindPin, system.OwnershipKindDigitalPin)
+	}
+}
+
+func i2cBusLocation(busNum int) string {
+	return fmt.Sprintf("/dev/i2c-%d", busNum)
+}
+
+func i2cAddressResource(busNum int, address int) string {
+	return fmt.Sprintf("%s:0x%02x", i2cBusLocation(busNum), address)
+}
@@ -1,6 +1,7 @@
 package adaptors
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -12,7 +13,11 @@ import (
 )
 
 // make sure that this Adaptor fulfills all the required interfaces
-var _ i2c.Connector = (*I2cBusAdaptor)(nil)
+var (
+	_ i2c.Connector      = (*I2cBusAdaptor)(nil)
+	_ i2c.AddressClaimer = (*I2cBusAdaptor)(nil)
+	_ i2c.BusRecoverer   = (*I2cBusAdaptor)(nil)
+)
 
 const i2cBus1 = "/dev/i2c-1"
 
@@ -112,3 +117,212 @@ func TestI2cGetDefaultBus(t *testing.T) {
 	a := NewI2cBusAdaptor(system.NewAccesser(), nil, 2)
 	assert.Equal(t, 2, a.DefaultI2cBus())
 }
+
+func TestI2cGetI2cConnectionWithTracer(t *testing.T) {
+	// arrange
+	tracer := system.NewTracer(0)
+	a := NewI2cBusAdaptor(system.NewAccesser(system.WithTracer(tracer)), func(int) error { return nil }, 1)
+	a.sys.UseMockSyscall()
+	a.sys.UseMockFilesystem([]string{i2cBus1})
+	require.NoError(t, a.Connect())
+	con, err := a.GetI2cConnection(0x42, 1)
+	require.NoError(t, err)
+	// act
+	_, err = con.Write([]byte{0x01, 0x02})
+	// assert
+	require.NoError(t, err)
+	records := tracer.Records()
+	require.Len(t, records, 1)
+	assert.Equal(t, system.TraceKindI2c, records[0].Kind)
+	assert.Equal(t, i2cBus1, records[0].Device)
+	assert.Equal(t, 0x42, records[0].Address)
+	assert.Equal(t, []byte{0x01, 0x02}, records[0].Tx)
+}
+
+func TestI2cClaimI2cAddress(t *testing.T) {
+	// arrange
+	a, _ := initTestI2cAdaptorWithMockedFilesystem([]string{i2cBus1})
+	a.SetI2cBusPins(1, "3", "5")
+	registry := a.sys.Ownerships()
+	require.NoError(t, a.ClaimI2cAddress("BMP280", 1, 0x77))
+	require.NoError(t, a.ClaimI2cAddress("INA3221", 1, 0x40))
+	// act & assert: address is already in use
+	err := a.ClaimI2cAddress("BME280", 1, 0x77)
+	require.EqualError(t, err,
+		"'BME280' can not claim i2c '/dev/i2c-1:0x77', because it is already owned by 'BMP280'")
+	// act & assert: pins are used by the bus
+	err = registry.Claim("LED", system.OwnershipResource{Kind: system.OwnershipKindPin, Resource: "3"})
+	require.EqualError(t, err, "'LED' can not claim pin '3', because it is already owned by '/dev/i2c-1'")
+	// act & assert: invalid bus
+	require.ErrorContains(t, a.ClaimI2cAddress("BMP280", 2, 0x77), "2 not valid")
+	// act & assert: pins are kept until the last address is released
+	a.ReleaseI2cAddresses("BMP280")
+	_, ok := registry.Owner(system.OwnershipKindPin, "3")
+	assert.True(t, ok)
+	a.ReleaseI2cAddresses("INA3221")
+	assert.Empty(t, registry.Table())
+}
+
+func TestI2cClaimI2cAddressPinConflict(t *testing.T) {
+	// arrange
+	a, _ := initTestI2cAdaptorWithMockedFilesystem([]string{i2cBus1})
+	a.SetI2cBusPins(1, "3", "5")
+	require.NoError(t, a.sys.Ownerships().Claim("LED",
+		system.OwnershipResource{Kind: system.OwnershipKindPin, Resource: "5"}))
+	// act
+	err := a.ClaimI2cAddress("BMP280", 1, 0x77)
+	// assert
+	require.EqualError(t, err, "I2C bus 1 can not be used by 'BMP280': "+
+		"'/dev/i2c-1' can not claim pin '5', because it is already owned by 'LED'")
+	assert.Len(t, a.sys.Ownerships().Table(), 1)
+}
+
+// testBusPinTranslator translates the header pins and the line names of the first I2C and SPI bus of a Raspberry Pi
+func testBusPinTranslator(id string) (string, int, error) {
+	lines := map[string]int{"3": 2, "5": 3, "GPIO2": 2, "GPIO3": 3, "19": 10, "24": 8, "GPIO10": 10, "GPIO8": 8}
+	if line, ok := lines[id]; ok {
+		return "gpiochip0", line, nil
+	}
+	return "", -1, fmt.Errorf("'%s' is not a valid id for a digital pin", id)
+}
+
+func TestI2cClaimI2cAddressLineAlias(t *testing.T) {
+	// arrange
+	a, _ := initTestI2cAdaptorWithMockedFilesystem([]string{i2cBus1})
+	a.SetI2cBusPins(1, "3", "5")
+	a.SetI2cBusPinTranslator(testBusPinTranslator)
+	dpa := NewDigitalPinsAdaptor(a.sys, testBusPinTranslator)
+	require.NoError(t, dpa.ClaimDigitalPin("Button", "GPIO3"))
+	// act & assert: the line of a bus pin is already owned by the alias
+	err := a.ClaimI2cAddress("BMP280", 1, 0x77)
+	require.EqualError(t, err, "I2C bus 1 can not be used by 'BMP280': "+
+		"'/dev/i2c-1' can not claim gpio 'gpiochip0:3', because it is already owned by 'Button'")
+	dpa.ReleaseDigitalPins("Button")
+	// act & assert: the alias can not be claimed, while the bus is used
+	require.NoError(t, a.ClaimI2cAddress("BMP280", 1, 0x77))
+	err = dpa.ClaimDigitalPin("LED", "GPIO2")
+	require.EqualError(t, err, "'LED' can not claim gpio 'gpiochip0:2', because it is already owned by '/dev/i2c-1'")
+	// act & assert: the lines are released with the last address
+	a.ReleaseI2cAddresses("BMP280")
+	assert.Empty(t, a.sys.Ownerships().Table())
+	require.NoError(t, dpa.ClaimDigitalPin("LED", "GPIO2"))
+}
+
+func TestI2cRecoverI2cBus(t *testing.T) {
+	tests := map[string]struct {
+		sdaValues    []int
+		withPins     bool
+		restoreErr   error
+		noRestore    bool
+		wantSclWrite []int
+		wantRestored int
+		wantErr      string
+	}{
+		"without_recovery_pins": {},
+		"sda_released_after_two_pulses": {
+			withPins:     true,
+			sdaValues:    []int{0, 0, 1, 1},
+			wantSclWrite: []int{0, 1, 0, 1, 0, 1},
+			wantRestored: 1,
+		},
+		"sda_not_stuck": {
+			withPins:     true,
+			sdaValues:    []int{1, 1},
+			wantSclWrite: []int{0, 1},
+			wantRestored: 1,
+		},
+		"error_sda_still_stuck": {
+			withPins:     true,
+			sdaValues:    []int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+			wantSclWrite: []int{0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1},
+			wantErr:      "recovery of I2C bus 1 by GPIO failed: SDA (pin '3') is still stuck low",
+		},
+		"error_without_restore": {
+			withPins:     true,
+			noRestore:    true,
+			sdaValues:    []int{1, 1},
+			wantSclWrite: []int{0, 1},
+			wantErr:      "I2C bus 1 recovered by GPIO: the I2C function of the bus pins is not restored",
+		},
+		"error_restore": {
+			withPins:     true,
+			restoreErr:   errors.New("pinctrl error"),
+			sdaValues:    []int{1, 1},
+			wantSclWrite: []int{0, 1},
+			wantRestored: 1,
+			wantErr: "I2C bus 1 recovered by GPIO: the I2C function of the bus pins is not restored: " +
+				"pinctrl error",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a, fs := initTestI2cAdaptorWithMockedFilesystem([]string{i2cBus1})
+			pa := NewDigitalPinsAdaptor(a.sys, testDigitalPinTranslator)
+			require.NoError(t, pa.Connect())
+			dpa := a.sys.UseMockDigitalPinAccess()
+			dpa.UseValues("", "14", tc.sdaValues) // SDA pin "3"
+			var restored int
+			restore := func() error {
+				restored++
+				return tc.restoreErr
+			}
+			if tc.noRestore {
+				restore = nil
+			}
+			if tc.withPins {
+				a.SetI2cBusRecoveryPins(1, pa, "5", "3", restore)
+			}
+			con, err := a.GetI2cConnection(0xff, 1)
+			require.NoError(t, err)
+			_, err = con.Write([]byte{0x01})
+			require.NoError(t, err)
+			// act
+			err = a.RecoverI2cBus(1)
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantRestored, restored)
+			if tc.withPins {
+				assert.Equal(t, tc.wantSclWrite, dpa.Written("", "16"))
+				assert.Equal(t, []int{1}, dpa.Written("", "14"))
+				// the pins are released for the operating system and the adaptor
+				assert.Equal(t, 0, dpa.Exported("", "16"))
+				assert.Equal(t, 0, dpa.Exported("", "14"))
+				assert.NotContains(t, pa.pins, "5")
+				assert.NotContains(t, pa.pins, "3")
+			}
+			assert.False(t, fs.Files[i2cBus1].Opened)
+			// the bus is usable again after recovery
+			_, err = con.Write([]byte{0x02})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestI2cRecoverI2cBusReleasesPinsOnError(t *testing.T) {
+	// arrange
+	a, _ := initTestI2cAdaptorWithMockedFilesystem([]string{i2cBus1})
+	pa := NewDigitalPinsAdaptor(a.sys, testDigitalPinTranslator)
+	require.NoError(t, pa.Connect())
+	dpa := a.sys.UseMockDigitalPinAccess()
+	a.SetI2cBusRecoveryPins(1, pa, "5", "invalid", nil)
+	// act
+	err := a.RecoverI2cBus(1)
+	// assert
+	require.ErrorContains(t, err, "recovery of I2C bus 1 by GPIO failed: not a valid pin")
+	assert.Equal(t, 0, dpa.Exported("", "16"))
+	assert.NotContains(t, pa.pins, "5")
+}
+
+func TestI2cRecoverI2cBusInvalidNumber(t *testing.T) {
+	// arrange
+	a, _ := initTestI2cAdaptorWithMockedFilesystem([]string{i2cBus1})
+	// act
+	err := a.RecoverI2cBus(2)
+	// assert
+	require.EqualError(t, err, "2 not valid")
+}
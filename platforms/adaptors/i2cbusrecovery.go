@@ -0,0 +1,145 @@
+package adaptors
+
+import (
+	"fmt"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/i2c"
+	"gobot.io/x/gobot/v2/system"
+)
+
+const (
+	i2cRecoveryPulses     = 9
+	i2cRecoveryHalfPeriod = 5 * time.Microsecond // 100kHz
+)
+
+// i2cBusRecoveryPins contains the pins for the recovery of an i2c bus by GPIO's
+type i2cBusRecoveryPins struct {
+	pinner  gobot.DigitalPinnerProvider
+	scl     string
+	sda     string
+	restore func() error
+}
+
+// digitalPinUnexporter is implemented by providers, which can release a single pin, e.g. the DigitalPinsAdaptor
+type digitalPinUnexporter interface {
+	UnexportDigitalPin(id string) error
+}
+
+// recover clocks out up to nine pulses on SCL, until the target device releases SDA, followed by a STOP condition.
+// Both lines are used in open drain mode, so the pull-up resistors of the bus define the high level. The pins are
+// released on each return.
+func (r *i2cBusRecoveryPins) recover() (err error) {
+	scl, err := r.pinner.DigitalPin(r.scl)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if e := r.release(r.scl, scl); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}()
+
+	sda, err := r.pinner.DigitalPin(r.sda)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if e := r.release(r.sda, sda); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}()
+
+	if err := sda.ApplyOptions(system.WithPinDirectionInput()); err != nil {
+		return err
+	}
+	if err := scl.ApplyOptions(system.WithPinOpenDrain(), system.WithPinDirectionOutput(1)); err != nil {
+		return err
+	}
+
+	for i := 0; i < i2cRecoveryPulses; i++ {
+		val, err := sda.Read()
+		if err != nil {
+			return err
+		}
+		if val == 1 {
+			break
+		}
+		if err := i2cRecoveryClockPulse(scl); err != nil {
+			return err
+		}
+	}
+
+	// STOP condition: rising SDA while SCL is high
+	if err := scl.Write(0); err != nil {
+		return err
+	}
+	if err := sda.ApplyOptions(system.WithPinOpenDrain(), system.WithPinDirectionOutput(0)); err != nil {
+		return err
+	}
+	time.Sleep(i2cRecoveryHalfPeriod)
+	if err := scl.Write(1); err != nil {
+		return err
+	}
+	time.Sleep(i2cRecoveryHalfPeriod)
+	if err := sda.Write(1); err != nil {
+		return err
+	}
+	time.Sleep(i2cRecoveryHalfPeriod)
+
+	// release both lines
+	if err := scl.ApplyOptions(system.WithPinDirectionInput()); err != nil {
+		return err
+	}
+	if err := sda.ApplyOptions(system.WithPinDirectionInput()); err != nil {
+		return err
+	}
+
+	val, err := sda.Read()
+	if err != nil {
+		return err
+	}
+	if val != 1 {
+		return fmt.Errorf("SDA (pin '%s') is still stuck low", r.sda)
+	}
+
+	return nil
+}
+
+// restoreFunction restores the I2C function of the pins after the release, an error is returned if not possible
+func (r *i2cBusRecoveryPins) restoreFunction() error {
+	if r.restore == nil {
+		return i2c.ErrBusPinsNotRestored
+	}
+
+	if err := r.restore(); err != nil {
+		return fmt.Errorf("%w: %w", i2c.ErrBusPinsNotRestored, err)
+	}
+
+	return nil
+}
+
+// release frees the pin for the operating system, if possible by the provider, so it does not keep the pin
+func (r *i2cBusRecoveryPins) release(id string, pin gobot.DigitalPinner) error {
+	if u, ok := r.pinner.(digitalPinUnexporter); ok {
+		return u.UnexportDigitalPin(id)
+	}
+
+	return pin.Unexport()
+}
+
+func i2cRecoveryClockPulse(scl gobot.DigitalPinner) error {
+	if err := scl.Write(0); err != nil {
+		return err
+	}
+	time.Sleep(i2cRecoveryHalfPeriod)
+	if err := scl.Write(1); err != nil {
+		return err
+	}
+	time.Sleep(i2cRecoveryHalfPeriod)
+
+	return nil
+}
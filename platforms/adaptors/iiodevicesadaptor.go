@@ -0,0 +1,103 @@
+package adaptors
+
+import (
+	"fmt"
+	"sync"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	"gobot.io/x/gobot/v2/system"
+)
+
+// IIODevicesAdaptor is a adaptor for Linux Industrial I/O devices (e.g. ADC's, IMU's), normally used for composition
+// in platforms. In contrast to the AnalogPinsAdaptor, the values are calibrated by the Kernel provided scale and
+// offset and a buffered capture by the IIO character device is possible.
+type IIODevicesAdaptor struct {
+	sys     *system.Accesser
+	mutex   sync.Mutex
+	devices map[string]*system.IIODevice
+}
+
+// NewIIODevicesAdaptor provides the access to IIO devices of the board.
+func NewIIODevicesAdaptor(sys *system.Accesser) *IIODevicesAdaptor {
+	a := IIODevicesAdaptor{sys: sys}
+
+	sys.AddIIOSupport()
+
+	return &a
+}
+
+// Connect prepares the connection to IIO devices.
+func (a *IIODevicesAdaptor) Connect() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.devices = make(map[string]*system.IIODevice)
+	return nil
+}
+
+// Finalize stops all running buffered captures of IIO devices.
+func (a *IIODevicesAdaptor) Finalize() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	var err error
+	for _, dev := range a.devices {
+		if dev != nil {
+			if e := dev.Close(); e != nil {
+				err = multierror.Append(err, e)
+			}
+		}
+	}
+	a.devices = nil
+	return err
+}
+
+// IIODevices returns the identification of all IIO devices of the board.
+func (a *IIODevicesAdaptor) IIODevices() ([]system.IIODeviceInfo, error) {
+	return a.sys.IIODevices()
+}
+
+// IIOTriggers returns the names of all IIO triggers of the board.
+func (a *IIODevicesAdaptor) IIOTriggers() ([]string, error) {
+	return a.sys.IIOTriggers()
+}
+
+// IIODevice returns the IIO device for the given id (e.g. "iio:device0") or name (e.g. "ads1015"). The device can be
+// used for configuration of triggers and buffered capture of samples.
+func (a *IIODevicesAdaptor) IIODevice(idOrName string) (*system.IIODevice, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.iioDevice(idOrName)
+}
+
+// IIORead returns the calibrated value of the given channel (e.g. "in_voltage0") of the given device.
+func (a *IIODevicesAdaptor) IIORead(idOrName string, channel string) (float64, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	dev, err := a.iioDevice(idOrName)
+	if err != nil {
+		return 0, err
+	}
+
+	return dev.Read(channel)
+}
+
+func (a *IIODevicesAdaptor) iioDevice(idOrName string) (*system.IIODevice, error) {
+	if a.devices == nil {
+		return nil, fmt.Errorf("not connected for IIO device %s", idOrName)
+	}
+
+	dev := a.devices[idOrName]
+	if dev == nil {
+		var err error
+		if dev, err = a.sys.NewIIODevice(idOrName); err != nil {
+			return nil, err
+		}
+		a.devices[idOrName] = dev
+	}
+
+	return dev, nil
+}
@@ -0,0 +1,102 @@
+package adaptors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2/system"
+)
+
+const iioTestDevicePath = "/sys/bus/iio/devices/iio:device0"
+
+var iioMockPaths = []string{
+	iioTestDevicePath + "/name",
+	iioTestDevicePath + "/in_voltage0_raw",
+	iioTestDevicePath + "/in_voltage_scale",
+	iioTestDevicePath + "/buffer/length",
+	iioTestDevicePath + "/buffer/enable",
+	iioTestDevicePath + "/scan_elements/in_voltage0_en",
+	iioTestDevicePath + "/scan_elements/in_voltage0_index",
+	iioTestDevicePath + "/scan_elements/in_voltage0_type",
+	"/sys/bus/iio/devices/trigger0/name",
+	"/dev/iio:device0",
+}
+
+func initTestIIODevicesAdaptorWithMockedFilesystem() (*IIODevicesAdaptor, *system.MockFilesystem) {
+	sys := system.NewAccesser()
+	fs := sys.UseMockFilesystem(iioMockPaths)
+	a := NewIIODevicesAdaptor(sys)
+	fs.Files[iioTestDevicePath+"/name"].Contents = "ads1015"
+	fs.Files[iioTestDevicePath+"/in_voltage0_raw"].Contents = "1200"
+	fs.Files[iioTestDevicePath+"/in_voltage_scale"].Contents = "0.125"
+	fs.Files[iioTestDevicePath+"/scan_elements/in_voltage0_en"].Contents = "0"
+	fs.Files[iioTestDevicePath+"/scan_elements/in_voltage0_index"].Contents = "0"
+	fs.Files[iioTestDevicePath+"/scan_elements/in_voltage0_type"].Contents = "be:s12/16>>4"
+	fs.Files["/sys/bus/iio/devices/trigger0/name"].Contents = "sysfstrig0"
+	if err := a.Connect(); err != nil {
+		panic(err)
+	}
+	return a, fs
+}
+
+func TestIIODevicesConnect(t *testing.T) {
+	a := NewIIODevicesAdaptor(system.NewAccesser())
+	assert.Nil(t, a.devices)
+
+	_, err := a.IIORead("ads1015", "in_voltage0")
+	require.ErrorContains(t, err, "not connected for IIO device ads1015")
+
+	err = a.Connect()
+	require.NoError(t, err)
+	assert.NotNil(t, a.devices)
+	assert.Empty(t, a.devices)
+}
+
+func TestIIODevicesFinalize(t *testing.T) {
+	// arrange
+	a, fs := initTestIIODevicesAdaptorWithMockedFilesystem()
+	dev, err := a.IIODevice("iio:device0")
+	require.NoError(t, err)
+	require.NoError(t, dev.StartBuffer(8, "in_voltage0"))
+	assert.Equal(t, "1", fs.Files[iioTestDevicePath+"/buffer/enable"].Contents)
+	// act
+	err = a.Finalize()
+	// assert
+	require.NoError(t, err)
+	assert.Nil(t, a.devices)
+	assert.Equal(t, "0", fs.Files[iioTestDevicePath+"/buffer/enable"].Contents)
+	assert.True(t, fs.Files["/dev/iio:device0"].Closed)
+	// assert that finalize after finalize is working
+	require.NoError(t, a.Finalize())
+}
+
+func TestIIODevicesRead(t *testing.T) {
+	// arrange
+	a, _ := initTestIIODevicesAdaptorWithMockedFilesystem()
+	// act
+	got, err := a.IIORead("ads1015", "in_voltage0")
+	// assert
+	require.NoError(t, err)
+	assert.InDelta(t, 150.0, got, 0.0)
+	assert.Len(t, a.devices, 1)
+	// act
+	_, err = a.IIORead("mcp3008", "in_voltage0")
+	// assert
+	require.EqualError(t, err, "IIO device 'mcp3008' not found in '/sys/bus/iio/devices'")
+	assert.Len(t, a.devices, 1)
+}
+
+func TestIIODevicesAndTriggers(t *testing.T) {
+	// arrange
+	a, _ := initTestIIODevicesAdaptorWithMockedFilesystem()
+	// act
+	devices, err := a.IIODevices()
+	triggers, errTrig := a.IIOTriggers()
+	// assert
+	require.NoError(t, err)
+	require.NoError(t, errTrig)
+	assert.Equal(t, []system.IIODeviceInfo{{ID: "iio:device0", Name: "ads1015", Path: iioTestDevicePath}}, devices)
+	assert.Equal(t, []string{"sysfstrig0"}, triggers)
+}
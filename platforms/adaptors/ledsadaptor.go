@@ -0,0 +1,195 @@
+package adaptors
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/system"
+)
+
+// ledPinPrefix is used to identify the pins of LED class devices, e.g. "led:ACT"
+const ledPinPrefix = "led:"
+
+type ledState struct {
+	led            *system.LED
+	maxBrightness  int
+	initTrigger    string
+	initBrightness int
+}
+
+// LEDsAdaptor is a adaptor for Linux LED class devices (e.g. onboard LED's), normally used for composition in
+// platforms. The LED's are accessed like digital or PWM pins by the name with prefix "led:", e.g. "led:ACT", so it can
+// be used by the gpio.LedDriver. On finalize, the initial trigger (e.g. "mmc0") of each used LED is restored.
+type LEDsAdaptor struct {
+	sys   *system.Accesser
+	mutex sync.Mutex
+	leds  map[string]*ledState
+}
+
+// NewLEDsAdaptor provides the access to LED class devices of the board.
+func NewLEDsAdaptor(sys *system.Accesser) *LEDsAdaptor {
+	a := LEDsAdaptor{sys: sys}
+
+	sys.AddLEDSupport()
+
+	return &a
+}
+
+// Connect prepares the connection to LED class devices.
+func (a *LEDsAdaptor) Connect() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.leds = make(map[string]*ledState)
+	return nil
+}
+
+// Finalize restores the initial trigger or brightness of all used LED class devices.
+func (a *LEDsAdaptor) Finalize() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	var err error
+	for _, state := range a.leds {
+		if state.initTrigger != "" && state.initTrigger != "none" {
+			if e := state.led.SetTrigger(state.initTrigger); e != nil {
+				err = multierror.Append(err, e)
+			}
+			continue
+		}
+		if e := state.led.SetBrightness(state.initBrightness); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+	a.leds = nil
+	return err
+}
+
+// IsLEDPin returns true, if the given id has the prefix "led:".
+func (a *LEDsAdaptor) IsLEDPin(id string) bool {
+	return strings.HasPrefix(id, ledPinPrefix)
+}
+
+// LEDs returns the names of all LED class devices of the board. Use the prefix "led:" to access them as pins.
+func (a *LEDsAdaptor) LEDs() ([]string, error) {
+	return a.sys.LEDs()
+}
+
+// DigitalWrite switches the LED off for "0" and on with maximum brightness for all other values.
+func (a *LEDsAdaptor) DigitalWrite(id string, val byte) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	state, err := a.ledState(id)
+	if err != nil {
+		return err
+	}
+
+	if val == 0 {
+		return state.led.SetBrightness(0)
+	}
+	return state.led.SetBrightness(state.maxBrightness)
+}
+
+// PwmWrite writes the brightness of the LED. The given value is between 0 and 255 and will be scaled to the maximum
+// brightness of the LED.
+func (a *LEDsAdaptor) PwmWrite(id string, val byte) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	state, err := a.ledState(id)
+	if err != nil {
+		return err
+	}
+
+	brightness := gobot.ToScale(gobot.FromScale(float64(val), 0, 255), 0, float64(state.maxBrightness))
+	return state.led.SetBrightness(int(math.Round(brightness)))
+}
+
+// LEDTriggers returns all available triggers and the active trigger of the given LED.
+func (a *LEDsAdaptor) LEDTriggers(id string) ([]string, string, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	state, err := a.ledState(id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return state.led.Triggers()
+}
+
+// LEDSetTrigger activates the given trigger of the given LED, e.g. "heartbeat" or "none".
+func (a *LEDsAdaptor) LEDSetTrigger(id string, trigger string) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	state, err := a.ledState(id)
+	if err != nil {
+		return err
+	}
+
+	return state.led.SetTrigger(trigger)
+}
+
+// LEDSetTimer activates the "timer" trigger of the given LED, which blinks with the given on and off times in
+// milliseconds without any further interaction.
+func (a *LEDsAdaptor) LEDSetTimer(id string, delayOnMs, delayOffMs int) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	state, err := a.ledState(id)
+	if err != nil {
+		return err
+	}
+
+	return state.led.SetTimer(delayOnMs, delayOffMs)
+}
+
+func (a *LEDsAdaptor) ledState(id string) (*ledState, error) {
+	if a.leds == nil {
+		return nil, fmt.Errorf("not connected for LED %s", id)
+	}
+
+	if !a.IsLEDPin(id) {
+		return nil, fmt.Errorf("'%s' is not a valid id for a LED, the prefix '%s' is missing", id, ledPinPrefix)
+	}
+
+	state := a.leds[id]
+	if state == nil {
+		led, err := a.sys.NewLED(strings.TrimPrefix(id, ledPinPrefix))
+		if err != nil {
+			return nil, err
+		}
+
+		maxBrightness, err := led.MaxBrightness()
+		if err != nil {
+			return nil, err
+		}
+
+		initBrightness, err := led.Brightness()
+		if err != nil {
+			return nil, err
+		}
+
+		_, initTrigger, err := led.Triggers()
+		if err != nil {
+			return nil, err
+		}
+
+		state = &ledState{
+			led:            led,
+			maxBrightness:  maxBrightness,
+			initTrigger:    initTrigger,
+			initBrightness: initBrightness,
+		}
+		a.leds[id] = state
+	}
+
+	return state, nil
+}
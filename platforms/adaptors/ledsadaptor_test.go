@@ -0,0 +1,152 @@
+package adaptors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2/system"
+)
+
+const ledTestPath = "/sys/class/leds/ACT"
+
+var ledMockPaths = []string{
+	ledTestPath + "/brightness",
+	ledTestPath + "/max_brightness",
+	ledTestPath + "/trigger",
+	ledTestPath + "/delay_on",
+	ledTestPath + "/delay_off",
+}
+
+func initTestLEDsAdaptorWithMockedFilesystem(initTrigger string) (*LEDsAdaptor, *system.MockFilesystem) {
+	sys := system.NewAccesser()
+	fs := sys.UseMockFilesystem(ledMockPaths)
+	a := NewLEDsAdaptor(sys)
+	fs.Files[ledTestPath+"/brightness"].Contents = "3"
+	fs.Files[ledTestPath+"/max_brightness"].Contents = "255"
+	fs.Files[ledTestPath+"/trigger"].Contents = initTrigger
+	if err := a.Connect(); err != nil {
+		panic(err)
+	}
+	return a, fs
+}
+
+func TestLEDsConnect(t *testing.T) {
+	a := NewLEDsAdaptor(system.NewAccesser())
+	assert.Nil(t, a.leds)
+
+	err := a.DigitalWrite("led:ACT", 1)
+	require.ErrorContains(t, err, "not connected for LED led:ACT")
+
+	err = a.Connect()
+	require.NoError(t, err)
+	assert.NotNil(t, a.leds)
+	assert.Empty(t, a.leds)
+}
+
+func TestLEDsFinalize(t *testing.T) {
+	tests := map[string]struct {
+		initTrigger    string
+		wantTrigger    string
+		wantBrightness string
+	}{
+		"restore_trigger": {
+			initTrigger:    "none [mmc0] heartbeat",
+			wantTrigger:    "mmc0",
+			wantBrightness: "255",
+		},
+		"restore_brightness": {
+			initTrigger:    "[none] mmc0 heartbeat",
+			wantTrigger:    "[none] mmc0 heartbeat",
+			wantBrightness: "3",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a, fs := initTestLEDsAdaptorWithMockedFilesystem(tc.initTrigger)
+			require.NoError(t, a.DigitalWrite("led:ACT", 1))
+			// act
+			err := a.Finalize()
+			// assert
+			require.NoError(t, err)
+			assert.Nil(t, a.leds)
+			assert.Equal(t, tc.wantTrigger, fs.Files[ledTestPath+"/trigger"].Contents)
+			assert.Equal(t, tc.wantBrightness, fs.Files[ledTestPath+"/brightness"].Contents)
+			// assert that finalize after finalize is working
+			require.NoError(t, a.Finalize())
+		})
+	}
+}
+
+func TestLEDsDigitalWrite(t *testing.T) {
+	tests := map[string]struct {
+		id      string
+		val     byte
+		want    string
+		wantErr string
+	}{
+		"on":  {id: "led:ACT", val: 1, want: "255"},
+		"off": {id: "led:ACT", val: 0, want: "0"},
+		"error_no_prefix": {
+			id:      "ACT",
+			want:    "3",
+			wantErr: "'ACT' is not a valid id for a LED, the prefix 'led:' is missing",
+		},
+		"error_unknown_led": {
+			id:      "led:PWR",
+			want:    "3",
+			wantErr: "LED 'PWR' not found in '/sys/class/leds'",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a, fs := initTestLEDsAdaptorWithMockedFilesystem("none [mmc0]")
+			// act
+			err := a.DigitalWrite(tc.id, tc.val)
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.want, fs.Files[ledTestPath+"/brightness"].Contents)
+		})
+	}
+}
+
+func TestLEDsPwmWrite(t *testing.T) {
+	// arrange
+	a, fs := initTestLEDsAdaptorWithMockedFilesystem("none [mmc0]")
+	fs.Files[ledTestPath+"/max_brightness"].Contents = "100"
+	// act
+	err := a.PwmWrite("led:ACT", 128)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "50", fs.Files[ledTestPath+"/brightness"].Contents)
+}
+
+func TestLEDsTriggers(t *testing.T) {
+	// arrange
+	a, fs := initTestLEDsAdaptorWithMockedFilesystem("none [mmc0] timer heartbeat")
+	// act
+	triggers, active, err := a.LEDTriggers("led:ACT")
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"none", "mmc0", "timer", "heartbeat"}, triggers)
+	assert.Equal(t, "mmc0", active)
+	// act
+	err = a.LEDSetTrigger("led:ACT", "heartbeat")
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "heartbeat", fs.Files[ledTestPath+"/trigger"].Contents)
+	// act
+	err = a.LEDSetTimer("led:ACT", 50, 450)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "timer", fs.Files[ledTestPath+"/trigger"].Contents)
+	assert.Equal(t, "50", fs.Files[ledTestPath+"/delay_on"].Contents)
+	assert.Equal(t, "450", fs.Files[ledTestPath+"/delay_off"].Contents)
+}
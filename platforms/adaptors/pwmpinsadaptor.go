@@ -3,6 +3,7 @@ package adaptors
 import (
 	"fmt"
 	"log"
+	"strings"
 	"sync"
 	"time"
 
@@ -43,6 +44,10 @@ type pwmPinsConfiguration struct {
 	adjustDutyOnSetPeriod      bool
 	pinsDefaultPeriod          map[string]uint32           // the key is the pin id
 	pinsServoScale             map[string]pwmPinServoScale // the key is the pin id
+	useSoftwareFallback        bool
+	pinsSoftware               map[string]bool // the key is the pin id
+	digitalPinnerProvider      gobot.DigitalPinnerProvider
+	classDevices               *ClassDevicesAdaptor
 }
 
 // PWMPinsAdaptor is a adaptor for PWM pins, normally used for composition in platforms.
@@ -67,6 +72,8 @@ type PWMPinsAdaptor struct {
 //	"WithPWMDefaultPeriodForPin"
 //	"WithPWMServoDutyCycleRangeForPin"
 //	"WithPWMServoAngleRangeForPin"
+//	"WithPWMSoftwareFallback"
+//	"WithPWMClassDevices"
 func NewPWMPinsAdaptor(sys *system.Accesser, t pwmPinTranslator, opts ...PwmPinsOptionApplier) *PWMPinsAdaptor {
 	a := PWMPinsAdaptor{
 		sys:       sys,
@@ -75,6 +82,7 @@ func NewPWMPinsAdaptor(sys *system.Accesser, t pwmPinTranslator, opts ...PwmPins
 			periodDefault:              pwmPeriodDefault,
 			pinsDefaultPeriod:          make(map[string]uint32),
 			pinsServoScale:             make(map[string]pwmPinServoScale),
+			pinsSoftware:               make(map[string]bool),
 			polarityNormalIdentifier:   "normal",
 			polarityInvertedIdentifier: "inversed",
 			adjustDutyOnSetPeriod:      true,
@@ -147,6 +155,27 @@ func WithPWMServoAngleRangeForPin(pin string, minimum, maximum float64) pwmPinsS
 	return pwmPinsServoAngleScaleForPinOption{id: pin, minDegree: minimum, maxDegree: maximum}
 }
 
+// WithPWMSoftwareFallback activates the software PWM on digital pins for each pin, which can not be translated to a
+// hardware PWM pin. The given pins are always used with software PWM, e.g. if the translation does not fail, but the
+// hardware PWM is not usable. The software PWM is suitable for LED's and hobby servos, but not for high frequencies,
+// see also "system.NewPWMPinSoft()". The platform needs to provide the digital pins, see
+// "WithPWMDigitalPinnerProvider()".
+func WithPWMSoftwareFallback(pins ...string) pwmPinsSoftwareFallbackOption {
+	return pwmPinsSoftwareFallbackOption(pins)
+}
+
+// WithPWMDigitalPinnerProvider sets the provider of the digital pins, which are used for the software PWM fallback.
+// This is normally done by the platform, e.g. with its digital pins adaptor.
+func WithPWMDigitalPinnerProvider(provider gobot.DigitalPinnerProvider) pwmPinsDigitalPinnerProviderOption {
+	return pwmPinsDigitalPinnerProviderOption{provider: provider}
+}
+
+// WithPWMClassDevices sets the adaptor of the class devices, which is used for writing the pins with the prefix "led:"
+// or "hwmon:". This is normally done by the platform, e.g. with its class devices adaptor.
+func WithPWMClassDevices(devices *ClassDevicesAdaptor) pwmPinsClassDevicesOption {
+	return pwmPinsClassDevicesOption{devices: devices}
+}
+
 // Connect prepare new connection to PWM pins.
 func (a *PWMPinsAdaptor) Connect() error {
 	a.mutex.Lock()
@@ -181,8 +210,13 @@ func (a *PWMPinsAdaptor) Finalize() error {
 	return err
 }
 
-// PwmWrite writes a PWM signal to the specified pin. The given value is between 0 and 255.
+// PwmWrite writes a PWM signal to the specified pin. The given value is between 0 and 255. LED's are supported by the
+// prefix "led:" and fans by the prefix "hwmon:", if the class devices are given by the option "WithPWMClassDevices()".
 func (a *PWMPinsAdaptor) PwmWrite(id string, val byte) error {
+	if ok, err := a.pwmPinsCfg.classDevices.pwmWrite(id, val); ok {
+		return err
+	}
+
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
 
@@ -261,6 +295,25 @@ func (a *PWMPinsAdaptor) PWMPin(id string) (gobot.PWMPinner, error) {
 	return a.pwmPin(id)
 }
 
+// ClaimPWMPin registers the given pin and the related channel of the pwmchip for the given owner, usually the name of
+// the driver. An error is returned, if the pin or the channel is already owned by another device.
+func (a *PWMPinsAdaptor) ClaimPWMPin(owner string, id string) error {
+	resources := []system.OwnershipResource{{Kind: system.OwnershipKindPin, Resource: id}}
+	if path, channel, err := a.translate(id); err == nil && !a.pwmPinsCfg.pinsSoftware[id] {
+		resources = append(resources, system.OwnershipResource{
+			Kind:     system.OwnershipKindPWMPin,
+			Resource: fmt.Sprintf("%s/pwm%d", strings.TrimRight(path, "/"), channel),
+		})
+	}
+
+	return a.sys.Ownerships().Claim(owner, resources...)
+}
+
+// ReleasePWMPins removes all pins and channels of the given owner from the ownership registry.
+func (a *PWMPinsAdaptor) ReleasePWMPins(owner string) {
+	a.sys.Ownerships().Release(owner, system.OwnershipKindPin, system.OwnershipKindPWMPin)
+}
+
 func (a *PWMPinsAdaptor) getDefaultInitializer() func(string, gobot.PWMPinner) error {
 	return func(id string, pin gobot.PWMPinner) error {
 		if err := pin.Export(); err != nil {
@@ -321,13 +374,16 @@ func (a *PWMPinsAdaptor) pwmPin(id string) (gobot.PWMPinner, error) {
 
 	if pin == nil {
 		path, channel, err := a.translate(id)
-		if err != nil {
+		switch {
+		case a.pwmPinsCfg.pinsSoftware[id] || (err != nil && a.pwmPinsCfg.useSoftwareFallback):
+			if pin, err = a.softwarePWMPin(id, err); err != nil {
+				return nil, err
+			}
+		case err != nil:
 			return nil, err
-		}
-
-		if a.pwmPinsCfg.usePiBlasterPin {
+		case a.pwmPinsCfg.usePiBlasterPin:
 			pin = newPiBlasterPWMPin(a.sys, channel)
-		} else {
+		default:
 			pin = a.sys.NewPWMPin(path, channel, a.pwmPinsCfg.polarityNormalIdentifier,
 				a.pwmPinsCfg.polarityInvertedIdentifier)
 		}
@@ -340,6 +396,29 @@ func (a *PWMPinsAdaptor) pwmPin(id string) (gobot.PWMPinner, error) {
 	return pin, nil
 }
 
+// softwarePWMPin creates a software PWM pin on the digital pin with the same id. The given error of the translation is
+// reported together with the error of the fallback, if any.
+func (a *PWMPinsAdaptor) softwarePWMPin(id string, translateErr error) (gobot.PWMPinner, error) {
+	provider := a.pwmPinsCfg.digitalPinnerProvider
+	if provider == nil {
+		err := fmt.Errorf("software PWM for pin '%s' not possible, because no digital pin provider is given", id)
+		if translateErr != nil {
+			err = multierror.Append(translateErr, err)
+		}
+		return nil, err
+	}
+
+	digitalPin, err := provider.DigitalPin(id)
+	if err != nil {
+		if translateErr != nil {
+			err = multierror.Append(translateErr, err)
+		}
+		return nil, err
+	}
+
+	return a.sys.NewPWMPinSoft(digitalPin), nil
+}
+
 func (a *PWMPinsAdaptor) validateDutyCycle(id string, dutyNanos, periodNanos float64) error {
 	if periodNanos == 0 {
 		return nil
@@ -51,6 +51,7 @@ var (
 	_ gobot.PWMPinnerProvider = (*PWMPinsAdaptor)(nil)
 	_ gpio.PwmWriter          = (*PWMPinsAdaptor)(nil)
 	_ gpio.ServoWriter        = (*PWMPinsAdaptor)(nil)
+	_ gpio.PWMPinClaimer      = (*PWMPinsAdaptor)(nil)
 )
 
 func initTestPWMPinsAdaptorWithMockedFilesystem(mockPaths []string) (*PWMPinsAdaptor, *system.MockFilesystem) {
@@ -450,6 +451,81 @@ func Test_PWMPin(t *testing.T) {
 	}
 }
 
+func TestPWMPinSoftwareFallback(t *testing.T) {
+	const translateErr = "translator_error"
+	tests := map[string]struct {
+		translate   pwmPinTranslator
+		options     []PwmPinsOptionApplier
+		noProvider  bool
+		wantErr     string
+		wantTypeSys bool
+	}{
+		"fallback_on_translate_error": {
+			translate: func(string) (string, int, error) { return "", -1, errors.New(translateErr) },
+			options:   []PwmPinsOptionApplier{WithPWMSoftwareFallback()},
+		},
+		"forced_software_pin": {
+			translate: testPWMPinTranslator,
+			options:   []PwmPinsOptionApplier{WithPWMSoftwareFallback("33")},
+		},
+		"no_fallback_for_translatable_pin": {
+			translate:   testPWMPinTranslator,
+			options:     []PwmPinsOptionApplier{WithPWMSoftwareFallback("34")},
+			wantTypeSys: true,
+		},
+		"error_no_fallback": {
+			translate: func(string) (string, int, error) { return "", -1, errors.New(translateErr) },
+			wantErr:   translateErr,
+		},
+		"error_no_provider": {
+			translate:  func(string) (string, int, error) { return "", -1, errors.New(translateErr) },
+			options:    []PwmPinsOptionApplier{WithPWMSoftwareFallback()},
+			noProvider: true,
+			wantErr:    "software PWM for pin '33' not possible, because no digital pin provider is given",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			sys := system.NewAccesser()
+			fs := sys.UseMockFilesystem(pwmMockPaths)
+			fs.Files[pwm44PeriodPath].Contents = "0"
+			fs.Files[pwm44DutyCyclePath].Contents = "0"
+			dpa := sys.UseMockDigitalPinAccess()
+			options := tc.options
+			if !tc.noProvider {
+				options = append(options, WithPWMDigitalPinnerProvider(dpa))
+			}
+			a := NewPWMPinsAdaptor(sys, tc.translate, options...)
+			require.NoError(t, a.Connect())
+			// act
+			got, err := a.PWMPin("33")
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+			require.NoError(t, err)
+			if tc.wantTypeSys {
+				assert.Equal(t, "1", fs.Files[pwm44EnablePath].Contents)
+				return
+			}
+			period, _ := got.Period()
+			assert.Equal(t, uint32(pwmPeriodDefault), period)
+			enabled, _ := got.Enabled()
+			assert.True(t, enabled)
+			require.NoError(t, a.ServoWrite("33", 90))
+			duty, _ := got.DutyCycle()
+			assert.Equal(t, uint32(750000), duty)
+			require.NoError(t, a.Finalize())
+			enabled, _ = got.Enabled()
+			assert.False(t, enabled)
+			assert.Equal(t, 1, dpa.AppliedOptions("", "33"))
+		})
+	}
+}
+
 func TestPWMPinConcurrency(t *testing.T) {
 	oldProcs := runtime.GOMAXPROCS(0)
 	runtime.GOMAXPROCS(8)
@@ -476,3 +552,21 @@ func TestPWMPinConcurrency(t *testing.T) {
 		wg.Wait()
 	}
 }
+
+func TestPWMClaimPWMPin(t *testing.T) {
+	// arrange
+	a, _ := initTestPWMPinsAdaptorWithMockedFilesystem(pwmMockPaths)
+	require.NoError(t, a.ClaimPWMPin("Servo", "33"))
+	// act
+	err := a.ClaimPWMPin("Motor", "33")
+	// assert
+	require.EqualError(t, err, "'Motor' can not claim pin '33', because it is already owned by 'Servo'")
+	owner, ok := a.sys.Ownerships().Owner(system.OwnershipKindPWMPin, pwmDir+"pwm44")
+	assert.True(t, ok)
+	assert.Equal(t, "Servo", owner)
+	// act
+	a.ReleasePWMPins("Servo")
+	// assert
+	require.NoError(t, a.ClaimPWMPin("Motor", "33"))
+	assert.Len(t, a.sys.Ownerships().Table(), 2)
+}
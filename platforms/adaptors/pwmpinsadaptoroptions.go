@@ -1,6 +1,10 @@
 package adaptors
 
-import "time"
+import (
+	"time"
+
+	"gobot.io/x/gobot/v2"
+)
 
 // PwmPinsOptionApplier needs to be implemented by each configurable option type
 type PwmPinsOptionApplier interface {
@@ -55,6 +59,22 @@ type pwmPinsServoAngleScaleForPinOption struct {
 	maxDegree float64
 }
 
+// pwmPinsSoftwareFallbackOption is the type for activating the software PWM fallback, the given pin ids are always
+// used with software PWM.
+type pwmPinsSoftwareFallbackOption []string
+
+// pwmPinsDigitalPinnerProviderOption is the type for applying the provider of digital pins, which is used for the
+// software PWM fallback.
+type pwmPinsDigitalPinnerProviderOption struct {
+	provider gobot.DigitalPinnerProvider
+}
+
+// pwmPinsClassDevicesOption is the type for applying the adaptor of the class devices, which is used for the pins with
+// the prefix "led:" or "hwmon:".
+type pwmPinsClassDevicesOption struct {
+	devices *ClassDevicesAdaptor
+}
+
 func (o pwmPinsInitializeOption) String() string {
 	return "pin initializer option for PWM's"
 }
@@ -95,6 +115,18 @@ func (o pwmPinsServoAngleScaleForPinOption) String() string {
 	return "angle min-max range for a servo pin option for PWM's"
 }
 
+func (o pwmPinsSoftwareFallbackOption) String() string {
+	return "software fallback option for PWM's"
+}
+
+func (o pwmPinsDigitalPinnerProviderOption) String() string {
+	return "digital pin provider for software fallback option for PWM's"
+}
+
+func (o pwmPinsClassDevicesOption) String() string {
+	return "class devices for LED and hwmon pins option for PWM's"
+}
+
 func (o pwmPinsInitializeOption) apply(cfg *pwmPinsConfiguration) {
 	cfg.initialize = pwmPinInitializer(o)
 }
@@ -150,3 +182,18 @@ func (o pwmPinsServoAngleScaleForPinOption) apply(cfg *pwmPinsConfiguration) {
 
 	cfg.pinsServoScale[o.id] = scale
 }
+
+func (o pwmPinsSoftwareFallbackOption) apply(cfg *pwmPinsConfiguration) {
+	cfg.useSoftwareFallback = true
+	for _, id := range o {
+		cfg.pinsSoftware[id] = true
+	}
+}
+
+func (o pwmPinsDigitalPinnerProviderOption) apply(cfg *pwmPinsConfiguration) {
+	cfg.digitalPinnerProvider = o.provider
+}
+
+func (o pwmPinsClassDevicesOption) apply(cfg *pwmPinsConfiguration) {
+	cfg.classDevices = o.devices
+}
@@ -176,6 +176,26 @@ func TestWithPWMServoAngleRangeForPin(t *testing.T) {
 	}
 }
 
+func TestWithPWMSoftwareFallback(t *testing.T) {
+	// arrange
+	cfg := &pwmPinsConfiguration{pinsSoftware: make(map[string]bool)}
+	// act
+	WithPWMSoftwareFallback("11", "12").apply(cfg)
+	// assert
+	assert.True(t, cfg.useSoftwareFallback)
+	assert.Equal(t, map[string]bool{"11": true, "12": true}, cfg.pinsSoftware)
+}
+
+func TestWithPWMDigitalPinnerProvider(t *testing.T) {
+	// arrange
+	cfg := &pwmPinsConfiguration{}
+	provider := system.NewAccesser().UseMockDigitalPinAccess()
+	// act
+	WithPWMDigitalPinnerProvider(provider).apply(cfg)
+	// assert
+	assert.Equal(t, provider, cfg.digitalPinnerProvider)
+}
+
 func TestStringer(t *testing.T) {
 	assert.NotEmpty(t, pwmPinsInitializeOption(nil).String())
 	assert.NotEmpty(t, pwmPinsUsePiBlasterPinOption(true).String())
@@ -187,4 +207,6 @@ func TestStringer(t *testing.T) {
 	assert.NotEmpty(t, pwmPinsDefaultPeriodForPinOption{}.String())
 	assert.NotEmpty(t, pwmPinsServoDutyScaleForPinOption{}.String())
 	assert.NotEmpty(t, pwmPinsServoAngleScaleForPinOption{}.String())
+	assert.NotEmpty(t, pwmPinsSoftwareFallbackOption{}.String())
+	assert.NotEmpty(t, pwmPinsDigitalPinnerProviderOption{}.String())
 }
@@ -32,6 +32,8 @@ type SpiBusAdaptor struct {
 	spiBusCfg         *spiBusConfiguration
 	mutex             sync.Mutex
 	connections       map[string]spi.Connection
+	busPins           map[int][]string
+	translateBusPin   digitalPinTranslator
 }
 
 // NewSpiBusAdaptor provides the access to SPI buses of the board. The validator is used to check the
@@ -53,6 +55,7 @@ func NewSpiBusAdaptor(
 		defaultBitCount:   bits,
 		defaultMaxSpeed:   maxSpeed,
 		spiBusCfg:         &spiBusConfiguration{spiGpioPinnerProvider: spiGpioPinnerProvider},
+		busPins:           make(map[int][]string),
 	}
 
 	for _, o := range opts {
@@ -69,6 +72,12 @@ func WithSpiDebug() spiBusDebugOption {
 	return spiBusDebugOption(true)
 }
 
+// WithSpiCdevAccess can be used to switch the default SPI implementation (periph.io) to the native character device
+// access, which supports multi-segment transfers with per segment settings.
+func WithSpiCdevAccess() spiBusCdevForSystemSpiOption {
+	return spiBusCdevForSystemSpiOption(true)
+}
+
 // WithSpiGpioAccess can be used to switch the default SPI implementation to GPIO usage.
 func WithSpiGpioAccess(sclkPin, ncsPin, sdoPin, sdiPin string) spiBusDigitalPinsForSystemSpiOption {
 	o := spiBusDigitalPinsForSystemSpiOption{
@@ -148,6 +157,67 @@ func (a *SpiBusAdaptor) GetSpiConnection(busNum, chipNum, mode, bits int, maxSpe
 	return con, nil
 }
 
+// SetSpiBusPins defines the pins, which are used by the given bus, including all chip select pins. The pins are named
+// like for the digital pins of the platform. As long as a chip of the bus is claimed, the pins can not be claimed by
+// other devices.
+func (a *SpiBusAdaptor) SetSpiBusPins(busNum int, pins ...string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.busPins[busNum] = pins
+}
+
+// SetSpiBusPinTranslator defines the translator of the bus pins, normally the same as for the digital pins of the
+// platform. The lines of the bus pins are claimed together with the pins, so a pin of an active bus can not be claimed
+// by another name, e.g. by the line name "GPIO2" instead of the header pin "3".
+func (a *SpiBusAdaptor) SetSpiBusPinTranslator(translate func(id string) (string, int, error)) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.translateBusPin = translate
+}
+
+// ClaimSpiChip registers the given chip select of the bus for the given owner, usually the name of the driver. The
+// pins of the bus are registered for the bus itself. An error is returned, if the chip is already owned by another
+// device or a pin of the bus is in use, e.g. as digital pin.
+func (a *SpiBusAdaptor) ClaimSpiChip(owner string, busNum int, chipNum int) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if err := a.validateBusNumber(busNum); err != nil {
+		return err
+	}
+
+	registry := a.sys.Ownerships()
+	var busResources []system.OwnershipResource
+	for _, pin := range a.busPins[busNum] {
+		busResources = append(busResources, digitalPinResources(a.translateBusPin, pin)...)
+	}
+	if err := registry.Claim(spiBusName(busNum), busResources...); err != nil {
+		return fmt.Errorf("SPI bus %d can not be used by '%s': %w", busNum, owner, err)
+	}
+
+	resource := system.OwnershipResource{Kind: system.OwnershipKindSpi, Resource: spiChipResource(busNum, chipNum)}
+	if err := registry.Claim(owner, resource); err != nil {
+		a.releaseUnusedBusPins(busNum)
+		return err
+	}
+
+	return nil
+}
+
+// ReleaseSpiChips removes all chips of the given owner from the ownership registry. The pins of a bus are released, if
+// no chip of the bus is owned anymore.
+func (a *SpiBusAdaptor) ReleaseSpiChips(owner string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.sys.Ownerships().Release(owner, system.OwnershipKindSpi)
+	for busNum := range a.busPins {
+		a.releaseUnusedBusPins(busNum)
+	}
+}
+
 // SpiDefaultBusNumber returns the default bus number for this platform.
 func (a *SpiBusAdaptor) SpiDefaultBusNumber() int {
 	return a.defaultBusNumber
@@ -172,3 +242,18 @@ func (a *SpiBusAdaptor) SpiDefaultBitCount() int {
 func (a *SpiBusAdaptor) SpiDefaultMaxSpeed() int64 {
 	return a.defaultMaxSpeed
 }
+
+func (a *SpiBusAdaptor) releaseUnusedBusPins(busNum int) {
+	registry := a.sys.Ownerships()
+	if !registry.HasResourcePrefix(system.OwnershipKindSpi, spiBusName(busNum)+".") {
+		registry.Release(spiBusName(busNum), system.OwnershipKindPin, system.OwnershipKindDigitalPin)
+	}
+}
+
+func spiBusName(busNum int) string {
+	return fmt.Sprintf("spi%d", busNum)
+}
+
+func spiChipResource(busNum int, chipNum int) string {
+	return fmt.Sprintf("%s.%d", spiBusName(busNum), chipNum)
+}
@@ -12,7 +12,10 @@ import (
 )
 
 // make sure that this SpiBusAdaptor fulfills all the required interfaces
-var _ spi.Connector = (*SpiBusAdaptor)(nil)
+var (
+	_ spi.Connector   = (*SpiBusAdaptor)(nil)
+	_ spi.ChipClaimer = (*SpiBusAdaptor)(nil)
+)
 
 const spiTestAllowedBus = 15
 
@@ -126,3 +129,39 @@ func TestSpiReConnect(t *testing.T) {
 	assert.NotNil(t, a.connections)
 	assert.Empty(t, a.connections)
 }
+
+func TestSpiClaimSpiChip(t *testing.T) {
+	// arrange
+	a, _ := initTestSpiBusAdaptorWithMockedSpi()
+	a.SetSpiBusPins(spiTestAllowedBus, "19", "21", "23", "24")
+	registry := a.sys.Ownerships()
+	require.NoError(t, a.ClaimSpiChip("MCP3008", spiTestAllowedBus, 0))
+	// act & assert: chip is already in use
+	err := a.ClaimSpiChip("MFRC522", spiTestAllowedBus, 0)
+	require.EqualError(t, err, "'MFRC522' can not claim spi 'spi15.0', because it is already owned by 'MCP3008'")
+	// act & assert: pins are used by the bus
+	owner, ok := registry.Owner(system.OwnershipKindPin, "24")
+	assert.True(t, ok)
+	assert.Equal(t, "spi15", owner)
+	// act & assert: invalid bus
+	require.ErrorContains(t, a.ClaimSpiChip("MFRC522", 1, 0), "1 not valid")
+	// act
+	a.ReleaseSpiChips("MCP3008")
+	// assert
+	assert.Empty(t, registry.Table())
+}
+
+func TestSpiClaimSpiChipLineAlias(t *testing.T) {
+	// arrange
+	a, _ := initTestSpiBusAdaptorWithMockedSpi()
+	a.SetSpiBusPins(spiTestAllowedBus, "19", "24")
+	a.SetSpiBusPinTranslator(testBusPinTranslator)
+	dpa := NewDigitalPinsAdaptor(a.sys, testBusPinTranslator)
+	require.NoError(t, a.ClaimSpiChip("MCP3008", spiTestAllowedBus, 0))
+	// act
+	err := dpa.ClaimDigitalPin("LED", "GPIO8")
+	// assert
+	require.EqualError(t, err, "'LED' can not claim gpio 'gpiochip0:8', because it is already owned by 'spi15'")
+	a.ReleaseSpiChips("MCP3008")
+	assert.Empty(t, a.sys.Ownerships().Table())
+}
@@ -12,6 +12,9 @@ type SpiBusOptionApplier interface {
 // spiBusDebugOption is the type to switch on SPI related debug messages.
 type spiBusDebugOption bool
 
+// spiBusCdevForSystemSpiOption is the type to switch the default SPI implementation to the native character device
+type spiBusCdevForSystemSpiOption bool
+
 // spiBusDigitalPinsForSystemSpiOption is the type to switch the default SPI implementation to GPIO usage
 type spiBusDigitalPinsForSystemSpiOption struct {
 	sclkPin string
@@ -24,6 +27,10 @@ func (o spiBusDebugOption) String() string {
 	return "switch on debugging for SPI option"
 }
 
+func (o spiBusCdevForSystemSpiOption) String() string {
+	return "use native character device for SPI option"
+}
+
 func (o spiBusDigitalPinsForSystemSpiOption) String() string {
 	return "use digital pins for SPI option"
 }
@@ -33,6 +40,10 @@ func (o spiBusDebugOption) apply(cfg *spiBusConfiguration) {
 	cfg.systemOptions = append(cfg.systemOptions, system.WithSpiDebug())
 }
 
+func (o spiBusCdevForSystemSpiOption) apply(cfg *spiBusConfiguration) {
+	cfg.systemOptions = append(cfg.systemOptions, system.WithSpiCdevAccess())
+}
+
 func (o spiBusDigitalPinsForSystemSpiOption) apply(cfg *spiBusConfiguration) {
 	cfg.systemOptions = append(cfg.systemOptions, system.WithSpiGpioAccess(cfg.spiGpioPinnerProvider, o.sclkPin, o.ncsPin,
 		o.sdoPin, o.sdiPin))
@@ -36,3 +36,14 @@ func TestNewSpiBusAdaptorWithSpiGpioAccess(t *testing.T) {
 	assert.Equal(t, 1, dpa.AppliedOptions("", sdoPinTranslated))
 	assert.Equal(t, 0, dpa.AppliedOptions("", sdiPinTranslated)) // already input, so no option applied
 }
+
+func TestNewSpiBusAdaptorWithSpiCdevAccess(t *testing.T) {
+	// arrange
+	sys := system.NewAccesser()
+	sys.UseMockFilesystem([]string{"/dev/spidev0.0"})
+	// act
+	a := NewSpiBusAdaptor(sys, nil, 1, 2, 3, 4, 5, nil, WithSpiCdevAccess())
+	// assert
+	assert.True(t, a.sys.HasSpiCdevAccess())
+	assert.False(t, a.sys.HasSpiPeriphioAccess())
+}
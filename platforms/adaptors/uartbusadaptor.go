@@ -0,0 +1,214 @@
+package adaptors
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/system"
+)
+
+// UartBusAdaptor is a adaptor for the UART's of the board, normally used for composition in platforms.
+type UartBusAdaptor struct {
+	sys             *system.Accesser
+	paths           map[int]string
+	defaultUartNum  int
+	defaultBaudRate int
+	mutex           sync.Mutex
+	connections     map[int]*UartConnection
+}
+
+// UartConnection is the connection to one UART of the board. It implements the gobot.Adaptor interface and the
+// interfaces for reading and writing, which are used by the drivers of package "drivers/serial". The connection needs
+// to be added to the connections of the robot, like each other adaptor.
+type UartConnection struct {
+	name     string
+	sys      *system.Accesser
+	path     string
+	baudRate int
+	mutex    sync.Mutex
+	port     io.ReadWriteCloser
+}
+
+// NewUartBusAdaptor provides the access to the UART's of the board. The given paths maps the UART numbers of the board
+// to the character devices, e.g. {0: "/dev/ttyS0"}. Only the UART numbers of the map are valid.
+func NewUartBusAdaptor(sys *system.Accesser, paths map[int]string, defaultUartNum int,
+	defaultBaudRate int,
+) *UartBusAdaptor {
+	a := UartBusAdaptor{
+		sys:             sys,
+		paths:           paths,
+		defaultUartNum:  defaultUartNum,
+		defaultBaudRate: defaultBaudRate,
+		connections:     make(map[int]*UartConnection),
+	}
+
+	sys.AddUartSupport()
+
+	return &a
+}
+
+// Connect prepares the connection to the UART's. The UART connections are connected by itself.
+func (a *UartBusAdaptor) Connect() error {
+	return nil
+}
+
+// Finalize closes all connected UART's.
+func (a *UartBusAdaptor) Finalize() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	var err error
+	for _, con := range a.connections {
+		if e := con.Finalize(); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+	a.connections = make(map[int]*UartConnection)
+	return err
+}
+
+// GetUartConnection returns the connection for the given UART number with the given baud rate. A baud rate of 0 means
+// the default baud rate of the platform. The same connection is returned for the same UART number.
+func (a *UartBusAdaptor) GetUartConnection(uartNum int, baudRate int) (*UartConnection, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	path, err := a.validateNumber(uartNum)
+	if err != nil {
+		return nil, err
+	}
+
+	if baudRate == 0 {
+		baudRate = a.defaultBaudRate
+	}
+
+	if con := a.connections[uartNum]; con != nil {
+		if con.baudRate != baudRate {
+			return nil, fmt.Errorf("UART %d is already used with baud rate %d", uartNum, con.baudRate)
+		}
+		return con, nil
+	}
+
+	con := &UartConnection{
+		name:     gobot.DefaultName("UART"),
+		sys:      a.sys,
+		path:     path,
+		baudRate: baudRate,
+	}
+	a.connections[uartNum] = con
+
+	return con, nil
+}
+
+// DefaultUartNumber returns the default UART number for this platform.
+func (a *UartBusAdaptor) DefaultUartNumber() int {
+	return a.defaultUartNum
+}
+
+// DefaultUartBaudRate returns the default baud rate for the UART's of this platform.
+func (a *UartBusAdaptor) DefaultUartBaudRate() int {
+	return a.defaultBaudRate
+}
+
+func (a *UartBusAdaptor) validateNumber(uartNum int) (string, error) {
+	path, ok := a.paths[uartNum]
+	if !ok {
+		valid := make([]int, 0, len(a.paths))
+		for num := range a.paths {
+			valid = append(valid, num)
+		}
+		sort.Ints(valid)
+		return "", fmt.Errorf("UART number %d out of range %v", uartNum, valid)
+	}
+
+	return path, nil
+}
+
+// Name returns the name of the UART connection.
+func (c *UartConnection) Name() string { return c.name }
+
+// SetName sets the name of the UART connection.
+func (c *UartConnection) SetName(n string) { c.name = n }
+
+// Port returns the path of the character device, e.g. "/dev/ttyS0".
+func (c *UartConnection) Port() string { return c.path }
+
+// BaudRate returns the baud rate of the connection.
+func (c *UartConnection) BaudRate() int { return c.baudRate }
+
+// Connect opens the UART.
+func (c *UartConnection) Connect() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.port != nil {
+		return nil
+	}
+
+	port, err := c.sys.NewUartDevice(c.path, c.baudRate)
+	if err != nil {
+		return err
+	}
+
+	c.port = port
+	return nil
+}
+
+// Finalize closes the UART.
+func (c *UartConnection) Finalize() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.port == nil {
+		return nil
+	}
+
+	err := c.port.Close()
+	c.port = nil
+	return err
+}
+
+// IsConnected returns the connection state.
+func (c *UartConnection) IsConnected() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.port != nil
+}
+
+// SerialRead reads from the UART to the given buffer.
+func (c *UartConnection) SerialRead(b []byte) (int, error) {
+	port, err := c.connectedPort()
+	if err != nil {
+		return 0, err
+	}
+
+	return port.Read(b)
+}
+
+// SerialWrite writes the given data to the UART.
+func (c *UartConnection) SerialWrite(b []byte) (int, error) {
+	port, err := c.connectedPort()
+	if err != nil {
+		return 0, err
+	}
+
+	return port.Write(b)
+}
+
+// connectedPort returns the port without holding the lock, so a blocking read does not block a concurrent write.
+func (c *UartConnection) connectedPort() (io.ReadWriteCloser, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.port == nil {
+		return nil, fmt.Errorf("UART '%s' is not connected", c.path)
+	}
+
+	return c.port, nil
+}
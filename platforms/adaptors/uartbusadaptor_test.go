@@ -0,0 +1,150 @@
+package adaptors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/serial"
+	"gobot.io/x/gobot/v2/system"
+)
+
+// make sure that the UART connection fulfills all the required interfaces
+var (
+	_ gobot.Adaptor       = (*UartConnection)(nil)
+	_ serial.SerialReader = (*UartConnection)(nil)
+	_ serial.SerialWriter = (*UartConnection)(nil)
+)
+
+func initTestUartBusAdaptorWithMockedUart() (*UartBusAdaptor, *system.MockUartAccess) {
+	a := NewUartBusAdaptor(system.NewAccesser(), map[int]string{0: "/dev/ttyS0", 2: "/dev/ttyAMA2"}, 0, 115200)
+	mua := a.sys.UseMockUart()
+	if err := a.Connect(); err != nil {
+		panic(err)
+	}
+	return a, mua
+}
+
+func TestUartWorkflow(t *testing.T) {
+	// arrange
+	a, mua := initTestUartBusAdaptorWithMockedUart()
+	con, err := a.GetUartConnection(2, 9600)
+	require.NoError(t, err)
+	assert.Equal(t, "/dev/ttyAMA2", con.Port())
+	assert.Equal(t, 9600, con.BaudRate())
+	assert.False(t, con.IsConnected())
+	_, err = con.SerialWrite([]byte{0x01})
+	require.EqualError(t, err, "UART '/dev/ttyAMA2' is not connected")
+	// act
+	require.NoError(t, con.Connect())
+	// assert
+	assert.True(t, con.IsConnected())
+	dev := mua.Devices["/dev/ttyAMA2"]
+	assert.Equal(t, 9600, dev.BaudRate)
+	n, err := con.SerialWrite([]byte{0x01, 0x02})
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []byte{0x01, 0x02}, dev.Written())
+	dev.UseReadData([]byte{0x03, 0x04})
+	buf := make([]byte, 4)
+	n, err = con.SerialRead(buf)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x03, 0x04}, buf[:n])
+	// act & assert finalize
+	require.NoError(t, a.Finalize())
+	assert.True(t, dev.Closed)
+	assert.False(t, con.IsConnected())
+}
+
+func TestGetUartConnection(t *testing.T) {
+	tests := map[string]struct {
+		uartNum      int
+		baudRate     int
+		wantPath     string
+		wantBaudRate int
+		wantErr      string
+	}{
+		"default_baud_rate": {
+			uartNum:      0,
+			wantPath:     "/dev/ttyS0",
+			wantBaudRate: 115200,
+		},
+		"given_baud_rate": {
+			uartNum:      2,
+			baudRate:     57600,
+			wantPath:     "/dev/ttyAMA2",
+			wantBaudRate: 57600,
+		},
+		"error_invalid_number": {
+			uartNum: 1,
+			wantErr: "UART number 1 out of range [0 2]",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a, _ := initTestUartBusAdaptorWithMockedUart()
+			// act
+			con, err := a.GetUartConnection(tc.uartNum, tc.baudRate)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				assert.Nil(t, con)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantPath, con.Port())
+			assert.Equal(t, tc.wantBaudRate, con.BaudRate())
+		})
+	}
+}
+
+func TestGetUartConnectionReused(t *testing.T) {
+	// arrange
+	a, _ := initTestUartBusAdaptorWithMockedUart()
+	con1, err := a.GetUartConnection(0, 0)
+	require.NoError(t, err)
+	// act
+	con2, err := a.GetUartConnection(0, 115200)
+	// assert
+	require.NoError(t, err)
+	assert.Same(t, con1, con2)
+	_, err = a.GetUartConnection(0, 9600)
+	require.EqualError(t, err, "UART 0 is already used with baud rate 115200")
+}
+
+func TestUartDefaults(t *testing.T) {
+	// arrange
+	a, _ := initTestUartBusAdaptorWithMockedUart()
+	// act & assert
+	assert.Equal(t, 0, a.DefaultUartNumber())
+	assert.Equal(t, 115200, a.DefaultUartBaudRate())
+}
+
+func TestUartConnectError(t *testing.T) {
+	// arrange
+	a, mua := initTestUartBusAdaptorWithMockedUart()
+	mua.OpenError = true
+	con, err := a.GetUartConnection(0, 0)
+	require.NoError(t, err)
+	// act
+	err = con.Connect()
+	// assert
+	require.EqualError(t, err, "error while open UART '/dev/ttyS0' in mock")
+	assert.False(t, con.IsConnected())
+}
+
+func TestUartFinalizeWithErrors(t *testing.T) {
+	// arrange
+	a, mua := initTestUartBusAdaptorWithMockedUart()
+	con, err := a.GetUartConnection(0, 0)
+	require.NoError(t, err)
+	require.NoError(t, con.Connect())
+	mua.Devices["/dev/ttyS0"].CloseError = true
+	// act
+	err = a.Finalize()
+	// assert
+	require.ErrorContains(t, err, "close error")
+}
@@ -29,6 +29,7 @@ type Tinkerboard2Adaptor struct {
 // Optional parameters:
 //
 //	adaptors.WithGpioSysfsAccess():	use legacy sysfs driver instead of default character device driver
+//	adaptors.WithSpiCdevAccess():	use native /dev/spidev#.# access instead of periph.io
 //	adaptors.WithSpiGpioAccess(sclk, ncs, sdo, sdi):	use GPIO's instead of /dev/spidev#.#
 //	adaptors.WithGpiosActiveLow(pin's): invert the pin behavior
 //	adaptors.WithGpiosPullUp/Down(pin's): sets the internal pull resistor
@@ -67,6 +68,7 @@ func NewAdaptor(opts ...interface{}) *Tinkerboard2Adaptor {
 	spiBusNumberValidator := adaptors.NewBusNumberValidator([]int{1, 5})
 
 	a.DigitalPinsAdaptor = adaptors.NewDigitalPinsAdaptor(sys, digitalPinTranslator.Translate, digitalPinsOpts...)
+	pwmPinsOpts = append(pwmPinsOpts, adaptors.WithPWMDigitalPinnerProvider(a.DigitalPinsAdaptor))
 	a.PWMPinsAdaptor = adaptors.NewPWMPinsAdaptor(sys, pwmPinTranslator.Translate, pwmPinsOpts...)
 	a.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, i2cBusNumberValidator.Validate, defaultI2cBusNumber)
 	a.SpiBusAdaptor = adaptors.NewSpiBusAdaptor(sys, spiBusNumberValidator.Validate, defaultSpiBusNumber,
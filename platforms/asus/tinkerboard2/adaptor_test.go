@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"gobot.io/x/gobot/v2/platforms/adaptors"
+	"gobot.io/x/gobot/v2/platforms/linuxboard"
 )
 
 func TestNewAdaptor(t *testing.T) {
@@ -34,3 +35,18 @@ func TestNewAdaptorWithOption(t *testing.T) {
 	require.NoError(t, a.Connect())
 	assert.True(t, a.sys.HasDigitalPinSysfsAccess())
 }
+
+func TestPinMapsMatchLinuxBoardDefinition(t *testing.T) {
+	// arrange
+	def, err := linuxboard.BoardDefinition("tinkerboard2")
+	require.NoError(t, err)
+	// act & assert
+	assert.Equal(t, gpioPinDefinitions, def.DigitalPinDefinitions())
+	assert.Equal(t, pwmPinDefinitions, def.PWMPinDefinitions())
+	// the analog pins are inherited from the tinkerboard adaptor
+	tinkerboardDef, err := linuxboard.BoardDefinition("tinkerboard")
+	require.NoError(t, err)
+	assert.Equal(t, tinkerboardDef.AnalogPinDefinitions(), def.AnalogPinDefinitions())
+	assert.Equal(t, defaultI2cBusNumber, def.I2c.DefaultBus)
+	assert.Equal(t, defaultSpiBusNumber, def.Spi.DefaultBus)
+}
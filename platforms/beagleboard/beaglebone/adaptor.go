@@ -32,10 +32,12 @@ type Adaptor struct {
 	sys   *system.Accesser
 	mutex *sync.Mutex
 	*adaptors.AnalogPinsAdaptor
+	*adaptors.IIODevicesAdaptor
 	*adaptors.DigitalPinsAdaptor
 	*adaptors.PWMPinsAdaptor
 	*adaptors.I2cBusAdaptor
 	*adaptors.SpiBusAdaptor
+	*adaptors.ClassDevicesAdaptor
 	usrLed string
 }
 
@@ -44,6 +46,7 @@ type Adaptor struct {
 // Optional parameters:
 //
 //	adaptors.WithGpioCdevAccess():	use character device driver instead of sysfs
+//	adaptors.WithSpiCdevAccess():	use native /dev/spidev#.# access instead of periph.io
 //	adaptors.WithSpiGpioAccess(sclk, ncs, sdo, sdi):	use GPIO's instead of /dev/spidev#.#
 //
 //	Optional parameters for PWM, see [adaptors.NewPWMPinsAdaptor]
@@ -80,8 +83,14 @@ func NewAdaptor(opts ...interface{}) *Adaptor {
 	// x is the chip number <255
 	spiBusNumberValidator := adaptors.NewBusNumberValidator([]int{0, 1})
 
-	a.AnalogPinsAdaptor = adaptors.NewAnalogPinsAdaptor(sys, analogPinTranslator.Translate)
+	a.ClassDevicesAdaptor = adaptors.NewClassDevicesAdaptor(sys)
+	a.AnalogPinsAdaptor = adaptors.NewAnalogPinsAdaptor(sys, analogPinTranslator.Translate,
+		adaptors.WithAnalogClassDevices(a.ClassDevicesAdaptor))
+	a.IIODevicesAdaptor = adaptors.NewIIODevicesAdaptor(sys)
+	digitalPinsOpts = append(digitalPinsOpts, adaptors.WithDigitalPinClassDevices(a.ClassDevicesAdaptor))
 	a.DigitalPinsAdaptor = adaptors.NewDigitalPinsAdaptor(sys, a.translateAndMuxDigitalPin, digitalPinsOpts...)
+	pwmPinsOpts = append(pwmPinsOpts, adaptors.WithPWMDigitalPinnerProvider(a.DigitalPinsAdaptor),
+		adaptors.WithPWMClassDevices(a.ClassDevicesAdaptor))
 	a.PWMPinsAdaptor = adaptors.NewPWMPinsAdaptor(sys, a.getTranslateAndMuxPWMPinFunc(pwmPinTranslator.Translate),
 		pwmPinsOpts...)
 	a.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, i2cBusNumberValidator.Validate, defaultI2cBusNumber)
@@ -113,9 +122,17 @@ func (a *Adaptor) Connect() error {
 		return err
 	}
 
+	if err := a.IIODevicesAdaptor.Connect(); err != nil {
+		return err
+	}
+
 	if err := a.PWMPinsAdaptor.Connect(); err != nil {
 		return err
 	}
+
+	if err := a.ClassDevicesAdaptor.Connect(); err != nil {
+		return err
+	}
 	return a.DigitalPinsAdaptor.Connect()
 }
 
@@ -124,9 +141,10 @@ func (a *Adaptor) Finalize() error {
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
 
-	err := a.DigitalPinsAdaptor.Finalize()
+	// the software PWM pins are based on digital pins, so they need to be finalized before
+	err := a.PWMPinsAdaptor.Finalize()
 
-	if e := a.PWMPinsAdaptor.Finalize(); e != nil {
+	if e := a.DigitalPinsAdaptor.Finalize(); e != nil {
 		err = multierror.Append(err, e)
 	}
 
@@ -134,6 +152,14 @@ func (a *Adaptor) Finalize() error {
 		err = multierror.Append(err, e)
 	}
 
+	if e := a.IIODevicesAdaptor.Finalize(); e != nil {
+		err = multierror.Append(err, e)
+	}
+
+	if e := a.ClassDevicesAdaptor.Finalize(); e != nil {
+		err = multierror.Append(err, e)
+	}
+
 	if e := a.I2cBusAdaptor.Finalize(); e != nil {
 		err = multierror.Append(err, e)
 	}
@@ -145,7 +171,7 @@ func (a *Adaptor) Finalize() error {
 }
 
 // DigitalWrite writes a digital value to specified pin.
-// valid usr pin values are usr0, usr1, usr2 and usr3
+// valid usr pin values are usr0, usr1, usr2 and usr3, all other LED's are supported by the prefix "led:"
 func (a *Adaptor) DigitalWrite(id string, val byte) error {
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
@@ -161,6 +161,25 @@ func TestAnalogRead(t *testing.T) {
 	require.NoError(t, a.Finalize())
 }
 
+func TestIIORead(t *testing.T) {
+	mockPaths := []string{
+		"/sys/bus/iio/devices/iio:device0/name",
+		"/sys/bus/iio/devices/iio:device0/in_voltage1_raw",
+		"/sys/bus/iio/devices/iio:device0/in_voltage_scale",
+	}
+
+	a, fs := initConnectedTestAdaptorWithMockedFilesystem(mockPaths)
+
+	fs.Files["/sys/bus/iio/devices/iio:device0/name"].Contents = "TI-am335x-adc.0.auto\n"
+	fs.Files["/sys/bus/iio/devices/iio:device0/in_voltage1_raw"].Contents = "1000\n"
+	fs.Files["/sys/bus/iio/devices/iio:device0/in_voltage_scale"].Contents = "0.439453125\n"
+	got, err := a.IIORead("TI-am335x-adc.0.auto", "in_voltage1")
+	require.NoError(t, err)
+	assert.InDelta(t, 439.453125, got, 0.0)
+
+	require.NoError(t, a.Finalize())
+}
+
 func TestDigitalIO(t *testing.T) {
 	mockPaths := []string{
 		"/sys/devices/platform/ocp/ocp:P8_07_pinmux/state",
@@ -27,6 +27,7 @@ type PocketBeagleAdaptor struct {
 // Optional parameters:
 //
 //	adaptors.WithGpioCdevAccess():	use character device driver instead of sysfs
+//	adaptors.WithSpiCdevAccess():	use native /dev/spidev#.# access instead of periph.io
 //	adaptors.WithSpiGpioAccess(sclk, ncs, sdo, sdi):	use GPIO's instead of /dev/spidev#.#
 //	adaptors.WithGpiosPullUp(pins): will be silently ignored (for some pins)
 //	adaptors.WithGpioDebounce(inPinNum, debounceTime): is only supported for debounceTime < 8ms
@@ -61,6 +62,7 @@ func NewAdaptor(opts ...interface{}) *PocketBeagleAdaptor {
 	a.AnalogPinsAdaptor = adaptors.NewAnalogPinsAdaptor(sys, analogPinTranslator.Translate)
 	a.DigitalPinsAdaptor = adaptors.NewDigitalPinsAdaptor(sys,
 		a.getTranslateAndMuxDigitalPinFunc(digitalPinTranslator.Translate), digitalPinsOpts...)
+	pwmPinsOpts = append(pwmPinsOpts, adaptors.WithPWMDigitalPinnerProvider(a.DigitalPinsAdaptor))
 	a.PWMPinsAdaptor = adaptors.NewPWMPinsAdaptor(sys, a.getTranslateAndMuxPWMPinFunc(pwmPinTranslator.Translate),
 		pwmPinsOpts...)
 
@@ -38,6 +38,7 @@ type Adaptor struct {
 	*adaptors.DigitalPinsAdaptor
 	*adaptors.PWMPinsAdaptor
 	*adaptors.I2cBusAdaptor
+	*adaptors.ClassDevicesAdaptor
 	*adaptors.SpiBusAdaptor // for usage of "adaptors.WithSpiGpioAccess()"
 }
 
@@ -46,6 +47,7 @@ type Adaptor struct {
 // Optional parameters:
 //
 //	adaptors.WithGpioCdevAccess():	use character device driver instead of sysfs
+//	adaptors.WithSpiCdevAccess():	use native /dev/spidev#.# access instead of periph.io
 //	adaptors.WithSpiGpioAccess(sclk, ncs, sdo, sdi):	use GPIO's instead of /dev/spidev#.#
 //
 //	Optional parameters for PWM, see [adaptors.NewPWMPinsAdaptor]
@@ -82,7 +84,11 @@ func NewAdaptor(opts ...interface{}) *Adaptor {
 	// Valid bus numbers are [0..2] which corresponds to /dev/i2c-0 through /dev/i2c-2.
 	i2cBusNumberValidator := adaptors.NewBusNumberValidator([]int{0, 1, 2})
 
+	a.ClassDevicesAdaptor = adaptors.NewClassDevicesAdaptor(sys)
+	digitalPinsOpts = append(digitalPinsOpts, adaptors.WithDigitalPinClassDevices(a.ClassDevicesAdaptor))
 	a.DigitalPinsAdaptor = adaptors.NewDigitalPinsAdaptor(sys, a.translateDigitalPin, digitalPinsOpts...)
+	pwmPinsOpts = append(pwmPinsOpts, adaptors.WithPWMDigitalPinnerProvider(a.DigitalPinsAdaptor),
+		adaptors.WithPWMClassDevices(a.ClassDevicesAdaptor))
 	a.PWMPinsAdaptor = adaptors.NewPWMPinsAdaptor(sys, a.translatePWMPin, pwmPinsOpts...)
 	a.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, i2cBusNumberValidator.Validate, defaultI2cBusNumber)
 
@@ -113,6 +119,10 @@ func (a *Adaptor) Connect() error {
 	if err := a.PWMPinsAdaptor.Connect(); err != nil {
 		return err
 	}
+	if err := a.ClassDevicesAdaptor.Connect(); err != nil {
+		return err
+	}
+
 	return a.DigitalPinsAdaptor.Connect()
 }
 
@@ -121,9 +131,10 @@ func (a *Adaptor) Finalize() error {
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
 
-	err := a.DigitalPinsAdaptor.Finalize()
+	// the software PWM pins are based on digital pins, so they need to be finalized before
+	err := a.PWMPinsAdaptor.Finalize()
 
-	if e := a.PWMPinsAdaptor.Finalize(); e != nil {
+	if e := a.DigitalPinsAdaptor.Finalize(); e != nil {
 		err = multierror.Append(err, e)
 	}
 
@@ -131,6 +142,10 @@ func (a *Adaptor) Finalize() error {
 		err = multierror.Append(err, e)
 	}
 
+	if e := a.ClassDevicesAdaptor.Finalize(); e != nil {
+		err = multierror.Append(err, e)
+	}
+
 	return err
 }
 
@@ -24,7 +24,9 @@ type Adaptor struct {
 	mutex  sync.Mutex
 	pinMap map[string]int
 	*adaptors.DigitalPinsAdaptor
+	*adaptors.PWMPinsAdaptor
 	*adaptors.I2cBusAdaptor
+	*adaptors.ClassDevicesAdaptor
 	*adaptors.SpiBusAdaptor // for usage of "adaptors.WithSpiGpioAccess()"
 }
 
@@ -54,6 +56,7 @@ var fixedPins = map[string]int{
 // Optional parameters:
 //
 //	adaptors.WithGpioCdevAccess():	use character device driver instead of sysfs
+//	adaptors.WithSpiCdevAccess():	use native /dev/spidev#.# access instead of periph.io
 //	adaptors.WithSpiGpioAccess(sclk, ncs, sdo, sdi):	use GPIO's instead of /dev/spidev#.#
 func NewAdaptor(opts ...interface{}) *Adaptor {
 	sys := system.NewAccesser(system.WithDigitalPinSysfsAccess())
@@ -78,7 +81,12 @@ func NewAdaptor(opts ...interface{}) *Adaptor {
 	// Valid bus numbers are [0,1] which corresponds to /dev/i2c-0 through /dev/i2c-1.
 	i2cBusNumberValidator := adaptors.NewBusNumberValidator([]int{0, 1})
 
+	a.ClassDevicesAdaptor = adaptors.NewClassDevicesAdaptor(sys)
+	digitalPinsOpts = append(digitalPinsOpts, adaptors.WithDigitalPinClassDevices(a.ClassDevicesAdaptor))
 	a.DigitalPinsAdaptor = adaptors.NewDigitalPinsAdaptor(sys, a.translateDigitalPin, digitalPinsOpts...)
+	// PWM is only supported for the LED's and fans of the board, e.g. "led:<name>" or "hwmon:pwmfan/pwm1"
+	a.PWMPinsAdaptor = adaptors.NewPWMPinsAdaptor(sys, adaptors.NewPWMPinTranslator(sys, nil).Translate,
+		adaptors.WithPWMClassDevices(a.ClassDevicesAdaptor))
 	a.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, i2cBusNumberValidator.Validate, defaultI2cBusNumber)
 
 	// SPI is only supported when "adaptors.WithSpiGpioAccess()" is given
@@ -110,6 +118,14 @@ func (a *Adaptor) Connect() error {
 		return err
 	}
 
+	if err := a.PWMPinsAdaptor.Connect(); err != nil {
+		return err
+	}
+
+	if err := a.ClassDevicesAdaptor.Connect(); err != nil {
+		return err
+	}
+
 	return a.DigitalPinsAdaptor.Connect()
 }
 
@@ -124,6 +140,14 @@ func (a *Adaptor) Finalize() error {
 		err = multierror.Append(err, e)
 	}
 
+	if e := a.PWMPinsAdaptor.Finalize(); e != nil {
+		err = multierror.Append(err, e)
+	}
+
+	if e := a.ClassDevicesAdaptor.Finalize(); e != nil {
+		err = multierror.Append(err, e)
+	}
+
 	return err
 }
 
@@ -0,0 +1,244 @@
+package client
+
+import (
+	"fmt"
+	"math"
+)
+
+// AccelStepper sub commands, see https://github.com/firmata/protocol/blob/master/accelStepperFirmata.md
+const (
+	StepperConfig            byte = 0x00
+	StepperZero              byte = 0x01
+	StepperStep              byte = 0x02
+	StepperTo                byte = 0x03
+	StepperEnable            byte = 0x04
+	StepperStop              byte = 0x05
+	StepperReportPosition    byte = 0x06
+	StepperSetAcceleration   byte = 0x08
+	StepperSetSpeed          byte = 0x09
+	StepperMoveComplete      byte = 0x0A
+	MultiStepperConfig       byte = 0x20
+	MultiStepperTo           byte = 0x21
+	MultiStepperStop         byte = 0x23
+	MultiStepperMoveComplete byte = 0x24
+)
+
+// AccelStepper interfaces and step sizes
+const (
+	StepperInterfaceDriver    = 0x01 // step and direction pin, e.g. for A4988
+	StepperInterfaceTwoWire   = 0x02
+	StepperInterfaceThreeWire = 0x03
+	StepperInterfaceFourWire  = 0x04
+	StepperWholeStep          = 0x00
+	StepperHalfStep           = 0x01
+)
+
+// stepperMaxSignificand is the limit of the 23 bit significand of the custom float encoding
+const stepperMaxSignificand = 1 << 23
+
+// AccelStepperSettings represents the configuration of a stepper motor
+type AccelStepperSettings struct {
+	Interface  int   // StepperInterfaceDriver, StepperInterfaceTwoWire, ...ThreeWire or ...FourWire
+	StepSize   int   // StepperWholeStep or StepperHalfStep
+	Pins       []int // step and direction pin for the driver interface, otherwise the 2..4 motor pins
+	EnablePin  int   // a value < 0 means there is no enable pin
+	InvertPins int   // bit mask of the pins to invert, bit 0 for the first pin, bit 4 for the enable pin
+}
+
+// StepperPositionReply represents the position of a stepper motor, reported on request or after a move is completed
+type StepperPositionReply struct {
+	Device   int
+	Position int32
+}
+
+// AccelStepperConfig configures the stepper motor with the given device number (0..9).
+func (b *Client) AccelStepperConfig(device int, settings AccelStepperSettings) error {
+	iface := byte(settings.Interface<<4) | byte(settings.StepSize<<1)
+	if settings.EnablePin >= 0 {
+		iface |= 0x01
+	}
+
+	ret := []byte{AccelStepperData, StepperConfig, byte(device), iface}
+	for _, pin := range settings.Pins {
+		ret = append(ret, byte(pin))
+	}
+	if settings.EnablePin >= 0 {
+		ret = append(ret, byte(settings.EnablePin))
+	}
+	if settings.InvertPins != 0 {
+		ret = append(ret, byte(settings.InvertPins&0x1F))
+	}
+	return b.WriteSysex(ret)
+}
+
+// AccelStepperZero sets the current position of the stepper motor to zero.
+func (b *Client) AccelStepperZero(device int) error {
+	return b.WriteSysex([]byte{AccelStepperData, StepperZero, byte(device)})
+}
+
+// AccelStepperStep moves the stepper motor by the given steps, relative to the current position.
+func (b *Client) AccelStepperStep(device int, steps int32) error {
+	return b.WriteSysex(append([]byte{AccelStepperData, StepperStep, byte(device)}, encodeSigned32Bit(steps)...))
+}
+
+// AccelStepperTo moves the stepper motor to the absolute position.
+func (b *Client) AccelStepperTo(device int, position int32) error {
+	return b.WriteSysex(append([]byte{AccelStepperData, StepperTo, byte(device)}, encodeSigned32Bit(position)...))
+}
+
+// AccelStepperEnable switches the enable pin of the stepper motor.
+func (b *Client) AccelStepperEnable(device int, enable bool) error {
+	state := byte(0x00)
+	if enable {
+		state = 0x01
+	}
+	return b.WriteSysex([]byte{AccelStepperData, StepperEnable, byte(device), state})
+}
+
+// AccelStepperStop stops the stepper motor, the position is published by the "StepperMoveComplete" event.
+func (b *Client) AccelStepperStop(device int) error {
+	return b.WriteSysex([]byte{AccelStepperData, StepperStop, byte(device)})
+}
+
+// AccelStepperReportPosition requests the current position, which is published by the "StepperPosition" event.
+func (b *Client) AccelStepperReportPosition(device int) error {
+	return b.WriteSysex([]byte{AccelStepperData, StepperReportPosition, byte(device)})
+}
+
+// AccelStepperSetAcceleration sets the acceleration in steps per second². A value of 0 disables the acceleration.
+func (b *Client) AccelStepperSetAcceleration(device int, acceleration float64) error {
+	val, err := encodeCustomFloat(acceleration)
+	if err != nil {
+		return err
+	}
+	return b.WriteSysex(append([]byte{AccelStepperData, StepperSetAcceleration, byte(device)}, val...))
+}
+
+// AccelStepperSetSpeed sets the maximum speed in steps per second, respectively the constant speed, if the
+// acceleration is disabled.
+func (b *Client) AccelStepperSetSpeed(device int, speed float64) error {
+	val, err := encodeCustomFloat(speed)
+	if err != nil {
+		return err
+	}
+	return b.WriteSysex(append([]byte{AccelStepperData, StepperSetSpeed, byte(device)}, val...))
+}
+
+// MultiStepperConfig configures the group (0..4) of stepper motors, which are moved together.
+func (b *Client) MultiStepperConfig(group int, devices []int) error {
+	ret := []byte{AccelStepperData, MultiStepperConfig, byte(group)}
+	for _, device := range devices {
+		ret = append(ret, byte(device))
+	}
+	return b.WriteSysex(ret)
+}
+
+// MultiStepperTo moves all stepper motors of the group to the absolute positions, so that all motors arrive at the
+// same time. The positions are in order of the configured devices. The "MultiStepperMoveComplete" event is published
+// with the group number, when all motors arrived.
+func (b *Client) MultiStepperTo(group int, positions []int32) error {
+	ret := []byte{AccelStepperData, MultiStepperTo, byte(group)}
+	for _, position := range positions {
+		ret = append(ret, encodeSigned32Bit(position)...)
+	}
+	return b.WriteSysex(ret)
+}
+
+// MultiStepperStop stops all stepper motors of the group.
+func (b *Client) MultiStepperStop(group int) error {
+	return b.WriteSysex([]byte{AccelStepperData, MultiStepperStop, byte(group)})
+}
+
+func (b *Client) processAccelStepper(currentBuffer []byte) {
+	switch {
+	case len(currentBuffer) >= 10 &&
+		(currentBuffer[2] == StepperReportPosition || currentBuffer[2] == StepperMoveComplete):
+		reply := StepperPositionReply{
+			Device:   int(currentBuffer[3]),
+			Position: decodeSigned32Bit(currentBuffer[4:9]),
+		}
+		event := "StepperPosition"
+		if currentBuffer[2] == StepperMoveComplete {
+			event = "StepperMoveComplete"
+		}
+		b.Publish(b.Event(event), reply)
+	case len(currentBuffer) >= 5 && currentBuffer[2] == MultiStepperMoveComplete:
+		b.Publish(b.Event("MultiStepperMoveComplete"), int(currentBuffer[3]))
+	default:
+		b.publishSysexResponse(currentBuffer)
+	}
+}
+
+// encodeSigned32Bit encodes the value to 5 bytes, the sign is in bit 3 of the last byte
+func encodeSigned32Bit(val int32) []byte {
+	abs := int64(val)
+	negative := abs < 0
+	if negative {
+		abs = -abs
+	}
+	encoded := []byte{
+		byte(abs & 0x7F),
+		byte((abs >> 7) & 0x7F),
+		byte((abs >> 14) & 0x7F),
+		byte((abs >> 21) & 0x7F),
+		byte((abs >> 28) & 0x07),
+	}
+	if negative {
+		encoded[4] |= 0x08
+	}
+
+	return encoded
+}
+
+// decodeSigned32Bit is the reverse of encodeSigned32Bit()
+func decodeSigned32Bit(encoded []byte) int32 {
+	abs := int64(encoded[0]) | int64(encoded[1])<<7 | int64(encoded[2])<<14 | int64(encoded[3])<<21 |
+		int64(encoded[4]&0x07)<<28
+	if encoded[4]&0x08 != 0 {
+		abs = -abs
+	}
+
+	return int32(abs) //nolint:gosec // ok here, the value has only 31 bits
+}
+
+// encodeCustomFloat encodes the value to 4 bytes with a 23 bit significand, a 4 bit exponent (base 10, offset 11)
+// and the sign bit
+func encodeCustomFloat(val float64) ([]byte, error) {
+	if val == 0 {
+		return []byte{0, 0, 0, 0}, nil
+	}
+
+	var sign byte
+	if val < 0 {
+		sign = 1
+		val = -val
+	}
+
+	exponent := int(math.Floor(math.Log10(val)))
+	significand := val / math.Pow10(exponent)
+	// shift the decimal point to the right as far as possible and needed
+	for math.Abs(significand-math.Round(significand)) > 1e-6 && significand*10 < stepperMaxSignificand {
+		exponent--
+		significand *= 10
+	}
+	for significand >= stepperMaxSignificand {
+		exponent++
+		significand /= 10
+	}
+
+	exponent += 11
+	if exponent < 0 || exponent > 0x0F {
+		return nil, fmt.Errorf("the value %v is out of range for the custom float encoding", val)
+	}
+
+	s := int(math.Round(significand))
+	if s >= stepperMaxSignificand {
+		s = stepperMaxSignificand - 1
+	}
+	return []byte{
+		byte(s & 0x7F),
+		byte((s >> 7) & 0x7F),
+		byte((s >> 14) & 0x7F),
+		byte((s>>21)&0x03) | byte(exponent<<2) | sign<<6,
+	}, nil
+}
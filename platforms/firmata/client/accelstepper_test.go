@@ -0,0 +1,183 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccelStepperCommands(t *testing.T) {
+	b := New()
+	b.connection = readWriteCloser{}
+
+	tests := map[string]struct {
+		run  func() error
+		want []byte
+	}{
+		"config_driver_with_enable_pin": {
+			run: func() error {
+				return b.AccelStepperConfig(0, AccelStepperSettings{
+					Interface: StepperInterfaceDriver,
+					StepSize:  StepperWholeStep,
+					Pins:      []int{2, 3},
+					EnablePin: 4,
+				})
+			},
+			want: []byte{0xF0, 0x62, 0x00, 0, 0x11, 2, 3, 4, 0xF7},
+		},
+		"config_four_wire_half_step_inverted": {
+			run: func() error {
+				return b.AccelStepperConfig(1, AccelStepperSettings{
+					Interface:  StepperInterfaceFourWire,
+					StepSize:   StepperHalfStep,
+					Pins:       []int{8, 9, 10, 11},
+					EnablePin:  -1,
+					InvertPins: 0x03,
+				})
+			},
+			want: []byte{0xF0, 0x62, 0x00, 1, 0x42, 8, 9, 10, 11, 0x03, 0xF7},
+		},
+		"zero": {
+			run:  func() error { return b.AccelStepperZero(2) },
+			want: []byte{0xF0, 0x62, 0x01, 2, 0xF7},
+		},
+		"step_forward": {
+			run:  func() error { return b.AccelStepperStep(0, 2000) },
+			want: []byte{0xF0, 0x62, 0x02, 0, 0x50, 0x0F, 0, 0, 0, 0xF7},
+		},
+		"step_backward": {
+			run:  func() error { return b.AccelStepperStep(0, -2000) },
+			want: []byte{0xF0, 0x62, 0x02, 0, 0x50, 0x0F, 0, 0, 0x08, 0xF7},
+		},
+		"to": {
+			run:  func() error { return b.AccelStepperTo(3, 100) },
+			want: []byte{0xF0, 0x62, 0x03, 3, 100, 0, 0, 0, 0, 0xF7},
+		},
+		"enable": {
+			run:  func() error { return b.AccelStepperEnable(3, true) },
+			want: []byte{0xF0, 0x62, 0x04, 3, 0x01, 0xF7},
+		},
+		"stop": {
+			run:  func() error { return b.AccelStepperStop(3) },
+			want: []byte{0xF0, 0x62, 0x05, 3, 0xF7},
+		},
+		"report_position": {
+			run:  func() error { return b.AccelStepperReportPosition(3) },
+			want: []byte{0xF0, 0x62, 0x06, 3, 0xF7},
+		},
+		"set_acceleration": {
+			run:  func() error { return b.AccelStepperSetAcceleration(0, 500) },
+			want: []byte{0xF0, 0x62, 0x08, 0, 5, 0, 0, 13 << 2, 0xF7},
+		},
+		"set_speed": {
+			run:  func() error { return b.AccelStepperSetSpeed(0, 0.5) },
+			want: []byte{0xF0, 0x62, 0x09, 0, 5, 0, 0, 10 << 2, 0xF7},
+		},
+		"multi_config": {
+			run:  func() error { return b.MultiStepperConfig(1, []int{0, 2}) },
+			want: []byte{0xF0, 0x62, 0x20, 1, 0, 2, 0xF7},
+		},
+		"multi_to": {
+			run:  func() error { return b.MultiStepperTo(1, []int32{10, -10}) },
+			want: []byte{0xF0, 0x62, 0x21, 1, 10, 0, 0, 0, 0, 10, 0, 0, 0, 0x08, 0xF7},
+		},
+		"multi_stop": {
+			run:  func() error { return b.MultiStepperStop(1) },
+			want: []byte{0xF0, 0x62, 0x23, 1, 0xF7},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			writeDataMutex.Lock()
+			testWriteData.Reset()
+			writeDataMutex.Unlock()
+			// act
+			err := tc.run()
+			// assert
+			require.NoError(t, err)
+			writeDataMutex.Lock()
+			assert.Equal(t, tc.want, testWriteData.Bytes())
+			writeDataMutex.Unlock()
+		})
+	}
+}
+
+func TestEncodeCustomFloat(t *testing.T) {
+	tests := map[string]struct {
+		val     float64
+		want    []byte
+		wantErr string
+	}{
+		"zero":     {val: 0, want: []byte{0, 0, 0, 0}},
+		"integer":  {val: 1000, want: []byte{1, 0, 0, 14 << 2}},
+		"fraction": {val: 12.25, want: []byte{0x49, 0x09, 0, 9 << 2}},
+		"negative": {val: -2, want: []byte{2, 0, 0, 11<<2 | 0x40}},
+		"too_big":  {val: 1e20, wantErr: "the value 1e+20 is out of range for the custom float encoding"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			got, err := encodeCustomFloat(tc.val)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestEncodeDecodeSigned32Bit(t *testing.T) {
+	for _, val := range []int32{0, 1, -1, 2000, -123456789, 1<<31 - 1} {
+		assert.Equal(t, val, decodeSigned32Bit(encodeSigned32Bit(val)))
+	}
+}
+
+func TestProcessStepperReplies(t *testing.T) {
+	tests := map[string]struct {
+		msg       []byte
+		wantEvent string
+		wantData  interface{}
+	}{
+		"position": {
+			msg:       []byte{240, 0x62, 0x06, 2, 0x50, 0x0F, 0, 0, 0, 247},
+			wantEvent: "StepperPosition",
+			wantData:  StepperPositionReply{Device: 2, Position: 2000},
+		},
+		"move_complete": {
+			msg:       []byte{240, 0x62, 0x0A, 1, 0x50, 0x0F, 0, 0, 0x08, 247},
+			wantEvent: "StepperMoveComplete",
+			wantData:  StepperPositionReply{Device: 1, Position: -2000},
+		},
+		"multi_move_complete": {
+			msg:       []byte{240, 0x62, 0x24, 3, 247},
+			wantEvent: "MultiStepperMoveComplete",
+			wantData:  3,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			sem := make(chan bool)
+			b, rwc := initTestFirmataWithReadWriteCloser(t.Name())
+			rwc.addTestReadData(tc.msg)
+			_ = b.Once(b.Event(tc.wantEvent), func(data interface{}) {
+				assert.Equal(t, tc.wantData, data)
+				sem <- true
+			})
+			// act
+			_ = b.process()
+			// assert
+			select {
+			case <-sem:
+			case <-time.After(semPublishWait):
+				require.Fail(t, tc.wantEvent+" was not published")
+			}
+		})
+	}
+}
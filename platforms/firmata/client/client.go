@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -15,13 +16,35 @@ import (
 
 // Pin Modes
 const (
-	Input  = 0x00
-	Output = 0x01
-	Analog = 0x02
-	Pwm    = 0x03
-	Servo  = 0x04
+	Input   = 0x00
+	Output  = 0x01
+	Analog  = 0x02
+	Pwm     = 0x03
+	Servo   = 0x04
+	Shift   = 0x05
+	I2C     = 0x06
+	OneWire = 0x07
+	Stepper = 0x08
+	Encoder = 0x09
+	Serial  = 0x0A
+	Pullup  = 0x0B
 )
 
+var modeNames = map[int]string{
+	Input:   "input",
+	Output:  "output",
+	Analog:  "analog",
+	Pwm:     "pwm",
+	Servo:   "servo",
+	Shift:   "shift",
+	I2C:     "i2c",
+	OneWire: "onewire",
+	Stepper: "stepper",
+	Encoder: "encoder",
+	Serial:  "serial",
+	Pullup:  "pullup",
+}
+
 // Sysex Codes
 const (
 	ProtocolVersion          byte = 0xF9
@@ -35,6 +58,7 @@ const (
 	ReportAnalog             byte = 0xC0
 	ReportDigital            byte = 0xD0
 	PinMode                  byte = 0xF4
+	SetDigitalPinValue       byte = 0xF5
 	StartSysex               byte = 0xF0
 	EndSysex                 byte = 0xF7
 	CapabilityQuery          byte = 0x6B
@@ -43,16 +67,44 @@ const (
 	PinStateResponse         byte = 0x6E
 	AnalogMappingQuery       byte = 0x69
 	AnalogMappingResponse    byte = 0x6A
+	ExtendedAnalog           byte = 0x6F
 	StringData               byte = 0x71
 	I2CRequest               byte = 0x76
 	I2CReply                 byte = 0x77
 	I2CConfig                byte = 0x78
 	FirmwareQuery            byte = 0x79
+	SamplingInterval         byte = 0x7A
 	I2CModeWrite             byte = 0x00
 	I2CModeRead              byte = 0x01
 	I2CModeContinuousRead    byte = 0x02
 	I2CModeStopReading       byte = 0x03
+	I2CAutoRestart           byte = 0x40
+	I2C10BitAddressMode      byte = 0x20
 	ServoConfig              byte = 0x70
+	SerialData               byte = 0x60
+	SerialConfig             byte = 0x10
+	SerialWrite              byte = 0x20
+	SerialRead               byte = 0x30
+	SerialReply              byte = 0x40
+	SerialClose              byte = 0x50
+	SerialFlush              byte = 0x60
+	SerialListen             byte = 0x70
+	SerialReadContinuously   byte = 0x00
+	SerialStopReading        byte = 0x01
+	OneWireData              byte = 0x73
+	AccelStepperData         byte = 0x62
+)
+
+// Serial Ports
+const (
+	HwSerial0 = 0x00
+	HwSerial1 = 0x01
+	HwSerial2 = 0x02
+	HwSerial3 = 0x03
+	SwSerial0 = 0x08
+	SwSerial1 = 0x09
+	SwSerial2 = 0x0A
+	SwSerial3 = 0x0B
 )
 
 // Errors
@@ -63,6 +115,7 @@ var (
 // Client represents a client connection to a firmata board
 type Client struct {
 	pins            []Pin
+	pinsMutex       sync.Mutex
 	FirmwareName    string
 	ProtocolVersion string
 	connecting      atomic.Value
@@ -80,6 +133,37 @@ type Pin struct {
 	Value          int
 	State          int
 	AnalogChannel  int
+	Resolutions    map[int]int // the resolution in bits of each supported mode
+}
+
+// SupportsMode returns true, if the mode is reported as supported by the capabilities of the pin.
+func (p Pin) SupportsMode(mode int) bool {
+	for _, m := range p.SupportedModes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// clone returns a deep copy of the pin, because the values and capabilities are updated by the received messages
+func (p Pin) clone() Pin {
+	p.SupportedModes = append([]int(nil), p.SupportedModes...)
+	resolutions := make(map[int]int, len(p.Resolutions))
+	for mode, resolution := range p.Resolutions {
+		resolutions[mode] = resolution
+	}
+	p.Resolutions = resolutions
+
+	return p
+}
+
+// ModeName returns the name of the pin mode, e.g. "pwm".
+func ModeName(mode int) string {
+	if name, ok := modeNames[mode]; ok {
+		return name
+	}
+	return fmt.Sprintf("0x%02X", mode)
 }
 
 // I2cReply represents the response from an I2cReply message
@@ -89,6 +173,12 @@ type I2cReply struct {
 	Data     []byte
 }
 
+// SerialPortReply represents the data received by a serial port of the board
+type SerialPortReply struct {
+	Port int
+	Data []byte
+}
+
 // New returns a new Client
 func New() *Client {
 	c := &Client{
@@ -110,6 +200,13 @@ func New() *Client {
 		"AnalogMappingQuery",
 		"ProtocolVersion",
 		"I2cReply",
+		"SerialReply",
+		"OneWireSearchReply",
+		"OneWireSearchAlarmsReply",
+		"OneWireReadReply",
+		"StepperPosition",
+		"StepperMoveComplete",
+		"MultiStepperMoveComplete",
 		"StringData",
 		"Error",
 	} {
@@ -147,7 +244,27 @@ func (b *Client) Connected() bool {
 
 // Pins returns all available pins
 func (b *Client) Pins() []Pin {
-	return b.pins
+	b.pinsMutex.Lock()
+	defer b.pinsMutex.Unlock()
+
+	pins := make([]Pin, len(b.pins))
+	for i, pin := range b.pins {
+		pins[i] = pin.clone()
+	}
+
+	return pins
+}
+
+// Pin returns the given pin, e.g. to access the mode, value and capabilities of a single pin without copying all pins
+func (b *Client) Pin(pin int) (Pin, error) {
+	b.pinsMutex.Lock()
+	defer b.pinsMutex.Unlock()
+
+	if pin < 0 || pin >= len(b.pins) {
+		return Pin{}, fmt.Errorf("Invalid pin %d, the board reports %d pins", pin, len(b.pins))
+	}
+
+	return b.pins[pin].clone(), nil
 }
 
 // Connect connects to the Client given conn. It first resets the firmata board
@@ -258,7 +375,10 @@ func (b *Client) Reset() error {
 
 // SetPinMode sets the pin to mode.
 func (b *Client) SetPinMode(pin int, mode int) error {
+	b.pinsMutex.Lock()
 	b.pins[byte(pin)].Mode = mode
+	b.pinsMutex.Unlock()
+
 	return b.write([]byte{PinMode, byte(pin), byte(mode)})
 }
 
@@ -267,13 +387,15 @@ func (b *Client) DigitalWrite(pin int, value int) error {
 	port := byte(math.Floor(float64(pin) / 8))
 	portValue := byte(0)
 
+	b.pinsMutex.Lock()
 	b.pins[pin].Value = value
-
 	for i := byte(0); i < 8; i++ {
-		if b.pins[8*port+i].Value != 0 {
+		if int(8*port+i) < len(b.pins) && b.pins[8*port+i].Value != 0 {
 			portValue = portValue | (1 << i)
 		}
 	}
+	b.pinsMutex.Unlock()
+
 	return b.write([]byte{DigitalMessage | port, portValue & 0x7F, (portValue >> 7) & 0x7F})
 }
 
@@ -290,9 +412,23 @@ func (b *Client) ServoConfig(pin int, maximum int, minimum int) error {
 	return b.WriteSysex(ret)
 }
 
-// AnalogWrite writes value to pin.
+// AnalogWrite writes value to pin. The extended analog message is used for pins above 15 and values with more than
+// 14 bits.
 func (b *Client) AnalogWrite(pin int, value int) error {
+	b.pinsMutex.Lock()
 	b.pins[pin].Value = value
+	b.pinsMutex.Unlock()
+
+	if pin > 15 || value > 0x3FFF {
+		ret := []byte{ExtendedAnalog, byte(pin)}
+		for v := value; ; v >>= 7 {
+			ret = append(ret, byte(v&0x7F))
+			if v <= 0x7F {
+				break
+			}
+		}
+		return b.WriteSysex(ret)
+	}
 	return b.write([]byte{AnalogMessage | byte(pin), byte(value & 0x7F), byte((value >> 7) & 0x7F)})
 }
 
@@ -351,12 +487,131 @@ func (b *Client) I2cWrite(address int, data []byte) error {
 	return b.WriteSysex(ret)
 }
 
+// I2cReadWithFlags reads numBytes from the register of the device at address once. A negative register reads without
+// register. The flags I2CAutoRestart (repeated start instead of stop between writing the register and reading) and
+// I2C10BitAddressMode can be combined.
+func (b *Client) I2cReadWithFlags(address int, register int, numBytes int, flags byte) error {
+	return b.i2cReadRequest(address, register, numBytes, flags, I2CModeRead)
+}
+
+// I2cWriteWithFlags writes data to address. The flag I2C10BitAddressMode needs to be given for addresses above 0x7F.
+func (b *Client) I2cWriteWithFlags(address int, data []byte, flags byte) error {
+	ret, err := i2cRequestHeader(address, flags, I2CModeWrite)
+	if err != nil {
+		return err
+	}
+	for _, val := range data {
+		ret = append(ret, val&0x7F, (val>>7)&0x7F)
+	}
+	return b.WriteSysex(ret)
+}
+
+// I2cReadContinuously starts the continuous reading of numBytes from the register of the device at address. A negative
+// register reads without register. Each reading is published as "I2cReply" event until I2cStopReading is called.
+func (b *Client) I2cReadContinuously(address int, register int, numBytes int, flags byte) error {
+	return b.i2cReadRequest(address, register, numBytes, flags, I2CModeContinuousRead)
+}
+
+// I2cStopReading stops one continuous reading of the device at address.
+func (b *Client) I2cStopReading(address int, flags byte) error {
+	ret, err := i2cRequestHeader(address, flags, I2CModeStopReading)
+	if err != nil {
+		return err
+	}
+	return b.WriteSysex(ret)
+}
+
+func (b *Client) i2cReadRequest(address int, register int, numBytes int, flags byte, mode byte) error {
+	ret, err := i2cRequestHeader(address, flags, mode)
+	if err != nil {
+		return err
+	}
+	if register >= 0 {
+		ret = append(ret, byte(register)&0x7F, byte(register>>7)&0x7F)
+	}
+	ret = append(ret, byte(numBytes)&0x7F, byte(numBytes>>7)&0x7F)
+	return b.WriteSysex(ret)
+}
+
+// i2cRequestHeader creates the start of an I2C request, the upper 3 bits of a 10-bit address are part of the mode byte
+func i2cRequestHeader(address int, flags byte, mode byte) ([]byte, error) {
+	if address < 0 || address > 0x3FF {
+		return nil, fmt.Errorf("I2C address 0x%X is out of range", address)
+	}
+	if address > 0x7F && flags&I2C10BitAddressMode == 0 {
+		return nil, fmt.Errorf("I2C address 0x%X needs the 10-bit address mode", address)
+	}
+	flags &= I2CAutoRestart | I2C10BitAddressMode
+	return []byte{I2CRequest, byte(address) & 0x7F, flags | mode<<3 | byte(address>>7)&0x07}, nil
+}
+
 // I2cConfig configures the delay in which a register can be read from after it
 // has been written to.
 func (b *Client) I2cConfig(delay int) error {
 	return b.WriteSysex([]byte{I2CConfig, byte(delay & 0xFF), byte((delay >> 8) & 0xFF)})
 }
 
+// SerialConfig configures the serial port with the given baud rate. The RX and TX pins are only used for the
+// software serial ports (SwSerial0..3) and ignored for the hardware serial ports.
+func (b *Client) SerialConfig(port int, baudRate int, rxPin int, txPin int) error {
+	ret := []byte{
+		SerialData,
+		SerialConfig | byte(port),
+		byte(baudRate & 0x7F),
+		byte((baudRate >> 7) & 0x7F),
+		byte((baudRate >> 14) & 0x7F),
+	}
+	if isSoftwareSerial(port) {
+		ret = append(ret, byte(rxPin), byte(txPin))
+	}
+	return b.WriteSysex(ret)
+}
+
+// SerialWrite writes data to the serial port.
+func (b *Client) SerialWrite(port int, data []byte) error {
+	ret := []byte{SerialData, SerialWrite | byte(port)}
+	for _, val := range data {
+		ret = append(ret, val&0x7F)
+		ret = append(ret, (val>>7)&0x7F)
+	}
+	return b.WriteSysex(ret)
+}
+
+// SerialStartReading starts the continuous reading of the serial port, the received data are published by the
+// "SerialReply" event. A maxBytes value of 0 means to read all available bytes.
+func (b *Client) SerialStartReading(port int, maxBytes int) error {
+	ret := []byte{SerialData, SerialRead | byte(port), SerialReadContinuously}
+	if maxBytes > 0 {
+		ret = append(ret, byte(maxBytes&0x7F), byte((maxBytes>>7)&0x7F))
+	}
+	return b.WriteSysex(ret)
+}
+
+// SerialStopReading stops the continuous reading of the serial port.
+func (b *Client) SerialStopReading(port int) error {
+	return b.WriteSysex([]byte{SerialData, SerialRead | byte(port), SerialStopReading})
+}
+
+// SerialClose closes the serial port.
+func (b *Client) SerialClose(port int) error {
+	return b.WriteSysex([]byte{SerialData, SerialClose | byte(port)})
+}
+
+// SerialFlush waits for the transmission of outgoing data of the serial port to complete.
+func (b *Client) SerialFlush(port int) error {
+	return b.WriteSysex([]byte{SerialData, SerialFlush | byte(port)})
+}
+
+// SerialListen enables the software serial port to receive data. Only one software serial port can receive data
+// at a time.
+func (b *Client) SerialListen(port int) error {
+	return b.WriteSysex([]byte{SerialData, SerialListen | byte(port)})
+}
+
+func isSoftwareSerial(port int) bool {
+	return port >= SwSerial0 && port <= SwSerial3
+}
+
 func (b *Client) togglePinReporting(pin int, state int, mode byte) error {
 	if state != 0 {
 		state = 1
@@ -408,12 +663,16 @@ func (b *Client) process() error {
 		value := uint(buf[0]) | uint(buf[1])<<7
 		pin := int((messageType & 0x0F))
 
-		if len(b.analogPins) > pin {
-			if len(b.pins) > b.analogPins[pin] {
-				//nolint:gosec // TODO: fix later
-				b.pins[b.analogPins[pin]].Value = int(value)
-				b.Publish(b.Event(fmt.Sprintf("AnalogRead%v", pin)), b.pins[b.analogPins[pin]].Value)
-			}
+		b.pinsMutex.Lock()
+		updated := len(b.analogPins) > pin && len(b.pins) > b.analogPins[pin]
+		if updated {
+			//nolint:gosec // TODO: fix later
+			b.pins[b.analogPins[pin]].Value = int(value)
+		}
+		b.pinsMutex.Unlock()
+
+		if updated {
+			b.Publish(b.Event(fmt.Sprintf("AnalogRead%v", pin)), int(value))
 		}
 	case DigitalMessageRangeStart <= messageType &&
 		DigitalMessageRangeEnd >= messageType:
@@ -425,15 +684,25 @@ func (b *Client) process() error {
 		port := messageType & 0x0F
 		portValue := buf[0] | (buf[1] << 7)
 
+		updated := make(map[int]int)
+		b.pinsMutex.Lock()
 		for i := 0; i < 8; i++ {
 			pinNumber := int((8*port + byte(i)))
 			if len(b.pins) > pinNumber {
 				if b.pins[pinNumber].Mode == Input {
 					b.pins[pinNumber].Value = int((portValue >> (byte(i) & 0x07)) & 0x01)
-					b.Publish(b.Event(fmt.Sprintf("DigitalRead%v", pinNumber)), b.pins[pinNumber].Value)
+					updated[pinNumber] = b.pins[pinNumber].Value
 				}
 			}
 		}
+		b.pinsMutex.Unlock()
+
+		for i := 0; i < 8; i++ {
+			pinNumber := int((8*port + byte(i)))
+			if value, ok := updated[pinNumber]; ok {
+				b.Publish(b.Event(fmt.Sprintf("DigitalRead%v", pinNumber)), value)
+			}
+		}
 	case StartSysex == messageType:
 		buf, err := b.read(2)
 		if err != nil {
@@ -454,38 +723,41 @@ func (b *Client) process() error {
 		command := currentBuffer[1]
 		switch command {
 		case CapabilityResponse:
-			b.pins = []Pin{}
-			supportedModes := 0
-			n := 0
-
-			for _, val := range currentBuffer[2 : len(currentBuffer)-1] {
-				if val == 127 {
-					modes := []int{}
-					for _, mode := range []int{Input, Output, Analog, Pwm, Servo} {
-						if (supportedModes & (1 << byte(mode))) != 0 {
-							modes = append(modes, mode)
-						}
-					}
-
-					b.pins = append(b.pins, Pin{SupportedModes: modes, Mode: Output})
-					b.AddEvent(fmt.Sprintf("DigitalRead%v", len(b.pins)-1))
-					b.AddEvent(fmt.Sprintf("PinState%v", len(b.pins)-1))
-					supportedModes = 0
-					n = 0
+			pins := []Pin{}
+			pin := newCapabilityPin()
+			capabilities := currentBuffer[2 : len(currentBuffer)-1]
+
+			for i := 0; i < len(capabilities); i++ {
+				if capabilities[i] == 127 {
+					pins = append(pins, pin)
+					b.AddEvent(fmt.Sprintf("DigitalRead%v", len(pins)-1))
+					b.AddEvent(fmt.Sprintf("PinState%v", len(pins)-1))
+					pin = newCapabilityPin()
 					continue
 				}
 
-				if n == 0 {
-					supportedModes = supportedModes | (1 << val)
+				// each mode is followed by its resolution
+				if i+1 < len(capabilities) {
+					mode := int(capabilities[i])
+					pin.SupportedModes = append(pin.SupportedModes, mode)
+					pin.Resolutions[mode] = int(capabilities[i+1])
+					i++
 				}
-				n ^= 1
 			}
+			b.pinsMutex.Lock()
+			b.pins = pins
+			b.pinsMutex.Unlock()
+
 			b.Publish(b.Event("CapabilityQuery"), nil)
 		case AnalogMappingResponse:
 			pinIndex := 0
+			b.pinsMutex.Lock()
 			b.analogPins = []int{}
 
 			for _, val := range currentBuffer[2 : len(currentBuffer)-1] {
+				if pinIndex >= len(b.pins) {
+					break
+				}
 				b.pins[pinIndex].AnalogChannel = int(val)
 
 				if val != 127 {
@@ -494,9 +766,16 @@ func (b *Client) process() error {
 				b.AddEvent(fmt.Sprintf("AnalogRead%v", pinIndex))
 				pinIndex++
 			}
+			b.pinsMutex.Unlock()
+
 			b.Publish(b.Event("AnalogMappingQuery"), nil)
 		case PinStateResponse:
 			pin := currentBuffer[2]
+			b.pinsMutex.Lock()
+			if int(pin) >= len(b.pins) {
+				b.pinsMutex.Unlock()
+				break
+			}
 			b.pins[pin].Mode = int(currentBuffer[3])
 			b.pins[pin].State = int(currentBuffer[4])
 
@@ -509,10 +788,13 @@ func (b *Client) process() error {
 				b.pins[pin].State = int(uint(b.pins[pin].State) | uint(currentBuffer[6])<<14)
 			}
 
-			b.Publish(b.Event(fmt.Sprintf("PinState%v", pin)), b.pins[pin])
+			state := b.pins[pin]
+			b.pinsMutex.Unlock()
+
+			b.Publish(b.Event(fmt.Sprintf("PinState%v", pin)), state)
 		case I2CReply:
 			reply := I2cReply{
-				Address:  int(currentBuffer[2] | currentBuffer[3]<<7),
+				Address:  int(currentBuffer[2]) | int(currentBuffer[3])<<7,
 				Register: int(currentBuffer[4] | currentBuffer[5]<<7),
 				Data:     []byte{currentBuffer[6] | currentBuffer[7]<<7},
 			}
@@ -528,6 +810,20 @@ func (b *Client) process() error {
 				)
 			}
 			b.Publish(b.Event("I2cReply"), reply)
+		case SerialData:
+			if currentBuffer[2]&0xF0 != SerialReply {
+				b.publishSysexResponse(currentBuffer)
+				break
+			}
+			reply := SerialPortReply{Port: int(currentBuffer[2] & 0x0F), Data: []byte{}}
+			for i := 3; i+1 < len(currentBuffer)-1; i = i + 2 {
+				reply.Data = append(reply.Data, currentBuffer[i]|currentBuffer[i+1]<<7)
+			}
+			b.Publish(b.Event("SerialReply"), reply)
+		case OneWireData:
+			b.processOneWire(currentBuffer)
+		case AccelStepperData:
+			b.processAccelStepper(currentBuffer)
 		case FirmwareQuery:
 			name := []byte{}
 			for _, val := range currentBuffer[4:(len(currentBuffer) - 1)] {
@@ -541,10 +837,19 @@ func (b *Client) process() error {
 			str := currentBuffer[2:]
 			b.Publish(b.Event("StringData"), string(str[:len(str)-1]))
 		default:
-			data := make([]byte, len(currentBuffer))
-			copy(data, currentBuffer)
-			b.Publish("SysexResponse", data)
+			b.publishSysexResponse(currentBuffer)
 		}
 	}
 	return nil
 }
+
+func newCapabilityPin() Pin {
+	return Pin{SupportedModes: []int{}, Mode: Output, Resolutions: make(map[int]int)}
+}
+
+// publishSysexResponse publishes a copy of the unhandled sysex message
+func (b *Client) publishSysexResponse(currentBuffer []byte) {
+	data := make([]byte, len(currentBuffer))
+	copy(data, currentBuffer)
+	b.Publish("SysexResponse", data)
+}
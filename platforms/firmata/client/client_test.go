@@ -81,6 +81,13 @@ func (rwc readWriteCloser) Read(b []byte) (int, error) {
 	defer rwDataMapMutex.Unlock()
 
 	data, ok := testReadDataMap[rwc.id]
+	if ok && len(data) == 0 {
+		// nothing to read, back off to prevent a busy loop of a still running process routine
+		rwDataMapMutex.Unlock()
+		time.Sleep(time.Millisecond)
+		rwDataMapMutex.Lock()
+		data = testReadDataMap[rwc.id]
+	}
 	if !ok {
 		// there was no content stored before to read
 		log.Printf("no content stored in %s", rwc.id)
@@ -117,6 +124,67 @@ func TestPins(t *testing.T) {
 	b, _ := initTestFirmataWithReadWriteCloser(t.Name(), testDataCapabilitiesResponse, testDataAnalogMappingResponse)
 	assert.Len(t, b.Pins(), 20)
 	assert.Len(t, b.analogPins, 6)
+	// pin 0 is used by the serial port, pin 3 supports PWM and pin 18 analog input and I2C
+	assert.Empty(t, b.Pins()[0].SupportedModes)
+	assert.Equal(t, []int{Input, Output, Pwm, Servo}, b.Pins()[3].SupportedModes)
+	assert.Equal(t, map[int]int{Input: 1, Output: 1, Pwm: 8, Servo: 14}, b.Pins()[3].Resolutions)
+	assert.True(t, b.Pins()[18].SupportsMode(I2C))
+	assert.False(t, b.Pins()[18].SupportsMode(Pwm))
+	assert.Equal(t, 10, b.Pins()[18].Resolutions[Analog])
+	assert.Equal(t, 4, b.Pins()[18].AnalogChannel)
+}
+
+func TestPinsReturnsCopy(t *testing.T) {
+	// arrange
+	b, _ := initTestFirmataWithReadWriteCloser(t.Name(), testDataCapabilitiesResponse, testDataAnalogMappingResponse)
+	pins := b.Pins()
+	// act
+	pins[13].Value = 1
+	pins[3].SupportedModes[0] = Analog
+	pins[3].Resolutions[Pwm] = 16
+	// assert
+	assert.Equal(t, 0, b.Pins()[13].Value)
+	assert.Equal(t, []int{Input, Output, Pwm, Servo}, b.Pins()[3].SupportedModes)
+	assert.Equal(t, 8, b.Pins()[3].Resolutions[Pwm])
+}
+
+func TestPin(t *testing.T) {
+	tests := map[string]struct {
+		pin     int
+		wantErr string
+	}{
+		"pwm_pin":       {pin: 3},
+		"last_pin":      {pin: 19},
+		"negative_pin":  {pin: -1, wantErr: "Invalid pin -1, the board reports 20 pins"},
+		"not_available": {pin: 20, wantErr: "Invalid pin 20, the board reports 20 pins"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			b, _ := initTestFirmataWithReadWriteCloser(t.Name(), testDataCapabilitiesResponse,
+				testDataAnalogMappingResponse)
+			// act
+			got, err := b.Pin(tc.pin)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, b.Pins()[tc.pin], got)
+			// the pin is a copy
+			got.Resolutions[Pwm] = 16
+			got.Value = 1
+			assert.NotEqual(t, 16, b.pins[tc.pin].Resolutions[Pwm])
+			assert.Equal(t, 0, b.pins[tc.pin].Value)
+		})
+	}
+}
+
+func TestModeName(t *testing.T) {
+	assert.Equal(t, "pwm", ModeName(Pwm))
+	assert.Equal(t, "pullup", ModeName(Pullup))
+	assert.Equal(t, "0x7E", ModeName(0x7E))
 }
 
 func TestProtocolVersionQuery(t *testing.T) {
@@ -246,6 +314,36 @@ func TestAnalogWrite(t *testing.T) {
 	require.NoError(t, b.AnalogWrite(0, 128))
 }
 
+func TestAnalogWriteExtended(t *testing.T) {
+	b, _ := initTestFirmataWithReadWriteCloser(t.Name(), testDataCapabilitiesResponse)
+
+	tests := map[string]struct {
+		pin   int
+		value int
+		want  []byte
+	}{
+		"analog_message": {pin: 3, value: 0x3FFF, want: []byte{0xE3, 0x7F, 0x7F}},
+		"pin_above_15":   {pin: 16, value: 200, want: []byte{0xF0, 0x6F, 16, 0x48, 0x01, 0xF7}},
+		"16_bit_value":   {pin: 5, value: 0xFFFF, want: []byte{0xF0, 0x6F, 5, 0x7F, 0x7F, 0x03, 0xF7}},
+		"zero_on_pin_17": {pin: 17, value: 0, want: []byte{0xF0, 0x6F, 17, 0x00, 0xF7}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			writeDataMutex.Lock()
+			testWriteData.Reset()
+			writeDataMutex.Unlock()
+			// act
+			err := b.AnalogWrite(tc.pin, tc.value)
+			// assert
+			require.NoError(t, err)
+			writeDataMutex.Lock()
+			assert.Equal(t, tc.want, testWriteData.Bytes())
+			writeDataMutex.Unlock()
+		})
+	}
+}
+
 func TestReportAnalog(t *testing.T) {
 	b, _ := initTestFirmataWithReadWriteCloser(t.Name())
 	require.NoError(t, b.ReportAnalog(0, 1))
@@ -258,7 +356,7 @@ func TestProcessPinState13(t *testing.T) {
 	rwc.addTestReadData([]byte{240, 110, 13, 1, 1, 247})
 
 	_ = b.Once(b.Event("PinState13"), func(data interface{}) {
-		assert.Equal(t, Pin{[]int{0, 1, 4}, 1, 0, 1, 127}, data)
+		assert.Equal(t, Pin{[]int{0, 1, 4}, 1, 0, 1, 127, map[int]int{0: 1, 1: 1, 4: 14}}, data)
 		sem <- true
 	})
 
@@ -286,6 +384,70 @@ func TestI2cRead(t *testing.T) {
 	require.NoError(t, b.I2cRead(0x00, 10))
 }
 
+func TestI2cCommandsWithFlags(t *testing.T) {
+	b := New()
+	b.connection = readWriteCloser{}
+
+	tests := map[string]struct {
+		run     func() error
+		want    []byte
+		wantErr string
+	}{
+		"read_without_register": {
+			run:  func() error { return b.I2cReadWithFlags(0x68, -1, 6, 0) },
+			want: []byte{0xF0, 0x76, 0x68, 0x08, 0x06, 0x00, 0xF7},
+		},
+		"read_register_with_restart": {
+			run:  func() error { return b.I2cReadWithFlags(0x68, 0x3B, 14, I2CAutoRestart) },
+			want: []byte{0xF0, 0x76, 0x68, 0x48, 0x3B, 0x00, 0x0E, 0x00, 0xF7},
+		},
+		"read_register_10bit": {
+			run:  func() error { return b.I2cReadWithFlags(0x2A3, 0xA0, 200, I2C10BitAddressMode) },
+			want: []byte{0xF0, 0x76, 0x23, 0x2D, 0x20, 0x01, 0x48, 0x01, 0xF7},
+		},
+		"write_10bit": {
+			run:  func() error { return b.I2cWriteWithFlags(0x150, []byte{0x01, 0xFF}, I2C10BitAddressMode) },
+			want: []byte{0xF0, 0x76, 0x50, 0x22, 0x01, 0x00, 0x7F, 0x01, 0xF7},
+		},
+		"read_continuously": {
+			run:  func() error { return b.I2cReadContinuously(0x1E, 0x03, 6, 0) },
+			want: []byte{0xF0, 0x76, 0x1E, 0x10, 0x03, 0x00, 0x06, 0x00, 0xF7},
+		},
+		"stop_reading": {
+			run:  func() error { return b.I2cStopReading(0x1E, 0) },
+			want: []byte{0xF0, 0x76, 0x1E, 0x18, 0xF7},
+		},
+		"error_10bit_flag_missing": {
+			run:     func() error { return b.I2cReadWithFlags(0x150, -1, 1, 0) },
+			wantErr: "I2C address 0x150 needs the 10-bit address mode",
+		},
+		"error_address_out_of_range": {
+			run:     func() error { return b.I2cStopReading(0x400, I2C10BitAddressMode) },
+			wantErr: "I2C address 0x400 is out of range",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			writeDataMutex.Lock()
+			testWriteData.Reset()
+			writeDataMutex.Unlock()
+			// act
+			err := tc.run()
+			// assert
+			writeDataMutex.Lock()
+			defer writeDataMutex.Unlock()
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				assert.Empty(t, testWriteData.Bytes())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, testWriteData.Bytes())
+		})
+	}
+}
+
 func TestWriteSysex(t *testing.T) {
 	b, _ := initTestFirmataWithReadWriteCloser(t.Name())
 	require.NoError(t, b.WriteSysex([]byte{0x01, 0x02}))
@@ -314,6 +476,29 @@ func TestProcessI2cReply(t *testing.T) {
 	}
 }
 
+func TestProcessI2cReply10BitAddress(t *testing.T) {
+	sem := make(chan bool)
+	b, rwc := initTestFirmataWithReadWriteCloser(t.Name())
+	rwc.addTestReadData([]byte{240, 119, 0x23, 0x05, 0x10, 0, 0x7F, 1, 247})
+
+	_ = b.Once(b.Event("I2cReply"), func(data interface{}) {
+		assert.Equal(t, I2cReply{
+			Address:  0x2A3,
+			Register: 0x10,
+			Data:     []byte{0xFF},
+		}, data)
+		sem <- true
+	})
+
+	_ = b.process()
+
+	select {
+	case <-sem:
+	case <-time.After(semPublishWait):
+		require.Fail(t, "I2cReply was not published")
+	}
+}
+
 func TestProcessFirmwareQuery(t *testing.T) {
 	sem := make(chan bool)
 	b, rwc := initTestFirmataWithReadWriteCloser(t.Name())
@@ -436,3 +621,87 @@ func TestProcessSysexData(t *testing.T) {
 		require.Fail(t, "SysexResponse was not published")
 	}
 }
+
+func TestSerialCommands(t *testing.T) {
+	b := New()
+	b.connection = readWriteCloser{}
+
+	tests := map[string]struct {
+		run  func() error
+		want []byte
+	}{
+		"config_hardware_serial": {
+			run:  func() error { return b.SerialConfig(HwSerial1, 57600, 10, 11) },
+			want: []byte{0xF0, 0x60, 0x11, 0x00, 0x42, 0x03, 0xF7},
+		},
+		"config_software_serial": {
+			run:  func() error { return b.SerialConfig(SwSerial0, 9600, 10, 11) },
+			want: []byte{0xF0, 0x60, 0x18, 0x00, 0x4B, 0x00, 10, 11, 0xF7},
+		},
+		"write": {
+			run:  func() error { return b.SerialWrite(HwSerial2, []byte{0x41, 0xFF}) },
+			want: []byte{0xF0, 0x60, 0x22, 0x41, 0x00, 0x7F, 0x01, 0xF7},
+		},
+		"start_reading": {
+			run:  func() error { return b.SerialStartReading(HwSerial3, 0) },
+			want: []byte{0xF0, 0x60, 0x33, 0x00, 0xF7},
+		},
+		"start_reading_max_bytes": {
+			run:  func() error { return b.SerialStartReading(HwSerial3, 200) },
+			want: []byte{0xF0, 0x60, 0x33, 0x00, 0x48, 0x01, 0xF7},
+		},
+		"stop_reading": {
+			run:  func() error { return b.SerialStopReading(HwSerial3) },
+			want: []byte{0xF0, 0x60, 0x33, 0x01, 0xF7},
+		},
+		"close": {
+			run:  func() error { return b.SerialClose(SwSerial1) },
+			want: []byte{0xF0, 0x60, 0x59, 0xF7},
+		},
+		"flush": {
+			run:  func() error { return b.SerialFlush(HwSerial0) },
+			want: []byte{0xF0, 0x60, 0x60, 0xF7},
+		},
+		"listen": {
+			run:  func() error { return b.SerialListen(SwSerial2) },
+			want: []byte{0xF0, 0x60, 0x7A, 0xF7},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			writeDataMutex.Lock()
+			testWriteData.Reset()
+			writeDataMutex.Unlock()
+			// act
+			err := tc.run()
+			// assert
+			require.NoError(t, err)
+			writeDataMutex.Lock()
+			assert.Equal(t, tc.want, testWriteData.Bytes())
+			writeDataMutex.Unlock()
+		})
+	}
+}
+
+func TestProcessSerialReply(t *testing.T) {
+	sem := make(chan bool)
+	b, rwc := initTestFirmataWithReadWriteCloser(t.Name())
+	rwc.addTestReadData([]byte{240, 0x60, 0x41, 0x24, 0x00, 0x47, 0x00, 0x7F, 0x01, 247})
+
+	_ = b.Once(b.Event("SerialReply"), func(data interface{}) {
+		assert.Equal(t, SerialPortReply{
+			Port: HwSerial1,
+			Data: []byte{'$', 'G', 0xFF},
+		}, data)
+		sem <- true
+	})
+
+	_ = b.process()
+
+	select {
+	case <-sem:
+	case <-time.After(semPublishWait):
+		require.Fail(t, "SerialReply was not published")
+	}
+}
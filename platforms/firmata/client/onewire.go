@@ -0,0 +1,179 @@
+package client
+
+// OneWire sub commands and request bits, see https://github.com/firmata/protocol/blob/master/onewire.md
+const (
+	OneWireSearchRequest       byte = 0x40
+	OneWireConfigRequest       byte = 0x41
+	OneWireSearchReply         byte = 0x42
+	OneWireReadReply           byte = 0x43
+	OneWireSearchAlarmsRequest byte = 0x44
+	OneWireSearchAlarmsReply   byte = 0x45
+	OneWireResetRequestBit     byte = 0x01
+	OneWireSkipRequestBit      byte = 0x02
+	OneWireSelectRequestBit    byte = 0x04
+	OneWireReadRequestBit      byte = 0x08
+	OneWireDelayRequestBit     byte = 0x10
+	OneWireWriteRequestBit     byte = 0x20
+)
+
+// OneWireRequest represents the steps of a 1-wire request, which are executed by the board in the order reset,
+// skip, select, delay, write and read.
+type OneWireRequest struct {
+	Reset         bool   // reset the bus
+	Skip          bool   // skip the ROM selection, addresses all devices
+	Address       []byte // the 8 byte ROM address of the device to select, no selection if empty
+	ReadCount     int    // the number of bytes to read after writing, no read if 0
+	CorrelationID int    // returned with the read reply to identify the request
+	Delay         int    // delay in milliseconds
+	Data          []byte // the data to write
+}
+
+// OneWireSearchResponse represents the response from a 1-wire search or alarms search
+type OneWireSearchResponse struct {
+	Pin       int
+	Addresses [][]byte
+}
+
+// OneWireReadResponse represents the response from a 1-wire read request
+type OneWireReadResponse struct {
+	Pin           int
+	CorrelationID int
+	Data          []byte
+}
+
+// OneWireConfig configures the pin for the 1-wire bus. A parasitic powered device needs the power to be switched on
+// after each write.
+func (b *Client) OneWireConfig(pin int, power bool) error {
+	p := byte(0x00)
+	if power {
+		p = 0x01
+	}
+	return b.WriteSysex([]byte{OneWireData, OneWireConfigRequest, byte(pin), p})
+}
+
+// OneWireSearch starts the search of all devices on the bus, the addresses are published by the
+// "OneWireSearchReply" event.
+func (b *Client) OneWireSearch(pin int) error {
+	return b.WriteSysex([]byte{OneWireData, OneWireSearchRequest, byte(pin)})
+}
+
+// OneWireSearchAlarms starts the search of all devices in alarm state, the addresses are published by the
+// "OneWireSearchAlarmsReply" event.
+func (b *Client) OneWireSearchAlarms(pin int) error {
+	return b.WriteSysex([]byte{OneWireData, OneWireSearchAlarmsRequest, byte(pin)})
+}
+
+// OneWireReset resets the bus.
+func (b *Client) OneWireReset(pin int) error {
+	return b.OneWireCommand(pin, OneWireRequest{Reset: true})
+}
+
+// OneWireCommand sends the request to the bus. The data of a read request are published by the "OneWireReadReply"
+// event together with the correlation ID.
+func (b *Client) OneWireCommand(pin int, req OneWireRequest) error {
+	var cmd byte
+	var data []byte
+	if req.Reset {
+		cmd |= OneWireResetRequestBit
+	}
+	if req.Skip {
+		cmd |= OneWireSkipRequestBit
+	}
+	if len(req.Address) > 0 {
+		cmd |= OneWireSelectRequestBit
+		data = append(data, req.Address...)
+	}
+	if req.ReadCount > 0 {
+		cmd |= OneWireReadRequestBit
+		data = append(data,
+			byte(req.ReadCount&0xFF), byte((req.ReadCount>>8)&0xFF),
+			byte(req.CorrelationID&0xFF), byte((req.CorrelationID>>8)&0xFF))
+	}
+	if req.Delay > 0 {
+		cmd |= OneWireDelayRequestBit
+		data = append(data, byte(req.Delay&0xFF), byte((req.Delay>>8)&0xFF), byte((req.Delay>>16)&0xFF),
+			byte((req.Delay>>24)&0xFF))
+	}
+	if len(req.Data) > 0 {
+		cmd |= OneWireWriteRequestBit
+		data = append(data, req.Data...)
+	}
+
+	return b.WriteSysex(append([]byte{OneWireData, cmd, byte(pin)}, encode7Bit(data)...))
+}
+
+func (b *Client) processOneWire(currentBuffer []byte) {
+	if len(currentBuffer) < 5 {
+		b.publishSysexResponse(currentBuffer)
+		return
+	}
+
+	pin := int(currentBuffer[3])
+	data := decode7Bit(currentBuffer[4 : len(currentBuffer)-1])
+	switch currentBuffer[2] {
+	case OneWireSearchReply, OneWireSearchAlarmsReply:
+		response := OneWireSearchResponse{Pin: pin, Addresses: [][]byte{}}
+		for i := 0; i+8 <= len(data); i = i + 8 {
+			response.Addresses = append(response.Addresses, data[i:i+8])
+		}
+		event := "OneWireSearchReply"
+		if currentBuffer[2] == OneWireSearchAlarmsReply {
+			event = "OneWireSearchAlarmsReply"
+		}
+		b.Publish(b.Event(event), response)
+	case OneWireReadReply:
+		if len(data) < 2 {
+			b.publishSysexResponse(currentBuffer)
+			return
+		}
+		b.Publish(b.Event("OneWireReadReply"), OneWireReadResponse{
+			Pin:           pin,
+			CorrelationID: int(data[0]) | int(data[1])<<8,
+			Data:          data[2:],
+		})
+	default:
+		b.publishSysexResponse(currentBuffer)
+	}
+}
+
+// encode7Bit packs the 8 bit data into 7 bit bytes, used for the binary data of the 1-wire messages
+func encode7Bit(data []byte) []byte {
+	var encoded []byte
+	var shift uint
+	var previous byte
+	for _, val := range data {
+		if shift == 0 {
+			encoded = append(encoded, val&0x7F)
+			shift++
+			previous = val >> 7
+			continue
+		}
+		encoded = append(encoded, ((val<<shift)&0x7F)|previous)
+		if shift == 6 {
+			encoded = append(encoded, val>>1)
+			shift = 0
+		} else {
+			shift++
+			previous = val >> (8 - shift)
+		}
+	}
+	if shift > 0 {
+		encoded = append(encoded, previous)
+	}
+
+	return encoded
+}
+
+// decode7Bit unpacks the 7 bit bytes to 8 bit data, the reverse of encode7Bit()
+func decode7Bit(encoded []byte) []byte {
+	count := len(encoded) * 7 >> 3
+	decoded := make([]byte, count)
+	for i := 0; i < count; i++ {
+		j := i << 3
+		pos := j / 7
+		shift := uint(j % 7)
+		decoded[i] = (encoded[pos] >> shift) | (encoded[pos+1] << (7 - shift))
+	}
+
+	return decoded
+}
@@ -0,0 +1,134 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOneWireCommands(t *testing.T) {
+	b := New()
+	b.connection = readWriteCloser{}
+	address := []byte{0x28, 0xFF, 0x64, 0x1E, 0x0F, 0x00, 0x00, 0x8A}
+
+	tests := map[string]struct {
+		run  func() error
+		want []byte
+	}{
+		"config": {
+			run:  func() error { return b.OneWireConfig(2, true) },
+			want: []byte{0xF0, 0x73, 0x41, 2, 0x01, 0xF7},
+		},
+		"search": {
+			run:  func() error { return b.OneWireSearch(2) },
+			want: []byte{0xF0, 0x73, 0x40, 2, 0xF7},
+		},
+		"search_alarms": {
+			run:  func() error { return b.OneWireSearchAlarms(2) },
+			want: []byte{0xF0, 0x73, 0x44, 2, 0xF7},
+		},
+		"reset": {
+			run:  func() error { return b.OneWireReset(2) },
+			want: []byte{0xF0, 0x73, 0x01, 2, 0xF7},
+		},
+		"skip_and_write": {
+			run:  func() error { return b.OneWireCommand(2, OneWireRequest{Reset: true, Skip: true, Data: []byte{0x44}}) },
+			want: append(append([]byte{0xF0, 0x73, 0x23, 2}, encode7Bit([]byte{0x44})...), 0xF7),
+		},
+		"select_write_and_read": {
+			run: func() error {
+				return b.OneWireCommand(2, OneWireRequest{
+					Reset:         true,
+					Address:       address,
+					ReadCount:     9,
+					CorrelationID: 0x0102,
+					Delay:         750,
+					Data:          []byte{0xBE},
+				})
+			},
+			want: append(append([]byte{0xF0, 0x73, 0x3D, 2},
+				encode7Bit(append(append([]byte{}, address...), 9, 0, 0x02, 0x01, 0xEE, 0x02, 0, 0, 0xBE))...),
+				0xF7),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			writeDataMutex.Lock()
+			testWriteData.Reset()
+			writeDataMutex.Unlock()
+			// act
+			err := tc.run()
+			// assert
+			require.NoError(t, err)
+			writeDataMutex.Lock()
+			assert.Equal(t, tc.want, testWriteData.Bytes())
+			writeDataMutex.Unlock()
+		})
+	}
+}
+
+func TestEncodeDecode7Bit(t *testing.T) {
+	tests := map[string]struct {
+		data []byte
+		want []byte
+	}{
+		"one_byte":    {data: []byte{0xFF}, want: []byte{0x7F, 0x01}},
+		"two_bytes":   {data: []byte{0xFF, 0x01}, want: []byte{0x7F, 0x03, 0x00}},
+		"seven_bytes": {data: []byte{1, 2, 3, 4, 5, 6, 7}, want: []byte{1, 4, 12, 32, 80, 64, 0x41, 3}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			encoded := encode7Bit(tc.data)
+			decoded := decode7Bit(encoded)
+			// assert
+			assert.Equal(t, tc.want, encoded)
+			assert.Equal(t, tc.data, decoded)
+		})
+	}
+}
+
+func TestProcessOneWireSearchReply(t *testing.T) {
+	sem := make(chan bool)
+	b, rwc := initTestFirmataWithReadWriteCloser(t.Name())
+	addr1 := []byte{0x28, 0xFF, 0x64, 0x1E, 0x0F, 0x00, 0x00, 0x8A}
+	addr2 := []byte{0x28, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07}
+	msg := append([]byte{240, 0x73, 0x42, 2}, encode7Bit(append(append([]byte{}, addr1...), addr2...))...)
+	rwc.addTestReadData(append(msg, 247))
+
+	_ = b.Once(b.Event("OneWireSearchReply"), func(data interface{}) {
+		assert.Equal(t, OneWireSearchResponse{Pin: 2, Addresses: [][]byte{addr1, addr2}}, data)
+		sem <- true
+	})
+
+	_ = b.process()
+
+	select {
+	case <-sem:
+	case <-time.After(semPublishWait):
+		require.Fail(t, "OneWireSearchReply was not published")
+	}
+}
+
+func TestProcessOneWireReadReply(t *testing.T) {
+	sem := make(chan bool)
+	b, rwc := initTestFirmataWithReadWriteCloser(t.Name())
+	msg := append([]byte{240, 0x73, 0x43, 2}, encode7Bit([]byte{0x02, 0x01, 0x50, 0x05})...)
+	rwc.addTestReadData(append(msg, 247))
+
+	_ = b.Once(b.Event("OneWireReadReply"), func(data interface{}) {
+		assert.Equal(t, OneWireReadResponse{Pin: 2, CorrelationID: 0x0102, Data: []byte{0x50, 0x05}}, data)
+		sem <- true
+	})
+
+	_ = b.process()
+
+	select {
+	case <-sem:
+	case <-time.After(semPublishWait):
+		require.Fail(t, "OneWireReadReply was not published")
+	}
+}
@@ -0,0 +1,8 @@
+/*
+Package emulator provides an in-process Firmata board, which speaks the Firmata protocol over any io.ReadWriteCloser,
+e.g. a net.Pipe, a TCP connection or a pty. It can be used to test robots with the Firmata adaptor without hardware.
+
+For further information refer to firmata readme:
+https://github.com/hybridgroup/gobot/blob/release/platforms/firmata/README.md
+*/
+package emulator // import "gobot.io/x/gobot/v2/platforms/firmata/emulator"
@@ -0,0 +1,704 @@
+package emulator
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot/v2/platforms/firmata/client"
+)
+
+const (
+	// i2cRegisterNotSpecified is reported as register for reads without register
+	i2cRegisterNotSpecified = 0xFF
+	// i2cMaxQueries is the maximum number of continuous I2C readings, like defined by StandardFirmata
+	i2cMaxQueries = 8
+)
+
+const (
+	defaultFirmwareName     = "EmulatedFirmata"
+	defaultSamplingInterval = 19 * time.Millisecond
+	// noAnalogChannel is reported by the analog mapping for pins without analog input
+	noAnalogChannel = 127
+)
+
+// PinConfig contains the capabilities of an emulated pin.
+type PinConfig struct {
+	Modes         map[int]int // the supported modes (e.g. client.Pwm) with the resolution in bits
+	AnalogChannel int         // the analog input channel, -1 for pins without analog input
+}
+
+// configuration contains all changeable attributes of the emulator.
+type configuration struct {
+	firmwareName     string
+	firmwareMajor    byte
+	firmwareMinor    byte
+	protocolMajor    byte
+	protocolMinor    byte
+	pins             []PinConfig
+	samplingInterval time.Duration
+	i2cDevices       map[int]I2cDevice
+}
+
+type pinState struct {
+	mode  int
+	value int
+}
+
+type i2cQuery struct {
+	address  int
+	register int
+	numBytes int
+}
+
+// Emulator is an emulated Firmata board. It answers the firmware, version, capability, analog mapping and pin state
+// queries, keeps the state of the virtual pins, reports digital and analog inputs and emulates I2C devices.
+type Emulator struct {
+	cfg              *configuration
+	mutex            sync.Mutex
+	writeMutex       sync.Mutex // protects also the connection
+	conn             io.Writer
+	pins             []pinState
+	reportedPorts    map[int]bool
+	reportedChannels map[int]bool
+	i2cQueries       []i2cQuery
+	samplingInterval time.Duration
+}
+
+// NewEmulator creates a new emulated Firmata board with the pins of an Arduino Uno.
+//
+// Supported options:
+//
+//	"WithFirmware"
+//	"WithProtocolVersion"
+//	"WithPins"
+//	"WithSamplingInterval"
+//	"WithI2cDevice"
+func NewEmulator(opts ...optionApplier) *Emulator {
+	cfg := configuration{
+		firmwareName:     defaultFirmwareName,
+		firmwareMajor:    2,
+		firmwareMinor:    5,
+		protocolMajor:    2,
+		protocolMinor:    6,
+		pins:             UnoPins(),
+		samplingInterval: defaultSamplingInterval,
+		i2cDevices:       make(map[int]I2cDevice),
+	}
+
+	for _, o := range opts {
+		o.apply(&cfg)
+	}
+
+	e := &Emulator{cfg: &cfg}
+	e.reset()
+
+	return e
+}
+
+// WithFirmware is used to replace the default firmware name "EmulatedFirmata" and version 2.5.
+func WithFirmware(name string, major, minor byte) optionApplier {
+	return firmwareOption{name: name, major: major, minor: minor}
+}
+
+// WithProtocolVersion is used to replace the default protocol version 2.6.
+func WithProtocolVersion(major, minor byte) optionApplier {
+	return protocolVersionOption{major: major, minor: minor}
+}
+
+// WithPins is used to replace the default pins of an Arduino Uno.
+func WithPins(pins ...PinConfig) optionApplier {
+	return pinsOption(pins)
+}
+
+// WithSamplingInterval is used to replace the default sampling interval of 19 ms for the reporting of analog inputs
+// and continuous I2C readings.
+func WithSamplingInterval(interval time.Duration) optionApplier {
+	return samplingIntervalOption(interval)
+}
+
+// WithI2cDevice is used to add an emulated I2C device at the given address.
+func WithI2cDevice(address int, device I2cDevice) optionApplier {
+	return i2cDeviceOption{address: address, device: device}
+}
+
+// UnoPins returns the pin configuration of an Arduino Uno running StandardFirmata. The pins 0 and 1 are used for the
+// serial connection, the pins 14..19 are the analog inputs A0..A5 and the pins 18 and 19 are used for I2C.
+func UnoPins() []PinConfig {
+	pins := make([]PinConfig, 20)
+	for i := range pins {
+		pins[i] = PinConfig{Modes: make(map[int]int), AnalogChannel: -1}
+		switch {
+		case i < 2:
+			continue
+		case i < 14:
+			pins[i].Modes[client.Servo] = 14
+			switch i {
+			case 3, 5, 6, 9, 10, 11:
+				pins[i].Modes[client.Pwm] = 8
+			}
+		default:
+			pins[i].Modes[client.Analog] = 10
+			pins[i].AnalogChannel = i - 14
+			if i >= 18 {
+				pins[i].Modes[client.I2C] = 1
+			}
+		}
+		pins[i].Modes[client.Input] = 1
+		pins[i].Modes[client.Output] = 1
+		pins[i].Modes[client.Pullup] = 1
+	}
+
+	return pins
+}
+
+// AddI2cDevice adds an emulated I2C device at the given address, an existing device is replaced.
+func (e *Emulator) AddI2cDevice(address int, device I2cDevice) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.cfg.i2cDevices[address] = device
+}
+
+// Serve speaks the Firmata protocol over the given connection, until the connection is closed by the other side.
+func (e *Emulator) Serve(conn io.ReadWriteCloser) error {
+	e.writeMutex.Lock()
+	e.conn = conn
+	e.writeMutex.Unlock()
+
+	done := make(chan struct{})
+	defer func() {
+		close(done)
+		e.writeMutex.Lock()
+		e.conn = nil
+		e.writeMutex.Unlock()
+	}()
+	go e.report(done)
+
+	r := bufio.NewReader(conn)
+	for {
+		if err := e.process(r); err != nil {
+			if isClosed(err) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// ServeListener accepts the connections of the listener one after another and serves each connection, until the
+// listener is closed. This can be used to emulate a board running WiFiFirmata for the TCP adaptor.
+func (e *Emulator) ServeListener(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if isClosed(err) {
+				return nil
+			}
+			return err
+		}
+		err = e.Serve(conn)
+		_ = conn.Close()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// PinMode returns the current mode of the pin.
+func (e *Emulator) PinMode(pin int) int {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if pin < 0 || pin >= len(e.pins) {
+		return -1
+	}
+	return e.pins[pin].mode
+}
+
+// PinValue returns the current value of the pin, e.g. the last written level of a digital output or the duty cycle
+// of a PWM pin.
+func (e *Emulator) PinValue(pin int) int {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if pin < 0 || pin >= len(e.pins) {
+		return -1
+	}
+	return e.pins[pin].value
+}
+
+// SetDigitalInput sets the level of the digital input pin. The port of the pin is reported, if the reporting of the
+// port is enabled.
+func (e *Emulator) SetDigitalInput(pin int, value int) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if pin < 0 || pin >= len(e.pins) {
+		return fmt.Errorf("invalid pin %d, the emulator has %d pins", pin, len(e.pins))
+	}
+	e.pins[pin].value = value
+	if value != 0 {
+		e.pins[pin].value = 1
+	}
+
+	return e.reportPortIfEnabled(pin / 8)
+}
+
+// SetAnalogInput sets the raw value of the analog input channel, which is reported at the next sampling interval.
+func (e *Emulator) SetAnalogInput(channel int, value int) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	pin := e.analogPin(channel)
+	if pin < 0 {
+		return fmt.Errorf("invalid analog channel %d", channel)
+	}
+	e.pins[pin].value = value
+
+	return nil
+}
+
+// SamplingInterval returns the current sampling interval, which can be changed by the host.
+func (e *Emulator) SamplingInterval() time.Duration {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	return e.samplingInterval
+}
+
+// reset restores the state after power on, analog pins are in analog mode and all other pins are outputs
+func (e *Emulator) reset() {
+	e.pins = make([]pinState, len(e.cfg.pins))
+	for i, pc := range e.cfg.pins {
+		e.pins[i].mode = client.Output
+		if _, ok := pc.Modes[client.Analog]; ok {
+			e.pins[i].mode = client.Analog
+		}
+	}
+	e.reportedPorts = make(map[int]bool)
+	e.reportedChannels = make(map[int]bool)
+	e.i2cQueries = nil
+	e.samplingInterval = e.cfg.samplingInterval
+}
+
+// process reads and executes the next message of the host
+func (e *Emulator) process(r *bufio.Reader) error {
+	cmd, err := r.ReadByte()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case cmd == client.StartSysex:
+		data, err := r.ReadBytes(client.EndSysex)
+		if err != nil {
+			return err
+		}
+		return e.processSysex(data[:len(data)-1])
+	case cmd == client.ProtocolVersion:
+		return e.write(client.ProtocolVersion, e.cfg.protocolMajor, e.cfg.protocolMinor)
+	case cmd == client.SystemReset:
+		e.mutex.Lock()
+		e.reset()
+		e.mutex.Unlock()
+		return nil
+	case cmd == client.PinMode, cmd == client.SetDigitalPinValue:
+		args, err := readArgs(r, 2)
+		if err != nil {
+			return err
+		}
+		if cmd == client.PinMode {
+			return e.setPinMode(int(args[0]), int(args[1]))
+		}
+		return e.writeDigitalPin(int(args[0]), int(args[1]))
+	case cmd&0xF0 == client.DigitalMessage, cmd&0xF0 == client.AnalogMessage:
+		args, err := readArgs(r, 2)
+		if err != nil {
+			return err
+		}
+		value := int(args[0]) | int(args[1])<<7
+		if cmd&0xF0 == client.DigitalMessage {
+			return e.writeDigitalPort(int(cmd&0x0F), value)
+		}
+		e.writeAnalog(int(cmd&0x0F), value)
+		return nil
+	case cmd&0xF0 == client.ReportAnalog, cmd&0xF0 == client.ReportDigital:
+		args, err := readArgs(r, 1)
+		if err != nil {
+			return err
+		}
+		if cmd&0xF0 == client.ReportAnalog {
+			e.setAnalogReporting(int(cmd&0x0F), args[0] != 0)
+			return nil
+		}
+		return e.setDigitalReporting(int(cmd&0x0F), args[0] != 0)
+	}
+
+	// unknown messages are ignored
+	return nil
+}
+
+func (e *Emulator) processSysex(data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	switch data[0] {
+	case client.FirmwareQuery:
+		msg := []byte{client.StartSysex, client.FirmwareQuery, e.cfg.firmwareMajor, e.cfg.firmwareMinor}
+		msg = append(msg, encode7Bit([]byte(e.cfg.firmwareName))...)
+		return e.write(append(msg, client.EndSysex)...)
+	case client.CapabilityQuery:
+		return e.write(e.capabilityResponse()...)
+	case client.AnalogMappingQuery:
+		msg := []byte{client.StartSysex, client.AnalogMappingResponse}
+		for _, pc := range e.cfg.pins {
+			channel := byte(noAnalogChannel)
+			if pc.AnalogChannel >= 0 {
+				channel = byte(pc.AnalogChannel)
+			}
+			msg = append(msg, channel)
+		}
+		return e.write(append(msg, client.EndSysex)...)
+	case client.PinStateQuery:
+		if len(data) < 2 {
+			return nil
+		}
+		return e.pinStateResponse(int(data[1]))
+	case client.ExtendedAnalog:
+		if len(data) < 3 {
+			return nil
+		}
+		value := 0
+		for i, v := range data[2:] {
+			value |= int(v) << (7 * i)
+		}
+		e.writeAnalog(int(data[1]), value)
+	case client.SamplingInterval:
+		if len(data) < 3 {
+			return nil
+		}
+		e.mutex.Lock()
+		e.samplingInterval = time.Duration(int(data[1])|int(data[2])<<7) * time.Millisecond
+		e.mutex.Unlock()
+	case client.I2CRequest:
+		return e.processI2cRequest(data[1:])
+	case client.I2CConfig, client.ServoConfig, client.StringData:
+		// accepted without reply
+	}
+
+	// unknown commands are ignored
+	return nil
+}
+
+func (e *Emulator) capabilityResponse() []byte {
+	msg := []byte{client.StartSysex, client.CapabilityResponse}
+	for _, pc := range e.cfg.pins {
+		modes := make([]int, 0, len(pc.Modes))
+		for mode := range pc.Modes {
+			modes = append(modes, mode)
+		}
+		sort.Ints(modes)
+		for _, mode := range modes {
+			msg = append(msg, byte(mode), byte(pc.Modes[mode]))
+		}
+		msg = append(msg, 0x7F)
+	}
+
+	return append(msg, client.EndSysex)
+}
+
+func (e *Emulator) pinStateResponse(pin int) error {
+	e.mutex.Lock()
+	if pin >= len(e.pins) {
+		e.mutex.Unlock()
+		return nil
+	}
+	state := e.pins[pin]
+	e.mutex.Unlock()
+
+	msg := []byte{client.StartSysex, client.PinStateResponse, byte(pin), byte(state.mode)}
+	for v := state.value; ; v >>= 7 {
+		msg = append(msg, byte(v&0x7F))
+		if v <= 0x7F {
+			break
+		}
+	}
+
+	return e.write(append(msg, client.EndSysex)...)
+}
+
+func (e *Emulator) setPinMode(pin int, mode int) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if pin >= len(e.pins) {
+		return nil
+	}
+	if _, ok := e.cfg.pins[pin].Modes[mode]; !ok {
+		return e.writeString("Unknown pin mode")
+	}
+
+	e.pins[pin].mode = mode
+	switch mode {
+	case client.Analog:
+		// like StandardFirmata, the reporting is enabled by setting the analog mode
+		e.reportedChannels[e.cfg.pins[pin].AnalogChannel] = true
+	case client.Input, client.Pullup:
+		return e.reportPortIfEnabled(pin / 8)
+	}
+
+	return nil
+}
+
+func (e *Emulator) writeDigitalPin(pin int, value int) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if pin < len(e.pins) && e.pins[pin].mode == client.Output {
+		e.pins[pin].value = value & 0x01
+	}
+
+	return nil
+}
+
+// writeDigitalPort sets the value of all output pins of the port
+func (e *Emulator) writeDigitalPort(port int, value int) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	for i := 0; i < 8; i++ {
+		pin := port*8 + i
+		if pin < len(e.pins) && e.pins[pin].mode == client.Output {
+			e.pins[pin].value = (value >> i) & 0x01
+		}
+	}
+
+	return nil
+}
+
+// writeAnalog sets the value of a PWM or servo pin
+func (e *Emulator) writeAnalog(pin int, value int) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if pin < len(e.pins) && (e.pins[pin].mode == client.Pwm || e.pins[pin].mode == client.Servo) {
+		e.pins[pin].value = value
+	}
+}
+
+func (e *Emulator) setAnalogReporting(channel int, enable bool) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	// like StandardFirmata, the reporting of unknown channels is ignored
+	if e.analogPin(channel) < 0 {
+		return
+	}
+	e.reportedChannels[channel] = enable
+}
+
+func (e *Emulator) setDigitalReporting(port int, enable bool) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.reportedPorts[port] = enable
+
+	return e.reportPortIfEnabled(port)
+}
+
+// reportPortIfEnabled sends the values of all input pins of the port, if the reporting of the port is enabled
+func (e *Emulator) reportPortIfEnabled(port int) error {
+	if !e.reportedPorts[port] {
+		return nil
+	}
+
+	value := 0
+	for i := 0; i < 8; i++ {
+		pin := port*8 + i
+		if pin < len(e.pins) && (e.pins[pin].mode == client.Input || e.pins[pin].mode == client.Pullup) {
+			value |= (e.pins[pin].value & 0x01) << i
+		}
+	}
+
+	return e.write(client.DigitalMessage|byte(port), byte(value&0x7F), byte(value>>7&0x7F))
+}
+
+// report sends the values of the reported analog channels and the continuous I2C readings at each sampling interval
+func (e *Emulator) report(done chan struct{}) {
+	for {
+		e.mutex.Lock()
+		interval := e.samplingInterval
+		e.mutex.Unlock()
+
+		select {
+		case <-done:
+			return
+		case <-time.After(interval):
+		}
+
+		e.mutex.Lock()
+		var messages [][]byte
+		for pin, state := range e.pins {
+			channel := e.cfg.pins[pin].AnalogChannel
+			if channel >= 0 && state.mode == client.Analog && e.reportedChannels[channel] {
+				messages = append(messages,
+					[]byte{client.AnalogMessage | byte(channel&0x0F), byte(state.value & 0x7F), byte(state.value >> 7 & 0x7F)})
+			}
+		}
+		queries := append([]i2cQuery{}, e.i2cQueries...)
+		e.mutex.Unlock()
+
+		for _, msg := range messages {
+			if err := e.write(msg...); err != nil {
+				return
+			}
+		}
+		for _, q := range queries {
+			if err := e.i2cRead(q.address, q.register, q.numBytes); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (e *Emulator) processI2cRequest(args []byte) error {
+	if len(args) < 2 {
+		return nil
+	}
+
+	address := int(args[0])
+	if args[1]&client.I2C10BitAddressMode != 0 {
+		address |= int(args[1]&0x07) << 7
+	}
+	mode := (args[1] >> 3) & 0x03
+	payload := decode7Bit(args[2:])
+
+	switch mode {
+	case client.I2CModeWrite:
+		e.mutex.Lock()
+		device, ok := e.cfg.i2cDevices[address]
+		e.mutex.Unlock()
+		if !ok {
+			return e.writeString(fmt.Sprintf("I2C: no device at address 0x%X", address))
+		}
+		if err := device.I2cWrite(payload); err != nil {
+			return e.writeString(fmt.Sprintf("I2C: write error %v", err))
+		}
+	case client.I2CModeRead, client.I2CModeContinuousRead:
+		register, numBytes := -1, 0
+		switch len(args[2:]) {
+		case 4:
+			register = int(args[2]) | int(args[3])<<7
+			numBytes = int(args[4]) | int(args[5])<<7
+		case 2:
+			numBytes = int(args[2]) | int(args[3])<<7
+		default:
+			return nil
+		}
+		if mode == client.I2CModeRead {
+			return e.i2cRead(address, register, numBytes)
+		}
+		e.mutex.Lock()
+		if len(e.i2cQueries) < i2cMaxQueries {
+			e.i2cQueries = append(e.i2cQueries, i2cQuery{address: address, register: register, numBytes: numBytes})
+		}
+		e.mutex.Unlock()
+	case client.I2CModeStopReading:
+		// like StandardFirmata, only the first reading of the address is stopped
+		e.mutex.Lock()
+		for i, q := range e.i2cQueries {
+			if q.address == address {
+				e.i2cQueries = append(e.i2cQueries[:i], e.i2cQueries[i+1:]...)
+				break
+			}
+		}
+		e.mutex.Unlock()
+	}
+
+	return nil
+}
+
+// i2cRead reads from the emulated device and sends the reply
+func (e *Emulator) i2cRead(address int, register int, numBytes int) error {
+	e.mutex.Lock()
+	device, ok := e.cfg.i2cDevices[address]
+	e.mutex.Unlock()
+	if !ok {
+		return e.writeString("I2C: Too few bytes received")
+	}
+
+	data, err := device.I2cRead(register, numBytes)
+	if err != nil || len(data) < numBytes {
+		return e.writeString("I2C: Too few bytes received")
+	}
+
+	reg := i2cRegisterNotSpecified
+	if register >= 0 {
+		reg = register
+	}
+	msg := []byte{client.StartSysex, client.I2CReply, byte(address & 0x7F), byte(address >> 7 & 0x7F), byte(reg & 0x7F),
+		byte(reg >> 7 & 0x7F)}
+	msg = append(msg, encode7Bit(data[:numBytes])...)
+
+	return e.write(append(msg, client.EndSysex)...)
+}
+
+func (e *Emulator) writeString(s string) error {
+	msg := append([]byte{client.StartSysex, client.StringData}, encode7Bit([]byte(s))...)
+	return e.write(append(msg, client.EndSysex)...)
+}
+
+func (e *Emulator) analogPin(channel int) int {
+	for pin, pc := range e.cfg.pins {
+		if pc.AnalogChannel == channel && channel >= 0 {
+			return pin
+		}
+	}
+	return -1
+}
+
+func (e *Emulator) write(data ...byte) error {
+	e.writeMutex.Lock()
+	defer e.writeMutex.Unlock()
+
+	if e.conn == nil {
+		return nil
+	}
+	_, err := e.conn.Write(data)
+	return err
+}
+
+func readArgs(r *bufio.Reader, n int) ([]byte, error) {
+	args := make([]byte, n)
+	_, err := io.ReadFull(r, args)
+	return args, err
+}
+
+// encode7Bit splits each byte into two 7-bit bytes, the LSB first
+func encode7Bit(data []byte) []byte {
+	encoded := make([]byte, 0, 2*len(data))
+	for _, b := range data {
+		encoded = append(encoded, b&0x7F, b>>7&0x01)
+	}
+	return encoded
+}
+
+// decode7Bit combines each pair of 7-bit bytes to a byte
+func decode7Bit(data []byte) []byte {
+	decoded := make([]byte, 0, len(data)/2)
+	for i := 0; i+1 < len(data); i += 2 {
+		decoded = append(decoded, data[i]|data[i+1]<<7)
+	}
+	return decoded
+}
+
+func isClosed(err error) bool {
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrClosedPipe) || errors.Is(err, net.ErrClosed)
+}
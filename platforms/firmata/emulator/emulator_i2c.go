@@ -0,0 +1,109 @@
+package emulator
+
+import (
+	"fmt"
+	"sync"
+)
+
+// I2cDevice is the interface of an emulated I2C device, which is called by the emulator for each I2C request of the
+// host to the address of the device.
+type I2cDevice interface {
+	// I2cWrite is called with the written data.
+	I2cWrite(data []byte) error
+	// I2cRead returns numBytes of data, read from the register. The register is -1 for reads without register.
+	I2cRead(register int, numBytes int) ([]byte, error)
+}
+
+// I2cDeviceFuncs is an I2cDevice, which calls the given functions. A nil function is not supported by the device.
+type I2cDeviceFuncs struct {
+	WriteFunc func(data []byte) error
+	ReadFunc  func(register int, numBytes int) ([]byte, error)
+}
+
+// I2cRegisterDevice emulates a simple I2C device with registers, like most sensors. The first written byte sets the
+// register pointer, all following bytes are written to the registers with auto increment. Reads start at the given
+// register or at the register pointer and increment the pointer as well.
+type I2cRegisterDevice struct {
+	mutex     sync.Mutex
+	registers []byte
+	pointer   int
+}
+
+// I2cWrite calls the write function.
+func (d I2cDeviceFuncs) I2cWrite(data []byte) error {
+	if d.WriteFunc == nil {
+		return fmt.Errorf("write is not supported by the emulated I2C device")
+	}
+	return d.WriteFunc(data)
+}
+
+// I2cRead calls the read function.
+func (d I2cDeviceFuncs) I2cRead(register int, numBytes int) ([]byte, error) {
+	if d.ReadFunc == nil {
+		return nil, fmt.Errorf("read is not supported by the emulated I2C device")
+	}
+	return d.ReadFunc(register, numBytes)
+}
+
+// NewI2cRegisterDevice creates a new emulated I2C device with the given initial register content.
+func NewI2cRegisterDevice(registers []byte) *I2cRegisterDevice {
+	return &I2cRegisterDevice{registers: append([]byte{}, registers...)}
+}
+
+// Registers returns a copy of the current register content.
+func (d *I2cRegisterDevice) Registers() []byte {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return append([]byte{}, d.registers...)
+}
+
+// SetRegisters overwrites the registers, starting at the given register.
+func (d *I2cRegisterDevice) SetRegisters(register int, data ...byte) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if register < 0 || register+len(data) > len(d.registers) {
+		return fmt.Errorf("registers 0x%02X..0x%02X are out of range", register, register+len(data)-1)
+	}
+	copy(d.registers[register:], data)
+
+	return nil
+}
+
+// I2cWrite sets the register pointer by the first byte and writes the remaining bytes to the registers.
+func (d *I2cRegisterDevice) I2cWrite(data []byte) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if len(data) == 0 {
+		return nil
+	}
+	if int(data[0])+len(data)-1 > len(d.registers) {
+		return fmt.Errorf("registers 0x%02X..0x%02X are out of range", data[0], int(data[0])+len(data)-2)
+	}
+	d.pointer = int(data[0])
+	for _, val := range data[1:] {
+		d.registers[d.pointer] = val
+		d.pointer++
+	}
+
+	return nil
+}
+
+// I2cRead reads from the registers, starting at the given register or at the register pointer.
+func (d *I2cRegisterDevice) I2cRead(register int, numBytes int) ([]byte, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if register >= 0 {
+		d.pointer = register
+	}
+	if d.pointer+numBytes > len(d.registers) {
+		return nil, fmt.Errorf("registers 0x%02X..0x%02X are out of range", d.pointer, d.pointer+numBytes-1)
+	}
+	data := append([]byte{}, d.registers[d.pointer:d.pointer+numBytes]...)
+	d.pointer += numBytes
+
+	return data, nil
+}
@@ -0,0 +1,74 @@
+package emulator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// make sure that the devices fulfill the I2cDevice interface
+var (
+	_ I2cDevice = (*I2cRegisterDevice)(nil)
+	_ I2cDevice = I2cDeviceFuncs{}
+)
+
+func TestI2cRegisterDevice(t *testing.T) {
+	// arrange
+	d := NewI2cRegisterDevice(make([]byte, 4))
+	// act & assert: write with auto increment
+	require.NoError(t, d.I2cWrite([]byte{0x01, 0xAA, 0xBB}))
+	assert.Equal(t, []byte{0x00, 0xAA, 0xBB, 0x00}, d.Registers())
+	// act & assert: read from the register pointer, which was incremented by the write
+	data, err := d.I2cRead(-1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x00}, data)
+	// act & assert: read from register
+	require.NoError(t, d.SetRegisters(3, 0xCC))
+	data, err = d.I2cRead(1, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xAA, 0xBB, 0xCC}, data)
+	// act & assert: set the register pointer only
+	require.NoError(t, d.I2cWrite([]byte{0x02}))
+	data, err = d.I2cRead(-1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xBB, 0xCC}, data)
+	require.NoError(t, d.I2cWrite(nil))
+	// act & assert: errors
+	require.EqualError(t, d.I2cWrite([]byte{0x03, 0x01, 0x02}), "registers 0x03..0x04 are out of range")
+	_, err = d.I2cRead(2, 3)
+	require.EqualError(t, err, "registers 0x02..0x04 are out of range")
+	require.EqualError(t, d.SetRegisters(4, 0x01), "registers 0x04..0x04 are out of range")
+}
+
+func TestI2cDeviceFuncs(t *testing.T) {
+	// arrange
+	var written []byte
+	d := I2cDeviceFuncs{
+		WriteFunc: func(data []byte) error {
+			written = data
+			return nil
+		},
+		ReadFunc: func(register int, numBytes int) ([]byte, error) {
+			if register < 0 {
+				return nil, errors.New("register needed")
+			}
+			return make([]byte, numBytes), nil
+		},
+	}
+	// act
+	errWrite := d.I2cWrite([]byte{0x01})
+	data, errRead := d.I2cRead(0x10, 2)
+	_, errNoRegister := d.I2cRead(-1, 2)
+	// assert
+	require.NoError(t, errWrite)
+	assert.Equal(t, []byte{0x01}, written)
+	require.NoError(t, errRead)
+	assert.Equal(t, []byte{0x00, 0x00}, data)
+	require.EqualError(t, errNoRegister, "register needed")
+	// act & assert: missing functions
+	require.EqualError(t, I2cDeviceFuncs{}.I2cWrite(nil), "write is not supported by the emulated I2C device")
+	_, err := I2cDeviceFuncs{}.I2cRead(0, 1)
+	require.EqualError(t, err, "read is not supported by the emulated I2C device")
+}
@@ -0,0 +1,76 @@
+package emulator
+
+import "time"
+
+// optionApplier needs to be implemented by each configurable option type
+type optionApplier interface {
+	apply(cfg *configuration)
+}
+
+// firmwareOption is the type for applying another firmware name and version
+type firmwareOption struct {
+	name  string
+	major byte
+	minor byte
+}
+
+// protocolVersionOption is the type for applying another protocol version
+type protocolVersionOption struct {
+	major byte
+	minor byte
+}
+
+// pinsOption is the type for applying another pin configuration than the Arduino Uno
+type pinsOption []PinConfig
+
+// samplingIntervalOption is the type for applying another default sampling interval
+type samplingIntervalOption time.Duration
+
+// i2cDeviceOption is the type for adding an emulated I2C device
+type i2cDeviceOption struct {
+	address int
+	device  I2cDevice
+}
+
+func (o firmwareOption) String() string {
+	return "firmware option for Firmata emulators"
+}
+
+func (o protocolVersionOption) String() string {
+	return "protocol version option for Firmata emulators"
+}
+
+func (o pinsOption) String() string {
+	return "pins option for Firmata emulators"
+}
+
+func (o samplingIntervalOption) String() string {
+	return "sampling interval option for Firmata emulators"
+}
+
+func (o i2cDeviceOption) String() string {
+	return "I2C device option for Firmata emulators"
+}
+
+func (o firmwareOption) apply(cfg *configuration) {
+	cfg.firmwareName = o.name
+	cfg.firmwareMajor = o.major
+	cfg.firmwareMinor = o.minor
+}
+
+func (o protocolVersionOption) apply(cfg *configuration) {
+	cfg.protocolMajor = o.major
+	cfg.protocolMinor = o.minor
+}
+
+func (o pinsOption) apply(cfg *configuration) {
+	cfg.pins = o
+}
+
+func (o samplingIntervalOption) apply(cfg *configuration) {
+	cfg.samplingInterval = time.Duration(o)
+}
+
+func (o i2cDeviceOption) apply(cfg *configuration) {
+	cfg.i2cDevices[o.address] = o.device
+}
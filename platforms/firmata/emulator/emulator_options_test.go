@@ -0,0 +1,49 @@
+package emulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"gobot.io/x/gobot/v2/platforms/firmata/client"
+)
+
+func TestWithFirmware(t *testing.T) {
+	// This is a general test, that options are applied by using the WithFirmware() option.
+	// All other configuration options can also be tested by With..(val).apply(cfg).
+	// arrange & act
+	e := NewEmulator(WithFirmware("StandardFirmata.ino", 2, 3))
+	// assert
+	assert.Equal(t, "StandardFirmata.ino", e.cfg.firmwareName)
+	assert.Equal(t, byte(2), e.cfg.firmwareMajor)
+	assert.Equal(t, byte(3), e.cfg.firmwareMinor)
+}
+
+func TestWithPins(t *testing.T) {
+	// arrange
+	cfg := &configuration{pins: UnoPins()}
+	pins := []PinConfig{{Modes: map[int]int{client.Input: 1}, AnalogChannel: -1}}
+	// act
+	WithPins(pins...).apply(cfg)
+	// assert
+	assert.Equal(t, pins, cfg.pins)
+}
+
+func TestWithSamplingInterval(t *testing.T) {
+	// arrange & act
+	e := NewEmulator(WithSamplingInterval(50 * time.Millisecond))
+	// assert
+	assert.Equal(t, 50*time.Millisecond, e.cfg.samplingInterval)
+	assert.Equal(t, 50*time.Millisecond, e.SamplingInterval())
+}
+
+func TestWithI2cDevice(t *testing.T) {
+	// arrange
+	cfg := &configuration{i2cDevices: make(map[int]I2cDevice)}
+	dev := NewI2cRegisterDevice([]byte{0x01})
+	// act
+	WithI2cDevice(0x42, dev).apply(cfg)
+	// assert
+	assert.Same(t, dev, cfg.i2cDevices[0x42])
+}
@@ -0,0 +1,250 @@
+package emulator
+
+import (
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2/platforms/firmata/client"
+)
+
+func initTestEmulatorWithPipe(t *testing.T, opts ...optionApplier) (*Emulator, net.Conn) {
+	t.Helper()
+	board, host := net.Pipe()
+	e := NewEmulator(opts...)
+	served := make(chan error, 1)
+	go func() { served <- e.Serve(board) }()
+	t.Cleanup(func() {
+		_ = host.Close()
+		require.NoError(t, <-served)
+	})
+	return e, host
+}
+
+func writeTestMessage(t *testing.T, host net.Conn, msg ...byte) {
+	t.Helper()
+	require.NoError(t, host.SetWriteDeadline(time.Now().Add(time.Second)))
+	_, err := host.Write(msg)
+	require.NoError(t, err)
+}
+
+func readTestMessage(t *testing.T, host net.Conn, n int) []byte {
+	t.Helper()
+	require.NoError(t, host.SetReadDeadline(time.Now().Add(time.Second)))
+	buf := make([]byte, n)
+	_, err := io.ReadFull(host, buf)
+	require.NoError(t, err)
+	return buf
+}
+
+// syncTestEmulator ensures, that all previous messages are processed by the emulator
+func syncTestEmulator(t *testing.T, host net.Conn) {
+	t.Helper()
+	writeTestMessage(t, host, client.ProtocolVersion)
+	assert.Equal(t, []byte{client.ProtocolVersion, 2, 6}, readTestMessage(t, host, 3))
+}
+
+func TestQueries(t *testing.T) {
+	tests := map[string]struct {
+		opts []optionApplier
+		msg  []byte
+		want []byte
+	}{
+		"protocol_version": {
+			opts: []optionApplier{WithProtocolVersion(2, 3)},
+			msg:  []byte{0xF9},
+			want: []byte{0xF9, 2, 3},
+		},
+		"firmware": {
+			opts: []optionApplier{WithFirmware("Test", 1, 2)},
+			msg:  []byte{0xF0, 0x79, 0xF7},
+			want: []byte{0xF0, 0x79, 1, 2, 'T', 0, 'e', 0, 's', 0, 't', 0, 0xF7},
+		},
+		"capabilities": {
+			opts: []optionApplier{WithPins(
+				PinConfig{Modes: map[int]int{}, AnalogChannel: -1},
+				PinConfig{Modes: map[int]int{client.Pwm: 10, client.Input: 1, client.Output: 1}, AnalogChannel: -1},
+				PinConfig{Modes: map[int]int{client.Analog: 12}, AnalogChannel: 0},
+			)},
+			msg:  []byte{0xF0, 0x6B, 0xF7},
+			want: []byte{0xF0, 0x6C, 0x7F, 0, 1, 1, 1, 3, 10, 0x7F, 2, 12, 0x7F, 0xF7},
+		},
+		"analog_mapping_uno": {
+			msg: []byte{0xF0, 0x69, 0xF7},
+			want: []byte{
+				0xF0, 0x6A, 0x7F, 0x7F, 0x7F, 0x7F, 0x7F, 0x7F, 0x7F, 0x7F, 0x7F, 0x7F, 0x7F, 0x7F, 0x7F, 0x7F,
+				0, 1, 2, 3, 4, 5, 0xF7,
+			},
+		},
+		"pin_state_analog_pin_after_reset": {
+			msg:  []byte{0xF0, 0x6D, 15, 0xF7},
+			want: []byte{0xF0, 0x6E, 15, client.Analog, 0, 0xF7},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			_, host := initTestEmulatorWithPipe(t, tc.opts...)
+			// act
+			writeTestMessage(t, host, tc.msg...)
+			// assert
+			assert.Equal(t, tc.want, readTestMessage(t, host, len(tc.want)))
+		})
+	}
+}
+
+func TestUnoCapabilities(t *testing.T) {
+	// arrange
+	pins := UnoPins()
+	// act & assert
+	require.Len(t, pins, 20)
+	assert.Empty(t, pins[1].Modes)
+	assert.Equal(t, map[int]int{client.Input: 1, client.Output: 1, client.Pwm: 8, client.Servo: 14, client.Pullup: 1},
+		pins[3].Modes)
+	assert.Equal(t, map[int]int{client.Input: 1, client.Output: 1, client.Servo: 14, client.Pullup: 1}, pins[4].Modes)
+	assert.Equal(t, map[int]int{client.Input: 1, client.Output: 1, client.Analog: 10, client.I2C: 1, client.Pullup: 1},
+		pins[18].Modes)
+	assert.Equal(t, 4, pins[18].AnalogChannel)
+	assert.Equal(t, -1, pins[13].AnalogChannel)
+}
+
+func TestDigitalAndAnalogWrite(t *testing.T) {
+	// arrange
+	e, host := initTestEmulatorWithPipe(t)
+	// act
+	writeTestMessage(t, host, client.PinMode, 3, client.Pwm)
+	writeTestMessage(t, host, client.AnalogMessage|3, 0x7F, 0x01)
+	writeTestMessage(t, host, client.PinMode, 9, client.Servo)
+	writeTestMessage(t, host, client.StartSysex, client.ExtendedAnalog, 9, 0x5A, 0x00, client.EndSysex)
+	// pins 8 and 10 are set, pin 9 is not an output
+	writeTestMessage(t, host, client.DigitalMessage|1, 0x07, 0x00)
+	writeTestMessage(t, host, client.SetDigitalPinValue, 13, 1)
+	// the mode is not changed for an unsupported mode
+	writeTestMessage(t, host, client.PinMode, 4, client.Pwm)
+	assert.Equal(t, []byte{0xF0, 0x71, 'U', 0, 'n', 0}, readTestMessage(t, host, 6))
+	_ = readTestMessage(t, host, 2*len("Unknown pin mode")-4+1)
+	syncTestEmulator(t, host)
+	// assert
+	assert.Equal(t, client.Pwm, e.PinMode(3))
+	assert.Equal(t, 0xFF, e.PinValue(3))
+	assert.Equal(t, client.Servo, e.PinMode(9))
+	assert.Equal(t, 90, e.PinValue(9))
+	assert.Equal(t, 1, e.PinValue(8))
+	assert.Equal(t, 1, e.PinValue(10))
+	assert.Equal(t, 1, e.PinValue(13))
+	assert.Equal(t, client.Output, e.PinMode(4))
+	assert.Equal(t, -1, e.PinMode(20))
+	assert.Equal(t, -1, e.PinValue(-1))
+}
+
+func TestDigitalReporting(t *testing.T) {
+	// arrange
+	e, host := initTestEmulatorWithPipe(t)
+	require.NoError(t, e.SetDigitalInput(9, 1))
+	writeTestMessage(t, host, client.PinMode, 9, client.Input)
+	syncTestEmulator(t, host)
+	// act & assert: the port is reported immediately, when enabled
+	writeTestMessage(t, host, client.ReportDigital|1, 1)
+	assert.Equal(t, []byte{client.DigitalMessage | 1, 0x02, 0x00}, readTestMessage(t, host, 3))
+	// act & assert: the port is reported on change of an input
+	go func() { _ = e.SetDigitalInput(15, 1) }()
+	assert.Equal(t, []byte{client.DigitalMessage | 1, 0x02, 0x00}, readTestMessage(t, host, 3))
+	writeTestMessage(t, host, client.PinMode, 15, client.Pullup)
+	assert.Equal(t, []byte{client.DigitalMessage | 1, 0x02, 0x01}, readTestMessage(t, host, 3))
+	require.EqualError(t, e.SetDigitalInput(20, 1), "invalid pin 20, the emulator has 20 pins")
+}
+
+func TestAnalogReporting(t *testing.T) {
+	// arrange
+	e, host := initTestEmulatorWithPipe(t, WithSamplingInterval(5*time.Millisecond))
+	require.NoError(t, e.SetAnalogInput(2, 0x2AA))
+	// act: the reporting is enabled by the analog mode
+	writeTestMessage(t, host, client.PinMode, 16, client.Analog)
+	// assert
+	assert.Equal(t, []byte{client.AnalogMessage | 2, 0x2A, 0x05}, readTestMessage(t, host, 3))
+	// act & assert: disable reporting, unknown channels are ignored
+	writeTestMessage(t, host, client.ReportAnalog|2, 0)
+	writeTestMessage(t, host, client.ReportAnalog|7, 1)
+	syncTestEmulator(t, host)
+	time.Sleep(20 * time.Millisecond)
+	syncTestEmulator(t, host)
+	require.EqualError(t, e.SetAnalogInput(6, 1), "invalid analog channel 6")
+}
+
+func TestSamplingIntervalAndReset(t *testing.T) {
+	// arrange
+	e, host := initTestEmulatorWithPipe(t)
+	// act
+	writeTestMessage(t, host, client.StartSysex, client.SamplingInterval, 0x64, 0x00, client.EndSysex)
+	writeTestMessage(t, host, client.PinMode, 13, client.Input)
+	syncTestEmulator(t, host)
+	// assert
+	assert.Equal(t, 100*time.Millisecond, e.SamplingInterval())
+	assert.Equal(t, client.Input, e.PinMode(13))
+	// act & assert: reset
+	writeTestMessage(t, host, client.SystemReset)
+	syncTestEmulator(t, host)
+	assert.Equal(t, defaultSamplingInterval, e.SamplingInterval())
+	assert.Equal(t, client.Output, e.PinMode(13))
+	assert.Equal(t, client.Analog, e.PinMode(14))
+}
+
+func TestI2cRequests(t *testing.T) {
+	// arrange
+	dev := NewI2cRegisterDevice([]byte{0x00, 0x11, 0x22, 0x33})
+	e, host := initTestEmulatorWithPipe(t, WithI2cDevice(0x42, dev), WithSamplingInterval(5*time.Millisecond))
+	e.AddI2cDevice(0x2A3, NewI2cRegisterDevice([]byte{0xAB}))
+	writeTestMessage(t, host, client.StartSysex, client.I2CConfig, 0, 0, client.EndSysex)
+	// act & assert: write register 2
+	writeTestMessage(t, host, client.StartSysex, client.I2CRequest, 0x42, 0x00, 0x02, 0x00, 0x7F, 0x01, client.EndSysex)
+	syncTestEmulator(t, host)
+	assert.Equal(t, []byte{0x00, 0x11, 0xFF, 0x33}, dev.Registers())
+	// act & assert: read 2 bytes from register 1 with restart
+	writeTestMessage(t, host, client.StartSysex, client.I2CRequest, 0x42, 0x48, 0x01, 0x00, 0x02, 0x00, client.EndSysex)
+	assert.Equal(t, []byte{0xF0, 0x77, 0x42, 0x00, 0x01, 0x00, 0x11, 0x00, 0x7F, 0x01, 0xF7},
+		readTestMessage(t, host, 11))
+	// act & assert: read 1 byte without register from a 10-bit address
+	writeTestMessage(t, host, client.StartSysex, client.I2CRequest, 0x23, 0x2D, 0x01, 0x00, client.EndSysex)
+	assert.Equal(t, []byte{0xF0, 0x77, 0x23, 0x05, 0x7F, 0x01, 0x2B, 0x01, 0xF7}, readTestMessage(t, host, 9))
+	// act & assert: no device at the address
+	writeTestMessage(t, host, client.StartSysex, client.I2CRequest, 0x10, 0x08, 0x01, 0x00, client.EndSysex)
+	assert.Equal(t, []byte{0xF0, 0x71, 'I', 0, '2', 0, 'C', 0}, readTestMessage(t, host, 8))
+	_ = readTestMessage(t, host, 2*len("I2C: Too few bytes received")-6+1)
+	// act & assert: continuous reading until stopped
+	writeTestMessage(t, host, client.StartSysex, client.I2CRequest, 0x42, 0x10, 0x03, 0x00, 0x01, 0x00, client.EndSysex)
+	assert.Equal(t, []byte{0xF0, 0x77, 0x42, 0x00, 0x03, 0x00, 0x33, 0x00, 0xF7}, readTestMessage(t, host, 9))
+	assert.Equal(t, []byte{0xF0, 0x77, 0x42, 0x00, 0x03, 0x00, 0x33, 0x00, 0xF7}, readTestMessage(t, host, 9))
+	writeTestMessage(t, host, client.StartSysex, client.I2CRequest, 0x42, 0x18, client.EndSysex)
+	// a reply can be on its way, while the reading is stopped
+	require.NoError(t, host.SetReadDeadline(time.Now().Add(50*time.Millisecond)))
+	_, _ = io.ReadFull(host, make([]byte, 9))
+	syncTestEmulator(t, host)
+}
+
+func TestServeListener(t *testing.T) {
+	// arrange
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	e := NewEmulator()
+	served := make(chan error, 1)
+	go func() { served <- e.ServeListener(l) }()
+	// act
+	for i := 0; i < 2; i++ {
+		host, err := net.Dial("tcp", l.Addr().String())
+		require.NoError(t, err)
+		syncTestEmulator(t, host)
+		require.NoError(t, host.Close())
+	}
+	require.NoError(t, l.Close())
+	// assert
+	select {
+	case err := <-served:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		require.Fail(t, "emulator has not stopped after closing the listener")
+	}
+}
@@ -0,0 +1,225 @@
+//go:build !windows
+// +build !windows
+
+package firmata
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"gobot.io/x/gobot/v2/platforms/firmata/client"
+)
+
+const (
+	stepperMaxDevices     = 10
+	multiStepperMaxGroups = 5
+	// stepperReplyTimeout is the maximum time to wait for the reply of a position request
+	stepperReplyTimeout = time.Second
+)
+
+// StepperSettings contains the configuration of a stepper motor for the AccelStepper feature of the
+// ConfigurableFirmata. The stepping is done by the microcontroller.
+type StepperSettings struct {
+	Interface  int      // client.StepperInterfaceDriver, client.StepperInterfaceTwoWire, ...ThreeWire or ...FourWire
+	StepSize   int      // client.StepperWholeStep or client.StepperHalfStep
+	Pins       []string // step and direction pin for the driver interface, otherwise the 2..4 motor pins
+	EnablePin  string   // optional
+	InvertPins int      // bit mask of the pins to invert, bit 0 for the first pin, bit 4 for the enable pin
+}
+
+// StepperConfig configures the stepper motor with the given device number (0..9).
+func (f *Adaptor) StepperConfig(device int, settings StepperSettings) error {
+	if err := validateStepperDevice(device); err != nil {
+		return err
+	}
+
+	wantPins := settings.Interface
+	if settings.Interface == client.StepperInterfaceDriver {
+		wantPins = 2
+	}
+	if wantPins < 2 || wantPins > 4 || len(settings.Pins) != wantPins {
+		return fmt.Errorf("Invalid stepper interface %d with %d pins", settings.Interface, len(settings.Pins))
+	}
+
+	cfg := client.AccelStepperSettings{
+		Interface:  settings.Interface,
+		StepSize:   settings.StepSize,
+		EnablePin:  -1,
+		InvertPins: settings.InvertPins,
+	}
+	for _, pin := range settings.Pins {
+		p, err := strconv.Atoi(pin)
+		if err != nil {
+			return err
+		}
+		cfg.Pins = append(cfg.Pins, p)
+	}
+	if settings.EnablePin != "" {
+		p, err := strconv.Atoi(settings.EnablePin)
+		if err != nil {
+			return err
+		}
+		cfg.EnablePin = p
+	}
+
+	return f.Board.AccelStepperConfig(device, cfg)
+}
+
+// StepperZero sets the current position of the stepper motor to zero.
+func (f *Adaptor) StepperZero(device int) error {
+	if err := validateStepperDevice(device); err != nil {
+		return err
+	}
+
+	return f.Board.AccelStepperZero(device)
+}
+
+// StepperStep moves the stepper motor by the given steps. The event "StepperMoveComplete" is published with a
+// client.StepperPositionReply, when the move is completed.
+func (f *Adaptor) StepperStep(device int, steps int32) error {
+	if err := validateStepperDevice(device); err != nil {
+		return err
+	}
+
+	return f.Board.AccelStepperStep(device, steps)
+}
+
+// StepperTo moves the stepper motor to the absolute position. The event "StepperMoveComplete" is published with a
+// client.StepperPositionReply, when the move is completed.
+func (f *Adaptor) StepperTo(device int, position int32) error {
+	if err := validateStepperDevice(device); err != nil {
+		return err
+	}
+
+	return f.Board.AccelStepperTo(device, position)
+}
+
+// StepperEnable switches the enable pin of the stepper motor.
+func (f *Adaptor) StepperEnable(device int, enable bool) error {
+	if err := validateStepperDevice(device); err != nil {
+		return err
+	}
+
+	return f.Board.AccelStepperEnable(device, enable)
+}
+
+// StepperStop stops the stepper motor, the event "StepperMoveComplete" is published afterwards.
+func (f *Adaptor) StepperStop(device int) error {
+	if err := validateStepperDevice(device); err != nil {
+		return err
+	}
+
+	return f.Board.AccelStepperStop(device)
+}
+
+// StepperSetSpeed sets the maximum speed of the stepper motor in steps per second.
+func (f *Adaptor) StepperSetSpeed(device int, speed float64) error {
+	if err := validateStepperDevice(device); err != nil {
+		return err
+	}
+
+	return f.Board.AccelStepperSetSpeed(device, speed)
+}
+
+// StepperSetAcceleration sets the acceleration of the stepper motor in steps per second². A value of 0 disables the
+// acceleration.
+func (f *Adaptor) StepperSetAcceleration(device int, acceleration float64) error {
+	if err := validateStepperDevice(device); err != nil {
+		return err
+	}
+
+	return f.Board.AccelStepperSetAcceleration(device, acceleration)
+}
+
+// StepperPosition requests and returns the current position of the stepper motor.
+func (f *Adaptor) StepperPosition(device int) (int32, error) {
+	if err := validateStepperDevice(device); err != nil {
+		return 0, err
+	}
+
+	reply := make(chan int32, 1)
+	f.mutex.Lock()
+	f.stepperPositions[device] = reply
+	f.mutex.Unlock()
+
+	defer func() {
+		f.mutex.Lock()
+		delete(f.stepperPositions, device)
+		f.mutex.Unlock()
+	}()
+
+	if err := f.Board.AccelStepperReportPosition(device); err != nil {
+		return 0, err
+	}
+
+	select {
+	case position := <-reply:
+		return position, nil
+	case <-time.After(stepperReplyTimeout):
+		return 0, fmt.Errorf("position request of stepper %d timed out", device)
+	}
+}
+
+// MultiStepperConfig configures the group (0..4) of stepper motors, which are moved together.
+func (f *Adaptor) MultiStepperConfig(group int, devices ...int) error {
+	if err := validateMultiStepperGroup(group); err != nil {
+		return err
+	}
+	for _, device := range devices {
+		if err := validateStepperDevice(device); err != nil {
+			return err
+		}
+	}
+
+	return f.Board.MultiStepperConfig(group, devices)
+}
+
+// MultiStepperTo moves all stepper motors of the group to the absolute positions, so that all motors arrive at the
+// same time. The event "MultiStepperMoveComplete" is published with the group number afterwards.
+func (f *Adaptor) MultiStepperTo(group int, positions ...int32) error {
+	if err := validateMultiStepperGroup(group); err != nil {
+		return err
+	}
+
+	return f.Board.MultiStepperTo(group, positions)
+}
+
+// MultiStepperStop stops all stepper motors of the group.
+func (f *Adaptor) MultiStepperStop(group int) error {
+	if err := validateMultiStepperGroup(group); err != nil {
+		return err
+	}
+
+	return f.Board.MultiStepperStop(group)
+}
+
+// receiveStepperPosition forwards the reported position to the waiting request
+func (f *Adaptor) receiveStepperPosition(data interface{}) {
+	reply, ok := data.(client.StepperPositionReply)
+	if !ok {
+		return
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if position, ok := f.stepperPositions[reply.Device]; ok {
+		position <- reply.Position
+		delete(f.stepperPositions, reply.Device)
+	}
+}
+
+func validateStepperDevice(device int) error {
+	if device < 0 || device >= stepperMaxDevices {
+		return fmt.Errorf("Invalid stepper device %d, only 0..%d are supported", device, stepperMaxDevices-1)
+	}
+	return nil
+}
+
+func validateMultiStepperGroup(group int) error {
+	if group < 0 || group >= multiStepperMaxGroups {
+		return fmt.Errorf("Invalid multi stepper group %d, only 0..%d are supported", group, multiStepperMaxGroups-1)
+	}
+	return nil
+}
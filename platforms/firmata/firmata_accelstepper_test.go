@@ -0,0 +1,223 @@
+//go:build !windows
+// +build !windows
+
+package firmata
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2/platforms/firmata/client"
+)
+
+type stepperMockFirmataBoard struct {
+	mockFirmataBoard
+	calls    []string
+	settings client.AccelStepperSettings
+	position int32
+}
+
+func (m *stepperMockFirmataBoard) AccelStepperConfig(device int, settings client.AccelStepperSettings) error {
+	m.calls = append(m.calls, fmt.Sprintf("config %d", device))
+	m.settings = settings
+	return nil
+}
+
+func (m *stepperMockFirmataBoard) AccelStepperZero(device int) error {
+	m.calls = append(m.calls, fmt.Sprintf("zero %d", device))
+	return nil
+}
+
+func (m *stepperMockFirmataBoard) AccelStepperStep(device int, steps int32) error {
+	m.calls = append(m.calls, fmt.Sprintf("step %d %d", device, steps))
+	go m.Publish("StepperMoveComplete", client.StepperPositionReply{Device: device, Position: m.position + steps})
+	return nil
+}
+
+func (m *stepperMockFirmataBoard) AccelStepperTo(device int, position int32) error {
+	m.calls = append(m.calls, fmt.Sprintf("to %d %d", device, position))
+	return nil
+}
+
+func (m *stepperMockFirmataBoard) AccelStepperEnable(device int, enable bool) error {
+	m.calls = append(m.calls, fmt.Sprintf("enable %d %t", device, enable))
+	return nil
+}
+
+func (m *stepperMockFirmataBoard) AccelStepperStop(device int) error {
+	m.calls = append(m.calls, fmt.Sprintf("stop %d", device))
+	return nil
+}
+
+func (m *stepperMockFirmataBoard) AccelStepperReportPosition(device int) error {
+	go m.Publish("StepperPosition", client.StepperPositionReply{Device: device, Position: m.position})
+	return nil
+}
+
+func (m *stepperMockFirmataBoard) AccelStepperSetAcceleration(device int, acceleration float64) error {
+	m.calls = append(m.calls, fmt.Sprintf("acceleration %d %v", device, acceleration))
+	return nil
+}
+
+func (m *stepperMockFirmataBoard) AccelStepperSetSpeed(device int, speed float64) error {
+	m.calls = append(m.calls, fmt.Sprintf("speed %d %v", device, speed))
+	return nil
+}
+
+func (m *stepperMockFirmataBoard) MultiStepperConfig(group int, devices []int) error {
+	m.calls = append(m.calls, fmt.Sprintf("multi config %d %v", group, devices))
+	return nil
+}
+
+func (m *stepperMockFirmataBoard) MultiStepperTo(group int, positions []int32) error {
+	m.calls = append(m.calls, fmt.Sprintf("multi to %d %v", group, positions))
+	go m.Publish("MultiStepperMoveComplete", group)
+	return nil
+}
+
+func (m *stepperMockFirmataBoard) MultiStepperStop(group int) error {
+	m.calls = append(m.calls, fmt.Sprintf("multi stop %d", group))
+	return nil
+}
+
+func initTestAdaptorWithStepperBoard(t *testing.T) (*Adaptor, *stepperMockFirmataBoard) {
+	a := NewAdaptor(&readWriteCloser{})
+	board := &stepperMockFirmataBoard{mockFirmataBoard: *newMockFirmataBoard()}
+	a.Board = board
+	require.NoError(t, a.Connect())
+	return a, board
+}
+
+func TestStepperConfig(t *testing.T) {
+	tests := map[string]struct {
+		device   int
+		settings StepperSettings
+		want     client.AccelStepperSettings
+		wantErr  string
+	}{
+		"driver_with_enable_pin": {
+			device: 1,
+			settings: StepperSettings{
+				Interface: client.StepperInterfaceDriver,
+				Pins:      []string{"2", "3"},
+				EnablePin: "4",
+			},
+			want: client.AccelStepperSettings{
+				Interface: client.StepperInterfaceDriver,
+				Pins:      []int{2, 3},
+				EnablePin: 4,
+			},
+		},
+		"four_wire_half_step": {
+			device: 9,
+			settings: StepperSettings{
+				Interface: client.StepperInterfaceFourWire,
+				StepSize:  client.StepperHalfStep,
+				Pins:      []string{"8", "9", "10", "11"},
+			},
+			want: client.AccelStepperSettings{
+				Interface: client.StepperInterfaceFourWire,
+				StepSize:  client.StepperHalfStep,
+				Pins:      []int{8, 9, 10, 11},
+				EnablePin: -1,
+			},
+		},
+		"error_device": {
+			device:  10,
+			wantErr: "Invalid stepper device 10, only 0..9 are supported",
+		},
+		"error_pin_count": {
+			settings: StepperSettings{Interface: client.StepperInterfaceThreeWire, Pins: []string{"2", "3"}},
+			wantErr:  "Invalid stepper interface 3 with 2 pins",
+		},
+		"error_pin": {
+			settings: StepperSettings{Interface: client.StepperInterfaceDriver, Pins: []string{"2", "x"}},
+			wantErr:  "invalid syntax",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a, board := initTestAdaptorWithStepperBoard(t)
+			// act
+			err := a.StepperConfig(tc.device, tc.settings)
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, board.settings)
+		})
+	}
+}
+
+func TestStepperCommands(t *testing.T) {
+	// arrange
+	a, board := initTestAdaptorWithStepperBoard(t)
+	// act
+	require.NoError(t, a.StepperZero(0))
+	require.NoError(t, a.StepperEnable(0, true))
+	require.NoError(t, a.StepperSetSpeed(0, 500))
+	require.NoError(t, a.StepperSetAcceleration(0, 100.5))
+	require.NoError(t, a.StepperTo(0, -300))
+	require.NoError(t, a.StepperStop(0))
+	require.NoError(t, a.MultiStepperConfig(1, 0, 2))
+	require.NoError(t, a.MultiStepperStop(1))
+	// assert
+	assert.Equal(t, []string{
+		"zero 0", "enable 0 true", "speed 0 500", "acceleration 0 100.5", "to 0 -300", "stop 0",
+		"multi config 1 [0 2]", "multi stop 1",
+	}, board.calls)
+	require.EqualError(t, a.StepperStep(-1, 10), "Invalid stepper device -1, only 0..9 are supported")
+	require.EqualError(t, a.MultiStepperConfig(0, 12), "Invalid stepper device 12, only 0..9 are supported")
+	require.EqualError(t, a.MultiStepperTo(5, 10), "Invalid multi stepper group 5, only 0..4 are supported")
+}
+
+func TestStepperPosition(t *testing.T) {
+	// arrange
+	a, board := initTestAdaptorWithStepperBoard(t)
+	board.position = 1234
+	// act
+	pos, err := a.StepperPosition(3)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, int32(1234), pos)
+	_, err = a.StepperPosition(11)
+	require.EqualError(t, err, "Invalid stepper device 11, only 0..9 are supported")
+}
+
+func TestStepperMoveCompleteEvents(t *testing.T) {
+	// arrange
+	a, board := initTestAdaptorWithStepperBoard(t)
+	board.position = 100
+	moved := make(chan client.StepperPositionReply, 1)
+	groupMoved := make(chan int, 1)
+	_ = a.On("StepperMoveComplete", func(data interface{}) {
+		moved <- data.(client.StepperPositionReply) //nolint:forcetypeassert // ok here
+	})
+	_ = a.On("MultiStepperMoveComplete", func(data interface{}) {
+		groupMoved <- data.(int) //nolint:forcetypeassert // ok here
+	})
+	// act
+	require.NoError(t, a.StepperStep(2, 50))
+	require.NoError(t, a.MultiStepperTo(1, 10, 20))
+	// assert
+	select {
+	case got := <-moved:
+		assert.Equal(t, client.StepperPositionReply{Device: 2, Position: 150}, got)
+	case <-time.After(time.Second):
+		require.Fail(t, "StepperMoveComplete was not published")
+	}
+	select {
+	case got := <-groupMoved:
+		assert.Equal(t, 1, got)
+	case <-time.After(time.Second):
+		require.Fail(t, "MultiStepperMoveComplete was not published")
+	}
+	assert.Contains(t, board.calls, "multi to 1 [10 20]")
+}
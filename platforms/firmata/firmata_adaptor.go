@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"sync"
 	"time"
 
+	"github.com/hashicorp/go-multierror"
 	"go.bug.st/serial"
 
 	"gobot.io/x/gobot/v2"
@@ -20,6 +22,7 @@ type firmataBoard interface {
 	Connect(conn io.ReadWriteCloser) error
 	Disconnect() error
 	Pins() []client.Pin
+	Pin(pin int) (client.Pin, error)
 	AnalogWrite(pin int, value int) error
 	SetPinMode(pin int, mode int) error
 	ReportAnalog(pin int, state int) error
@@ -28,7 +31,34 @@ type firmataBoard interface {
 	I2cRead(address int, numBytes int) error
 	I2cWrite(address int, data []byte) error
 	I2cConfig(delay int) error
+	I2cReadWithFlags(address int, register int, numBytes int, flags byte) error
+	I2cWriteWithFlags(address int, data []byte, flags byte) error
+	I2cReadContinuously(address int, register int, numBytes int, flags byte) error
+	I2cStopReading(address int, flags byte) error
 	ServoConfig(pin int, maximum int, minimum int) error
+	SerialConfig(port int, baudRate int, rxPin int, txPin int) error
+	SerialWrite(port int, data []byte) error
+	SerialStartReading(port int, maxBytes int) error
+	SerialStopReading(port int) error
+	SerialClose(port int) error
+	SerialFlush(port int) error
+	SerialListen(port int) error
+	OneWireConfig(pin int, power bool) error
+	OneWireSearch(pin int) error
+	OneWireSearchAlarms(pin int) error
+	OneWireCommand(pin int, req client.OneWireRequest) error
+	AccelStepperConfig(device int, settings client.AccelStepperSettings) error
+	AccelStepperZero(device int) error
+	AccelStepperStep(device int, steps int32) error
+	AccelStepperTo(device int, position int32) error
+	AccelStepperEnable(device int, enable bool) error
+	AccelStepperStop(device int) error
+	AccelStepperReportPosition(device int) error
+	AccelStepperSetAcceleration(device int, acceleration float64) error
+	AccelStepperSetSpeed(device int, speed float64) error
+	MultiStepperConfig(group int, devices []int) error
+	MultiStepperTo(group int, positions []int32) error
+	MultiStepperStop(group int) error
 	WriteSysex(data []byte) error
 	gobot.Eventer
 }
@@ -50,12 +80,21 @@ type Adaptor struct {
 	conn       io.ReadWriteCloser
 	PortOpener func(port string) (io.ReadWriteCloser, error)
 	gobot.Eventer
+	mutex             sync.Mutex
+	serialConnections map[int]*SerialConnection
+	oneWireBuses      map[int]*OneWireBus
+	stepperPositions  map[int]chan int32
+	i2cSubscribed     bool
+	i2cAutoRestart    bool
+	i2cReads          map[i2cSubscriptionKey]i2cPendingRead
+	i2cSubscriptions  map[i2cSubscriptionKey]func(data []byte)
 }
 
 // NewAdaptor returns a new Firmata Adaptor which optionally accepts:
 //
 //	string: port the Adaptor uses to connect to a serial port with a baude rate of 57600
 //	io.ReadWriteCloser: connection the Adaptor uses to communication with the hardware
+//	WithI2cAutoRestart(): read registers of I2C devices with a repeated start condition
 //
 // If an io.ReadWriteCloser is not supplied, the Adaptor will open a connection
 // to a serial port with a baude rate of 57600. If an io.ReadWriteCloser
@@ -70,8 +109,15 @@ func NewAdaptor(args ...interface{}) *Adaptor {
 		PortOpener: func(port string) (io.ReadWriteCloser, error) {
 			return serial.Open(port, &serial.Mode{BaudRate: 57600})
 		},
-		Eventer: gobot.NewEventer(),
+		Eventer:           gobot.NewEventer(),
+		serialConnections: make(map[int]*SerialConnection),
+		oneWireBuses:      make(map[int]*OneWireBus),
+		stepperPositions:  make(map[int]chan int32),
+		i2cReads:          make(map[i2cSubscriptionKey]i2cPendingRead),
+		i2cSubscriptions:  make(map[i2cSubscriptionKey]func(data []byte)),
 	}
+	f.AddEvent("StepperMoveComplete")
+	f.AddEvent("MultiStepperMoveComplete")
 
 	for _, arg := range args {
 		switch a := arg.(type) {
@@ -79,6 +125,8 @@ func NewAdaptor(args ...interface{}) *Adaptor {
 			f.port = a
 		case io.ReadWriteCloser:
 			f.conn = a
+		case i2cAutoRestartOption:
+			f.i2cAutoRestart = bool(a)
 		}
 	}
 
@@ -98,6 +146,24 @@ func (f *Adaptor) Connect() error {
 		return err
 	}
 
+	for name, handler := range map[string]func(interface{}){
+		"SerialReply":              f.receiveSerial,
+		"OneWireReadReply":         f.receiveOneWire,
+		"OneWireSearchReply":       f.receiveOneWire,
+		"OneWireSearchAlarmsReply": f.receiveOneWire,
+		"StepperPosition":          f.receiveStepperPosition,
+		"StepperMoveComplete": func(data interface{}) {
+			f.Publish("StepperMoveComplete", data)
+		},
+		"MultiStepperMoveComplete": func(data interface{}) {
+			f.Publish("MultiStepperMoveComplete", data)
+		},
+	} {
+		if err := f.Board.On(name, handler); err != nil {
+			return err
+		}
+	}
+
 	return f.Board.On("SysexResponse", func(data interface{}) {
 		f.Publish("SysexResponse", data)
 	})
@@ -111,9 +177,36 @@ func (f *Adaptor) Disconnect() error {
 	return nil
 }
 
-// Finalize terminates the firmata connection
+// Finalize stops all continuous I2C readings, closes all serial connections and 1-wire buses and terminates the
+// firmata connection
 func (f *Adaptor) Finalize() error {
-	return f.Disconnect()
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	var err error
+	if e := f.stopI2cReadings(func(i2cSubscriptionKey) bool { return true }); e != nil {
+		err = multierror.Append(err, e)
+	}
+
+	for _, con := range f.serialConnections {
+		if e := con.Finalize(); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+	f.serialConnections = make(map[int]*SerialConnection)
+
+	for _, bus := range f.oneWireBuses {
+		if e := bus.Finalize(); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+	f.oneWireBuses = make(map[int]*OneWireBus)
+
+	if e := f.Disconnect(); e != nil {
+		err = multierror.Append(err, e)
+	}
+
+	return err
 }
 
 // Port returns the Firmata adaptors port
@@ -127,7 +220,7 @@ func (f *Adaptor) SetName(n string) { f.name = n }
 
 // ServoConfig sets the pulse width in microseconds for a pin attached to a servo
 func (f *Adaptor) ServoConfig(pin string, minimum, maximum int) error {
-	p, err := strconv.Atoi(pin)
+	p, _, err := f.pinWithMode(pin, client.Servo)
 	if err != nil {
 		return err
 	}
@@ -137,12 +230,12 @@ func (f *Adaptor) ServoConfig(pin string, minimum, maximum int) error {
 
 // ServoWrite writes the 0-180 degree angle to the specified pin.
 func (f *Adaptor) ServoWrite(pin string, angle byte) error {
-	p, err := strconv.Atoi(pin)
+	p, bp, err := f.pinWithMode(pin, client.Servo)
 	if err != nil {
 		return err
 	}
 
-	if f.Board.Pins()[p].Mode != client.Servo {
+	if bp.Mode != client.Servo {
 		err = f.Board.SetPinMode(p, client.Servo)
 		if err != nil {
 			return err
@@ -152,31 +245,32 @@ func (f *Adaptor) ServoWrite(pin string, angle byte) error {
 	return f.Board.AnalogWrite(p, int(angle))
 }
 
-// PwmWrite writes the 0-254 value to the specified pin
+// PwmWrite writes the 0-255 value to the specified pin. The value is scaled to the PWM resolution of the pin, which
+// is reported by the board, e.g. 255 is written as 1023 for a pin with a resolution of 10 bits.
 func (f *Adaptor) PwmWrite(pin string, level byte) error {
-	p, err := strconv.Atoi(pin)
+	p, bp, err := f.pinWithMode(pin, client.Pwm)
 	if err != nil {
 		return err
 	}
 
-	if f.Board.Pins()[p].Mode != client.Pwm {
+	if bp.Mode != client.Pwm {
 		err = f.Board.SetPinMode(p, client.Pwm)
 		if err != nil {
 			return err
 		}
 	}
 
-	return f.Board.AnalogWrite(p, int(level))
+	return f.Board.AnalogWrite(p, scaleToResolution(level, bp.Resolutions[client.Pwm]))
 }
 
 // DigitalWrite writes a value to the pin. Acceptable values are 1 or 0.
 func (f *Adaptor) DigitalWrite(pin string, level byte) error {
-	p, err := strconv.Atoi(pin)
+	p, bp, err := f.pinWithMode(pin, client.Output)
 	if err != nil {
 		return err
 	}
 
-	if f.Board.Pins()[p].Mode != client.Output {
+	if bp.Mode != client.Output {
 		if err = f.Board.SetPinMode(p, client.Output); err != nil {
 			return err
 		}
@@ -188,12 +282,12 @@ func (f *Adaptor) DigitalWrite(pin string, level byte) error {
 // DigitalRead retrieves digital value from specified pin.
 // Returns -1 if the response from the board has timed out
 func (f *Adaptor) DigitalRead(pin string) (int, error) {
-	p, err := strconv.Atoi(pin)
+	p, bp, err := f.pinWithMode(pin, client.Input)
 	if err != nil {
 		return 0, err
 	}
 
-	if f.Board.Pins()[p].Mode != client.Input {
+	if bp.Mode != client.Input {
 		if err := f.Board.SetPinMode(p, client.Input); err != nil {
 			return 0, err
 		}
@@ -201,22 +295,24 @@ func (f *Adaptor) DigitalRead(pin string) (int, error) {
 			return 0, err
 		}
 		<-time.After(10 * time.Millisecond)
+		if bp, err = f.Board.Pin(p); err != nil {
+			return 0, err
+		}
 	}
 
-	return f.Board.Pins()[p].Value, nil
+	return bp.Value, nil
 }
 
-// AnalogRead retrieves value from analog pin.
+// AnalogRead retrieves the raw value from the analog pin (the analog channel, e.g. "0" for A0). The range of the value
+// depends on the resolution of the pin, see AnalogReadResolution().
 // Returns -1 if the response from the board has timed out
 func (f *Adaptor) AnalogRead(pin string) (int, error) {
-	p, err := strconv.Atoi(pin)
+	p, bp, err := f.analogPin(pin)
 	if err != nil {
 		return 0, err
 	}
 
-	p = f.digitalPin(p)
-
-	if f.Board.Pins()[p].Mode != client.Analog {
+	if bp.Mode != client.Analog {
 		if err := f.Board.SetPinMode(p, client.Analog); err != nil {
 			return 0, err
 		}
@@ -225,20 +321,19 @@ func (f *Adaptor) AnalogRead(pin string) (int, error) {
 			return 0, err
 		}
 		<-time.After(10 * time.Millisecond)
+		if bp, err = f.Board.Pin(p); err != nil {
+			return 0, err
+		}
 	}
 
-	return f.Board.Pins()[p].Value, nil
+	return bp.Value, nil
 }
 
+// WriteSysex sends a SysEx message to the Firmata board.
 func (f *Adaptor) WriteSysex(data []byte) error {
 	return f.Board.WriteSysex(data)
 }
 
-// digitalPin converts pin number to digital mapping
-func (f *Adaptor) digitalPin(pin int) int {
-	return pin + 14
-}
-
 // GetI2cConnection returns an i2c connection to a device on a specified bus.
 // Only supports bus number 0
 func (f *Adaptor) GetI2cConnection(address int, bus int) (i2c.Connection, error) {
@@ -255,3 +350,67 @@ func (f *Adaptor) GetI2cConnection(address int, bus int) (i2c.Connection, error)
 func (f *Adaptor) DefaultI2cBus() int {
 	return 0
 }
+
+// GetSerialConnection returns the connection to the hardware serial port (client.HwSerial0..3) of the board with the
+// given baud rate. The same connection is returned for the same port.
+func (f *Adaptor) GetSerialConnection(port int, baudRate int) (*SerialConnection, error) {
+	if port < client.HwSerial0 || port > client.HwSerial3 {
+		return nil, fmt.Errorf("Invalid hardware serial port %d, only %d..%d are supported", port, client.HwSerial0,
+			client.HwSerial3)
+	}
+
+	return f.serialConnection(port, baudRate, 0, 0)
+}
+
+// GetSoftwareSerialConnection returns the connection to the software serial port (client.SwSerial0..3) of the board
+// with the given baud rate and pins. The same connection is returned for the same port.
+func (f *Adaptor) GetSoftwareSerialConnection(port int, baudRate int, rxPin, txPin string) (*SerialConnection, error) {
+	if port < client.SwSerial0 || port > client.SwSerial3 {
+		return nil, fmt.Errorf("Invalid software serial port %d, only %d..%d are supported", port, client.SwSerial0,
+			client.SwSerial3)
+	}
+
+	rx, err := strconv.Atoi(rxPin)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := strconv.Atoi(txPin)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.serialConnection(port, baudRate, rx, tx)
+}
+
+func (f *Adaptor) serialConnection(port int, baudRate int, rxPin int, txPin int) (*SerialConnection, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if con := f.serialConnections[port]; con != nil {
+		if con.baudRate != baudRate || con.rxPin != rxPin || con.txPin != txPin {
+			return nil, fmt.Errorf("Serial port %d is already used with baud rate %d", port, con.baudRate)
+		}
+		return con, nil
+	}
+
+	con := newSerialConnection(f, port, baudRate, rxPin, txPin)
+	f.serialConnections[port] = con
+
+	return con, nil
+}
+
+// receiveSerial dispatches the received data to the connection of the serial port
+func (f *Adaptor) receiveSerial(data interface{}) {
+	reply, ok := data.(client.SerialPortReply)
+	if !ok {
+		return
+	}
+
+	f.mutex.Lock()
+	con := f.serialConnections[reply.Port]
+	f.mutex.Unlock()
+
+	if con != nil {
+		con.receive(reply.Data)
+	}
+}
@@ -7,6 +7,7 @@ package firmata
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
 	"testing"
 
@@ -69,6 +70,17 @@ func newMockFirmataBoard() *mockFirmataBoard {
 		pins:            make([]client.Pin, 100),
 	}
 
+	// all pins support the digital, PWM and servo modes, the pins 14..19 are the analog inputs A0..A5
+	for i := range m.pins {
+		m.pins[i].SupportedModes = []int{client.Input, client.Output, client.Pwm, client.Servo}
+		m.pins[i].Resolutions = map[int]int{client.Input: 1, client.Output: 1, client.Pwm: 8, client.Servo: 14}
+		m.pins[i].AnalogChannel = analogChannelNone
+		if i >= 14 && i < 20 {
+			m.pins[i].SupportedModes = append(m.pins[i].SupportedModes, client.Analog)
+			m.pins[i].Resolutions[client.Analog] = 10
+			m.pins[i].AnalogChannel = i - 14
+		}
+	}
 	m.pins[1].Value = 1
 	m.pins[15].Value = 133
 
@@ -85,6 +97,13 @@ func (m mockFirmataBoard) Disconnect() error {
 func (m mockFirmataBoard) Pins() []client.Pin {
 	return m.pins
 }
+
+func (m mockFirmataBoard) Pin(pin int) (client.Pin, error) {
+	if pin < 0 || pin >= len(m.pins) {
+		return client.Pin{}, fmt.Errorf("Invalid pin %d, the board reports %d pins", pin, len(m.pins))
+	}
+	return m.pins[pin], nil
+}
 func (mockFirmataBoard) AnalogWrite(int, int) error      { return nil }
 func (mockFirmataBoard) SetPinMode(int, int) error       { return nil }
 func (mockFirmataBoard) ReportAnalog(int, int) error     { return nil }
@@ -94,9 +113,40 @@ func (mockFirmataBoard) ServoConfig(int, int, int) error { return nil }
 func (mockFirmataBoard) WriteSysex([]byte) error         { return nil }
 
 // i2c functions unused in this test scenarios
-func (mockFirmataBoard) I2cRead(int, int) error     { return nil }
-func (mockFirmataBoard) I2cWrite(int, []byte) error { return nil }
-func (mockFirmataBoard) I2cConfig(int) error        { return nil }
+func (mockFirmataBoard) I2cRead(int, int) error                        { return nil }
+func (mockFirmataBoard) I2cWrite(int, []byte) error                    { return nil }
+func (mockFirmataBoard) I2cConfig(int) error                           { return nil }
+func (mockFirmataBoard) I2cReadWithFlags(int, int, int, byte) error    { return nil }
+func (mockFirmataBoard) I2cWriteWithFlags(int, []byte, byte) error     { return nil }
+func (mockFirmataBoard) I2cReadContinuously(int, int, int, byte) error { return nil }
+func (mockFirmataBoard) I2cStopReading(int, byte) error                { return nil }
+
+// serial functions unused in this test scenarios
+func (mockFirmataBoard) SerialConfig(int, int, int, int) error { return nil }
+func (mockFirmataBoard) SerialWrite(int, []byte) error         { return nil }
+func (mockFirmataBoard) SerialStartReading(int, int) error     { return nil }
+func (mockFirmataBoard) SerialStopReading(int) error           { return nil }
+func (mockFirmataBoard) SerialClose(int) error                 { return nil }
+func (mockFirmataBoard) SerialFlush(int) error                 { return nil }
+func (mockFirmataBoard) SerialListen(int) error                { return nil }
+
+// 1-wire and stepper functions unused in this test scenarios
+func (mockFirmataBoard) OneWireConfig(int, bool) error                             { return nil }
+func (mockFirmataBoard) OneWireSearch(int) error                                   { return nil }
+func (mockFirmataBoard) OneWireSearchAlarms(int) error                             { return nil }
+func (mockFirmataBoard) OneWireCommand(int, client.OneWireRequest) error           { return nil }
+func (mockFirmataBoard) AccelStepperConfig(int, client.AccelStepperSettings) error { return nil }
+func (mockFirmataBoard) AccelStepperZero(int) error                                { return nil }
+func (mockFirmataBoard) AccelStepperStep(int, int32) error                         { return nil }
+func (mockFirmataBoard) AccelStepperTo(int, int32) error                           { return nil }
+func (mockFirmataBoard) AccelStepperEnable(int, bool) error                        { return nil }
+func (mockFirmataBoard) AccelStepperStop(int) error                                { return nil }
+func (mockFirmataBoard) AccelStepperReportPosition(int) error                      { return nil }
+func (mockFirmataBoard) AccelStepperSetAcceleration(int, float64) error            { return nil }
+func (mockFirmataBoard) AccelStepperSetSpeed(int, float64) error                   { return nil }
+func (mockFirmataBoard) MultiStepperConfig(int, []int) error                       { return nil }
+func (mockFirmataBoard) MultiStepperTo(int, []int32) error                         { return nil }
+func (mockFirmataBoard) MultiStepperStop(int) error                                { return nil }
 
 func initTestAdaptor() *Adaptor {
 	a := NewAdaptor("/dev/null")
@@ -0,0 +1,149 @@
+//go:build !windows
+// +build !windows
+
+package firmata
+
+import (
+	"fmt"
+	"strconv"
+
+	"gobot.io/x/gobot/v2/platforms/firmata/client"
+)
+
+// analogChannelNone is the analog channel of pins without analog input, reported by the analog mapping
+const analogChannelNone = 127
+
+// BoardDescription contains the firmware and the capabilities of all pins reported by the board, e.g. to be rendered
+// by the API.
+type BoardDescription struct {
+	Firmware        string           `json:"firmware"`
+	ProtocolVersion string           `json:"protocol_version"`
+	Pins            []PinDescription `json:"pins"`
+}
+
+// PinDescription contains the capabilities of a pin of the board.
+type PinDescription struct {
+	Pin           int            `json:"pin"`
+	AnalogChannel int            `json:"analog_channel"` // -1 for pins without analog input
+	Mode          string         `json:"mode"`
+	Modes         map[string]int `json:"modes"` // the supported modes with the resolution in bits
+}
+
+// BoardDescription returns the description of the board, which is created from the reported firmware, capabilities
+// and analog mapping.
+func (f *Adaptor) BoardDescription() BoardDescription {
+	desc := BoardDescription{Pins: []PinDescription{}}
+	if c, ok := f.Board.(*client.Client); ok {
+		desc.Firmware = c.FirmwareName
+		desc.ProtocolVersion = c.ProtocolVersion
+	}
+
+	for i, pin := range f.Board.Pins() {
+		pd := PinDescription{Pin: i, AnalogChannel: -1, Mode: client.ModeName(pin.Mode), Modes: make(map[string]int)}
+		if pin.SupportsMode(client.Analog) && pin.AnalogChannel != analogChannelNone {
+			pd.AnalogChannel = pin.AnalogChannel
+		}
+		for _, mode := range pin.SupportedModes {
+			pd.Modes[client.ModeName(mode)] = pin.Resolutions[mode]
+		}
+		desc.Pins = append(desc.Pins, pd)
+	}
+
+	return desc
+}
+
+// AnalogReadResolution returns the resolution in bits of the analog pin (the analog channel, e.g. "0" for A0).
+func (f *Adaptor) AnalogReadResolution(pin string) (int, error) {
+	_, bp, err := f.analogPin(pin)
+	if err != nil {
+		return 0, err
+	}
+
+	return bp.Resolutions[client.Analog], nil
+}
+
+// AnalogReadScaled retrieves the value from the analog pin (the analog channel, e.g. "0" for A0) and scales it from the
+// resolution of the pin to the given resolution in bits, e.g. a 12-bit value of 4095 is returned as 1023 for a
+// resolution of 10 bits. This makes the values independent of the ADC of the board.
+func (f *Adaptor) AnalogReadScaled(pin string, resolution int) (int, error) {
+	if resolution < 1 || resolution > 31 {
+		return 0, fmt.Errorf("Invalid resolution %d, only 1..31 bits are supported", resolution)
+	}
+
+	value, err := f.AnalogRead(pin)
+	if err != nil {
+		return 0, err
+	}
+
+	pinResolution, err := f.AnalogReadResolution(pin)
+	if err != nil {
+		return 0, err
+	}
+
+	return scaleBetweenResolutions(value, pinResolution, resolution), nil
+}
+
+// PwmResolution returns the resolution in bits of the PWM pin.
+func (f *Adaptor) PwmResolution(pin string) (int, error) {
+	_, bp, err := f.pinWithMode(pin, client.Pwm)
+	if err != nil {
+		return 0, err
+	}
+
+	return bp.Resolutions[client.Pwm], nil
+}
+
+// pinWithMode converts the pin and validates the mode against the capabilities reported by the board, the current
+// state of the pin is returned too
+func (f *Adaptor) pinWithMode(pin string, mode int) (int, client.Pin, error) {
+	p, err := strconv.Atoi(pin)
+	if err != nil {
+		return 0, client.Pin{}, err
+	}
+
+	bp, err := f.Board.Pin(p)
+	if err != nil {
+		return 0, client.Pin{}, err
+	}
+	if !bp.SupportsMode(mode) {
+		return 0, client.Pin{}, fmt.Errorf("Pin %d does not support the mode '%s'", p, client.ModeName(mode))
+	}
+
+	return p, bp, nil
+}
+
+// analogPin converts the analog channel to the pin by the analog mapping reported by the board, the current state of
+// the pin is returned too
+func (f *Adaptor) analogPin(pin string) (int, client.Pin, error) {
+	channel, err := strconv.Atoi(pin)
+	if err != nil {
+		return 0, client.Pin{}, err
+	}
+
+	for p, bp := range f.Board.Pins() {
+		if bp.AnalogChannel == channel && bp.SupportsMode(client.Analog) {
+			return p, bp, nil
+		}
+	}
+
+	return 0, client.Pin{}, fmt.Errorf("Invalid analog pin %d, not reported by the analog mapping of the board", channel)
+}
+
+// scaleToResolution scales the 8-bit value to the given resolution in bits
+func scaleToResolution(value byte, resolution int) int {
+	if resolution <= 0 || resolution == 8 {
+		return int(value)
+	}
+
+	return int(value) * (1<<resolution - 1) / 0xFF
+}
+
+// scaleBetweenResolutions scales the value from one resolution in bits to another, an unknown resolution of the pin
+// keeps the value unchanged
+func scaleBetweenResolutions(value int, from int, to int) int {
+	if from <= 0 || from == to {
+		return value
+	}
+
+	return int(int64(value) * (1<<to - 1) / (1<<from - 1))
+}
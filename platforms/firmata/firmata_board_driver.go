@@ -0,0 +1,69 @@
+//go:build !windows
+// +build !windows
+
+package firmata
+
+import (
+	"gobot.io/x/gobot/v2"
+)
+
+// BoardDriver provides the description and the pin capabilities reported by the Firmata board, e.g. to be rendered
+// by the API. No pin is used by this driver.
+type BoardDriver struct {
+	name       string
+	connection *Adaptor
+	gobot.Commander
+}
+
+// NewBoardDriver creates a new driver for the description of the Firmata board.
+//
+// Adds the following API Commands:
+//
+//	"BoardDescription" - See BoardDriver.Description
+//	"AnalogReadResolution" - See Adaptor.AnalogReadResolution, params "pin", returns "resolution" and "err"
+//	"PwmResolution" - See Adaptor.PwmResolution, params "pin", returns "resolution" and "err"
+func NewBoardDriver(a *Adaptor) *BoardDriver {
+	d := &BoardDriver{
+		name:       gobot.DefaultName("FirmataBoard"),
+		connection: a,
+		Commander:  gobot.NewCommander(),
+	}
+
+	d.AddCommand("BoardDescription", func(_ map[string]interface{}) interface{} {
+		return d.Description()
+	})
+
+	//nolint:forcetypeassert // ok here
+	d.AddCommand("AnalogReadResolution", func(params map[string]interface{}) interface{} {
+		resolution, err := d.connection.AnalogReadResolution(params["pin"].(string))
+		return map[string]interface{}{"resolution": resolution, "err": err}
+	})
+
+	//nolint:forcetypeassert // ok here
+	d.AddCommand("PwmResolution", func(params map[string]interface{}) interface{} {
+		resolution, err := d.connection.PwmResolution(params["pin"].(string))
+		return map[string]interface{}{"resolution": resolution, "err": err}
+	})
+
+	return d
+}
+
+// Name returns the name of the driver.
+func (d *BoardDriver) Name() string { return d.name }
+
+// SetName sets the name of the driver.
+func (d *BoardDriver) SetName(n string) { d.name = n }
+
+// Connection returns the connection of the driver.
+func (d *BoardDriver) Connection() gobot.Connection { return d.connection }
+
+// Start initializes the driver, nothing to do here.
+func (d *BoardDriver) Start() error { return nil }
+
+// Halt stops the driver, nothing to do here.
+func (d *BoardDriver) Halt() error { return nil }
+
+// Description returns the description of the board, see Adaptor.BoardDescription.
+func (d *BoardDriver) Description() BoardDescription {
+	return d.connection.BoardDescription()
+}
@@ -0,0 +1,65 @@
+//go:build !windows
+// +build !windows
+
+//nolint:forcetypeassert // ok here
+package firmata
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/platforms/firmata/client"
+)
+
+var _ gobot.Driver = (*BoardDriver)(nil)
+
+func TestNewBoardDriver(t *testing.T) {
+	// arrange
+	a, _ := initTestAdaptorWithAnalogWriteBoard()
+	// act
+	d := NewBoardDriver(a)
+	// assert
+	assert.True(t, strings.HasPrefix(d.Name(), "FirmataBoard"))
+	assert.Equal(t, a, d.Connection())
+	require.NoError(t, d.Start())
+	require.NoError(t, d.Halt())
+	d.SetName("mybot")
+	assert.Equal(t, "mybot", d.Name())
+}
+
+func TestBoardDriverCommands(t *testing.T) {
+	// arrange
+	a, board := initTestAdaptorWithAnalogWriteBoard()
+	board.pins = board.pins[:16]
+	d := NewBoardDriver(a)
+	// act & assert
+	desc := d.Command("BoardDescription")(map[string]interface{}{}).(BoardDescription)
+	assert.Equal(t, a.BoardDescription(), desc)
+	assert.Len(t, desc.Pins, 16)
+	got := d.Command("AnalogReadResolution")(map[string]interface{}{"pin": "1"}).(map[string]interface{})
+	assert.Equal(t, 12, got["resolution"])
+	assert.Nil(t, got["err"])
+	got = d.Command("PwmResolution")(map[string]interface{}{"pin": "3"}).(map[string]interface{})
+	assert.Equal(t, 10, got["resolution"])
+	assert.Nil(t, got["err"])
+	got = d.Command("PwmResolution")(map[string]interface{}{"pin": "2"}).(map[string]interface{})
+	require.EqualError(t, got["err"].(error), "Pin 2 does not support the mode 'pwm'")
+}
+
+func TestBoardDriverDescription(t *testing.T) {
+	// arrange
+	a, board := initTestAdaptorWithAnalogWriteBoard()
+	board.pins = board.pins[:4]
+	board.pins[3].Mode = client.Pwm
+	d := NewBoardDriver(a)
+	// act
+	desc := d.Description()
+	// assert
+	require.Len(t, desc.Pins, 4)
+	assert.Equal(t, "pwm", desc.Pins[3].Mode)
+	assert.Equal(t, 10, desc.Pins[3].Modes["pwm"])
+}
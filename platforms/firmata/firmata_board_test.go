@@ -0,0 +1,200 @@
+//go:build !windows
+// +build !windows
+
+package firmata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2/platforms/firmata/client"
+)
+
+type analogWriteMockFirmataBoard struct {
+	mockFirmataBoard
+	pin   int
+	value int
+}
+
+func (m *analogWriteMockFirmataBoard) AnalogWrite(pin int, value int) error {
+	m.pin = pin
+	m.value = value
+	return nil
+}
+
+func initTestAdaptorWithAnalogWriteBoard() (*Adaptor, *analogWriteMockFirmataBoard) {
+	a := NewAdaptor()
+	board := &analogWriteMockFirmataBoard{mockFirmataBoard: *newMockFirmataBoard()}
+	// pin 2 supports only digital modes, pin 3 has a PWM resolution of 10 bits
+	board.pins[2].SupportedModes = []int{client.Input, client.Output}
+	board.pins[3].Resolutions[client.Pwm] = 10
+	board.pins[15].Resolutions[client.Analog] = 12
+	a.Board = board
+	return a, board
+}
+
+func TestPinModeValidation(t *testing.T) {
+	tests := map[string]struct {
+		run     func(a *Adaptor) error
+		wantErr string
+	}{
+		"pwm_not_supported": {
+			run:     func(a *Adaptor) error { return a.PwmWrite("2", 100) },
+			wantErr: "Pin 2 does not support the mode 'pwm'",
+		},
+		"servo_not_supported": {
+			run:     func(a *Adaptor) error { return a.ServoWrite("2", 90) },
+			wantErr: "Pin 2 does not support the mode 'servo'",
+		},
+		"servo_config_not_supported": {
+			run:     func(a *Adaptor) error { return a.ServoConfig("2", 500, 2500) },
+			wantErr: "Pin 2 does not support the mode 'servo'",
+		},
+		"digital_pin_out_of_range": {
+			run:     func(a *Adaptor) error { return a.DigitalWrite("100", 1) },
+			wantErr: "Invalid pin 100, the board reports 100 pins",
+		},
+		"negative_pin": {
+			run: func(a *Adaptor) error {
+				_, err := a.DigitalRead("-1")
+				return err
+			},
+			wantErr: "Invalid pin -1, the board reports 100 pins",
+		},
+		"analog_channel_not_mapped": {
+			run: func(a *Adaptor) error {
+				_, err := a.AnalogRead("6")
+				return err
+			},
+			wantErr: "Invalid analog pin 6, not reported by the analog mapping of the board",
+		},
+		"pwm_supported": {
+			run: func(a *Adaptor) error { return a.PwmWrite("3", 100) },
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a, _ := initTestAdaptorWithAnalogWriteBoard()
+			// act
+			err := tc.run(a)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPwmWriteScaling(t *testing.T) {
+	tests := map[string]struct {
+		pin   string
+		level byte
+		want  int
+	}{
+		"8_bit_max":  {pin: "5", level: 255, want: 255},
+		"8_bit_half": {pin: "5", level: 128, want: 128},
+		"10_bit_max": {pin: "3", level: 255, want: 1023},
+		"10_bit_min": {pin: "3", level: 0, want: 0},
+		"10_bit_mid": {pin: "3", level: 51, want: 204},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a, board := initTestAdaptorWithAnalogWriteBoard()
+			// act
+			err := a.PwmWrite(tc.pin, tc.level)
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, board.value)
+		})
+	}
+}
+
+func TestResolutions(t *testing.T) {
+	// arrange
+	a, _ := initTestAdaptorWithAnalogWriteBoard()
+	// act
+	pwmRes, errPwm := a.PwmResolution("3")
+	analogRes, errAnalog := a.AnalogReadResolution("1")
+	_, errNoPwm := a.PwmResolution("2")
+	// assert
+	require.NoError(t, errPwm)
+	assert.Equal(t, 10, pwmRes)
+	require.NoError(t, errAnalog)
+	assert.Equal(t, 12, analogRes)
+	require.EqualError(t, errNoPwm, "Pin 2 does not support the mode 'pwm'")
+}
+
+func TestAnalogReadScaled(t *testing.T) {
+	tests := map[string]struct {
+		value      int
+		resolution int
+		want       int
+		wantErr    string
+	}{
+		"12_bit_max_to_10_bit": {value: 4095, resolution: 10, want: 1023},
+		"12_bit_mid_to_10_bit": {value: 2048, resolution: 10, want: 511},
+		"12_bit_min_to_10_bit": {value: 0, resolution: 10, want: 0},
+		"12_bit_unchanged":     {value: 1234, resolution: 12, want: 1234},
+		"12_bit_max_to_16_bit": {value: 4095, resolution: 16, want: 65535},
+		"invalid_resolution": {
+			value:      4095,
+			resolution: 0,
+			wantErr:    "Invalid resolution 0, only 1..31 bits are supported",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a, board := initTestAdaptorWithAnalogWriteBoard()
+			board.pins[15].Mode = client.Analog
+			board.pins[15].Value = tc.value
+			// act
+			got, err := a.AnalogReadScaled("1", tc.resolution)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestBoardDescription(t *testing.T) {
+	// arrange
+	a, board := initTestAdaptorWithAnalogWriteBoard()
+	board.pins = board.pins[:16]
+	board.pins[15].Mode = client.Analog
+	// act
+	desc := a.BoardDescription()
+	// assert
+	require.Len(t, desc.Pins, 16)
+	assert.Equal(t, PinDescription{
+		Pin:           2,
+		AnalogChannel: -1,
+		Mode:          "input",
+		Modes:         map[string]int{"input": 1, "output": 1},
+	}, desc.Pins[2])
+	assert.Equal(t, PinDescription{
+		Pin:           15,
+		AnalogChannel: 1,
+		Mode:          "analog",
+		Modes:         map[string]int{"input": 1, "output": 1, "pwm": 8, "servo": 14, "analog": 12},
+	}, desc.Pins[15])
+	assert.Empty(t, desc.Firmware)
+	// act & assert: the firmware is taken from the client
+	c := client.New()
+	c.FirmwareName = "StandardFirmata.ino"
+	c.ProtocolVersion = "2.5"
+	a.Board = c
+	desc = a.BoardDescription()
+	assert.Equal(t, BoardDescription{Firmware: "StandardFirmata.ino", ProtocolVersion: "2.5", Pins: []PinDescription{}},
+		desc)
+}
@@ -0,0 +1,81 @@
+//go:build !windows
+// +build !windows
+
+package firmata
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2/platforms/firmata/emulator"
+)
+
+func initTestAdaptorWithEmulator(t *testing.T) (*Adaptor, *emulator.Emulator) {
+	t.Helper()
+	dev := emulator.NewI2cRegisterDevice([]byte{0x10, 0x20, 0x30, 0x40})
+	em := emulator.NewEmulator(emulator.WithI2cDevice(0x42, dev))
+	host, board := net.Pipe()
+	go func() { _ = em.Serve(board) }()
+	a := NewAdaptor(host)
+	require.NoError(t, a.Connect())
+	t.Cleanup(func() { _ = a.Finalize() })
+	return a, em
+}
+
+func TestAdaptorWithEmulator(t *testing.T) {
+	// arrange
+	a, em := initTestAdaptorWithEmulator(t)
+	// act & assert: board description
+	desc := a.BoardDescription()
+	assert.Equal(t, "EmulatedFirmata", desc.Firmware)
+	assert.Len(t, desc.Pins, 20)
+	// act & assert: digital write
+	require.NoError(t, a.DigitalWrite("13", 1))
+	assert.Eventually(t, func() bool { return em.PinValue(13) == 1 }, time.Second, time.Millisecond)
+	// act & assert: PWM write
+	require.NoError(t, a.PwmWrite("3", 128))
+	assert.Eventually(t, func() bool { return em.PinValue(3) == 128 }, time.Second, time.Millisecond)
+	// act & assert: digital read
+	_, err := a.DigitalRead("7")
+	require.NoError(t, err)
+	require.NoError(t, em.SetDigitalInput(7, 1))
+	assert.Eventually(t, func() bool {
+		val, err := a.DigitalRead("7")
+		return err == nil && val == 1
+	}, time.Second, time.Millisecond)
+	// act & assert: analog read
+	require.NoError(t, em.SetAnalogInput(0, 512))
+	assert.Eventually(t, func() bool {
+		val, err := a.AnalogRead("0")
+		return err == nil && val == 512
+	}, time.Second, time.Millisecond)
+}
+
+func TestAdaptorWithEmulatorI2c(t *testing.T) {
+	// arrange
+	a, _ := initTestAdaptorWithEmulator(t)
+	con, err := a.GetI2cConnection(0x42, 0)
+	require.NoError(t, err)
+	// act & assert: register read and write
+	val, err := con.ReadByteData(0x01)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(0x20), val)
+	require.NoError(t, con.WriteByteData(0x02, 0x55))
+	val, err = con.ReadByteData(0x02)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(0x55), val)
+	// act & assert: continuous reading
+	got := make(chan []byte, 10)
+	require.NoError(t, a.I2cReadContinuously(0x42, 0x00, 2, func(data []byte) { got <- data }))
+	select {
+	case data := <-got:
+		assert.Equal(t, []byte{0x10, 0x20}, data)
+	case <-time.After(time.Second):
+		t.Fatal("continuous I2C reading timed out")
+	}
+	require.NoError(t, a.I2cStopReading(0x42))
+}
@@ -6,21 +6,57 @@ package firmata
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"gobot.io/x/gobot/v2/platforms/firmata/client"
 )
 
+const (
+	// i2cReplyTimeout is the maximum time to wait for the reply of a read request
+	i2cReplyTimeout = time.Second
+	// i2cRegisterNotSpecified is the register of replies to read requests without register
+	i2cRegisterNotSpecified = 0xFF
+)
+
+type i2cSubscriptionKey struct {
+	address  int
+	register int
+}
+
+// i2cPendingRead is a one-shot read request, which waits for its reply
+type i2cPendingRead struct {
+	numBytes int
+	reply    chan []byte
+}
+
+// i2cAutoRestartOption is the type to enable the repeated start condition for register reads
+type i2cAutoRestartOption bool
+
+// WithI2cAutoRestart can be given to NewAdaptor to read registers of I2C devices with a repeated start condition
+// instead of a stop condition between writing the register and reading the data. This is needed by some devices,
+// but not supported by all firmware versions, so it is disabled by default.
+func WithI2cAutoRestart() i2cAutoRestartOption {
+	return i2cAutoRestartOption(true)
+}
+
 // firmataI2cConnection implements the interface gobot.I2cOperations
 type firmataI2cConnection struct {
-	address int
-	adaptor *Adaptor
-	mtx     sync.Mutex
+	address     int
+	flags       byte
+	autoRestart bool
+	adaptor     *Adaptor
+	mtx         sync.Mutex
 }
 
 // NewFirmataI2cConnection creates an I2C connection to an I2C device at
-// the specified address
+// the specified address. Addresses above 0x7F are used in 10-bit address mode.
 func NewFirmataI2cConnection(adaptor *Adaptor, address int) *firmataI2cConnection {
-	return &firmataI2cConnection{adaptor: adaptor, address: address}
+	return &firmataI2cConnection{
+		adaptor:     adaptor,
+		address:     address,
+		flags:       i2cAddressFlags(address),
+		autoRestart: adaptor.i2cAutoRestart,
+	}
 }
 
 // Read tries to read a full buffer from the i2c device.
@@ -58,40 +94,28 @@ func (c *firmataI2cConnection) ReadByte() (byte, error) {
 }
 
 // ReadByteData reads one byte of the given register address from the i2c device.
-// TODO: implement the specification, because some devices will not work with this
 //
-//	current:  "S Addr Wr [A] Comm [A] P S Addr Rd [A] [Data] NA P"
-//	required: "S Addr Wr [A] Comm [A] S Addr Rd [A] [Data] NA P"
+//	"S Addr Wr [A] Comm [A] S Addr Rd [A] [Data] NA P"
 func (c *firmataI2cConnection) ReadByteData(reg uint8) (uint8, error) {
 	c.mtx.Lock()
 	defer c.mtx.Unlock()
 
-	if err := c.writeAndCheckCount([]byte{reg}); err != nil {
-		return 0, err
-	}
-
 	buf := []byte{0}
-	if err := c.readAndCheckCount(buf); err != nil {
+	if err := c.readRegisterAndCheckCount(reg, buf); err != nil {
 		return 0, err
 	}
 	return buf[0], nil
 }
 
 // ReadWordData reads two bytes of the given register address from the i2c device.
-// TODO: implement the specification, because some devices will not work with this
 //
-//	current:  "S Addr Wr [A] Comm [A] P S Addr Rd [A] [DataLow] A [DataHigh] NA P"
-//	required: "S Addr Wr [A] Comm [A] S Addr Rd [A] [DataLow] A [DataHigh] NA P"
+//	"S Addr Wr [A] Comm [A] S Addr Rd [A] [DataLow] A [DataHigh] NA P"
 func (c *firmataI2cConnection) ReadWordData(reg uint8) (uint16, error) {
 	c.mtx.Lock()
 	defer c.mtx.Unlock()
 
-	if err := c.writeAndCheckCount([]byte{reg}); err != nil {
-		return uint16(0), err
-	}
-
 	buf := []byte{0, 0}
-	if err := c.readAndCheckCount(buf); err != nil {
+	if err := c.readRegisterAndCheckCount(reg, buf); err != nil {
 		return uint16(0), err
 	}
 	low, high := buf[0], buf[1]
@@ -99,22 +123,16 @@ func (c *firmataI2cConnection) ReadWordData(reg uint8) (uint16, error) {
 }
 
 // ReadBlockData reads a block of maximum 32 bytes from the given register address of the i2c device.
-// TODO: implement the specification, because some devices will not work with this
 //
-//	current:  "S Addr Wr [A] Comm [A] P S Addr Rd [A] [Count] A [Data] A [Data] A ... A [Data] NA P"
-//	required: "S Addr Wr [A] Comm [A] S Addr Rd [A] [Count] A [Data] A [Data] A ... A [Data] NA P"
+//	"S Addr Wr [A] Comm [A] S Addr Rd [A] [Data] A [Data] A ... A [Data] NA P"
 func (c *firmataI2cConnection) ReadBlockData(reg uint8, data []byte) error {
 	c.mtx.Lock()
 	defer c.mtx.Unlock()
 
-	if err := c.writeAndCheckCount([]byte{reg}); err != nil {
-		return err
-	}
-
 	if len(data) > 32 {
 		data = data[:32]
 	}
-	return c.readAndCheckCount(data)
+	return c.readRegisterAndCheckCount(reg, data)
 }
 
 // WriteByte writes one byte to the i2c device.
@@ -185,6 +203,24 @@ func (c *firmataI2cConnection) readAndCheckCount(buf []byte) error {
 	return nil
 }
 
+// readRegisterAndCheckCount reads from the register, if configured with a repeated start condition instead of a stop
+// condition between writing the register and reading the data
+func (c *firmataI2cConnection) readRegisterAndCheckCount(reg uint8, buf []byte) error {
+	flags := c.flags
+	if c.autoRestart {
+		flags |= client.I2CAutoRestart
+	}
+	countRead, err := c.readRegister(int(reg), flags, buf)
+	if err != nil {
+		return err
+	}
+	expectedCount := len(buf)
+	if countRead != expectedCount {
+		return fmt.Errorf("Firmata i2c read %d bytes, expected %d bytes", countRead, expectedCount)
+	}
+	return nil
+}
+
 func (c *firmataI2cConnection) writeAndCheckCount(buf []byte) error {
 	countWritten, err := c.writeInternal(buf)
 	if err != nil {
@@ -198,19 +234,14 @@ func (c *firmataI2cConnection) writeAndCheckCount(buf []byte) error {
 }
 
 func (c *firmataI2cConnection) readInternal(b []byte) (int, error) {
-	ret := make(chan []byte)
-
-	if err := c.adaptor.Board.I2cRead(c.address, len(b)); err != nil {
-		return 0, err
-	}
+	return c.readRegister(-1, c.flags, b)
+}
 
-	if err := c.adaptor.Board.Once(c.adaptor.Board.Event("I2cReply"), func(data interface{}) {
-		ret <- data.(client.I2cReply).Data //nolint:forcetypeassert // ok here
-	}); err != nil {
+func (c *firmataI2cConnection) readRegister(register int, flags byte, b []byte) (int, error) {
+	result, err := c.adaptor.i2cRead(c.address, register, len(b), flags)
+	if err != nil {
 		return 0, err
 	}
-
-	result := <-ret
 	copy(b, result)
 
 	return len(result), nil
@@ -221,16 +252,161 @@ func (c *firmataI2cConnection) writeInternal(data []byte) (int, error) {
 	var written int
 	for len(data) >= 16 {
 		chunk, data = data[:16], data[16:]
-		if err := c.adaptor.Board.I2cWrite(c.address, chunk); err != nil {
+		if err := c.adaptor.Board.I2cWriteWithFlags(c.address, chunk, c.flags); err != nil {
 			return written, err
 		}
 		written += len(chunk)
 	}
 	if len(data) > 0 {
-		if err := c.adaptor.Board.I2cWrite(c.address, data); err != nil {
+		if err := c.adaptor.Board.I2cWriteWithFlags(c.address, data, c.flags); err != nil {
 			return written, err
 		}
 		written += len(data)
 	}
 	return written, nil
 }
+
+// I2cReadContinuously starts the continuous reading of numBytes from the register of the I2C device at the given
+// address, a negative register is used for devices without registers. The handler is called for each reading, until
+// I2cStopReading is called. This is much faster than single reads, e.g. for sampling an IMU.
+func (f *Adaptor) I2cReadContinuously(address int, register int, numBytes int, handler func(data []byte)) error {
+	if register > 0xFF {
+		return fmt.Errorf("Invalid register 0x%X for continuous reading, only 0x00..0xFF are supported", register)
+	}
+	if err := f.subscribeI2cReplies(); err != nil {
+		return err
+	}
+
+	key := newI2cSubscriptionKey(address, register)
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if _, ok := f.i2cSubscriptions[key]; ok {
+		return fmt.Errorf("Continuous reading of register 0x%X from I2C address 0x%X is already running",
+			key.register, address)
+	}
+
+	if err := f.Board.I2cReadContinuously(address, register, numBytes, i2cAddressFlags(address)); err != nil {
+		return err
+	}
+	f.i2cSubscriptions[key] = handler
+
+	return nil
+}
+
+// I2cStopReading stops all continuous readings of the I2C device at the given address.
+func (f *Adaptor) I2cStopReading(address int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.stopI2cReadings(func(key i2cSubscriptionKey) bool { return key.address == address })
+}
+
+// stopI2cReadings stops the matching continuous readings, the firmware stops one reading of the address per request
+func (f *Adaptor) stopI2cReadings(match func(key i2cSubscriptionKey) bool) error {
+	for key := range f.i2cSubscriptions {
+		if !match(key) {
+			continue
+		}
+		if err := f.Board.I2cStopReading(key.address, i2cAddressFlags(key.address)); err != nil {
+			return err
+		}
+		delete(f.i2cSubscriptions, key)
+	}
+
+	return nil
+}
+
+// i2cRead requests the data and waits for the reply
+func (f *Adaptor) i2cRead(address int, register int, numBytes int, flags byte) ([]byte, error) {
+	if err := f.subscribeI2cReplies(); err != nil {
+		return nil, err
+	}
+
+	key := newI2cSubscriptionKey(address, register)
+	read := i2cPendingRead{numBytes: numBytes, reply: make(chan []byte, 1)}
+	f.mutex.Lock()
+	if _, ok := f.i2cReads[key]; ok {
+		f.mutex.Unlock()
+		return nil, fmt.Errorf("Firmata i2c read of register 0x%X from address 0x%X is already pending",
+			key.register, address)
+	}
+	f.i2cReads[key] = read
+	f.mutex.Unlock()
+
+	defer func() {
+		f.mutex.Lock()
+		delete(f.i2cReads, key)
+		f.mutex.Unlock()
+	}()
+
+	if err := f.Board.I2cReadWithFlags(address, register, numBytes, flags); err != nil {
+		return nil, err
+	}
+
+	select {
+	case data := <-read.reply:
+		return data, nil
+	case <-time.After(i2cReplyTimeout):
+		return nil, fmt.Errorf("Firmata i2c read of %d bytes from address 0x%X timed out", numBytes, address)
+	}
+}
+
+// subscribeI2cReplies registers the handler for the I2C replies of the board once
+func (f *Adaptor) subscribeI2cReplies() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.i2cSubscribed {
+		return nil
+	}
+	if err := f.Board.On("I2cReply", f.receiveI2c); err != nil {
+		return err
+	}
+	f.i2cSubscribed = true
+
+	return nil
+}
+
+// receiveI2c forwards the reply to the waiting read request of the same address, register and size, otherwise to the
+// handler of the continuous reading. The firmware does not mark the replies of continuous readings, so a reading of
+// the same register and size is taken as reply of the one-shot request.
+func (f *Adaptor) receiveI2c(data interface{}) {
+	reply, ok := data.(client.I2cReply)
+	if !ok {
+		return
+	}
+
+	key := i2cSubscriptionKey{address: reply.Address, register: reply.Register}
+	f.mutex.Lock()
+	read, isRead := f.i2cReads[key]
+	isRead = isRead && read.numBytes == len(reply.Data)
+	if isRead {
+		delete(f.i2cReads, key)
+	}
+	handler, isSubscribed := f.i2cSubscriptions[key]
+	f.mutex.Unlock()
+
+	switch {
+	case isRead:
+		read.reply <- reply.Data
+	case isSubscribed:
+		handler(reply.Data)
+	}
+}
+
+// newI2cSubscriptionKey creates the key to match the replies, a negative register is used for reads without register
+func newI2cSubscriptionKey(address int, register int) i2cSubscriptionKey {
+	if register < 0 {
+		register = i2cRegisterNotSpecified
+	}
+	return i2cSubscriptionKey{address: address, register: register}
+}
+
+func i2cAddressFlags(address int) byte {
+	if address > 0x7F {
+		return client.I2C10BitAddressMode
+	}
+	return 0
+}
@@ -5,6 +5,7 @@
 package firmata
 
 import (
+	"fmt"
 	"io"
 	"testing"
 	"time"
@@ -25,12 +26,24 @@ type i2cMockFirmataBoard struct {
 	i2cDataForRead []byte
 	numBytesToRead int
 	i2cWritten     []byte
+	register       int
+	flags          byte
+	noReply        bool
+	continuous     []string
 }
 
 // setup mock for i2c tests
-func (t *i2cMockFirmataBoard) I2cRead(address int, numBytes int) error {
+func (t *i2cMockFirmataBoard) I2cReadWithFlags(address int, register int, numBytes int, flags byte) error {
 	t.numBytesToRead = numBytes
-	i2cReply := client.I2cReply{Data: t.i2cDataForRead}
+	t.register = register
+	t.flags = flags
+	if t.noReply {
+		return nil
+	}
+	i2cReply := client.I2cReply{Address: address, Register: i2cRegisterNotSpecified, Data: t.i2cDataForRead}
+	if register >= 0 {
+		i2cReply.Register = register
+	}
 	go func() {
 		<-time.After(10 * time.Millisecond)
 		t.Publish(t.Event("I2cReply"), i2cReply)
@@ -38,16 +51,33 @@ func (t *i2cMockFirmataBoard) I2cRead(address int, numBytes int) error {
 	return nil
 }
 
-func (t *i2cMockFirmataBoard) I2cWrite(address int, data []byte) error {
+func (t *i2cMockFirmataBoard) I2cWriteWithFlags(address int, data []byte, flags byte) error {
 	t.i2cWritten = append(t.i2cWritten, data...)
+	t.flags = flags
+	return nil
+}
+
+func (t *i2cMockFirmataBoard) I2cReadContinuously(address int, register int, numBytes int, flags byte) error {
+	t.continuous = append(t.continuous, fmt.Sprintf("start 0x%X %d %d %d", address, register, numBytes, flags))
+	return nil
+}
+
+func (t *i2cMockFirmataBoard) I2cStopReading(address int, flags byte) error {
+	t.continuous = append(t.continuous, fmt.Sprintf("stop 0x%X %d", address, flags))
 	return nil
 }
+
 func (*i2cMockFirmataBoard) I2cConfig(int) error { return nil }
 
+// replaced by the functions with flags
+func (*i2cMockFirmataBoard) I2cRead(int, int) error     { return nil }
+func (*i2cMockFirmataBoard) I2cWrite(int, []byte) error { return nil }
+
 // GPIO, PWM and servo functions unused in this test scenarios
 func (*i2cMockFirmataBoard) Connect(io.ReadWriteCloser) error { return nil }
 func (*i2cMockFirmataBoard) Disconnect() error                { return nil }
 func (*i2cMockFirmataBoard) Pins() []client.Pin               { return nil }
+func (*i2cMockFirmataBoard) Pin(int) (client.Pin, error)      { return client.Pin{}, nil }
 func (*i2cMockFirmataBoard) AnalogWrite(int, int) error       { return nil }
 func (*i2cMockFirmataBoard) SetPinMode(int, int) error        { return nil }
 func (*i2cMockFirmataBoard) ReportAnalog(int, int) error      { return nil }
@@ -55,6 +85,33 @@ func (*i2cMockFirmataBoard) ReportDigital(int, int) error     { return nil }
 func (*i2cMockFirmataBoard) DigitalWrite(int, int) error      { return nil }
 func (*i2cMockFirmataBoard) ServoConfig(int, int, int) error  { return nil }
 
+// serial functions unused in this test scenarios
+func (*i2cMockFirmataBoard) SerialConfig(int, int, int, int) error { return nil }
+func (*i2cMockFirmataBoard) SerialWrite(int, []byte) error         { return nil }
+func (*i2cMockFirmataBoard) SerialStartReading(int, int) error     { return nil }
+func (*i2cMockFirmataBoard) SerialStopReading(int) error           { return nil }
+func (*i2cMockFirmataBoard) SerialClose(int) error                 { return nil }
+func (*i2cMockFirmataBoard) SerialFlush(int) error                 { return nil }
+func (*i2cMockFirmataBoard) SerialListen(int) error                { return nil }
+
+// 1-wire and stepper functions unused in this test scenarios
+func (*i2cMockFirmataBoard) OneWireConfig(int, bool) error                             { return nil }
+func (*i2cMockFirmataBoard) OneWireSearch(int) error                                   { return nil }
+func (*i2cMockFirmataBoard) OneWireSearchAlarms(int) error                             { return nil }
+func (*i2cMockFirmataBoard) OneWireCommand(int, client.OneWireRequest) error           { return nil }
+func (*i2cMockFirmataBoard) AccelStepperConfig(int, client.AccelStepperSettings) error { return nil }
+func (*i2cMockFirmataBoard) AccelStepperZero(int) error                                { return nil }
+func (*i2cMockFirmataBoard) AccelStepperStep(int, int32) error                         { return nil }
+func (*i2cMockFirmataBoard) AccelStepperTo(int, int32) error                           { return nil }
+func (*i2cMockFirmataBoard) AccelStepperEnable(int, bool) error                        { return nil }
+func (*i2cMockFirmataBoard) AccelStepperStop(int) error                                { return nil }
+func (*i2cMockFirmataBoard) AccelStepperReportPosition(int) error                      { return nil }
+func (*i2cMockFirmataBoard) AccelStepperSetAcceleration(int, float64) error            { return nil }
+func (*i2cMockFirmataBoard) AccelStepperSetSpeed(int, float64) error                   { return nil }
+func (*i2cMockFirmataBoard) MultiStepperConfig(int, []int) error                       { return nil }
+func (*i2cMockFirmataBoard) MultiStepperTo(int, []int32) error                         { return nil }
+func (*i2cMockFirmataBoard) MultiStepperStop(int) error                                { return nil }
+
 // WriteSysex of the client implementation not tested here
 func (*i2cMockFirmataBoard) WriteSysex([]byte) error { return nil }
 
@@ -119,8 +176,9 @@ func TestReadByteData(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, 1, brd.numBytesToRead)
 	assert.Equal(t, brd.i2cDataForRead[0], val)
-	assert.Len(t, brd.i2cWritten, 1)
-	assert.Equal(t, reg, brd.i2cWritten[0])
+	assert.Empty(t, brd.i2cWritten)
+	assert.Equal(t, int(reg), brd.register)
+	assert.Equal(t, byte(0), brd.flags)
 }
 
 func TestReadWordData(t *testing.T) {
@@ -136,8 +194,9 @@ func TestReadWordData(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, 2, brd.numBytesToRead)
 	assert.Equal(t, uint16(lsb)|uint16(msb)<<8, val)
-	assert.Len(t, brd.i2cWritten, 1)
-	assert.Equal(t, reg, brd.i2cWritten[0])
+	assert.Empty(t, brd.i2cWritten)
+	assert.Equal(t, int(reg), brd.register)
+	assert.Equal(t, byte(0), brd.flags)
 }
 
 func TestReadBlockData(t *testing.T) {
@@ -152,8 +211,9 @@ func TestReadBlockData(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, 5, brd.numBytesToRead)
 	assert.Equal(t, brd.i2cDataForRead, buf)
-	assert.Len(t, brd.i2cWritten, 1)
-	assert.Equal(t, reg, brd.i2cWritten[0])
+	assert.Empty(t, brd.i2cWritten)
+	assert.Equal(t, int(reg), brd.register)
+	assert.Equal(t, byte(0), brd.flags)
 }
 
 func TestWrite(t *testing.T) {
@@ -251,3 +311,124 @@ func TestGetI2cConnectionInvalidBus(t *testing.T) {
 	_, err := a.GetI2cConnection(0x01, 99)
 	require.ErrorContains(t, err, "Invalid bus number 99, only 0 is supported")
 }
+
+func TestI2c10BitAddress(t *testing.T) {
+	// arrange
+	a := NewAdaptor()
+	brd := newI2cMockFirmataBoard()
+	a.Board = brd
+	con, err := a.GetI2cConnection(0x2A3, 0)
+	require.NoError(t, err)
+	brd.i2cDataForRead = []byte{0x42}
+	// act
+	val, errRead := con.ReadByte()
+	readFlags := brd.flags
+	errWrite := con.WriteByteData(0x10, 0x20)
+	// assert
+	require.NoError(t, errRead)
+	assert.Equal(t, byte(0x42), val)
+	assert.Equal(t, -1, brd.register)
+	assert.Equal(t, client.I2C10BitAddressMode, readFlags)
+	require.NoError(t, errWrite)
+	assert.Equal(t, []byte{0x10, 0x20}, brd.i2cWritten)
+	assert.Equal(t, client.I2C10BitAddressMode, brd.flags)
+}
+
+func TestI2cAutoRestart(t *testing.T) {
+	// arrange
+	a := NewAdaptor(WithI2cAutoRestart())
+	brd := newI2cMockFirmataBoard()
+	a.Board = brd
+	con, err := a.GetI2cConnection(0x2A3, 0)
+	require.NoError(t, err)
+	brd.i2cDataForRead = []byte{0x42}
+	// act
+	_, errRegister := con.ReadByteData(0x01)
+	registerFlags := brd.flags
+	_, errRead := con.ReadByte()
+	readFlags := brd.flags
+	errWrite := con.WriteByteData(0x10, 0x20)
+	// assert
+	require.NoError(t, errRegister)
+	assert.Equal(t, client.I2C10BitAddressMode|client.I2CAutoRestart, registerFlags)
+	require.NoError(t, errRead)
+	assert.Equal(t, client.I2C10BitAddressMode, readFlags)
+	require.NoError(t, errWrite)
+	assert.Equal(t, client.I2C10BitAddressMode, brd.flags)
+}
+
+func TestI2cReadWithContinuousReading(t *testing.T) {
+	// arrange
+	a := NewAdaptor()
+	brd := newI2cMockFirmataBoard()
+	a.Board = brd
+	con, err := a.GetI2cConnection(0x68, 0)
+	require.NoError(t, err)
+	received := make(chan []byte, 10)
+	require.NoError(t, a.I2cReadContinuously(0x68, 0x3B, 2, func(data []byte) { received <- data }))
+	brd.i2cDataForRead = []byte{0x42}
+	// readings of the continuous reading do not satisfy the single read of another register or size
+	brd.noReply = true
+	go func() {
+		<-time.After(10 * time.Millisecond)
+		brd.Publish("I2cReply", client.I2cReply{Address: 0x68, Register: 0x3B, Data: []byte{0x12, 0x34}})
+		<-time.After(10 * time.Millisecond)
+		brd.Publish("I2cReply", client.I2cReply{Address: 0x68, Register: 0x3C, Data: []byte{0x42}})
+	}()
+	// act
+	val, errRead := con.ReadByteData(0x3C)
+	// assert
+	require.NoError(t, errRead)
+	assert.Equal(t, byte(0x42), val)
+	select {
+	case data := <-received:
+		assert.Equal(t, []byte{0x12, 0x34}, data)
+	case <-time.After(time.Second):
+		require.Fail(t, "continuous reading was not delivered")
+	}
+	// the reply of the single read is not delivered to the handler
+	assert.Empty(t, received)
+}
+
+func TestI2cReadTimeout(t *testing.T) {
+	// arrange
+	con, brd := initTestTestAdaptorWithI2cConnection()
+	brd.noReply = true
+	// act
+	_, err := con.ReadByteData(0x01)
+	// assert
+	require.EqualError(t, err, "Firmata i2c read of 1 bytes from address 0x0 timed out")
+}
+
+func TestI2cReadContinuously(t *testing.T) {
+	// arrange
+	a := NewAdaptor()
+	brd := newI2cMockFirmataBoard()
+	a.Board = brd
+	received := make(chan []byte, 1)
+	// act
+	err := a.I2cReadContinuously(0x68, 0x3B, 2, func(data []byte) { received <- data })
+	errAgain := a.I2cReadContinuously(0x68, 0x3B, 2, func([]byte) {})
+	errRegister := a.I2cReadContinuously(0x68, 0x100, 2, func([]byte) {})
+	// readings of other registers are not delivered to the handler
+	brd.Publish("I2cReply", client.I2cReply{Address: 0x68, Register: 0x3C, Data: []byte{0x01}})
+	brd.Publish("I2cReply", client.I2cReply{Address: 0x68, Register: 0x3B, Data: []byte{0x12, 0x34}})
+	// assert
+	require.NoError(t, err)
+	require.EqualError(t, errAgain, "Continuous reading of register 0x3B from I2C address 0x68 is already running")
+	require.EqualError(t, errRegister,
+		"Invalid register 0x100 for continuous reading, only 0x00..0xFF are supported")
+	select {
+	case data := <-received:
+		assert.Equal(t, []byte{0x12, 0x34}, data)
+	case <-time.After(time.Second):
+		require.Fail(t, "continuous reading was not delivered")
+	}
+	// act & assert: stop
+	require.NoError(t, a.I2cReadContinuously(0x1E, -1, 6, func([]byte) {}))
+	require.NoError(t, a.I2cStopReading(0x68))
+	assert.Equal(t, []string{"start 0x68 59 2 0", "start 0x1E -1 6 0", "stop 0x68 0"}, brd.continuous)
+	// act & assert: finalize stops the remaining readings
+	require.NoError(t, a.Finalize())
+	assert.Equal(t, "stop 0x1E 0", brd.continuous[len(brd.continuous)-1])
+}
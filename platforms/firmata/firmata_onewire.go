@@ -0,0 +1,484 @@
+//go:build !windows
+// +build !windows
+
+package firmata
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/onewire"
+	"gobot.io/x/gobot/v2/platforms/firmata/client"
+)
+
+// oneWireReplyTimeout is the maximum time to wait for the reply of a read or search request
+const oneWireReplyTimeout = time.Second
+
+// OneWireAddress is the 8 byte ROM address of a 1-wire device: the family code, 6 bytes of serial number (LSB first)
+// and the CRC.
+type OneWireAddress [8]byte
+
+// OneWireBus is the 1-wire bus on a pin of the Firmata board. The timing of the bus is done by the microcontroller,
+// which needs the ConfigurableFirmata with the OneWire feature. The bus implements the gobot.Connection interface and
+// provides the connections for the drivers of package "drivers/onewire". It needs to be added to the connections of
+// the robot after the Firmata adaptor, because the board needs to be connected before the pin can be configured.
+type OneWireBus struct {
+	name           string
+	adaptor        *Adaptor
+	pin            int
+	parasiticPower bool
+	mutex          sync.Mutex
+	searchMutex    sync.Mutex
+	connected      bool
+	correlationID  int
+	pendingReads   map[int]chan []byte
+	pendingSearch  chan [][]byte
+	connections    map[OneWireAddress]onewire.Connection
+}
+
+// GetOneWireBus returns the 1-wire bus on the given pin. The power of the pin is switched on after each write, if
+// parasitic powered devices are used. The same bus is returned for the same pin.
+func (f *Adaptor) GetOneWireBus(pin string, parasiticPower bool) (*OneWireBus, error) {
+	p, err := strconv.Atoi(pin)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if bus := f.oneWireBuses[p]; bus != nil {
+		if bus.parasiticPower != parasiticPower {
+			return nil, fmt.Errorf("1-wire bus on pin %d is already used with parasitic power %t", p, bus.parasiticPower)
+		}
+		return bus, nil
+	}
+
+	bus := &OneWireBus{
+		name:           gobot.DefaultName("FirmataOneWire"),
+		adaptor:        f,
+		pin:            p,
+		parasiticPower: parasiticPower,
+		pendingReads:   make(map[int]chan []byte),
+		connections:    make(map[OneWireAddress]onewire.Connection),
+	}
+	f.oneWireBuses[p] = bus
+
+	return bus, nil
+}
+
+// Name returns the name of the 1-wire bus.
+func (b *OneWireBus) Name() string { return b.name }
+
+// SetName sets the name of the 1-wire bus.
+func (b *OneWireBus) SetName(n string) { b.name = n }
+
+// Pin returns the pin number of the bus.
+func (b *OneWireBus) Pin() int { return b.pin }
+
+// Connect configures the pin of the bus on the board.
+func (b *OneWireBus) Connect() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.connected {
+		return nil
+	}
+
+	if err := b.adaptor.Board.OneWireConfig(b.pin, b.parasiticPower); err != nil {
+		return err
+	}
+
+	b.connected = true
+	return nil
+}
+
+// Finalize marks the bus as not connected, there is no deconfiguration on the board.
+func (b *OneWireBus) Finalize() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.connected = false
+	b.pendingReads = make(map[int]chan []byte)
+	return nil
+}
+
+// Search returns the addresses of all devices on the bus.
+func (b *OneWireBus) Search() ([]OneWireAddress, error) {
+	return b.search(false)
+}
+
+// SearchAlarms returns the addresses of all devices in alarm state on the bus.
+func (b *OneWireBus) SearchAlarms() ([]OneWireAddress, error) {
+	return b.search(true)
+}
+
+// Reset resets the bus.
+func (b *OneWireBus) Reset() error {
+	if err := b.checkConnected(); err != nil {
+		return err
+	}
+
+	return b.adaptor.Board.OneWireCommand(b.pin, client.OneWireRequest{Reset: true})
+}
+
+// Transfer resets the bus, selects the device with the given address, writes the data and reads the given count of
+// bytes afterwards. All devices are addressed, if the address is nil (skip ROM).
+func (b *OneWireBus) Transfer(address *OneWireAddress, data []byte, readCount int) ([]byte, error) {
+	if err := b.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	req := client.OneWireRequest{Reset: true, Data: data, ReadCount: readCount}
+	if address != nil {
+		req.Address = address[:]
+	} else {
+		req.Skip = true
+	}
+
+	if readCount == 0 {
+		return nil, b.adaptor.Board.OneWireCommand(b.pin, req)
+	}
+
+	b.mutex.Lock()
+	b.correlationID = (b.correlationID + 1) & 0x3FFF
+	req.CorrelationID = b.correlationID
+	reply := make(chan []byte, 1)
+	b.pendingReads[req.CorrelationID] = reply
+	b.mutex.Unlock()
+
+	defer func() {
+		b.mutex.Lock()
+		delete(b.pendingReads, req.CorrelationID)
+		b.mutex.Unlock()
+	}()
+
+	if err := b.adaptor.Board.OneWireCommand(b.pin, req); err != nil {
+		return nil, err
+	}
+
+	select {
+	case result := <-reply:
+		return result, nil
+	case <-time.After(oneWireReplyTimeout):
+		return nil, fmt.Errorf("1-wire read of %d bytes on pin %d timed out", readCount, b.pin)
+	}
+}
+
+// GetOneWireConnection returns a connection to the 1-wire device with the given family code and serial number,
+// usable by the drivers of package "drivers/onewire". The connection supports the commands of the DS18B20 driver
+// and the command "rw" to write and read raw data. The same connection is returned for the same device.
+func (b *OneWireBus) GetOneWireConnection(familyCode byte, serialNumber uint64) (onewire.Connection, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	address := newOneWireAddress(familyCode, serialNumber)
+	con := b.connections[address]
+	if con == nil {
+		con = onewire.NewConnection(newFirmataOneWireDevice(b, address))
+		b.connections[address] = con
+	}
+
+	return con, nil
+}
+
+func (b *OneWireBus) search(alarms bool) ([]OneWireAddress, error) {
+	if err := b.checkConnected(); err != nil {
+		return nil, err
+	}
+
+	b.searchMutex.Lock()
+	defer b.searchMutex.Unlock()
+
+	result := make(chan [][]byte, 1)
+	b.mutex.Lock()
+	b.pendingSearch = result
+	b.mutex.Unlock()
+
+	defer func() {
+		b.mutex.Lock()
+		b.pendingSearch = nil
+		b.mutex.Unlock()
+	}()
+
+	var err error
+	if alarms {
+		err = b.adaptor.Board.OneWireSearchAlarms(b.pin)
+	} else {
+		err = b.adaptor.Board.OneWireSearch(b.pin)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case addresses := <-result:
+		found := make([]OneWireAddress, 0, len(addresses))
+		for _, addr := range addresses {
+			var a OneWireAddress
+			copy(a[:], addr)
+			found = append(found, a)
+		}
+		return found, nil
+	case <-time.After(oneWireReplyTimeout):
+		return nil, fmt.Errorf("1-wire search on pin %d timed out", b.pin)
+	}
+}
+
+func (b *OneWireBus) checkConnected() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if !b.connected {
+		return fmt.Errorf("1-wire bus on pin %d is not connected", b.pin)
+	}
+	return nil
+}
+
+// receiveRead forwards the data of a read reply to the waiting request
+func (b *OneWireBus) receiveRead(response client.OneWireReadResponse) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if reply, ok := b.pendingReads[response.CorrelationID]; ok {
+		reply <- response.Data
+		delete(b.pendingReads, response.CorrelationID)
+	}
+}
+
+// receiveSearch forwards the addresses of a search reply to the waiting search
+func (b *OneWireBus) receiveSearch(response client.OneWireSearchResponse) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.pendingSearch != nil {
+		b.pendingSearch <- response.Addresses
+		b.pendingSearch = nil
+	}
+}
+
+// receiveOneWire dispatches the replies to the bus of the pin
+func (f *Adaptor) receiveOneWire(data interface{}) {
+	var pin int
+	switch response := data.(type) {
+	case client.OneWireReadResponse:
+		pin = response.Pin
+	case client.OneWireSearchResponse:
+		pin = response.Pin
+	default:
+		return
+	}
+
+	f.mutex.Lock()
+	bus := f.oneWireBuses[pin]
+	f.mutex.Unlock()
+
+	if bus == nil {
+		return
+	}
+
+	switch response := data.(type) {
+	case client.OneWireReadResponse:
+		bus.receiveRead(response)
+	case client.OneWireSearchResponse:
+		bus.receiveSearch(response)
+	}
+}
+
+func newOneWireAddress(familyCode byte, serialNumber uint64) OneWireAddress {
+	var a OneWireAddress
+	a[0] = familyCode
+	for i := 1; i < 7; i++ {
+		a[i] = byte(serialNumber >> (8 * (i - 1)))
+	}
+	a[7] = oneWireCRC8(a[:7])
+
+	return a
+}
+
+// FamilyCode returns the family code of the device, e.g. 0x28 for a DS18B20.
+func (a OneWireAddress) FamilyCode() byte { return a[0] }
+
+// SerialNumber returns the 48 bit serial number of the device.
+func (a OneWireAddress) SerialNumber() uint64 {
+	var sn uint64
+	for i := 6; i > 0; i-- {
+		sn = sn<<8 | uint64(a[i])
+	}
+	return sn
+}
+
+// String returns the address in the form "family code"-"serial number", e.g. "28-00000f1e64ff".
+func (a OneWireAddress) String() string {
+	return fmt.Sprintf("%02x-%012x", a.FamilyCode(), a.SerialNumber())
+}
+
+// oneWireCRC8 calculates the Dallas/Maxim CRC8 (polynomial x^8 + x^5 + x^4 + 1)
+func oneWireCRC8(data []byte) byte {
+	var crc byte
+	for _, val := range data {
+		for i := 0; i < 8; i++ {
+			mix := (crc ^ val) & 0x01
+			crc >>= 1
+			if mix != 0 {
+				crc ^= 0x8C
+			}
+			val >>= 1
+		}
+	}
+	return crc
+}
+
+// DS18B20 function commands and the command names, which are the names of the w1_therm sysfs files
+const (
+	ds18b20ConvertT        = 0x44
+	ds18b20ReadScratchpad  = 0xBE
+	ds18b20WriteScratchpad = 0x4E
+	ds18b20ReadPowerSupply = 0xB4
+
+	oneWireRawCommand         = "rw"
+	oneWireTemperatureCommand = "temperature"
+	oneWireResolutionCommand  = "resolution"
+	oneWireExtPowerCommand    = "ext_power"
+	oneWireConvTimeCommand    = "conv_time"
+)
+
+// firmataOneWireDevice implements the gobot.OneWireSystemDevicer interface for a device on the Firmata 1-wire bus.
+// The commands of the w1_therm sysfs driver are emulated, so the DS18B20 driver can be used unchanged.
+type firmataOneWireDevice struct {
+	bus            *OneWireBus
+	address        OneWireAddress
+	conversionTime int
+}
+
+func newFirmataOneWireDevice(bus *OneWireBus, address OneWireAddress) *firmataOneWireDevice {
+	return &firmataOneWireDevice{bus: bus, address: address, conversionTime: 750}
+}
+
+// ID returns the device id in the form "family code"-"serial number". Implements gobot.OneWireSystemDevicer.
+func (d *firmataOneWireDevice) ID() string {
+	return d.address.String()
+}
+
+// ReadData reads raw data from the device, only the command "rw" is supported. Implements
+// gobot.OneWireSystemDevicer.
+func (d *firmataOneWireDevice) ReadData(command string, data []byte) error {
+	if command != oneWireRawCommand {
+		return d.unsupported(command)
+	}
+
+	buf, err := d.bus.Transfer(&d.address, nil, len(data))
+	if err != nil {
+		return err
+	}
+	copy(data, buf)
+
+	if len(buf) < len(data) {
+		return fmt.Errorf("count of read bytes (%d) is smaller than expected (%d)", len(buf), len(data))
+	}
+
+	return nil
+}
+
+// WriteData writes raw data to the device, only the command "rw" is supported. Implements
+// gobot.OneWireSystemDevicer.
+func (d *firmataOneWireDevice) WriteData(command string, data []byte) error {
+	if command != oneWireRawCommand {
+		return d.unsupported(command)
+	}
+
+	_, err := d.bus.Transfer(&d.address, data, 0)
+	return err
+}
+
+// ReadInteger reads an integer value from the device. Implements gobot.OneWireSystemDevicer.
+func (d *firmataOneWireDevice) ReadInteger(command string) (int, error) {
+	switch command {
+	case oneWireTemperatureCommand:
+		if _, err := d.bus.Transfer(&d.address, []byte{ds18b20ConvertT}, 0); err != nil {
+			return 0, err
+		}
+		time.Sleep(time.Duration(d.conversionTime) * time.Millisecond)
+		sp, err := d.readScratchpad()
+		if err != nil {
+			return 0, err
+		}
+		// the resolution is 1/16 °C, the result is in m°C like the w1_therm driver
+		return int(int16(uint16(sp[1])<<8|uint16(sp[0]))) * 1000 / 16, nil
+	case oneWireResolutionCommand:
+		sp, err := d.readScratchpad()
+		if err != nil {
+			return 0, err
+		}
+		return 9 + int((sp[4]>>5)&0x03), nil
+	case oneWireExtPowerCommand:
+		buf, err := d.bus.Transfer(&d.address, []byte{ds18b20ReadPowerSupply}, 1)
+		if err != nil {
+			return 0, err
+		}
+		// a parasitic powered device pulls the bus low
+		if len(buf) > 0 && buf[0] != 0 {
+			return 1, nil
+		}
+		return 0, nil
+	case oneWireConvTimeCommand:
+		return d.conversionTime, nil
+	default:
+		return 0, d.unsupported(command)
+	}
+}
+
+// WriteInteger writes an integer value to the device. Implements gobot.OneWireSystemDevicer.
+func (d *firmataOneWireDevice) WriteInteger(command string, val int) error {
+	switch command {
+	case oneWireResolutionCommand:
+		if val < 9 || val > 12 {
+			return fmt.Errorf("the resolution '%d' is out of range (9, 10, 11, 12)", val)
+		}
+		sp, err := d.readScratchpad()
+		if err != nil {
+			return err
+		}
+		cfg := byte((val-9)<<5) | 0x1F
+		if _, err := d.bus.Transfer(&d.address, []byte{ds18b20WriteScratchpad, sp[2], sp[3], cfg}, 0); err != nil {
+			return err
+		}
+		// the conversion time is halved with each bit less
+		d.conversionTime = 750 >> (12 - val)
+		return nil
+	case oneWireConvTimeCommand:
+		if val <= 0 {
+			return fmt.Errorf("the conversion time '%d' needs to be positive", val)
+		}
+		d.conversionTime = val
+		return nil
+	default:
+		return d.unsupported(command)
+	}
+}
+
+// Close the 1-wire connection. Implements gobot.OneWireSystemDevicer.
+func (d *firmataOneWireDevice) Close() error {
+	// nothing to do here, the bus stays open for other devices
+	return nil
+}
+
+func (d *firmataOneWireDevice) readScratchpad() ([]byte, error) {
+	sp, err := d.bus.Transfer(&d.address, []byte{ds18b20ReadScratchpad}, 9)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sp) < 9 || oneWireCRC8(sp[:8]) != sp[8] {
+		return nil, fmt.Errorf("CRC error in scratchpad of 1-wire device %s", d.ID())
+	}
+
+	return sp, nil
+}
+
+func (d *firmataOneWireDevice) unsupported(command string) error {
+	return fmt.Errorf("command '%s' is not supported by the Firmata 1-wire device %s", command, d.ID())
+}
@@ -0,0 +1,241 @@
+//go:build !windows
+// +build !windows
+
+package firmata
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/onewire"
+	"gobot.io/x/gobot/v2/platforms/firmata/client"
+)
+
+// make sure that this bus fulfills all required 1-wire interfaces
+var (
+	_ gobot.Connection           = (*OneWireBus)(nil)
+	_ gobot.OneWireSystemDevicer = (*firmataOneWireDevice)(nil)
+)
+
+const testOneWireSerial = uint64(0x0f1e64ff)
+
+// oneWireMockFirmataBoard emulates a DS18B20 on the 1-wire bus
+type oneWireMockFirmataBoard struct {
+	mockFirmataBoard
+	mutex       sync.Mutex
+	configured  map[int]bool
+	requests    []client.OneWireRequest
+	scratchpad  []byte
+	extPower    byte
+	commandErr  error
+	noReply     bool
+	badCRC      bool
+	searchReply [][]byte
+}
+
+func newOneWireMockFirmataBoard() *oneWireMockFirmataBoard {
+	m := &oneWireMockFirmataBoard{
+		mockFirmataBoard: *newMockFirmataBoard(),
+		configured:       make(map[int]bool),
+		// 25.0625 °C, TH, TL, 12 bit resolution
+		scratchpad: []byte{0x91, 0x01, 0x4B, 0x46, 0x7F, 0xFF, 0x0F, 0x10},
+		extPower:   0xFF,
+	}
+	return m
+}
+
+func (m *oneWireMockFirmataBoard) OneWireConfig(pin int, power bool) error {
+	m.configured[pin] = power
+	return nil
+}
+
+func (m *oneWireMockFirmataBoard) OneWireSearch(pin int) error {
+	go m.Publish("OneWireSearchReply", client.OneWireSearchResponse{Pin: pin, Addresses: m.searchReply})
+	return nil
+}
+
+func (m *oneWireMockFirmataBoard) OneWireCommand(pin int, req client.OneWireRequest) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.requests = append(m.requests, req)
+	if m.commandErr != nil {
+		return m.commandErr
+	}
+
+	if len(req.Data) == 0 {
+		return nil
+	}
+
+	var data []byte
+	switch req.Data[0] {
+	case ds18b20WriteScratchpad:
+		copy(m.scratchpad[2:5], req.Data[1:])
+	case ds18b20ReadScratchpad:
+		crc := oneWireCRC8(m.scratchpad)
+		if m.badCRC {
+			crc = ^crc
+		}
+		data = append(append([]byte{}, m.scratchpad...), crc)
+	case ds18b20ReadPowerSupply:
+		data = []byte{m.extPower}
+	case 0x01:
+		data = []byte{0xAA, 0xBB}
+	}
+
+	if req.ReadCount > 0 && !m.noReply {
+		go m.Publish("OneWireReadReply",
+			client.OneWireReadResponse{Pin: pin, CorrelationID: req.CorrelationID, Data: data[:req.ReadCount]})
+	}
+
+	return nil
+}
+
+func initTestOneWireBus(t *testing.T) (*OneWireBus, *oneWireMockFirmataBoard) {
+	a := NewAdaptor(&readWriteCloser{})
+	board := newOneWireMockFirmataBoard()
+	a.Board = board
+	require.NoError(t, a.Connect())
+	bus, err := a.GetOneWireBus("2", false)
+	require.NoError(t, err)
+	return bus, board
+}
+
+func TestGetOneWireBus(t *testing.T) {
+	// arrange
+	a := initTestAdaptor()
+	// act
+	bus, err := a.GetOneWireBus("2", true)
+	require.NoError(t, err)
+	same, errSame := a.GetOneWireBus("2", true)
+	_, errPower := a.GetOneWireBus("2", false)
+	_, errPin := a.GetOneWireBus("xyz", false)
+	// assert
+	require.NoError(t, errSame)
+	assert.Same(t, bus, same)
+	assert.Equal(t, 2, bus.Pin())
+	require.EqualError(t, errPower, "1-wire bus on pin 2 is already used with parasitic power true")
+	require.ErrorContains(t, errPin, "invalid syntax")
+}
+
+func TestOneWireBusConnectAndFinalize(t *testing.T) {
+	// arrange
+	a := NewAdaptor(&readWriteCloser{})
+	board := newOneWireMockFirmataBoard()
+	a.Board = board
+	require.NoError(t, a.Connect())
+	bus, _ := a.GetOneWireBus("3", true)
+	errNotConnected := bus.Reset()
+	// act
+	require.NoError(t, bus.Connect())
+	// assert
+	require.EqualError(t, errNotConnected, "1-wire bus on pin 3 is not connected")
+	assert.Equal(t, map[int]bool{3: true}, board.configured)
+	require.NoError(t, bus.Reset())
+	assert.Equal(t, []client.OneWireRequest{{Reset: true}}, board.requests)
+	// act & assert: finalize of the adaptor finalizes the bus
+	require.NoError(t, a.Finalize())
+	require.Error(t, bus.Reset())
+}
+
+func TestOneWireBusSearch(t *testing.T) {
+	// arrange
+	bus, board := initTestOneWireBus(t)
+	require.NoError(t, bus.Connect())
+	addr := newOneWireAddress(0x28, testOneWireSerial)
+	board.searchReply = [][]byte{addr[:]}
+	// act
+	found, err := bus.Search()
+	// assert
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, addr, found[0])
+	assert.Equal(t, byte(0x28), found[0].FamilyCode())
+	assert.Equal(t, testOneWireSerial, found[0].SerialNumber())
+	assert.Equal(t, "28-00000f1e64ff", found[0].String())
+}
+
+func TestOneWireBusTransfer(t *testing.T) {
+	// arrange
+	bus, board := initTestOneWireBus(t)
+	require.NoError(t, bus.Connect())
+	// act
+	data, err := bus.Transfer(nil, []byte{0x01}, 2)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xAA, 0xBB}, data)
+	require.Len(t, board.requests, 1)
+	assert.True(t, board.requests[0].Skip)
+	assert.Equal(t, 1, board.requests[0].CorrelationID)
+	// act & assert: errors
+	board.noReply = true
+	_, err = bus.Transfer(nil, []byte{0x01}, 2)
+	require.EqualError(t, err, "1-wire read of 2 bytes on pin 2 timed out")
+	board.commandErr = errors.New("command error")
+	_, err = bus.Transfer(nil, []byte{0x01}, 2)
+	require.EqualError(t, err, "command error")
+}
+
+func TestOneWireDS18B20(t *testing.T) {
+	// arrange
+	bus, board := initTestOneWireBus(t)
+	d := onewire.NewDS18B20Driver(bus, testOneWireSerial, onewire.WithResolution(10),
+		onewire.WithConversionTime(10))
+	require.NoError(t, bus.Connect())
+	// act
+	require.NoError(t, d.Start())
+	temp, errTemp := d.Temperature()
+	res, errRes := d.Resolution()
+	ext, errExt := d.IsExternalPowered()
+	ct, errCt := d.ConversionTime()
+	// assert
+	require.NoError(t, errTemp)
+	assert.InDelta(t, 25.062, temp, 0.0001)
+	require.NoError(t, errRes)
+	assert.Equal(t, uint8(10), res)
+	require.NoError(t, errExt)
+	assert.True(t, ext)
+	require.NoError(t, errCt)
+	assert.Equal(t, uint16(10), ct)
+	// the device is selected by the address
+	assert.Equal(t, []byte{0x28, 0xff, 0x64, 0x1e, 0x0f, 0x00, 0x00}, board.requests[0].Address[:7])
+	// act & assert: halt restores the defaults
+	require.NoError(t, d.Halt())
+	assert.Equal(t, byte(0x7F), board.scratchpad[4])
+}
+
+func TestOneWireDeviceErrors(t *testing.T) {
+	// arrange
+	bus, board := initTestOneWireBus(t)
+	require.NoError(t, bus.Connect())
+	con, err := bus.GetOneWireConnection(0x28, testOneWireSerial)
+	require.NoError(t, err)
+	// act & assert
+	_, err = con.ReadInteger("unknown")
+	require.EqualError(t, err, "command 'unknown' is not supported by the Firmata 1-wire device 28-00000f1e64ff")
+	require.EqualError(t, con.WriteInteger("resolution", 13), "the resolution '13' is out of range (9, 10, 11, 12)")
+	require.EqualError(t, con.WriteData("unknown", nil),
+		"command 'unknown' is not supported by the Firmata 1-wire device 28-00000f1e64ff")
+	board.extPower = 0x00
+	ext, err := con.ReadInteger("ext_power")
+	require.NoError(t, err)
+	assert.Equal(t, 0, ext)
+	// act & assert: CRC error
+	board.badCRC = true
+	_, err = con.ReadInteger("resolution")
+	require.EqualError(t, err, "CRC error in scratchpad of 1-wire device 28-00000f1e64ff")
+	// act & assert: the same connection is returned for the same device
+	con2, err := bus.GetOneWireConnection(0x28, testOneWireSerial)
+	require.NoError(t, err)
+	assert.Same(t, con, con2)
+}
+
+func TestOneWireCRC8(t *testing.T) {
+	// example from Maxim application note 27
+	assert.Equal(t, byte(0xA2), oneWireCRC8([]byte{0x02, 0x1C, 0xB8, 0x01, 0x00, 0x00, 0x00}))
+}
@@ -0,0 +1,206 @@
+//go:build !windows
+// +build !windows
+
+package firmata
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/platforms/firmata/client"
+)
+
+const (
+	// serialWriteChunkSize ensures the sysex message fits into the input buffer of the firmware
+	serialWriteChunkSize = 24
+	// serialBufferSize limits the received and not yet read data, older data are dropped
+	serialBufferSize = 1024
+)
+
+// SerialConnection is the connection to a serial port (UART) of the Firmata board. It implements the
+// io.ReadWriteCloser, the gobot.Connection interface and the interfaces for reading and writing, which are used by
+// the drivers of package "drivers/serial". The connection needs to be added to the connections of the robot after
+// the Firmata adaptor, because the board needs to be connected before the serial port can be configured.
+type SerialConnection struct {
+	name          string
+	adaptor       *Adaptor
+	port          int
+	baudRate      int
+	rxPin         int
+	txPin         int
+	mutex         sync.Mutex
+	dataAvailable *sync.Cond
+	connected     bool
+	buffer        []byte
+}
+
+func newSerialConnection(adaptor *Adaptor, port int, baudRate int, rxPin int, txPin int) *SerialConnection {
+	c := &SerialConnection{
+		name:     gobot.DefaultName("FirmataSerial"),
+		adaptor:  adaptor,
+		port:     port,
+		baudRate: baudRate,
+		rxPin:    rxPin,
+		txPin:    txPin,
+	}
+	c.dataAvailable = sync.NewCond(&c.mutex)
+
+	return c
+}
+
+// Name returns the name of the serial connection.
+func (c *SerialConnection) Name() string { return c.name }
+
+// SetName sets the name of the serial connection.
+func (c *SerialConnection) SetName(n string) { c.name = n }
+
+// Port returns the Firmata serial port, e.g. client.HwSerial1.
+func (c *SerialConnection) Port() int { return c.port }
+
+// BaudRate returns the baud rate of the connection.
+func (c *SerialConnection) BaudRate() int { return c.baudRate }
+
+// Connect configures the serial port on the board and starts the continuous reading.
+func (c *SerialConnection) Connect() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.connected {
+		return nil
+	}
+
+	board := c.adaptor.Board
+	if err := board.SerialConfig(c.port, c.baudRate, c.rxPin, c.txPin); err != nil {
+		return err
+	}
+	if c.port >= client.SwSerial0 {
+		if err := board.SerialListen(c.port); err != nil {
+			return err
+		}
+	}
+	if err := board.SerialStartReading(c.port, 0); err != nil {
+		return err
+	}
+
+	c.connected = true
+	return nil
+}
+
+// Finalize stops the reading and closes the serial port on the board. A blocked Read() returns with io.EOF.
+func (c *SerialConnection) Finalize() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.connected {
+		return nil
+	}
+
+	c.connected = false
+	c.buffer = nil
+	c.dataAvailable.Broadcast()
+
+	var err error
+	if e := c.adaptor.Board.SerialStopReading(c.port); e != nil {
+		err = multierror.Append(err, e)
+	}
+	if e := c.adaptor.Board.SerialClose(c.port); e != nil {
+		err = multierror.Append(err, e)
+	}
+
+	return err
+}
+
+// Close implements the io.Closer interface, see Finalize().
+func (c *SerialConnection) Close() error {
+	return c.Finalize()
+}
+
+// IsConnected returns the connection state.
+func (c *SerialConnection) IsConnected() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.connected
+}
+
+// Read reads the received data to the given buffer. It blocks until data are available or the connection is closed.
+func (c *SerialConnection) Read(b []byte) (int, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.connected {
+		return 0, fmt.Errorf("Firmata serial port %d is not connected", c.port)
+	}
+
+	for len(c.buffer) == 0 && c.connected {
+		c.dataAvailable.Wait()
+	}
+
+	if len(c.buffer) == 0 {
+		return 0, io.EOF
+	}
+
+	n := copy(b, c.buffer)
+	c.buffer = c.buffer[n:]
+
+	return n, nil
+}
+
+// Write writes the given data to the serial port. The data are split in chunks, which fit into the sysex buffer of
+// the firmware.
+func (c *SerialConnection) Write(data []byte) (int, error) {
+	if !c.IsConnected() {
+		return 0, fmt.Errorf("Firmata serial port %d is not connected", c.port)
+	}
+
+	var chunk []byte
+	var written int
+	for len(data) > 0 {
+		if len(data) > serialWriteChunkSize {
+			chunk, data = data[:serialWriteChunkSize], data[serialWriteChunkSize:]
+		} else {
+			chunk, data = data, nil
+		}
+		if err := c.adaptor.Board.SerialWrite(c.port, chunk); err != nil {
+			return written, err
+		}
+		written += len(chunk)
+	}
+
+	return written, nil
+}
+
+// Flush waits for the transmission of outgoing data of the serial port to complete.
+func (c *SerialConnection) Flush() error {
+	return c.adaptor.Board.SerialFlush(c.port)
+}
+
+// SerialRead reads from the serial port to the given buffer, see Read().
+func (c *SerialConnection) SerialRead(b []byte) (int, error) {
+	return c.Read(b)
+}
+
+// SerialWrite writes the given data to the serial port, see Write().
+func (c *SerialConnection) SerialWrite(b []byte) (int, error) {
+	return c.Write(b)
+}
+
+// receive adds the data of a reply to the buffer and wakes up a waiting reader
+func (c *SerialConnection) receive(data []byte) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.connected {
+		return
+	}
+
+	c.buffer = append(c.buffer, data...)
+	if len(c.buffer) > serialBufferSize {
+		c.buffer = c.buffer[len(c.buffer)-serialBufferSize:]
+	}
+	c.dataAvailable.Broadcast()
+}
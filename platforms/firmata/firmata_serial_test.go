@@ -0,0 +1,283 @@
+//go:build !windows
+// +build !windows
+
+package firmata
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/serial"
+	"gobot.io/x/gobot/v2/platforms/firmata/client"
+)
+
+// make sure that this connection fulfills all required serial interfaces
+var (
+	_ io.ReadWriteCloser  = (*SerialConnection)(nil)
+	_ gobot.Connection    = (*SerialConnection)(nil)
+	_ serial.SerialReader = (*SerialConnection)(nil)
+	_ serial.SerialWriter = (*SerialConnection)(nil)
+)
+
+type serialMockFirmataBoard struct {
+	mockFirmataBoard
+	mutex        sync.Mutex
+	calls        []string
+	written      [][]byte
+	serialWriteE error
+}
+
+func newSerialMockFirmataBoard() *serialMockFirmataBoard {
+	return &serialMockFirmataBoard{mockFirmataBoard: *newMockFirmataBoard()}
+}
+
+func (m *serialMockFirmataBoard) record(call string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.calls = append(m.calls, call)
+}
+
+func (m *serialMockFirmataBoard) SerialConfig(port int, baudRate int, rxPin int, txPin int) error {
+	m.record(fmt.Sprintf("config %d %d %d %d", port, baudRate, rxPin, txPin))
+	return nil
+}
+
+func (m *serialMockFirmataBoard) SerialWrite(port int, data []byte) error {
+	m.record(fmt.Sprintf("write %d", port))
+	m.written = append(m.written, data)
+	return m.serialWriteE
+}
+
+func (m *serialMockFirmataBoard) SerialStartReading(port int, maxBytes int) error {
+	m.record(fmt.Sprintf("start %d %d", port, maxBytes))
+	return nil
+}
+
+func (m *serialMockFirmataBoard) SerialStopReading(port int) error {
+	m.record(fmt.Sprintf("stop %d", port))
+	return nil
+}
+
+func (m *serialMockFirmataBoard) SerialClose(port int) error {
+	m.record(fmt.Sprintf("close %d", port))
+	return nil
+}
+
+func (m *serialMockFirmataBoard) SerialFlush(port int) error {
+	m.record(fmt.Sprintf("flush %d", port))
+	return nil
+}
+
+func (m *serialMockFirmataBoard) SerialListen(port int) error {
+	m.record(fmt.Sprintf("listen %d", port))
+	return nil
+}
+
+func initTestAdaptorWithSerialBoard() (*Adaptor, *serialMockFirmataBoard) {
+	a := NewAdaptor(&readWriteCloser{})
+	board := newSerialMockFirmataBoard()
+	a.Board = board
+	_ = a.Connect()
+	return a, board
+}
+
+func TestGetSerialConnection(t *testing.T) {
+	tests := map[string]struct {
+		port    int
+		baud    int
+		rxPin   string
+		txPin   string
+		soft    bool
+		wantErr string
+	}{
+		"hardware_serial": {
+			port: client.HwSerial1,
+			baud: 9600,
+		},
+		"software_serial": {
+			port:  client.SwSerial0,
+			baud:  4800,
+			rxPin: "10",
+			txPin: "11",
+			soft:  true,
+		},
+		"error_hardware_serial_with_software_port": {
+			port:    client.SwSerial0,
+			baud:    9600,
+			wantErr: "Invalid hardware serial port 8, only 0..3 are supported",
+		},
+		"error_software_serial_with_hardware_port": {
+			port:    client.HwSerial2,
+			baud:    9600,
+			rxPin:   "10",
+			txPin:   "11",
+			soft:    true,
+			wantErr: "Invalid software serial port 2, only 8..11 are supported",
+		},
+		"error_software_serial_bad_pin": {
+			port:    client.SwSerial1,
+			baud:    9600,
+			rxPin:   "xyz",
+			txPin:   "11",
+			soft:    true,
+			wantErr: "invalid syntax",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a, _ := initTestAdaptorWithSerialBoard()
+			// act
+			var con *SerialConnection
+			var err error
+			if tc.soft {
+				con, err = a.GetSoftwareSerialConnection(tc.port, tc.baud, tc.rxPin, tc.txPin)
+			} else {
+				con, err = a.GetSerialConnection(tc.port, tc.baud)
+			}
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				assert.Nil(t, con)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.port, con.Port())
+			assert.Equal(t, tc.baud, con.BaudRate())
+			assert.False(t, con.IsConnected())
+		})
+	}
+}
+
+func TestGetSerialConnectionReused(t *testing.T) {
+	// arrange
+	a, _ := initTestAdaptorWithSerialBoard()
+	con1, err := a.GetSerialConnection(client.HwSerial1, 9600)
+	require.NoError(t, err)
+	// act
+	con2, err := a.GetSerialConnection(client.HwSerial1, 9600)
+	require.NoError(t, err)
+	_, errBaud := a.GetSerialConnection(client.HwSerial1, 115200)
+	// assert
+	assert.Same(t, con1, con2)
+	require.EqualError(t, errBaud, "Serial port 1 is already used with baud rate 9600")
+}
+
+func TestSerialConnectionConnectAndFinalize(t *testing.T) {
+	// arrange
+	a, board := initTestAdaptorWithSerialBoard()
+	hw, _ := a.GetSerialConnection(client.HwSerial2, 57600)
+	sw, _ := a.GetSoftwareSerialConnection(client.SwSerial1, 9600, "10", "11")
+	// act
+	require.NoError(t, hw.Connect())
+	require.NoError(t, sw.Connect())
+	require.NoError(t, hw.Connect())
+	// assert
+	assert.True(t, hw.IsConnected())
+	assert.True(t, sw.IsConnected())
+	assert.Equal(t, []string{
+		"config 2 57600 0 0", "start 2 0",
+		"config 9 9600 10 11", "listen 9", "start 9 0",
+	}, board.calls)
+	// act & assert: finalize of the adaptor closes all ports
+	board.calls = nil
+	require.NoError(t, hw.Finalize())
+	require.NoError(t, a.Finalize())
+	assert.False(t, hw.IsConnected())
+	assert.False(t, sw.IsConnected())
+	assert.Equal(t, []string{"stop 2", "close 2", "stop 9", "close 9"}, board.calls)
+}
+
+func TestSerialConnectionWrite(t *testing.T) {
+	// arrange
+	a, board := initTestAdaptorWithSerialBoard()
+	con, _ := a.GetSerialConnection(client.HwSerial1, 9600)
+	data := make([]byte, serialWriteChunkSize+5)
+	_, errNotConnected := con.SerialWrite(data)
+	require.NoError(t, con.Connect())
+	// act
+	n, err := con.SerialWrite(data)
+	// assert
+	require.EqualError(t, errNotConnected, "Firmata serial port 1 is not connected")
+	require.NoError(t, err)
+	assert.Equal(t, len(data), n)
+	require.Len(t, board.written, 2)
+	assert.Len(t, board.written[0], serialWriteChunkSize)
+	assert.Len(t, board.written[1], 5)
+	// act & assert: error while writing
+	board.serialWriteE = errors.New("write error")
+	n, err = con.Write([]byte{1, 2})
+	require.EqualError(t, err, "write error")
+	assert.Equal(t, 0, n)
+	// act & assert: flush
+	require.NoError(t, con.Flush())
+	assert.Equal(t, "flush 1", board.calls[len(board.calls)-1])
+}
+
+func TestSerialConnectionRead(t *testing.T) {
+	// arrange
+	a, board := initTestAdaptorWithSerialBoard()
+	con, _ := a.GetSerialConnection(client.HwSerial3, 9600)
+	other, _ := a.GetSerialConnection(client.HwSerial0, 9600)
+	_, errNotConnected := con.Read(make([]byte, 1))
+	require.NoError(t, con.Connect())
+	require.NoError(t, other.Connect())
+	// act
+	board.Publish("SerialReply", client.SerialPortReply{Port: client.HwSerial3, Data: []byte("$GP")})
+	buf := make([]byte, 2)
+	n1, err1 := con.SerialRead(buf)
+	first := string(buf[:n1])
+	n2, err2 := con.SerialRead(buf)
+	// assert
+	require.EqualError(t, errNotConnected, "Firmata serial port 3 is not connected")
+	require.NoError(t, err1)
+	require.NoError(t, err2)
+	assert.Equal(t, "$G", first)
+	assert.Equal(t, "P", string(buf[:n2]))
+	assert.Empty(t, other.buffer)
+}
+
+func TestSerialConnectionReadUnblockedByClose(t *testing.T) {
+	// arrange
+	a, _ := initTestAdaptorWithSerialBoard()
+	con, _ := a.GetSerialConnection(client.HwSerial1, 9600)
+	require.NoError(t, con.Connect())
+	result := make(chan error, 1)
+	go func() {
+		_, err := con.Read(make([]byte, 1))
+		result <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	// act
+	require.NoError(t, con.Close())
+	// assert
+	select {
+	case err := <-result:
+		require.ErrorIs(t, err, io.EOF)
+	case <-time.After(time.Second):
+		require.Fail(t, "read was not unblocked")
+	}
+}
+
+func TestSerialConnectionBufferLimit(t *testing.T) {
+	// arrange
+	a, _ := initTestAdaptorWithSerialBoard()
+	con, _ := a.GetSerialConnection(client.HwSerial1, 9600)
+	require.NoError(t, con.Connect())
+	data := make([]byte, serialBufferSize+10)
+	data[10] = 0x42
+	// act
+	con.receive(data)
+	// assert
+	assert.Len(t, con.buffer, serialBufferSize)
+	assert.Equal(t, byte(0x42), con.buffer[0])
+}
@@ -32,6 +32,7 @@ type Adaptor struct {
 	*adaptors.I2cBusAdaptor
 	*adaptors.SpiBusAdaptor
 	*adaptors.OneWireBusAdaptor
+	*adaptors.ClassDevicesAdaptor
 }
 
 // NewAdaptor creates a NanoPC-T6 Adaptor
@@ -39,6 +40,7 @@ type Adaptor struct {
 // Optional parameters:
 //
 //	adaptors.WithGpioSysfsAccess():	use legacy sysfs driver instead of default character device driver
+//	adaptors.WithSpiCdevAccess():	use native /dev/spidev#.# access instead of periph.io
 //	adaptors.WithSpiGpioAccess(sclk, ncs, sdo, sdi):	use GPIO's instead of /dev/spidev#.#
 //	adaptors.WithGpiosActiveLow(pin's): invert the pin behavior
 //	adaptors.WithGpiosPullUp/Down(pin's): sets the internal pull resistor
@@ -84,8 +86,13 @@ func NewAdaptor(opts ...interface{}) *Adaptor {
 	// x is the chip number <255
 	spiBusNumberValidator := adaptors.NewBusNumberValidator([]int{0, 4})
 
-	a.AnalogPinsAdaptor = adaptors.NewAnalogPinsAdaptor(sys, analogPinTranslator.Translate)
+	a.ClassDevicesAdaptor = adaptors.NewClassDevicesAdaptor(sys)
+	a.AnalogPinsAdaptor = adaptors.NewAnalogPinsAdaptor(sys, analogPinTranslator.Translate,
+		adaptors.WithAnalogClassDevices(a.ClassDevicesAdaptor))
+	digitalPinsOpts = append(digitalPinsOpts, adaptors.WithDigitalPinClassDevices(a.ClassDevicesAdaptor))
 	a.DigitalPinsAdaptor = adaptors.NewDigitalPinsAdaptor(sys, digitalPinTranslator.Translate, digitalPinsOpts...)
+	pwmPinsOpts = append(pwmPinsOpts, adaptors.WithPWMDigitalPinnerProvider(a.DigitalPinsAdaptor),
+		adaptors.WithPWMClassDevices(a.ClassDevicesAdaptor))
 	a.PWMPinsAdaptor = adaptors.NewPWMPinsAdaptor(sys, pwmPinTranslator.Translate, pwmPinsOpts...)
 	a.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, i2cBusNumberValidator.Validate, defaultI2cBusNumber)
 	a.SpiBusAdaptor = adaptors.NewSpiBusAdaptor(sys, spiBusNumberValidator.Validate, defaultSpiBusNumber,
@@ -127,6 +134,10 @@ func (a *Adaptor) Connect() error {
 		return err
 	}
 
+	if err := a.ClassDevicesAdaptor.Connect(); err != nil {
+		return err
+	}
+
 	return a.DigitalPinsAdaptor.Connect()
 }
 
@@ -135,9 +146,10 @@ func (a *Adaptor) Finalize() error {
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
 
-	err := a.DigitalPinsAdaptor.Finalize()
+	// the software PWM pins are based on digital pins, so they need to be finalized before
+	err := a.PWMPinsAdaptor.Finalize()
 
-	if e := a.PWMPinsAdaptor.Finalize(); e != nil {
+	if e := a.DigitalPinsAdaptor.Finalize(); e != nil {
 		err = multierror.Append(err, e)
 	}
 
@@ -157,5 +169,9 @@ func (a *Adaptor) Finalize() error {
 		err = multierror.Append(err, e)
 	}
 
+	if e := a.ClassDevicesAdaptor.Finalize(); e != nil {
+		err = multierror.Append(err, e)
+	}
+
 	return err
 }
@@ -12,6 +12,7 @@ import (
 	"gobot.io/x/gobot/v2/drivers/gpio"
 	"gobot.io/x/gobot/v2/drivers/i2c"
 	"gobot.io/x/gobot/v2/platforms/adaptors"
+	"gobot.io/x/gobot/v2/platforms/linuxboard"
 	"gobot.io/x/gobot/v2/system"
 )
 
@@ -222,3 +223,15 @@ func TestI2cFinalizeWithErrors(t *testing.T) {
 	// assert
 	require.ErrorContains(t, err, "close error")
 }
+
+func TestPinMapsMatchLinuxBoardDefinition(t *testing.T) {
+	// arrange
+	def, err := linuxboard.BoardDefinition("nanopct6")
+	require.NoError(t, err)
+	// act & assert
+	assert.Equal(t, gpioPinDefinitions, def.DigitalPinDefinitions())
+	assert.Equal(t, pwmPinDefinitions, def.PWMPinDefinitions())
+	assert.Equal(t, analogPinDefinitions, def.AnalogPinDefinitions())
+	assert.Equal(t, defaultI2cBusNumber, def.I2c.DefaultBus)
+	assert.Equal(t, defaultSpiBusNumber, def.Spi.DefaultBus)
+}
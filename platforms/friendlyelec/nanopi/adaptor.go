@@ -31,6 +31,7 @@ type Adaptor struct {
 	*adaptors.PWMPinsAdaptor
 	*adaptors.I2cBusAdaptor
 	*adaptors.SpiBusAdaptor
+	*adaptors.ClassDevicesAdaptor
 }
 
 // NewNeoAdaptor creates a board adaptor for NanoPi NEO
@@ -38,6 +39,7 @@ type Adaptor struct {
 // Optional parameters:
 //
 //	adaptors.WithGpioSysfsAccess():	use legacy sysfs driver instead of default character device driver
+//	adaptors.WithSpiCdevAccess():	use native /dev/spidev#.# access instead of periph.io
 //	adaptors.WithSpiGpioAccess(sclk, ncs, sdo, sdi):	use GPIO's instead of /dev/spidev#.#
 //	adaptors.WithGpiosActiveLow(pin's): invert the pin behavior
 //	adaptors.WithGpiosPullUp/Down(pin's): sets the internal pull resistor
@@ -78,8 +80,13 @@ func NewNeoAdaptor(opts ...interface{}) *Adaptor {
 	// x is the chip number <255
 	spiBusNumberValidator := adaptors.NewBusNumberValidator([]int{0})
 
-	a.AnalogPinsAdaptor = adaptors.NewAnalogPinsAdaptor(sys, analogPinTranslator.Translate)
+	a.ClassDevicesAdaptor = adaptors.NewClassDevicesAdaptor(sys)
+	a.AnalogPinsAdaptor = adaptors.NewAnalogPinsAdaptor(sys, analogPinTranslator.Translate,
+		adaptors.WithAnalogClassDevices(a.ClassDevicesAdaptor))
+	digitalPinsOpts = append(digitalPinsOpts, adaptors.WithDigitalPinClassDevices(a.ClassDevicesAdaptor))
 	a.DigitalPinsAdaptor = adaptors.NewDigitalPinsAdaptor(sys, digitalPinTranslator.Translate, digitalPinsOpts...)
+	pwmPinsOpts = append(pwmPinsOpts, adaptors.WithPWMDigitalPinnerProvider(a.DigitalPinsAdaptor),
+		adaptors.WithPWMClassDevices(a.ClassDevicesAdaptor))
 	a.PWMPinsAdaptor = adaptors.NewPWMPinsAdaptor(sys, pwmPinTranslator.Translate, pwmPinsOpts...)
 	a.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, i2cBusNumberValidator.Validate, defaultI2cBusNumber)
 	a.SpiBusAdaptor = adaptors.NewSpiBusAdaptor(sys, spiBusNumberValidator.Validate, defaultSpiBusNumber,
@@ -113,6 +120,10 @@ func (a *Adaptor) Connect() error {
 	if err := a.PWMPinsAdaptor.Connect(); err != nil {
 		return err
 	}
+	if err := a.ClassDevicesAdaptor.Connect(); err != nil {
+		return err
+	}
+
 	return a.DigitalPinsAdaptor.Connect()
 }
 
@@ -121,9 +132,10 @@ func (a *Adaptor) Finalize() error {
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
 
-	err := a.DigitalPinsAdaptor.Finalize()
+	// the software PWM pins are based on digital pins, so they need to be finalized before
+	err := a.PWMPinsAdaptor.Finalize()
 
-	if e := a.PWMPinsAdaptor.Finalize(); e != nil {
+	if e := a.DigitalPinsAdaptor.Finalize(); e != nil {
 		err = multierror.Append(err, e)
 	}
 
@@ -138,5 +150,10 @@ func (a *Adaptor) Finalize() error {
 	if e := a.SpiBusAdaptor.Finalize(); e != nil {
 		err = multierror.Append(err, e)
 	}
+
+	if e := a.ClassDevicesAdaptor.Finalize(); e != nil {
+		err = multierror.Append(err, e)
+	}
+
 	return err
 }
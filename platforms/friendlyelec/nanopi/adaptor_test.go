@@ -12,6 +12,7 @@ import (
 	"gobot.io/x/gobot/v2/drivers/gpio"
 	"gobot.io/x/gobot/v2/drivers/i2c"
 	"gobot.io/x/gobot/v2/platforms/adaptors"
+	"gobot.io/x/gobot/v2/platforms/linuxboard"
 	"gobot.io/x/gobot/v2/system"
 )
 
@@ -224,3 +225,15 @@ func TestI2cFinalizeWithErrors(t *testing.T) {
 	// assert
 	require.ErrorContains(t, err, "close error")
 }
+
+func TestPinMapsMatchLinuxBoardDefinition(t *testing.T) {
+	// arrange
+	def, err := linuxboard.BoardDefinition("nanopi-neo")
+	require.NoError(t, err)
+	// act & assert
+	assert.Equal(t, neoDigitalPinDefinitions, def.DigitalPinDefinitions())
+	assert.Equal(t, neoPWMPinDefinitions, def.PWMPinDefinitions())
+	assert.Equal(t, analogPinDefinitions, def.AnalogPinDefinitions())
+	assert.Equal(t, defaultI2cBusNumber, def.I2c.DefaultBus)
+	assert.Equal(t, defaultSpiBusNumber, def.Spi.DefaultBus)
+}
@@ -2,6 +2,7 @@ package curie
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"strings"
 	"testing"
@@ -70,16 +71,52 @@ func (m mockFirmataBoard) Disconnect() error {
 func (m mockFirmataBoard) Pins() []client.Pin {
 	return m.pins
 }
-func (mockFirmataBoard) AnalogWrite(int, int) error      { return nil }
-func (mockFirmataBoard) SetPinMode(int, int) error       { return nil }
-func (mockFirmataBoard) ReportAnalog(int, int) error     { return nil }
-func (mockFirmataBoard) ReportDigital(int, int) error    { return nil }
-func (mockFirmataBoard) DigitalWrite(int, int) error     { return nil }
-func (mockFirmataBoard) I2cRead(int, int) error          { return nil }
-func (mockFirmataBoard) I2cWrite(int, []byte) error      { return nil }
-func (mockFirmataBoard) I2cConfig(int) error             { return nil }
-func (mockFirmataBoard) ServoConfig(int, int, int) error { return nil }
-func (mockFirmataBoard) WriteSysex(data []byte) error    { return nil }
+
+func (m mockFirmataBoard) Pin(pin int) (client.Pin, error) {
+	if pin < 0 || pin >= len(m.pins) {
+		return client.Pin{}, fmt.Errorf("Invalid pin %d, the board reports %d pins", pin, len(m.pins))
+	}
+	return m.pins[pin], nil
+}
+func (mockFirmataBoard) AnalogWrite(int, int) error                    { return nil }
+func (mockFirmataBoard) SetPinMode(int, int) error                     { return nil }
+func (mockFirmataBoard) ReportAnalog(int, int) error                   { return nil }
+func (mockFirmataBoard) ReportDigital(int, int) error                  { return nil }
+func (mockFirmataBoard) DigitalWrite(int, int) error                   { return nil }
+func (mockFirmataBoard) I2cRead(int, int) error                        { return nil }
+func (mockFirmataBoard) I2cWrite(int, []byte) error                    { return nil }
+func (mockFirmataBoard) I2cConfig(int) error                           { return nil }
+func (mockFirmataBoard) I2cReadWithFlags(int, int, int, byte) error    { return nil }
+func (mockFirmataBoard) I2cWriteWithFlags(int, []byte, byte) error     { return nil }
+func (mockFirmataBoard) I2cReadContinuously(int, int, int, byte) error { return nil }
+func (mockFirmataBoard) I2cStopReading(int, byte) error                { return nil }
+func (mockFirmataBoard) ServoConfig(int, int, int) error               { return nil }
+func (mockFirmataBoard) WriteSysex(data []byte) error                  { return nil }
+
+func (mockFirmataBoard) SerialConfig(int, int, int, int) error { return nil }
+func (mockFirmataBoard) SerialWrite(int, []byte) error         { return nil }
+func (mockFirmataBoard) SerialStartReading(int, int) error     { return nil }
+func (mockFirmataBoard) SerialStopReading(int) error           { return nil }
+func (mockFirmataBoard) SerialClose(int) error                 { return nil }
+func (mockFirmataBoard) SerialFlush(int) error                 { return nil }
+func (mockFirmataBoard) SerialListen(int) error                { return nil }
+
+func (mockFirmataBoard) OneWireConfig(int, bool) error                             { return nil }
+func (mockFirmataBoard) OneWireSearch(int) error                                   { return nil }
+func (mockFirmataBoard) OneWireSearchAlarms(int) error                             { return nil }
+func (mockFirmataBoard) OneWireCommand(int, client.OneWireRequest) error           { return nil }
+func (mockFirmataBoard) AccelStepperConfig(int, client.AccelStepperSettings) error { return nil }
+func (mockFirmataBoard) AccelStepperZero(int) error                                { return nil }
+func (mockFirmataBoard) AccelStepperStep(int, int32) error                         { return nil }
+func (mockFirmataBoard) AccelStepperTo(int, int32) error                           { return nil }
+func (mockFirmataBoard) AccelStepperEnable(int, bool) error                        { return nil }
+func (mockFirmataBoard) AccelStepperStop(int) error                                { return nil }
+func (mockFirmataBoard) AccelStepperReportPosition(int) error                      { return nil }
+func (mockFirmataBoard) AccelStepperSetAcceleration(int, float64) error            { return nil }
+func (mockFirmataBoard) AccelStepperSetSpeed(int, float64) error                   { return nil }
+func (mockFirmataBoard) MultiStepperConfig(int, []int) error                       { return nil }
+func (mockFirmataBoard) MultiStepperTo(int, []int32) error                         { return nil }
+func (mockFirmataBoard) MultiStepperStop(int) error                                { return nil }
 
 func initTestIMUDriver() *IMUDriver {
 	a := firmata.NewAdaptor("/dev/null")
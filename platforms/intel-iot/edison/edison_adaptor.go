@@ -16,6 +16,7 @@ import (
 const (
 	defaultI2cBusNumber      = 6
 	defaultI2cBusNumberOther = 1
+	defaultPwmPeriod         = 10000000 // 10 ms = 100 Hz, only used for software PWM pins
 )
 
 type mux struct {
@@ -43,6 +44,7 @@ type Adaptor struct {
 	*adaptors.AnalogPinsAdaptor
 	*adaptors.PWMPinsAdaptor
 	*adaptors.I2cBusAdaptor
+	*adaptors.ClassDevicesAdaptor
 	arduinoI2cInitialized bool
 }
 
@@ -74,7 +76,11 @@ func NewAdaptor(opts ...interface{}) *Adaptor {
 		}
 	}
 
+	a.ClassDevicesAdaptor = adaptors.NewClassDevicesAdaptor(sys)
 	a.AnalogPinsAdaptor = adaptors.NewAnalogPinsAdaptor(sys, a.translateAnalogPin)
+	// the software PWM uses the digital pins of the adaptor, so the pin muxing applies also to this pins
+	pwmPinsOpts = append(pwmPinsOpts, adaptors.WithPWMDigitalPinnerProvider(a),
+		adaptors.WithPWMClassDevices(a.ClassDevicesAdaptor))
 	a.PWMPinsAdaptor = adaptors.NewPWMPinsAdaptor(sys, a.translateAndMuxPWMPin, pwmPinsOpts...)
 	defI2cBusNr := defaultI2cBusNumber
 	if a.board != "arduino" {
@@ -106,6 +112,10 @@ func (a *Adaptor) Connect() error {
 		return err
 	}
 
+	if err := a.ClassDevicesAdaptor.Connect(); err != nil {
+		return err
+	}
+
 	switch a.board {
 	case "sparkfun":
 		a.pinMap = sparkfunPinMap
@@ -127,6 +137,11 @@ func (a *Adaptor) Connect() error {
 // Finalize releases all i2c devices and exported analog, digital, pwm pins.
 func (a *Adaptor) Finalize() error {
 	var err error
+	// stop the software PWM before the underlying digital pins are released
+	if e := a.PWMPinsAdaptor.Finalize(); e != nil {
+		err = multierror.Append(err, e)
+	}
+
 	if a.tristate != nil {
 		if errs := a.tristate.Unexport(); errs != nil {
 			err = multierror.Append(err, errs)
@@ -143,15 +158,15 @@ func (a *Adaptor) Finalize() error {
 	}
 	a.digitalPins = nil
 
-	if e := a.PWMPinsAdaptor.Finalize(); e != nil {
+	if e := a.AnalogPinsAdaptor.Finalize(); e != nil {
 		err = multierror.Append(err, e)
 	}
 
-	if e := a.AnalogPinsAdaptor.Finalize(); e != nil {
+	if e := a.I2cBusAdaptor.Finalize(); e != nil {
 		err = multierror.Append(err, e)
 	}
 
-	if e := a.I2cBusAdaptor.Finalize(); e != nil {
+	if e := a.ClassDevicesAdaptor.Finalize(); e != nil {
 		err = multierror.Append(err, e)
 	}
 	a.arduinoI2cInitialized = false
@@ -170,8 +185,13 @@ func (a *Adaptor) DigitalRead(pin string) (int, error) {
 	return sysPin.Read()
 }
 
-// DigitalWrite writes a value to the pin. Acceptable values are 1 or 0.
+// DigitalWrite writes a value to the pin. Acceptable values are 1 or 0. LED's of the board are supported by the prefix
+// "led:".
 func (a *Adaptor) DigitalWrite(pin string, val byte) error {
+	if a.IsLEDPin(pin) {
+		return a.LEDsAdaptor.DigitalWrite(pin, val)
+	}
+
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
 
@@ -187,8 +207,13 @@ func (a *Adaptor) DigitalPin(id string) (gobot.DigitalPinner, error) {
 	return a.digitalPin(id)
 }
 
-// AnalogRead returns value from analog reading of specified pin
+// AnalogRead returns value from analog reading of specified pin. Hardware monitoring sensors are supported by the
+// prefix "hwmon:" and returned unscaled.
 func (a *Adaptor) AnalogRead(pin string) (int, error) {
+	if a.IsHwmonPin(pin) {
+		return a.HwmonAdaptor.AnalogRead(pin)
+	}
+
 	rawRead, err := a.AnalogPinsAdaptor.AnalogRead(pin)
 	if err != nil {
 		return 0, err
@@ -350,6 +375,12 @@ func pwmPinInitializer(_ string, pin gobot.PWMPinner) error {
 	if err := pin.Export(); err != nil {
 		return err
 	}
+	// a software PWM pin starts without period, but needs one before it can be enabled
+	if period, err := pin.Period(); err == nil && period == 0 {
+		if err := pin.SetPeriod(defaultPwmPeriod); err != nil {
+			return err
+		}
+	}
 	return pin.SetEnabled(true)
 }
 
@@ -11,6 +11,7 @@ import (
 	"gobot.io/x/gobot/v2/drivers/aio"
 	"gobot.io/x/gobot/v2/drivers/gpio"
 	"gobot.io/x/gobot/v2/drivers/i2c"
+	"gobot.io/x/gobot/v2/platforms/adaptors"
 	"gobot.io/x/gobot/v2/system"
 )
 
@@ -484,6 +485,28 @@ func TestPwm(t *testing.T) {
 	require.ErrorContains(t, err, "'7' is not a valid id for a PWM pin")
 }
 
+func TestPwmSoftwareFallback(t *testing.T) {
+	// arrange
+	a := NewAdaptor("arduino", adaptors.WithPWMSoftwareFallback())
+	mockedPaths := append([]string{}, testPinFiles...)
+	mockedPaths = append(mockedPaths,
+		"/sys/class/gpio/gpio218/value", // resistor
+		"/sys/class/gpio/gpio218/direction",
+		"/sys/class/gpio/gpio250/value", // level shifter
+		"/sys/class/gpio/gpio250/direction",
+	)
+	fs := a.sys.UseMockFilesystem(mockedPaths)
+	require.NoError(t, a.Connect())
+	// act
+	err := a.PwmWrite("2", 100)
+	// assert
+	require.NoError(t, err)
+	require.NoError(t, a.Finalize())
+	assert.Equal(t, "out", fs.Files["/sys/class/gpio/gpio128/direction"].Contents)
+	assert.Equal(t, "0", fs.Files["/sys/class/gpio/gpio128/value"].Contents)
+	assert.Equal(t, "out", fs.Files["/sys/class/gpio/gpio250/direction"].Contents)
+}
+
 func TestPwmExportError(t *testing.T) {
 	a := NewAdaptor()
 	fs := a.sys.UseMockFilesystem(pwmMockPathsMux13Arduino)
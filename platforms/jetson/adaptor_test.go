@@ -14,6 +14,7 @@ import (
 	"gobot.io/x/gobot/v2/drivers/gpio"
 	"gobot.io/x/gobot/v2/drivers/i2c"
 	"gobot.io/x/gobot/v2/drivers/spi"
+	"gobot.io/x/gobot/v2/platforms/linuxboard"
 	"gobot.io/x/gobot/v2/system"
 )
 
@@ -211,3 +212,27 @@ func Test_translatePWMPin(t *testing.T) {
 		})
 	}
 }
+
+func TestPinMapsMatchLinuxBoardDefinition(t *testing.T) {
+	// arrange
+	def, err := linuxboard.BoardDefinition("jetson-nano")
+	require.NoError(t, err)
+	a := NewAdaptor()
+	// act & assert
+	require.Len(t, def.DigitalPins, len(gpioPins))
+	for id, pin := range def.DigitalPins {
+		_, line, err := a.translateDigitalPin(id)
+		require.NoError(t, err)
+		assert.Equal(t, line, pin.Sysfs, "pin '%s'", id)
+	}
+	require.Len(t, def.PWMPins, len(pwmPins))
+	for id, pin := range def.PWMPins {
+		dir, channel, err := a.translatePWMPin(id)
+		require.NoError(t, err)
+		assert.Equal(t, dir, pin.Dir+"pwmchip0", "PWM pin '%s'", id)
+		assert.Equal(t, channel, pin.Channel, "PWM pin '%s'", id)
+	}
+	assert.Equal(t, defaultI2cBusNumber, def.I2c.DefaultBus)
+	assert.Equal(t, defaultSpiBusNumber, def.Spi.DefaultBus)
+	assert.Equal(t, int64(defaultSpiMaxSpeed), def.Spi.DefaultMaxSpeed)
+}
@@ -9,20 +9,39 @@ import (
 	"gobot.io/x/gobot/v2"
 )
 
+// configuration contains all changeable attributes of the adaptor.
+type configuration struct {
+	inputEventDevice bool
+}
+
 // Adaptor represents a connection to a joystick
 type Adaptor struct {
 	name     string
 	id       string
+	cfg      *configuration
 	joystick js.Joystick
 	connect  func(*Adaptor) error
 }
 
 // NewAdaptor returns a new Joystick Adaptor.
-// Pass in the ID of the joystick you wish to connect to.
-func NewAdaptor(id string) *Adaptor {
-	return &Adaptor{
+// Pass in the ID of the joystick you wish to connect to. When using the option [joystick.WithInputEventDevice], the
+// ID is the path, the name or the "vendor:product" ID of the Linux input event device.
+func NewAdaptor(id string, opts ...optionApplier) *Adaptor {
+	a := &Adaptor{
 		name: gobot.DefaultName("Joystick"),
+		cfg:  &configuration{},
 		connect: func(j *Adaptor) error {
+			if j.cfg.inputEventDevice {
+				joy, err := openInputEventJoystick(id)
+				if err != nil {
+					return fmt.Errorf("no joystick available: %v", err)
+				}
+
+				j.id = id
+				j.joystick = joy
+				return nil
+			}
+
 			i, err := strconv.Atoi(id)
 			if err != nil {
 				return fmt.Errorf("invalid joystick ID: %v", err)
@@ -38,6 +57,18 @@ func NewAdaptor(id string) *Adaptor {
 			return nil
 		},
 	}
+
+	for _, o := range opts {
+		o.apply(a.cfg)
+	}
+
+	return a
+}
+
+// WithInputEventDevice is used to read the joystick by the Linux input event interface ("/dev/input/event*") instead
+// of the joystick interface ("/dev/input/js*"). This is only supported on Linux.
+func WithInputEventDevice() optionApplier {
+	return inputEventDeviceOption(true)
 }
 
 // Name returns the adaptors name
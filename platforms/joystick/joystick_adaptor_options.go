@@ -0,0 +1,17 @@
+package joystick
+
+// optionApplier needs to be implemented by each configurable option type
+type optionApplier interface {
+	apply(cfg *configuration)
+}
+
+// inputEventDeviceOption is the type for applying the usage of the Linux input event interface
+type inputEventDeviceOption bool
+
+func (o inputEventDeviceOption) String() string {
+	return "input event device option for Joystick adaptors"
+}
+
+func (o inputEventDeviceOption) apply(cfg *configuration) {
+	cfg.inputEventDevice = bool(o)
+}
@@ -0,0 +1,14 @@
+package joystick
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithInputEventDevice(t *testing.T) {
+	// arrange & act
+	a := NewAdaptor("/dev/input/event3", WithInputEventDevice())
+	// assert
+	assert.True(t, a.cfg.inputEventDevice)
+}
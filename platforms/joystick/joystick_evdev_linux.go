@@ -0,0 +1,165 @@
+package joystick
+
+import (
+	"sync"
+
+	js "github.com/0xcafed00d/joystick"
+
+	"gobot.io/x/gobot/v2/system"
+)
+
+// axis values are scaled to the same range like the Linux joystick interface ("/dev/input/js*") does
+const (
+	inputEventAxisMin = -32767
+	inputEventAxisMax = 32767
+	// the button state is a bitmask of type uint32
+	inputEventButtonMaxCount = 32
+)
+
+// inputEventDevicer is the needed subset of system.InputEventDevice
+type inputEventDevicer interface {
+	AbsInfo(axis uint16) (system.InputAbsInfo, error)
+	SupportedCodes(evType uint16) ([]uint16, error)
+	ReadEvent() (system.InputEvent, error)
+	Close() error
+}
+
+// inputEventJoystick implements the js.Joystick interface by an Linux input event device ("/dev/input/event*")
+type inputEventJoystick struct {
+	name     string
+	dev      inputEventDevicer
+	axes     map[uint16]int // event code to axis number
+	absInfos []system.InputAbsInfo
+	buttons  map[uint16]int // event code to button number
+	state    js.State
+	readErr  error
+	mutex    sync.RWMutex
+}
+
+func openInputEventJoystick(pathNameOrID string) (js.Joystick, error) {
+	sys := system.NewAccesser()
+	sys.AddInputEventSupport()
+
+	dev, err := sys.NewInputEventDevice(pathNameOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	return newInputEventJoystick(dev.Name, dev)
+}
+
+// newInputEventJoystick creates the joystick and starts reading the events. The axes are numbered in order of the
+// absolute axis codes, the buttons in order of the key codes, starting with BTN_JOYSTICK, followed by BTN_MISC. This
+// is the same behavior like the Linux joystick interface, so the existing configurations can be used.
+func newInputEventJoystick(name string, dev inputEventDevicer) (*inputEventJoystick, error) {
+	absCodes, err := dev.SupportedCodes(system.EV_ABS)
+	if err != nil {
+		return nil, err
+	}
+
+	keyCodes, err := dev.SupportedCodes(system.EV_KEY)
+	if err != nil {
+		return nil, err
+	}
+
+	j := inputEventJoystick{
+		name:    name,
+		dev:     dev,
+		axes:    make(map[uint16]int),
+		buttons: make(map[uint16]int),
+	}
+
+	for _, code := range absCodes {
+		if code > system.ABS_MAX {
+			continue
+		}
+		info, err := dev.AbsInfo(code)
+		if err != nil {
+			return nil, err
+		}
+		j.axes[code] = len(j.absInfos)
+		j.absInfos = append(j.absInfos, info)
+		j.state.AxisData = append(j.state.AxisData, scaleInputEventAxis(info.Value, info))
+	}
+
+	for _, code := range keyCodes {
+		if code >= system.BTN_JOYSTICK {
+			j.addButton(code)
+		}
+	}
+	for _, code := range keyCodes {
+		if code >= system.BTN_MISC && code < system.BTN_JOYSTICK {
+			j.addButton(code)
+		}
+	}
+
+	go j.updateState()
+
+	return &j, nil
+}
+
+func (j *inputEventJoystick) AxisCount() int { return len(j.absInfos) }
+
+func (j *inputEventJoystick) ButtonCount() int { return len(j.buttons) }
+
+func (j *inputEventJoystick) Name() string { return j.name }
+
+// Read returns the last state of the joystick. After the device was disconnected, the read error is returned.
+func (j *inputEventJoystick) Read() (js.State, error) {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+
+	state := js.State{AxisData: make([]int, len(j.state.AxisData)), Buttons: j.state.Buttons}
+	copy(state.AxisData, j.state.AxisData)
+	return state, j.readErr
+}
+
+func (j *inputEventJoystick) Close() {
+	_ = j.dev.Close()
+}
+
+func (j *inputEventJoystick) addButton(code uint16) {
+	if len(j.buttons) < inputEventButtonMaxCount {
+		j.buttons[code] = len(j.buttons)
+	}
+}
+
+func (j *inputEventJoystick) updateState() {
+	for {
+		evt, err := j.dev.ReadEvent()
+		if err != nil {
+			j.mutex.Lock()
+			j.readErr = err
+			j.mutex.Unlock()
+			return
+		}
+
+		j.mutex.Lock()
+		switch evt.Type {
+		case system.EV_ABS:
+			if axis, ok := j.axes[evt.Code]; ok {
+				j.state.AxisData[axis] = scaleInputEventAxis(evt.Value, j.absInfos[axis])
+			}
+		case system.EV_KEY:
+			if button, ok := j.buttons[evt.Code]; ok {
+				if evt.Value == 0 {
+					j.state.Buttons &= ^(1 << uint32(button)) //nolint:gosec // button count is limited to 32
+				} else {
+					j.state.Buttons |= 1 << uint32(button) //nolint:gosec // button count is limited to 32
+				}
+			}
+		}
+		j.mutex.Unlock()
+	}
+}
+
+// scaleInputEventAxis converts the value from the device range to the range -32767..32767
+func scaleInputEventAxis(value int32, info system.InputAbsInfo) int {
+	if info.Maximum <= info.Minimum {
+		return int(value)
+	}
+
+	rng := int64(info.Maximum) - int64(info.Minimum)
+	scaled := (int64(value)-int64(info.Minimum))*(inputEventAxisMax-inputEventAxisMin)/rng + inputEventAxisMin
+	return int(min(max(scaled, inputEventAxisMin), inputEventAxisMax))
+}
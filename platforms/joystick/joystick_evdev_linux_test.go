@@ -0,0 +1,123 @@
+package joystick
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	js "github.com/0xcafed00d/joystick"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2/system"
+)
+
+type testInputEventDevice struct {
+	absCodes []uint16
+	keyCodes []uint16
+	absInfos map[uint16]system.InputAbsInfo
+	events   chan system.InputEvent
+	closed   bool
+}
+
+func newTestInputEventDevice() *testInputEventDevice {
+	return &testInputEventDevice{
+		absCodes: []uint16{0x00, 0x01, 0x10},
+		// BTN_0, BTN_SOUTH, BTN_EAST, BTN_TRIGGER_HAPPY1
+		keyCodes: []uint16{0x100, 0x130, 0x131, 0x2c0},
+		absInfos: map[uint16]system.InputAbsInfo{
+			0x00: {Value: 128, Minimum: 0, Maximum: 255},
+			0x01: {Value: 0, Minimum: 0, Maximum: 255},
+			0x10: {Value: 0, Minimum: -1, Maximum: 1},
+		},
+		events: make(chan system.InputEvent, 10),
+	}
+}
+
+func (d *testInputEventDevice) AbsInfo(axis uint16) (system.InputAbsInfo, error) {
+	return d.absInfos[axis], nil
+}
+
+func (d *testInputEventDevice) SupportedCodes(evType uint16) ([]uint16, error) {
+	if evType == system.EV_ABS {
+		return d.absCodes, nil
+	}
+	return d.keyCodes, nil
+}
+
+func (d *testInputEventDevice) ReadEvent() (system.InputEvent, error) {
+	evt, ok := <-d.events
+	if !ok {
+		return system.InputEvent{}, fmt.Errorf("device closed")
+	}
+	return evt, nil
+}
+
+func (d *testInputEventDevice) Close() error {
+	d.closed = true
+	return nil
+}
+
+func TestNewInputEventJoystick(t *testing.T) {
+	// arrange
+	dev := newTestInputEventDevice()
+	// act
+	j, err := newInputEventJoystick("gamepad", dev)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "gamepad", j.Name())
+	assert.Equal(t, 3, j.AxisCount())
+	assert.Equal(t, 4, j.ButtonCount())
+	assert.Equal(t, map[uint16]int{0x130: 0, 0x131: 1, 0x2c0: 2, 0x100: 3}, j.buttons)
+	state, err := j.Read()
+	require.NoError(t, err)
+	assert.Equal(t, js.State{AxisData: []int{128, -32767, 0}}, state)
+	// act
+	j.Close()
+	// assert
+	assert.True(t, dev.closed)
+}
+
+func TestInputEventJoystickRead(t *testing.T) {
+	// arrange
+	dev := newTestInputEventDevice()
+	j, err := newInputEventJoystick("gamepad", dev)
+	require.NoError(t, err)
+	// act
+	dev.events <- system.InputEvent{Type: system.EV_ABS, Code: 0x10, Value: 1}
+	dev.events <- system.InputEvent{Type: system.EV_KEY, Code: 0x131, Value: 1}
+	dev.events <- system.InputEvent{Type: system.EV_KEY, Code: 0x100, Value: 1}
+	dev.events <- system.InputEvent{Type: system.EV_KEY, Code: 0x100, Value: 0}
+	dev.events <- system.InputEvent{Type: system.EV_ABS, Code: 0x01, Value: 255}
+	close(dev.events)
+	// assert
+	assert.Eventually(t, func() bool {
+		_, err := j.Read()
+		return err != nil
+	}, time.Second, time.Millisecond)
+	state, err := j.Read()
+	require.EqualError(t, err, "device closed")
+	assert.Equal(t, js.State{AxisData: []int{128, 32767, 32767}, Buttons: 0x02}, state)
+}
+
+func TestScaleInputEventAxis(t *testing.T) {
+	tests := map[string]struct {
+		value int32
+		info  system.InputAbsInfo
+		want  int
+	}{
+		"min":          {value: 0, info: system.InputAbsInfo{Maximum: 1023}, want: -32767},
+		"max":          {value: 1023, info: system.InputAbsInfo{Maximum: 1023}, want: 32767},
+		"center":       {value: 0, info: system.InputAbsInfo{Minimum: -512, Maximum: 512}, want: 0},
+		"out_of_range": {value: 2000, info: system.InputAbsInfo{Maximum: 1023}, want: 32767},
+		"no_range":     {value: 42, info: system.InputAbsInfo{}, want: 42},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			got := scaleInputEventAxis(tc.value, tc.info)
+			// assert
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
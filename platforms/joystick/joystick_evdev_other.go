@@ -0,0 +1,13 @@
+//go:build !linux
+
+package joystick
+
+import (
+	"fmt"
+
+	js "github.com/0xcafed00d/joystick"
+)
+
+func openInputEventJoystick(pathNameOrID string) (js.Joystick, error) {
+	return nil, fmt.Errorf("input event device '%s' can not be used, this is only supported on Linux", pathNameOrID)
+}
@@ -1,6 +1,7 @@
 package keyboard
 
 import (
+	"io"
 	"log"
 	"os"
 
@@ -12,19 +13,29 @@ const (
 	Key = "key"
 )
 
+// configuration contains all changeable attributes of the driver.
+type configuration struct {
+	inputEventDevice string
+	grab             bool
+}
+
 // Driver is gobot software device to the keyboard
 type Driver struct {
-	name    string
-	connect func(*Driver) error
-	listen  func(*Driver)
-	stdin   *os.File
+	name        string
+	cfg         *configuration
+	connect     func(*Driver) error
+	listen      func(*Driver)
+	stdin       *os.File
+	inputDevice io.Closer
 	gobot.Eventer
 }
 
-// NewDriver returns a new keyboard Driver.
-func NewDriver() *Driver {
+// NewDriver returns a new keyboard Driver. By default the keys are read from the terminal (stdin). With the option
+// [keyboard.WithInputEventDevice] a Linux input event device is used instead, so no terminal is needed.
+func NewDriver(opts ...optionApplier) *Driver {
 	k := &Driver{
 		name: gobot.DefaultName("Keyboard"),
+		cfg:  &configuration{},
 		connect: func(k *Driver) error {
 			if err := configure(); err != nil {
 				return err
@@ -61,11 +72,32 @@ func NewDriver() *Driver {
 		Eventer: gobot.NewEventer(),
 	}
 
+	for _, o := range opts {
+		o.apply(k.cfg)
+	}
+
+	if k.cfg.inputEventDevice != "" {
+		k.connect = connectInputEventDevice
+	}
+
 	k.AddEvent(Key)
 
 	return k
 }
 
+// WithInputEventDevice is used to read the keys from the given Linux input event device instead of the terminal.
+// The device is given by its path (e.g. "/dev/input/event0"), its name (e.g. "Logitech USB Keyboard") or its vendor
+// and product ID in hex format (e.g. "046d:c31c"). This is only supported on Linux.
+func WithInputEventDevice(pathNameOrID string) optionApplier {
+	return inputEventDeviceOption(pathNameOrID)
+}
+
+// WithInputEventDeviceGrab is used to get exclusive access to the input event device, so the key strokes are not
+// passed to other applications (e.g. the console). In this case "Ctrl+C" is handled by the driver.
+func WithInputEventDeviceGrab() optionApplier {
+	return inputEventDeviceGrabOption(true)
+}
+
 // Name returns the Driver Name
 func (k *Driver) Name() string { return k.name }
 
@@ -89,6 +121,11 @@ func (k *Driver) Start() error {
 
 // Halt stops keyboard driver
 func (k *Driver) Halt() error {
+	if k.inputDevice != nil {
+		err := k.inputDevice.Close()
+		k.inputDevice = nil
+		return err
+	}
 	if originalState != "" {
 		return restore()
 	}
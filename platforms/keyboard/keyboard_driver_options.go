@@ -0,0 +1,28 @@
+package keyboard
+
+// optionApplier needs to be implemented by each configurable option type
+type optionApplier interface {
+	apply(cfg *configuration)
+}
+
+// inputEventDeviceOption is the type for applying a Linux input event device instead of the terminal
+type inputEventDeviceOption string
+
+// inputEventDeviceGrabOption is the type for applying the exclusive access to the input event device
+type inputEventDeviceGrabOption bool
+
+func (o inputEventDeviceOption) String() string {
+	return "input event device option for keyboard drivers"
+}
+
+func (o inputEventDeviceGrabOption) String() string {
+	return "input event device grab option for keyboard drivers"
+}
+
+func (o inputEventDeviceOption) apply(cfg *configuration) {
+	cfg.inputEventDevice = string(o)
+}
+
+func (o inputEventDeviceGrabOption) apply(cfg *configuration) {
+	cfg.grab = bool(o)
+}
@@ -0,0 +1,24 @@
+package keyboard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithInputEventDevice(t *testing.T) {
+	// arrange & act
+	d := NewDriver(WithInputEventDevice("046d:c31c"))
+	// assert
+	assert.Equal(t, "046d:c31c", d.cfg.inputEventDevice)
+	assert.False(t, d.cfg.grab)
+}
+
+func TestWithInputEventDeviceGrab(t *testing.T) {
+	// arrange
+	cfg := &configuration{}
+	// act
+	WithInputEventDeviceGrab().apply(cfg)
+	// assert
+	assert.True(t, cfg.grab)
+}
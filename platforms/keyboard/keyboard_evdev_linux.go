@@ -0,0 +1,113 @@
+package keyboard
+
+import (
+	"log"
+	"os"
+
+	"gobot.io/x/gobot/v2/system"
+)
+
+// some key codes, the complete list is found in "/usr/include/linux/input-event-codes.h"
+const (
+	inputKeyLeftCtrl  = 29
+	inputKeyC         = 46
+	inputKeyRightCtrl = 97
+)
+
+// inputEventKeys maps the Linux key codes to the bytes, which are read from the terminal for the same key
+var inputEventKeys = map[uint16]bytes{
+	1: {Escape}, 57: {Spacebar}, 12: {Hyphen}, 52: {Dot}, 53: {Slash},
+	// keypad
+	55: {Asterisk}, 74: {Hyphen}, 78: {Plus}, 83: {Dot}, 98: {Slash},
+	// digits of the main block and the keypad
+	11: {'0'}, 2: {'1'}, 3: {'2'}, 4: {'3'}, 5: {'4'}, 6: {'5'}, 7: {'6'}, 8: {'7'}, 9: {'8'}, 10: {'9'},
+	82: {'0'}, 79: {'1'}, 80: {'2'}, 81: {'3'}, 75: {'4'}, 76: {'5'}, 77: {'6'}, 71: {'7'}, 72: {'8'}, 73: {'9'},
+	// letters in order of the US layout
+	16: {'q'}, 17: {'w'}, 18: {'e'}, 19: {'r'}, 20: {'t'}, 21: {'y'}, 22: {'u'}, 23: {'i'}, 24: {'o'}, 25: {'p'},
+	30: {'a'}, 31: {'s'}, 32: {'d'}, 33: {'f'}, 34: {'g'}, 35: {'h'}, 36: {'j'}, 37: {'k'}, 38: {'l'},
+	44: {'z'}, 45: {'x'}, 46: {'c'}, 47: {'v'}, 48: {'b'}, 49: {'n'}, 50: {'m'},
+	// arrows
+	103: {Escape, '[', ArrowUp}, 108: {Escape, '[', ArrowDown},
+	106: {Escape, '[', ArrowRight}, 105: {Escape, '[', ArrowLeft},
+}
+
+// inputEventReader is the needed subset of system.InputEventDevice
+type inputEventReader interface {
+	ReadEvent() (system.InputEvent, error)
+}
+
+func connectInputEventDevice(k *Driver) error {
+	sys := system.NewAccesser()
+	sys.AddInputEventSupport()
+
+	dev, err := sys.NewInputEventDevice(k.cfg.inputEventDevice)
+	if err != nil {
+		return err
+	}
+
+	if k.cfg.grab {
+		if err := dev.Grab(true); err != nil {
+			_ = dev.Close()
+			return err
+		}
+	}
+
+	k.inputDevice = dev
+	k.listen = func(k *Driver) { listenInputEventDevice(k, dev) }
+	return nil
+}
+
+// listenInputEventDevice publishes a key event for each pressed or auto-repeated key, until the device is closed
+func listenInputEventDevice(k *Driver, dev inputEventReader) {
+	var ctrlPressed bool
+
+	for {
+		evt, err := dev.ReadEvent()
+		if err != nil {
+			return
+		}
+
+		if evt.Type != system.EV_KEY {
+			continue
+		}
+
+		if evt.Code == inputKeyLeftCtrl || evt.Code == inputKeyRightCtrl {
+			ctrlPressed = evt.Value != 0
+			continue
+		}
+
+		// 0=release, 1=press, 2=autorepeat
+		if evt.Value == 0 {
+			continue
+		}
+
+		// when grabbed, the terminal does not receive "Ctrl+C" anymore
+		if ctrlPressed && evt.Code == inputKeyC && k.cfg.grab {
+			proc, err := os.FindProcess(os.Getpid())
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			if err := proc.Signal(os.Interrupt); err != nil {
+				panic(err)
+			}
+			return
+		}
+
+		if keyEvent, ok := ParseInputEvent(evt.Code); ok {
+			k.Publish(Key, keyEvent)
+		}
+	}
+}
+
+// ParseInputEvent converts the given Linux key code (e.g. 30 for KEY_A) to a key event, like the one created by
+// Parse() for the terminal input. Keys not supported by Parse() are ignored. The key code is interpreted by the US
+// layout, modifiers like "Shift" are not considered.
+func ParseInputEvent(code uint16) (KeyEvent, bool) {
+	input, ok := inputEventKeys[code]
+	if !ok {
+		return KeyEvent{}, false
+	}
+
+	return Parse(input), true
+}
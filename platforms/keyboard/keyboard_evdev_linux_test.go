@@ -0,0 +1,90 @@
+package keyboard
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2/system"
+)
+
+type testInputEventReader struct {
+	events []system.InputEvent
+}
+
+func (r *testInputEventReader) ReadEvent() (system.InputEvent, error) {
+	if len(r.events) == 0 {
+		return system.InputEvent{}, fmt.Errorf("device closed")
+	}
+	evt := r.events[0]
+	r.events = r.events[1:]
+	return evt, nil
+}
+
+func TestParseInputEvent(t *testing.T) {
+	tests := map[string]struct {
+		code   uint16
+		want   int
+		wantOk bool
+	}{
+		"letter":      {code: 30, want: A, wantOk: true},
+		"digit":       {code: 11, want: Zero, wantOk: true},
+		"keypad":      {code: 73, want: Nine, wantOk: true},
+		"keypad_plus": {code: 78, want: Plus, wantOk: true},
+		"space":       {code: 57, want: Spacebar, wantOk: true},
+		"arrow":       {code: 105, want: ArrowLeft, wantOk: true},
+		"unknown":     {code: 59}, // KEY_F1
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			got, ok := ParseInputEvent(tc.code)
+			// assert
+			assert.Equal(t, tc.wantOk, ok)
+			assert.Equal(t, tc.want, got.Key)
+		})
+	}
+}
+
+func TestListenInputEventDevice(t *testing.T) {
+	// arrange
+	d := NewDriver(WithInputEventDevice("/dev/input/event0"))
+	keys := make(chan KeyEvent, 10)
+	_ = d.On(Key, func(data interface{}) {
+		keys <- data.(KeyEvent)
+	})
+	dev := &testInputEventReader{events: []system.InputEvent{
+		{Type: system.EV_MSC, Code: 4, Value: 30},
+		{Type: system.EV_KEY, Code: 30, Value: 1},
+		{Type: system.EV_SYN},
+		{Type: system.EV_KEY, Code: 30, Value: 2},
+		{Type: system.EV_KEY, Code: 30, Value: 0},
+		{Type: system.EV_KEY, Code: 103, Value: 1},
+	}}
+	// act
+	listenInputEventDevice(d, dev)
+	// assert
+	var got []int
+	for i := 0; i < 3; i++ {
+		select {
+		case evt := <-keys:
+			got = append(got, evt.Key)
+		case <-time.After(time.Second):
+			require.Fail(t, "key event not published")
+		}
+	}
+	// the order of the published events is not guaranteed
+	assert.ElementsMatch(t, []int{A, A, ArrowUp}, got)
+}
+
+func TestKeyboardDriverStartInputEventDeviceError(t *testing.T) {
+	// arrange
+	d := NewDriver(WithInputEventDevice("unknown keyboard"))
+	// act
+	err := d.Start()
+	// assert
+	require.Error(t, err)
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package keyboard
+
+import "fmt"
+
+func connectInputEventDevice(k *Driver) error {
+	return fmt.Errorf("input event device '%s' can not be used, this is only supported on Linux", k.cfg.inputEventDevice)
+}
@@ -0,0 +1,227 @@
+package linuxboard
+
+import (
+	"fmt"
+	"sync"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/onewire"
+	"gobot.io/x/gobot/v2/platforms/adaptors"
+	"gobot.io/x/gobot/v2/system"
+)
+
+// Adaptor represents a Gobot Adaptor for a Linux board, which is described by a board definition
+type Adaptor struct {
+	name       string
+	definition Definition
+	sys        *system.Accesser // used for unit tests only
+	mutex      *sync.Mutex
+	*adaptors.AnalogPinsAdaptor
+	*adaptors.DigitalPinsAdaptor
+	*adaptors.PWMPinsAdaptor
+	*adaptors.I2cBusAdaptor
+	*adaptors.SpiBusAdaptor
+	*adaptors.UartBusAdaptor
+	*adaptors.ClassDevicesAdaptor
+	oneWire *adaptors.OneWireBusAdaptor
+}
+
+// NewAdaptor creates a Linux board Adaptor for the given definition. An invalid definition leads to a panic, like
+// an invalid option does.
+//
+// Optional parameters:
+//
+//	adaptors.WithGpioSysfsAccess():	use legacy sysfs driver instead of the access given by the definition
+//	adaptors.WithGpioCdevAccess():	use character device driver instead of the access given by the definition
+//	adaptors.WithSpiCdevAccess():	use native /dev/spidev#.# access instead of periph.io
+//	adaptors.WithSpiGpioAccess(sclk, ncs, sdo, sdi):	use GPIO's instead of /dev/spidev#.#
+//	adaptors.WithGpiosActiveLow(pin's): invert the pin behavior
+//	adaptors.WithGpiosPullUp/Down(pin's): sets the internal pull resistor
+//	adaptors.WithGpiosOpenDrain/Source(pin's): sets the output behavior
+//	adaptors.WithGpioDebounce(pin, period): sets the input debouncer
+//	adaptors.WithGpioEventOnFallingEdge/RaisingEdge/BothEdges(pin, handler): activate edge detection
+//
+//	Optional parameters for PWM, see [adaptors.NewPWMPinsAdaptor]
+func NewAdaptor(def Definition, opts ...interface{}) *Adaptor {
+	if err := def.Validate(); err != nil {
+		panic(err.Error())
+	}
+
+	var sys *system.Accesser
+	if def.GpioAccess == gpioAccessSysfs {
+		sys = system.NewAccesser(system.WithDigitalPinSysfsAccess())
+	} else {
+		sys = system.NewAccesser()
+	}
+
+	a := &Adaptor{
+		name:       gobot.DefaultName(def.Name),
+		definition: def,
+		sys:        sys,
+		mutex:      &sync.Mutex{},
+	}
+
+	var digitalPinsOpts []adaptors.DigitalPinsOptionApplier
+	var pwmPinsOpts []adaptors.PwmPinsOptionApplier
+	var spiBusOpts []adaptors.SpiBusOptionApplier
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case adaptors.DigitalPinsOptionApplier:
+			digitalPinsOpts = append(digitalPinsOpts, o)
+		case adaptors.PwmPinsOptionApplier:
+			pwmPinsOpts = append(pwmPinsOpts, o)
+		case adaptors.SpiBusOptionApplier:
+			spiBusOpts = append(spiBusOpts, o)
+		default:
+			panic(fmt.Sprintf("'%s' can not be applied on adaptor '%s'", opt, a.name))
+		}
+	}
+
+	analogPinTranslator := adaptors.NewAnalogPinTranslator(sys, def.AnalogPinDefinitions())
+	digitalPinTranslator := adaptors.NewDigitalPinTranslator(sys, def.DigitalPinDefinitions())
+	pwmPinTranslator := adaptors.NewPWMPinTranslator(sys, def.PWMPinDefinitions())
+	i2cBusNumberValidator := adaptors.NewBusNumberValidator(def.I2c.Buses)
+	spiBusNumberValidator := adaptors.NewBusNumberValidator(def.Spi.Buses)
+
+	a.ClassDevicesAdaptor = adaptors.NewClassDevicesAdaptor(sys)
+	a.AnalogPinsAdaptor = adaptors.NewAnalogPinsAdaptor(sys, analogPinTranslator.Translate,
+		adaptors.WithAnalogClassDevices(a.ClassDevicesAdaptor))
+	digitalPinsOpts = append(digitalPinsOpts, adaptors.WithDigitalPinClassDevices(a.ClassDevicesAdaptor))
+	a.DigitalPinsAdaptor = adaptors.NewDigitalPinsAdaptor(sys, digitalPinTranslator.Translate, digitalPinsOpts...)
+	pwmPinsOpts = append(pwmPinsOpts, adaptors.WithPWMDigitalPinnerProvider(a.DigitalPinsAdaptor),
+		adaptors.WithPWMClassDevices(a.ClassDevicesAdaptor))
+	a.PWMPinsAdaptor = adaptors.NewPWMPinsAdaptor(sys, pwmPinTranslator.Translate, pwmPinsOpts...)
+	a.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, i2cBusNumberValidator.Validate, def.I2c.DefaultBus)
+	a.SpiBusAdaptor = adaptors.NewSpiBusAdaptor(sys, spiBusNumberValidator.Validate, def.Spi.DefaultBus,
+		def.Spi.DefaultChip, def.Spi.DefaultMode, def.Spi.bitsOrDefault(), def.Spi.maxSpeedOrDefault(),
+		a.DigitalPinsAdaptor, spiBusOpts...)
+	a.UartBusAdaptor = adaptors.NewUartBusAdaptor(sys, def.Uart.Paths, def.Uart.DefaultNumber,
+		def.Uart.baudRateOrDefault())
+
+	// the lines of the bus pins are claimed too, so a pin of an active bus can not be used by its line name
+	a.SetI2cBusPinTranslator(digitalPinTranslator.Translate)
+	a.SetSpiBusPinTranslator(digitalPinTranslator.Translate)
+	for bus, pins := range def.I2c.Pins {
+		a.SetI2cBusPins(bus, pins...)
+	}
+	for bus, pins := range def.Spi.Pins {
+		a.SetSpiBusPins(bus, pins...)
+	}
+
+	if def.OneWire {
+		a.oneWire = adaptors.NewOneWireBusAdaptor(sys)
+	}
+
+	return a
+}
+
+// NewAdaptorFromFile creates a Linux board Adaptor for the definition in the given JSON or YAML file. For optional
+// parameters see [NewAdaptor].
+func NewAdaptorFromFile(path string, opts ...interface{}) (*Adaptor, error) {
+	def, err := LoadDefinition(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAdaptor(*def, opts...), nil
+}
+
+// Name returns the name of the Adaptor
+func (a *Adaptor) Name() string { return a.name }
+
+// SetName sets the name of the Adaptor
+func (a *Adaptor) SetName(n string) { a.name = n }
+
+// Definition returns the board definition, which is used by the Adaptor
+func (a *Adaptor) Definition() Definition { return a.definition }
+
+// Connect create new connection to board and pins.
+func (a *Adaptor) Connect() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.oneWire != nil {
+		if err := a.oneWire.Connect(); err != nil {
+			return err
+		}
+	}
+
+	if err := a.SpiBusAdaptor.Connect(); err != nil {
+		return err
+	}
+
+	if err := a.UartBusAdaptor.Connect(); err != nil {
+		return err
+	}
+
+	if err := a.I2cBusAdaptor.Connect(); err != nil {
+		return err
+	}
+
+	if err := a.AnalogPinsAdaptor.Connect(); err != nil {
+		return err
+	}
+
+	if err := a.PWMPinsAdaptor.Connect(); err != nil {
+		return err
+	}
+
+	if err := a.ClassDevicesAdaptor.Connect(); err != nil {
+		return err
+	}
+
+	return a.DigitalPinsAdaptor.Connect()
+}
+
+// Finalize closes connection to board, pins and bus
+func (a *Adaptor) Finalize() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	// the software PWM pins are based on digital pins, so they need to be finalized before
+	err := a.PWMPinsAdaptor.Finalize()
+
+	if e := a.DigitalPinsAdaptor.Finalize(); e != nil {
+		err = multierror.Append(err, e)
+	}
+
+	if e := a.AnalogPinsAdaptor.Finalize(); e != nil {
+		err = multierror.Append(err, e)
+	}
+
+	if e := a.I2cBusAdaptor.Finalize(); e != nil {
+		err = multierror.Append(err, e)
+	}
+
+	if e := a.SpiBusAdaptor.Finalize(); e != nil {
+		err = multierror.Append(err, e)
+	}
+
+	if e := a.UartBusAdaptor.Finalize(); e != nil {
+		err = multierror.Append(err, e)
+	}
+
+	if a.oneWire != nil {
+		if e := a.oneWire.Finalize(); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+
+	if e := a.ClassDevicesAdaptor.Finalize(); e != nil {
+		err = multierror.Append(err, e)
+	}
+
+	return err
+}
+
+// GetOneWireConnection returns a 1-wire connection to a device with the given family code and serial number. This is
+// only supported, if 1-wire is activated in the board definition.
+func (a *Adaptor) GetOneWireConnection(familyCode byte, serialNumber uint64) (onewire.Connection, error) {
+	if a.oneWire == nil {
+		return nil, fmt.Errorf("1-wire is not supported by board '%s'", a.definition.Name)
+	}
+
+	return a.oneWire.GetOneWireConnection(familyCode, serialNumber)
+}
@@ -0,0 +1,273 @@
+package linuxboard
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	"gobot.io/x/gobot/v2/drivers/aio"
+	"gobot.io/x/gobot/v2/drivers/gpio"
+	"gobot.io/x/gobot/v2/drivers/i2c"
+	"gobot.io/x/gobot/v2/drivers/spi"
+	"gobot.io/x/gobot/v2/platforms/adaptors"
+)
+
+// make sure that this Adaptor fulfills all the required interfaces
+var (
+	_ gobot.Adaptor               = (*Adaptor)(nil)
+	_ gobot.DigitalPinnerProvider = (*Adaptor)(nil)
+	_ gobot.PWMPinnerProvider     = (*Adaptor)(nil)
+	_ gpio.DigitalReader          = (*Adaptor)(nil)
+	_ gpio.DigitalWriter          = (*Adaptor)(nil)
+	_ gpio.GpioInfoProvider       = (*Adaptor)(nil)
+	_ gpio.GpioInfoWatcher        = (*Adaptor)(nil)
+	_ gpio.PwmWriter              = (*Adaptor)(nil)
+	_ gpio.ServoWriter            = (*Adaptor)(nil)
+	_ aio.AnalogReader            = (*Adaptor)(nil)
+	_ i2c.Connector               = (*Adaptor)(nil)
+	_ spi.Connector               = (*Adaptor)(nil)
+)
+
+func initConnectedTestAdaptor(def Definition, opts ...interface{}) *Adaptor {
+	a := NewAdaptor(def, opts...)
+	if err := a.Connect(); err != nil {
+		panic(err)
+	}
+	return a
+}
+
+func TestNewAdaptor(t *testing.T) {
+	// arrange & act
+	a := NewAdaptor(testDefinition)
+	// assert
+	assert.IsType(t, &Adaptor{}, a)
+	assert.True(t, strings.HasPrefix(a.Name(), "My Board"))
+	assert.Equal(t, testDefinition, a.Definition())
+	assert.NotNil(t, a.sys)
+	assert.NotNil(t, a.mutex)
+	assert.NotNil(t, a.AnalogPinsAdaptor)
+	assert.NotNil(t, a.DigitalPinsAdaptor)
+	assert.NotNil(t, a.PWMPinsAdaptor)
+	assert.NotNil(t, a.I2cBusAdaptor)
+	assert.NotNil(t, a.SpiBusAdaptor)
+	assert.NotNil(t, a.UartBusAdaptor)
+	assert.NotNil(t, a.oneWire)
+	assert.True(t, a.sys.HasDigitalPinSysfsAccess())
+	assert.Equal(t, 1, a.DefaultI2cBus())
+	assert.Equal(t, 0, a.SpiDefaultBusNumber())
+	assert.Equal(t, 8, a.SpiDefaultBitCount())
+	assert.Equal(t, int64(500000), a.SpiDefaultMaxSpeed())
+	assert.Equal(t, 1, a.DefaultUartNumber())
+	assert.Equal(t, 115200, a.DefaultUartBaudRate())
+	// act & assert
+	a.SetName("NewName")
+	assert.Equal(t, "NewName", a.Name())
+}
+
+func TestNewAdaptorWithOption(t *testing.T) {
+	// arrange
+	def := testDefinition
+	def.GpioAccess = ""
+	// act
+	a := NewAdaptor(def, adaptors.WithGpioSysfsAccess())
+	// assert
+	require.NoError(t, a.Connect())
+	assert.True(t, a.sys.HasDigitalPinSysfsAccess())
+}
+
+func TestNewAdaptorPanics(t *testing.T) {
+	// arrange
+	def := testDefinition
+	def.I2c.Buses = nil
+	// act & assert
+	assert.PanicsWithValue(t, "default I2C bus 1 given, but no I2C bus defined for board 'My Board'",
+		func() { NewAdaptor(def) })
+	assert.Panics(t, func() { NewAdaptor(testDefinition, "unknown option") })
+}
+
+func TestNewAdaptorFromFile(t *testing.T) {
+	// arrange
+	path := filepath.Join(t.TempDir(), "board.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testDefinitionYAML), 0o600))
+	// act
+	a, err := NewAdaptorFromFile(path)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, testDefinition, a.Definition())
+	// act & assert error
+	_, err = NewAdaptorFromFile(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
+
+func TestDigitalIO(t *testing.T) {
+	// some basic tests, further tests are done in "digitalpinsadaptor.go"
+	// arrange
+	def := testDefinition
+	def.GpioAccess = ""
+	a := initConnectedTestAdaptor(def)
+	dpa := a.sys.UseMockDigitalPinAccess()
+	require.True(t, a.sys.HasDigitalPinCdevAccess())
+	// act & assert write
+	require.NoError(t, a.DigitalWrite("7", 1))
+	assert.Equal(t, []int{1}, dpa.Written("gpiochip0", "17"))
+	// act and assert unknown pin
+	require.ErrorContains(t, a.DigitalWrite("99", 1), "'99' is not a valid id for a digital pin")
+	// act and assert finalize
+	require.NoError(t, a.Finalize())
+	assert.Equal(t, 0, dpa.Exported("gpiochip0", "17"))
+}
+
+func TestDigitalIOSysfs(t *testing.T) {
+	// arrange
+	a := initConnectedTestAdaptor(testDefinition)
+	dpa := a.sys.UseMockDigitalPinAccess()
+	// act & assert
+	dpa.UseValues("", "17", []int{1})
+	val, err := a.DigitalRead("7")
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+	require.NoError(t, a.Finalize())
+}
+
+func TestPWMPin(t *testing.T) {
+	// arrange
+	const pwmDir = "/sys/class/pwm/pwmchip0/"
+	a := initConnectedTestAdaptor(testDefinition)
+	fs := a.sys.UseMockFilesystem([]string{
+		pwmDir + "export",
+		pwmDir + "unexport",
+		pwmDir + "pwm1/enable",
+		pwmDir + "pwm1/period",
+		pwmDir + "pwm1/duty_cycle",
+		pwmDir + "pwm1/polarity",
+	})
+	fs.Files[pwmDir+"pwm1/period"].Contents = "0"
+	fs.Files[pwmDir+"pwm1/duty_cycle"].Contents = "0"
+	fs.Files[pwmDir+"pwm1/polarity"].Contents = "normal"
+	// act
+	err := a.PwmWrite("33", 100)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "1", fs.Files[pwmDir+"export"].Contents)
+	assert.Equal(t, "1", fs.Files[pwmDir+"pwm1/enable"].Contents)
+}
+
+func TestAnalogRead(t *testing.T) {
+	// arrange
+	const path = "/sys/class/thermal/thermal_zone0/temp"
+	a := initConnectedTestAdaptor(testDefinition)
+	fs := a.sys.UseMockFilesystem([]string{path})
+	fs.Files[path].Contents = "567\n"
+	// act
+	got, err := a.AnalogRead("thermal")
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 567, got)
+	_, err = a.AnalogRead("thermal_zone10")
+	require.ErrorContains(t, err, "'thermal_zone10' is not a valid id for an analog pin")
+}
+
+func TestI2cBusPinsOwnership(t *testing.T) {
+	// arrange
+	a := initConnectedTestAdaptor(testDefinition)
+	require.NoError(t, a.ClaimDigitalPin("LED", "3"))
+	// act
+	err := a.ClaimI2cAddress("BMP280", 1, 0x77)
+	// assert
+	require.ErrorContains(t, err, "I2C bus 1 can not be used by 'BMP280'")
+	require.NoError(t, a.ClaimI2cAddress("BMP280", 0, 0x77))
+}
+
+func TestWithoutBuses(t *testing.T) {
+	// arrange
+	def := testDefinition
+	def.I2c = I2cDefinition{}
+	def.Spi = SpiDefinition{}
+	a := initConnectedTestAdaptor(def)
+	// act
+	i2cCon, i2cErr := a.GetI2cConnection(0x77, 0)
+	spiCon, spiErr := a.GetSpiConnection(0, 0, 0, 8, 500000)
+	// assert
+	require.ErrorContains(t, i2cErr, "Bus number 0 out of range")
+	assert.Nil(t, i2cCon)
+	require.ErrorContains(t, spiErr, "Bus number 0 out of range")
+	assert.Nil(t, spiCon)
+	require.NoError(t, a.Finalize())
+}
+
+func TestUart(t *testing.T) {
+	// arrange
+	a := NewAdaptor(testDefinition)
+	mua := a.sys.UseMockUart()
+	require.NoError(t, a.Connect())
+	con, err := a.GetUartConnection(a.DefaultUartNumber(), 9600)
+	require.NoError(t, err)
+	require.NoError(t, con.Connect())
+	// act
+	_, err = con.SerialWrite([]byte{0x01, 0x02})
+	// assert
+	require.NoError(t, err)
+	dev := mua.Devices["/dev/ttyS1"]
+	assert.Equal(t, 9600, dev.BaudRate)
+	assert.Equal(t, []byte{0x01, 0x02}, dev.Written())
+	_, err = a.GetUartConnection(0, 0)
+	require.EqualError(t, err, "UART number 0 out of range [1]")
+	require.NoError(t, a.Finalize())
+	assert.True(t, dev.Closed)
+}
+
+func TestOneWire(t *testing.T) {
+	// arrange
+	def := testDefinition
+	def.OneWire = false
+	a := initConnectedTestAdaptor(def)
+	// act
+	_, err := a.GetOneWireConnection(0x28, 1)
+	// assert
+	require.EqualError(t, err, "1-wire is not supported by board 'My Board'")
+	require.NoError(t, a.Finalize())
+	// arrange with 1-wire
+	a = initConnectedTestAdaptor(testDefinition)
+	a.sys.UseMockFilesystem([]string{"/sys/bus/w1/devices/28-0000000001/"})
+	// act
+	con, err := a.GetOneWireConnection(0x28, 1)
+	// assert
+	require.NoError(t, err)
+	assert.NotNil(t, con)
+	require.NoError(t, a.Finalize())
+}
+
+func TestFinalizeErrorAfterGPIO(t *testing.T) {
+	// arrange
+	def := testDefinition
+	def.GpioAccess = "cdev"
+	a := initConnectedTestAdaptor(def)
+	dpa := a.sys.UseMockDigitalPinAccess()
+	require.NoError(t, a.DigitalWrite("7", 1))
+	dpa.UseUnexportError("gpiochip0", "17")
+	// act
+	err := a.Finalize()
+	// assert
+	require.ErrorContains(t, err, "unexport error")
+}
+
+func TestI2cFinalizeWithErrors(t *testing.T) {
+	// arrange
+	a := initConnectedTestAdaptor(testDefinition)
+	a.sys.UseMockSyscall()
+	fs := a.sys.UseMockFilesystem([]string{"/dev/i2c-1"})
+	con, err := a.GetI2cConnection(0xff, 1)
+	require.NoError(t, err)
+	_, err = con.Write([]byte{0xbf})
+	require.NoError(t, err)
+	fs.WithCloseError = true
+	// act
+	err = a.Finalize()
+	// assert
+	require.ErrorContains(t, err, "close error")
+}
@@ -0,0 +1,49 @@
+package linuxboard
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// boardFiles contains the definitions of well known boards, the file name is the board name
+//
+//go:embed boards/*.yaml
+var boardFiles embed.FS
+
+const boardFilesDir = "boards"
+
+// Boards returns the names of all embedded board definitions, sorted by name.
+func Boards() []string {
+	entries, _ := boardFiles.ReadDir(boardFilesDir)
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), ".yaml"))
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// BoardDefinition returns the embedded definition of the board with the given name, see [Boards].
+func BoardDefinition(board string) (*Definition, error) {
+	data, err := boardFiles.ReadFile(path.Join(boardFilesDir, board+".yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("unknown board '%s', known boards are %v", board, Boards())
+	}
+
+	return ParseYAMLDefinition(data)
+}
+
+// NewAdaptorForBoard creates a Linux board Adaptor for the embedded definition of the board with the given name. For
+// optional parameters see [NewAdaptor].
+func NewAdaptorForBoard(board string, opts ...interface{}) (*Adaptor, error) {
+	def, err := BoardDefinition(board)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAdaptor(*def, opts...), nil
+}
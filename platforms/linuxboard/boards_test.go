@@ -0,0 +1,53 @@
+package linuxboard
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoards(t *testing.T) {
+	// act
+	got := Boards()
+	// assert
+	assert.Equal(t, []string{
+		"jetson-nano", "nanopct6", "nanopi-neo", "orangepi5pro", "radxa-zero", "rock64", "rockpi4", "rockpi4c-plus",
+		"tinkerboard", "tinkerboard2",
+	}, got)
+}
+
+func TestBoardDefinitionsAreValid(t *testing.T) {
+	for _, board := range Boards() {
+		t.Run(board, func(t *testing.T) {
+			// act
+			def, err := BoardDefinition(board)
+			// assert
+			require.NoError(t, err)
+			assert.NotEmpty(t, def.DigitalPins)
+		})
+	}
+}
+
+func TestNewAdaptorForBoard(t *testing.T) {
+	// act
+	a, err := NewAdaptorForBoard("tinkerboard")
+	// assert
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(a.Name(), "Tinker Board"))
+	assert.Equal(t, 1, a.DefaultI2cBus())
+	require.NoError(t, a.Connect())
+	dpa := a.sys.UseMockDigitalPinAccess()
+	require.NoError(t, a.DigitalWrite("7", 1))
+	assert.Equal(t, []int{1}, dpa.Written("gpiochip0", "17"))
+	require.NoError(t, a.Finalize())
+}
+
+func TestNewAdaptorForUnknownBoard(t *testing.T) {
+	// act
+	a, err := NewAdaptorForBoard("unknown")
+	// assert
+	require.ErrorContains(t, err, "unknown board 'unknown', known boards are [jetson-nano")
+	assert.Nil(t, a)
+}
@@ -0,0 +1,269 @@
+package linuxboard
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"gobot.io/x/gobot/v2/platforms/adaptors"
+)
+
+const (
+	gpioAccessCdev  = "cdev"
+	gpioAccessSysfs = "sysfs"
+
+	defaultSpiBitsNumber = 8
+	defaultSpiMaxSpeed   = 500000
+
+	defaultUartBaudRate = 115200
+)
+
+// Definition describes a board with all its pins and buses. It can be loaded from a JSON or YAML file.
+type Definition struct {
+	Name string `json:"name" yaml:"name"`
+	// GpioAccess is the default access to digital pins, "cdev" (default) or "sysfs"
+	GpioAccess  string                `json:"gpioAccess,omitempty" yaml:"gpioAccess,omitempty"`
+	DigitalPins map[string]DigitalPin `json:"digitalPins,omitempty" yaml:"digitalPins,omitempty"`
+	PWMPins     map[string]PWMPin     `json:"pwmPins,omitempty" yaml:"pwmPins,omitempty"`
+	AnalogPins  map[string]AnalogPin  `json:"analogPins,omitempty" yaml:"analogPins,omitempty"`
+	I2c         I2cDefinition         `json:"i2c" yaml:"i2c"`
+	Spi         SpiDefinition         `json:"spi" yaml:"spi"`
+	Uart        UartDefinition        `json:"uart,omitempty" yaml:"uart,omitempty"`
+	// OneWire activates the access to 1-wire devices by the kernel driver (w1-gpio)
+	OneWire bool `json:"oneWire,omitempty" yaml:"oneWire,omitempty"`
+}
+
+// DigitalPin describes a digital pin of the board header by the line of a gpiochip and the legacy sysfs number.
+type DigitalPin struct {
+	Sysfs int   `json:"sysfs" yaml:"sysfs"`
+	Chip  uint8 `json:"chip" yaml:"chip"`
+	Line  uint8 `json:"line" yaml:"line"`
+}
+
+// PWMPin describes a PWM pin of the board header by the sysfs directory of the pwmchip and the channel.
+type PWMPin struct {
+	Dir       string `json:"dir" yaml:"dir"`
+	DirRegexp string `json:"dirRegexp" yaml:"dirRegexp"`
+	Channel   int    `json:"channel" yaml:"channel"`
+}
+
+// AnalogPin describes an analog value, e.g. a thermal zone, by the sysfs path.
+type AnalogPin struct {
+	Path       string `json:"path" yaml:"path"`
+	Writable   bool   `json:"writable,omitempty" yaml:"writable,omitempty"`
+	ReadBufLen uint16 `json:"readBufLen,omitempty" yaml:"readBufLen,omitempty"`
+}
+
+// I2cDefinition describes the valid I2C buses of the board. Without buses, the access to any I2C bus fails.
+type I2cDefinition struct {
+	Buses      []int `json:"buses" yaml:"buses"`
+	DefaultBus int   `json:"defaultBus" yaml:"defaultBus"`
+	// Pins are the header pins used by a bus, which is needed for the detection of ownership conflicts
+	Pins map[int][]string `json:"pins,omitempty" yaml:"pins,omitempty"`
+}
+
+// SpiDefinition describes the valid SPI buses of the board and the defaults for the devices. Without buses, the
+// access to any SPI bus fails.
+type SpiDefinition struct {
+	Buses       []int `json:"buses" yaml:"buses"`
+	DefaultBus  int   `json:"defaultBus" yaml:"defaultBus"`
+	DefaultChip int   `json:"defaultChip,omitempty" yaml:"defaultChip,omitempty"`
+	DefaultMode int   `json:"defaultMode,omitempty" yaml:"defaultMode,omitempty"`
+	// DefaultBits defaults to 8, if not given
+	DefaultBits int `json:"defaultBits,omitempty" yaml:"defaultBits,omitempty"`
+	// DefaultMaxSpeed defaults to 500kHz, if not given
+	DefaultMaxSpeed int64 `json:"defaultMaxSpeed,omitempty" yaml:"defaultMaxSpeed,omitempty"`
+	// Pins are the header pins used by a bus, which is needed for the detection of ownership conflicts
+	Pins map[int][]string `json:"pins,omitempty" yaml:"pins,omitempty"`
+}
+
+// UartDefinition describes the valid UART's of the board. Without paths, the access to any UART fails.
+type UartDefinition struct {
+	// Paths maps the UART numbers of the board to the character devices, e.g. {0: "/dev/ttyS0"}
+	Paths         map[int]string `json:"paths,omitempty" yaml:"paths,omitempty"`
+	DefaultNumber int            `json:"defaultNumber,omitempty" yaml:"defaultNumber,omitempty"`
+	// DefaultBaudRate defaults to 115200, if not given
+	DefaultBaudRate int `json:"defaultBaudRate,omitempty" yaml:"defaultBaudRate,omitempty"`
+}
+
+// LoadDefinition reads the board definition from the given file. Files with extension ".json" are parsed as JSON,
+// all others as YAML.
+func LoadDefinition(path string) (*Definition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return ParseJSONDefinition(data)
+	}
+
+	return ParseYAMLDefinition(data)
+}
+
+// ParseJSONDefinition creates the board definition from the given JSON content. Unknown fields are rejected.
+func ParseJSONDefinition(data []byte) (*Definition, error) {
+	var def Definition
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&def); err != nil {
+		return nil, fmt.Errorf("invalid JSON board definition: %w", err)
+	}
+
+	if err := def.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &def, nil
+}
+
+// ParseYAMLDefinition creates the board definition from the given YAML content. Unknown fields are rejected.
+func ParseYAMLDefinition(data []byte) (*Definition, error) {
+	var def Definition
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&def); err != nil {
+		return nil, fmt.Errorf("invalid YAML board definition: %w", err)
+	}
+
+	if err := def.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &def, nil
+}
+
+// Validate checks the consistency of the definition.
+func (d *Definition) Validate() error {
+	if d.Name == "" {
+		return fmt.Errorf("the board definition needs a name")
+	}
+
+	switch d.GpioAccess {
+	case "", gpioAccessCdev, gpioAccessSysfs:
+	default:
+		return fmt.Errorf("unknown GPIO access '%s' for board '%s', use '%s' or '%s'", d.GpioAccess, d.Name,
+			gpioAccessCdev, gpioAccessSysfs)
+	}
+
+	if err := validateBuses("I2C", d.I2c.Buses, d.I2c.DefaultBus, d.I2c.Pins); err != nil {
+		return fmt.Errorf("%w for board '%s'", err, d.Name)
+	}
+
+	if err := validateBuses("SPI", d.Spi.Buses, d.Spi.DefaultBus, d.Spi.Pins); err != nil {
+		return fmt.Errorf("%w for board '%s'", err, d.Name)
+	}
+
+	if _, ok := d.Uart.Paths[d.Uart.DefaultNumber]; len(d.Uart.Paths) > 0 && !ok {
+		return fmt.Errorf("default UART %d is not in the list of UART's for board '%s'", d.Uart.DefaultNumber, d.Name)
+	}
+
+	for id, pin := range d.PWMPins {
+		if pin.Dir == "" {
+			return fmt.Errorf("PWM pin '%s' of board '%s' needs a directory", id, d.Name)
+		}
+	}
+
+	for id, pin := range d.AnalogPins {
+		if pin.Path == "" {
+			return fmt.Errorf("analog pin '%s' of board '%s' needs a path", id, d.Name)
+		}
+	}
+
+	return nil
+}
+
+// DigitalPinDefinitions returns the digital pins in the format of the adaptors package.
+func (d *Definition) DigitalPinDefinitions() adaptors.DigitalPinDefinitions {
+	defs := make(adaptors.DigitalPinDefinitions, len(d.DigitalPins))
+	for id, pin := range d.DigitalPins {
+		defs[id] = adaptors.DigitalPinDefinition{Sysfs: pin.Sysfs, Cdev: adaptors.CdevPin{Chip: pin.Chip, Line: pin.Line}}
+	}
+
+	return defs
+}
+
+// PWMPinDefinitions returns the PWM pins in the format of the adaptors package.
+func (d *Definition) PWMPinDefinitions() adaptors.PWMPinDefinitions {
+	defs := make(adaptors.PWMPinDefinitions, len(d.PWMPins))
+	for id, pin := range d.PWMPins {
+		defs[id] = adaptors.PWMPinDefinition{Dir: pin.Dir, DirRegexp: pin.DirRegexp, Channel: pin.Channel}
+	}
+
+	return defs
+}
+
+// AnalogPinDefinitions returns the analog pins in the format of the adaptors package.
+func (d *Definition) AnalogPinDefinitions() adaptors.AnalogPinDefinitions {
+	defs := make(adaptors.AnalogPinDefinitions, len(d.AnalogPins))
+	for id, pin := range d.AnalogPins {
+		defs[id] = adaptors.AnalogPinDefinition{Path: pin.Path, W: pin.Writable, ReadBufLen: pin.ReadBufLen}
+	}
+
+	return defs
+}
+
+func (s SpiDefinition) bitsOrDefault() int {
+	if s.DefaultBits == 0 {
+		return defaultSpiBitsNumber
+	}
+
+	return s.DefaultBits
+}
+
+func (s SpiDefinition) maxSpeedOrDefault() int64 {
+	if s.DefaultMaxSpeed == 0 {
+		return defaultSpiMaxSpeed
+	}
+
+	return s.DefaultMaxSpeed
+}
+
+func (u UartDefinition) baudRateOrDefault() int {
+	if u.DefaultBaudRate == 0 {
+		return defaultUartBaudRate
+	}
+
+	return u.DefaultBaudRate
+}
+
+func validateBuses(kind string, buses []int, defaultBus int, pins map[int][]string) error {
+	// a board without buses is valid, the access to any bus will fail in this case
+	if len(buses) == 0 && defaultBus != 0 {
+		return fmt.Errorf("default %s bus %d given, but no %s bus defined", kind, defaultBus, kind)
+	}
+
+	if len(buses) > 0 && !containsBus(buses, defaultBus) {
+		return fmt.Errorf("default %s bus %d is not in the list of buses %v", kind, defaultBus, buses)
+	}
+
+	busNumbers := make([]int, 0, len(pins))
+	for bus := range pins {
+		busNumbers = append(busNumbers, bus)
+	}
+	sort.Ints(busNumbers)
+
+	for _, bus := range busNumbers {
+		if !containsBus(buses, bus) {
+			return fmt.Errorf("pins given for unknown %s bus %d", kind, bus)
+		}
+	}
+
+	return nil
+}
+
+func containsBus(buses []int, bus int) bool {
+	for _, b := range buses {
+		if b == bus {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,207 @@
+package linuxboard
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDefinitionYAML = `
+name: "My Board"
+gpioAccess: sysfs
+digitalPins:
+  "7": { sysfs: 17, chip: 0, line: 17 }
+pwmPins:
+  "33": { dir: "/sys/class/pwm/", dirRegexp: "pwmchip0$", channel: 1 }
+analogPins:
+  thermal: { path: "/sys/class/thermal/thermal_zone0/temp", readBufLen: 7 }
+i2c:
+  buses: [0, 1]
+  defaultBus: 1
+  pins:
+    1: ["3", "5"]
+spi:
+  buses: [0]
+  defaultBus: 0
+uart:
+  paths:
+    1: "/dev/ttyS1"
+  defaultNumber: 1
+oneWire: true
+`
+
+const testDefinitionJSON = `{
+  "name": "My Board",
+  "gpioAccess": "sysfs",
+  "digitalPins": {"7": {"sysfs": 17, "chip": 0, "line": 17}},
+  "pwmPins": {"33": {"dir": "/sys/class/pwm/", "dirRegexp": "pwmchip0$", "channel": 1}},
+  "analogPins": {"thermal": {"path": "/sys/class/thermal/thermal_zone0/temp", "readBufLen": 7}},
+  "i2c": {"buses": [0, 1], "defaultBus": 1, "pins": {"1": ["3", "5"]}},
+  "spi": {"buses": [0], "defaultBus": 0},
+  "uart": {"paths": {"1": "/dev/ttyS1"}, "defaultNumber": 1},
+  "oneWire": true
+}`
+
+var testDefinition = Definition{
+	Name:        "My Board",
+	GpioAccess:  "sysfs",
+	DigitalPins: map[string]DigitalPin{"7": {Sysfs: 17, Chip: 0, Line: 17}},
+	PWMPins:     map[string]PWMPin{"33": {Dir: "/sys/class/pwm/", DirRegexp: "pwmchip0$", Channel: 1}},
+	AnalogPins:  map[string]AnalogPin{"thermal": {Path: "/sys/class/thermal/thermal_zone0/temp", ReadBufLen: 7}},
+	I2c:         I2cDefinition{Buses: []int{0, 1}, DefaultBus: 1, Pins: map[int][]string{1: {"3", "5"}}},
+	Spi:         SpiDefinition{Buses: []int{0}, DefaultBus: 0},
+	Uart:        UartDefinition{Paths: map[int]string{1: "/dev/ttyS1"}, DefaultNumber: 1},
+	OneWire:     true,
+}
+
+func TestLoadDefinition(t *testing.T) {
+	tests := map[string]struct {
+		fileName string
+		content  string
+		wantErr  string
+	}{
+		"yaml": {
+			fileName: "board.yaml",
+			content:  testDefinitionYAML,
+		},
+		"json": {
+			fileName: "board.JSON",
+			content:  testDefinitionJSON,
+		},
+		"error_unknown_yaml_field": {
+			fileName: "board.yml",
+			content:  testDefinitionYAML + "unknown: 1\n",
+			wantErr: "invalid YAML board definition: yaml: unmarshal errors:\n" +
+				"  line 23: field unknown not found in type linuxboard.Definition",
+		},
+		"error_unknown_json_field": {
+			fileName: "board.json",
+			content:  `{"name": "My Board", "unknown": 1}`,
+			wantErr:  "invalid JSON board definition: json: unknown field \"unknown\"",
+		},
+		"error_invalid": {
+			fileName: "board.yaml",
+			content:  "name: My Board\ni2c:\n  defaultBus: 1\n",
+			wantErr:  "default I2C bus 1 given, but no I2C bus defined for board 'My Board'",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			path := filepath.Join(t.TempDir(), tc.fileName)
+			require.NoError(t, os.WriteFile(path, []byte(tc.content), 0o600))
+			// act
+			got, err := LoadDefinition(path)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testDefinition, *got)
+		})
+	}
+}
+
+func TestLoadDefinitionMissingFile(t *testing.T) {
+	// act
+	_, err := LoadDefinition(filepath.Join(t.TempDir(), "missing.yaml"))
+	// assert
+	require.ErrorContains(t, err, "no such file or directory")
+}
+
+func TestDefinitionValidate(t *testing.T) {
+	tests := map[string]struct {
+		modify  func(d *Definition)
+		wantErr string
+	}{
+		"valid": {
+			modify: func(*Definition) {},
+		},
+		"error_no_name": {
+			modify:  func(d *Definition) { d.Name = "" },
+			wantErr: "the board definition needs a name",
+		},
+		"error_gpio_access": {
+			modify:  func(d *Definition) { d.GpioAccess = "mmap" },
+			wantErr: "unknown GPIO access 'mmap' for board 'My Board', use 'cdev' or 'sysfs'",
+		},
+		"error_i2c_default_bus": {
+			modify:  func(d *Definition) { d.I2c.DefaultBus = 2 },
+			wantErr: "default I2C bus 2 is not in the list of buses [0 1] for board 'My Board'",
+		},
+		"no_buses": {
+			modify: func(d *Definition) {
+				d.I2c = I2cDefinition{}
+				d.Spi = SpiDefinition{}
+			},
+		},
+		"error_spi_no_bus_but_default": {
+			modify:  func(d *Definition) { d.Spi = SpiDefinition{DefaultBus: 1} },
+			wantErr: "default SPI bus 1 given, but no SPI bus defined for board 'My Board'",
+		},
+		"error_spi_no_bus_but_pins": {
+			modify:  func(d *Definition) { d.Spi = SpiDefinition{Pins: map[int][]string{0: {"19"}}} },
+			wantErr: "pins given for unknown SPI bus 0 for board 'My Board'",
+		},
+		"error_spi_pins_unknown_bus": {
+			modify:  func(d *Definition) { d.Spi.Pins = map[int][]string{3: {"19"}} },
+			wantErr: "pins given for unknown SPI bus 3 for board 'My Board'",
+		},
+		"no_uart": {
+			modify: func(d *Definition) { d.Uart = UartDefinition{} },
+		},
+		"error_uart_default_number": {
+			modify:  func(d *Definition) { d.Uart.DefaultNumber = 0 },
+			wantErr: "default UART 0 is not in the list of UART's for board 'My Board'",
+		},
+		"error_pwm_dir": {
+			modify:  func(d *Definition) { d.PWMPins = map[string]PWMPin{"33": {Channel: 1}} },
+			wantErr: "PWM pin '33' of board 'My Board' needs a directory",
+		},
+		"error_analog_path": {
+			modify:  func(d *Definition) { d.AnalogPins = map[string]AnalogPin{"thermal": {}} },
+			wantErr: "analog pin 'thermal' of board 'My Board' needs a path",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			def := testDefinition
+			tc.modify(&def)
+			// act
+			err := def.Validate()
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSpiDefinitionDefaults(t *testing.T) {
+	// arrange
+	s := SpiDefinition{}
+	// act & assert
+	assert.Equal(t, 8, s.bitsOrDefault())
+	assert.Equal(t, int64(500000), s.maxSpeedOrDefault())
+	s.DefaultBits = 16
+	s.DefaultMaxSpeed = 1000
+	assert.Equal(t, 16, s.bitsOrDefault())
+	assert.Equal(t, int64(1000), s.maxSpeedOrDefault())
+}
+
+func TestUartDefinitionDefaults(t *testing.T) {
+	// arrange
+	u := UartDefinition{}
+	// act & assert
+	assert.Equal(t, 115200, u.baudRateOrDefault())
+	u.DefaultBaudRate = 9600
+	assert.Equal(t, 9600, u.baudRateOrDefault())
+}
@@ -0,0 +1,7 @@
+/*
+Package linuxboard contains the Gobot adaptor for Linux boards, which are described by a board definition file.
+
+For further information refer to linuxboard README:
+https://github.com/hybridgroup/gobot/blob/release/platforms/linuxboard/README.md
+*/
+package linuxboard // import "gobot.io/x/gobot/v2/platforms/linuxboard"
@@ -1,17 +1,20 @@
+// Code generated by mavgen from common.xml. DO NOT EDIT.
+
 //nolint:dupl,gocritic,lll // seems to be useful here
 package mavlink
 
 //
 // MAVLink comm protocol generated from common.xml
-// http://qgroundcontrol.org/mavlink/
+// https://mavlink.io/
 //
 import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"reflect"
 )
 
-var messages = map[uint8]MAVLinkMessage{
+var messages = map[uint32]MAVLinkMessage{
 	0:   &Heartbeat{},
 	1:   &SysStatus{},
 	2:   &SystemTime{},
@@ -51,7 +54,7 @@ var messages = map[uint8]MAVLinkMessage{
 	48:  &SetGpsGlobalOrigin{},
 	49:  &GpsGlobalOrigin{},
 	50:  &SetLocalPositionSetpoint{},
-	51:  &LocalPositionSetpoint{},
+	51:  &MissionRequestInt{},
 	52:  &GlobalPositionSetpointInt{},
 	53:  &SetGlobalPositionSetpointInt{},
 	54:  &SafetySetAllowedArea{},
@@ -70,6 +73,7 @@ var messages = map[uint8]MAVLinkMessage{
 	67:  &DataStream{},
 	69:  &ManualControl{},
 	70:  &RcChannelsOverride{},
+	73:  &MissionItemInt{},
 	74:  &VfrHud{},
 	76:  &CommandLong{},
 	77:  &CommandAck{},
@@ -130,11 +134,13 @@ var messages = map[uint8]MAVLinkMessage{
 }
 
 // NewMAVLinkMessage returns a new MAVLinkMessage or an error if it encounters an unknown Message ID
-func NewMAVLinkMessage(msgid uint8, data []byte) (MAVLinkMessage, error) {
-	message := messages[msgid]
-	if message != nil {
-		message.Decode(data)
-		return message, nil
+func NewMAVLinkMessage(msgid uint32, data []byte) (MAVLinkMessage, error) {
+	if message := messages[msgid]; message != nil {
+		// a new message for each call, because the messages are passed to concurrent event handlers
+		//nolint:forcetypeassert // ok here
+		m := reflect.New(reflect.TypeOf(message).Elem()).Interface().(MAVLinkMessage)
+		m.Decode(data)
+		return m, nil
 	}
 	return nil, fmt.Errorf("Unknown Message ID: %v", msgid)
 }
@@ -214,12 +220,12 @@ const (
 const (
 	MAV_MODE_FLAG_DECODE_POSITION_CUSTOM_MODE = 1   // Eighth bit: 00000001 |
 	MAV_MODE_FLAG_DECODE_POSITION_TEST        = 2   // Seventh bit: 00000010 |
-	MAV_MODE_FLAG_DECODE_POSITION_AUTO        = 4   // Sixt bit:   00000100 |
-	MAV_MODE_FLAG_DECODE_POSITION_GUIDED      = 8   // Fifth bit:  00001000 |
+	MAV_MODE_FLAG_DECODE_POSITION_AUTO        = 4   // Sixt bit: 00000100 |
+	MAV_MODE_FLAG_DECODE_POSITION_GUIDED      = 8   // Fifth bit: 00001000 |
 	MAV_MODE_FLAG_DECODE_POSITION_STABILIZE   = 16  // Fourth bit: 00010000 |
-	MAV_MODE_FLAG_DECODE_POSITION_HIL         = 32  // Third bit:  00100000 |
+	MAV_MODE_FLAG_DECODE_POSITION_HIL         = 32  // Third bit: 00100000 |
 	MAV_MODE_FLAG_DECODE_POSITION_MANUAL      = 64  // Second bit: 01000000 |
-	MAV_MODE_FLAG_DECODE_POSITION_SAFETY      = 128 // First bit:  10000000 |
+	MAV_MODE_FLAG_DECODE_POSITION_SAFETY      = 128 // First bit: 10000000 |
 	MAV_MODE_FLAG_DECODE_POSITION_ENUM_END    = 129 //  |
 )
 
@@ -424,15 +430,15 @@ const (
 	MAV_CMD_NAV_GUIDED_ENABLE            = 92  // hand control over to an external controller | On / Off (> 0.5f on) | Empty | Empty | Empty | Empty | Empty | Empty |
 	MAV_CMD_NAV_LAST                     = 95  // NOP - This command is only used to mark the upper limit of the NAV/ACTION commands in the enumeration | Empty | Empty | Empty | Empty | Empty | Empty | Empty |
 	MAV_CMD_CONDITION_DELAY              = 112 // Delay mission state machine. | Delay in seconds (decimal) | Empty | Empty | Empty | Empty | Empty | Empty |
-	MAV_CMD_CONDITION_CHANGE_ALT         = 113 // Ascend/descend at rate.  Delay mission state machine until desired altitude reached. | Descent / Ascend rate (m/s) | Empty | Empty | Empty | Empty | Empty | Finish Altitude |
+	MAV_CMD_CONDITION_CHANGE_ALT         = 113 // Ascend/descend at rate. Delay mission state machine until desired altitude reached. | Descent / Ascend rate (m/s) | Empty | Empty | Empty | Empty | Empty | Finish Altitude |
 	MAV_CMD_CONDITION_DISTANCE           = 114 // Delay mission state machine until within desired distance of next NAV point. | Distance (meters) | Empty | Empty | Empty | Empty | Empty | Empty |
 	MAV_CMD_CONDITION_YAW                = 115 // Reach a certain target angle. | target angle: [0-360], 0 is north | speed during yaw change:[deg per second] | direction: negative: counter clockwise, positive: clockwise [-1,1] | relative offset or absolute angle: [ 1,0] | Empty | Empty | Empty |
 	MAV_CMD_CONDITION_LAST               = 159 // NOP - This command is only used to mark the upper limit of the CONDITION commands in the enumeration | Empty | Empty | Empty | Empty | Empty | Empty | Empty |
 	MAV_CMD_DO_SET_MODE                  = 176 // Set system mode. | Mode, as defined by ENUM MAV_MODE | Custom mode - this is system specific, please refer to the individual autopilot specifications for details. | Empty | Empty | Empty | Empty | Empty |
-	MAV_CMD_DO_JUMP                      = 177 // Jump to the desired command in the mission list.  Repeat this action only the specified number of times | Sequence number | Repeat count | Empty | Empty | Empty | Empty | Empty |
-	MAV_CMD_DO_CHANGE_SPEED              = 178 // Change speed and/or throttle set points. | Speed type (0=Airspeed, 1=Ground Speed) | Speed  (m/s, -1 indicates no change) | Throttle  ( Percent, -1 indicates no change) | Empty | Empty | Empty | Empty |
+	MAV_CMD_DO_JUMP                      = 177 // Jump to the desired command in the mission list. Repeat this action only the specified number of times | Sequence number | Repeat count | Empty | Empty | Empty | Empty | Empty |
+	MAV_CMD_DO_CHANGE_SPEED              = 178 // Change speed and/or throttle set points. | Speed type (0=Airspeed, 1=Ground Speed) | Speed (m/s, -1 indicates no change) | Throttle ( Percent, -1 indicates no change) | Empty | Empty | Empty | Empty |
 	MAV_CMD_DO_SET_HOME                  = 179 // Changes the home location either to the current location or a specified location. | Use current (1=use current location, 0=use specified location) | Empty | Empty | Empty | Latitude | Longitude | Altitude |
-	MAV_CMD_DO_SET_PARAMETER             = 180 // Set a system parameter.  Caution!  Use of this command requires knowledge of the numeric enumeration value of the parameter. | Parameter number | Parameter value | Empty | Empty | Empty | Empty | Empty |
+	MAV_CMD_DO_SET_PARAMETER             = 180 // Set a system parameter. Caution! Use of this command requires knowledge of the numeric enumeration value of the parameter. | Parameter number | Parameter value | Empty | Empty | Empty | Empty | Empty |
 	MAV_CMD_DO_SET_RELAY                 = 181 // Set a relay to a condition. | Relay number | Setting (1=on, 0=off, others possible depending on system hardware) | Empty | Empty | Empty | Empty | Empty |
 	MAV_CMD_DO_REPEAT_RELAY              = 182 // Cycle a relay on and off for a desired number of cyles with a desired period. | Relay number | Cycle count | Cycle time (seconds, decimal) | Empty | Empty | Empty | Empty |
 	MAV_CMD_DO_SET_SERVO                 = 183 // Set a servo to a desired PWM value. | Servo number | PWM (microseconds, 1000 to 2000 typical) | Empty | Empty | Empty | Empty | Empty |
@@ -452,14 +458,14 @@ const (
 	MAV_CMD_DO_INVERTED_FLIGHT           = 210 // Change to/from inverted flight | inverted (0=normal, 1=inverted) | Empty | Empty | Empty | Empty | Empty | Empty |
 	MAV_CMD_DO_MOUNT_CONTROL_QUAT        = 220 // Mission command to control a camera or antenna mount, using a quaternion as reference. | q1 - quaternion param #1, w (1 in null-rotation) | q2 - quaternion param #2, x (0 in null-rotation) | q3 - quaternion param #3, y (0 in null-rotation) | q4 - quaternion param #4, z (0 in null-rotation) | Empty | Empty | Empty |
 	MAV_CMD_DO_GUIDED_CONTROLLER         = 221 // set id of the controller | System ID | Component ID | Empty | Empty | Empty | Empty | Empty |
-	MAV_CMD_DO_GUIDED_LIMITS             = 222 // set limits for external control | timeout - maximum time (in seconds) that external controller will be allowed to control vehicle. 0 means no timeout | absolute altitude min (in meters, WGS84) - if vehicle moves below this alt, the command will be aborted and the mission will continue.  0 means no lower altitude limit | absolute altitude max (in meters)- if vehicle moves above this alt, the command will be aborted and the mission will continue.  0 means no upper altitude limit | horizontal move limit (in meters, WGS84) - if vehicle moves more than this distance from it's location at the moment the command was executed, the command will be aborted and the mission will continue. 0 means no horizontal altitude limit | Empty | Empty | Empty |
+	MAV_CMD_DO_GUIDED_LIMITS             = 222 // set limits for external control | timeout - maximum time (in seconds) that external controller will be allowed to control vehicle. 0 means no timeout | absolute altitude min (in meters, WGS84) - if vehicle moves below this alt, the command will be aborted and the mission will continue. 0 means no lower altitude limit | absolute altitude max (in meters)- if vehicle moves above this alt, the command will be aborted and the mission will continue. 0 means no upper altitude limit | horizontal move limit (in meters, WGS84) - if vehicle moves more than this distance from it's location at the moment the command was executed, the command will be aborted and the mission will continue. 0 means no horizontal altitude limit | Empty | Empty | Empty |
 	MAV_CMD_DO_LAST                      = 240 // NOP - This command is only used to mark the upper limit of the DO commands in the enumeration | Empty | Empty | Empty | Empty | Empty | Empty | Empty |
 	MAV_CMD_PREFLIGHT_CALIBRATION        = 241 // Trigger calibration. This command will be only accepted if in pre-flight mode. | Gyro calibration: 0: no, 1: yes | Magnetometer calibration: 0: no, 1: yes | Ground pressure: 0: no, 1: yes | Radio calibration: 0: no, 1: yes | Accelerometer calibration: 0: no, 1: yes | Compass/Motor interference calibration: 0: no, 1: yes | Empty |
 	MAV_CMD_PREFLIGHT_SET_SENSOR_OFFSETS = 242 // Set sensor offsets. This command will be only accepted if in pre-flight mode. | Sensor to adjust the offsets for: 0: gyros, 1: accelerometer, 2: magnetometer, 3: barometer, 4: optical flow, 5: second magnetometer | X axis offset (or generic dimension 1), in the sensor's raw units | Y axis offset (or generic dimension 2), in the sensor's raw units | Z axis offset (or generic dimension 3), in the sensor's raw units | Generic dimension 4, in the sensor's raw units | Generic dimension 5, in the sensor's raw units | Generic dimension 6, in the sensor's raw units |
 	MAV_CMD_PREFLIGHT_STORAGE            = 245 // Request storage of different parameter values and logs. This command will be only accepted if in pre-flight mode. | Parameter storage: 0: READ FROM FLASH/EEPROM, 1: WRITE CURRENT TO FLASH/EEPROM | Mission storage: 0: READ FROM FLASH/EEPROM, 1: WRITE CURRENT TO FLASH/EEPROM | Reserved | Reserved | Empty | Empty | Empty |
 	MAV_CMD_PREFLIGHT_REBOOT_SHUTDOWN    = 246 // Request the reboot or shutdown of system components. | 0: Do nothing for autopilot, 1: Reboot autopilot, 2: Shutdown autopilot. | 0: Do nothing for onboard computer, 1: Reboot onboard computer, 2: Shutdown onboard computer. | Reserved | Reserved | Empty | Empty | Empty |
 	MAV_CMD_OVERRIDE_GOTO                = 252 // Hold / continue the current action | MAV_GOTO_DO_HOLD: hold MAV_GOTO_DO_CONTINUE: continue with next item in mission plan | MAV_GOTO_HOLD_AT_CURRENT_POSITION: Hold at current position MAV_GOTO_HOLD_AT_SPECIFIED_POSITION: hold at specified position | MAV_FRAME coordinate frame of hold point | Desired yaw angle in degrees | Latitude / X position | Longitude / Y position | Altitude / Z position |
-	MAV_CMD_MISSION_START                = 300 // start running a mission | first_item: the first mission item to run | last_item:  the last mission item to run (after this item is run, the mission ends) |
+	MAV_CMD_MISSION_START                = 300 // start running a mission | first_item: the first mission item to run | last_item: the last mission item to run (after this item is run, the mission ends) |
 	MAV_CMD_COMPONENT_ARM_DISARM         = 400 // Arms / Disarms a component | 1 to arm, 0 to disarm |
 	MAV_CMD_START_RX_PAIR                = 500 // Starts receiver pairing | 0:Spektrum | 0:Spektrum DSM2, 1:Spektrum DSMX |
 	MAV_CMD_ENUM_END                     = 501 //  |
@@ -486,9 +492,9 @@ const (
 
 //
 // MAV_ROI
-/* The ROI (region of interest) for the vehicle. This can be
-   used by the vehicle for camera/vehicle attitude alignment (see
-   MAV_CMD_NAV_ROI).*/
+/*The ROI (region of interest) for the vehicle. This can be
+  used by the vehicle for camera/vehicle attitude alignment (see
+  MAV_CMD_NAV_ROI).*/
 //
 const (
 	MAV_ROI_NONE     = 0 // No region of interest. |
@@ -544,7 +550,9 @@ const (
 	MAV_RESULT_DENIED               = 2 // Command PERMANENTLY DENIED |
 	MAV_RESULT_UNSUPPORTED          = 3 // Command UNKNOWN/UNSUPPORTED |
 	MAV_RESULT_FAILED               = 4 // Command executed, but failed |
-	MAV_RESULT_ENUM_END             = 5 //  |
+	MAV_RESULT_IN_PROGRESS          = 5 // Command is valid and is being executed, a final acknowledge will follow |
+	MAV_RESULT_CANCELLED            = 6 // Command has been cancelled |
+	MAV_RESULT_ENUM_END             = 7 //  |
 )
 
 //
@@ -664,7 +672,7 @@ func NewHeartbeat(CUSTOM_MODE uint32, TYPE uint8, AUTOPILOT uint8, BASE_MODE uin
 }
 
 // Id returns the Heartbeat Message ID
-func (*Heartbeat) Id() uint8 {
+func (*Heartbeat) Id() uint32 {
 	return 0
 }
 
@@ -734,8 +742,8 @@ func (m *Heartbeat) Decode(buf []byte) {
 // MAVLINK_MSG_ID_SYS_STATUS_CRC 124
 type SysStatus struct {
 	ONBOARD_CONTROL_SENSORS_PRESENT uint32 // Bitmask showing which onboard controllers and sensors are present. Value of 0: not present. Value of 1: present. Indices defined by ENUM MAV_SYS_STATUS_SENSOR
-	ONBOARD_CONTROL_SENSORS_ENABLED uint32 // Bitmask showing which onboard controllers and sensors are enabled:  Value of 0: not enabled. Value of 1: enabled. Indices defined by ENUM MAV_SYS_STATUS_SENSOR
-	ONBOARD_CONTROL_SENSORS_HEALTH  uint32 // Bitmask showing which onboard controllers and sensors are operational or have an error:  Value of 0: not enabled. Value of 1: enabled. Indices defined by ENUM MAV_SYS_STATUS_SENSOR
+	ONBOARD_CONTROL_SENSORS_ENABLED uint32 // Bitmask showing which onboard controllers and sensors are enabled: Value of 0: not enabled. Value of 1: enabled. Indices defined by ENUM MAV_SYS_STATUS_SENSOR
+	ONBOARD_CONTROL_SENSORS_HEALTH  uint32 // Bitmask showing which onboard controllers and sensors are operational or have an error: Value of 0: not enabled. Value of 1: enabled. Indices defined by ENUM MAV_SYS_STATUS_SENSOR
 	LOAD                            uint16 // Maximum usage in percent of the mainloop time, (0%: 0, 100%: 1000) should be always below 1000
 	VOLTAGE_BATTERY                 uint16 // Battery voltage, in millivolts (1 = 1 millivolt)
 	CURRENT_BATTERY                 int16  // Battery current, in 10*milliamperes (1 = 10 milliampere), -1: autopilot does not measure the current
@@ -768,7 +776,7 @@ func NewSysStatus(ONBOARD_CONTROL_SENSORS_PRESENT uint32, ONBOARD_CONTROL_SENSOR
 }
 
 // Id returns the SysStatus Message ID
-func (*SysStatus) Id() uint8 {
+func (*SysStatus) Id() uint32 {
 	return 1
 }
 
@@ -892,7 +900,7 @@ func NewSystemTime(TIME_UNIX_USEC uint64, TIME_BOOT_MS uint32) *SystemTime {
 }
 
 // Id returns the SystemTime Message ID
-func (*SystemTime) Id() uint8 {
+func (*SystemTime) Id() uint32 {
 	return 2
 }
 
@@ -954,7 +962,7 @@ func NewPing(TIME_USEC uint64, SEQ uint32, TARGET_SYSTEM uint8, TARGET_COMPONENT
 }
 
 // Id returns the Ping Message ID
-func (*Ping) Id() uint8 {
+func (*Ping) Id() uint32 {
 	return 4
 }
 
@@ -1028,7 +1036,7 @@ func NewChangeOperatorControl(TARGET_SYSTEM uint8, CONTROL_REQUEST uint8, VERSIO
 }
 
 // Id returns the ChangeOperatorControl Message ID
-func (*ChangeOperatorControl) Id() uint8 {
+func (*ChangeOperatorControl) Id() uint32 {
 	return 5
 }
 
@@ -1104,7 +1112,7 @@ func NewChangeOperatorControlAck(GCS_SYSTEM_ID uint8, CONTROL_REQUEST uint8, ACK
 }
 
 // Id returns the ChangeOperatorControlAck Message ID
-func (*ChangeOperatorControlAck) Id() uint8 {
+func (*ChangeOperatorControlAck) Id() uint32 {
 	return 6
 }
 
@@ -1166,7 +1174,7 @@ func NewAuthKey(KEY [32]uint8) *AuthKey {
 }
 
 // Id returns the AuthKey Message ID
-func (*AuthKey) Id() uint8 {
+func (*AuthKey) Id() uint32 {
 	return 7
 }
 
@@ -1224,7 +1232,7 @@ func NewSetMode(CUSTOM_MODE uint32, TARGET_SYSTEM uint8, BASE_MODE uint8) *SetMo
 }
 
 // Id returns the SetMode Message ID
-func (*SetMode) Id() uint8 {
+func (*SetMode) Id() uint32 {
 	return 11
 }
 
@@ -1292,7 +1300,7 @@ func NewParamRequestRead(PARAM_INDEX int16, TARGET_SYSTEM uint8, TARGET_COMPONEN
 }
 
 // Id returns the ParamRequestRead Message ID
-func (*ParamRequestRead) Id() uint8 {
+func (*ParamRequestRead) Id() uint32 {
 	return 20
 }
 
@@ -1366,7 +1374,7 @@ func NewParamRequestList(TARGET_SYSTEM uint8, TARGET_COMPONENT uint8) *ParamRequ
 }
 
 // Id returns the ParamRequestList Message ID
-func (*ParamRequestList) Id() uint8 {
+func (*ParamRequestList) Id() uint32 {
 	return 21
 }
 
@@ -1430,7 +1438,7 @@ func NewParamValue(PARAM_VALUE float32, PARAM_COUNT uint16, PARAM_INDEX uint16,
 }
 
 // Id returns the ParamValue Message ID
-func (*ParamValue) Id() uint8 {
+func (*ParamValue) Id() uint32 {
 	return 22
 }
 
@@ -1516,7 +1524,7 @@ func NewParamSet(PARAM_VALUE float32, TARGET_SYSTEM uint8, TARGET_COMPONENT uint
 }
 
 // Id returns the ParamSet Message ID
-func (*ParamSet) Id() uint8 {
+func (*ParamSet) Id() uint32 {
 	return 23
 }
 
@@ -1612,7 +1620,7 @@ func NewGpsRawInt(TIME_USEC uint64, LAT int32, LON int32, ALT int32, EPH uint16,
 }
 
 // Id returns the GpsRawInt Message ID
-func (*GpsRawInt) Id() uint8 {
+func (*GpsRawInt) Id() uint32 {
 	return 24
 }
 
@@ -1726,7 +1734,7 @@ func NewGpsStatus(SATELLITES_VISIBLE uint8, SATELLITE_PRN [20]uint8, SATELLITE_U
 }
 
 // Id returns the GpsStatus Message ID
-func (*GpsStatus) Id() uint8 {
+func (*GpsStatus) Id() uint32 {
 	return 25
 }
 
@@ -1832,7 +1840,7 @@ func NewScaledImu(TIME_BOOT_MS uint32, XACC int16, YACC int16, ZACC int16, XGYRO
 }
 
 // Id returns the ScaledImu Message ID
-func (*ScaledImu) Id() uint8 {
+func (*ScaledImu) Id() uint32 {
 	return 26
 }
 
@@ -1954,7 +1962,7 @@ func NewRawImu(TIME_USEC uint64, XACC int16, YACC int16, ZACC int16, XGYRO int16
 }
 
 // Id returns the RawImu Message ID
-func (*RawImu) Id() uint8 {
+func (*RawImu) Id() uint32 {
 	return 27
 }
 
@@ -2066,7 +2074,7 @@ func NewRawPressure(TIME_USEC uint64, PRESS_ABS int16, PRESS_DIFF1 int16, PRESS_
 }
 
 // Id returns the RawPressure Message ID
-func (*RawPressure) Id() uint8 {
+func (*RawPressure) Id() uint32 {
 	return 28
 }
 
@@ -2146,7 +2154,7 @@ func NewScaledPressure(TIME_BOOT_MS uint32, PRESS_ABS float32, PRESS_DIFF float3
 }
 
 // Id returns the ScaledPressure Message ID
-func (*ScaledPressure) Id() uint8 {
+func (*ScaledPressure) Id() uint32 {
 	return 29
 }
 
@@ -2226,7 +2234,7 @@ func NewAttitude(TIME_BOOT_MS uint32, ROLL float32, PITCH float32, YAW float32,
 }
 
 // Id returns the Attitude Message ID
-func (*Attitude) Id() uint8 {
+func (*Attitude) Id() uint32 {
 	return 30
 }
 
@@ -2326,7 +2334,7 @@ func NewAttitudeQuaternion(TIME_BOOT_MS uint32, Q1 float32, Q2 float32, Q3 float
 }
 
 // Id returns the AttitudeQuaternion Message ID
-func (*AttitudeQuaternion) Id() uint8 {
+func (*AttitudeQuaternion) Id() uint32 {
 	return 31
 }
 
@@ -2430,7 +2438,7 @@ func NewLocalPositionNed(TIME_BOOT_MS uint32, X float32, Y float32, Z float32, V
 }
 
 // Id returns the LocalPositionNed Message ID
-func (*LocalPositionNed) Id() uint8 {
+func (*LocalPositionNed) Id() uint32 {
 	return 32
 }
 
@@ -2532,7 +2540,7 @@ func NewGlobalPositionInt(TIME_BOOT_MS uint32, LAT int32, LON int32, ALT int32,
 }
 
 // Id returns the GlobalPositionInt Message ID
-func (*GlobalPositionInt) Id() uint8 {
+func (*GlobalPositionInt) Id() uint32 {
 	return 33
 }
 
@@ -2650,7 +2658,7 @@ func NewRcChannelsScaled(TIME_BOOT_MS uint32, CHAN1_SCALED int16, CHAN2_SCALED i
 }
 
 // Id returns the RcChannelsScaled Message ID
-func (*RcChannelsScaled) Id() uint8 {
+func (*RcChannelsScaled) Id() uint32 {
 	return 34
 }
 
@@ -2780,7 +2788,7 @@ func NewRcChannelsRaw(TIME_BOOT_MS uint32, CHAN1_RAW uint16, CHAN2_RAW uint16, C
 }
 
 // Id returns the RcChannelsRaw Message ID
-func (*RcChannelsRaw) Id() uint8 {
+func (*RcChannelsRaw) Id() uint32 {
 	return 35
 }
 
@@ -2908,7 +2916,7 @@ func NewServoOutputRaw(TIME_USEC uint32, SERVO1_RAW uint16, SERVO2_RAW uint16, S
 }
 
 // Id returns the ServoOutputRaw Message ID
-func (*ServoOutputRaw) Id() uint8 {
+func (*ServoOutputRaw) Id() uint32 {
 	return 36
 }
 
@@ -3018,7 +3026,7 @@ func NewMissionRequestPartialList(START_INDEX int16, END_INDEX int16, TARGET_SYS
 }
 
 // Id returns the MissionRequestPartialList Message ID
-func (*MissionRequestPartialList) Id() uint8 {
+func (*MissionRequestPartialList) Id() uint32 {
 	return 37
 }
 
@@ -3092,7 +3100,7 @@ func NewMissionWritePartialList(START_INDEX int16, END_INDEX int16, TARGET_SYSTE
 }
 
 // Id returns the MissionWritePartialList Message ID
-func (*MissionWritePartialList) Id() uint8 {
+func (*MissionWritePartialList) Id() uint32 {
 	return 38
 }
 
@@ -3186,7 +3194,7 @@ func NewMissionItem(PARAM1 float32, PARAM2 float32, PARAM3 float32, PARAM4 float
 }
 
 // Id returns the MissionItem Message ID
-func (*MissionItem) Id() uint8 {
+func (*MissionItem) Id() uint32 {
 	return 39
 }
 
@@ -3318,7 +3326,7 @@ func NewMissionRequest(SEQ uint16, TARGET_SYSTEM uint8, TARGET_COMPONENT uint8)
 }
 
 // Id returns the MissionRequest Message ID
-func (*MissionRequest) Id() uint8 {
+func (*MissionRequest) Id() uint32 {
 	return 40
 }
 
@@ -3384,7 +3392,7 @@ func NewMissionSetCurrent(SEQ uint16, TARGET_SYSTEM uint8, TARGET_COMPONENT uint
 }
 
 // Id returns the MissionSetCurrent Message ID
-func (*MissionSetCurrent) Id() uint8 {
+func (*MissionSetCurrent) Id() uint32 {
 	return 41
 }
 
@@ -3446,7 +3454,7 @@ func NewMissionCurrent(SEQ uint16) *MissionCurrent {
 }
 
 // Id returns the MissionCurrent Message ID
-func (*MissionCurrent) Id() uint8 {
+func (*MissionCurrent) Id() uint32 {
 	return 42
 }
 
@@ -3498,7 +3506,7 @@ func NewMissionRequestList(TARGET_SYSTEM uint8, TARGET_COMPONENT uint8) *Mission
 }
 
 // Id returns the MissionRequestList Message ID
-func (*MissionRequestList) Id() uint8 {
+func (*MissionRequestList) Id() uint32 {
 	return 43
 }
 
@@ -3558,7 +3566,7 @@ func NewMissionCount(COUNT uint16, TARGET_SYSTEM uint8, TARGET_COMPONENT uint8)
 }
 
 // Id returns the MissionCount Message ID
-func (*MissionCount) Id() uint8 {
+func (*MissionCount) Id() uint32 {
 	return 44
 }
 
@@ -3622,7 +3630,7 @@ func NewMissionClearAll(TARGET_SYSTEM uint8, TARGET_COMPONENT uint8) *MissionCle
 }
 
 // Id returns the MissionClearAll Message ID
-func (*MissionClearAll) Id() uint8 {
+func (*MissionClearAll) Id() uint32 {
 	return 45
 }
 
@@ -3678,7 +3686,7 @@ func NewMissionItemReached(SEQ uint16) *MissionItemReached {
 }
 
 // Id returns the MissionItemReached Message ID
-func (*MissionItemReached) Id() uint8 {
+func (*MissionItemReached) Id() uint32 {
 	return 46
 }
 
@@ -3732,7 +3740,7 @@ func NewMissionAck(TARGET_SYSTEM uint8, TARGET_COMPONENT uint8, TYPE uint8) *Mis
 }
 
 // Id returns the MissionAck Message ID
-func (*MissionAck) Id() uint8 {
+func (*MissionAck) Id() uint32 {
 	return 47
 }
 
@@ -3800,7 +3808,7 @@ func NewSetGpsGlobalOrigin(LATITUDE int32, LONGITUDE int32, ALTITUDE int32, TARG
 }
 
 // Id returns the SetGpsGlobalOrigin Message ID
-func (*SetGpsGlobalOrigin) Id() uint8 {
+func (*SetGpsGlobalOrigin) Id() uint32 {
 	return 48
 }
 
@@ -3872,7 +3880,7 @@ func NewGpsGlobalOrigin(LATITUDE int32, LONGITUDE int32, ALTITUDE int32) *GpsGlo
 }
 
 // Id returns the GpsGlobalOrigin Message ID
-func (*GpsGlobalOrigin) Id() uint8 {
+func (*GpsGlobalOrigin) Id() uint32 {
 	return 49
 }
 
@@ -3946,7 +3954,7 @@ func NewSetLocalPositionSetpoint(X float32, Y float32, Z float32, YAW float32, T
 }
 
 // Id returns the SetLocalPositionSetpoint Message ID
-func (*SetLocalPositionSetpoint) Id() uint8 {
+func (*SetLocalPositionSetpoint) Id() uint32 {
 	return 50
 }
 
@@ -4013,84 +4021,70 @@ func (m *SetLocalPositionSetpoint) Decode(buf []byte) {
 	}
 }
 
-// MESSAGE LOCAL_POSITION_SETPOINT
+// MESSAGE MISSION_REQUEST_INT
 //
-// MAVLINK_MSG_ID_LOCAL_POSITION_SETPOINT 51
+// Request the information of the mission item with the sequence number seq. The response of the system to this message should be a MISSION_ITEM_INT message.
 //
-// MAVLINK_MSG_ID_LOCAL_POSITION_SETPOINT_LEN 17
+// MAVLINK_MSG_ID_MISSION_REQUEST_INT 51
 //
-// MAVLINK_MSG_ID_LOCAL_POSITION_SETPOINT_CRC 223
-type LocalPositionSetpoint struct {
-	X                float32 // x position
-	Y                float32 // y position
-	Z                float32 // z position
-	YAW              float32 // Desired yaw angle
-	COORDINATE_FRAME uint8   // Coordinate frame - valid values are only MAV_FRAME_LOCAL_NED or MAV_FRAME_LOCAL_ENU
+// MAVLINK_MSG_ID_MISSION_REQUEST_INT_LEN 4
+//
+// MAVLINK_MSG_ID_MISSION_REQUEST_INT_CRC 196
+type MissionRequestInt struct {
+	SEQ              uint16 // Sequence
+	TARGET_SYSTEM    uint8  // System ID
+	TARGET_COMPONENT uint8  // Component ID
 }
 
-// NewLocalPositionSetpoint returns a new LocalPositionSetpoint
-func NewLocalPositionSetpoint(X float32, Y float32, Z float32, YAW float32, COORDINATE_FRAME uint8) *LocalPositionSetpoint {
-	m := LocalPositionSetpoint{}
-	m.X = X
-	m.Y = Y
-	m.Z = Z
-	m.YAW = YAW
-	m.COORDINATE_FRAME = COORDINATE_FRAME
+// NewMissionRequestInt returns a new MissionRequestInt
+func NewMissionRequestInt(SEQ uint16, TARGET_SYSTEM uint8, TARGET_COMPONENT uint8) *MissionRequestInt {
+	m := MissionRequestInt{}
+	m.SEQ = SEQ
+	m.TARGET_SYSTEM = TARGET_SYSTEM
+	m.TARGET_COMPONENT = TARGET_COMPONENT
 	return &m
 }
 
-// Id returns the LocalPositionSetpoint Message ID
-func (*LocalPositionSetpoint) Id() uint8 {
+// Id returns the MissionRequestInt Message ID
+func (*MissionRequestInt) Id() uint32 {
 	return 51
 }
 
-// Len returns the LocalPositionSetpoint Message Length
-func (*LocalPositionSetpoint) Len() uint8 {
-	return 17
+// Len returns the MissionRequestInt Message Length
+func (*MissionRequestInt) Len() uint8 {
+	return 4
 }
 
-// Crc returns the LocalPositionSetpoint Message CRC
-func (*LocalPositionSetpoint) Crc() uint8 {
-	return 223
+// Crc returns the MissionRequestInt Message CRC
+func (*MissionRequestInt) Crc() uint8 {
+	return 196
 }
 
-// Pack returns a packed byte array which represents a LocalPositionSetpoint payload
-func (m *LocalPositionSetpoint) Pack() []byte {
+// Pack returns a packed byte array which represents a MissionRequestInt payload
+func (m *MissionRequestInt) Pack() []byte {
 	data := new(bytes.Buffer)
-	if err := binary.Write(data, binary.LittleEndian, m.X); err != nil {
-		panic(err)
-	}
-	if err := binary.Write(data, binary.LittleEndian, m.Y); err != nil {
-		panic(err)
-	}
-	if err := binary.Write(data, binary.LittleEndian, m.Z); err != nil {
+	if err := binary.Write(data, binary.LittleEndian, m.SEQ); err != nil {
 		panic(err)
 	}
-	if err := binary.Write(data, binary.LittleEndian, m.YAW); err != nil {
+	if err := binary.Write(data, binary.LittleEndian, m.TARGET_SYSTEM); err != nil {
 		panic(err)
 	}
-	if err := binary.Write(data, binary.LittleEndian, m.COORDINATE_FRAME); err != nil {
+	if err := binary.Write(data, binary.LittleEndian, m.TARGET_COMPONENT); err != nil {
 		panic(err)
 	}
 	return data.Bytes()
 }
 
-// Decode accepts a packed byte array and populates the fields of the LocalPositionSetpoint
-func (m *LocalPositionSetpoint) Decode(buf []byte) {
+// Decode accepts a packed byte array and populates the fields of the MissionRequestInt
+func (m *MissionRequestInt) Decode(buf []byte) {
 	data := bytes.NewBuffer(buf)
-	if err := binary.Read(data, binary.LittleEndian, &m.X); err != nil {
-		panic(err)
-	}
-	if err := binary.Read(data, binary.LittleEndian, &m.Y); err != nil {
-		panic(err)
-	}
-	if err := binary.Read(data, binary.LittleEndian, &m.Z); err != nil {
+	if err := binary.Read(data, binary.LittleEndian, &m.SEQ); err != nil {
 		panic(err)
 	}
-	if err := binary.Read(data, binary.LittleEndian, &m.YAW); err != nil {
+	if err := binary.Read(data, binary.LittleEndian, &m.TARGET_SYSTEM); err != nil {
 		panic(err)
 	}
-	if err := binary.Read(data, binary.LittleEndian, &m.COORDINATE_FRAME); err != nil {
+	if err := binary.Read(data, binary.LittleEndian, &m.TARGET_COMPONENT); err != nil {
 		panic(err)
 	}
 }
@@ -4122,7 +4116,7 @@ func NewGlobalPositionSetpointInt(LATITUDE int32, LONGITUDE int32, ALTITUDE int3
 }
 
 // Id returns the GlobalPositionSetpointInt Message ID
-func (*GlobalPositionSetpointInt) Id() uint8 {
+func (*GlobalPositionSetpointInt) Id() uint32 {
 	return 52
 }
 
@@ -4204,7 +4198,7 @@ func NewSetGlobalPositionSetpointInt(LATITUDE int32, LONGITUDE int32, ALTITUDE i
 }
 
 // Id returns the SetGlobalPositionSetpointInt Message ID
-func (*SetGlobalPositionSetpointInt) Id() uint8 {
+func (*SetGlobalPositionSetpointInt) Id() uint32 {
 	return 53
 }
 
@@ -4294,7 +4288,7 @@ func NewSafetySetAllowedArea(P1X float32, P1Y float32, P1Z float32, P2X float32,
 }
 
 // Id returns the SafetySetAllowedArea Message ID
-func (*SafetySetAllowedArea) Id() uint8 {
+func (*SafetySetAllowedArea) Id() uint32 {
 	return 54
 }
 
@@ -4404,7 +4398,7 @@ func NewSafetyAllowedArea(P1X float32, P1Y float32, P1Z float32, P2X float32, P2
 }
 
 // Id returns the SafetyAllowedArea Message ID
-func (*SafetyAllowedArea) Id() uint8 {
+func (*SafetyAllowedArea) Id() uint32 {
 	return 55
 }
 
@@ -4500,7 +4494,7 @@ func NewSetRollPitchYawThrust(ROLL float32, PITCH float32, YAW float32, THRUST f
 }
 
 // Id returns the SetRollPitchYawThrust Message ID
-func (*SetRollPitchYawThrust) Id() uint8 {
+func (*SetRollPitchYawThrust) Id() uint32 {
 	return 56
 }
 
@@ -4590,7 +4584,7 @@ func NewSetRollPitchYawSpeedThrust(ROLL_SPEED float32, PITCH_SPEED float32, YAW_
 }
 
 // Id returns the SetRollPitchYawSpeedThrust Message ID
-func (*SetRollPitchYawSpeedThrust) Id() uint8 {
+func (*SetRollPitchYawSpeedThrust) Id() uint32 {
 	return 57
 }
 
@@ -4678,7 +4672,7 @@ func NewRollPitchYawThrustSetpoint(TIME_BOOT_MS uint32, ROLL float32, PITCH floa
 }
 
 // Id returns the RollPitchYawThrustSetpoint Message ID
-func (*RollPitchYawThrustSetpoint) Id() uint8 {
+func (*RollPitchYawThrustSetpoint) Id() uint32 {
 	return 58
 }
 
@@ -4760,7 +4754,7 @@ func NewRollPitchYawSpeedThrustSetpoint(TIME_BOOT_MS uint32, ROLL_SPEED float32,
 }
 
 // Id returns the RollPitchYawSpeedThrustSetpoint Message ID
-func (*RollPitchYawSpeedThrustSetpoint) Id() uint8 {
+func (*RollPitchYawSpeedThrustSetpoint) Id() uint32 {
 	return 59
 }
 
@@ -4842,7 +4836,7 @@ func NewSetQuadMotorsSetpoint(MOTOR_FRONT_NW uint16, MOTOR_RIGHT_NE uint16, MOTO
 }
 
 // Id returns the SetQuadMotorsSetpoint Message ID
-func (*SetQuadMotorsSetpoint) Id() uint8 {
+func (*SetQuadMotorsSetpoint) Id() uint32 {
 	return 60
 }
 
@@ -4926,7 +4920,7 @@ func NewSetQuadSwarmRollPitchYawThrust(ROLL [4]int16, PITCH [4]int16, YAW [4]int
 }
 
 // Id returns the SetQuadSwarmRollPitchYawThrust Message ID
-func (*SetQuadSwarmRollPitchYawThrust) Id() uint8 {
+func (*SetQuadSwarmRollPitchYawThrust) Id() uint32 {
 	return 61
 }
 
@@ -5027,7 +5021,7 @@ func NewNavControllerOutput(NAV_ROLL float32, NAV_PITCH float32, ALT_ERROR float
 }
 
 // Id returns the NavControllerOutput Message ID
-func (*NavControllerOutput) Id() uint8 {
+func (*NavControllerOutput) Id() uint32 {
 	return 62
 }
 
@@ -5135,7 +5129,7 @@ func NewSetQuadSwarmLedRollPitchYawThrust(ROLL [4]int16, PITCH [4]int16, YAW [4]
 }
 
 // Id returns the SetQuadSwarmLedRollPitchYawThrust Message ID
-func (*SetQuadSwarmLedRollPitchYawThrust) Id() uint8 {
+func (*SetQuadSwarmLedRollPitchYawThrust) Id() uint32 {
 	return 63
 }
 
@@ -5259,7 +5253,7 @@ func NewStateCorrection(XERR float32, YERR float32, ZERR float32, ROLLERR float3
 }
 
 // Id returns the StateCorrection Message ID
-func (*StateCorrection) Id() uint8 {
+func (*StateCorrection) Id() uint32 {
 	return 64
 }
 
@@ -5397,7 +5391,7 @@ func NewRcChannels(TIME_BOOT_MS uint32, CHAN1_RAW uint16, CHAN2_RAW uint16, CHAN
 }
 
 // Id returns the RcChannels Message ID
-func (*RcChannels) Id() uint8 {
+func (*RcChannels) Id() uint32 {
 	return 65
 }
 
@@ -5575,7 +5569,7 @@ func NewRequestDataStream(REQ_MESSAGE_RATE uint16, TARGET_SYSTEM uint8, TARGET_C
 }
 
 // Id returns the RequestDataStream Message ID
-func (*RequestDataStream) Id() uint8 {
+func (*RequestDataStream) Id() uint32 {
 	return 66
 }
 
@@ -5653,7 +5647,7 @@ func NewDataStream(MESSAGE_RATE uint16, STREAM_ID uint8, ON_OFF uint8) *DataStre
 }
 
 // Id returns the DataStream Message ID
-func (*DataStream) Id() uint8 {
+func (*DataStream) Id() uint32 {
 	return 67
 }
 
@@ -5725,7 +5719,7 @@ func NewManualControl(X int16, Y int16, Z int16, R int16, BUTTONS uint16, TARGET
 }
 
 // Id returns the ManualControl Message ID
-func (*ManualControl) Id() uint8 {
+func (*ManualControl) Id() uint32 {
 	return 69
 }
 
@@ -5823,7 +5817,7 @@ func NewRcChannelsOverride(CHAN1_RAW uint16, CHAN2_RAW uint16, CHAN3_RAW uint16,
 }
 
 // Id returns the RcChannelsOverride Message ID
-func (*RcChannelsOverride) Id() uint8 {
+func (*RcChannelsOverride) Id() uint32 {
 	return 70
 }
 
@@ -5908,6 +5902,162 @@ func (m *RcChannelsOverride) Decode(buf []byte) {
 	}
 }
 
+// MESSAGE MISSION_ITEM_INT
+//
+// Message encoding a mission item. This message is emitted to announce the presence of a mission item and to set a mission item on the system. The mission item can be either in x, y, z meters (type: LOCAL) or x:lat, y:lon, z:altitude. Local frame is Z-down, right handed (NED), global frame is Z-up, right handed (ENU). See also https://mavlink.io/en/services/mission.html.
+//
+// MAVLINK_MSG_ID_MISSION_ITEM_INT 73
+//
+// MAVLINK_MSG_ID_MISSION_ITEM_INT_LEN 37
+//
+// MAVLINK_MSG_ID_MISSION_ITEM_INT_CRC 38
+type MissionItemInt struct {
+	PARAM1           float32 // PARAM1, see MAV_CMD enum
+	PARAM2           float32 // PARAM2, see MAV_CMD enum
+	PARAM3           float32 // PARAM3, see MAV_CMD enum
+	PARAM4           float32 // PARAM4, see MAV_CMD enum
+	X                int32   // PARAM5 / local: x position in meters * 1e4, global: latitude in degrees * 10^7
+	Y                int32   // PARAM6 / y position: local: x position in meters * 1e4, global: longitude in degrees *10^7
+	Z                float32 // PARAM7 / z position: global: altitude in meters (relative or absolute, depending on frame.
+	SEQ              uint16  // Waypoint ID (sequence number). Starts at zero. Increases monotonically for each waypoint, no gaps in the sequence (0,1,2,3,4).
+	COMMAND          uint16  // The scheduled action for the waypoint, as defined by MAV_CMD enum
+	TARGET_SYSTEM    uint8   // System ID
+	TARGET_COMPONENT uint8   // Component ID
+	FRAME            uint8   // The coordinate system of the waypoint, as defined by MAV_FRAME enum
+	CURRENT          uint8   // false:0, true:1
+	AUTOCONTINUE     uint8   // autocontinue to next wp
+}
+
+// NewMissionItemInt returns a new MissionItemInt
+func NewMissionItemInt(PARAM1 float32, PARAM2 float32, PARAM3 float32, PARAM4 float32, X int32, Y int32, Z float32, SEQ uint16, COMMAND uint16, TARGET_SYSTEM uint8, TARGET_COMPONENT uint8, FRAME uint8, CURRENT uint8, AUTOCONTINUE uint8) *MissionItemInt {
+	m := MissionItemInt{}
+	m.PARAM1 = PARAM1
+	m.PARAM2 = PARAM2
+	m.PARAM3 = PARAM3
+	m.PARAM4 = PARAM4
+	m.X = X
+	m.Y = Y
+	m.Z = Z
+	m.SEQ = SEQ
+	m.COMMAND = COMMAND
+	m.TARGET_SYSTEM = TARGET_SYSTEM
+	m.TARGET_COMPONENT = TARGET_COMPONENT
+	m.FRAME = FRAME
+	m.CURRENT = CURRENT
+	m.AUTOCONTINUE = AUTOCONTINUE
+	return &m
+}
+
+// Id returns the MissionItemInt Message ID
+func (*MissionItemInt) Id() uint32 {
+	return 73
+}
+
+// Len returns the MissionItemInt Message Length
+func (*MissionItemInt) Len() uint8 {
+	return 37
+}
+
+// Crc returns the MissionItemInt Message CRC
+func (*MissionItemInt) Crc() uint8 {
+	return 38
+}
+
+// Pack returns a packed byte array which represents a MissionItemInt payload
+func (m *MissionItemInt) Pack() []byte {
+	data := new(bytes.Buffer)
+	if err := binary.Write(data, binary.LittleEndian, m.PARAM1); err != nil {
+		panic(err)
+	}
+	if err := binary.Write(data, binary.LittleEndian, m.PARAM2); err != nil {
+		panic(err)
+	}
+	if err := binary.Write(data, binary.LittleEndian, m.PARAM3); err != nil {
+		panic(err)
+	}
+	if err := binary.Write(data, binary.LittleEndian, m.PARAM4); err != nil {
+		panic(err)
+	}
+	if err := binary.Write(data, binary.LittleEndian, m.X); err != nil {
+		panic(err)
+	}
+	if err := binary.Write(data, binary.LittleEndian, m.Y); err != nil {
+		panic(err)
+	}
+	if err := binary.Write(data, binary.LittleEndian, m.Z); err != nil {
+		panic(err)
+	}
+	if err := binary.Write(data, binary.LittleEndian, m.SEQ); err != nil {
+		panic(err)
+	}
+	if err := binary.Write(data, binary.LittleEndian, m.COMMAND); err != nil {
+		panic(err)
+	}
+	if err := binary.Write(data, binary.LittleEndian, m.TARGET_SYSTEM); err != nil {
+		panic(err)
+	}
+	if err := binary.Write(data, binary.LittleEndian, m.TARGET_COMPONENT); err != nil {
+		panic(err)
+	}
+	if err := binary.Write(data, binary.LittleEndian, m.FRAME); err != nil {
+		panic(err)
+	}
+	if err := binary.Write(data, binary.LittleEndian, m.CURRENT); err != nil {
+		panic(err)
+	}
+	if err := binary.Write(data, binary.LittleEndian, m.AUTOCONTINUE); err != nil {
+		panic(err)
+	}
+	return data.Bytes()
+}
+
+// Decode accepts a packed byte array and populates the fields of the MissionItemInt
+func (m *MissionItemInt) Decode(buf []byte) {
+	data := bytes.NewBuffer(buf)
+	if err := binary.Read(data, binary.LittleEndian, &m.PARAM1); err != nil {
+		panic(err)
+	}
+	if err := binary.Read(data, binary.LittleEndian, &m.PARAM2); err != nil {
+		panic(err)
+	}
+	if err := binary.Read(data, binary.LittleEndian, &m.PARAM3); err != nil {
+		panic(err)
+	}
+	if err := binary.Read(data, binary.LittleEndian, &m.PARAM4); err != nil {
+		panic(err)
+	}
+	if err := binary.Read(data, binary.LittleEndian, &m.X); err != nil {
+		panic(err)
+	}
+	if err := binary.Read(data, binary.LittleEndian, &m.Y); err != nil {
+		panic(err)
+	}
+	if err := binary.Read(data, binary.LittleEndian, &m.Z); err != nil {
+		panic(err)
+	}
+	if err := binary.Read(data, binary.LittleEndian, &m.SEQ); err != nil {
+		panic(err)
+	}
+	if err := binary.Read(data, binary.LittleEndian, &m.COMMAND); err != nil {
+		panic(err)
+	}
+	if err := binary.Read(data, binary.LittleEndian, &m.TARGET_SYSTEM); err != nil {
+		panic(err)
+	}
+	if err := binary.Read(data, binary.LittleEndian, &m.TARGET_COMPONENT); err != nil {
+		panic(err)
+	}
+	if err := binary.Read(data, binary.LittleEndian, &m.FRAME); err != nil {
+		panic(err)
+	}
+	if err := binary.Read(data, binary.LittleEndian, &m.CURRENT); err != nil {
+		panic(err)
+	}
+	if err := binary.Read(data, binary.LittleEndian, &m.AUTOCONTINUE); err != nil {
+		panic(err)
+	}
+}
+
 // MESSAGE VFR_HUD
 //
 // MAVLINK_MSG_ID_VFR_HUD 74
@@ -5937,7 +6087,7 @@ func NewVfrHud(AIRSPEED float32, GROUNDSPEED float32, ALT float32, CLIMB float32
 }
 
 // Id returns the VfrHud Message ID
-func (*VfrHud) Id() uint8 {
+func (*VfrHud) Id() uint32 {
 	return 74
 }
 
@@ -6037,7 +6187,7 @@ func NewCommandLong(PARAM1 float32, PARAM2 float32, PARAM3 float32, PARAM4 float
 }
 
 // Id returns the CommandLong Message ID
-func (*CommandLong) Id() uint8 {
+func (*CommandLong) Id() uint32 {
 	return 76
 }
 
@@ -6149,7 +6299,7 @@ func NewCommandAck(COMMAND uint16, RESULT uint8) *CommandAck {
 }
 
 // Id returns the CommandAck Message ID
-func (*CommandAck) Id() uint8 {
+func (*CommandAck) Id() uint32 {
 	return 77
 }
 
@@ -6213,7 +6363,7 @@ func NewRollPitchYawRatesThrustSetpoint(TIME_BOOT_MS uint32, ROLL_RATE float32,
 }
 
 // Id returns the RollPitchYawRatesThrustSetpoint Message ID
-func (*RollPitchYawRatesThrustSetpoint) Id() uint8 {
+func (*RollPitchYawRatesThrustSetpoint) Id() uint32 {
 	return 80
 }
 
@@ -6299,7 +6449,7 @@ func NewManualSetpoint(TIME_BOOT_MS uint32, ROLL float32, PITCH float32, YAW flo
 }
 
 // Id returns the ManualSetpoint Message ID
-func (*ManualSetpoint) Id() uint8 {
+func (*ManualSetpoint) Id() uint32 {
 	return 81
 }
 
@@ -6401,7 +6551,7 @@ func NewAttitudeSetpointExternal(TIME_BOOT_MS uint32, Q [4]float32, BODY_ROLL_RA
 }
 
 // Id returns the AttitudeSetpointExternal Message ID
-func (*AttitudeSetpointExternal) Id() uint8 {
+func (*AttitudeSetpointExternal) Id() uint32 {
 	return 82
 }
 
@@ -6529,7 +6679,7 @@ func NewLocalNedPositionSetpointExternal(TIME_BOOT_MS uint32, X float32, Y float
 }
 
 // Id returns the LocalNedPositionSetpointExternal Message ID
-func (*LocalNedPositionSetpointExternal) Id() uint8 {
+func (*LocalNedPositionSetpointExternal) Id() uint32 {
 	return 83
 }
 
@@ -6681,7 +6831,7 @@ func NewGlobalPositionSetpointExternalInt(TIME_BOOT_MS uint32, LAT_INT int32, LO
 }
 
 // Id returns the GlobalPositionSetpointExternalInt Message ID
-func (*GlobalPositionSetpointExternalInt) Id() uint8 {
+func (*GlobalPositionSetpointExternalInt) Id() uint32 {
 	return 84
 }
 
@@ -6815,7 +6965,7 @@ func NewLocalPositionNedSystemGlobalOffset(TIME_BOOT_MS uint32, X float32, Y flo
 }
 
 // Id returns the LocalPositionNedSystemGlobalOffset Message ID
-func (*LocalPositionNedSystemGlobalOffset) Id() uint8 {
+func (*LocalPositionNedSystemGlobalOffset) Id() uint32 {
 	return 89
 }
 
@@ -6931,7 +7081,7 @@ func NewHilState(TIME_USEC uint64, ROLL float32, PITCH float32, YAW float32, ROL
 }
 
 // Id returns the HilState Message ID
-func (*HilState) Id() uint8 {
+func (*HilState) Id() uint32 {
 	return 90
 }
 
@@ -7091,7 +7241,7 @@ func NewHilControls(TIME_USEC uint64, ROLL_AILERONS float32, PITCH_ELEVATOR floa
 }
 
 // Id returns the HilControls Message ID
-func (*HilControls) Id() uint8 {
+func (*HilControls) Id() uint32 {
 	return 91
 }
 
@@ -7227,7 +7377,7 @@ func NewHilRcInputsRaw(TIME_USEC uint64, CHAN1_RAW uint16, CHAN2_RAW uint16, CHA
 }
 
 // Id returns the HilRcInputsRaw Message ID
-func (*HilRcInputsRaw) Id() uint8 {
+func (*HilRcInputsRaw) Id() uint32 {
 	return 92
 }
 
@@ -7369,7 +7519,7 @@ func NewOpticalFlow(TIME_USEC uint64, FLOW_COMP_M_X float32, FLOW_COMP_M_Y float
 }
 
 // Id returns the OpticalFlow Message ID
-func (*OpticalFlow) Id() uint8 {
+func (*OpticalFlow) Id() uint32 {
 	return 100
 }
 
@@ -7473,7 +7623,7 @@ func NewGlobalVisionPositionEstimate(USEC uint64, X float32, Y float32, Z float3
 }
 
 // Id returns the GlobalVisionPositionEstimate Message ID
-func (*GlobalVisionPositionEstimate) Id() uint8 {
+func (*GlobalVisionPositionEstimate) Id() uint32 {
 	return 101
 }
 
@@ -7571,7 +7721,7 @@ func NewVisionPositionEstimate(USEC uint64, X float32, Y float32, Z float32, ROL
 }
 
 // Id returns the VisionPositionEstimate Message ID
-func (*VisionPositionEstimate) Id() uint8 {
+func (*VisionPositionEstimate) Id() uint32 {
 	return 102
 }
 
@@ -7663,7 +7813,7 @@ func NewVisionSpeedEstimate(USEC uint64, X float32, Y float32, Z float32) *Visio
 }
 
 // Id returns the VisionSpeedEstimate Message ID
-func (*VisionSpeedEstimate) Id() uint8 {
+func (*VisionSpeedEstimate) Id() uint32 {
 	return 103
 }
 
@@ -7743,7 +7893,7 @@ func NewViconPositionEstimate(USEC uint64, X float32, Y float32, Z float32, ROLL
 }
 
 // Id returns the ViconPositionEstimate Message ID
-func (*ViconPositionEstimate) Id() uint8 {
+func (*ViconPositionEstimate) Id() uint32 {
 	return 104
 }
 
@@ -7857,7 +8007,7 @@ func NewHighresImu(TIME_USEC uint64, XACC float32, YACC float32, ZACC float32, X
 }
 
 // Id returns the HighresImu Message ID
-func (*HighresImu) Id() uint8 {
+func (*HighresImu) Id() uint32 {
 	return 105
 }
 
@@ -8001,7 +8151,7 @@ func NewOmnidirectionalFlow(TIME_USEC uint64, FRONT_DISTANCE_M float32, LEFT [10
 }
 
 // Id returns the OmnidirectionalFlow Message ID
-func (*OmnidirectionalFlow) Id() uint8 {
+func (*OmnidirectionalFlow) Id() uint32 {
 	return 106
 }
 
@@ -8114,7 +8264,7 @@ func NewHilSensor(TIME_USEC uint64, XACC float32, YACC float32, ZACC float32, XG
 }
 
 // Id returns the HilSensor Message ID
-func (*HilSensor) Id() uint8 {
+func (*HilSensor) Id() uint32 {
 	return 107
 }
 
@@ -8288,7 +8438,7 @@ func NewSimState(Q1 float32, Q2 float32, Q3 float32, Q4 float32, ROLL float32, P
 }
 
 // Id returns the SimState Message ID
-func (*SimState) Id() uint8 {
+func (*SimState) Id() uint32 {
 	return 108
 }
 
@@ -8470,7 +8620,7 @@ func NewRadioStatus(RXERRORS uint16, FIXED uint16, RSSI uint8, REMRSSI uint8, TX
 }
 
 // Id returns the RadioStatus Message ID
-func (*RadioStatus) Id() uint8 {
+func (*RadioStatus) Id() uint32 {
 	return 109
 }
 
@@ -8564,7 +8714,7 @@ func NewFileTransferStart(TRANSFER_UID uint64, FILE_SIZE uint32, DEST_PATH [240]
 }
 
 // Id returns the FileTransferStart Message ID
-func (*FileTransferStart) Id() uint8 {
+func (*FileTransferStart) Id() uint32 {
 	return 110
 }
 
@@ -8646,7 +8796,7 @@ func NewFileTransferDirList(TRANSFER_UID uint64, DIR_PATH [240]uint8, FLAGS uint
 }
 
 // Id returns the FileTransferDirList Message ID
-func (*FileTransferDirList) Id() uint8 {
+func (*FileTransferDirList) Id() uint32 {
 	return 111
 }
 
@@ -8714,7 +8864,7 @@ func NewFileTransferRes(TRANSFER_UID uint64, RESULT uint8) *FileTransferRes {
 }
 
 // Id returns the FileTransferRes Message ID
-func (*FileTransferRes) Id() uint8 {
+func (*FileTransferRes) Id() uint32 {
 	return 112
 }
 
@@ -8794,7 +8944,7 @@ func NewHilGps(TIME_USEC uint64, LAT int32, LON int32, ALT int32, EPH uint16, EP
 }
 
 // Id returns the HilGps Message ID
-func (*HilGps) Id() uint8 {
+func (*HilGps) Id() uint32 {
 	return 113
 }
 
@@ -8930,7 +9080,7 @@ func NewHilOpticalFlow(TIME_USEC uint64, FLOW_COMP_M_X float32, FLOW_COMP_M_Y fl
 }
 
 // Id returns the HilOpticalFlow Message ID
-func (*HilOpticalFlow) Id() uint8 {
+func (*HilOpticalFlow) Id() uint32 {
 	return 114
 }
 
@@ -9052,7 +9202,7 @@ func NewHilStateQuaternion(TIME_USEC uint64, ATTITUDE_QUATERNION [4]float32, ROL
 }
 
 // Id returns the HilStateQuaternion Message ID
-func (*HilStateQuaternion) Id() uint8 {
+func (*HilStateQuaternion) Id() uint32 {
 	return 115
 }
 
@@ -9214,7 +9364,7 @@ func NewScaledImu2(TIME_BOOT_MS uint32, XACC int16, YACC int16, ZACC int16, XGYR
 }
 
 // Id returns the ScaledImu2 Message ID
-func (*ScaledImu2) Id() uint8 {
+func (*ScaledImu2) Id() uint32 {
 	return 116
 }
 
@@ -9324,7 +9474,7 @@ func NewLogRequestList(START uint16, END uint16, TARGET_SYSTEM uint8, TARGET_COM
 }
 
 // Id returns the LogRequestList Message ID
-func (*LogRequestList) Id() uint8 {
+func (*LogRequestList) Id() uint32 {
 	return 117
 }
 
@@ -9400,7 +9550,7 @@ func NewLogEntry(TIME_UTC uint32, SIZE uint32, ID uint16, NUM_LOGS uint16, LAST_
 }
 
 // Id returns the LogEntry Message ID
-func (*LogEntry) Id() uint8 {
+func (*LogEntry) Id() uint32 {
 	return 118
 }
 
@@ -9482,7 +9632,7 @@ func NewLogRequestData(OFS uint32, COUNT uint32, ID uint16, TARGET_SYSTEM uint8,
 }
 
 // Id returns the LogRequestData Message ID
-func (*LogRequestData) Id() uint8 {
+func (*LogRequestData) Id() uint32 {
 	return 119
 }
 
@@ -9562,7 +9712,7 @@ func NewLogData(OFS uint32, ID uint16, COUNT uint8, DATA [90]uint8) *LogData {
 }
 
 // Id returns the LogData Message ID
-func (*LogData) Id() uint8 {
+func (*LogData) Id() uint32 {
 	return 120
 }
 
@@ -9636,7 +9786,7 @@ func NewLogErase(TARGET_SYSTEM uint8, TARGET_COMPONENT uint8) *LogErase {
 }
 
 // Id returns the LogErase Message ID
-func (*LogErase) Id() uint8 {
+func (*LogErase) Id() uint32 {
 	return 121
 }
 
@@ -9694,7 +9844,7 @@ func NewLogRequestEnd(TARGET_SYSTEM uint8, TARGET_COMPONENT uint8) *LogRequestEn
 }
 
 // Id returns the LogRequestEnd Message ID
-func (*LogRequestEnd) Id() uint8 {
+func (*LogRequestEnd) Id() uint32 {
 	return 122
 }
 
@@ -9756,7 +9906,7 @@ func NewGpsInjectData(TARGET_SYSTEM uint8, TARGET_COMPONENT uint8, LEN uint8, DA
 }
 
 // Id returns the GpsInjectData Message ID
-func (*GpsInjectData) Id() uint8 {
+func (*GpsInjectData) Id() uint32 {
 	return 123
 }
 
@@ -9850,7 +10000,7 @@ func NewGps2Raw(TIME_USEC uint64, LAT int32, LON int32, ALT int32, DGPS_AGE uint
 }
 
 // Id returns the Gps2Raw Message ID
-func (*Gps2Raw) Id() uint8 {
+func (*Gps2Raw) Id() uint32 {
 	return 124
 }
 
@@ -9970,7 +10120,7 @@ func NewPowerStatus(VCC uint16, VSERVO uint16, FLAGS uint16) *PowerStatus {
 }
 
 // Id returns the PowerStatus Message ID
-func (*PowerStatus) Id() uint8 {
+func (*PowerStatus) Id() uint32 {
 	return 125
 }
 
@@ -10042,7 +10192,7 @@ func NewSerialControl(BAUDRATE uint32, TIMEOUT uint16, DEVICE uint8, FLAGS uint8
 }
 
 // Id returns the SerialControl Message ID
-func (*SerialControl) Id() uint8 {
+func (*SerialControl) Id() uint32 {
 	return 126
 }
 
@@ -10150,7 +10300,7 @@ func NewGpsRtk(TIME_LAST_BASELINE_MS uint32, TOW uint32, BASELINE_A_MM int32, BA
 }
 
 // Id returns the GpsRtk Message ID
-func (*GpsRtk) Id() uint8 {
+func (*GpsRtk) Id() uint32 {
 	return 127
 }
 
@@ -10296,7 +10446,7 @@ func NewGps2Rtk(TIME_LAST_BASELINE_MS uint32, TOW uint32, BASELINE_A_MM int32, B
 }
 
 // Id returns the Gps2Rtk Message ID
-func (*Gps2Rtk) Id() uint8 {
+func (*Gps2Rtk) Id() uint32 {
 	return 128
 }
 
@@ -10430,7 +10580,7 @@ func NewDataTransmissionHandshake(SIZE uint32, WIDTH uint16, HEIGHT uint16, PACK
 }
 
 // Id returns the DataTransmissionHandshake Message ID
-func (*DataTransmissionHandshake) Id() uint8 {
+func (*DataTransmissionHandshake) Id() uint32 {
 	return 130
 }
 
@@ -10518,7 +10668,7 @@ func NewEncapsulatedData(SEQNR uint16, DATA [253]uint8) *EncapsulatedData {
 }
 
 // Id returns the EncapsulatedData Message ID
-func (*EncapsulatedData) Id() uint8 {
+func (*EncapsulatedData) Id() uint32 {
 	return 131
 }
 
@@ -10592,7 +10742,7 @@ func NewDistanceSensor(TIME_BOOT_MS uint32, MIN_DISTANCE uint16, MAX_DISTANCE ui
 }
 
 // Id returns the DistanceSensor Message ID
-func (*DistanceSensor) Id() uint8 {
+func (*DistanceSensor) Id() uint32 {
 	return 132
 }
 
@@ -10690,7 +10840,7 @@ func NewTerrainRequest(MASK uint64, LAT int32, LON int32, GRID_SPACING uint16) *
 }
 
 // Id returns the TerrainRequest Message ID
-func (*TerrainRequest) Id() uint8 {
+func (*TerrainRequest) Id() uint32 {
 	return 133
 }
 
@@ -10766,7 +10916,7 @@ func NewTerrainData(LAT int32, LON int32, GRID_SPACING uint16, DATA [16]int16, G
 }
 
 // Id returns the TerrainData Message ID
-func (*TerrainData) Id() uint8 {
+func (*TerrainData) Id() uint32 {
 	return 134
 }
 
@@ -10846,7 +10996,7 @@ func NewTerrainCheck(LAT int32, LON int32) *TerrainCheck {
 }
 
 // Id returns the TerrainCheck Message ID
-func (*TerrainCheck) Id() uint8 {
+func (*TerrainCheck) Id() uint32 {
 	return 135
 }
 
@@ -10914,7 +11064,7 @@ func NewTerrainReport(LAT int32, LON int32, TERRAIN_HEIGHT float32, CURRENT_HEIG
 }
 
 // Id returns the TerrainReport Message ID
-func (*TerrainReport) Id() uint8 {
+func (*TerrainReport) Id() uint32 {
 	return 136
 }
 
@@ -10990,7 +11140,7 @@ func (m *TerrainReport) Decode(buf []byte) {
 // MAVLINK_MSG_ID_BATTERY_STATUS_CRC 177
 type BatteryStatus struct {
 	CURRENT_CONSUMED  int32  // Consumed charge, in milliampere hours (1 = 1 mAh), -1: autopilot does not provide mAh consumption estimate
-	ENERGY_CONSUMED   int32  // Consumed energy, in 100*Joules (integrated U*I*dt)  (1 = 100 Joule), -1: autopilot does not provide energy consumption estimate
+	ENERGY_CONSUMED   int32  // Consumed energy, in 100*Joules (integrated U*I*dt) (1 = 100 Joule), -1: autopilot does not provide energy consumption estimate
 	VOLTAGE_CELL_1    uint16 // Battery voltage of cell 1, in millivolts (1 = 1 millivolt)
 	VOLTAGE_CELL_2    uint16 // Battery voltage of cell 2, in millivolts (1 = 1 millivolt), -1: no cell
 	VOLTAGE_CELL_3    uint16 // Battery voltage of cell 3, in millivolts (1 = 1 millivolt), -1: no cell
@@ -11020,7 +11170,7 @@ func NewBatteryStatus(CURRENT_CONSUMED int32, ENERGY_CONSUMED int32, VOLTAGE_CEL
 }
 
 // Id returns the BatteryStatus Message ID
-func (*BatteryStatus) Id() uint8 {
+func (*BatteryStatus) Id() uint32 {
 	return 147
 }
 
@@ -11146,7 +11296,7 @@ func NewSetpoint8Dof(VAL1 float32, VAL2 float32, VAL3 float32, VAL4 float32, VAL
 }
 
 // Id returns the Setpoint8Dof Message ID
-func (*Setpoint8Dof) Id() uint8 {
+func (*Setpoint8Dof) Id() uint32 {
 	return 148
 }
 
@@ -11256,7 +11406,7 @@ func NewSetpoint6Dof(TRANS_X float32, TRANS_Y float32, TRANS_Z float32, ROT_X fl
 }
 
 // Id returns the Setpoint6Dof Message ID
-func (*Setpoint6Dof) Id() uint8 {
+func (*Setpoint6Dof) Id() uint32 {
 	return 149
 }
 
@@ -11348,7 +11498,7 @@ func NewMemoryVect(ADDRESS uint16, VER uint8, TYPE uint8, VALUE [32]int8) *Memor
 }
 
 // Id returns the MemoryVect Message ID
-func (*MemoryVect) Id() uint8 {
+func (*MemoryVect) Id() uint32 {
 	return 249
 }
 
@@ -11428,7 +11578,7 @@ func NewDebugVect(TIME_USEC uint64, X float32, Y float32, Z float32, NAME [10]ui
 }
 
 // Id returns the DebugVect Message ID
-func (*DebugVect) Id() uint8 {
+func (*DebugVect) Id() uint32 {
 	return 250
 }
 
@@ -11510,7 +11660,7 @@ func NewNamedValueFloat(TIME_BOOT_MS uint32, VALUE float32, NAME [10]uint8) *Nam
 }
 
 // Id returns the NamedValueFloat Message ID
-func (*NamedValueFloat) Id() uint8 {
+func (*NamedValueFloat) Id() uint32 {
 	return 251
 }
 
@@ -11580,7 +11730,7 @@ func NewNamedValueInt(TIME_BOOT_MS uint32, VALUE int32, NAME [10]uint8) *NamedVa
 }
 
 // Id returns the NamedValueInt Message ID
-func (*NamedValueInt) Id() uint8 {
+func (*NamedValueInt) Id() uint32 {
 	return 252
 }
 
@@ -11648,7 +11798,7 @@ func NewStatustext(SEVERITY uint8, TEXT [50]uint8) *Statustext {
 }
 
 // Id returns the Statustext Message ID
-func (*Statustext) Id() uint8 {
+func (*Statustext) Id() uint32 {
 	return 253
 }
 
@@ -11712,7 +11862,7 @@ func NewDebug(TIME_BOOT_MS uint32, VALUE float32, IND uint8) *Debug {
 }
 
 // Id returns the Debug Message ID
-func (*Debug) Id() uint8 {
+func (*Debug) Id() uint32 {
 	return 254
 }
 
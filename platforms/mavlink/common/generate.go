@@ -0,0 +1,3 @@
+package mavlink
+
+//go:generate go run gobot.io/x/gobot/v2/platforms/mavlink/mavgen -package mavlink -output common.go common.xml
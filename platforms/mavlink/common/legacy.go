@@ -0,0 +1,96 @@
+//nolint:gocritic,lll // the same as the generated code
+package mavlink
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// The message LOCAL_POSITION_SETPOINT was removed from the MAVLink dialect, the id 51 is used by MISSION_REQUEST_INT
+// since then. The type is kept to not break the build of existing code, but received packets with id 51 are decoded
+// as MissionRequestInt.
+
+// MAVLINK_MSG_ID_LOCAL_POSITION_SETPOINT 51
+//
+// MAVLINK_MSG_ID_LOCAL_POSITION_SETPOINT_LEN 17
+//
+// MAVLINK_MSG_ID_LOCAL_POSITION_SETPOINT_CRC 223
+//
+// Deprecated: The message is not part of the MAVLink dialect anymore and can not be received, use
+// SetLocalPositionSetpoint to send a local position setpoint.
+type LocalPositionSetpoint struct {
+	X                float32 // x position
+	Y                float32 // y position
+	Z                float32 // z position
+	YAW              float32 // Desired yaw angle
+	COORDINATE_FRAME uint8   // Coordinate frame - valid values are only MAV_FRAME_LOCAL_NED or MAV_FRAME_LOCAL_ENU
+}
+
+// NewLocalPositionSetpoint returns a new LocalPositionSetpoint
+//
+// Deprecated: The message is not part of the MAVLink dialect anymore, see LocalPositionSetpoint.
+func NewLocalPositionSetpoint(X float32, Y float32, Z float32, YAW float32, COORDINATE_FRAME uint8) *LocalPositionSetpoint {
+	m := LocalPositionSetpoint{}
+	m.X = X
+	m.Y = Y
+	m.Z = Z
+	m.YAW = YAW
+	m.COORDINATE_FRAME = COORDINATE_FRAME
+	return &m
+}
+
+// Id returns the LocalPositionSetpoint Message ID
+func (*LocalPositionSetpoint) Id() uint32 {
+	return 51
+}
+
+// Len returns the LocalPositionSetpoint Message Length
+func (*LocalPositionSetpoint) Len() uint8 {
+	return 17
+}
+
+// Crc returns the LocalPositionSetpoint Message CRC
+func (*LocalPositionSetpoint) Crc() uint8 {
+	return 223
+}
+
+// Pack returns a packed byte array which represents a LocalPositionSetpoint payload
+func (m *LocalPositionSetpoint) Pack() []byte {
+	data := new(bytes.Buffer)
+	if err := binary.Write(data, binary.LittleEndian, m.X); err != nil {
+		panic(err)
+	}
+	if err := binary.Write(data, binary.LittleEndian, m.Y); err != nil {
+		panic(err)
+	}
+	if err := binary.Write(data, binary.LittleEndian, m.Z); err != nil {
+		panic(err)
+	}
+	if err := binary.Write(data, binary.LittleEndian, m.YAW); err != nil {
+		panic(err)
+	}
+	if err := binary.Write(data, binary.LittleEndian, m.COORDINATE_FRAME); err != nil {
+		panic(err)
+	}
+	return data.Bytes()
+}
+
+// Decode accepts a packed byte array and populates the fields of the LocalPositionSetpoint
+func (m *LocalPositionSetpoint) Decode(buf []byte) {
+	data := bytes.NewBuffer(buf)
+	if err := binary.Read(data, binary.LittleEndian, &m.X); err != nil {
+		panic(err)
+	}
+	if err := binary.Read(data, binary.LittleEndian, &m.Y); err != nil {
+		panic(err)
+	}
+	if err := binary.Read(data, binary.LittleEndian, &m.Z); err != nil {
+		panic(err)
+	}
+	if err := binary.Read(data, binary.LittleEndian, &m.YAW); err != nil {
+		panic(err)
+	}
+	if err := binary.Read(data, binary.LittleEndian, &m.COORDINATE_FRAME); err != nil {
+		panic(err)
+	}
+}
@@ -9,58 +9,75 @@ import (
 	"bytes"
 	"encoding/binary"
 	"io"
+	"sync/atomic"
 	"time"
 )
 
 const (
-	MAVLINK_BIG_ENDIAN     = 0
-	MAVLINK_LITTLE_ENDIAN  = 1
-	MAVLINK_10_STX         = 254
-	MAVLINK_20_STX         = 253
-	MAVLINK_ENDIAN         = MAVLINK_LITTLE_ENDIAN
-	MAVLINK_ALIGNED_FIELDS = 1
-	MAVLINK_CRC_EXTRA      = 1
-	X25_INIT_CRC           = 0xffff
-	X25_VALIDATE_CRC       = 0xf0b8
+	MAVLINK_BIG_ENDIAN          = 0
+	MAVLINK_LITTLE_ENDIAN       = 1
+	MAVLINK_10_STX              = 254
+	MAVLINK_20_STX              = 253
+	MAVLINK_ENDIAN              = MAVLINK_LITTLE_ENDIAN
+	MAVLINK_ALIGNED_FIELDS      = 1
+	MAVLINK_CRC_EXTRA           = 1
+	MAVLINK_10_HEADER_LEN       = 6
+	MAVLINK_20_HEADER_LEN       = 10
+	MAVLINK_IFLAG_SIGNED        = 0x01
+	MAVLINK_SIGNATURE_BLOCK_LEN = 13
+	MAVLINK_MAX_MESSAGE_ID      = 0xFFFFFF
+	X25_INIT_CRC                = 0xffff
+	X25_VALIDATE_CRC            = 0xf0b8
 )
 
-var sequence uint16 = 0
+var sequence atomic.Uint32
 
 func generateSequence() uint8 {
-	sequence = (sequence + 1) % 256
-	return uint8(sequence) //nolint:gosec // ok here
+	return uint8(sequence.Add(1)) //nolint:gosec // wrap around is intended
 }
 
 // The MAVLinkMessage interface is implemented by MAVLink messages
 type MAVLinkMessage interface {
-	Id() uint8
+	Id() uint32
 	Len() uint8
 	Crc() uint8
 	Pack() []byte
 	Decode(buf []byte)
 }
 
-// A MAVLinkPacket represents a raw packet received from a micro air vehicle
+// The MAVLinkExtensionMessage interface is implemented by MAVLink messages with extension fields. The extension fields
+// are only transmitted by MAVLink 2 frames, so they are zero when the message was received by a MAVLink 1 frame.
+type MAVLinkExtensionMessage interface {
+	MAVLinkMessage
+	BaseLen() uint8 // the payload length without the extension fields
+}
+
+// A MAVLinkPacket represents a raw packet received from a micro air vehicle. The Protocol contains the start
+// of frame marker, which is MAVLINK_10_STX for MAVLink 1 and MAVLINK_20_STX for MAVLink 2 packets.
 type MAVLinkPacket struct {
-	Protocol    uint8
-	Length      uint8
-	Sequence    uint8
-	SystemID    uint8
-	ComponentID uint8
-	MessageID   uint8
-	Data        []uint8
-	Checksum    uint16
-}
-
-// ReadMAVLinkPacket reads an io.Reader for a new packet and returns a new MAVLink packet
-// or returns the error received by the io.Reader
+	Protocol      uint8
+	Length        uint8
+	IncompatFlags uint8 // MAVLink 2 only
+	CompatFlags   uint8 // MAVLink 2 only
+	Sequence      uint8
+	SystemID      uint8
+	ComponentID   uint8
+	MessageID     uint32 // 24-bit for MAVLink 2, 8-bit for MAVLink 1
+	Data          []uint8
+	Checksum      uint16
+	Signature     *MAVLinkSignature // MAVLink 2 only, nil for unsigned packets
+}
+
+// ReadMAVLinkPacket reads an io.Reader for a new MAVLink 1 or MAVLink 2 packet and returns a new MAVLink packet
+// or returns the error received by the io.Reader. Packets with unknown incompatibility flags are skipped.
 func ReadMAVLinkPacket(r io.Reader) (*MAVLinkPacket, error) {
 	for {
 		header, err := read(r, 1)
 		if err != nil {
 			return nil, err
 		}
-		if header[0] == 254 {
+		switch header[0] {
+		case MAVLINK_10_STX:
 			length, err := read(r, 1)
 			if err != nil {
 				return nil, err
@@ -68,38 +85,90 @@ func ReadMAVLinkPacket(r io.Reader) (*MAVLinkPacket, error) {
 				continue
 			}
 			m := &MAVLinkPacket{}
-			data, err := read(r, int(length[0])+7)
+			data, err := read(r, MAVLINK_10_HEADER_LEN-2+int(length[0])+2)
 			if err != nil {
 				return nil, err
 			}
 			data = append([]byte{header[0], length[0]}, data...)
 			m.Decode(data)
 			return m, nil
+		case MAVLINK_20_STX:
+			head, err := read(r, 2)
+			if err != nil {
+				return nil, err
+			}
+			incompatFlags := head[1]
+			if incompatFlags&^MAVLINK_IFLAG_SIGNED != 0 {
+				continue
+			}
+			n := MAVLINK_20_HEADER_LEN - 3 + int(head[0]) + 2
+			if incompatFlags&MAVLINK_IFLAG_SIGNED != 0 {
+				n += MAVLINK_SIGNATURE_BLOCK_LEN
+			}
+			m := &MAVLinkPacket{}
+			data, err := read(r, n)
+			if err != nil {
+				return nil, err
+			}
+			data = append([]byte{header[0], head[0], head[1]}, data...)
+			m.Decode(data)
+			return m, nil
 		}
 	}
 }
 
-// CraftMAVLinkPacket returns a new MAVLinkPacket from a MAVLinkMessage
+// CraftMAVLinkPacket returns a new MAVLink 1 packet from a MAVLinkMessage. Extension fields are not transmitted by
+// MAVLink 1, and messages with an ID above 255 can only be transmitted by MAVLink 2, so a MAVLink 2 packet is returned
+// for them.
 func CraftMAVLinkPacket(systemID uint8, cComponentID uint8, mMessage MAVLinkMessage) *MAVLinkPacket {
+	if mMessage.Id() > 0xFF {
+		return CraftMAVLink2Packet(systemID, cComponentID, mMessage)
+	}
+
+	length := mMessage.Len()
+	if em, ok := mMessage.(MAVLinkExtensionMessage); ok {
+		length = em.BaseLen()
+	}
+
 	return NewMAVLinkPacket(
-		0xFE,
-		mMessage.Len(),
+		MAVLINK_10_STX,
+		length,
 		generateSequence(),
 		systemID,
 		cComponentID,
 		mMessage.Id(),
-		mMessage.Pack(),
+		mMessage.Pack()[:length],
 	)
 }
 
-// NewMAVLinkPacket returns a new MAVLinkPacket
+// CraftMAVLink2Packet returns a new MAVLink 2 packet from a MAVLinkMessage. The trailing zero bytes of the payload are
+// truncated, like the MAVLink 2 specification demands.
+func CraftMAVLink2Packet(systemID uint8, componentID uint8, message MAVLinkMessage) *MAVLinkPacket {
+	data := message.Pack()
+	length := len(data)
+	for length > 1 && data[length-1] == 0 {
+		length--
+	}
+
+	return NewMAVLinkPacket(
+		MAVLINK_20_STX,
+		uint8(length), //nolint:gosec // the payload has max. 255 bytes
+		generateSequence(),
+		systemID,
+		componentID,
+		message.Id(),
+		data[:length],
+	)
+}
+
+// NewMAVLinkPacket returns a new MAVLinkPacket. The protocol version is given by the start of frame marker pProtocol.
 func NewMAVLinkPacket(
 	pProtocol uint8,
 	lLength uint8,
 	sSequence uint8,
 	sSystemID uint8,
 	cComponentID uint8,
-	mMessageID uint8,
+	mMessageID uint32,
 	dData []uint8,
 ) *MAVLinkPacket {
 	m := &MAVLinkPacket{
@@ -115,70 +184,86 @@ func NewMAVLinkPacket(
 	return m
 }
 
+// Version returns the MAVLink protocol version of the packet, which is 1 or 2.
+func (m *MAVLinkPacket) Version() int {
+	if m.Protocol == MAVLINK_20_STX {
+		return 2
+	}
+	return 1
+}
+
 // MAVLinkMessage returns the decoded MAVLinkMessage from the MAVLinkPacket
-// or returns an error generated from the MAVLinkMessage
+// or returns an error generated from the MAVLinkMessage. A truncated payload,
+// e.g. by MAVLink 2 or because of missing extension fields, is filled with zeros.
 func (m *MAVLinkPacket) MAVLinkMessage() (MAVLinkMessage, error) {
-	return NewMAVLinkMessage(m.MessageID, m.Data)
+	data := m.Data
+	if message, ok := messages[m.MessageID]; ok && len(data) < int(message.Len()) {
+		data = append(append([]byte{}, data...), make([]byte, int(message.Len())-len(data))...)
+	}
+	return NewMAVLinkMessage(m.MessageID, data)
 }
 
 // Pack returns a packed byte array which represents the MAVLinkPacket
 func (m *MAVLinkPacket) Pack() []byte {
 	data := new(bytes.Buffer)
-	if err := binary.Write(data, binary.LittleEndian, m.Protocol); err != nil {
-		panic(err)
-	}
-	if err := binary.Write(data, binary.LittleEndian, m.Length); err != nil {
-		panic(err)
-	}
-	if err := binary.Write(data, binary.LittleEndian, m.Sequence); err != nil {
-		panic(err)
-	}
-	if err := binary.Write(data, binary.LittleEndian, m.SystemID); err != nil {
-		panic(err)
-	}
-	if err := binary.Write(data, binary.LittleEndian, m.ComponentID); err != nil {
-		panic(err)
-	}
-	if err := binary.Write(data, binary.LittleEndian, m.MessageID); err != nil {
-		panic(err)
+	data.Write([]byte{m.Protocol, m.Length})
+	if m.Version() == 2 {
+		data.Write([]byte{m.IncompatFlags, m.CompatFlags, m.Sequence, m.SystemID, m.ComponentID,
+			byte(m.MessageID), byte(m.MessageID >> 8), byte(m.MessageID >> 16)})
+	} else {
+		//nolint:gosec // the message ID of MAVLink 1 has 8 bits
+		data.Write([]byte{m.Sequence, m.SystemID, m.ComponentID, byte(m.MessageID)})
 	}
 	data.Write(m.Data)
 	if err := binary.Write(data, binary.LittleEndian, m.Checksum); err != nil {
 		panic(err)
 	}
+	if m.Version() == 2 && m.Signature != nil {
+		data.Write(m.Signature.pack())
+	}
 	return data.Bytes()
 }
 
-// Decode accepts a packed byte array and populates the fields of the MAVLinkPacket
+// Decode accepts a packed byte array of a MAVLink 1 or MAVLink 2 frame and populates the fields of the MAVLinkPacket
 func (m *MAVLinkPacket) Decode(buf []byte) {
 	m.Protocol = buf[0]
 	m.Length = buf[1]
-	m.Sequence = buf[2]
-	m.SystemID = buf[3]
-	m.ComponentID = buf[4]
-	m.MessageID = buf[5]
-	m.Data = buf[6 : 6+int(m.Length)]
-	checksum := buf[7+int(m.Length):]
+	headerLen := MAVLINK_10_HEADER_LEN
+	if m.Version() == 2 {
+		headerLen = MAVLINK_20_HEADER_LEN
+		m.IncompatFlags = buf[2]
+		m.CompatFlags = buf[3]
+		m.Sequence = buf[4]
+		m.SystemID = buf[5]
+		m.ComponentID = buf[6]
+		m.MessageID = uint32(buf[7]) | uint32(buf[8])<<8 | uint32(buf[9])<<16
+	} else {
+		m.Sequence = buf[2]
+		m.SystemID = buf[3]
+		m.ComponentID = buf[4]
+		m.MessageID = uint32(buf[5])
+	}
+	m.Data = buf[headerLen : headerLen+int(m.Length)]
+	checksum := buf[headerLen+int(m.Length):]
 	m.Checksum = uint16(checksum[1])<<8 | uint16(checksum[0])
+	m.Signature = nil
+	if m.Version() == 2 && m.IncompatFlags&MAVLINK_IFLAG_SIGNED != 0 {
+		m.Signature = decodeSignature(checksum[2 : 2+MAVLINK_SIGNATURE_BLOCK_LEN])
+	}
 }
 
 func read(r io.Reader, length int) ([]byte, error) {
-	buf := []byte{}
-	for length > 0 {
-		tmp := make([]byte, length)
-		i, err := r.Read(tmp)
+	buf := make([]byte, length)
+	for n := 0; n < length; {
+		i, err := r.Read(buf[n:])
 		if err != nil {
 			return nil, err
 		}
 
-		length -= i
-		buf = append(buf, tmp...)
-		if length != i {
+		n += i
+		if n < length {
 			time.Sleep(1 * time.Millisecond)
-		} else {
-			break
 		}
-
 	}
 	return buf, nil
 }
@@ -211,10 +296,15 @@ func crcInit() uint16 {
 func crcCalculate(m *MAVLinkPacket) uint16 {
 	crc := crcInit()
 
-	for _, v := range m.Pack()[1 : m.Length+6] {
+	headerLen := MAVLINK_10_HEADER_LEN
+	if m.Version() == 2 {
+		headerLen = MAVLINK_20_HEADER_LEN
+	}
+	for _, v := range m.Pack()[1 : int(m.Length)+headerLen] {
 		crc = crcAccumulate(v, crc)
 	}
-	message, _ := m.MAVLinkMessage()
-	crc = crcAccumulate(message.Crc(), crc)
+	if message, ok := messages[m.MessageID]; ok {
+		crc = crcAccumulate(message.Crc(), crc)
+	}
 	return crc
 }
@@ -0,0 +1,162 @@
+package mavlink
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// signingEpoch is the start of the MAVLink 2 signing timestamps, which are given in units of 10 microseconds
+var signingEpoch = time.Date(2015, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// signingTimestampMaxAge is the maximum age of the timestamp of the first packet of a new stream
+const signingTimestampMaxAge = time.Minute
+
+// MAVLinkSignature represents the signature block of a signed MAVLink 2 packet
+type MAVLinkSignature struct {
+	LinkID    uint8
+	Timestamp uint64 // 48-bit, in units of 10 microseconds since 1st January 2015 GMT
+	Signature [6]byte
+}
+
+// MAVLinkSigningTimestamp returns the MAVLink 2 signing timestamp of the given time
+func MAVLinkSigningTimestamp(t time.Time) uint64 {
+	//nolint:gosec // times before the epoch are not supported
+	return uint64(t.Sub(signingEpoch) / (10 * time.Microsecond))
+}
+
+// Sign converts the packet to a signed MAVLink 2 packet by the given secret key, link ID and timestamp. The checksum is
+// recalculated, because the incompatibility flags are changed.
+func (m *MAVLinkPacket) Sign(secretKey [32]byte, linkID uint8, timestamp uint64) error {
+	if m.Version() != 2 {
+		return errors.New("MAVLink 1 packets can not be signed")
+	}
+
+	m.IncompatFlags |= MAVLINK_IFLAG_SIGNED
+	m.Checksum = crcCalculate(m)
+	m.Signature = &MAVLinkSignature{LinkID: linkID, Timestamp: timestamp & 0xFFFFFFFFFFFF}
+	m.Signature.Signature = m.signature(secretKey)
+
+	return nil
+}
+
+// SignatureValid returns true, if the packet is signed by the given secret key
+func (m *MAVLinkPacket) SignatureValid(secretKey [32]byte) bool {
+	if m.Version() != 2 || m.Signature == nil || m.IncompatFlags&MAVLINK_IFLAG_SIGNED == 0 {
+		return false
+	}
+
+	return m.signature(secretKey) == m.Signature.Signature
+}
+
+// signature calculates the first 48 bits of the SHA-256 hash over the secret key, the packet and the signature block
+func (m *MAVLinkPacket) signature(secretKey [32]byte) [6]byte {
+	h := sha256.New()
+	h.Write(secretKey[:])
+	packed := m.Pack()
+	// the packed signature is not part of the hash
+	h.Write(packed[:MAVLINK_20_HEADER_LEN+int(m.Length)+2])
+	h.Write(m.Signature.pack()[:7])
+
+	var sig [6]byte
+	copy(sig[:], h.Sum(nil))
+	return sig
+}
+
+func (s *MAVLinkSignature) pack() []byte {
+	data := new(bytes.Buffer)
+	data.WriteByte(s.LinkID)
+	ts := make([]byte, 8)
+	binary.LittleEndian.PutUint64(ts, s.Timestamp)
+	data.Write(ts[:6])
+	data.Write(s.Signature[:])
+	return data.Bytes()
+}
+
+func decodeSignature(buf []byte) *MAVLinkSignature {
+	s := &MAVLinkSignature{LinkID: buf[0]}
+	ts := make([]byte, 8)
+	copy(ts, buf[1:7])
+	s.Timestamp = binary.LittleEndian.Uint64(ts)
+	copy(s.Signature[:], buf[7:13])
+	return s
+}
+
+// signingStream identifies a stream of signed packets for the replay protection
+type signingStream struct {
+	systemID    uint8
+	componentID uint8
+	linkID      uint8
+}
+
+// MAVLinkSigner signs outgoing MAVLink 2 packets with increasing timestamps and verifies incoming packets, including
+// the replay protection by the timestamps of each stream.
+type MAVLinkSigner struct {
+	secretKey     [32]byte
+	linkID        uint8
+	now           func() time.Time
+	lastTimestamp uint64
+	streams       map[signingStream]uint64
+	mutex         sync.Mutex
+}
+
+// NewMAVLinkSigner creates a new signer with the given secret key, which is shared by all participants of the network,
+// and the ID of the outgoing link.
+func NewMAVLinkSigner(secretKey [32]byte, linkID uint8) *MAVLinkSigner {
+	return &MAVLinkSigner{
+		secretKey: secretKey,
+		linkID:    linkID,
+		now:       time.Now,
+		streams:   make(map[signingStream]uint64),
+	}
+}
+
+// Sign signs the MAVLink 2 packet. The timestamp is taken from the clock, but is at least increased by one for each
+// packet.
+func (s *MAVLinkSigner) Sign(m *MAVLinkPacket) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	timestamp := MAVLinkSigningTimestamp(s.now())
+	if timestamp <= s.lastTimestamp {
+		timestamp = s.lastTimestamp + 1
+	}
+	if err := m.Sign(s.secretKey, s.linkID, timestamp); err != nil {
+		return err
+	}
+	s.lastTimestamp = timestamp
+
+	return nil
+}
+
+// Verify returns an error, if the packet is not signed, the signature is invalid or the timestamp is not newer than the
+// last timestamp of the stream. The first timestamp of a new stream must not be older than one minute.
+func (s *MAVLinkSigner) Verify(m *MAVLinkPacket) error {
+	if m.Signature == nil {
+		return fmt.Errorf("MAVLink packet %d from system %d is not signed", m.MessageID, m.SystemID)
+	}
+	if !m.SignatureValid(s.secretKey) {
+		return fmt.Errorf("MAVLink packet %d from system %d has an invalid signature", m.MessageID, m.SystemID)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stream := signingStream{systemID: m.SystemID, componentID: m.ComponentID, linkID: m.Signature.LinkID}
+	if last, ok := s.streams[stream]; ok {
+		if m.Signature.Timestamp <= last {
+			return fmt.Errorf("MAVLink packet %d from system %d is replayed (timestamp %d, last %d)", m.MessageID,
+				m.SystemID, m.Signature.Timestamp, last)
+		}
+	} else if m.Signature.Timestamp < MAVLinkSigningTimestamp(s.now().Add(-signingTimestampMaxAge)) {
+		return fmt.Errorf("MAVLink packet %d from system %d is too old (timestamp %d)", m.MessageID, m.SystemID,
+			m.Signature.Timestamp)
+	}
+	s.streams[stream] = m.Signature.Timestamp
+
+	return nil
+}
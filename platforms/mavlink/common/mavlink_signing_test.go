@@ -0,0 +1,75 @@
+package mavlink
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSecretKey = [32]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25,
+	26, 27, 28, 29, 30, 31, 32}
+
+func TestMAVLinkSigningTimestamp(t *testing.T) {
+	assert.Equal(t, uint64(0), MAVLinkSigningTimestamp(time.Date(2015, time.January, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, uint64(100000), MAVLinkSigningTimestamp(time.Date(2015, time.January, 1, 0, 0, 1, 0, time.UTC)))
+}
+
+func TestSign(t *testing.T) {
+	// arrange
+	p := CraftMAVLink2Packet(1, 1, NewAttitude(1, 2, 3, 4, 5, 6, 7))
+	// act
+	err := p.Sign(testSecretKey, 5, 0x0102030405060708)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, uint8(MAVLINK_IFLAG_SIGNED), p.IncompatFlags)
+	assert.Equal(t, uint8(5), p.Signature.LinkID)
+	assert.Equal(t, uint64(0x030405060708), p.Signature.Timestamp)
+	assert.True(t, p.SignatureValid(testSecretKey))
+	assert.False(t, p.SignatureValid([32]byte{}))
+	// act & assert: the signature block is transmitted
+	packed := p.Pack()
+	assert.Len(t, packed, MAVLINK_20_HEADER_LEN+int(p.Length)+2+MAVLINK_SIGNATURE_BLOCK_LEN)
+	got, err := ReadMAVLinkPacket(bytes.NewReader(packed))
+	require.NoError(t, err)
+	assert.Equal(t, p.Signature, got.Signature)
+	assert.Equal(t, p.Checksum, crcCalculate(got))
+	assert.True(t, got.SignatureValid(testSecretKey))
+	// act & assert: manipulated payload
+	got.Data[0]++
+	assert.False(t, got.SignatureValid(testSecretKey))
+	// act & assert: MAVLink 1
+	require.EqualError(t, CraftMAVLinkPacket(1, 1, NewAttitude(1, 2, 3, 4, 5, 6, 7)).Sign(testSecretKey, 0, 0),
+		"MAVLink 1 packets can not be signed")
+}
+
+func TestMAVLinkSigner(t *testing.T) {
+	// arrange
+	now := time.Date(2024, time.May, 1, 0, 0, 0, 0, time.UTC)
+	sender := NewMAVLinkSigner(testSecretKey, 1)
+	sender.now = func() time.Time { return now }
+	receiver := NewMAVLinkSigner(testSecretKey, 2)
+	receiver.now = func() time.Time { return now }
+	first := CraftMAVLink2Packet(1, 1, NewAttitude(1, 0, 0, 0, 0, 0, 0))
+	second := CraftMAVLink2Packet(1, 1, NewAttitude(2, 0, 0, 0, 0, 0, 0))
+	// act
+	require.NoError(t, sender.Sign(first))
+	require.NoError(t, sender.Sign(second))
+	// assert: the timestamp is increased, although the clock stands still
+	assert.Equal(t, MAVLinkSigningTimestamp(now), first.Signature.Timestamp)
+	assert.Equal(t, first.Signature.Timestamp+1, second.Signature.Timestamp)
+	require.NoError(t, receiver.Verify(first))
+	require.NoError(t, receiver.Verify(second))
+	require.ErrorContains(t, receiver.Verify(first), "MAVLink packet 30 from system 1 is replayed")
+	require.EqualError(t, receiver.Verify(CraftMAVLink2Packet(1, 1, NewAttitude(3, 0, 0, 0, 0, 0, 0))),
+		"MAVLink packet 30 from system 1 is not signed")
+	wrongKey := CraftMAVLink2Packet(1, 1, NewAttitude(3, 0, 0, 0, 0, 0, 0))
+	require.NoError(t, wrongKey.Sign([32]byte{}, 1, MAVLinkSigningTimestamp(now)+10))
+	require.EqualError(t, receiver.Verify(wrongKey), "MAVLink packet 30 from system 1 has an invalid signature")
+	// act & assert: the first packet of a new stream must not be older than a minute
+	old := CraftMAVLink2Packet(2, 1, NewAttitude(3, 0, 0, 0, 0, 0, 0))
+	require.NoError(t, old.Sign(testSecretKey, 1, MAVLinkSigningTimestamp(now.Add(-2*time.Minute))))
+	require.ErrorContains(t, receiver.Verify(old), "MAVLink packet 30 from system 2 is too old")
+}
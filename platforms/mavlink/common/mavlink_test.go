@@ -0,0 +1,197 @@
+package mavlink
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// heartbeat, MAVLink 1, sequence 0x4E, system 1, component 1
+var testHeartbeatV1 = []byte{
+	0xFE, 0x09, 0x4E, 0x01, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0x03, 0x51, 0x04, 0x03, 0x1C,
+}
+
+// testExtensionMessage is an attitude message with an additional extension field
+type testExtensionMessage struct {
+	Attitude
+	EXTENSION uint16
+}
+
+func (*testExtensionMessage) Id() uint32     { return 0x012345 }
+func (*testExtensionMessage) Len() uint8     { return 30 }
+func (*testExtensionMessage) BaseLen() uint8 { return 28 }
+
+func (m *testExtensionMessage) Pack() []byte {
+	return append(m.Attitude.Pack(), byte(m.EXTENSION), byte(m.EXTENSION>>8))
+}
+
+func (m *testExtensionMessage) Decode(buf []byte) {
+	m.Attitude.Decode(buf)
+	m.EXTENSION = uint16(buf[28]) | uint16(buf[29])<<8
+}
+
+func TestCrcAccumulate(t *testing.T) {
+	// arrange
+	crc := crcInit()
+	// act
+	for _, b := range []byte("123456789") {
+		crc = crcAccumulate(b, crc)
+	}
+	// assert: the check value of CRC-16/MCRF4XX
+	assert.Equal(t, uint16(0x6F91), crc)
+}
+
+func TestReadMAVLinkPacketV1(t *testing.T) {
+	// arrange
+	r := bytes.NewReader(append(append([]byte{0x00, 0x01}, testHeartbeatV1...), MAVLINK_10_STX))
+	// act
+	p, err := ReadMAVLinkPacket(r)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, p.Version())
+	assert.Equal(t, uint8(9), p.Length)
+	assert.Equal(t, uint8(0x4E), p.Sequence)
+	assert.Equal(t, uint8(1), p.SystemID)
+	assert.Equal(t, uint8(1), p.ComponentID)
+	assert.Equal(t, uint32(0), p.MessageID)
+	assert.Equal(t, uint16(0x1C03), p.Checksum)
+	assert.Equal(t, testHeartbeatV1, p.Pack())
+	assert.Equal(t, 1, r.Len(), "the next frame must not be consumed")
+	msg, err := p.MAVLinkMessage()
+	require.NoError(t, err)
+	assert.Equal(t, uint8(0x51), msg.(*Heartbeat).SYSTEM_STATUS)
+}
+
+func TestNewMAVLinkPacketV1(t *testing.T) {
+	// arrange
+	data := testHeartbeatV1[6:15]
+	// act
+	p := NewMAVLinkPacket(MAVLINK_10_STX, 9, 0x4E, 1, 1, 0, data)
+	// assert
+	assert.Equal(t, testHeartbeatV1[:15], p.Pack()[:15])
+}
+
+func TestMAVLink2RoundTrip(t *testing.T) {
+	tests := map[string]struct {
+		message     MAVLinkMessage
+		wantVersion int
+		wantLength  uint8
+		wantPayload []byte
+	}{
+		"v1_frame": {
+			message:     NewAttitude(1, 0, 0, 0, 0, 0, 0),
+			wantVersion: 1,
+			wantLength:  28,
+		},
+		"v2_truncated_payload": {
+			message: &testExtensionMessage{Attitude: *NewAttitude(0x0201, 0, 0, 0, 0, 0, 0)},
+			// the trailing zeros are truncated, but at least one byte remains
+			wantVersion: 2,
+			wantLength:  2,
+			wantPayload: []byte{0x01, 0x02},
+		},
+		"v2_extension": {
+			message:     &testExtensionMessage{Attitude: *NewAttitude(1, 0, 0, 0, 0, 0, 0), EXTENSION: 0x0304},
+			wantVersion: 2,
+			wantLength:  30,
+		},
+		"v2_empty_payload": {
+			message:     &testExtensionMessage{},
+			wantVersion: 2,
+			wantLength:  1,
+			wantPayload: []byte{0x00},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			messages[0x012345] = &testExtensionMessage{}
+			defer delete(messages, 0x012345)
+			// act
+			sent := CraftMAVLinkPacket(7, 8, tc.message)
+			if tc.wantVersion == 2 {
+				sent = CraftMAVLink2Packet(7, 8, tc.message)
+			}
+			got, err := ReadMAVLinkPacket(bytes.NewReader(sent.Pack()))
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantVersion, got.Version())
+			assert.Equal(t, tc.wantLength, got.Length)
+			if tc.wantPayload != nil {
+				assert.Equal(t, tc.wantPayload, got.Data)
+			}
+			assert.Equal(t, sent.Pack(), got.Pack())
+			assert.Equal(t, got.Checksum, crcCalculate(got))
+			assert.Equal(t, uint8(7), got.SystemID)
+			assert.Equal(t, uint8(8), got.ComponentID)
+			assert.Equal(t, tc.message.Id(), got.MessageID)
+			msg, err := got.MAVLinkMessage()
+			require.NoError(t, err)
+			assert.Equal(t, tc.message.Pack(), msg.Pack())
+		})
+	}
+}
+
+func TestCraftMAVLinkPacketWithExtension(t *testing.T) {
+	// arrange
+	messages[0x012345] = &testExtensionMessage{}
+	defer delete(messages, 0x012345)
+	msg := &testExtensionMessage{Attitude: *NewAttitude(1, 0, 0, 0, 0, 0, 0), EXTENSION: 0x0304}
+	// act
+	p := CraftMAVLinkPacket(1, 1, msg)
+	// assert: the ID needs MAVLink 2, the extension is transmitted
+	assert.Equal(t, 2, p.Version())
+	assert.Equal(t, uint32(0x012345), p.MessageID)
+	assert.Equal(t, []byte{0x45, 0x23, 0x01}, p.Pack()[7:10])
+	got, err := p.MAVLinkMessage()
+	require.NoError(t, err)
+	assert.Equal(t, uint16(0x0304), got.(*testExtensionMessage).EXTENSION)
+}
+
+func TestReadMAVLinkPacketSkipsUnknownIncompatFlags(t *testing.T) {
+	// arrange
+	p := CraftMAVLink2Packet(1, 1, NewAttitude(1, 0, 0, 0, 0, 0, 0))
+	unknown := CraftMAVLink2Packet(1, 1, NewAttitude(2, 0, 0, 0, 0, 0, 0))
+	unknown.IncompatFlags = 0x02
+	r := bytes.NewReader(append(unknown.Pack()[:3], p.Pack()...))
+	// act
+	got, err := ReadMAVLinkPacket(r)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, p.Pack(), got.Pack())
+}
+
+func TestReadMAVLinkPacketPartialReads(t *testing.T) {
+	// arrange
+	p := CraftMAVLink2Packet(1, 1, NewAttitude(1, 2, 3, 4, 5, 6, 7))
+	r := &oneByteReader{data: p.Pack()}
+	// act
+	got, err := ReadMAVLinkPacket(r)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, p.Pack(), got.Pack())
+}
+
+type oneByteReader struct {
+	data []byte
+}
+
+func (r *oneByteReader) Read(b []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, bytes.ErrTooLarge
+	}
+	b[0] = r.data[0]
+	r.data = r.data[1:]
+	return 1, nil
+}
+
+func TestUnknownMessageChecksum(t *testing.T) {
+	// act
+	p := NewMAVLinkPacket(MAVLINK_20_STX, 1, 0, 1, 1, 0xFFFFFF, []byte{0x01})
+	_, err := p.MAVLinkMessage()
+	// assert
+	assert.NotZero(t, p.Checksum)
+	require.EqualError(t, err, "Unknown Message ID: 16777215")
+}
@@ -0,0 +1,378 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxPayloadLen = 255
+	maxMessageID  = 0xFFFFFF
+)
+
+// dialect contains the enums and messages of a MAVLink XML dialect, including all enums and messages of the included
+// dialects
+type dialect struct {
+	name     string
+	version  string
+	enums    []*enum
+	messages []*message
+}
+
+type enum struct {
+	Name        string   `xml:"name,attr"`
+	Description string   `xml:"description"`
+	Entries     []*entry `xml:"entry"`
+}
+
+type entry struct {
+	Value       string   `xml:"value,attr"`
+	Name        string   `xml:"name,attr"`
+	Description string   `xml:"description"`
+	Params      []*param `xml:"param"`
+	value       int64
+}
+
+type param struct {
+	Index       int    `xml:"index,attr"`
+	Description string `xml:",chardata"`
+}
+
+type message struct {
+	ID          uint32
+	Name        string
+	Description string
+	Fields      []*field // all fields in the order of the XML definition, the extension fields last
+	source      string
+}
+
+type field struct {
+	Type        string `xml:"type,attr"`
+	Name        string `xml:"name,attr"`
+	Enum        string `xml:"enum,attr"`
+	Units       string `xml:"units,attr"`
+	Description string `xml:",chardata"`
+	Extension   bool   `xml:"-"`
+	baseType    string // the XML type without array length, e.g. "char" for "char[16]"
+	arrayLen    int
+	size        int // the size of a single element
+}
+
+// xmlDialect is the content of a single XML file
+type xmlDialect struct {
+	Includes []string   `xml:"include"`
+	Version  string     `xml:"version"`
+	Enums    []*enum    `xml:"enums>enum"`
+	Messages []*message `xml:"messages>message"`
+}
+
+// fieldTypes contains the size of all supported MAVLink types
+var fieldTypes = map[string]int{
+	"char":                    1,
+	"int8_t":                  1,
+	"uint8_t":                 1,
+	"uint8_t_mavlink_version": 1,
+	"int16_t":                 2,
+	"uint16_t":                2,
+	"int32_t":                 4,
+	"uint32_t":                4,
+	"float":                   4,
+	"int64_t":                 8,
+	"uint64_t":                8,
+	"double":                  8,
+}
+
+// UnmarshalXML decodes the message, which is needed to detect the "extensions" marker between the fields
+func (m *message) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "name":
+			m.Name = attr.Value
+		case "id":
+			id, err := strconv.ParseUint(attr.Value, 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid ID of message %s: %w", m.Name, err)
+			}
+			m.ID = uint32(id)
+		}
+	}
+
+	extension := false
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "description":
+				if err := d.DecodeElement(&m.Description, &t); err != nil {
+					return err
+				}
+			case "field":
+				f := &field{}
+				if err := d.DecodeElement(f, &t); err != nil {
+					return err
+				}
+				f.Extension = extension
+				m.Fields = append(m.Fields, f)
+			case "extensions":
+				extension = true
+				if err := d.Skip(); err != nil {
+					return err
+				}
+			default:
+				if err := d.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// loadDialect reads the dialect from the XML file and resolves the includes relative to the file
+func loadDialect(path string) (*dialect, error) {
+	d := &dialect{name: strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))}
+	if err := d.load(path, make(map[string]bool)); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(d.messages, func(i, j int) bool { return d.messages[i].ID < d.messages[j].ID })
+
+	return d, nil
+}
+
+func (d *dialect) load(path string, loaded map[string]bool) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	// each file is loaded only once, e.g. when common.xml is included by multiple dialects
+	if loaded[abs] {
+		return nil
+	}
+	loaded[abs] = true
+
+	f, err := os.Open(abs)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	x, err := parseDialect(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	for _, include := range x.Includes {
+		if err := d.load(filepath.Join(filepath.Dir(abs), strings.TrimSpace(include)), loaded); err != nil {
+			return err
+		}
+	}
+
+	if x.Version != "" {
+		d.version = strings.TrimSpace(x.Version)
+	}
+	for _, e := range x.Enums {
+		if err := d.addEnum(e); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	for _, m := range x.Messages {
+		m.source = filepath.Base(path)
+		if err := d.addMessage(m); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+func parseDialect(r io.Reader) (*xmlDialect, error) {
+	x := &xmlDialect{}
+	if err := xml.NewDecoder(r).Decode(x); err != nil {
+		return nil, err
+	}
+
+	return x, nil
+}
+
+// addEnum adds the enum or merges the entries, if an enum with the same name was already defined by an included
+// dialect
+func (d *dialect) addEnum(e *enum) error {
+	var merged *enum
+	for _, existing := range d.enums {
+		if existing.Name == e.Name {
+			merged = existing
+			break
+		}
+	}
+	if merged == nil {
+		merged = &enum{Name: e.Name, Description: e.Description}
+		d.enums = append(d.enums, merged)
+	}
+
+	for _, en := range e.Entries {
+		if strings.TrimSpace(en.Value) == "" {
+			// entries without value get the value of the last entry plus one
+			if len(merged.Entries) > 0 {
+				en.value = merged.Entries[len(merged.Entries)-1].value + 1
+			}
+		} else {
+			v, err := strconv.ParseInt(strings.TrimSpace(en.Value), 0, 64)
+			if err != nil {
+				return fmt.Errorf("invalid value of enum entry %s: %w", en.Name, err)
+			}
+			en.value = v
+		}
+		merged.Entries = append(merged.Entries, en)
+	}
+
+	return nil
+}
+
+func (d *dialect) addMessage(m *message) error {
+	if m.ID > maxMessageID {
+		return fmt.Errorf("ID %d of message %s exceeds the 24 bits of MAVLink 2", m.ID, m.Name)
+	}
+	for _, existing := range d.messages {
+		if existing.ID == m.ID || existing.Name == m.Name {
+			return fmt.Errorf("message %s (ID %d) conflicts with message %s (ID %d) of %s", m.Name, m.ID, existing.Name,
+				existing.ID, existing.source)
+		}
+	}
+	if len(m.Fields) == 0 {
+		return fmt.Errorf("message %s has no fields", m.Name)
+	}
+
+	for _, f := range m.Fields {
+		if err := f.parseType(); err != nil {
+			return fmt.Errorf("message %s: %w", m.Name, err)
+		}
+	}
+	if m.payloadLen() > maxPayloadLen {
+		return fmt.Errorf("payload of message %s has %d bytes, but only %d are allowed", m.Name, m.payloadLen(),
+			maxPayloadLen)
+	}
+
+	d.messages = append(d.messages, m)
+
+	return nil
+}
+
+// parseType splits the XML type into the base type and the array length, e.g. "char[16]"
+func (f *field) parseType() error {
+	t := strings.TrimSpace(f.Type)
+	f.arrayLen = 0
+	if i := strings.Index(t, "["); i >= 0 {
+		if !strings.HasSuffix(t, "]") {
+			return fmt.Errorf("invalid type %s of field %s", f.Type, f.Name)
+		}
+		n, err := strconv.Atoi(t[i+1 : len(t)-1])
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid array length of field %s: %s", f.Name, f.Type)
+		}
+		f.arrayLen = n
+		t = t[:i]
+	}
+
+	size, ok := fieldTypes[t]
+	if !ok {
+		return fmt.Errorf("unknown type %s of field %s", f.Type, f.Name)
+	}
+	f.baseType = t
+	f.size = size
+
+	return nil
+}
+
+// wireFields returns the fields in the order of the serialization: the fields are sorted by the size of the type,
+// the largest first, and the extension fields are appended in the order of the definition
+func (m *message) wireFields() []*field {
+	var base, extensions []*field
+	for _, f := range m.Fields {
+		if f.Extension {
+			extensions = append(extensions, f)
+		} else {
+			base = append(base, f)
+		}
+	}
+	sort.SliceStable(base, func(i, j int) bool { return base[i].size > base[j].size })
+
+	return append(base, extensions...)
+}
+
+// baseLen returns the length of the payload without the extension fields
+func (m *message) baseLen() int {
+	n := 0
+	for _, f := range m.Fields {
+		if !f.Extension {
+			n += f.len()
+		}
+	}
+	return n
+}
+
+func (m *message) payloadLen() int {
+	n := 0
+	for _, f := range m.Fields {
+		n += f.len()
+	}
+	return n
+}
+
+func (m *message) hasExtensions() bool {
+	for _, f := range m.Fields {
+		if f.Extension {
+			return true
+		}
+	}
+	return false
+}
+
+// crcExtra calculates the CRC extra byte over the name and the types and names of the fields without extensions
+func (m *message) crcExtra() uint8 {
+	crc := crcAccumulate([]byte(m.Name+" "), 0xFFFF)
+	for _, f := range m.wireFields() {
+		if f.Extension {
+			continue
+		}
+		t := f.baseType
+		if t == "uint8_t_mavlink_version" {
+			t = "uint8_t"
+		}
+		crc = crcAccumulate([]byte(t+" "), crc)
+		crc = crcAccumulate([]byte(f.Name+" "), crc)
+		if f.arrayLen > 0 {
+			crc = crcAccumulate([]byte{byte(f.arrayLen)}, crc)
+		}
+	}
+
+	return uint8(crc&0xFF) ^ uint8(crc>>8)
+}
+
+func (f *field) len() int {
+	if f.arrayLen > 0 {
+		return f.size * f.arrayLen
+	}
+	return f.size
+}
+
+// crcAccumulate adds the data to the X.25 checksum
+func crcAccumulate(data []byte, crc uint16) uint16 {
+	for _, b := range data {
+		tmp := b ^ uint8(crc&0xFF)
+		tmp ^= tmp << 4
+		crc = (crc >> 8) ^ (uint16(tmp) << 8) ^ (uint16(tmp) << 3) ^ (uint16(tmp) >> 4)
+	}
+	return crc
+}
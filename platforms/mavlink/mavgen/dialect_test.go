@@ -0,0 +1,174 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDialect(t *testing.T) {
+	// act
+	d, err := loadDialect(filepath.Join("testdata", "custom.xml"))
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "custom", d.name)
+	assert.Equal(t, "4", d.version)
+	// enums of the included dialect are merged
+	require.Len(t, d.enums, 4)
+	assert.Equal(t, "MAV_TYPE", d.enums[1].Name)
+	assert.Equal(t, "MAVLINK component type reported in HEARTBEAT message.", d.enums[1].Description)
+	require.Len(t, d.enums[1].Entries, 5)
+	assert.Equal(t, int64(101), d.enums[1].Entries[4].value)
+	// values are counted from the last entry, hex values are supported
+	colors := d.enums[3]
+	assert.Equal(t, []int64{0, 0x10, 0x11},
+		[]int64{colors.Entries[0].value, colors.Entries[1].value, colors.Entries[2].value})
+	require.Len(t, colors.Entries[2].Params, 1)
+	assert.Equal(t, "Brightness in percent", colors.Entries[2].Params[0].Description)
+	// messages are sorted by ID
+	require.Len(t, d.messages, 3)
+	assert.Equal(t, []string{"HEARTBEAT", "PROTOCOL_VERSION", "GOBOT_STATUS"},
+		[]string{d.messages[0].Name, d.messages[1].Name, d.messages[2].Name})
+	assert.Equal(t, "minimal.xml", d.messages[0].source)
+}
+
+func TestMessageLayout(t *testing.T) {
+	// arrange
+	d, err := loadDialect(filepath.Join("testdata", "custom.xml"))
+	require.NoError(t, err)
+	tests := map[string]struct {
+		message        *message
+		wantFields     []string
+		wantLen        int
+		wantBaseLen    int
+		wantExtensions bool
+		wantCrc        uint8
+	}{
+		"heartbeat": {
+			message:     d.messages[0],
+			wantFields:  []string{"custom_mode", "type", "autopilot", "base_mode", "system_status", "mavlink_version"},
+			wantLen:     9,
+			wantBaseLen: 9,
+			wantCrc:     50,
+		},
+		"protocol_version": {
+			message:     d.messages[1],
+			wantFields:  []string{"version", "min_version", "max_version", "spec_version_hash", "library_version_hash"},
+			wantLen:     22,
+			wantBaseLen: 22,
+			wantCrc:     217,
+		},
+		"extensions_are_not_sorted": {
+			message:        d.messages[2],
+			wantFields:     []string{"uptime", "temperature", "led_color", "name", "battery_remaining", "voltage"},
+			wantLen:        22,
+			wantBaseLen:    17,
+			wantExtensions: true,
+			wantCrc:        64,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			var names []string
+			for _, f := range tc.message.wireFields() {
+				names = append(names, f.Name)
+			}
+			// assert
+			assert.Equal(t, tc.wantFields, names)
+			assert.Equal(t, tc.wantLen, tc.message.payloadLen())
+			assert.Equal(t, tc.wantBaseLen, tc.message.baseLen())
+			assert.Equal(t, tc.wantExtensions, tc.message.hasExtensions())
+			assert.Equal(t, tc.wantCrc, tc.message.crcExtra())
+		})
+	}
+}
+
+func TestCrcExtraIgnoresExtensions(t *testing.T) {
+	// arrange
+	d, err := loadDialect(filepath.Join("testdata", "custom.xml"))
+	require.NoError(t, err)
+	m := d.messages[2]
+	crc := m.crcExtra()
+	// act
+	m.Fields = m.Fields[:len(m.Fields)-1]
+	// assert
+	assert.Equal(t, crc, m.crcExtra())
+}
+
+func TestLoadDialectErrors(t *testing.T) {
+	tests := map[string]struct {
+		xml     string
+		wantErr string
+	}{
+		"missing_include": {
+			xml:     `<mavlink><include>missing.xml</include></mavlink>`,
+			wantErr: "missing.xml",
+		},
+		"conflicting_id": {
+			xml: `<mavlink><include>minimal.xml</include><messages>
+				<message id="0" name="OTHER"><field type="uint8_t" name="a">A</field></message></messages></mavlink>`,
+			wantErr: "message OTHER (ID 0) conflicts with message HEARTBEAT (ID 0) of minimal.xml",
+		},
+		"unknown_type": {
+			xml:     `<mavlink><messages><message id="1" name="A"><field type="bool" name="a">A</field></message></messages></mavlink>`,
+			wantErr: "message A: unknown type bool of field a",
+		},
+		"invalid_array": {
+			xml:     `<mavlink><messages><message id="1" name="A"><field type="char[x]" name="a">A</field></message></messages></mavlink>`,
+			wantErr: "message A: invalid array length of field a: char[x]",
+		},
+		"payload_too_large": {
+			xml: `<mavlink><messages><message id="1" name="A"><field type="double[32]" name="a">A</field>
+				</message></messages></mavlink>`,
+			wantErr: "payload of message A has 256 bytes, but only 255 are allowed",
+		},
+		"id_too_large": {
+			xml:     `<mavlink><messages><message id="16777216" name="A"><field type="char" name="a">A</field></message></messages></mavlink>`,
+			wantErr: "ID 16777216 of message A exceeds the 24 bits of MAVLink 2",
+		},
+		"no_fields": {
+			xml:     `<mavlink><messages><message id="1" name="A"></message></messages></mavlink>`,
+			wantErr: "message A has no fields",
+		},
+		"invalid_enum_value": {
+			xml:     `<mavlink><enums><enum name="E"><entry value="a" name="E_A"/></enum></enums></mavlink>`,
+			wantErr: "invalid value of enum entry E_A",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			dir := t.TempDir()
+			minimal, err := os.ReadFile(filepath.Join("testdata", "minimal.xml"))
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "minimal.xml"), minimal, 0o600))
+			path := filepath.Join(dir, "test.xml")
+			require.NoError(t, os.WriteFile(path, []byte(tc.xml), 0o600))
+			// act
+			_, err = loadDialect(path)
+			// assert
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestLoadDialectIncludesOnce(t *testing.T) {
+	// arrange: both dialects include minimal.xml, which must be loaded only once
+	dir := t.TempDir()
+	minimal, err := os.ReadFile(filepath.Join("testdata", "minimal.xml"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "minimal.xml"), minimal, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.xml"), []byte(`<mavlink><include>minimal.xml</include>
+		<include>b.xml</include></mavlink>`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.xml"), []byte(`<mavlink><include>minimal.xml</include>
+		<include>a.xml</include></mavlink>`), 0o600))
+	// act
+	d, err := loadDialect(filepath.Join(dir, "a.xml"))
+	// assert
+	require.NoError(t, err)
+	assert.Len(t, d.messages, 2)
+}
@@ -0,0 +1,322 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// goTypes contains the Go type of each MAVLink type
+var goTypes = map[string]string{
+	"char":                    "uint8",
+	"int8_t":                  "int8",
+	"uint8_t":                 "uint8",
+	"uint8_t_mavlink_version": "uint8",
+	"int16_t":                 "int16",
+	"uint16_t":                "uint16",
+	"int32_t":                 "int32",
+	"uint32_t":                "uint32",
+	"float":                   "float32",
+	"int64_t":                 "int64",
+	"uint64_t":                "uint64",
+	"double":                  "float64",
+}
+
+var codeTemplate = template.Must(template.New("dialect").Funcs(template.FuncMap{
+	"goName":      goName,
+	"fieldName":   fieldName,
+	"goType":      goType,
+	"oneLine":     oneLine,
+	"comment":     comment,
+	"entryNote":   entryNote,
+	"enumEnd":     enumEnd,
+	"arrayFields": arrayFields,
+}).Parse(`// Code generated by mavgen from {{.Name}}.xml. DO NOT EDIT.
+
+//nolint:dupl,gocritic,lll // seems to be useful here
+package {{.Package}}
+
+//
+// MAVLink comm protocol generated from {{.Name}}.xml
+// https://mavlink.io/
+//
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+)
+
+var messages = map[uint32]MAVLinkMessage{
+{{- range .Messages}}
+	{{.ID}}: &{{goName .Name}}{},
+{{- end}}
+}
+
+// NewMAVLinkMessage returns a new MAVLinkMessage or an error if it encounters an unknown Message ID
+func NewMAVLinkMessage(msgid uint32, data []byte) (MAVLinkMessage, error) {
+	if message := messages[msgid]; message != nil {
+		// a new message for each call, because the messages are passed to concurrent event handlers
+		//nolint:forcetypeassert // ok here
+		m := reflect.New(reflect.TypeOf(message).Elem()).Interface().(MAVLinkMessage)
+		m.Decode(data)
+		return m, nil
+	}
+	return nil, fmt.Errorf("Unknown Message ID: %v", msgid)
+}
+{{range .Enums}}
+//
+// {{.Name}}
+/*{{comment .Description}}*/
+//
+const (
+{{- range .Entries}}
+	{{.Name}} = {{.Value}} // {{entryNote .}}
+{{- end}}
+	{{.Name}}_ENUM_END = {{enumEnd .}} //  |
+)
+{{end}}
+{{- range .Messages}}{{$name := goName .Name}}{{$msgName := .Name}}
+// MESSAGE {{.Name}}
+//
+{{- if .Description}}
+// {{oneLine .Description}}
+//
+{{- end}}
+// MAVLINK_MSG_ID_{{.Name}} {{.ID}}
+//
+// MAVLINK_MSG_ID_{{.Name}}_LEN {{.PayloadLen}}
+//
+// MAVLINK_MSG_ID_{{.Name}}_CRC {{.CrcExtra}}
+type {{$name}} struct {
+{{- range .Fields}}
+	{{fieldName .Name}} {{goType .}} // {{if .Extension}}(extension) {{end}}{{oneLine .Description}}
+{{- end}}
+}
+
+// New{{$name}} returns a new {{$name}}
+func New{{$name}}({{range $i, $f := .Fields}}{{if $i}}, {{end}}{{fieldName $f.Name}} {{goType $f}}{{end}}) *{{$name}} {
+	m := {{$name}}{}
+{{- range .Fields}}
+	m.{{fieldName .Name}} = {{fieldName .Name}}
+{{- end}}
+	return &m
+}
+
+// Id returns the {{$name}} Message ID
+func (*{{$name}}) Id() uint32 {
+	return {{.ID}}
+}
+
+// Len returns the {{$name}} Message Length
+func (*{{$name}}) Len() uint8 {
+	return {{.PayloadLen}}
+}
+{{- if .HasExtensions}}
+
+// BaseLen returns the {{$name}} Message Length without the extension fields
+func (*{{$name}}) BaseLen() uint8 {
+	return {{.BaseLen}}
+}
+{{- end}}
+
+// Crc returns the {{$name}} Message CRC
+func (*{{$name}}) Crc() uint8 {
+	return {{.CrcExtra}}
+}
+
+// Pack returns a packed byte array which represents a {{$name}} payload
+func (m *{{$name}}) Pack() []byte {
+	data := new(bytes.Buffer)
+{{- range .Fields}}
+	if err := binary.Write(data, binary.LittleEndian, m.{{fieldName .Name}}); err != nil {
+		panic(err)
+	}
+{{- end}}
+	return data.Bytes()
+}
+
+// Decode accepts a packed byte array and populates the fields of the {{$name}}
+func (m *{{$name}}) Decode(buf []byte) {
+	data := bytes.NewBuffer(buf)
+{{- range .Fields}}
+	if err := binary.Read(data, binary.LittleEndian, &m.{{fieldName .Name}}); err != nil {
+		panic(err)
+	}
+{{- end}}
+}
+{{- with arrayFields .Fields}}
+
+const (
+{{- range .}}
+	MAVLINK_MSG_{{$msgName}}_FIELD_{{.Name}}_LEN = {{.ArrayLen}}
+{{- end}}
+)
+{{- end}}
+{{end}}`))
+
+// templateData is the view of the dialect for the template
+type templateData struct {
+	Name     string
+	Package  string
+	Enums    []templateEnum
+	Messages []templateMessage
+}
+
+type templateEnum struct {
+	Name        string
+	Description string
+	Entries     []templateEntry
+}
+
+type templateEntry struct {
+	Name        string
+	Value       int64
+	Description string
+	Params      []string
+}
+
+type templateMessage struct {
+	ID            uint32
+	Name          string
+	Description   string
+	Fields        []templateField // in the order of the serialization
+	PayloadLen    int
+	BaseLen       int
+	HasExtensions bool
+	CrcExtra      uint8
+}
+
+type templateField struct {
+	Name        string
+	Type        string
+	ArrayLen    int
+	Extension   bool
+	Description string
+}
+
+// generate creates the formatted Go code of the dialect
+func generate(d *dialect, pkg string) ([]byte, error) {
+	data := templateData{Name: d.name, Package: pkg}
+	for _, e := range d.enums {
+		te := templateEnum{Name: e.Name, Description: e.Description}
+		for _, en := range e.Entries {
+			tn := templateEntry{Name: en.Name, Value: en.value, Description: en.Description}
+			for _, p := range en.Params {
+				tn.Params = append(tn.Params, p.Description)
+			}
+			te.Entries = append(te.Entries, tn)
+		}
+		data.Enums = append(data.Enums, te)
+	}
+	for _, m := range d.messages {
+		tm := templateMessage{
+			ID:            m.ID,
+			Name:          m.Name,
+			Description:   m.Description,
+			PayloadLen:    m.payloadLen(),
+			BaseLen:       m.baseLen(),
+			HasExtensions: m.hasExtensions(),
+			CrcExtra:      m.crcExtra(),
+		}
+		for _, f := range m.wireFields() {
+			tm.Fields = append(tm.Fields, templateField{
+				Name:        f.Name,
+				Type:        f.baseType,
+				ArrayLen:    f.arrayLen,
+				Extension:   f.Extension,
+				Description: f.Description,
+			})
+		}
+		data.Messages = append(data.Messages, tm)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := codeTemplate.Execute(buf, data); err != nil {
+		return nil, err
+	}
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting of the generated code failed: %w", err)
+	}
+
+	return code, nil
+}
+
+// goName converts the MAVLink name to the Go name, e.g. "GPS_RAW_INT" to "GpsRawInt" and "SETPOINT_8DOF" to
+// "Setpoint8Dof"
+func goName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r == '_':
+			upper = true
+		case unicode.IsDigit(r):
+			b.WriteRune(r)
+			upper = true
+		case upper:
+			b.WriteRune(unicode.ToUpper(r))
+			upper = false
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// fieldName converts the MAVLink field name to the exported Go name, e.g. "param_id" to "PARAM_ID"
+func fieldName(name string) string {
+	return strings.ToUpper(name)
+}
+
+func goType(f templateField) string {
+	if f.ArrayLen > 0 {
+		return fmt.Sprintf("[%d]%s", f.ArrayLen, goTypes[f.Type])
+	}
+	return goTypes[f.Type]
+}
+
+// oneLine removes the line breaks and the indentation of the XML descriptions
+func oneLine(s string) string {
+	return strings.Join(strings.FieldsFunc(s, unicode.IsSpace), " ")
+}
+
+// comment makes the description safe for a block comment
+func comment(s string) string {
+	return strings.ReplaceAll(strings.TrimSpace(s), "*/", "* /")
+}
+
+// entryNote creates the comment of the enum entry with the description and the parameters, separated by "|"
+func entryNote(e templateEntry) string {
+	note := oneLine(e.Description) + " |"
+	for _, p := range e.Params {
+		note += " " + oneLine(p) + " |"
+	}
+	return note
+}
+
+// enumEnd returns the value of the _ENUM_END constant, which is the highest value plus one
+func enumEnd(e templateEnum) int64 {
+	var end int64
+	for _, en := range e.Entries {
+		if en.Value+1 > end {
+			end = en.Value + 1
+		}
+	}
+	return end
+}
+
+func arrayFields(fields []templateField) []templateField {
+	var arrays []templateField
+	for _, f := range fields {
+		if f.ArrayLen > 0 {
+			arrays = append(arrays, f)
+		}
+	}
+	return arrays
+}
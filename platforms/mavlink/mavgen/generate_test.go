@@ -0,0 +1,105 @@
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	// arrange
+	d, err := loadDialect(filepath.Join("testdata", "custom.xml"))
+	require.NoError(t, err)
+	// act
+	code, err := generate(d, "custom")
+	// assert
+	require.NoError(t, err)
+	for _, want := range []string{
+		"// Code generated by mavgen from custom.xml. DO NOT EDIT.",
+		"package custom",
+		"\t300:   &ProtocolVersion{},",
+		"\t42000: &GobotStatus{},",
+		"\tMAV_TYPE_GOBOT_BOAT  = 101 // A boat controlled by Gobot |",
+		"\tMAV_TYPE_ENUM_END    = 102 //  |",
+		"\tGOBOT_LED_COLOR_RED      = 16 // Red | Brightness in percent |",
+		"\tMAVLINK_VERSION uint8  // MAVLink version, not writable by user",
+		"\tNAME              [10]uint8 // Name of the robot without termination",
+		"\tVOLTAGE           float32   // (extension) Battery voltage",
+		"func (*GobotStatus) Id() uint32 {\n\treturn 42000\n}",
+		"func (*GobotStatus) Len() uint8 {\n\treturn 22\n}",
+		"func (*GobotStatus) BaseLen() uint8 {\n\treturn 17\n}",
+		"func (*GobotStatus) Crc() uint8 {\n\treturn 64\n}",
+		"\tMAVLINK_MSG_GOBOT_STATUS_FIELD_name_LEN = 10",
+	} {
+		assert.Contains(t, string(code), want)
+	}
+	assert.NotContains(t, string(code), "func (*Heartbeat) BaseLen()")
+}
+
+func TestGeneratedCodeCompiles(t *testing.T) {
+	// arrange: the generated code needs the framing of the common package
+	d, err := loadDialect(filepath.Join("testdata", "custom.xml"))
+	require.NoError(t, err)
+	code, err := generate(d, "mavlink")
+	require.NoError(t, err)
+	fset := token.NewFileSet()
+	files := []*ast.File{}
+	for _, name := range []string{"mavlink.go", "mavlink_signing.go"} {
+		f, err := parser.ParseFile(fset, filepath.Join("..", "common", name), nil, 0)
+		require.NoError(t, err)
+		files = append(files, f)
+	}
+	f, err := parser.ParseFile(fset, "custom.go", code, 0)
+	require.NoError(t, err)
+	files = append(files, f)
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	// act
+	pkg, err := conf.Check("mavlink", fset, files, nil)
+	// assert
+	require.NoError(t, err)
+	// the extension message must fulfill the interface for the MAVLink 1 truncation
+	ext := pkg.Scope().Lookup("MAVLinkExtensionMessage").Type().Underlying().(*types.Interface)
+	status := types.NewPointer(pkg.Scope().Lookup("GobotStatus").Type())
+	heartbeat := types.NewPointer(pkg.Scope().Lookup("Heartbeat").Type())
+	assert.True(t, types.Implements(status, ext))
+	assert.False(t, types.Implements(heartbeat, ext))
+}
+
+func TestCommonIsUpToDate(t *testing.T) {
+	// arrange
+	d, err := loadDialect(filepath.Join("..", "common", "common.xml"))
+	require.NoError(t, err)
+	want, err := os.ReadFile(filepath.Join("..", "common", "common.go"))
+	require.NoError(t, err)
+	// act
+	code, err := generate(d, "mavlink")
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(code), "common.go is outdated, please run 'go generate' in platforms/mavlink/common")
+}
+
+func TestGoName(t *testing.T) {
+	tests := map[string]struct {
+		name string
+		want string
+	}{
+		"single_word":   {name: "HEARTBEAT", want: "Heartbeat"},
+		"words":         {name: "GPS_RAW_INT", want: "GpsRawInt"},
+		"digits":        {name: "SETPOINT_8DOF", want: "Setpoint8Dof"},
+		"trailing_num":  {name: "SCALED_IMU2", want: "ScaledImu2"},
+		"double_marker": {name: "A__B", want: "AB"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, goName(tc.name))
+		})
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Mavgen generates the Go code of the MAVLink messages and enums from a MAVLink XML dialect definition.
+
+The generated code contains the message structs with their constructors, the CRC extras and the pack and decode
+methods, the enums and the registry of all messages of the dialect. Included dialects are resolved relative to the
+including file and are merged, so a custom dialect with vendor-specific messages can include e.g. "common.xml".
+
+Usage:
+
+	go run gobot.io/x/gobot/v2/platforms/mavlink/mavgen [-package name] [-output file] dialect.xml
+
+The generated file needs the framing of the package "gobot.io/x/gobot/v2/platforms/mavlink/common" (mavlink.go and
+mavlink_signing.go) in the same package. The code of the package "common" is generated by "go generate".
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	pkg := flag.String("package", "mavlink", "the name of the generated Go package")
+	output := flag.String("output", "", "the generated Go file, the code is written to stdout if empty")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-package name] [-output file] dialect.xml\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(flag.Arg(0), *pkg, *output); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(dialectFile string, pkg string, output string) error {
+	d, err := loadDialect(dialectFile)
+	if err != nil {
+		return err
+	}
+
+	code, err := generate(d, pkg)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		_, err = os.Stdout.Write(code)
+		return err
+	}
+
+	return os.WriteFile(output, code, 0o644) //nolint:gosec // generated code is readable by all
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	// arrange
+	output := filepath.Join(t.TempDir(), "minimal.go")
+	// act
+	err := run(filepath.Join("testdata", "minimal.xml"), "minimal", output)
+	// assert
+	require.NoError(t, err)
+	code, err := os.ReadFile(output)
+	require.NoError(t, err)
+	assert.Contains(t, string(code), "package minimal")
+	assert.Contains(t, string(code), "type Heartbeat struct")
+}
+
+func TestRunError(t *testing.T) {
+	// act
+	err := run(filepath.Join("testdata", "missing.xml"), "minimal", filepath.Join(t.TempDir(), "missing.go"))
+	// assert
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -2,6 +2,7 @@ package mavlink
 
 import (
 	"io"
+	"sync/atomic"
 
 	"go.bug.st/serial"
 
@@ -17,12 +18,20 @@ type BaseAdaptor interface {
 	ReadMAVLinkPacket() (*common.MAVLinkPacket, error)
 }
 
+// ProtocolVersioner is an optional interface of an adaptor, which detects the MAVLink version from the received
+// packets. Packets are sent with MAVLink 1 by the driver for adaptors without this interface, unless signing is active.
+type ProtocolVersioner interface {
+	// ProtocolVersion returns the MAVLink version detected from the received packets
+	ProtocolVersion() int
+}
+
 // Adaptor is a Mavlink-over-serial adaptor.
 type Adaptor struct {
 	name    string
 	port    string
 	sp      io.ReadWriteCloser
 	connect func(string) (io.ReadWriteCloser, error)
+	version atomic.Int32
 }
 
 // NewAdaptor creates a new mavlink adaptor with specified port
@@ -56,10 +65,29 @@ func (m *Adaptor) Finalize() error {
 	return m.sp.Close()
 }
 
+// ReadMAVLinkPacket reads the next MAVLink 1 or MAVLink 2 packet and remembers its protocol version
 func (m *Adaptor) ReadMAVLinkPacket() (*common.MAVLinkPacket, error) {
-	return common.ReadMAVLinkPacket(m.sp)
+	packet, err := common.ReadMAVLinkPacket(m.sp)
+	if err != nil {
+		return nil, err
+	}
+	m.version.Store(int32(packet.Version())) //nolint:gosec // only 1 or 2
+
+	return packet, nil
+}
+
+// ProtocolVersion returns the MAVLink version of the last received packet, which is 1 until a packet was received.
+func (m *Adaptor) ProtocolVersion() int {
+	return protocolVersion(&m.version)
 }
 
 func (m *Adaptor) Write(b []byte) (int, error) {
 	return m.sp.Write(b)
 }
+
+func protocolVersion(version *atomic.Int32) int {
+	if v := version.Load(); v > 0 {
+		return int(v)
+	}
+	return 1
+}
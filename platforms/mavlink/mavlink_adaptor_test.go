@@ -10,9 +10,13 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"gobot.io/x/gobot/v2"
+	common "gobot.io/x/gobot/v2/platforms/mavlink/common"
 )
 
-var _ gobot.Adaptor = (*Adaptor)(nil)
+var (
+	_ gobot.Adaptor     = (*Adaptor)(nil)
+	_ ProtocolVersioner = (*Adaptor)(nil)
+)
 
 type nullReadWriteCloser struct{}
 
@@ -85,3 +89,22 @@ func TestMavlinkAdaptorFinalize(t *testing.T) {
 	}
 	require.ErrorContains(t, a.Finalize(), "close error")
 }
+
+func TestMavlinkAdaptorProtocolVersion(t *testing.T) {
+	a := initTestMavlinkAdaptor()
+	oldPayload := payload
+	defer func() { payload = oldPayload }()
+	payload = append(common.CraftMAVLink2Packet(1, 1, common.NewAttitude(1, 0, 0, 0, 0, 0, 0)).Pack(),
+		common.CraftMAVLinkPacket(1, 1, common.NewAttitude(1, 0, 0, 0, 0, 0, 0)).Pack()...)
+
+	assert.Equal(t, 1, a.ProtocolVersion())
+	p, err := a.ReadMAVLinkPacket()
+	require.NoError(t, err)
+	assert.Equal(t, 2, p.Version())
+	assert.Equal(t, 2, a.ProtocolVersion())
+
+	p, err = a.ReadMAVLinkPacket()
+	require.NoError(t, err)
+	assert.Equal(t, 1, p.Version())
+	assert.Equal(t, 1, a.ProtocolVersion())
+}
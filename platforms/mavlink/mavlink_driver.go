@@ -1,6 +1,7 @@
 package mavlink
 
 import (
+	"sync"
 	"time"
 
 	"gobot.io/x/gobot/v2"
@@ -22,6 +23,8 @@ type Driver struct {
 	name       string
 	connection gobot.Connection
 	interval   time.Duration
+	signer     *common.MAVLinkSigner
+	writeMutex sync.Mutex
 	gobot.Eventer
 }
 
@@ -57,6 +60,10 @@ func (m *Driver) Connection() gobot.Connection { return m.connection }
 func (m *Driver) Name() string                 { return m.name }
 func (m *Driver) SetName(n string)             { m.name = n }
 
+// SetSigner activates the MAVLink 2 signing. All sent packets are signed and all received packets without a valid
+// signature are dropped with an "errorMAVLink" event. Must be called before Start().
+func (m *Driver) SetSigner(signer *common.MAVLinkSigner) { m.signer = signer }
+
 // adaptor returns driver associated adaptor
 func (m *Driver) adaptor() BaseAdaptor {
 	//nolint:forcetypeassert // ok here
@@ -73,6 +80,12 @@ func (m *Driver) Start() error {
 				m.Publish(ErrorIOEvent, err)
 				continue
 			}
+			if m.signer != nil {
+				if err := m.signer.Verify(packet); err != nil {
+					m.Publish(ErrorMAVLinkEvent, err)
+					continue
+				}
+			}
 			m.Publish(PacketEvent, packet)
 			message, err := packet.MAVLinkMessage()
 			if err != nil {
@@ -91,6 +104,36 @@ func (m *Driver) Halt() error { return nil }
 
 // SendPacket sends a packet to mavlink device
 func (m *Driver) SendPacket(packet *common.MAVLinkPacket) error {
+	// ensure that packets of concurrent senders are not interleaved
+	m.writeMutex.Lock()
+	defer m.writeMutex.Unlock()
+
 	_, err := m.adaptor().Write(packet.Pack())
 	return err
 }
+
+// protocolVersion returns the MAVLink version detected by the adaptor, which is 1 for adaptors without detection
+func (m *Driver) protocolVersion() int {
+	if v, ok := m.adaptor().(ProtocolVersioner); ok {
+		return v.ProtocolVersion()
+	}
+
+	return 1
+}
+
+// SendMessage crafts a packet from the message and sends it to the mavlink device. The MAVLink version of the packet is
+// taken from the received packets, MAVLink 2 is always used when signing is active.
+func (m *Driver) SendMessage(systemID uint8, componentID uint8, message common.MAVLinkMessage) error {
+	if m.signer == nil && m.protocolVersion() < 2 {
+		return m.SendPacket(common.CraftMAVLinkPacket(systemID, componentID, message))
+	}
+
+	packet := common.CraftMAVLink2Packet(systemID, componentID, message)
+	if m.signer != nil {
+		if err := m.signer.Sign(packet); err != nil {
+			return err
+		}
+	}
+
+	return m.SendPacket(packet)
+}
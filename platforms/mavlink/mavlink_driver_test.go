@@ -2,6 +2,7 @@
 package mavlink
 
 import (
+	"bytes"
 	"io"
 	"strings"
 	"testing"
@@ -87,3 +88,100 @@ func TestMavlinkDriverHalt(t *testing.T) {
 	d := initTestMavlinkDriver()
 	require.NoError(t, d.Halt())
 }
+
+type mavlinkTestAdaptor struct {
+	Adaptor
+	version int
+	packets chan *common.MAVLinkPacket
+	written [][]byte
+}
+
+func (a *mavlinkTestAdaptor) ReadMAVLinkPacket() (*common.MAVLinkPacket, error) {
+	p, ok := <-a.packets
+	if !ok {
+		return nil, io.EOF
+	}
+	return p, nil
+}
+
+func (a *mavlinkTestAdaptor) Write(b []byte) (int, error) {
+	a.written = append(a.written, b)
+	return len(b), nil
+}
+
+func (a *mavlinkTestAdaptor) ProtocolVersion() int { return a.version }
+
+// unversionedTestAdaptor hides the optional ProtocolVersioner interface of the wrapped adaptor
+type unversionedTestAdaptor struct {
+	BaseAdaptor
+}
+
+func TestMavlinkDriverSendMessage(t *testing.T) {
+	tests := map[string]struct {
+		version     int
+		unversioned bool
+		signed      bool
+		wantVersion int
+	}{
+		"v1":                 {version: 1, wantVersion: 1},
+		"v2":                 {version: 2, wantVersion: 2},
+		"v1_signed":          {version: 1, signed: true, wantVersion: 2},
+		"unversioned":        {version: 2, unversioned: true, wantVersion: 1},
+		"unversioned_signed": {version: 2, unversioned: true, signed: true, wantVersion: 2},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := &mavlinkTestAdaptor{version: tc.version}
+			d := NewDriver(a)
+			if tc.unversioned {
+				d = NewDriver(&unversionedTestAdaptor{BaseAdaptor: a})
+			}
+			if tc.signed {
+				d.SetSigner(common.NewMAVLinkSigner([32]byte{1}, 0))
+			}
+			// act
+			err := d.SendMessage(255, 190, common.NewHeartbeat(0, 6, 8, 0, 0, 3))
+			// assert
+			require.NoError(t, err)
+			require.Len(t, a.written, 1)
+			p, err := common.ReadMAVLinkPacket(bytes.NewReader(a.written[0]))
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantVersion, p.Version())
+			assert.Equal(t, uint8(255), p.SystemID)
+			assert.Equal(t, uint8(190), p.ComponentID)
+			assert.Equal(t, tc.signed, p.SignatureValid([32]byte{1}))
+		})
+	}
+}
+
+func TestMavlinkDriverSigning(t *testing.T) {
+	// arrange
+	a := &mavlinkTestAdaptor{packets: make(chan *common.MAVLinkPacket, 2)}
+	d := NewDriver(a, time.Millisecond)
+	signer := common.NewMAVLinkSigner([32]byte{1}, 0)
+	d.SetSigner(signer)
+	signed := common.CraftMAVLink2Packet(1, 1, common.NewAttitude(1, 0, 0, 0, 0, 0, 0))
+	require.NoError(t, signer.Sign(signed))
+	a.packets <- common.CraftMAVLink2Packet(1, 1, common.NewAttitude(2, 0, 0, 0, 0, 0, 0))
+	a.packets <- signed
+	errs := make(chan error, 10)
+	packets := make(chan *common.MAVLinkPacket, 10)
+	_ = d.On(ErrorMAVLinkEvent, func(data interface{}) { errs <- data.(error) })
+	_ = d.On(PacketEvent, func(data interface{}) { packets <- data.(*common.MAVLinkPacket) })
+	// act
+	require.NoError(t, d.Start())
+	// assert
+	select {
+	case err := <-errs:
+		require.EqualError(t, err, "MAVLink packet 30 from system 1 is not signed")
+	case <-time.After(time.Second):
+		require.Fail(t, "error was not emitted")
+	}
+	select {
+	case p := <-packets:
+		assert.Same(t, signed, p)
+	case <-time.After(time.Second):
+		require.Fail(t, "packet was not emitted")
+	}
+}
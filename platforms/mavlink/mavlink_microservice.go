@@ -0,0 +1,169 @@
+package mavlink
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+	common "gobot.io/x/gobot/v2/platforms/mavlink/common"
+)
+
+// packetBufferSize is the count of received messages, which are buffered for a running exchange
+const packetBufferSize = 256
+
+// exchangeHandler is called for each message received during an exchange. It returns the next message to send, if the
+// exchange is done or an error to abort the exchange.
+type exchangeHandler func(message common.MAVLinkMessage) (next common.MAVLinkMessage, done bool, err error)
+
+// microservice contains the common parts of the clients for the MAVLink microservices, e.g. the mission protocol.
+// Only one exchange can run at a time.
+type microservice struct {
+	driver          *Driver
+	cfg             *microserviceConfiguration
+	targetSystem    uint8
+	targetComponent uint8
+	mutex           sync.Mutex
+}
+
+func newMicroservice(
+	driver *Driver,
+	targetSystem, targetComponent uint8,
+	opts ...microserviceOptionApplier,
+) *microservice {
+	s := &microservice{
+		driver: driver,
+		cfg: &microserviceConfiguration{
+			systemID:    255,
+			componentID: common.MAV_COMP_ID_MISSIONPLANNER,
+			timeout:     1500 * time.Millisecond,
+			retries:     5,
+			clock:       gobot.NewRealClock(),
+		},
+		targetSystem:    targetSystem,
+		targetComponent: targetComponent,
+	}
+
+	for _, o := range opts {
+		o.apply(s.cfg)
+	}
+
+	return s
+}
+
+// send sends the message to the target system.
+func (s *microservice) send(message common.MAVLinkMessage) error {
+	return s.driver.SendMessage(s.cfg.systemID, s.cfg.componentID, message)
+}
+
+// addressed returns true, if a message with the given target system is intended for this ground control station.
+func (s *microservice) addressed(targetSystem uint8) bool {
+	return targetSystem == 0 || targetSystem == s.cfg.systemID
+}
+
+// exchange sends the first message and passes all messages of the target to the handler until it is done. The last
+// sent message is retransmitted, if the handler does not send a new message within the timeout.
+func (s *microservice) exchange(name string, first common.MAVLinkMessage, handle exchangeHandler) error {
+	sub := subscribePackets(s.driver, s.cfg.clock, s.targetSystem, s.targetComponent)
+	defer sub.cancel()
+
+	last := first
+	if err := s.send(last); err != nil {
+		return err
+	}
+	deadline := s.cfg.clock.Now().Add(s.cfg.timeout)
+
+	for attempt := 1; ; {
+		message, ok := sub.next(deadline)
+		if !ok {
+			if attempt > s.cfg.retries {
+				return fmt.Errorf("%s: no response of system %d after %d attempts", name, s.targetSystem, attempt)
+			}
+			attempt++
+			if err := s.send(last); err != nil {
+				return err
+			}
+			deadline = s.cfg.clock.Now().Add(s.cfg.timeout)
+			continue
+		}
+
+		next, done, err := handle(message)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		if done {
+			return nil
+		}
+		if next != nil {
+			last = next
+			attempt = 1
+			if err := s.send(last); err != nil {
+				return err
+			}
+			deadline = s.cfg.clock.Now().Add(s.cfg.timeout)
+		}
+	}
+}
+
+// packetSubscription forwards the messages of a system, received by the driver, to a buffered channel. The eventer of
+// the driver is never blocked, messages are dropped if the buffer is full.
+type packetSubscription struct {
+	driver   *Driver
+	clock    gobot.Clock
+	events   chan *gobot.Event
+	messages chan common.MAVLinkMessage
+	stop     chan struct{}
+}
+
+func subscribePackets(driver *Driver, clock gobot.Clock, systemID, componentID uint8) *packetSubscription {
+	sub := &packetSubscription{
+		driver:   driver,
+		clock:    clock,
+		events:   driver.Subscribe(),
+		messages: make(chan common.MAVLinkMessage, packetBufferSize),
+		stop:     make(chan struct{}),
+	}
+
+	go func() {
+		for {
+			select {
+			case evt := <-sub.events:
+				packet, ok := evt.Data.(*common.MAVLinkPacket)
+				if evt.Name != PacketEvent || !ok || packet.SystemID != systemID ||
+					(componentID != common.MAV_COMP_ID_ALL && packet.ComponentID != componentID) {
+					continue
+				}
+				message, err := packet.MAVLinkMessage()
+				if err != nil {
+					continue
+				}
+				select {
+				case sub.messages <- message:
+				default:
+				}
+			case <-sub.stop:
+				return
+			}
+		}
+	}()
+
+	return sub
+}
+
+// next returns the next message or false, if no message was received until the deadline of the clock.
+func (s *packetSubscription) next(deadline time.Time) (common.MAVLinkMessage, bool) {
+	timer := s.clock.NewTimer(deadline.Sub(s.clock.Now()))
+	defer timer.Stop()
+
+	select {
+	case message := <-s.messages:
+		return message, true
+	case <-timer.C():
+		return nil, false
+	}
+}
+
+func (s *packetSubscription) cancel() {
+	s.driver.Unsubscribe(s.events)
+	close(s.stop)
+}
@@ -0,0 +1,107 @@
+package mavlink
+
+import (
+	"time"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// microserviceOptionApplier needs to be implemented by each configurable option type of the mission and parameter
+// clients
+type microserviceOptionApplier interface {
+	apply(cfg *microserviceConfiguration)
+}
+
+// microserviceConfiguration contains all changeable attributes of the mission and parameter clients.
+type microserviceConfiguration struct {
+	systemID    uint8
+	componentID uint8
+	timeout     time.Duration
+	retries     int
+	clock       gobot.Clock
+}
+
+// microserviceSystemIDOption is the type for applying another own system id to the configuration
+type microserviceSystemIDOption uint8
+
+// microserviceComponentIDOption is the type for applying another own component id to the configuration
+type microserviceComponentIDOption uint8
+
+// microserviceTimeoutOption is the type for applying another response timeout to the configuration
+type microserviceTimeoutOption time.Duration
+
+// microserviceRetriesOption is the type for applying another count of retransmissions to the configuration
+type microserviceRetriesOption int
+
+// microserviceClockOption is the type for applying another clock to the configuration
+type microserviceClockOption struct {
+	clock gobot.Clock
+}
+
+// WithMicroserviceSystemID is used to replace the default system id 255 of the ground control station.
+func WithMicroserviceSystemID(id uint8) microserviceOptionApplier {
+	return microserviceSystemIDOption(id)
+}
+
+// WithMicroserviceComponentID is used to replace the default component id 190 (MAV_COMP_ID_MISSIONPLANNER) of the
+// ground control station.
+func WithMicroserviceComponentID(id uint8) microserviceOptionApplier {
+	return microserviceComponentIDOption(id)
+}
+
+// WithMicroserviceTimeout change the time to wait for a response of the vehicle from default 1.5s to the given value.
+func WithMicroserviceTimeout(timeout time.Duration) microserviceOptionApplier {
+	return microserviceTimeoutOption(timeout)
+}
+
+// WithMicroserviceRetries change the count of retransmissions of an unanswered message from default 5 to the given
+// value.
+func WithMicroserviceRetries(retries int) microserviceOptionApplier {
+	return microserviceRetriesOption(retries)
+}
+
+// WithMicroserviceClock is used to replace the real clock of the mission and parameter clients, e.g. by a fake clock
+// for tests. The clock is used for the response timeout.
+func WithMicroserviceClock(clock gobot.Clock) microserviceOptionApplier {
+	return microserviceClockOption{clock: clock}
+}
+
+func (o microserviceSystemIDOption) String() string {
+	return "system id option for MAVLink mission and parameter clients"
+}
+
+func (o microserviceComponentIDOption) String() string {
+	return "component id option for MAVLink mission and parameter clients"
+}
+
+func (o microserviceTimeoutOption) String() string {
+	return "timeout option for MAVLink mission and parameter clients"
+}
+
+func (o microserviceRetriesOption) String() string {
+	return "retries option for MAVLink mission and parameter clients"
+}
+
+func (o microserviceClockOption) String() string {
+	return "clock option for MAVLink mission and parameter clients"
+}
+
+func (o microserviceSystemIDOption) apply(cfg *microserviceConfiguration) {
+	cfg.systemID = uint8(o)
+}
+
+func (o microserviceComponentIDOption) apply(cfg *microserviceConfiguration) {
+	cfg.componentID = uint8(o)
+}
+
+func (o microserviceTimeoutOption) apply(cfg *microserviceConfiguration) {
+	cfg.timeout = time.Duration(o)
+}
+
+func (o microserviceRetriesOption) apply(cfg *microserviceConfiguration) {
+	cfg.retries = int(o)
+}
+
+func (o microserviceClockOption) apply(cfg *microserviceConfiguration) {
+	cfg.clock = o.clock
+}
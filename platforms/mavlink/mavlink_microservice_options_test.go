@@ -0,0 +1,35 @@
+package mavlink
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"gobot.io/x/gobot/v2"
+)
+
+func TestWithMicroserviceOptions(t *testing.T) {
+	// arrange
+	cfg := &microserviceConfiguration{}
+	// act
+	WithMicroserviceSystemID(1).apply(cfg)
+	WithMicroserviceComponentID(2).apply(cfg)
+	WithMicroserviceTimeout(time.Second).apply(cfg)
+	WithMicroserviceRetries(7).apply(cfg)
+	// assert
+	assert.Equal(t, uint8(1), cfg.systemID)
+	assert.Equal(t, uint8(2), cfg.componentID)
+	assert.Equal(t, time.Second, cfg.timeout)
+	assert.Equal(t, 7, cfg.retries)
+}
+
+func TestWithMicroserviceClock(t *testing.T) {
+	// arrange
+	cfg := &microserviceConfiguration{clock: gobot.NewRealClock()}
+	clock := gobot.NewFakeClock(time.Now())
+	// act
+	WithMicroserviceClock(clock).apply(cfg)
+	// assert
+	assert.Same(t, clock, cfg.clock)
+}
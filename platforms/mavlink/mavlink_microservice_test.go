@@ -0,0 +1,91 @@
+//nolint:forcetypeassert // ok here
+package mavlink
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	common "gobot.io/x/gobot/v2/platforms/mavlink/common"
+)
+
+func TestNewMicroservice(t *testing.T) {
+	// arrange
+	d := NewDriver(newVehicleTestAdaptor())
+	// act
+	s := newMicroservice(d, 1, 2, WithMicroserviceRetries(1))
+	// assert
+	assert.Equal(t, uint8(255), s.cfg.systemID)
+	assert.Equal(t, uint8(190), s.cfg.componentID)
+	assert.Equal(t, 1500*time.Millisecond, s.cfg.timeout)
+	assert.Equal(t, 1, s.cfg.retries)
+	assert.Equal(t, uint8(1), s.targetSystem)
+	assert.Equal(t, uint8(2), s.targetComponent)
+	assert.True(t, s.addressed(0))
+	assert.True(t, s.addressed(255))
+	assert.False(t, s.addressed(1))
+}
+
+func TestPacketSubscription(t *testing.T) {
+	// arrange
+	a := newVehicleTestAdaptor()
+	d := NewDriver(a, 0)
+	require.NoError(t, d.Start())
+	sub := subscribePackets(d, gobot.NewRealClock(), 1, 1)
+	defer sub.cancel()
+	// act
+	a.packets <- common.CraftMAVLink2Packet(2, 1, common.NewAttitude(1, 0, 0, 0, 0, 0, 0))
+	a.packets <- common.CraftMAVLink2Packet(1, 2, common.NewAttitude(2, 0, 0, 0, 0, 0, 0))
+	a.packets <- common.CraftMAVLink2Packet(1, 1, common.NewAttitude(3, 0, 0, 0, 0, 0, 0))
+	// assert
+	m, ok := sub.next(time.Now().Add(time.Second))
+	require.True(t, ok)
+	assert.Equal(t, uint32(3), m.(*common.Attitude).TIME_BOOT_MS)
+	_, ok = sub.next(time.Now().Add(10 * time.Millisecond))
+	assert.False(t, ok)
+}
+
+func TestMicroserviceExchangeRetransmission(t *testing.T) {
+	// arrange
+	a := newVehicleTestAdaptor()
+	d := NewDriver(a, 0)
+	require.NoError(t, d.Start())
+	s := newMicroservice(d, 1, 1, WithMicroserviceTimeout(10*time.Millisecond), WithMicroserviceRetries(2))
+	var handled int
+	// act
+	err := s.exchange("test", common.NewMissionClearAll(1, 1),
+		func(common.MAVLinkMessage) (common.MAVLinkMessage, bool, error) {
+			handled++
+			return nil, false, nil
+		})
+	// assert
+	require.EqualError(t, err, "test: no response of system 1 after 3 attempts")
+	assert.Equal(t, 0, handled)
+	assert.Len(t, a.written, 3)
+}
+
+func TestMicroserviceExchangeWithClock(t *testing.T) {
+	// arrange
+	a := newVehicleTestAdaptor()
+	d := NewDriver(a, 0)
+	require.NoError(t, d.Start())
+	clock := &timerSignalingClock{FakeClock: gobot.NewFakeClock(time.Now()), timers: make(chan struct{}, 10)}
+	s := newMicroservice(d, 1, 1, WithMicroserviceClock(clock), WithMicroserviceTimeout(time.Minute),
+		WithMicroserviceRetries(1))
+	done := make(chan error)
+	// act
+	go func() {
+		done <- s.exchange("test", common.NewMissionClearAll(1, 1),
+			func(common.MAVLinkMessage) (common.MAVLinkMessage, bool, error) { return nil, false, nil })
+	}()
+	for attempt := 1; attempt <= 2; attempt++ {
+		<-clock.timers
+		assert.Len(t, a.written, attempt)
+		clock.Advance(time.Minute)
+	}
+	// assert
+	require.EqualError(t, <-done, "test: no response of system 1 after 2 attempts")
+}
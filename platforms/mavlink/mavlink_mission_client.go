@@ -0,0 +1,243 @@
+package mavlink
+
+import (
+	"fmt"
+
+	common "gobot.io/x/gobot/v2/platforms/mavlink/common"
+)
+
+// MissionItem is a single item of a mission, e.g. a waypoint. For global frames X and Y are the latitude and longitude
+// in degrees * 1e7 and Z is the altitude in meters.
+type MissionItem struct {
+	Frame        uint8  // see MAV_FRAME
+	Command      uint16 // see MAV_CMD
+	Current      bool
+	Autocontinue bool
+	Param1       float32
+	Param2       float32
+	Param3       float32
+	Param4       float32
+	X            int32
+	Y            int32
+	Z            float32
+}
+
+// MissionResultError is returned when the vehicle answers with another result than MAV_MISSION_ACCEPTED.
+type MissionResultError struct {
+	Result uint8 // see MAV_MISSION_RESULT
+}
+
+func (e *MissionResultError) Error() string {
+	return fmt.Sprintf("mission not accepted: %s", missionResultText(e.Result))
+}
+
+// MissionClient implements the ground control station side of the MAVLink mission protocol with MISSION_ITEM_INT.
+type MissionClient struct {
+	*microservice
+}
+
+// NewMissionClient creates a new client for the mission protocol of the given vehicle. The driver needs to be started.
+//
+// Supported options:
+//
+//	"WithMicroserviceSystemID"
+//	"WithMicroserviceComponentID"
+//	"WithMicroserviceTimeout"
+//	"WithMicroserviceRetries"
+//	"WithMicroserviceClock"
+func NewMissionClient(
+	driver *Driver,
+	targetSystem, targetComponent uint8,
+	opts ...microserviceOptionApplier,
+) *MissionClient {
+	return &MissionClient{microservice: newMicroservice(driver, targetSystem, targetComponent, opts...)}
+}
+
+// Upload replaces the mission of the vehicle by the given items. A vehicle which requests the items by the deprecated
+// MISSION_REQUEST gets the items as MISSION_ITEM.
+func (c *MissionClient) Upload(items []MissionItem) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if len(items) > 0xFFFF {
+		return fmt.Errorf("mission upload: %d items exceed the maximum of 65535", len(items))
+	}
+
+	//nolint:gosec // checked above
+	count := common.NewMissionCount(uint16(len(items)), c.targetSystem, c.targetComponent)
+	return c.exchange("mission upload", count, func(message common.MAVLinkMessage) (common.MAVLinkMessage, bool, error) {
+		switch m := message.(type) {
+		case *common.MissionRequestInt:
+			if !c.addressed(m.TARGET_SYSTEM) {
+				return nil, false, nil
+			}
+			if int(m.SEQ) >= len(items) {
+				return nil, false, fmt.Errorf("requested item %d is out of range", m.SEQ)
+			}
+			return c.missionItemMessage(m.SEQ, items[m.SEQ]), false, nil
+		case *common.MissionRequest:
+			if !c.addressed(m.TARGET_SYSTEM) {
+				return nil, false, nil
+			}
+			if int(m.SEQ) >= len(items) {
+				return nil, false, fmt.Errorf("requested item %d is out of range", m.SEQ)
+			}
+			return c.legacyMissionItemMessage(m.SEQ, items[m.SEQ]), false, nil
+		case *common.MissionAck:
+			if !c.addressed(m.TARGET_SYSTEM) {
+				return nil, false, nil
+			}
+			if m.TYPE != common.MAV_MISSION_ACCEPTED {
+				return nil, false, &MissionResultError{Result: m.TYPE}
+			}
+			return nil, true, nil
+		}
+		return nil, false, nil
+	})
+}
+
+// Download reads the mission of the vehicle.
+func (c *MissionClient) Download() ([]MissionItem, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var items []MissionItem
+	count := -1
+
+	requestList := common.NewMissionRequestList(c.targetSystem, c.targetComponent)
+	err := c.exchange("mission download", requestList,
+		func(message common.MAVLinkMessage) (common.MAVLinkMessage, bool, error) {
+			switch m := message.(type) {
+			case *common.MissionCount:
+				if count >= 0 || !c.addressed(m.TARGET_SYSTEM) {
+					return nil, false, nil
+				}
+				count = int(m.COUNT)
+				items = make([]MissionItem, 0, count)
+			case *common.MissionItemInt:
+				if count < 0 || !c.addressed(m.TARGET_SYSTEM) || int(m.SEQ) != len(items) {
+					return nil, false, nil
+				}
+				items = append(items, missionItemFromMessage(m))
+			case *common.MissionAck:
+				if c.addressed(m.TARGET_SYSTEM) && m.TYPE != common.MAV_MISSION_ACCEPTED {
+					return nil, false, &MissionResultError{Result: m.TYPE}
+				}
+				return nil, false, nil
+			default:
+				return nil, false, nil
+			}
+
+			if len(items) == count {
+				return nil, true, nil
+			}
+			//nolint:gosec // the count is an uint16
+			return common.NewMissionRequestInt(uint16(len(items)), c.targetSystem, c.targetComponent), false, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	ack := common.NewMissionAck(c.targetSystem, c.targetComponent, common.MAV_MISSION_ACCEPTED)
+	if err := c.send(ack); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// Clear removes all mission items of the vehicle.
+func (c *MissionClient) Clear() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	clearAll := common.NewMissionClearAll(c.targetSystem, c.targetComponent)
+	return c.exchange("mission clear", clearAll, func(message common.MAVLinkMessage) (common.MAVLinkMessage, bool, error) {
+		m, ok := message.(*common.MissionAck)
+		if !ok || !c.addressed(m.TARGET_SYSTEM) {
+			return nil, false, nil
+		}
+		if m.TYPE != common.MAV_MISSION_ACCEPTED {
+			return nil, false, &MissionResultError{Result: m.TYPE}
+		}
+		return nil, true, nil
+	})
+}
+
+func (c *MissionClient) missionItemMessage(seq uint16, item MissionItem) *common.MissionItemInt {
+	return common.NewMissionItemInt(item.Param1, item.Param2, item.Param3, item.Param4, item.X, item.Y, item.Z, seq,
+		item.Command, c.targetSystem, c.targetComponent, item.Frame, boolToUint8(item.Current),
+		boolToUint8(item.Autocontinue))
+}
+
+// legacyMissionItemMessage creates the deprecated MISSION_ITEM, the coordinates are converted from the scaled integers
+// to degrees for global frames and to meters for local frames
+func (c *MissionClient) legacyMissionItemMessage(seq uint16, item MissionItem) *common.MissionItem {
+	scale := missionCoordinateScale(item.Frame)
+	return common.NewMissionItem(item.Param1, item.Param2, item.Param3, item.Param4, float32(float64(item.X)/scale),
+		float32(float64(item.Y)/scale), item.Z, seq, item.Command, c.targetSystem, c.targetComponent, item.Frame,
+		boolToUint8(item.Current), boolToUint8(item.Autocontinue))
+}
+
+func missionItemFromMessage(m *common.MissionItemInt) MissionItem {
+	return MissionItem{
+		Frame:        m.FRAME,
+		Command:      m.COMMAND,
+		Current:      m.CURRENT != 0,
+		Autocontinue: m.AUTOCONTINUE != 0,
+		Param1:       m.PARAM1,
+		Param2:       m.PARAM2,
+		Param3:       m.PARAM3,
+		Param4:       m.PARAM4,
+		X:            m.X,
+		Y:            m.Y,
+		Z:            m.Z,
+	}
+}
+
+// missionCoordinateScale returns the scale of X and Y of a MISSION_ITEM_INT in the given frame, latitude and longitude
+// are given in degrees * 1e7 and local positions in meters * 1e4
+func missionCoordinateScale(frame uint8) float64 {
+	switch frame {
+	case common.MAV_FRAME_GLOBAL, common.MAV_FRAME_GLOBAL_RELATIVE_ALT, common.MAV_FRAME_GLOBAL_INT,
+		common.MAV_FRAME_GLOBAL_RELATIVE_ALT_INT, common.MAV_FRAME_GLOBAL_TERRAIN_ALT:
+		return 1e7
+	case common.MAV_FRAME_LOCAL_NED, common.MAV_FRAME_LOCAL_ENU, common.MAV_FRAME_LOCAL_OFFSET_NED,
+		common.MAV_FRAME_BODY_NED, common.MAV_FRAME_BODY_OFFSET_NED:
+		return 1e4
+	default:
+		return 1
+	}
+}
+
+func boolToUint8(b bool) uint8 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func missionResultText(result uint8) string {
+	switch result {
+	case common.MAV_MISSION_ACCEPTED:
+		return "accepted"
+	case common.MAV_MISSION_ERROR:
+		return "error"
+	case common.MAV_MISSION_UNSUPPORTED_FRAME:
+		return "unsupported frame"
+	case common.MAV_MISSION_UNSUPPORTED:
+		return "unsupported command"
+	case common.MAV_MISSION_NO_SPACE:
+		return "no space"
+	case common.MAV_MISSION_INVALID, common.MAV_MISSION_INVALID_PARAM1, common.MAV_MISSION_INVALID_PARAM2,
+		common.MAV_MISSION_INVALID_PARAM3, common.MAV_MISSION_INVALID_PARAM4, common.MAV_MISSION_INVALID_PARAM5_X,
+		common.MAV_MISSION_INVALID_PARAM6_Y, common.MAV_MISSION_INVALID_PARAM7:
+		return "invalid parameter"
+	case common.MAV_MISSION_INVALID_SEQUENCE:
+		return "invalid sequence"
+	case common.MAV_MISSION_DENIED:
+		return "denied"
+	default:
+		return fmt.Sprintf("result %d", result)
+	}
+}
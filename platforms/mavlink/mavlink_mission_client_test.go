@@ -0,0 +1,251 @@
+package mavlink
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	common "gobot.io/x/gobot/v2/platforms/mavlink/common"
+)
+
+// serve calls the handler for all messages written by the driver
+func (a *vehicleTestAdaptor) serve(handle func(message common.MAVLinkMessage)) {
+	go func() {
+		for p := range a.written {
+			if m, err := p.MAVLinkMessage(); err == nil {
+				handle(m)
+			}
+		}
+	}()
+}
+
+// missionTestVehicle stores a mission like an autopilot, the given count of messages per message id is ignored to
+// force retransmissions
+type missionTestVehicle struct {
+	a       *vehicleTestAdaptor
+	mutex   sync.Mutex
+	items   []*common.MissionItemInt
+	upload  []*common.MissionItemInt
+	result  uint8
+	drop    map[uint32]int
+	lastAck *common.MissionAck
+}
+
+func newMissionTestVehicle(a *vehicleTestAdaptor, drop map[uint32]int) *missionTestVehicle {
+	v := &missionTestVehicle{a: a, drop: drop}
+	a.serve(v.handle)
+	return v
+}
+
+func (v *missionTestVehicle) handle(message common.MAVLinkMessage) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	if v.drop[message.Id()] > 0 {
+		v.drop[message.Id()]--
+		return
+	}
+
+	switch m := message.(type) {
+	case *common.MissionCount:
+		v.upload = make([]*common.MissionItemInt, 0, m.COUNT)
+		if m.COUNT == 0 {
+			v.items = nil
+			v.a.sendFromVehicle(1, common.NewMissionAck(255, 190, v.result))
+			return
+		}
+		v.a.sendFromVehicle(1, common.NewMissionRequestInt(0, 255, 190))
+	case *common.MissionItemInt:
+		if int(m.SEQ) != len(v.upload) {
+			return
+		}
+		v.upload = append(v.upload, m)
+		if len(v.upload) < cap(v.upload) {
+			//nolint:gosec // test data
+			v.a.sendFromVehicle(1, common.NewMissionRequestInt(uint16(len(v.upload)), 255, 190))
+			return
+		}
+		if v.result == common.MAV_MISSION_ACCEPTED {
+			v.items = v.upload
+		}
+		v.a.sendFromVehicle(1, common.NewMissionAck(255, 190, v.result))
+	case *common.MissionRequestList:
+		//nolint:gosec // test data
+		v.a.sendFromVehicle(1, common.NewMissionCount(uint16(len(v.items)), 255, 190))
+	case *common.MissionRequestInt:
+		if int(m.SEQ) < len(v.items) {
+			item := *v.items[m.SEQ]
+			item.TARGET_SYSTEM, item.TARGET_COMPONENT = 255, 190
+			v.a.sendFromVehicle(1, &item)
+		}
+	case *common.MissionClearAll:
+		v.items = nil
+		v.a.sendFromVehicle(1, common.NewMissionAck(255, 190, v.result))
+	case *common.MissionAck:
+		v.lastAck = m
+	}
+}
+
+func (v *missionTestVehicle) mission() ([]*common.MissionItemInt, *common.MissionAck) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	return v.items, v.lastAck
+}
+
+func initTestMissionClient(t *testing.T, drop map[uint32]int) (*MissionClient, *missionTestVehicle) {
+	t.Helper()
+	a := newVehicleTestAdaptor()
+	d := NewDriver(a, time.Millisecond)
+	require.NoError(t, d.Start())
+	v := newMissionTestVehicle(a, drop)
+	c := NewMissionClient(d, 1, 1, WithMicroserviceTimeout(30*time.Millisecond), WithMicroserviceRetries(3))
+	return c, v
+}
+
+var testMission = []MissionItem{
+	{
+		Frame: common.MAV_FRAME_GLOBAL_RELATIVE_ALT_INT, Command: common.MAV_CMD_NAV_TAKEOFF, Current: true,
+		Autocontinue: true, Z: 10,
+	},
+	{
+		Frame: common.MAV_FRAME_GLOBAL_RELATIVE_ALT_INT, Command: common.MAV_CMD_NAV_WAYPOINT, Autocontinue: true,
+		Param1: 5, X: 475000000, Y: 85000000, Z: 20,
+	},
+	{Frame: common.MAV_FRAME_MISSION, Command: common.MAV_CMD_NAV_RETURN_TO_LAUNCH, Autocontinue: true},
+}
+
+func TestMissionClientUploadAndDownload(t *testing.T) {
+	tests := map[string]struct {
+		drop map[uint32]int
+	}{
+		"lossless": {},
+		"lost_count_and_item": {
+			drop: map[uint32]int{44: 1, 73: 2},
+		},
+		"lost_request_list_and_request": {
+			drop: map[uint32]int{43: 1, 51: 1},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			c, v := initTestMissionClient(t, tc.drop)
+			// act
+			errUpload := c.Upload(testMission)
+			items, errDownload := c.Download()
+			// assert
+			require.NoError(t, errUpload)
+			require.NoError(t, errDownload)
+			assert.Equal(t, testMission, items)
+			stored, _ := v.mission()
+			require.Len(t, stored, 3)
+			for i, item := range stored {
+				assert.Equal(t, uint16(i), item.SEQ) //nolint:gosec // test data
+				assert.Equal(t, uint8(1), item.TARGET_SYSTEM)
+				assert.Equal(t, uint8(1), item.TARGET_COMPONENT)
+			}
+			require.Eventually(t, func() bool {
+				_, ack := v.mission()
+				return ack != nil && ack.TYPE == common.MAV_MISSION_ACCEPTED
+			}, time.Second, time.Millisecond)
+		})
+	}
+}
+
+func TestMissionClientEmptyMission(t *testing.T) {
+	// arrange
+	c, _ := initTestMissionClient(t, nil)
+	// act
+	errUpload := c.Upload(nil)
+	items, errDownload := c.Download()
+	// assert
+	require.NoError(t, errUpload)
+	require.NoError(t, errDownload)
+	assert.Empty(t, items)
+}
+
+func TestMissionClientClear(t *testing.T) {
+	// arrange
+	c, v := initTestMissionClient(t, map[uint32]int{45: 1})
+	require.NoError(t, c.Upload(testMission))
+	// act
+	err := c.Clear()
+	// assert
+	require.NoError(t, err)
+	stored, _ := v.mission()
+	assert.Empty(t, stored)
+}
+
+func TestMissionClientNotAccepted(t *testing.T) {
+	// arrange
+	c, v := initTestMissionClient(t, nil)
+	v.result = common.MAV_MISSION_NO_SPACE
+	// act
+	err := c.Upload(testMission)
+	// assert
+	var resultErr *MissionResultError
+	require.True(t, errors.As(err, &resultErr))
+	assert.Equal(t, uint8(common.MAV_MISSION_NO_SPACE), resultErr.Result)
+	require.EqualError(t, err, "mission upload: mission not accepted: no space")
+}
+
+func TestMissionClientNoResponse(t *testing.T) {
+	// arrange
+	c, _ := initTestMissionClient(t, map[uint32]int{43: 10})
+	// act
+	_, err := c.Download()
+	// assert
+	require.EqualError(t, err, "mission download: no response of system 1 after 4 attempts")
+}
+
+func TestMissionClientUploadLegacyRequest(t *testing.T) {
+	// arrange
+	a := newVehicleTestAdaptor()
+	d := NewDriver(a, time.Millisecond)
+	require.NoError(t, d.Start())
+	uploaded := make(chan []*common.MissionItem, 1)
+	var items []*common.MissionItem
+	// the vehicle requests the items by the deprecated MISSION_REQUEST
+	a.serve(func(message common.MAVLinkMessage) {
+		switch m := message.(type) {
+		case *common.MissionCount:
+			items = make([]*common.MissionItem, 0, m.COUNT)
+			a.sendFromVehicle(1, common.NewMissionRequest(0, 255, 190))
+		case *common.MissionItem:
+			items = append(items, m)
+			if len(items) < cap(items) {
+				//nolint:gosec // test data
+				a.sendFromVehicle(1, common.NewMissionRequest(uint16(len(items)), 255, 190))
+				return
+			}
+			uploaded <- items
+			a.sendFromVehicle(1, common.NewMissionAck(255, 190, common.MAV_MISSION_ACCEPTED))
+		}
+	})
+	c := NewMissionClient(d, 1, 1, WithMicroserviceTimeout(time.Second))
+	local := MissionItem{Frame: common.MAV_FRAME_LOCAL_NED, Command: common.MAV_CMD_NAV_WAYPOINT, X: 15000, Y: -25000}
+	// act
+	err := c.Upload(append(append([]MissionItem{}, testMission...), local))
+	// assert
+	require.NoError(t, err)
+	got := <-uploaded
+	require.Len(t, got, 4)
+	for i, item := range got {
+		assert.Equal(t, uint16(i), item.SEQ) //nolint:gosec // test data
+		assert.Equal(t, uint8(1), item.TARGET_SYSTEM)
+	}
+	assert.Equal(t, uint16(common.MAV_CMD_NAV_WAYPOINT), got[1].COMMAND)
+	assert.Equal(t, uint8(common.MAV_FRAME_GLOBAL_RELATIVE_ALT_INT), got[1].FRAME)
+	assert.InDelta(t, 47.5, got[1].X, 1e-5)
+	assert.InDelta(t, 8.5, got[1].Y, 1e-5)
+	assert.InDelta(t, 20, got[1].Z, 1e-5)
+	assert.InDelta(t, 5, got[1].PARAM1, 1e-5)
+	assert.Equal(t, uint8(1), got[1].AUTOCONTINUE)
+	assert.InDelta(t, 1.5, got[3].X, 1e-5)
+	assert.InDelta(t, -2.5, got[3].Y, 1e-5)
+}
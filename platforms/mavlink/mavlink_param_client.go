@@ -0,0 +1,195 @@
+package mavlink
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	common "gobot.io/x/gobot/v2/platforms/mavlink/common"
+)
+
+// paramIDLen is the maximum length of a parameter id
+const paramIDLen = 16
+
+// Param is an onboard parameter of a vehicle.
+type Param struct {
+	ID    string
+	Value float32
+	Type  uint8 // see MAV_PARAM_TYPE
+	Index uint16
+}
+
+// ParamClient implements the ground control station side of the MAVLink parameter protocol. The values are
+// transferred as they are, it is up to the caller to encode integer parameters as the autopilot expects.
+type ParamClient struct {
+	*microservice
+}
+
+// NewParamClient creates a new client for the parameter protocol of the given vehicle. The driver needs to be started.
+//
+// Supported options:
+//
+//	"WithMicroserviceSystemID"
+//	"WithMicroserviceComponentID"
+//	"WithMicroserviceTimeout"
+//	"WithMicroserviceRetries"
+//	"WithMicroserviceClock"
+func NewParamClient(
+	driver *Driver,
+	targetSystem, targetComponent uint8,
+	opts ...microserviceOptionApplier,
+) *ParamClient {
+	return &ParamClient{microservice: newMicroservice(driver, targetSystem, targetComponent, opts...)}
+}
+
+// List reads all parameters of the vehicle, sorted by index. Parameters which are missing after the stream of values
+// has ended are requested again one by one.
+func (c *ParamClient) List() ([]Param, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	sub := subscribePackets(c.driver, c.cfg.clock, c.targetSystem, c.targetComponent)
+	defer sub.cancel()
+
+	if err := c.send(common.NewParamRequestList(c.targetSystem, c.targetComponent)); err != nil {
+		return nil, err
+	}
+	deadline := c.cfg.clock.Now().Add(c.cfg.timeout)
+
+	params := make(map[uint16]Param)
+	count := -1
+	for attempt := 1; count < 0 || len(params) < count; {
+		message, ok := sub.next(deadline)
+		if ok {
+			m, isValue := message.(*common.ParamValue)
+			if !isValue || m.PARAM_INDEX >= m.PARAM_COUNT {
+				continue
+			}
+			if count < 0 {
+				count = int(m.PARAM_COUNT)
+			}
+			if _, known := params[m.PARAM_INDEX]; !known {
+				params[m.PARAM_INDEX] = paramFromMessage(m)
+				attempt = 1
+			}
+			deadline = c.cfg.clock.Now().Add(c.cfg.timeout)
+			continue
+		}
+
+		if attempt > c.cfg.retries {
+			return nil, fmt.Errorf("parameter list: %d of %d parameters received from system %d after %d attempts",
+				len(params), max(count, 0), c.targetSystem, attempt)
+		}
+		attempt++
+		if err := c.requestMissing(params, count); err != nil {
+			return nil, err
+		}
+		deadline = c.cfg.clock.Now().Add(c.cfg.timeout)
+	}
+
+	list := make([]Param, 0, len(params))
+	for _, p := range params {
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Index < list[j].Index })
+
+	return list, nil
+}
+
+// Get reads a single parameter of the vehicle.
+func (c *ParamClient) Get(id string) (Param, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	paramID, err := paramIDFromString(id)
+	if err != nil {
+		return Param{}, err
+	}
+
+	var param Param
+	read := common.NewParamRequestRead(-1, c.targetSystem, c.targetComponent, paramID)
+	err = c.exchange("parameter read", read, func(message common.MAVLinkMessage) (common.MAVLinkMessage, bool, error) {
+		m, ok := message.(*common.ParamValue)
+		if !ok || m.PARAM_ID != paramID {
+			return nil, false, nil
+		}
+		param = paramFromMessage(m)
+		return nil, true, nil
+	})
+
+	return param, err
+}
+
+// Set writes a parameter of the vehicle and verifies the value reported back by the vehicle.
+func (c *ParamClient) Set(id string, value float32, paramType uint8) (Param, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	paramID, err := paramIDFromString(id)
+	if err != nil {
+		return Param{}, err
+	}
+
+	var param Param
+	set := common.NewParamSet(value, c.targetSystem, c.targetComponent, paramID, paramType)
+	err = c.exchange("parameter set", set, func(message common.MAVLinkMessage) (common.MAVLinkMessage, bool, error) {
+		m, ok := message.(*common.ParamValue)
+		if !ok || m.PARAM_ID != paramID {
+			return nil, false, nil
+		}
+		param = paramFromMessage(m)
+		if m.PARAM_VALUE != value {
+			return nil, false, fmt.Errorf("%s was set to %v instead of %v", id, m.PARAM_VALUE, value)
+		}
+		return nil, true, nil
+	})
+
+	return param, err
+}
+
+// requestMissing requests the whole list again, if nothing was received so far, or each missing parameter.
+func (c *ParamClient) requestMissing(params map[uint16]Param, count int) error {
+	if count < 0 {
+		return c.send(common.NewParamRequestList(c.targetSystem, c.targetComponent))
+	}
+
+	for i := 0; i < count; i++ {
+		//nolint:gosec // the count is an uint16
+		if _, ok := params[uint16(i)]; ok {
+			continue
+		}
+		//nolint:gosec // the count is an uint16
+		read := common.NewParamRequestRead(int16(i), c.targetSystem, c.targetComponent, [paramIDLen]uint8{})
+		if err := c.send(read); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func paramFromMessage(m *common.ParamValue) Param {
+	return Param{
+		ID:    paramIDToString(m.PARAM_ID),
+		Value: m.PARAM_VALUE,
+		Type:  m.PARAM_TYPE,
+		Index: m.PARAM_INDEX,
+	}
+}
+
+func paramIDFromString(id string) ([paramIDLen]uint8, error) {
+	var paramID [paramIDLen]uint8
+	if len(id) == 0 || len(id) > paramIDLen {
+		return paramID, fmt.Errorf("parameter id '%s' needs to have 1 up to %d characters", id, paramIDLen)
+	}
+	copy(paramID[:], id)
+
+	return paramID, nil
+}
+
+func paramIDToString(paramID [paramIDLen]uint8) string {
+	if i := bytes.IndexByte(paramID[:], 0); i >= 0 {
+		return string(paramID[:i])
+	}
+	return string(paramID[:])
+}
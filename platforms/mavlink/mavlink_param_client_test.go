@@ -0,0 +1,184 @@
+package mavlink
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	common "gobot.io/x/gobot/v2/platforms/mavlink/common"
+)
+
+// paramTestVehicle stores parameters like an autopilot, the values of the skipped indexes are not sent on a list
+// request, read-only parameters are not changed by a set request
+type paramTestVehicle struct {
+	a        *vehicleTestAdaptor
+	mutex    sync.Mutex
+	params   []Param
+	skip     map[uint16]bool
+	readOnly map[string]bool
+	silent   bool
+}
+
+func newParamTestVehicle(a *vehicleTestAdaptor, count int) *paramTestVehicle {
+	v := &paramTestVehicle{a: a, skip: map[uint16]bool{}, readOnly: map[string]bool{}}
+	for i := 0; i < count; i++ {
+		//nolint:gosec // test data
+		v.params = append(v.params, Param{
+			ID: fmt.Sprintf("PARAM_%d", i), Value: float32(i), Type: common.MAV_PARAM_TYPE_REAL32, Index: uint16(i),
+		})
+	}
+	a.serve(v.handle)
+	return v
+}
+
+func (v *paramTestVehicle) handle(message common.MAVLinkMessage) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	if v.silent {
+		return
+	}
+
+	switch m := message.(type) {
+	case *common.ParamRequestList:
+		for _, p := range v.params {
+			if !v.skip[p.Index] {
+				v.sendValue(p)
+			}
+		}
+		v.skip = map[uint16]bool{}
+	case *common.ParamRequestRead:
+		for _, p := range v.params {
+			if (m.PARAM_INDEX >= 0 && int(p.Index) == int(m.PARAM_INDEX)) ||
+				(m.PARAM_INDEX < 0 && p.ID == paramIDToString(m.PARAM_ID)) {
+				v.sendValue(p)
+			}
+		}
+	case *common.ParamSet:
+		for i, p := range v.params {
+			if p.ID != paramIDToString(m.PARAM_ID) {
+				continue
+			}
+			if !v.readOnly[p.ID] {
+				v.params[i].Value = m.PARAM_VALUE
+			}
+			v.sendValue(v.params[i])
+		}
+	}
+}
+
+func (v *paramTestVehicle) sendValue(p Param) {
+	id, _ := paramIDFromString(p.ID)
+	//nolint:gosec // test data
+	v.a.sendFromVehicle(1, common.NewParamValue(p.Value, uint16(len(v.params)), p.Index, id, p.Type))
+}
+
+func initTestParamClient(t *testing.T, count int) (*ParamClient, *paramTestVehicle) {
+	t.Helper()
+	a := newVehicleTestAdaptor()
+	d := NewDriver(a, 0)
+	require.NoError(t, d.Start())
+	v := newParamTestVehicle(a, count)
+	c := NewParamClient(d, 1, 1, WithMicroserviceTimeout(30*time.Millisecond), WithMicroserviceRetries(3))
+	return c, v
+}
+
+func TestParamClientList(t *testing.T) {
+	tests := map[string]struct {
+		skip []uint16
+	}{
+		"complete":         {},
+		"gaps":             {skip: []uint16{0, 3, 4, 9}},
+		"lost_all_but_one": {skip: []uint16{0, 1, 2, 3, 4, 5, 6, 7, 8}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			c, v := initTestParamClient(t, 10)
+			for _, i := range tc.skip {
+				v.skip[i] = true
+			}
+			// act
+			params, err := c.List()
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, v.params, params)
+		})
+	}
+}
+
+func TestParamClientListNoResponse(t *testing.T) {
+	// arrange
+	c, v := initTestParamClient(t, 10)
+	v.silent = true
+	// act
+	_, err := c.List()
+	// assert
+	require.EqualError(t, err, "parameter list: 0 of 0 parameters received from system 1 after 4 attempts")
+}
+
+func TestParamClientGet(t *testing.T) {
+	// arrange
+	c, _ := initTestParamClient(t, 3)
+	// act
+	p, err := c.Get("PARAM_2")
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, Param{ID: "PARAM_2", Value: 2, Type: common.MAV_PARAM_TYPE_REAL32, Index: 2}, p)
+}
+
+func TestParamClientSet(t *testing.T) {
+	// arrange
+	c, v := initTestParamClient(t, 3)
+	v.readOnly["PARAM_0"] = true
+	// act
+	p, err := c.Set("PARAM_1", 42.5, common.MAV_PARAM_TYPE_REAL32)
+	_, errReadOnly := c.Set("PARAM_0", 1, common.MAV_PARAM_TYPE_REAL32)
+	_, errUnknown := c.Set("UNKNOWN", 1, common.MAV_PARAM_TYPE_REAL32)
+	_, errID := c.Set("PARAMETER_ID_TOO_LONG", 1, common.MAV_PARAM_TYPE_REAL32)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, Param{ID: "PARAM_1", Value: 42.5, Type: common.MAV_PARAM_TYPE_REAL32, Index: 1}, p)
+	require.EqualError(t, errReadOnly, "parameter set: PARAM_0 was set to 0 instead of 1")
+	require.EqualError(t, errUnknown, "parameter set: no response of system 1 after 4 attempts")
+	require.EqualError(t, errID, "parameter id 'PARAMETER_ID_TOO_LONG' needs to have 1 up to 16 characters")
+}
+
+func TestParamID(t *testing.T) {
+	// arrange
+	const id = "SIXTEEN_CHARS_ID"
+	// act
+	paramID, err := paramIDFromString(id)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, id, paramIDToString(paramID))
+}
+
+func TestParamClientListWithClock(t *testing.T) {
+	// arrange
+	a := newVehicleTestAdaptor()
+	d := NewDriver(a, 0)
+	require.NoError(t, d.Start())
+	v := newParamTestVehicle(a, 3)
+	v.silent = true
+	clock := &timerSignalingClock{FakeClock: gobot.NewFakeClock(time.Now()), timers: make(chan struct{}, 10)}
+	c := NewParamClient(d, 1, 1, WithMicroserviceClock(clock), WithMicroserviceTimeout(time.Minute),
+		WithMicroserviceRetries(1))
+	done := make(chan error)
+	// act
+	go func() {
+		_, err := c.List()
+		done <- err
+	}()
+	<-clock.timers
+	clock.Advance(time.Minute)
+	<-clock.timers
+	clock.Advance(time.Minute)
+	// assert
+	require.EqualError(t, <-done, "parameter list: 0 of 0 parameters received from system 1 after 2 attempts")
+}
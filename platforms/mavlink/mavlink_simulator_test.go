@@ -0,0 +1,130 @@
+package mavlink
+
+import (
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	common "gobot.io/x/gobot/v2/platforms/mavlink/common"
+	"gobot.io/x/gobot/v2/platforms/mavlink/simulator"
+)
+
+// the connections are not closed after the tests, because the read loop of the driver can not be stopped
+
+// newTestSimulator creates a fast climbing simulator, the rate of the telemetry is kept below the packet rate of the
+// driver, otherwise the responses of the vehicle are dropped by the full UDP receive buffer
+func newTestSimulator() *simulator.Simulator {
+	return simulator.NewSimulator(
+		simulator.WithHeartbeatInterval(100*time.Millisecond),
+		simulator.WithTelemetryInterval(100*time.Millisecond),
+		simulator.WithVerticalSpeed(20),
+	)
+}
+
+func initTestSimulatorAdaptor(t *testing.T, sim *simulator.Simulator) *Adaptor {
+	t.Helper()
+	gcsEnd, vehicleEnd := net.Pipe()
+	go func() { _ = sim.Serve(vehicleEnd) }()
+	a := NewAdaptor("/dev/null")
+	a.connect = func(string) (io.ReadWriteCloser, error) { return gcsEnd, nil }
+	require.NoError(t, a.Connect())
+	return a
+}
+
+func initTestSimulatorUDPAdaptor(t *testing.T, sim *simulator.Simulator) *UDPAdaptor {
+	t.Helper()
+	a := NewUDPAdaptor("127.0.0.1:0", WithUDPReplyToSender())
+	require.NoError(t, a.Connect())
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	//nolint:forcetypeassert // ok here
+	go func() { _ = sim.ServeUDP(conn, a.sock.(*net.UDPConn).LocalAddr()) }()
+	return a
+}
+
+func initTestSimulatorVehicleDriver(t *testing.T, a BaseAdaptor) *VehicleDriver {
+	t.Helper()
+	d := NewVehicleDriver(a, WithVehicleLinkTimeout(500*time.Millisecond), WithVehicleCommandTimeout(200*time.Millisecond))
+	require.NoError(t, d.Start())
+	t.Cleanup(func() { _ = d.Halt() })
+	require.Eventually(t, func() bool { return d.State().Connected && d.State().SystemID == 1 },
+		2*time.Second, time.Millisecond)
+	return d
+}
+
+func TestVehicleDriverWithSimulator(t *testing.T) {
+	tests := map[string]struct {
+		adaptor func(t *testing.T, sim *simulator.Simulator) BaseAdaptor
+	}{
+		"serial": {
+			adaptor: func(t *testing.T, sim *simulator.Simulator) BaseAdaptor { return initTestSimulatorAdaptor(t, sim) },
+		},
+		"udp": {
+			adaptor: func(t *testing.T, sim *simulator.Simulator) BaseAdaptor { return initTestSimulatorUDPAdaptor(t, sim) },
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			sim := newTestSimulator()
+			d := initTestSimulatorVehicleDriver(t, tc.adaptor(t, sim))
+			// act & assert
+			require.EqualError(t, d.Takeoff(10), "command 22 was not accepted: temporarily rejected")
+			require.NoError(t, d.SetMode(simulator.ModeGuided))
+			require.NoError(t, d.Arm())
+			require.NoError(t, d.Takeoff(10))
+			require.Eventually(t, func() bool { return d.State().Position.RelativeAltitude > 9.5 },
+				2*time.Second, time.Millisecond)
+			assert.True(t, sim.Airborne())
+			assert.True(t, d.State().Armed)
+			require.NoError(t, d.ReturnToLaunch())
+			require.Eventually(t, func() bool { return !d.State().Armed }, 2*time.Second, time.Millisecond)
+			assert.False(t, sim.Airborne())
+			assert.Equal(t, uint32(simulator.ModeRTL), d.State().CustomMode)
+		})
+	}
+}
+
+func TestMissionClientWithSimulator(t *testing.T) {
+	// arrange
+	sim := newTestSimulator()
+	d := initTestSimulatorVehicleDriver(t, initTestSimulatorUDPAdaptor(t, sim))
+	c := NewMissionClient(d.Driver, 1, 1)
+	items := []MissionItem{
+		{Frame: common.MAV_FRAME_GLOBAL_RELATIVE_ALT_INT, Command: common.MAV_CMD_NAV_TAKEOFF, Current: true,
+			Autocontinue: true, Z: 10},
+		{Frame: common.MAV_FRAME_GLOBAL_RELATIVE_ALT_INT, Command: common.MAV_CMD_NAV_WAYPOINT, Autocontinue: true,
+			X: 473977420, Y: 85455940, Z: 20},
+	}
+	// act
+	errUpload := c.Upload(items)
+	downloaded, errDownload := c.Download()
+	errClear := c.Clear()
+	// assert
+	require.NoError(t, errUpload)
+	require.NoError(t, errDownload)
+	assert.Equal(t, items, downloaded)
+	require.NoError(t, errClear)
+	assert.Empty(t, sim.Mission())
+}
+
+func TestParamClientWithSimulator(t *testing.T) {
+	// arrange
+	sim := newTestSimulator()
+	d := initTestSimulatorVehicleDriver(t, initTestSimulatorAdaptor(t, sim))
+	c := NewParamClient(d.Driver, 1, 1)
+	// act
+	params, errList := c.List()
+	p, errSet := c.Set("RTL_ALT", 3000, common.MAV_PARAM_TYPE_INT32)
+	// assert
+	require.NoError(t, errList)
+	assert.Len(t, params, 5)
+	require.NoError(t, errSet)
+	assert.Equal(t, Param{ID: "RTL_ALT", Value: 3000, Type: common.MAV_PARAM_TYPE_INT32, Index: 1}, p)
+	value, _, _ := sim.Param("RTL_ALT")
+	assert.InDelta(t, 3000, value, 0)
+}
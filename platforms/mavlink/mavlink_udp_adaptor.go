@@ -2,6 +2,7 @@ package mavlink
 
 import (
 	"net"
+	"sync/atomic"
 
 	common "gobot.io/x/gobot/v2/platforms/mavlink/common"
 )
@@ -13,20 +14,34 @@ type UDPConnection interface {
 }
 
 type UDPAdaptor struct {
-	name string
-	port string
-	sock UDPConnection
+	name    string
+	port    string
+	sock    UDPConnection
+	version atomic.Int32
+	remote  atomic.Pointer[net.UDPAddr]
+	cfg     *udpAdaptorConfiguration
 }
 
 var _ BaseAdaptor = (*UDPAdaptor)(nil)
 
 // NewAdaptor creates a new Mavlink-over-UDP adaptor with specified
 // port.
-func NewUDPAdaptor(port string) *UDPAdaptor {
-	return &UDPAdaptor{
+//
+// Supported options:
+//
+//	"WithUDPReplyToSender"
+func NewUDPAdaptor(port string, opts ...udpAdaptorOptionApplier) *UDPAdaptor {
+	m := &UDPAdaptor{
 		name: "Mavlink",
 		port: port,
+		cfg:  &udpAdaptorConfiguration{},
 	}
+
+	for _, o := range opts {
+		o.apply(m.cfg)
+	}
+
+	return m
 }
 
 func (m *UDPAdaptor) Name() string     { return m.name }
@@ -69,7 +84,7 @@ func (m *UDPAdaptor) ReadMAVLinkPacket() (*common.MAVLinkPacket, error) {
 	buf := make([]byte, 4096)
 
 	for {
-		got, _, err := m.sock.ReadFromUDP(buf)
+		got, remote, err := m.sock.ReadFromUDP(buf)
 		if err != nil {
 			return nil, err
 		}
@@ -79,19 +94,41 @@ func (m *UDPAdaptor) ReadMAVLinkPacket() (*common.MAVLinkPacket, error) {
 		sof := buf[0]
 		length := buf[1]
 
-		if sof != common.MAVLINK_10_STX {
+		switch sof {
+		case common.MAVLINK_10_STX:
+			if length > 250 {
+				continue
+			}
+		case common.MAVLINK_20_STX:
+			// packets with unknown incompatibility flags must be dropped
+			if buf[2]&^common.MAVLINK_IFLAG_SIGNED != 0 {
+				continue
+			}
+		default:
 			continue
 		}
-		if length > 250 {
-			continue
+		p := &common.MAVLinkPacket{}
+		p.Decode(buf)
+		m.version.Store(int32(p.Version())) //nolint:gosec // only 1 or 2
+		if m.cfg.replyToSender && remote != nil {
+			m.remote.Store(remote)
 		}
-		m := &common.MAVLinkPacket{}
-		m.Decode(buf)
-		return m, nil
+		return p, nil
 	}
 }
 
+// ProtocolVersion returns the MAVLink version of the last received packet, which is 1 until a packet was received.
+func (m *UDPAdaptor) ProtocolVersion() int {
+	return protocolVersion(&m.version)
+}
+
+// Write sends the data to the port of the adaptor. If configured by WithUDPReplyToSender(), the data is sent to the
+// sender of the last received packet, until a packet was received also to the port of the adaptor.
 func (m *UDPAdaptor) Write(b []byte) (int, error) {
+	if remote := m.remote.Load(); remote != nil {
+		return m.sock.WriteTo(b, remote)
+	}
+
 	addr, err := net.ResolveUDPAddr("udp", m.Port())
 	if err != nil {
 		return 0, err
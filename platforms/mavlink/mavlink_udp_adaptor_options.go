@@ -0,0 +1,30 @@
+package mavlink
+
+// udpAdaptorOptionApplier needs to be implemented by each configurable option type of the UDP adaptor
+type udpAdaptorOptionApplier interface {
+	apply(cfg *udpAdaptorConfiguration)
+}
+
+// udpAdaptorConfiguration contains all changeable attributes of the UDP adaptor.
+type udpAdaptorConfiguration struct {
+	replyToSender bool
+}
+
+// udpReplyToSenderOption is the type for applying the answer to the sender of the last packet to the configuration
+type udpReplyToSenderOption bool
+
+// WithUDPReplyToSender is used to send the data to the sender of the last received packet instead of the port of the
+// adaptor. This is useful, if the vehicle is not known in advance, e.g. the simulator or a single vehicle which sends
+// to the listening port of the adaptor. For networks with multiple peers, e.g. a vehicle and a further ground control
+// station, the data would be sent to the peer who sent the last packet, so this is disabled by default.
+func WithUDPReplyToSender() udpAdaptorOptionApplier {
+	return udpReplyToSenderOption(true)
+}
+
+func (o udpReplyToSenderOption) String() string {
+	return "reply to sender option for MAVLink UDP adaptor"
+}
+
+func (o udpReplyToSenderOption) apply(cfg *udpAdaptorConfiguration) {
+	cfg.replyToSender = bool(o)
+}
@@ -0,0 +1,16 @@
+package mavlink
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithUDPReplyToSender(t *testing.T) {
+	// arrange
+	cfg := &udpAdaptorConfiguration{}
+	// act
+	WithUDPReplyToSender().apply(cfg)
+	// assert
+	assert.True(t, cfg.replyToSender)
+}
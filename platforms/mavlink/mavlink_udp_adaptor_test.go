@@ -14,7 +14,10 @@ import (
 	mavlink "gobot.io/x/gobot/v2/platforms/mavlink/common"
 )
 
-var _ gobot.Adaptor = (*UDPAdaptor)(nil)
+var (
+	_ gobot.Adaptor     = (*UDPAdaptor)(nil)
+	_ ProtocolVersioner = (*UDPAdaptor)(nil)
+)
 
 type MockUDPConnection struct {
 	TestClose       func() error
@@ -87,6 +90,54 @@ func TestMavlinkUDPAdaptorWrite(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestMavlinkUDPAdaptorWriteWithMultiplePeers(t *testing.T) {
+	tests := map[string]struct {
+		opts []udpAdaptorOptionApplier
+		want []string
+	}{
+		"to_port_by_default": {
+			want: []string{":14550", ":14550", ":14550"},
+		},
+		"to_last_sender": {
+			opts: []udpAdaptorOptionApplier{WithUDPReplyToSender()},
+			want: []string{":14550", "192.168.0.10:14555", "192.168.0.20:14560"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := NewUDPAdaptor(":14550", tc.opts...)
+			senders := []*net.UDPAddr{
+				{IP: net.IPv4(192, 168, 0, 10), Port: 14555},
+				{IP: net.IPv4(192, 168, 0, 20), Port: 14560},
+			}
+			var written []string
+			m := NewMockUDPConnection()
+			m.TestReadFromUDP = func(b []byte) (int, *net.UDPAddr, error) {
+				sender := senders[0]
+				senders = senders[1:]
+				return copy(b, mavlink.CraftMAVLinkPacket(1, 1, mavlink.NewAttitude(1, 0, 0, 0, 0, 0, 0)).Pack()), sender, nil
+			}
+			m.TestWriteTo = func(b []byte, addr net.Addr) (int, error) {
+				written = append(written, addr.String())
+				return len(b), nil
+			}
+			a.sock = m
+			// act
+			_, err := a.Write([]byte{0x01})
+			require.NoError(t, err)
+			for i := 0; i < 2; i++ {
+				_, err = a.ReadMAVLinkPacket()
+				require.NoError(t, err)
+				_, err = a.Write([]byte{0x02})
+				require.NoError(t, err)
+			}
+			// assert
+			assert.Equal(t, tc.want, written)
+		})
+	}
+}
+
 func TestMavlinkReadMAVLinkReadDefaultPacket(t *testing.T) {
 	a := initTestMavlinkUDPAdaptor()
 	_ = a.Connect()
@@ -140,3 +191,33 @@ func TestMavlinkReadMAVLinkPacketReadError(t *testing.T) {
 	_, err := a.ReadMAVLinkPacket()
 	require.ErrorContains(t, err, "read error")
 }
+
+func TestMavlinkUDPAdaptorReadMAVLink2Packet(t *testing.T) {
+	a := initTestMavlinkUDPAdaptor()
+	_ = a.Connect()
+	defer func() { _ = a.Finalize() }()
+
+	unknownFlags := mavlink.CraftMAVLink2Packet(1, 1, mavlink.NewAttitude(1, 0, 0, 0, 0, 0, 0))
+	unknownFlags.IncompatFlags = 0x80
+	packets := [][]byte{
+		unknownFlags.Pack(),
+		mavlink.CraftMAVLink2Packet(1, 1, mavlink.NewAttitude(2, 0, 0, 0, 0, 0, 0)).Pack(),
+	}
+	m := NewMockUDPConnection()
+	m.TestReadFromUDP = func(b []byte) (int, *net.UDPAddr, error) {
+		n := copy(b, packets[0])
+		packets = packets[1:]
+		return n, nil, nil
+	}
+	a.sock = m
+
+	assert.Equal(t, 1, a.ProtocolVersion())
+	p, err := a.ReadMAVLinkPacket()
+	require.NoError(t, err)
+	assert.Equal(t, 2, p.Version())
+	assert.Equal(t, uint32(30), p.MessageID)
+	msg, err := p.MAVLinkMessage()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), msg.(*mavlink.Attitude).TIME_BOOT_MS)
+	assert.Equal(t, 2, a.ProtocolVersion())
+}
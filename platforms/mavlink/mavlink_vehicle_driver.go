@@ -0,0 +1,484 @@
+package mavlink
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+	common "gobot.io/x/gobot/v2/platforms/mavlink/common"
+)
+
+const (
+	// VehicleConnectedEvent event, the system id of the vehicle is emitted when the first heartbeat is received or
+	// the link is back after a loss
+	VehicleConnectedEvent = "vehicleConnected"
+	// VehicleLinkLostEvent event, the system id of the vehicle is emitted when no heartbeat was received within the
+	// link timeout
+	VehicleLinkLostEvent = "vehicleLinkLost"
+)
+
+// VehicleAttitude contains the attitude of the vehicle in radians and radians per second.
+type VehicleAttitude struct {
+	Roll       float32
+	Pitch      float32
+	Yaw        float32
+	RollSpeed  float32
+	PitchSpeed float32
+	YawSpeed   float32
+}
+
+// VehiclePosition contains the fused global position of the vehicle. Latitude and longitude are given in degrees,
+// altitudes in meters, velocities in meters per second and the heading in degrees.
+type VehiclePosition struct {
+	Latitude         float64
+	Longitude        float64
+	Altitude         float32
+	RelativeAltitude float32
+	VX               float32
+	VY               float32
+	VZ               float32
+	Heading          float32
+}
+
+// VehicleBattery contains the battery state of the vehicle. Voltage is given in volts, the current in amperes (-1 if
+// not measured) and the remaining energy in percent (-1 if not estimated).
+type VehicleBattery struct {
+	Voltage   float32
+	Current   float32
+	Remaining int8
+}
+
+// VehicleGPS contains the raw GPS state of the vehicle. Latitude and longitude are given in degrees, the altitude in
+// meters.
+type VehicleGPS struct {
+	FixType           uint8
+	SatellitesVisible uint8
+	Latitude          float64
+	Longitude         float64
+	Altitude          float32
+	HDOP              float32
+	VDOP              float32
+}
+
+// VehicleState is the state of the vehicle, updated from the received messages.
+type VehicleState struct {
+	SystemID      uint8
+	ComponentID   uint8
+	Type          uint8 // see MAV_TYPE
+	Autopilot     uint8 // see MAV_AUTOPILOT
+	BaseMode      uint8 // see MAV_MODE_FLAG
+	CustomMode    uint32
+	SystemStatus  uint8 // see MAV_STATE
+	Armed         bool
+	Connected     bool // updated by the link supervision
+	LastHeartbeat time.Time
+	Attitude      VehicleAttitude
+	Position      VehiclePosition
+	Battery       VehicleBattery
+	GPS           VehicleGPS
+}
+
+// CommandResultError is returned when a command was acknowledged with another result than MAV_RESULT_ACCEPTED.
+type CommandResultError struct {
+	Command uint16
+	Result  uint8 // see MAV_RESULT
+}
+
+func (e *CommandResultError) Error() string {
+	return fmt.Sprintf("command %d was not accepted: %s", e.Command, commandResultText(e.Result))
+}
+
+// VehicleDriver is a high-level driver for a MAVLink vehicle. It acts as a ground control station, sends periodic
+// heartbeats, supervises the link to the vehicle and provides commands with acknowledge handling.
+type VehicleDriver struct {
+	*Driver
+	cfg     *vehicleConfiguration
+	mutex   sync.Mutex
+	state   VehicleState
+	pending map[uint16]chan *common.CommandAck
+	events  chan *gobot.Event
+	halt    chan struct{}
+}
+
+// NewVehicleDriver creates a new driver for a MAVLink vehicle, e.g. a drone or rover.
+//
+// Supported options:
+//
+//	"WithVehicleName"
+//	"WithVehicleSystemID"
+//	"WithVehicleComponentID"
+//	"WithVehicleTarget"
+//	"WithVehicleHeartbeatInterval"
+//	"WithVehicleLinkTimeout"
+//	"WithVehicleCommandTimeout"
+//	"WithVehicleCommandRetries"
+//	"WithVehicleClock"
+//
+// Additionally to the events of the Driver it adds the following events:
+//
+//	"vehicleConnected" - triggered when the first heartbeat of the vehicle is received or after a link loss
+//	"vehicleLinkLost" - triggered when the heartbeat of the vehicle is missing
+func NewVehicleDriver(a BaseAdaptor, opts ...vehicleOptionApplier) *VehicleDriver {
+	d := &VehicleDriver{
+		Driver: NewDriver(a),
+		cfg: &vehicleConfiguration{
+			name:              gobot.DefaultName("MavlinkVehicle"),
+			systemID:          255,
+			componentID:       common.MAV_COMP_ID_MISSIONPLANNER,
+			heartbeatInterval: time.Second,
+			linkTimeout:       3 * time.Second,
+			commandTimeout:    1500 * time.Millisecond,
+			commandRetries:    3,
+			clock:             gobot.NewRealClock(),
+		},
+		pending: make(map[uint16]chan *common.CommandAck),
+	}
+
+	for _, o := range opts {
+		o.apply(d.cfg)
+	}
+
+	d.SetName(d.cfg.name)
+	d.state.SystemID = d.cfg.targetSystem
+	d.state.ComponentID = d.cfg.targetComponent
+
+	d.AddEvent(VehicleConnectedEvent)
+	d.AddEvent(VehicleLinkLostEvent)
+
+	return d
+}
+
+// Start starts the reading of packets, the GCS heartbeat and the link supervision.
+func (d *VehicleDriver) Start() error {
+	if d.cfg.linkTimeout <= 0 {
+		return fmt.Errorf("the link timeout of the vehicle driver needs to be greater than zero")
+	}
+
+	events := d.Subscribe()
+	halt := make(chan struct{})
+
+	d.mutex.Lock()
+	d.events, d.halt = events, halt
+	d.mutex.Unlock()
+
+	go d.processPackets(events, halt)
+	go d.superviseLink(halt)
+
+	return d.Driver.Start()
+}
+
+// Halt stops the GCS heartbeat and the link supervision.
+func (d *VehicleDriver) Halt() error {
+	d.mutex.Lock()
+	halt, events := d.halt, d.events
+	d.halt, d.events = nil, nil
+	d.mutex.Unlock()
+
+	if halt != nil {
+		// unsubscribe before stopping the reader, so the eventer is never blocked by a full channel
+		d.Unsubscribe(events)
+		close(halt)
+	}
+
+	return d.Driver.Halt()
+}
+
+// State returns a copy of the current vehicle state.
+func (d *VehicleDriver) State() VehicleState {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.state
+}
+
+// Arm arms the vehicle.
+func (d *VehicleDriver) Arm() error {
+	return d.SendCommand(common.MAV_CMD_COMPONENT_ARM_DISARM, 1)
+}
+
+// Disarm disarms the vehicle.
+func (d *VehicleDriver) Disarm() error {
+	return d.SendCommand(common.MAV_CMD_COMPONENT_ARM_DISARM, 0)
+}
+
+// SetMode changes the autopilot specific flight mode of the vehicle, e.g. 4 for "GUIDED" on an ArduCopter.
+func (d *VehicleDriver) SetMode(customMode uint32) error {
+	return d.SendCommand(common.MAV_CMD_DO_SET_MODE, common.MAV_MODE_FLAG_CUSTOM_MODE_ENABLED, float32(customMode))
+}
+
+// Takeoff lets the vehicle take off to the given altitude in meters above the current position.
+func (d *VehicleDriver) Takeoff(altitude float32) error {
+	return d.SendCommand(common.MAV_CMD_NAV_TAKEOFF, 0, 0, 0, 0, 0, 0, altitude)
+}
+
+// Land lets the vehicle land at the current position.
+func (d *VehicleDriver) Land() error {
+	return d.SendCommand(common.MAV_CMD_NAV_LAND)
+}
+
+// ReturnToLaunch lets the vehicle return to the launch position.
+func (d *VehicleDriver) ReturnToLaunch() error {
+	return d.SendCommand(common.MAV_CMD_NAV_RETURN_TO_LAUNCH)
+}
+
+// SendCommand sends a COMMAND_LONG with up to 7 parameters to the vehicle and waits for the COMMAND_ACK. The command
+// is retransmitted with an increased confirmation counter if no acknowledge is received within the command timeout.
+// A long running command, which is acknowledged with MAV_RESULT_IN_PROGRESS, is not retransmitted, but the final
+// acknowledge is awaited, as long as the progress is reported within the command timeout.
+// A *CommandResultError is returned if the vehicle does not accept the command. The call blocks until the command is
+// acknowledged, so it must not be called directly in an event handler of the driver.
+func (d *VehicleDriver) SendCommand(command uint16, params ...float32) error {
+	if len(params) > 7 {
+		return fmt.Errorf("command %d accepts up to 7 parameters, got %d", command, len(params))
+	}
+	var p [7]float32
+	copy(p[:], params)
+
+	ack := make(chan *common.CommandAck, 1)
+	var err error
+
+	d.mutex.Lock()
+	halt, target, component := d.halt, d.state.SystemID, d.state.ComponentID
+	_, inProgress := d.pending[command]
+	switch {
+	case halt == nil:
+		err = fmt.Errorf("vehicle driver is not started")
+	case target == 0:
+		err = fmt.Errorf("no vehicle available for command %d", command)
+	case inProgress:
+		err = fmt.Errorf("command %d is already in progress", command)
+	default:
+		d.pending[command] = ack
+	}
+	d.mutex.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		d.mutex.Lock()
+		delete(d.pending, command)
+		d.mutex.Unlock()
+	}()
+
+	for attempt := 0; attempt <= d.cfg.commandRetries; attempt++ {
+		//nolint:gosec // the confirmation counter saturates at 255
+		confirmation := uint8(min(attempt, 255))
+		message := common.NewCommandLong(p[0], p[1], p[2], p[3], p[4], p[5], p[6], command, target, component,
+			confirmation)
+		if err := d.SendMessage(d.cfg.systemID, d.cfg.componentID, message); err != nil {
+			return err
+		}
+
+		if done, err := d.waitForCommandAck(command, ack, halt); done {
+			return err
+		}
+	}
+
+	return fmt.Errorf("command %d was not acknowledged by system %d after %d attempts", command, target,
+		d.cfg.commandRetries+1)
+}
+
+// waitForCommandAck waits for the final COMMAND_ACK of the command. Each MAV_RESULT_IN_PROGRESS restarts the command
+// timeout, because a long running command is not retransmitted. Returns false, if the command timeout elapses before
+// any acknowledge was received, so the command can be retransmitted.
+func (d *VehicleDriver) waitForCommandAck(command uint16, ack chan *common.CommandAck,
+	halt chan struct{},
+) (bool, error) {
+	inProgress := false
+	timeout := d.cfg.clock.NewTimer(d.cfg.commandTimeout)
+	defer func() { timeout.Stop() }()
+
+	for {
+		select {
+		case a := <-ack:
+			switch a.RESULT {
+			case common.MAV_RESULT_ACCEPTED:
+				return true, nil
+			case common.MAV_RESULT_IN_PROGRESS:
+				inProgress = true
+				timeout.Stop()
+				timeout = d.cfg.clock.NewTimer(d.cfg.commandTimeout)
+			default:
+				return true, &CommandResultError{Command: command, Result: a.RESULT}
+			}
+		case <-timeout.C():
+			if inProgress {
+				return true, fmt.Errorf("command %d is in progress, but the final acknowledge is missing", command)
+			}
+			return false, nil
+		case <-halt:
+			return true, fmt.Errorf("vehicle driver halted while waiting for command %d", command)
+		}
+	}
+}
+
+// processPackets updates the state from the received packets until halted.
+func (d *VehicleDriver) processPackets(events chan *gobot.Event, halt chan struct{}) {
+	for {
+		select {
+		case evt := <-events:
+			if evt.Name != PacketEvent {
+				continue
+			}
+			if packet, ok := evt.Data.(*common.MAVLinkPacket); ok {
+				d.handlePacket(packet)
+			}
+		case <-halt:
+			return
+		}
+	}
+}
+
+func (d *VehicleDriver) handlePacket(packet *common.MAVLinkPacket) {
+	message, err := packet.MAVLinkMessage()
+	if err != nil {
+		return
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if hb, ok := message.(*common.Heartbeat); ok && d.state.SystemID == 0 &&
+		hb.TYPE != common.MAV_TYPE_GCS && packet.SystemID != d.cfg.systemID {
+		d.state.SystemID = packet.SystemID
+		d.state.ComponentID = packet.ComponentID
+	}
+
+	if d.state.SystemID == 0 || packet.SystemID != d.state.SystemID {
+		return
+	}
+
+	switch m := message.(type) {
+	case *common.Heartbeat:
+		if d.state.ComponentID != common.MAV_COMP_ID_ALL && packet.ComponentID != d.state.ComponentID {
+			return
+		}
+		d.state.Type = m.TYPE
+		d.state.Autopilot = m.AUTOPILOT
+		d.state.BaseMode = m.BASE_MODE
+		d.state.CustomMode = m.CUSTOM_MODE
+		d.state.SystemStatus = m.SYSTEM_STATUS
+		d.state.Armed = m.BASE_MODE&common.MAV_MODE_FLAG_SAFETY_ARMED != 0
+		d.state.LastHeartbeat = d.cfg.clock.Now()
+	case *common.Attitude:
+		d.state.Attitude = VehicleAttitude{
+			Roll:       m.ROLL,
+			Pitch:      m.PITCH,
+			Yaw:        m.YAW,
+			RollSpeed:  m.ROLLSPEED,
+			PitchSpeed: m.PITCHSPEED,
+			YawSpeed:   m.YAWSPEED,
+		}
+	case *common.GlobalPositionInt:
+		d.state.Position = VehiclePosition{
+			Latitude:         float64(m.LAT) / 1e7,
+			Longitude:        float64(m.LON) / 1e7,
+			Altitude:         float32(m.ALT) / 1000,
+			RelativeAltitude: float32(m.RELATIVE_ALT) / 1000,
+			VX:               float32(m.VX) / 100,
+			VY:               float32(m.VY) / 100,
+			VZ:               float32(m.VZ) / 100,
+			Heading:          float32(m.HDG) / 100,
+		}
+	case *common.SysStatus:
+		current := float32(-1)
+		if m.CURRENT_BATTERY != -1 {
+			current = float32(m.CURRENT_BATTERY) / 100
+		}
+		d.state.Battery = VehicleBattery{
+			Voltage:   float32(m.VOLTAGE_BATTERY) / 1000,
+			Current:   current,
+			Remaining: m.BATTERY_REMAINING,
+		}
+	case *common.GpsRawInt:
+		d.state.GPS = VehicleGPS{
+			FixType:           m.FIX_TYPE,
+			SatellitesVisible: m.SATELLITES_VISIBLE,
+			Latitude:          float64(m.LAT) / 1e7,
+			Longitude:         float64(m.LON) / 1e7,
+			Altitude:          float32(m.ALT) / 1000,
+			HDOP:              float32(m.EPH) / 100,
+			VDOP:              float32(m.EPV) / 100,
+		}
+	case *common.CommandAck:
+		if ack, ok := d.pending[m.COMMAND]; ok {
+			select {
+			case ack <- m:
+			default:
+				// an acknowledge for this command is already waiting
+			}
+		}
+	}
+}
+
+// superviseLink sends the GCS heartbeat and checks the vehicle heartbeat until halted.
+func (d *VehicleDriver) superviseLink(halt chan struct{}) {
+	var heartbeat <-chan time.Time
+	if d.cfg.heartbeatInterval > 0 {
+		ticker := d.cfg.clock.NewTicker(d.cfg.heartbeatInterval)
+		defer ticker.Stop()
+		heartbeat = ticker.C()
+		d.sendHeartbeat()
+	}
+
+	check := d.cfg.clock.NewTicker(d.cfg.linkTimeout / 4)
+	defer check.Stop()
+
+	for {
+		select {
+		case <-heartbeat:
+			d.sendHeartbeat()
+		case <-check.C():
+			d.checkLink()
+		case <-halt:
+			return
+		}
+	}
+}
+
+func (d *VehicleDriver) sendHeartbeat() {
+	message := common.NewHeartbeat(0, common.MAV_TYPE_GCS, common.MAV_AUTOPILOT_INVALID, 0, common.MAV_STATE_ACTIVE, 3)
+	if err := d.SendMessage(d.cfg.systemID, d.cfg.componentID, message); err != nil {
+		d.Publish(ErrorIOEvent, err)
+	}
+}
+
+func (d *VehicleDriver) checkLink() {
+	d.mutex.Lock()
+	alive := !d.state.LastHeartbeat.IsZero() && d.cfg.clock.Since(d.state.LastHeartbeat) <= d.cfg.linkTimeout
+	changed := alive != d.state.Connected
+	d.state.Connected = alive
+	systemID := d.state.SystemID
+	d.mutex.Unlock()
+
+	if !changed {
+		return
+	}
+	if alive {
+		d.Publish(VehicleConnectedEvent, systemID)
+	} else {
+		d.Publish(VehicleLinkLostEvent, systemID)
+	}
+}
+
+func commandResultText(result uint8) string {
+	switch result {
+	case common.MAV_RESULT_ACCEPTED:
+		return "accepted"
+	case common.MAV_RESULT_TEMPORARILY_REJECTED:
+		return "temporarily rejected"
+	case common.MAV_RESULT_DENIED:
+		return "denied"
+	case common.MAV_RESULT_UNSUPPORTED:
+		return "unsupported"
+	case common.MAV_RESULT_FAILED:
+		return "failed"
+	case common.MAV_RESULT_CANCELLED:
+		return "cancelled"
+	default:
+		return fmt.Sprintf("result %d", result)
+	}
+}
@@ -0,0 +1,182 @@
+package mavlink
+
+import (
+	"time"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// vehicleOptionApplier needs to be implemented by each configurable option type of the vehicle driver
+type vehicleOptionApplier interface {
+	apply(cfg *vehicleConfiguration)
+}
+
+// vehicleConfiguration contains all changeable attributes of the vehicle driver.
+type vehicleConfiguration struct {
+	name              string
+	systemID          uint8
+	componentID       uint8
+	targetSystem      uint8
+	targetComponent   uint8
+	heartbeatInterval time.Duration
+	linkTimeout       time.Duration
+	commandTimeout    time.Duration
+	commandRetries    int
+	clock             gobot.Clock
+}
+
+// vehicleNameOption is the type for applying another name to the configuration
+type vehicleNameOption string
+
+// vehicleSystemIDOption is the type for applying another own system id to the configuration
+type vehicleSystemIDOption uint8
+
+// vehicleComponentIDOption is the type for applying another own component id to the configuration
+type vehicleComponentIDOption uint8
+
+// vehicleTargetOption is the type for applying a fixed vehicle system and component id to the configuration
+type vehicleTargetOption struct {
+	systemID    uint8
+	componentID uint8
+}
+
+// vehicleHeartbeatIntervalOption is the type for applying another GCS heartbeat interval to the configuration
+type vehicleHeartbeatIntervalOption time.Duration
+
+// vehicleLinkTimeoutOption is the type for applying another link timeout to the configuration
+type vehicleLinkTimeoutOption time.Duration
+
+// vehicleCommandTimeoutOption is the type for applying another command acknowledge timeout to the configuration
+type vehicleCommandTimeoutOption time.Duration
+
+// vehicleCommandRetriesOption is the type for applying another count of command retransmissions to the configuration
+type vehicleCommandRetriesOption int
+
+// vehicleClockOption is the type for applying another clock to the configuration
+type vehicleClockOption struct {
+	clock gobot.Clock
+}
+
+// WithVehicleName is used to replace the default name of the vehicle driver.
+func WithVehicleName(name string) vehicleOptionApplier {
+	return vehicleNameOption(name)
+}
+
+// WithVehicleSystemID is used to replace the default system id 255 of the ground control station.
+func WithVehicleSystemID(id uint8) vehicleOptionApplier {
+	return vehicleSystemIDOption(id)
+}
+
+// WithVehicleComponentID is used to replace the default component id 190 (MAV_COMP_ID_MISSIONPLANNER) of the ground
+// control station.
+func WithVehicleComponentID(id uint8) vehicleOptionApplier {
+	return vehicleComponentIDOption(id)
+}
+
+// WithVehicleTarget is used to talk only to the vehicle with the given system and component id. By default the first
+// vehicle which sends a heartbeat is used.
+func WithVehicleTarget(systemID, componentID uint8) vehicleOptionApplier {
+	return vehicleTargetOption{systemID: systemID, componentID: componentID}
+}
+
+// WithVehicleHeartbeatInterval change the interval of the GCS heartbeat from default 1s to the given value. A value of
+// zero disables the heartbeat.
+func WithVehicleHeartbeatInterval(interval time.Duration) vehicleOptionApplier {
+	return vehicleHeartbeatIntervalOption(interval)
+}
+
+// WithVehicleLinkTimeout change the duration without a vehicle heartbeat until the link is treated as lost from
+// default 3s to the given value.
+func WithVehicleLinkTimeout(timeout time.Duration) vehicleOptionApplier {
+	return vehicleLinkTimeoutOption(timeout)
+}
+
+// WithVehicleCommandTimeout change the time to wait for a COMMAND_ACK from default 1.5s to the given value.
+func WithVehicleCommandTimeout(timeout time.Duration) vehicleOptionApplier {
+	return vehicleCommandTimeoutOption(timeout)
+}
+
+// WithVehicleCommandRetries change the count of retransmissions of an unacknowledged command from default 3 to the
+// given value.
+func WithVehicleCommandRetries(retries int) vehicleOptionApplier {
+	return vehicleCommandRetriesOption(retries)
+}
+
+// WithVehicleClock is used to replace the real clock of the vehicle driver, e.g. by a fake clock for tests. The clock
+// is used for the GCS heartbeat, the link supervision and the command timeout.
+func WithVehicleClock(clock gobot.Clock) vehicleOptionApplier {
+	return vehicleClockOption{clock: clock}
+}
+
+func (o vehicleNameOption) String() string {
+	return "name option for MAVLink vehicle drivers"
+}
+
+func (o vehicleSystemIDOption) String() string {
+	return "system id option for MAVLink vehicle drivers"
+}
+
+func (o vehicleComponentIDOption) String() string {
+	return "component id option for MAVLink vehicle drivers"
+}
+
+func (o vehicleTargetOption) String() string {
+	return "target option for MAVLink vehicle drivers"
+}
+
+func (o vehicleHeartbeatIntervalOption) String() string {
+	return "heartbeat interval option for MAVLink vehicle drivers"
+}
+
+func (o vehicleLinkTimeoutOption) String() string {
+	return "link timeout option for MAVLink vehicle drivers"
+}
+
+func (o vehicleCommandTimeoutOption) String() string {
+	return "command timeout option for MAVLink vehicle drivers"
+}
+
+func (o vehicleCommandRetriesOption) String() string {
+	return "command retries option for MAVLink vehicle drivers"
+}
+
+func (o vehicleClockOption) String() string {
+	return "clock option for MAVLink vehicle drivers"
+}
+
+func (o vehicleNameOption) apply(cfg *vehicleConfiguration) {
+	cfg.name = string(o)
+}
+
+func (o vehicleSystemIDOption) apply(cfg *vehicleConfiguration) {
+	cfg.systemID = uint8(o)
+}
+
+func (o vehicleComponentIDOption) apply(cfg *vehicleConfiguration) {
+	cfg.componentID = uint8(o)
+}
+
+func (o vehicleTargetOption) apply(cfg *vehicleConfiguration) {
+	cfg.targetSystem = o.systemID
+	cfg.targetComponent = o.componentID
+}
+
+func (o vehicleHeartbeatIntervalOption) apply(cfg *vehicleConfiguration) {
+	cfg.heartbeatInterval = time.Duration(o)
+}
+
+func (o vehicleLinkTimeoutOption) apply(cfg *vehicleConfiguration) {
+	cfg.linkTimeout = time.Duration(o)
+}
+
+func (o vehicleCommandTimeoutOption) apply(cfg *vehicleConfiguration) {
+	cfg.commandTimeout = time.Duration(o)
+}
+
+func (o vehicleCommandRetriesOption) apply(cfg *vehicleConfiguration) {
+	cfg.commandRetries = int(o)
+}
+
+func (o vehicleClockOption) apply(cfg *vehicleConfiguration) {
+	cfg.clock = o.clock
+}
@@ -0,0 +1,59 @@
+package mavlink
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"gobot.io/x/gobot/v2"
+)
+
+func TestWithVehicleName(t *testing.T) {
+	// This is a general test, that options are applied by using the WithVehicleName() option.
+	// All other configuration options can also be tested by With..(val).apply(cfg).
+	// arrange & act
+	const newName = "new name"
+	d := NewVehicleDriver(newVehicleTestAdaptor(), WithVehicleName(newName))
+	// assert
+	assert.Equal(t, newName, d.Name())
+}
+
+func TestWithVehicleIDs(t *testing.T) {
+	// arrange
+	cfg := &vehicleConfiguration{systemID: 255, componentID: 190}
+	// act
+	WithVehicleSystemID(1).apply(cfg)
+	WithVehicleComponentID(2).apply(cfg)
+	WithVehicleTarget(3, 4).apply(cfg)
+	// assert
+	assert.Equal(t, uint8(1), cfg.systemID)
+	assert.Equal(t, uint8(2), cfg.componentID)
+	assert.Equal(t, uint8(3), cfg.targetSystem)
+	assert.Equal(t, uint8(4), cfg.targetComponent)
+}
+
+func TestWithVehicleTimings(t *testing.T) {
+	// arrange
+	cfg := &vehicleConfiguration{}
+	// act
+	WithVehicleHeartbeatInterval(2 * time.Second).apply(cfg)
+	WithVehicleLinkTimeout(5 * time.Second).apply(cfg)
+	WithVehicleCommandTimeout(time.Second).apply(cfg)
+	WithVehicleCommandRetries(5).apply(cfg)
+	// assert
+	assert.Equal(t, 2*time.Second, cfg.heartbeatInterval)
+	assert.Equal(t, 5*time.Second, cfg.linkTimeout)
+	assert.Equal(t, time.Second, cfg.commandTimeout)
+	assert.Equal(t, 5, cfg.commandRetries)
+}
+
+func TestWithVehicleClock(t *testing.T) {
+	// arrange
+	cfg := &vehicleConfiguration{clock: gobot.NewRealClock()}
+	clock := gobot.NewFakeClock(time.Now())
+	// act
+	WithVehicleClock(clock).apply(cfg)
+	// assert
+	assert.Same(t, clock, cfg.clock)
+}
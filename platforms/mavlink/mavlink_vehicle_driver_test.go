@@ -0,0 +1,437 @@
+//nolint:forcetypeassert // ok here
+package mavlink
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+	common "gobot.io/x/gobot/v2/platforms/mavlink/common"
+)
+
+var _ gobot.Driver = (*VehicleDriver)(nil)
+
+// vehicleTestAdaptor feeds the driver with packets of a simulated vehicle and records all written packets
+type vehicleTestAdaptor struct {
+	Adaptor
+	packets chan *common.MAVLinkPacket
+	written chan *common.MAVLinkPacket
+}
+
+func newVehicleTestAdaptor() *vehicleTestAdaptor {
+	return &vehicleTestAdaptor{
+		packets: make(chan *common.MAVLinkPacket, 100),
+		written: make(chan *common.MAVLinkPacket, 100),
+	}
+}
+
+func (a *vehicleTestAdaptor) ReadMAVLinkPacket() (*common.MAVLinkPacket, error) {
+	return <-a.packets, nil
+}
+
+func (a *vehicleTestAdaptor) Write(b []byte) (int, error) {
+	p, err := common.ReadMAVLinkPacket(bytes.NewReader(b))
+	if err != nil {
+		return 0, err
+	}
+	select {
+	case a.written <- p:
+	default:
+	}
+	return len(b), nil
+}
+
+func (a *vehicleTestAdaptor) ProtocolVersion() int { return 2 }
+
+func (a *vehicleTestAdaptor) sendFromVehicle(systemID uint8, message common.MAVLinkMessage) {
+	a.packets <- common.CraftMAVLink2Packet(systemID, 1, message)
+}
+
+// respond answers all commands of the driver with the given result, after the given count of ignored transmissions
+func (a *vehicleTestAdaptor) respond(result uint8, ignore int) <-chan *common.CommandLong {
+	commands := make(chan *common.CommandLong, 100)
+	go func() {
+		for p := range a.written {
+			m, err := p.MAVLinkMessage()
+			if err != nil {
+				continue
+			}
+			cmd, ok := m.(*common.CommandLong)
+			if !ok {
+				continue
+			}
+			commands <- cmd
+			if ignore > 0 {
+				ignore--
+				continue
+			}
+			a.sendFromVehicle(cmd.TARGET_SYSTEM, common.NewCommandAck(cmd.COMMAND, result))
+		}
+	}()
+	return commands
+}
+
+func initTestVehicleDriver(t *testing.T, opts ...vehicleOptionApplier) (*VehicleDriver, *vehicleTestAdaptor) {
+	t.Helper()
+	a := newVehicleTestAdaptor()
+	opts = append([]vehicleOptionApplier{
+		WithVehicleHeartbeatInterval(0),
+		WithVehicleLinkTimeout(200 * time.Millisecond),
+		WithVehicleCommandTimeout(50 * time.Millisecond),
+	}, opts...)
+	d := NewVehicleDriver(a, opts...)
+	require.NoError(t, d.Start())
+	t.Cleanup(func() { _ = d.Halt() })
+	return d, a
+}
+
+func connectTestVehicle(t *testing.T, d *VehicleDriver, a *vehicleTestAdaptor) {
+	t.Helper()
+	a.sendFromVehicle(1, common.NewHeartbeat(4, 2, 3, common.MAV_MODE_FLAG_SAFETY_ARMED, common.MAV_STATE_ACTIVE, 3))
+	require.Eventually(t, func() bool { return d.State().SystemID == 1 }, time.Second, time.Millisecond)
+}
+
+func TestNewVehicleDriver(t *testing.T) {
+	// arrange
+	a := newVehicleTestAdaptor()
+	// act
+	d := NewVehicleDriver(a)
+	// assert
+	assert.True(t, strings.HasPrefix(d.Name(), "MavlinkVehicle"))
+	assert.Same(t, a, d.Connection())
+	assert.Equal(t, uint8(255), d.cfg.systemID)
+	assert.Equal(t, uint8(190), d.cfg.componentID)
+	assert.Equal(t, time.Second, d.cfg.heartbeatInterval)
+	assert.Equal(t, 3*time.Second, d.cfg.linkTimeout)
+	assert.Equal(t, 1500*time.Millisecond, d.cfg.commandTimeout)
+	assert.Equal(t, 3, d.cfg.commandRetries)
+	assert.Equal(t, uint8(0), d.State().SystemID)
+	assert.Equal(t, VehicleConnectedEvent, d.Event(VehicleConnectedEvent))
+	assert.Equal(t, VehicleLinkLostEvent, d.Event(VehicleLinkLostEvent))
+	assert.Equal(t, PacketEvent, d.Event(PacketEvent))
+}
+
+func TestVehicleDriverStartError(t *testing.T) {
+	// arrange
+	d := NewVehicleDriver(newVehicleTestAdaptor(), WithVehicleLinkTimeout(0))
+	// act
+	err := d.Start()
+	// assert
+	require.EqualError(t, err, "the link timeout of the vehicle driver needs to be greater than zero")
+}
+
+func TestVehicleDriverHeartbeat(t *testing.T) {
+	// arrange & act
+	_, a := initTestVehicleDriver(t, WithVehicleHeartbeatInterval(10*time.Millisecond), WithVehicleSystemID(200))
+	// assert
+	for i := 0; i < 2; i++ {
+		select {
+		case p := <-a.written:
+			m, err := p.MAVLinkMessage()
+			require.NoError(t, err)
+			hb := m.(*common.Heartbeat)
+			assert.Equal(t, uint8(common.MAV_TYPE_GCS), hb.TYPE)
+			assert.Equal(t, uint8(common.MAV_AUTOPILOT_INVALID), hb.AUTOPILOT)
+			assert.Equal(t, uint8(200), p.SystemID)
+			assert.Equal(t, uint8(190), p.ComponentID)
+		case <-time.After(time.Second):
+			require.Fail(t, "heartbeat was not sent")
+		}
+	}
+}
+
+func TestVehicleDriverLinkSupervision(t *testing.T) {
+	// arrange
+	d, a := initTestVehicleDriver(t)
+	connected := make(chan interface{}, 10)
+	lost := make(chan interface{}, 10)
+	_ = d.On(VehicleConnectedEvent, func(data interface{}) { connected <- data })
+	_ = d.On(VehicleLinkLostEvent, func(data interface{}) { lost <- data })
+	// act
+	a.sendFromVehicle(5, common.NewHeartbeat(0, common.MAV_TYPE_GCS, common.MAV_AUTOPILOT_INVALID, 0, 0, 3))
+	connectTestVehicle(t, d, a)
+	// assert
+	select {
+	case id := <-connected:
+		assert.Equal(t, uint8(1), id)
+	case <-time.After(time.Second):
+		require.Fail(t, "connected event was not emitted")
+	}
+	state := d.State()
+	assert.True(t, state.Connected)
+	assert.True(t, state.Armed)
+	assert.Equal(t, uint8(1), state.ComponentID)
+	assert.Equal(t, uint8(2), state.Type)
+	assert.Equal(t, uint8(3), state.Autopilot)
+	assert.Equal(t, uint32(4), state.CustomMode)
+	assert.Equal(t, uint8(common.MAV_STATE_ACTIVE), state.SystemStatus)
+	assert.False(t, state.LastHeartbeat.IsZero())
+	select {
+	case id := <-lost:
+		assert.Equal(t, uint8(1), id)
+	case <-time.After(time.Second):
+		require.Fail(t, "link lost event was not emitted")
+	}
+	assert.False(t, d.State().Connected)
+}
+
+func TestVehicleDriverTelemetry(t *testing.T) {
+	// arrange
+	d, a := initTestVehicleDriver(t)
+	connectTestVehicle(t, d, a)
+	// act
+	a.sendFromVehicle(1, common.NewAttitude(1, 0.1, 0.2, 0.3, 0.4, 0.5, 0.6))
+	a.sendFromVehicle(1, common.NewGlobalPositionInt(1, 475000000, 85000000, 450500, 10250, 150, -250, 50, 9000))
+	a.sendFromVehicle(1, common.NewSysStatus(0, 0, 0, 0, 12600, 1550, 0, 0, 0, 0, 0, 0, 87))
+	a.sendFromVehicle(1, common.NewGpsRawInt(1, 475000001, 85000001, 450000, 120, 180, 0, 0, 3, 11))
+	a.sendFromVehicle(2, common.NewAttitude(1, 9, 9, 9, 9, 9, 9))
+	a.sendFromVehicle(1, common.NewHeartbeat(0, 2, 3, 0, common.MAV_STATE_STANDBY, 3))
+	// assert
+	require.Eventually(t, func() bool { return !d.State().Armed }, time.Second, time.Millisecond)
+	state := d.State()
+	assert.Equal(t, VehicleAttitude{Roll: 0.1, Pitch: 0.2, Yaw: 0.3, RollSpeed: 0.4, PitchSpeed: 0.5, YawSpeed: 0.6},
+		state.Attitude)
+	assert.InDelta(t, 47.5, state.Position.Latitude, 1e-9)
+	assert.InDelta(t, 8.5, state.Position.Longitude, 1e-9)
+	assert.InDelta(t, 450.5, state.Position.Altitude, 1e-3)
+	assert.InDelta(t, 10.25, state.Position.RelativeAltitude, 1e-3)
+	assert.InDelta(t, 1.5, state.Position.VX, 1e-3)
+	assert.InDelta(t, -2.5, state.Position.VY, 1e-3)
+	assert.InDelta(t, 0.5, state.Position.VZ, 1e-3)
+	assert.InDelta(t, 90, state.Position.Heading, 1e-3)
+	assert.Equal(t, VehicleBattery{Voltage: 12.6, Current: 15.5, Remaining: 87}, state.Battery)
+	assert.Equal(t, uint8(3), state.GPS.FixType)
+	assert.Equal(t, uint8(11), state.GPS.SatellitesVisible)
+	assert.InDelta(t, 47.5000001, state.GPS.Latitude, 1e-9)
+	assert.InDelta(t, 8.5000001, state.GPS.Longitude, 1e-9)
+	assert.InDelta(t, 450, state.GPS.Altitude, 1e-3)
+	assert.InDelta(t, 1.2, state.GPS.HDOP, 1e-3)
+	assert.InDelta(t, 1.8, state.GPS.VDOP, 1e-3)
+	assert.Equal(t, uint8(common.MAV_STATE_STANDBY), state.SystemStatus)
+}
+
+func TestVehicleDriverCommands(t *testing.T) {
+	tests := map[string]struct {
+		run        func(d *VehicleDriver) error
+		wantCmd    uint16
+		wantParams [7]float32
+	}{
+		"arm": {
+			run:        func(d *VehicleDriver) error { return d.Arm() },
+			wantCmd:    common.MAV_CMD_COMPONENT_ARM_DISARM,
+			wantParams: [7]float32{1},
+		},
+		"disarm": {
+			run:     func(d *VehicleDriver) error { return d.Disarm() },
+			wantCmd: common.MAV_CMD_COMPONENT_ARM_DISARM,
+		},
+		"set_mode": {
+			run:        func(d *VehicleDriver) error { return d.SetMode(4) },
+			wantCmd:    common.MAV_CMD_DO_SET_MODE,
+			wantParams: [7]float32{common.MAV_MODE_FLAG_CUSTOM_MODE_ENABLED, 4},
+		},
+		"takeoff": {
+			run:        func(d *VehicleDriver) error { return d.Takeoff(12.5) },
+			wantCmd:    common.MAV_CMD_NAV_TAKEOFF,
+			wantParams: [7]float32{6: 12.5},
+		},
+		"land": {
+			run:     func(d *VehicleDriver) error { return d.Land() },
+			wantCmd: common.MAV_CMD_NAV_LAND,
+		},
+		"return_to_launch": {
+			run:     func(d *VehicleDriver) error { return d.ReturnToLaunch() },
+			wantCmd: common.MAV_CMD_NAV_RETURN_TO_LAUNCH,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, a := initTestVehicleDriver(t)
+			connectTestVehicle(t, d, a)
+			commands := a.respond(common.MAV_RESULT_ACCEPTED, 0)
+			// act
+			err := tc.run(d)
+			// assert
+			require.NoError(t, err)
+			cmd := <-commands
+			assert.Equal(t, tc.wantCmd, cmd.COMMAND)
+			assert.Equal(t, tc.wantParams,
+				[7]float32{cmd.PARAM1, cmd.PARAM2, cmd.PARAM3, cmd.PARAM4, cmd.PARAM5, cmd.PARAM6, cmd.PARAM7})
+			assert.Equal(t, uint8(1), cmd.TARGET_SYSTEM)
+			assert.Equal(t, uint8(1), cmd.TARGET_COMPONENT)
+			assert.Equal(t, uint8(0), cmd.CONFIRMATION)
+		})
+	}
+}
+
+func TestVehicleDriverSendCommandRetries(t *testing.T) {
+	// arrange
+	d, a := initTestVehicleDriver(t, WithVehicleCommandRetries(2))
+	connectTestVehicle(t, d, a)
+	commands := a.respond(common.MAV_RESULT_ACCEPTED, 2)
+	// act
+	err := d.SendCommand(common.MAV_CMD_NAV_LAND)
+	// assert
+	require.NoError(t, err)
+	for want := uint8(0); want < 3; want++ {
+		assert.Equal(t, want, (<-commands).CONFIRMATION)
+	}
+}
+
+func TestVehicleDriverSendCommandNotAccepted(t *testing.T) {
+	// arrange
+	d, a := initTestVehicleDriver(t)
+	connectTestVehicle(t, d, a)
+	a.respond(common.MAV_RESULT_DENIED, 0)
+	// act
+	err := d.Arm()
+	// assert
+	var resultErr *CommandResultError
+	require.True(t, errors.As(err, &resultErr))
+	assert.Equal(t, uint16(common.MAV_CMD_COMPONENT_ARM_DISARM), resultErr.Command)
+	assert.Equal(t, uint8(common.MAV_RESULT_DENIED), resultErr.Result)
+	require.EqualError(t, err, "command 400 was not accepted: denied")
+}
+
+func TestVehicleDriverSendCommandErrors(t *testing.T) {
+	// arrange
+	notStarted := NewVehicleDriver(newVehicleTestAdaptor())
+	noVehicle, _ := initTestVehicleDriver(t)
+	noAck, a := initTestVehicleDriver(t, WithVehicleCommandRetries(1))
+	connectTestVehicle(t, noAck, a)
+	// act & assert
+	require.EqualError(t, notStarted.Land(), "vehicle driver is not started")
+	require.EqualError(t, noVehicle.Land(), "no vehicle available for command 21")
+	require.EqualError(t, noAck.SendCommand(common.MAV_CMD_NAV_LAND, 1, 2, 3, 4, 5, 6, 7, 8),
+		"command 21 accepts up to 7 parameters, got 8")
+	require.EqualError(t, noAck.Land(), "command 21 was not acknowledged by system 1 after 2 attempts")
+}
+
+func TestVehicleDriverSendCommandInProgress(t *testing.T) {
+	// arrange
+	d, a := initTestVehicleDriver(t, WithVehicleCommandTimeout(time.Second), WithVehicleCommandRetries(0))
+	connectTestVehicle(t, d, a)
+	done := make(chan error)
+	go func() { done <- d.Land() }()
+	require.Eventually(t, func() bool {
+		d.mutex.Lock()
+		defer d.mutex.Unlock()
+		return len(d.pending) == 1
+	}, time.Second, time.Millisecond)
+	// act
+	err := d.Land()
+	// assert
+	require.EqualError(t, err, "command 21 is already in progress")
+	require.NoError(t, d.Halt())
+	require.EqualError(t, <-done, "vehicle driver halted while waiting for command 21")
+}
+
+func TestVehicleDriverWithTarget(t *testing.T) {
+	// arrange
+	d, a := initTestVehicleDriver(t, WithVehicleTarget(7, 1))
+	// act
+	a.sendFromVehicle(1, common.NewHeartbeat(0, 2, 3, common.MAV_MODE_FLAG_SAFETY_ARMED, 0, 3))
+	a.sendFromVehicle(7, common.NewHeartbeat(0, 2, 3, 0, 0, 3))
+	// assert
+	require.Eventually(t, func() bool { return !d.State().LastHeartbeat.IsZero() }, time.Second, time.Millisecond)
+	state := d.State()
+	assert.Equal(t, uint8(7), state.SystemID)
+	assert.False(t, state.Armed)
+}
+
+// timerSignalingClock is a fake clock, which signals each created timer, e.g. to synchronize with the command timeout
+type timerSignalingClock struct {
+	*gobot.FakeClock
+	timers chan struct{}
+}
+
+func (c *timerSignalingClock) NewTimer(d time.Duration) gobot.Timer {
+	timer := c.FakeClock.NewTimer(d)
+	c.timers <- struct{}{}
+	return timer
+}
+
+func TestVehicleDriverSendCommandProgress(t *testing.T) {
+	// arrange
+	clock := &timerSignalingClock{FakeClock: gobot.NewFakeClock(time.Now()), timers: make(chan struct{}, 10)}
+	d, a := initTestVehicleDriver(t, WithVehicleClock(clock), WithVehicleCommandTimeout(50*time.Millisecond),
+		WithVehicleCommandRetries(1))
+	connectTestVehicle(t, d, a)
+	done := make(chan error)
+	// act
+	go func() { done <- d.Land() }()
+	<-clock.timers
+	clock.Advance(40 * time.Millisecond)
+	a.sendFromVehicle(1, common.NewCommandAck(common.MAV_CMD_NAV_LAND, common.MAV_RESULT_IN_PROGRESS))
+	<-clock.timers
+	clock.Advance(40 * time.Millisecond)
+	a.sendFromVehicle(1, common.NewCommandAck(common.MAV_CMD_NAV_LAND, common.MAV_RESULT_ACCEPTED))
+	// assert
+	require.NoError(t, <-done)
+	assert.Empty(t, clock.timers)
+	commands := 0
+	for len(a.written) > 0 {
+		m, err := (<-a.written).MAVLinkMessage()
+		require.NoError(t, err)
+		if _, ok := m.(*common.CommandLong); ok {
+			commands++
+		}
+	}
+	assert.Equal(t, 1, commands)
+}
+
+func TestVehicleDriverSendCommandProgressTimeout(t *testing.T) {
+	// arrange
+	clock := &timerSignalingClock{FakeClock: gobot.NewFakeClock(time.Now()), timers: make(chan struct{}, 10)}
+	d, a := initTestVehicleDriver(t, WithVehicleClock(clock), WithVehicleCommandTimeout(50*time.Millisecond),
+		WithVehicleCommandRetries(1))
+	connectTestVehicle(t, d, a)
+	done := make(chan error)
+	// act
+	go func() { done <- d.Land() }()
+	<-clock.timers
+	a.sendFromVehicle(1, common.NewCommandAck(common.MAV_CMD_NAV_LAND, common.MAV_RESULT_IN_PROGRESS))
+	<-clock.timers
+	clock.Advance(50 * time.Millisecond)
+	// assert
+	require.EqualError(t, <-done, "command 21 is in progress, but the final acknowledge is missing")
+	assert.Empty(t, clock.timers)
+}
+
+func TestVehicleDriverLinkSupervisionWithClock(t *testing.T) {
+	// arrange
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	clock := gobot.NewFakeClock(start)
+	d, a := initTestVehicleDriver(t, WithVehicleClock(clock), WithVehicleLinkTimeout(200*time.Millisecond))
+	connected := make(chan interface{}, 10)
+	lost := make(chan interface{}, 10)
+	_ = d.On(VehicleConnectedEvent, func(data interface{}) { connected <- data })
+	_ = d.On(VehicleLinkLostEvent, func(data interface{}) { lost <- data })
+	clock.BlockUntil(1)
+	connectTestVehicle(t, d, a)
+	// act & assert
+	clock.Advance(50 * time.Millisecond)
+	select {
+	case id := <-connected:
+		assert.Equal(t, uint8(1), id)
+	case <-time.After(time.Second):
+		require.Fail(t, "connected event was not emitted")
+	}
+	assert.Equal(t, start, d.State().LastHeartbeat)
+	assert.Empty(t, lost)
+	clock.Advance(300 * time.Millisecond)
+	select {
+	case id := <-lost:
+		assert.Equal(t, uint8(1), id)
+	case <-time.After(time.Second):
+		require.Fail(t, "link lost event was not emitted")
+	}
+	assert.False(t, d.State().Connected)
+}
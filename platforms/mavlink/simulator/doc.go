@@ -0,0 +1,10 @@
+/*
+Package simulator provides a simulated MAVLink vehicle, which speaks MAVLink over any io.ReadWriteCloser, e.g. a
+net.Pipe or a pty, or over UDP. It sends heartbeats and telemetry, executes arming, mode, takeoff, land and RTL
+commands and implements the mission and parameter protocol. It can be used to test robots with the MAVLink adaptors
+and drivers without SITL or a real autopilot.
+
+For further information refer to mavlink README:
+https://github.com/hybridgroup/gobot/blob/release/platforms/mavlink/README.md
+*/
+package simulator // import "gobot.io/x/gobot/v2/platforms/mavlink/simulator"
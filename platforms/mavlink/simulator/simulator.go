@@ -0,0 +1,533 @@
+package simulator
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"net"
+	"sync"
+	"time"
+
+	common "gobot.io/x/gobot/v2/platforms/mavlink/common"
+)
+
+// custom modes of the simulated autopilot, the same numbers as used by ArduCopter
+const (
+	ModeStabilize uint32 = 0
+	ModeAuto      uint32 = 3
+	ModeGuided    uint32 = 4
+	ModeRTL       uint32 = 6
+	ModeLand      uint32 = 9
+)
+
+// forceDisarmMagic is the value of the second parameter to disarm the vehicle in the air, like defined by
+// MAV_CMD_COMPONENT_ARM_DISARM
+const forceDisarmMagic = 21196
+
+// udpBufferSize is large enough for the biggest MAVLink 2 packet
+const udpBufferSize = 512
+
+// flightPhase describes the vertical state of the vehicle
+type flightPhase int
+
+const (
+	phaseLanded flightPhase = iota
+	phaseTakingOff
+	phaseFlying
+	phaseLanding
+)
+
+// param is an onboard parameter
+type param struct {
+	id        string
+	value     float32
+	paramType uint8
+}
+
+// configuration contains all changeable attributes of the simulator.
+type configuration struct {
+	systemID          uint8
+	componentID       uint8
+	vehicleType       uint8
+	homeLatitude      float64
+	homeLongitude     float64
+	homeAltitude      float32
+	heartbeatInterval time.Duration
+	telemetryInterval time.Duration
+	verticalSpeed     float32
+	params            []param
+	missionCapacity   int
+	protocolVersion   int
+	signer            *common.MAVLinkSigner
+}
+
+// Simulator is a simulated MAVLink vehicle. It sends heartbeats and telemetry, executes the basic flight commands with
+// plausible state transitions and implements the vehicle side of the mission and parameter protocol.
+type Simulator struct {
+	cfg        *configuration
+	mutex      sync.Mutex
+	writeMutex sync.Mutex // protects also the transmit function
+	transmit   func(b []byte) error
+	version    int
+	bootTime   time.Time
+	// vehicle state
+	armed          bool
+	customMode     uint32
+	phase          flightPhase
+	altitude       float32 // relative to home in meters
+	targetAltitude float32
+	battery        float32 // remaining energy in percent
+	// mission and parameter protocol
+	mission []*common.MissionItemInt
+	upload  []*common.MissionItemInt
+	params  []param
+}
+
+// NewSimulator creates a new simulated quadrotor with system id 1 at the given home position.
+//
+// Supported options:
+//
+//	"WithSystem"
+//	"WithVehicleType"
+//	"WithHome"
+//	"WithHeartbeatInterval"
+//	"WithTelemetryInterval"
+//	"WithVerticalSpeed"
+//	"WithParam"
+//	"WithMissionCapacity"
+//	"WithProtocolVersion"
+//	"WithSigning"
+func NewSimulator(opts ...optionApplier) *Simulator {
+	cfg := configuration{
+		systemID:          1,
+		componentID:       1,
+		vehicleType:       common.MAV_TYPE_QUADROTOR,
+		homeLatitude:      47.397742,
+		homeLongitude:     8.545594,
+		homeAltitude:      488,
+		heartbeatInterval: time.Second,
+		telemetryInterval: 200 * time.Millisecond,
+		verticalSpeed:     2.5,
+		params: []param{
+			{id: "FRAME_CLASS", value: 1, paramType: common.MAV_PARAM_TYPE_INT8},
+			{id: "RTL_ALT", value: 1500, paramType: common.MAV_PARAM_TYPE_INT32},
+			{id: "WPNAV_SPEED", value: 500, paramType: common.MAV_PARAM_TYPE_REAL32},
+			{id: "LAND_SPEED", value: 50, paramType: common.MAV_PARAM_TYPE_INT16},
+			{id: "BATT_CAPACITY", value: 5200, paramType: common.MAV_PARAM_TYPE_INT32},
+		},
+		missionCapacity: 700,
+		protocolVersion: 2,
+	}
+
+	for _, o := range opts {
+		o.apply(&cfg)
+	}
+
+	return &Simulator{
+		cfg:        &cfg,
+		version:    cfg.protocolVersion,
+		bootTime:   time.Now(),
+		customMode: ModeStabilize,
+		battery:    100,
+		params:     append([]param(nil), cfg.params...),
+	}
+}
+
+// WithSystem is used to replace the default system id 1 and component id 1 (MAV_COMP_ID_AUTOPILOT1).
+func WithSystem(systemID, componentID uint8) optionApplier {
+	return systemOption{systemID: systemID, componentID: componentID}
+}
+
+// WithVehicleType is used to replace the default vehicle type MAV_TYPE_QUADROTOR, which is reported by the heartbeat.
+func WithVehicleType(mavType uint8) optionApplier {
+	return vehicleTypeOption(mavType)
+}
+
+// WithHome is used to replace the default home position, latitude and longitude are given in degrees and the altitude
+// in meters above mean sea level.
+func WithHome(latitude, longitude float64, altitude float32) optionApplier {
+	return homeOption{latitude: latitude, longitude: longitude, altitude: altitude}
+}
+
+// WithHeartbeatInterval is used to replace the default heartbeat interval of 1s.
+func WithHeartbeatInterval(interval time.Duration) optionApplier {
+	return heartbeatIntervalOption(interval)
+}
+
+// WithTelemetryInterval is used to replace the default interval of 200ms for the telemetry and the simulation steps.
+func WithTelemetryInterval(interval time.Duration) optionApplier {
+	return telemetryIntervalOption(interval)
+}
+
+// WithVerticalSpeed is used to replace the default climb and descent rate of 2.5 m/s.
+func WithVerticalSpeed(metersPerSecond float32) optionApplier {
+	return verticalSpeedOption(metersPerSecond)
+}
+
+// WithParam is used to add an onboard parameter or to replace the value of a default parameter.
+func WithParam(id string, value float32, paramType uint8) optionApplier {
+	return paramOption{id: id, value: value, paramType: paramType}
+}
+
+// WithMissionCapacity is used to replace the default maximum of 700 mission items.
+func WithMissionCapacity(items int) optionApplier {
+	return missionCapacityOption(items)
+}
+
+// WithProtocolVersion is used to send MAVLink 1 packets until the first MAVLink 2 packet is received. By default
+// MAVLink 2 is used.
+func WithProtocolVersion(version int) optionApplier {
+	return protocolVersionOption(version)
+}
+
+// WithSigning activates the MAVLink 2 signing. All sent packets are signed, received packets without a valid
+// signature are dropped.
+func WithSigning(secretKey [32]byte, linkID uint8) optionApplier {
+	return signingOption{secretKey: secretKey, linkID: linkID}
+}
+
+// Serve speaks MAVLink over the given connection, e.g. a pipe to the serial adaptor, until the connection is closed by
+// the other side.
+func (s *Simulator) Serve(conn io.ReadWriteCloser) error {
+	s.setTransmit(func(b []byte) error {
+		_, err := conn.Write(b)
+		return err
+	})
+
+	done := make(chan struct{})
+	defer s.stop(done)
+	go s.run(done)
+
+	for {
+		packet, err := common.ReadMAVLinkPacket(conn)
+		if err != nil {
+			if isClosed(err) {
+				return nil
+			}
+			return err
+		}
+		s.receive(packet)
+	}
+}
+
+// ServeUDP speaks MAVLink over the given packet connection until it is closed. Heartbeats and telemetry are sent to
+// the given remote address, e.g. the port of the UDP adaptor, until a packet is received. Afterwards all packets are
+// sent to the sender of the last received packet. Without a remote address the simulator is silent until the first
+// packet is received.
+func (s *Simulator) ServeUDP(conn net.PacketConn, remote net.Addr) error {
+	var remoteMutex sync.Mutex
+	s.setTransmit(func(b []byte) error {
+		remoteMutex.Lock()
+		addr := remote
+		remoteMutex.Unlock()
+		if addr == nil {
+			return nil
+		}
+		_, err := conn.WriteTo(b, addr)
+		return err
+	})
+
+	done := make(chan struct{})
+	defer s.stop(done)
+	go s.run(done)
+
+	buf := make([]byte, udpBufferSize)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			if isClosed(err) {
+				return nil
+			}
+			return err
+		}
+		remoteMutex.Lock()
+		remote = addr
+		remoteMutex.Unlock()
+
+		r := bytes.NewReader(buf[:n])
+		for r.Len() > 0 {
+			packet, err := common.ReadMAVLinkPacket(r)
+			if err != nil {
+				break
+			}
+			s.receive(packet)
+		}
+	}
+}
+
+// Armed returns true, if the vehicle is armed.
+func (s *Simulator) Armed() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.armed
+}
+
+// Mode returns the current custom mode, e.g. ModeGuided.
+func (s *Simulator) Mode() uint32 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.customMode
+}
+
+// Airborne returns true, if the vehicle is not landed.
+func (s *Simulator) Airborne() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.phase != phaseLanded
+}
+
+// Altitude returns the altitude above home in meters.
+func (s *Simulator) Altitude() float32 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.altitude
+}
+
+// SetBattery sets the remaining battery energy in percent, e.g. to test a low battery failsafe.
+func (s *Simulator) SetBattery(remaining float32) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.battery = max(0, min(100, remaining))
+}
+
+func (s *Simulator) setTransmit(transmit func(b []byte) error) {
+	s.writeMutex.Lock()
+	defer s.writeMutex.Unlock()
+
+	s.transmit = transmit
+}
+
+func (s *Simulator) stop(done chan struct{}) {
+	close(done)
+	s.setTransmit(nil)
+}
+
+// run sends the heartbeat and the telemetry and advances the simulation until done
+func (s *Simulator) run(done chan struct{}) {
+	heartbeat := time.NewTicker(s.cfg.heartbeatInterval)
+	defer heartbeat.Stop()
+	telemetry := time.NewTicker(s.cfg.telemetryInterval)
+	defer telemetry.Stop()
+
+	s.sendHeartbeat()
+	last := time.Now()
+	for {
+		select {
+		case <-done:
+			return
+		case <-heartbeat.C:
+			s.sendHeartbeat()
+		case now := <-telemetry.C:
+			s.step(float32(now.Sub(last).Seconds()))
+			last = now
+			s.sendTelemetry()
+		}
+	}
+}
+
+// step advances the simulation by the given seconds
+func (s *Simulator) step(dt float32) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	switch s.phase {
+	case phaseTakingOff:
+		s.altitude = min(s.altitude+s.cfg.verticalSpeed*dt, s.targetAltitude)
+		if s.altitude >= s.targetAltitude {
+			s.phase = phaseFlying
+		}
+	case phaseLanding:
+		s.altitude = max(s.altitude-s.cfg.verticalSpeed*dt, 0)
+		if s.altitude <= 0 {
+			// like real autopilots the vehicle disarms after landing
+			s.phase = phaseLanded
+			s.armed = false
+		}
+	}
+
+	if s.armed {
+		// a full battery lasts 20 minutes
+		s.battery = max(s.battery-dt/12, 0)
+	}
+}
+
+func (s *Simulator) sendHeartbeat() {
+	s.mutex.Lock()
+	baseMode := uint8(common.MAV_MODE_FLAG_CUSTOM_MODE_ENABLED | common.MAV_MODE_FLAG_STABILIZE_ENABLED)
+	status := uint8(common.MAV_STATE_STANDBY)
+	if s.armed {
+		baseMode |= common.MAV_MODE_FLAG_SAFETY_ARMED
+		status = common.MAV_STATE_ACTIVE
+	}
+	message := common.NewHeartbeat(s.customMode, s.cfg.vehicleType, common.MAV_AUTOPILOT_ARDUPILOTMEGA, baseMode,
+		status, 3)
+	s.mutex.Unlock()
+
+	_ = s.send(message)
+}
+
+func (s *Simulator) sendTelemetry() {
+	s.mutex.Lock()
+	//nolint:gosec // the simulator does not run for 49 days
+	bootMs := uint32(time.Since(s.bootTime).Milliseconds())
+	lat := int32(math.Round(s.cfg.homeLatitude * 1e7))
+	lon := int32(math.Round(s.cfg.homeLongitude * 1e7))
+	altMSL := s.cfg.homeAltitude + s.altitude
+	var climb float32
+	switch s.phase {
+	case phaseTakingOff:
+		climb = s.cfg.verticalSpeed
+	case phaseLanding:
+		climb = -s.cfg.verticalSpeed
+	}
+	voltage := 12.6 - (100-s.battery)*0.03
+	current := float32(0)
+	if s.armed {
+		current = 15
+	}
+	messages := []common.MAVLinkMessage{
+		common.NewAttitude(bootMs, 0, 0, 0, 0, 0, 0),
+		common.NewGlobalPositionInt(bootMs, lat, lon, int32(altMSL*1000), int32(s.altitude*1000), 0, 0,
+			int16(-climb*100), 0),
+		common.NewSysStatus(0, 0, 0, 0, uint16(voltage*1000), int16(current*100), 0, 0, 0, 0, 0, 0,
+			int8(math.Ceil(float64(s.battery)))),
+		common.NewGpsRawInt(uint64(time.Since(s.bootTime).Microseconds()), lat, lon, int32(altMSL*1000), 80, 120, 0,
+			0, 3, 12),
+		common.NewVfrHud(0, 0, altMSL, climb, 0, 0),
+	}
+	s.mutex.Unlock()
+
+	for _, message := range messages {
+		if err := s.send(message); err != nil {
+			return
+		}
+	}
+}
+
+// receive verifies and dispatches a received packet
+func (s *Simulator) receive(packet *common.MAVLinkPacket) {
+	if s.cfg.signer != nil {
+		if err := s.cfg.signer.Verify(packet); err != nil {
+			return
+		}
+	}
+	message, err := packet.MAVLinkMessage()
+	if err != nil {
+		return
+	}
+
+	s.mutex.Lock()
+	if packet.Version() == 2 {
+		s.version = 2
+	}
+	s.mutex.Unlock()
+
+	switch m := message.(type) {
+	case *common.CommandLong:
+		if s.addressed(m.TARGET_SYSTEM, m.TARGET_COMPONENT) {
+			result := s.command(m)
+			_ = s.send(common.NewCommandAck(m.COMMAND, result))
+		}
+	case *common.SetMode:
+		if m.TARGET_SYSTEM == s.cfg.systemID && m.BASE_MODE&common.MAV_MODE_FLAG_CUSTOM_MODE_ENABLED != 0 {
+			s.mutex.Lock()
+			s.setMode(m.CUSTOM_MODE)
+			s.mutex.Unlock()
+		}
+	default:
+		s.handleMicroservices(packet, message)
+	}
+}
+
+// command executes the command and returns the MAV_RESULT
+func (s *Simulator) command(m *common.CommandLong) uint8 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	switch m.COMMAND {
+	case common.MAV_CMD_COMPONENT_ARM_DISARM:
+		switch {
+		case m.PARAM1 == 1:
+			s.armed = true
+		case s.phase == phaseLanded || m.PARAM2 == forceDisarmMagic:
+			s.armed = false
+			s.phase = phaseLanded
+			s.altitude = 0
+		default:
+			return common.MAV_RESULT_TEMPORARILY_REJECTED
+		}
+	case common.MAV_CMD_DO_SET_MODE:
+		if uint8(m.PARAM1)&common.MAV_MODE_FLAG_CUSTOM_MODE_ENABLED == 0 {
+			return common.MAV_RESULT_DENIED
+		}
+		s.setMode(uint32(m.PARAM2))
+	case common.MAV_CMD_NAV_TAKEOFF:
+		if !s.armed || s.customMode != ModeGuided || s.phase == phaseLanding {
+			return common.MAV_RESULT_TEMPORARILY_REJECTED
+		}
+		if m.PARAM7 <= 0 {
+			return common.MAV_RESULT_DENIED
+		}
+		s.targetAltitude = m.PARAM7
+		s.phase = phaseTakingOff
+	case common.MAV_CMD_NAV_LAND:
+		s.setMode(ModeLand)
+	case common.MAV_CMD_NAV_RETURN_TO_LAUNCH:
+		s.setMode(ModeRTL)
+	default:
+		return common.MAV_RESULT_UNSUPPORTED
+	}
+
+	return common.MAV_RESULT_ACCEPTED
+}
+
+// setMode changes the mode, the vehicle lands at home for the modes LAND and RTL
+func (s *Simulator) setMode(mode uint32) {
+	s.customMode = mode
+	if (mode == ModeLand || mode == ModeRTL) && s.phase != phaseLanded {
+		s.phase = phaseLanding
+	}
+}
+
+// addressed returns true, if a message with the given target is intended for this vehicle
+func (s *Simulator) addressed(targetSystem, targetComponent uint8) bool {
+	return (targetSystem == 0 || targetSystem == s.cfg.systemID) &&
+		(targetComponent == common.MAV_COMP_ID_ALL || targetComponent == s.cfg.componentID)
+}
+
+// send crafts a packet in the current protocol version and transmits it
+func (s *Simulator) send(message common.MAVLinkMessage) error {
+	s.mutex.Lock()
+	version := s.version
+	s.mutex.Unlock()
+
+	var packet *common.MAVLinkPacket
+	if version < 2 && s.cfg.signer == nil {
+		packet = common.CraftMAVLinkPacket(s.cfg.systemID, s.cfg.componentID, message)
+	} else {
+		packet = common.CraftMAVLink2Packet(s.cfg.systemID, s.cfg.componentID, message)
+		if s.cfg.signer != nil {
+			if err := s.cfg.signer.Sign(packet); err != nil {
+				return err
+			}
+		}
+	}
+
+	s.writeMutex.Lock()
+	defer s.writeMutex.Unlock()
+
+	if s.transmit == nil {
+		return net.ErrClosed
+	}
+	return s.transmit(packet.Pack())
+}
+
+func isClosed(err error) bool {
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrClosedPipe) || errors.Is(err, net.ErrClosed)
+}
@@ -0,0 +1,164 @@
+package simulator
+
+import (
+	"bytes"
+
+	common "gobot.io/x/gobot/v2/platforms/mavlink/common"
+)
+
+// Mission returns a copy of the current mission.
+func (s *Simulator) Mission() []common.MissionItemInt {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	items := make([]common.MissionItemInt, 0, len(s.mission))
+	for _, item := range s.mission {
+		items = append(items, *item)
+	}
+
+	return items
+}
+
+// Param returns the value and type of the onboard parameter.
+func (s *Simulator) Param(id string) (float32, uint8, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if i := s.paramIndex(id); i >= 0 {
+		return s.params[i].value, s.params[i].paramType, true
+	}
+
+	return 0, 0, false
+}
+
+// handleMicroservices answers the messages of the mission and parameter protocol, the ground control station is not
+// supervised with timeouts, because it retransmits unanswered messages
+func (s *Simulator) handleMicroservices(packet *common.MAVLinkPacket, message common.MAVLinkMessage) {
+	gcsSystem, gcsComponent := packet.SystemID, packet.ComponentID
+
+	var responses []common.MAVLinkMessage
+	s.mutex.Lock()
+	switch m := message.(type) {
+	case *common.MissionCount:
+		if s.addressed(m.TARGET_SYSTEM, m.TARGET_COMPONENT) {
+			responses = s.missionCount(m.COUNT, gcsSystem, gcsComponent)
+		}
+	case *common.MissionItemInt:
+		if s.addressed(m.TARGET_SYSTEM, m.TARGET_COMPONENT) {
+			responses = s.missionItem(m, gcsSystem, gcsComponent)
+		}
+	case *common.MissionRequestList:
+		if s.addressed(m.TARGET_SYSTEM, m.TARGET_COMPONENT) {
+			//nolint:gosec // limited by the mission capacity
+			responses = append(responses, common.NewMissionCount(uint16(len(s.mission)), gcsSystem, gcsComponent))
+		}
+	case *common.MissionRequestInt:
+		if s.addressed(m.TARGET_SYSTEM, m.TARGET_COMPONENT) {
+			if int(m.SEQ) >= len(s.mission) {
+				responses = append(responses,
+					common.NewMissionAck(gcsSystem, gcsComponent, common.MAV_MISSION_INVALID_SEQUENCE))
+				break
+			}
+			item := *s.mission[m.SEQ]
+			item.TARGET_SYSTEM, item.TARGET_COMPONENT = gcsSystem, gcsComponent
+			responses = append(responses, &item)
+		}
+	case *common.MissionClearAll:
+		if s.addressed(m.TARGET_SYSTEM, m.TARGET_COMPONENT) {
+			s.mission = nil
+			s.upload = nil
+			responses = append(responses, common.NewMissionAck(gcsSystem, gcsComponent, common.MAV_MISSION_ACCEPTED))
+		}
+	case *common.ParamRequestList:
+		if s.addressed(m.TARGET_SYSTEM, m.TARGET_COMPONENT) {
+			for i := range s.params {
+				responses = append(responses, s.paramValue(i))
+			}
+		}
+	case *common.ParamRequestRead:
+		if s.addressed(m.TARGET_SYSTEM, m.TARGET_COMPONENT) {
+			i := int(m.PARAM_INDEX)
+			if i < 0 {
+				i = s.paramIndex(paramIDToString(m.PARAM_ID))
+			}
+			if i >= 0 && i < len(s.params) {
+				responses = append(responses, s.paramValue(i))
+			}
+		}
+	case *common.ParamSet:
+		if s.addressed(m.TARGET_SYSTEM, m.TARGET_COMPONENT) {
+			// unknown parameters are ignored like by real autopilots, the type of a parameter can not be changed
+			if i := s.paramIndex(paramIDToString(m.PARAM_ID)); i >= 0 {
+				s.params[i].value = m.PARAM_VALUE
+				responses = append(responses, s.paramValue(i))
+			}
+		}
+	}
+	s.mutex.Unlock()
+
+	for _, response := range responses {
+		if err := s.send(response); err != nil {
+			return
+		}
+	}
+}
+
+// missionCount starts an upload, an empty mission is accepted immediately
+func (s *Simulator) missionCount(count uint16, gcsSystem, gcsComponent uint8) []common.MAVLinkMessage {
+	if int(count) > s.cfg.missionCapacity {
+		s.upload = nil
+		return []common.MAVLinkMessage{common.NewMissionAck(gcsSystem, gcsComponent, common.MAV_MISSION_NO_SPACE)}
+	}
+	if count == 0 {
+		s.mission = nil
+		s.upload = nil
+		return []common.MAVLinkMessage{common.NewMissionAck(gcsSystem, gcsComponent, common.MAV_MISSION_ACCEPTED)}
+	}
+
+	s.upload = make([]*common.MissionItemInt, 0, count)
+	return []common.MAVLinkMessage{common.NewMissionRequestInt(0, gcsSystem, gcsComponent)}
+}
+
+// missionItem stores the next item of an upload and requests the next one, a repeated item is answered again
+func (s *Simulator) missionItem(m *common.MissionItemInt, gcsSystem, gcsComponent uint8) []common.MAVLinkMessage {
+	if s.upload == nil {
+		// the upload is already finished and the acknowledge was lost
+		return []common.MAVLinkMessage{common.NewMissionAck(gcsSystem, gcsComponent, common.MAV_MISSION_ACCEPTED)}
+	}
+	if int(m.SEQ) == len(s.upload) {
+		item := *m
+		s.upload = append(s.upload, &item)
+	}
+	if len(s.upload) < cap(s.upload) {
+		//nolint:gosec // limited by the mission capacity
+		return []common.MAVLinkMessage{common.NewMissionRequestInt(uint16(len(s.upload)), gcsSystem, gcsComponent)}
+	}
+
+	s.mission = s.upload
+	s.upload = nil
+	return []common.MAVLinkMessage{common.NewMissionAck(gcsSystem, gcsComponent, common.MAV_MISSION_ACCEPTED)}
+}
+
+func (s *Simulator) paramValue(i int) *common.ParamValue {
+	var id [16]uint8
+	copy(id[:], s.params[i].id)
+	//nolint:gosec // the count of parameters is small
+	return common.NewParamValue(s.params[i].value, uint16(len(s.params)), uint16(i), id, s.params[i].paramType)
+}
+
+func (s *Simulator) paramIndex(id string) int {
+	for i, p := range s.params {
+		if p.id == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func paramIDToString(paramID [16]uint8) string {
+	if i := bytes.IndexByte(paramID[:], 0); i >= 0 {
+		return string(paramID[:i])
+	}
+	return string(paramID[:])
+}
@@ -0,0 +1,119 @@
+//nolint:forcetypeassert // ok here
+package simulator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	common "gobot.io/x/gobot/v2/platforms/mavlink/common"
+)
+
+func missionAck(m common.MAVLinkMessage) bool { return m.Id() == 47 }
+
+func TestSimulatorMissionUploadAndDownload(t *testing.T) {
+	// arrange
+	s, gcs := initTestSimulatorWithPipe(t)
+	// act & assert
+	gcs.send(t, common.NewMissionCount(2, 1, 1))
+	_, m := gcs.await(t, isMessage(51))
+	assert.Equal(t, uint16(0), m.(*common.MissionRequestInt).SEQ)
+	assert.Equal(t, uint8(255), m.(*common.MissionRequestInt).TARGET_SYSTEM)
+	assert.Equal(t, uint8(190), m.(*common.MissionRequestInt).TARGET_COMPONENT)
+	gcs.send(t, common.NewMissionItemInt(0, 0, 0, 0, 0, 0, 10, 0, common.MAV_CMD_NAV_TAKEOFF, 1, 1,
+		common.MAV_FRAME_GLOBAL_RELATIVE_ALT_INT, 1, 1))
+	_, m = gcs.await(t, isMessage(51))
+	assert.Equal(t, uint16(1), m.(*common.MissionRequestInt).SEQ)
+	// a retransmitted item is requested again
+	gcs.send(t, common.NewMissionItemInt(0, 0, 0, 0, 0, 0, 10, 0, common.MAV_CMD_NAV_TAKEOFF, 1, 1,
+		common.MAV_FRAME_GLOBAL_RELATIVE_ALT_INT, 1, 1))
+	_, m = gcs.await(t, isMessage(51))
+	assert.Equal(t, uint16(1), m.(*common.MissionRequestInt).SEQ)
+	gcs.send(t, common.NewMissionItemInt(0, 0, 0, 0, 475000000, 85000000, 20, 1, common.MAV_CMD_NAV_WAYPOINT, 1, 1,
+		common.MAV_FRAME_GLOBAL_RELATIVE_ALT_INT, 0, 1))
+	_, m = gcs.await(t, missionAck)
+	assert.Equal(t, uint8(common.MAV_MISSION_ACCEPTED), m.(*common.MissionAck).TYPE)
+	mission := s.Mission()
+	require.Len(t, mission, 2)
+	assert.Equal(t, uint16(common.MAV_CMD_NAV_WAYPOINT), mission[1].COMMAND)
+
+	gcs.send(t, common.NewMissionRequestList(1, 1))
+	_, m = gcs.await(t, isMessage(44))
+	assert.Equal(t, uint16(2), m.(*common.MissionCount).COUNT)
+	gcs.send(t, common.NewMissionRequestInt(1, 1, 1))
+	_, m = gcs.await(t, isMessage(73))
+	item := m.(*common.MissionItemInt)
+	assert.Equal(t, uint16(1), item.SEQ)
+	assert.Equal(t, int32(475000000), item.X)
+	assert.Equal(t, uint8(255), item.TARGET_SYSTEM)
+	gcs.send(t, common.NewMissionRequestInt(2, 1, 1))
+	_, m = gcs.await(t, missionAck)
+	assert.Equal(t, uint8(common.MAV_MISSION_INVALID_SEQUENCE), m.(*common.MissionAck).TYPE)
+}
+
+func TestSimulatorMissionClearAndEmpty(t *testing.T) {
+	// arrange
+	s, gcs := initTestSimulatorWithPipe(t)
+	s.mutex.Lock()
+	s.mission = []*common.MissionItemInt{common.NewMissionItemInt(0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 0, 0, 0)}
+	s.mutex.Unlock()
+	// act & assert
+	gcs.send(t, common.NewMissionClearAll(1, 1))
+	_, m := gcs.await(t, missionAck)
+	assert.Equal(t, uint8(common.MAV_MISSION_ACCEPTED), m.(*common.MissionAck).TYPE)
+	assert.Empty(t, s.Mission())
+	gcs.send(t, common.NewMissionCount(0, 1, 1))
+	_, m = gcs.await(t, missionAck)
+	assert.Equal(t, uint8(common.MAV_MISSION_ACCEPTED), m.(*common.MissionAck).TYPE)
+}
+
+func TestSimulatorMissionNoSpace(t *testing.T) {
+	// arrange
+	s, gcs := initTestSimulatorWithPipe(t, WithMissionCapacity(1))
+	// act
+	gcs.send(t, common.NewMissionCount(2, 1, 1))
+	// assert
+	_, m := gcs.await(t, missionAck)
+	assert.Equal(t, uint8(common.MAV_MISSION_NO_SPACE), m.(*common.MissionAck).TYPE)
+	assert.Empty(t, s.Mission())
+}
+
+func TestSimulatorParams(t *testing.T) {
+	// arrange
+	s, gcs := initTestSimulatorWithPipe(t, WithParam("MY_PARAM", 3, common.MAV_PARAM_TYPE_REAL32))
+	isParam := func(id string) func(common.MAVLinkMessage) bool {
+		return func(m common.MAVLinkMessage) bool {
+			v, ok := m.(*common.ParamValue)
+			return ok && paramIDToString(v.PARAM_ID) == id
+		}
+	}
+	// act & assert
+	gcs.send(t, common.NewParamRequestList(1, 1))
+	var ids []string
+	for len(ids) < 6 {
+		_, m := gcs.await(t, isMessage(22))
+		v := m.(*common.ParamValue)
+		assert.Equal(t, uint16(6), v.PARAM_COUNT)
+		assert.Equal(t, uint16(len(ids)), v.PARAM_INDEX) //nolint:gosec // test data
+		ids = append(ids, paramIDToString(v.PARAM_ID))
+	}
+	assert.Equal(t, []string{"FRAME_CLASS", "RTL_ALT", "WPNAV_SPEED", "LAND_SPEED", "BATT_CAPACITY", "MY_PARAM"}, ids)
+
+	gcs.send(t, common.NewParamRequestRead(1, 1, 1, [16]uint8{}))
+	gcs.await(t, isParam("RTL_ALT"))
+	gcs.send(t, common.NewParamRequestRead(-1, 1, 1, [16]uint8{'M', 'Y', '_', 'P', 'A', 'R', 'A', 'M'}))
+	_, m := gcs.await(t, isParam("MY_PARAM"))
+	assert.InDelta(t, 3, m.(*common.ParamValue).PARAM_VALUE, 0)
+
+	gcs.send(t, common.NewParamSet(42, 1, 1, [16]uint8{'M', 'Y', '_', 'P', 'A', 'R', 'A', 'M'},
+		common.MAV_PARAM_TYPE_INT32))
+	_, m = gcs.await(t, isParam("MY_PARAM"))
+	assert.InDelta(t, 42, m.(*common.ParamValue).PARAM_VALUE, 0)
+	assert.Equal(t, uint8(common.MAV_PARAM_TYPE_REAL32), m.(*common.ParamValue).PARAM_TYPE)
+	value, _, ok := s.Param("MY_PARAM")
+	assert.True(t, ok)
+	assert.InDelta(t, 42, value, 0)
+	_, _, ok = s.Param("UNKNOWN")
+	assert.False(t, ok)
+}
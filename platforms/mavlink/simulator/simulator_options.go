@@ -0,0 +1,145 @@
+package simulator
+
+import (
+	"time"
+
+	common "gobot.io/x/gobot/v2/platforms/mavlink/common"
+)
+
+// optionApplier needs to be implemented by each configurable option type
+type optionApplier interface {
+	apply(cfg *configuration)
+}
+
+// systemOption is the type for applying another system and component id
+type systemOption struct {
+	systemID    uint8
+	componentID uint8
+}
+
+// vehicleTypeOption is the type for applying another MAV_TYPE
+type vehicleTypeOption uint8
+
+// homeOption is the type for applying another home position
+type homeOption struct {
+	latitude  float64
+	longitude float64
+	altitude  float32
+}
+
+// heartbeatIntervalOption is the type for applying another heartbeat interval
+type heartbeatIntervalOption time.Duration
+
+// telemetryIntervalOption is the type for applying another telemetry interval
+type telemetryIntervalOption time.Duration
+
+// verticalSpeedOption is the type for applying another climb and descent rate
+type verticalSpeedOption float32
+
+// paramOption is the type for adding or replacing an onboard parameter
+type paramOption struct {
+	id        string
+	value     float32
+	paramType uint8
+}
+
+// missionCapacityOption is the type for applying another maximum count of mission items
+type missionCapacityOption int
+
+// protocolVersionOption is the type for applying another initial MAVLink version
+type protocolVersionOption int
+
+// signingOption is the type for activating the MAVLink 2 signing
+type signingOption struct {
+	secretKey [32]byte
+	linkID    uint8
+}
+
+func (o systemOption) String() string {
+	return "system option for MAVLink simulators"
+}
+
+func (o vehicleTypeOption) String() string {
+	return "vehicle type option for MAVLink simulators"
+}
+
+func (o homeOption) String() string {
+	return "home option for MAVLink simulators"
+}
+
+func (o heartbeatIntervalOption) String() string {
+	return "heartbeat interval option for MAVLink simulators"
+}
+
+func (o telemetryIntervalOption) String() string {
+	return "telemetry interval option for MAVLink simulators"
+}
+
+func (o verticalSpeedOption) String() string {
+	return "vertical speed option for MAVLink simulators"
+}
+
+func (o paramOption) String() string {
+	return "parameter option for MAVLink simulators"
+}
+
+func (o missionCapacityOption) String() string {
+	return "mission capacity option for MAVLink simulators"
+}
+
+func (o protocolVersionOption) String() string {
+	return "protocol version option for MAVLink simulators"
+}
+
+func (o signingOption) String() string {
+	return "signing option for MAVLink simulators"
+}
+
+func (o systemOption) apply(cfg *configuration) {
+	cfg.systemID = o.systemID
+	cfg.componentID = o.componentID
+}
+
+func (o vehicleTypeOption) apply(cfg *configuration) {
+	cfg.vehicleType = uint8(o)
+}
+
+func (o homeOption) apply(cfg *configuration) {
+	cfg.homeLatitude = o.latitude
+	cfg.homeLongitude = o.longitude
+	cfg.homeAltitude = o.altitude
+}
+
+func (o heartbeatIntervalOption) apply(cfg *configuration) {
+	cfg.heartbeatInterval = time.Duration(o)
+}
+
+func (o telemetryIntervalOption) apply(cfg *configuration) {
+	cfg.telemetryInterval = time.Duration(o)
+}
+
+func (o verticalSpeedOption) apply(cfg *configuration) {
+	cfg.verticalSpeed = float32(o)
+}
+
+func (o paramOption) apply(cfg *configuration) {
+	for i, p := range cfg.params {
+		if p.id == o.id {
+			cfg.params[i] = param(o)
+			return
+		}
+	}
+	cfg.params = append(cfg.params, param(o))
+}
+
+func (o missionCapacityOption) apply(cfg *configuration) {
+	cfg.missionCapacity = int(o)
+}
+
+func (o protocolVersionOption) apply(cfg *configuration) {
+	cfg.protocolVersion = int(o)
+}
+
+func (o signingOption) apply(cfg *configuration) {
+	cfg.signer = common.NewMAVLinkSigner(o.secretKey, o.linkID)
+}
@@ -0,0 +1,58 @@
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	common "gobot.io/x/gobot/v2/platforms/mavlink/common"
+)
+
+func TestWithSystem(t *testing.T) {
+	// This is a general test, that options are applied by using the WithSystem() option.
+	// All other configuration options can also be tested by With..(val).apply(cfg).
+	// arrange & act
+	s := NewSimulator(WithSystem(3, 4))
+	// assert
+	assert.Equal(t, uint8(3), s.cfg.systemID)
+	assert.Equal(t, uint8(4), s.cfg.componentID)
+}
+
+func TestWithOptions(t *testing.T) {
+	// arrange
+	cfg := &configuration{}
+	// act
+	WithVehicleType(common.MAV_TYPE_GROUND_ROVER).apply(cfg)
+	WithHome(1, 2, 3).apply(cfg)
+	WithHeartbeatInterval(2 * time.Second).apply(cfg)
+	WithTelemetryInterval(time.Second).apply(cfg)
+	WithVerticalSpeed(4).apply(cfg)
+	WithMissionCapacity(5).apply(cfg)
+	WithProtocolVersion(1).apply(cfg)
+	WithSigning([32]byte{1}, 2).apply(cfg)
+	// assert
+	assert.Equal(t, uint8(common.MAV_TYPE_GROUND_ROVER), cfg.vehicleType)
+	assert.InDelta(t, 1, cfg.homeLatitude, 0)
+	assert.InDelta(t, 2, cfg.homeLongitude, 0)
+	assert.InDelta(t, 3, cfg.homeAltitude, 0)
+	assert.Equal(t, 2*time.Second, cfg.heartbeatInterval)
+	assert.Equal(t, time.Second, cfg.telemetryInterval)
+	assert.InDelta(t, 4, cfg.verticalSpeed, 0)
+	assert.Equal(t, 5, cfg.missionCapacity)
+	assert.Equal(t, 1, cfg.protocolVersion)
+	assert.NotNil(t, cfg.signer)
+}
+
+func TestWithParam(t *testing.T) {
+	// arrange
+	cfg := &configuration{params: []param{{id: "A", value: 1, paramType: common.MAV_PARAM_TYPE_INT8}}}
+	// act
+	WithParam("A", 2, common.MAV_PARAM_TYPE_INT16).apply(cfg)
+	WithParam("B", 3, common.MAV_PARAM_TYPE_REAL32).apply(cfg)
+	// assert
+	assert.Equal(t, []param{
+		{id: "A", value: 2, paramType: common.MAV_PARAM_TYPE_INT16},
+		{id: "B", value: 3, paramType: common.MAV_PARAM_TYPE_REAL32},
+	}, cfg.params)
+}
@@ -0,0 +1,300 @@
+//nolint:forcetypeassert // ok here
+package simulator
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	common "gobot.io/x/gobot/v2/platforms/mavlink/common"
+)
+
+// testGCS is the ground control station side of a connection to the simulator
+type testGCS struct {
+	conn    net.Conn
+	packets chan *common.MAVLinkPacket
+	signer  *common.MAVLinkSigner
+}
+
+func initTestSimulatorWithPipe(t *testing.T, opts ...optionApplier) (*Simulator, *testGCS) {
+	t.Helper()
+	vehicle, host := net.Pipe()
+	opts = append([]optionApplier{
+		WithHeartbeatInterval(20 * time.Millisecond),
+		WithTelemetryInterval(10 * time.Millisecond),
+		WithVerticalSpeed(20),
+	}, opts...)
+	s := NewSimulator(opts...)
+	served := make(chan error, 1)
+	go func() { served <- s.Serve(vehicle) }()
+
+	gcs := &testGCS{conn: host, packets: make(chan *common.MAVLinkPacket, 1000)}
+	done := make(chan struct{})
+	go func() {
+		for {
+			p, err := common.ReadMAVLinkPacket(host)
+			if err != nil {
+				return
+			}
+			select {
+			case gcs.packets <- p:
+			case <-done:
+				return
+			}
+		}
+	}()
+	t.Cleanup(func() {
+		close(done)
+		_ = host.Close()
+		require.NoError(t, <-served)
+	})
+
+	return s, gcs
+}
+
+func (g *testGCS) send(t *testing.T, message common.MAVLinkMessage) {
+	t.Helper()
+	packet := common.CraftMAVLink2Packet(255, 190, message)
+	if g.signer != nil {
+		require.NoError(t, g.signer.Sign(packet))
+	}
+	require.NoError(t, g.conn.SetWriteDeadline(time.Now().Add(time.Second)))
+	_, err := g.conn.Write(packet.Pack())
+	require.NoError(t, err)
+}
+
+// await returns the first received packet with a message accepted by the given function
+func (g *testGCS) await(t *testing.T, accept func(common.MAVLinkMessage) bool) (*common.MAVLinkPacket, common.MAVLinkMessage) {
+	t.Helper()
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case p := <-g.packets:
+			m, err := p.MAVLinkMessage()
+			require.NoError(t, err)
+			if accept(m) {
+				return p, m
+			}
+		case <-timeout:
+			require.Fail(t, "expected message was not received")
+			return nil, nil
+		}
+	}
+}
+
+// command sends the command and returns the result of the acknowledge
+func (g *testGCS) command(t *testing.T, command uint16, params ...float32) uint8 {
+	t.Helper()
+	var p [7]float32
+	copy(p[:], params)
+	g.send(t, common.NewCommandLong(p[0], p[1], p[2], p[3], p[4], p[5], p[6], command, 1, 1, 0))
+	_, m := g.await(t, func(m common.MAVLinkMessage) bool {
+		ack, ok := m.(*common.CommandAck)
+		return ok && ack.COMMAND == command
+	})
+	return m.(*common.CommandAck).RESULT
+}
+
+func isMessage(id uint32) func(common.MAVLinkMessage) bool {
+	return func(m common.MAVLinkMessage) bool { return m.Id() == id }
+}
+
+func TestNewSimulator(t *testing.T) {
+	// arrange & act
+	s := NewSimulator()
+	// assert
+	assert.Equal(t, uint8(1), s.cfg.systemID)
+	assert.Equal(t, uint8(1), s.cfg.componentID)
+	assert.Equal(t, uint8(common.MAV_TYPE_QUADROTOR), s.cfg.vehicleType)
+	assert.Equal(t, time.Second, s.cfg.heartbeatInterval)
+	assert.Equal(t, 200*time.Millisecond, s.cfg.telemetryInterval)
+	assert.Equal(t, 2, s.version)
+	assert.False(t, s.Armed())
+	assert.False(t, s.Airborne())
+	assert.Equal(t, ModeStabilize, s.Mode())
+	assert.Empty(t, s.Mission())
+	value, paramType, ok := s.Param("RTL_ALT")
+	assert.True(t, ok)
+	assert.InDelta(t, 1500, value, 0)
+	assert.Equal(t, uint8(common.MAV_PARAM_TYPE_INT32), paramType)
+}
+
+func TestSimulatorHeartbeatAndTelemetry(t *testing.T) {
+	// arrange & act
+	_, gcs := initTestSimulatorWithPipe(t, WithHome(47.5, 8.5, 400))
+	// assert
+	p, m := gcs.await(t, isMessage(0))
+	assert.Equal(t, 2, p.Version())
+	assert.Equal(t, uint8(1), p.SystemID)
+	assert.Equal(t, uint8(1), p.ComponentID)
+	hb := m.(*common.Heartbeat)
+	assert.Equal(t, uint8(common.MAV_TYPE_QUADROTOR), hb.TYPE)
+	assert.Equal(t, uint8(common.MAV_AUTOPILOT_ARDUPILOTMEGA), hb.AUTOPILOT)
+	assert.Equal(t, uint8(common.MAV_STATE_STANDBY), hb.SYSTEM_STATUS)
+	assert.Zero(t, hb.BASE_MODE&common.MAV_MODE_FLAG_SAFETY_ARMED)
+	_, m = gcs.await(t, isMessage(33))
+	pos := m.(*common.GlobalPositionInt)
+	assert.Equal(t, int32(475000000), pos.LAT)
+	assert.Equal(t, int32(85000000), pos.LON)
+	assert.Equal(t, int32(400000), pos.ALT)
+	assert.Equal(t, int32(0), pos.RELATIVE_ALT)
+	_, m = gcs.await(t, isMessage(1))
+	assert.Equal(t, int8(100), m.(*common.SysStatus).BATTERY_REMAINING)
+	assert.Equal(t, uint16(12600), m.(*common.SysStatus).VOLTAGE_BATTERY)
+	_, m = gcs.await(t, isMessage(24))
+	assert.Equal(t, uint8(3), m.(*common.GpsRawInt).FIX_TYPE)
+	gcs.await(t, isMessage(30))
+	gcs.await(t, isMessage(74))
+}
+
+func TestSimulatorFlight(t *testing.T) {
+	// arrange
+	s, gcs := initTestSimulatorWithPipe(t)
+	// act & assert
+	assert.Equal(t, uint8(common.MAV_RESULT_ACCEPTED), gcs.command(t, common.MAV_CMD_COMPONENT_ARM_DISARM, 1))
+	assert.True(t, s.Armed())
+	_, m := gcs.await(t, isMessage(0))
+	assert.NotZero(t, m.(*common.Heartbeat).BASE_MODE&common.MAV_MODE_FLAG_SAFETY_ARMED)
+	assert.Equal(t, uint8(common.MAV_RESULT_TEMPORARILY_REJECTED),
+		gcs.command(t, common.MAV_CMD_NAV_TAKEOFF, 0, 0, 0, 0, 0, 0, 5))
+	assert.Equal(t, uint8(common.MAV_RESULT_DENIED), gcs.command(t, common.MAV_CMD_DO_SET_MODE, 0, float32(ModeGuided)))
+	assert.Equal(t, uint8(common.MAV_RESULT_ACCEPTED),
+		gcs.command(t, common.MAV_CMD_DO_SET_MODE, common.MAV_MODE_FLAG_CUSTOM_MODE_ENABLED, float32(ModeGuided)))
+	assert.Equal(t, ModeGuided, s.Mode())
+	assert.Equal(t, uint8(common.MAV_RESULT_DENIED), gcs.command(t, common.MAV_CMD_NAV_TAKEOFF))
+	assert.Equal(t, uint8(common.MAV_RESULT_ACCEPTED),
+		gcs.command(t, common.MAV_CMD_NAV_TAKEOFF, 0, 0, 0, 0, 0, 0, 5))
+	require.Eventually(t, func() bool { return s.Altitude() == 5 }, 2*time.Second, time.Millisecond)
+	assert.True(t, s.Airborne())
+	gcs.await(t, func(m common.MAVLinkMessage) bool {
+		pos, ok := m.(*common.GlobalPositionInt)
+		return ok && pos.RELATIVE_ALT == 5000
+	})
+	assert.Equal(t, uint8(common.MAV_RESULT_TEMPORARILY_REJECTED),
+		gcs.command(t, common.MAV_CMD_COMPONENT_ARM_DISARM, 0))
+	assert.Equal(t, uint8(common.MAV_RESULT_ACCEPTED), gcs.command(t, common.MAV_CMD_NAV_LAND))
+	assert.Equal(t, ModeLand, s.Mode())
+	require.Eventually(t, func() bool { return !s.Airborne() }, 2*time.Second, time.Millisecond)
+	assert.False(t, s.Armed())
+	assert.InDelta(t, 0, s.Altitude(), 0)
+}
+
+func TestSimulatorReturnToLaunch(t *testing.T) {
+	// arrange
+	s, gcs := initTestSimulatorWithPipe(t)
+	gcs.send(t, common.NewSetMode(ModeGuided, 1, common.MAV_MODE_FLAG_CUSTOM_MODE_ENABLED))
+	require.Eventually(t, func() bool { return s.Mode() == ModeGuided }, time.Second, time.Millisecond)
+	require.Equal(t, uint8(common.MAV_RESULT_ACCEPTED), gcs.command(t, common.MAV_CMD_COMPONENT_ARM_DISARM, 1))
+	require.Equal(t, uint8(common.MAV_RESULT_ACCEPTED), gcs.command(t, common.MAV_CMD_NAV_TAKEOFF, 0, 0, 0, 0, 0, 0, 2))
+	// act
+	result := gcs.command(t, common.MAV_CMD_NAV_RETURN_TO_LAUNCH)
+	// assert
+	assert.Equal(t, uint8(common.MAV_RESULT_ACCEPTED), result)
+	assert.Equal(t, ModeRTL, s.Mode())
+	require.Eventually(t, func() bool { return !s.Armed() }, 2*time.Second, time.Millisecond)
+	assert.False(t, s.Airborne())
+}
+
+func TestSimulatorForceDisarm(t *testing.T) {
+	// arrange
+	s, gcs := initTestSimulatorWithPipe(t, WithVerticalSpeed(0.1))
+	require.Equal(t, uint8(common.MAV_RESULT_ACCEPTED), gcs.command(t, common.MAV_CMD_COMPONENT_ARM_DISARM, 1))
+	require.Equal(t, uint8(common.MAV_RESULT_ACCEPTED),
+		gcs.command(t, common.MAV_CMD_DO_SET_MODE, common.MAV_MODE_FLAG_CUSTOM_MODE_ENABLED, float32(ModeGuided)))
+	require.Equal(t, uint8(common.MAV_RESULT_ACCEPTED), gcs.command(t, common.MAV_CMD_NAV_TAKEOFF, 0, 0, 0, 0, 0, 0, 9))
+	// act
+	result := gcs.command(t, common.MAV_CMD_COMPONENT_ARM_DISARM, 0, forceDisarmMagic)
+	// assert
+	assert.Equal(t, uint8(common.MAV_RESULT_ACCEPTED), result)
+	assert.False(t, s.Armed())
+	assert.False(t, s.Airborne())
+}
+
+func TestSimulatorCommandNotAddressed(t *testing.T) {
+	// arrange
+	s, gcs := initTestSimulatorWithPipe(t)
+	// act
+	gcs.send(t, common.NewCommandLong(1, 0, 0, 0, 0, 0, 0, common.MAV_CMD_COMPONENT_ARM_DISARM, 2, 1, 0))
+	result := gcs.command(t, 12345)
+	// assert
+	assert.Equal(t, uint8(common.MAV_RESULT_UNSUPPORTED), result)
+	assert.False(t, s.Armed())
+}
+
+func TestSimulatorBattery(t *testing.T) {
+	// arrange
+	s, gcs := initTestSimulatorWithPipe(t)
+	remaining := func(want int8) func(common.MAVLinkMessage) bool {
+		return func(m common.MAVLinkMessage) bool {
+			status, ok := m.(*common.SysStatus)
+			return ok && status.BATTERY_REMAINING == want
+		}
+	}
+	// act & assert
+	s.SetBattery(-10)
+	gcs.await(t, remaining(0))
+	s.SetBattery(42)
+	gcs.await(t, remaining(42))
+}
+
+func TestSimulatorProtocolVersion(t *testing.T) {
+	// arrange
+	_, gcs := initTestSimulatorWithPipe(t, WithProtocolVersion(1))
+	p, _ := gcs.await(t, isMessage(0))
+	assert.Equal(t, 1, p.Version())
+	// act
+	gcs.send(t, common.NewHeartbeat(0, common.MAV_TYPE_GCS, common.MAV_AUTOPILOT_INVALID, 0, 0, 3))
+	// assert
+	for i := 0; i < 10 && p.Version() == 1; i++ {
+		p, _ = gcs.await(t, isMessage(0))
+	}
+	assert.Equal(t, 2, p.Version())
+}
+
+func TestSimulatorSigning(t *testing.T) {
+	// arrange
+	key := [32]byte{1, 2, 3}
+	s, gcs := initTestSimulatorWithPipe(t, WithSigning(key, 1))
+	// act
+	gcs.send(t, common.NewCommandLong(1, 0, 0, 0, 0, 0, 0, common.MAV_CMD_COMPONENT_ARM_DISARM, 1, 1, 0))
+	time.Sleep(20 * time.Millisecond)
+	armedUnsigned := s.Armed()
+	gcs.signer = common.NewMAVLinkSigner(key, 2)
+	result := gcs.command(t, common.MAV_CMD_COMPONENT_ARM_DISARM, 1)
+	// assert
+	assert.False(t, armedUnsigned)
+	assert.Equal(t, uint8(common.MAV_RESULT_ACCEPTED), result)
+	p, _ := gcs.await(t, isMessage(0))
+	assert.True(t, p.SignatureValid(key))
+}
+
+func TestSimulatorServeUDP(t *testing.T) {
+	// arrange
+	vehicle, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	host, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer host.Close()
+	s := NewSimulator(WithHeartbeatInterval(10 * time.Millisecond))
+	served := make(chan error, 1)
+	// act
+	go func() { served <- s.ServeUDP(vehicle, host.LocalAddr()) }()
+	// assert
+	require.NoError(t, host.SetReadDeadline(time.Now().Add(2*time.Second)))
+	buf := make([]byte, udpBufferSize)
+	n, addr, err := host.ReadFrom(buf)
+	require.NoError(t, err)
+	hb, err := common.ReadMAVLinkPacket(bytes.NewReader(buf[:n]))
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0), hb.MessageID)
+	command := common.NewCommandLong(1, 0, 0, 0, 0, 0, 0, common.MAV_CMD_COMPONENT_ARM_DISARM, 1, 1, 0)
+	_, err = host.WriteTo(common.CraftMAVLink2Packet(255, 190, command).Pack(), addr)
+	require.NoError(t, err)
+	require.Eventually(t, s.Armed, 2*time.Second, time.Millisecond)
+	require.NoError(t, vehicle.Close())
+	require.NoError(t, <-served)
+}
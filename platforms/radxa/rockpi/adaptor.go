@@ -31,8 +31,10 @@ type Adaptor struct {
 	sys      *system.Accesser
 	revision string
 	*adaptors.DigitalPinsAdaptor
+	*adaptors.PWMPinsAdaptor
 	*adaptors.I2cBusAdaptor
 	*adaptors.SpiBusAdaptor
+	*adaptors.ClassDevicesAdaptor
 }
 
 // NewAdaptor creates a RockPi Adaptor
@@ -42,6 +44,7 @@ type Adaptor struct {
 // Optional parameters:
 //
 //	adaptors.WithGpioCdevAccess():	use character device driver instead of the default sysfs (NOT work on RockPi4C+!)
+//	adaptors.WithSpiCdevAccess():	use native /dev/spidev#.# access instead of periph.io
 //	adaptors.WithSpiGpioAccess(sclk, ncs, sdo, sdi):	use GPIO's instead of /dev/spidev#.#
 //	adaptors.WithGpiosActiveLow(pin's): invert the pin behavior
 func NewAdaptor(opts ...interface{}) *Adaptor {
@@ -71,7 +74,12 @@ func NewAdaptor(opts ...interface{}) *Adaptor {
 	// This could change in the future with other revisions!
 	spiBusNumberValidator := adaptors.NewBusNumberValidator([]int{1, 2})
 
+	a.ClassDevicesAdaptor = adaptors.NewClassDevicesAdaptor(sys)
+	digitalPinsOpts = append(digitalPinsOpts, adaptors.WithDigitalPinClassDevices(a.ClassDevicesAdaptor))
 	a.DigitalPinsAdaptor = adaptors.NewDigitalPinsAdaptor(sys, a.getPinTranslatorFunction(), digitalPinsOpts...)
+	// PWM is only supported for the LED's and fans of the board, e.g. "led:<name>" or "hwmon:pwmfan/pwm1"
+	a.PWMPinsAdaptor = adaptors.NewPWMPinsAdaptor(sys, adaptors.NewPWMPinTranslator(sys, nil).Translate,
+		adaptors.WithPWMClassDevices(a.ClassDevicesAdaptor))
 	a.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, i2cBusNumberValidator.Validate, defaultI2cBusNumber)
 	a.SpiBusAdaptor = adaptors.NewSpiBusAdaptor(sys, spiBusNumberValidator.Validate, defaultSpiBusNumber,
 		defaultSpiChipNumber, defaultSpiMode, defaultSpiBitsNumber, defaultSpiMaxSpeed, a.DigitalPinsAdaptor, spiBusOpts...)
@@ -108,6 +116,14 @@ func (a *Adaptor) Connect() error {
 		return err
 	}
 
+	if err := a.PWMPinsAdaptor.Connect(); err != nil {
+		return err
+	}
+
+	if err := a.ClassDevicesAdaptor.Connect(); err != nil {
+		return err
+	}
+
 	return a.DigitalPinsAdaptor.Connect()
 }
 
@@ -125,6 +141,15 @@ func (a *Adaptor) Finalize() error {
 	if e := a.SpiBusAdaptor.Finalize(); e != nil {
 		err = multierror.Append(err, e)
 	}
+
+	if e := a.PWMPinsAdaptor.Finalize(); e != nil {
+		err = multierror.Append(err, e)
+	}
+
+	if e := a.ClassDevicesAdaptor.Finalize(); e != nil {
+		err = multierror.Append(err, e)
+	}
+
 	return err
 }
 
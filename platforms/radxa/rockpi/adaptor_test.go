@@ -6,7 +6,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"gobot.io/x/gobot/v2/platforms/linuxboard"
 	"gobot.io/x/gobot/v2/system"
 )
 
@@ -87,3 +89,32 @@ func Test_getPinTranslatorFunction(t *testing.T) {
 		})
 	}
 }
+
+func TestPinMapsMatchLinuxBoardDefinition(t *testing.T) {
+	tests := map[string]struct {
+		board    string
+		revision string
+	}{
+		"rock_4":       {board: "rockpi4", revision: "4"},
+		"rock_4c_plus": {board: "rockpi4c-plus", revision: "4C+"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			def, err := linuxboard.BoardDefinition(tc.board)
+			require.NoError(t, err)
+			a := NewAdaptor()
+			a.revision = tc.revision
+			translate := a.getPinTranslatorFunction()
+			// act & assert
+			require.Len(t, def.DigitalPins, len(pins))
+			for id, pin := range def.DigitalPins {
+				_, line, err := translate(id)
+				require.NoError(t, err)
+				assert.Equal(t, line, pin.Sysfs, "pin '%s'", id)
+			}
+			assert.Equal(t, defaultI2cBusNumber, def.I2c.DefaultBus)
+			assert.Equal(t, defaultSpiBusNumber, def.Spi.DefaultBus)
+		})
+	}
+}
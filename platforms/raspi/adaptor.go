@@ -2,6 +2,8 @@ package raspi
 
 import (
 	"fmt"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -16,11 +18,24 @@ import (
 const (
 	infoFile = "/proc/cpuinfo"
 
+	// revisionRP1 is used for all boards with the RP1 I/O controller (Raspberry Pi 5, Pi 500, CM5)
+	revisionRP1 = "5"
+
+	// the RP1 gpiochip is found by its label, because the kernel enumerates it differently (e.g. "gpiochip4" before
+	// Kernel 6.6.45, "gpiochip0" later)
+	rp1GpioChipLabel = "pinctrl-rp1"
+	// the hardware PWM of RP1 with 4 channels: GPIO12 (0), GPIO13 (1), GPIO18 (2), GPIO19 (3)
+	rp1PwmDir       = "/sys/devices/platform/axi/1000120000.pcie/1f00098000.pwm/pwm/"
+	rp1PwmDirRegexp = "pwmchip[0-9]+$"
+
 	defaultSpiBusNumber  = 0
 	defaultSpiChipNumber = 0
 	defaultSpiMode       = 0
 	defaultSpiBitsNumber = 8
 	defaultSpiMaxSpeed   = 500000
+
+	defaultUartNumber   = 0
+	defaultUartBaudRate = 115200
 )
 
 // Adaptor is the Gobot Adaptor for the Raspberry Pi
@@ -29,11 +44,16 @@ type Adaptor struct {
 	mutex    sync.Mutex
 	sys      *system.Accesser
 	revision string
+	gpioChip string
+	// lineNames translates the line names of the gpiochips, e.g. "GPIO17", which are not a header pin
+	lineNames *adaptors.DigitalPinTranslator
 	*adaptors.AnalogPinsAdaptor
 	*adaptors.DigitalPinsAdaptor
 	*adaptors.PWMPinsAdaptor
 	*adaptors.I2cBusAdaptor
 	*adaptors.SpiBusAdaptor
+	*adaptors.UartBusAdaptor
+	*adaptors.ClassDevicesAdaptor
 }
 
 // NewAdaptor creates a Raspi Adaptor
@@ -41,6 +61,7 @@ type Adaptor struct {
 // Optional parameters:
 //
 //	adaptors.WithGpioSysfsAccess():	use legacy sysfs driver instead of default character device driver
+//	adaptors.WithSpiCdevAccess():	use native /dev/spidev#.# access instead of periph.io
 //	adaptors.WithSpiGpioAccess(sclk, ncs, sdo, sdi):	use GPIO's instead of /dev/spidev#.#
 //	adaptors.WithGpiosActiveLow(pin's): invert the pin behavior
 //	adaptors.WithGpiosPullUp/Down(pin's): sets the internal pull resistor
@@ -50,8 +71,9 @@ type Adaptor struct {
 func NewAdaptor(opts ...interface{}) *Adaptor {
 	sys := system.NewAccesser()
 	a := &Adaptor{
-		name: gobot.DefaultName("RaspberryPi"),
-		sys:  sys,
+		name:      gobot.DefaultName("RaspberryPi"),
+		sys:       sys,
+		lineNames: adaptors.NewDigitalPinTranslator(sys, nil),
 	}
 
 	var digitalPinsOpts []adaptors.DigitalPinsOptionApplier
@@ -71,18 +93,22 @@ func NewAdaptor(opts ...interface{}) *Adaptor {
 	}
 
 	analogPinTranslator := adaptors.NewAnalogPinTranslator(sys, analogPinDefinitions)
-	// Valid bus numbers are [0,1] which corresponds to /dev/i2c-0 through /dev/i2c-1.
-	i2cBusNumberValidator := adaptors.NewBusNumberValidator([]int{0, 1})
-	// Valid bus numbers are [0,1] which corresponds to /dev/spidev0.x through /dev/spidev1.x.
-	// x is the chip number <255
-	spiBusNumberValidator := adaptors.NewBusNumberValidator([]int{0, 1})
 
-	a.AnalogPinsAdaptor = adaptors.NewAnalogPinsAdaptor(sys, analogPinTranslator.Translate)
+	a.ClassDevicesAdaptor = adaptors.NewClassDevicesAdaptor(sys)
+	a.AnalogPinsAdaptor = adaptors.NewAnalogPinsAdaptor(sys, analogPinTranslator.Translate,
+		adaptors.WithAnalogClassDevices(a.ClassDevicesAdaptor))
+	digitalPinsOpts = append(digitalPinsOpts, adaptors.WithDigitalPinClassDevices(a.ClassDevicesAdaptor))
 	a.DigitalPinsAdaptor = adaptors.NewDigitalPinsAdaptor(sys, a.getPinTranslatorFunction(), digitalPinsOpts...)
+	pwmPinsOpts = append(pwmPinsOpts, adaptors.WithPWMDigitalPinnerProvider(a.DigitalPinsAdaptor),
+		adaptors.WithPWMClassDevices(a.ClassDevicesAdaptor))
 	a.PWMPinsAdaptor = adaptors.NewPWMPinsAdaptor(sys, a.getPinTranslatorFunction(), pwmPinsOpts...)
-	a.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, i2cBusNumberValidator.Validate, 1)
-	a.SpiBusAdaptor = adaptors.NewSpiBusAdaptor(sys, spiBusNumberValidator.Validate, defaultSpiBusNumber,
+	a.I2cBusAdaptor = adaptors.NewI2cBusAdaptor(sys, a.validateI2cBusNumber, 1)
+	a.SpiBusAdaptor = adaptors.NewSpiBusAdaptor(sys, a.validateSpiBusNumber, defaultSpiBusNumber,
 		defaultSpiChipNumber, defaultSpiMode, defaultSpiBitsNumber, defaultSpiMaxSpeed, a.DigitalPinsAdaptor, spiBusOpts...)
+	a.UartBusAdaptor = adaptors.NewUartBusAdaptor(sys, uartPaths, defaultUartNumber, defaultUartBaudRate)
+	// the lines of the bus pins are claimed too, so a pin of an active bus can not be used by its line name
+	a.SetI2cBusPinTranslator(a.getPinTranslatorFunction())
+	a.SetSpiBusPinTranslator(a.getPinTranslatorFunction())
 	return a
 }
 
@@ -107,10 +133,22 @@ func (a *Adaptor) Connect() error {
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
 
+	// the header pins of the buses depend on the revision
+	for bus, gpios := range i2cBusGpios {
+		a.SetI2cBusPins(bus, a.headerPins(gpios)...)
+	}
+	for bus, gpios := range spiBusGpios {
+		a.SetSpiBusPins(bus, a.headerPins(gpios)...)
+	}
+
 	if err := a.SpiBusAdaptor.Connect(); err != nil {
 		return err
 	}
 
+	if err := a.UartBusAdaptor.Connect(); err != nil {
+		return err
+	}
+
 	if err := a.I2cBusAdaptor.Connect(); err != nil {
 		return err
 	}
@@ -123,6 +161,10 @@ func (a *Adaptor) Connect() error {
 		return err
 	}
 
+	if err := a.ClassDevicesAdaptor.Connect(); err != nil {
+		return err
+	}
+
 	return a.DigitalPinsAdaptor.Connect()
 }
 
@@ -131,9 +173,14 @@ func (a *Adaptor) Finalize() error {
 	a.mutex.Lock()
 	defer a.mutex.Unlock()
 
-	err := a.DigitalPinsAdaptor.Finalize()
+	// the software PWM pins are based on digital pins, so they need to be finalized before
+	err := a.PWMPinsAdaptor.Finalize()
 
-	if e := a.PWMPinsAdaptor.Finalize(); e != nil {
+	if e := a.DigitalPinsAdaptor.Finalize(); e != nil {
+		err = multierror.Append(err, e)
+	}
+
+	if e := a.ClassDevicesAdaptor.Finalize(); e != nil {
 		err = multierror.Append(err, e)
 	}
 
@@ -148,6 +195,10 @@ func (a *Adaptor) Finalize() error {
 	if e := a.SpiBusAdaptor.Finalize(); e != nil {
 		err = multierror.Append(err, e)
 	}
+
+	if e := a.UartBusAdaptor.Finalize(); e != nil {
+		err = multierror.Append(err, e)
+	}
 	return err
 }
 
@@ -155,35 +206,131 @@ func (a *Adaptor) Finalize() error {
 // This overrides the base function due to the revision dependency.
 func (a *Adaptor) DefaultI2cBus() int {
 	rev := a.readRevision()
-	if rev == "2" || rev == "3" {
+	if rev == "2" || rev == "3" || rev == revisionRP1 {
 		return 1
 	}
 	return 0
 }
 
+// validateI2cBusNumber checks the bus number for the revision. Valid bus numbers are [0,1] which corresponds to
+// /dev/i2c-0 through /dev/i2c-1. With RP1 the buses [2,3] can be activated additionally, e.g. by "dtoverlay=i2c2-pi5".
+func (a *Adaptor) validateI2cBusNumber(busNr int) error {
+	if a.readRevision() == revisionRP1 {
+		return adaptors.NewBusNumberValidator([]int{0, 1, 2, 3}).Validate(busNr)
+	}
+
+	return adaptors.NewBusNumberValidator([]int{0, 1}).Validate(busNr)
+}
+
+// validateSpiBusNumber checks the bus number for the revision. Valid bus numbers are [0,1] which corresponds to
+// /dev/spidev0.x through /dev/spidev1.x, x is the chip number <255. With RP1 the buses [0..5] can be activated, e.g. by
+// "dtoverlay=spi2-2cs-pi5".
+func (a *Adaptor) validateSpiBusNumber(busNr int) error {
+	if a.readRevision() == revisionRP1 {
+		return adaptors.NewBusNumberValidator([]int{0, 1, 2, 3, 4, 5}).Validate(busNr)
+	}
+
+	return adaptors.NewBusNumberValidator([]int{0, 1}).Validate(busNr)
+}
+
 // getPinTranslatorFunction returns a function to be able to translate GPIO and PWM pins.
 // This means for pi-blaster usage, each pin can be used and therefore the pin is given as number, like a GPIO pin.
 // For sysfs-PWM usage, the pin will be given as "pwm0" or "pwm1", because the real pin number depends on the user
-// configuration in "/boot/config.txt". For further details, see "/boot/overlays/README".
+// configuration in "/boot/config.txt". For further details, see "/boot/overlays/README". With RP1 "pwm0" to "pwm3" are
+// the channels of the RP1 pwmchip. For the character device driver, an id which is not a header pin is looked up in
+// the line names of all gpiochips, e.g. "GPIO17" or "ID_SDA".
 func (a *Adaptor) getPinTranslatorFunction() func(string) (string, int, error) {
 	return func(pin string) (string, int, error) {
-		var line int
-		if val, ok := pins[pin][a.readRevision()]; ok {
-			line = val
-		} else if val, ok := pins[pin]["*"]; ok {
-			line = val
-		} else {
-			return "", 0, fmt.Errorf("'%s' is not a valid pin id for raspi revision %s", pin, a.revision)
+		rev := a.readRevision()
+		line, ok := headerPinLine(pin, rev)
+		if !ok {
+			if !a.sys.HasDigitalPinSysfsAccess() {
+				if chip, line, err := a.lineNames.TranslateLineName(pin); err == nil {
+					return chip, line, nil
+				}
+			}
+			return "", 0, fmt.Errorf("'%s' is not a valid pin id for raspi revision %s", pin, rev)
 		}
-		// We always use "gpiochip0", because currently all pins are available with this approach. A change of the
-		// translator would be needed to support different chips (e.g. gpiochip1) with different revisions.
-		path := "gpiochip0"
+
 		if strings.HasPrefix(pin, "pwm") {
-			path = "/sys/class/pwm/pwmchip0"
+			if rev == revisionRP1 {
+				path, err := adaptors.PWMPinDefinition{Dir: rp1PwmDir, DirRegexp: rp1PwmDirRegexp}.FindPWMDir(a.sys)
+				if err != nil {
+					return "", -1, err
+				}
+				return path, line, nil
+			}
+			return "/sys/class/pwm/pwmchip0", line, nil
 		}
 
-		return path, line, nil
+		chip, err := a.getGpioChip()
+		if err != nil {
+			return "", -1, err
+		}
+		return chip, line, nil
+	}
+}
+
+// headerPins returns all header pins of the current revision, which are connected to one of the given GPIO's, sorted
+// by the pin number
+func (a *Adaptor) headerPins(gpios []int) []string {
+	rev := a.readRevision()
+	var headerPins []string
+	for pin := range pins {
+		if strings.HasPrefix(pin, "pwm") {
+			continue
+		}
+		if line, ok := headerPinLine(pin, rev); ok && slices.Contains(gpios, line) {
+			headerPins = append(headerPins, pin)
+		}
+	}
+	sort.Slice(headerPins, func(i, j int) bool {
+		ni, _ := strconv.Atoi(headerPins[i])
+		nj, _ := strconv.Atoi(headerPins[j])
+		return ni < nj
+	})
+
+	return headerPins
+}
+
+// headerPinLine returns the line of the given pin for the given revision, the header of RP1 boards is the same as of
+// revision 3
+func headerPinLine(pin string, rev string) (int, bool) {
+	if val, ok := pins[pin][rev]; ok {
+		return val, true
+	}
+	if val, ok := pins[pin]["3"]; ok && rev == revisionRP1 {
+		return val, true
+	}
+	val, ok := pins[pin]["*"]
+	return val, ok
+}
+
+// getGpioChip returns the gpiochip of the header pins. Before RP1, all pins are available with "gpiochip0". The RP1
+// chip is detected by the label of the character device, an error is returned if no chip with this label exists.
+func (a *Adaptor) getGpioChip() (string, error) {
+	if a.gpioChip != "" {
+		return a.gpioChip, nil
+	}
+
+	if a.readRevision() != revisionRP1 {
+		a.gpioChip = "gpiochip0"
+		return a.gpioChip, nil
 	}
+
+	chips, err := a.sys.GpioChips()
+	if err != nil {
+		return "", fmt.Errorf("the gpiochip of RP1 can not be detected: %w", err)
+	}
+
+	for _, chip := range chips {
+		if chip.Label == rp1GpioChipLabel {
+			a.gpioChip = chip.Name
+			return a.gpioChip, nil
+		}
+	}
+
+	return "", fmt.Errorf("no gpiochip with label '%s' found for RP1", rp1GpioChipLabel)
 }
 
 func (a *Adaptor) readRevision() string {
@@ -198,6 +345,8 @@ func (a *Adaptor) readRevision() string {
 				s := strings.Split(v, " ")
 				version, _ := strconv.ParseInt("0x"+s[len(s)-1], 0, 64)
 				switch {
+				case isRP1Revision(version):
+					a.revision = revisionRP1
 				case version <= 3:
 					a.revision = "1"
 				case version <= 15:
@@ -211,3 +360,19 @@ func (a *Adaptor) readRevision() string {
 
 	return a.revision
 }
+
+// isRP1Revision returns true for the new-style revision codes of boards with RP1 (type 0x17: Pi 5, 0x18: CM5,
+// 0x19: Pi 500, 0x1a: CM5 Lite). See https://www.raspberrypi.com/documentation/computers/raspberry-pi.html
+func isRP1Revision(version int64) bool {
+	const newStyleFlag = 1 << 23
+	if version&newStyleFlag == 0 {
+		return false
+	}
+
+	switch (version >> 4) & 0xFF {
+	case 0x17, 0x18, 0x19, 0x1a:
+		return true
+	default:
+		return false
+	}
+}
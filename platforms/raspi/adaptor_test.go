@@ -15,6 +15,7 @@ import (
 	"gobot.io/x/gobot/v2/drivers/aio"
 	"gobot.io/x/gobot/v2/drivers/gpio"
 	"gobot.io/x/gobot/v2/drivers/i2c"
+	"gobot.io/x/gobot/v2/drivers/serial/megapi"
 	"gobot.io/x/gobot/v2/drivers/spi"
 	"gobot.io/x/gobot/v2/platforms/adaptors"
 	"gobot.io/x/gobot/v2/system"
@@ -49,6 +50,8 @@ var (
 	_ gobot.PWMPinnerProvider     = (*Adaptor)(nil)
 	_ gpio.DigitalReader          = (*Adaptor)(nil)
 	_ gpio.DigitalWriter          = (*Adaptor)(nil)
+	_ gpio.GpioInfoProvider       = (*Adaptor)(nil)
+	_ gpio.GpioInfoWatcher        = (*Adaptor)(nil)
 	_ gpio.PwmWriter              = (*Adaptor)(nil)
 	_ gpio.ServoWriter            = (*Adaptor)(nil)
 	_ aio.AnalogReader            = (*Adaptor)(nil)
@@ -124,6 +127,26 @@ func TestGetDefaultBus(t *testing.T) {
 			wantRev:      "3",
 			wantBus:      1,
 		},
+		"rev_3_new_style_pi4": {
+			revisionPart: "Revision        : c03111\n",
+			wantRev:      "3",
+			wantBus:      1,
+		},
+		"pi5": {
+			revisionPart: "Revision        : c04170\n",
+			wantRev:      "5",
+			wantBus:      1,
+		},
+		"cm5": {
+			revisionPart: "Revision        : d04180\n",
+			wantRev:      "5",
+			wantBus:      1,
+		},
+		"pi500": {
+			revisionPart: "Revision        : d04190\n",
+			wantRev:      "5",
+			wantBus:      1,
+		},
 	}
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -411,12 +434,45 @@ func Test_getPinTranslatorFunction(t *testing.T) {
 			wantPath: "/sys/class/pwm/pwmchip0",
 			wantLine: 1,
 		},
+		"translate_29_rev5": {
+			id:       "29",
+			revision: "5",
+			wantPath: "gpiochip0",
+			wantLine: 5,
+		},
+		"translate_line_name": {
+			id:       "GPIO27",
+			wantPath: "gpiochip0",
+			wantLine: 27,
+		},
+		"translate_unknown_line_name": {
+			id:      "GPIO99",
+			wantErr: "'GPIO99' is not a valid pin id for raspi revision 0",
+		},
+		"translate_pwm3_rev3": {
+			id:       "pwm3",
+			revision: "3",
+			wantErr:  "'pwm3' is not a valid pin id for raspi revision 3",
+		},
+		"translate_pwm3_rev5_without_pwmchip": {
+			id:       "pwm3",
+			revision: "5",
+			wantErr: "No path found for PWM directory pattern, 'pwmchip[0-9]+$' in path " +
+				"'/sys/devices/platform/axi/1000120000.pcie/1f00098000.pwm/pwm/'. See README.md for activation",
+			wantLine: -1,
+		},
 	}
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			// arrange
 			a := NewAdaptor()
 			a.revision = tc.revision
+			gia := a.sys.UseMockGpioInfo()
+			gia.Chips = []gobot.GpioChipInfo{{
+				Name:  "gpiochip0",
+				Label: "pinctrl-rp1",
+				Lines: []gobot.GpioLineInfo{{Chip: "gpiochip0", Offset: 27, Name: "GPIO27"}},
+			}}
 			// act
 			f := a.getPinTranslatorFunction()
 			path, line, err := f(tc.id)
@@ -431,3 +487,271 @@ func Test_getPinTranslatorFunction(t *testing.T) {
 		})
 	}
 }
+
+func Test_getPinTranslatorFunctionRP1(t *testing.T) {
+	const rp1PwmChipDir = "/sys/devices/platform/axi/1000120000.pcie/1f00098000.pwm/pwm/pwmchip2"
+	tests := map[string]struct {
+		id       string
+		wantPath string
+		wantLine int
+	}{
+		"translate_7": {
+			id:       "7",
+			wantPath: "gpiochip4",
+			wantLine: 4,
+		},
+		"translate_pwm0": {
+			id:       "pwm0",
+			wantPath: rp1PwmChipDir,
+			wantLine: 0,
+		},
+		"translate_pwm1": {
+			id:       "pwm1",
+			wantPath: rp1PwmChipDir,
+			wantLine: 1,
+		},
+		"translate_pwm2": {
+			id:       "pwm2",
+			wantPath: rp1PwmChipDir,
+			wantLine: 2,
+		},
+		"translate_pwm3": {
+			id:       "pwm3",
+			wantPath: rp1PwmChipDir,
+			wantLine: 3,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := NewAdaptor()
+			_ = a.sys.UseMockFilesystem([]string{rp1PwmChipDir + "/export"})
+			gia := a.sys.UseMockGpioInfo()
+			gia.Chips = []gobot.GpioChipInfo{
+				{Name: "gpiochip0", Label: "gpio-brcmstb@107d508500"},
+				{Name: "gpiochip4", Label: "pinctrl-rp1"},
+			}
+			a.revision = "5"
+			// act
+			path, line, err := a.getPinTranslatorFunction()(tc.id)
+			// assert
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantPath, path)
+			assert.Equal(t, tc.wantLine, line)
+		})
+	}
+}
+
+func TestGetGpioChipRP1(t *testing.T) {
+	tests := map[string]struct {
+		chips      []gobot.GpioChipInfo
+		chipsError bool
+		want       string
+		wantErr    string
+	}{
+		"rp1_is_gpiochip0": {
+			chips: []gobot.GpioChipInfo{
+				{Name: "gpiochip0", Label: "pinctrl-rp1"},
+				{Name: "gpiochip10", Label: "gpio-brcmstb@107d508500"},
+			},
+			want: "gpiochip0",
+		},
+		"error_no_rp1_label": {
+			chips:   []gobot.GpioChipInfo{{Name: "gpiochip0", Label: "gpio-brcmstb@107d508500"}},
+			wantErr: "no gpiochip with label 'pinctrl-rp1' found for RP1",
+		},
+		"error_chips": {
+			chipsError: true,
+			wantErr:    "the gpiochip of RP1 can not be detected: chips error",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := NewAdaptor()
+			gia := a.sys.UseMockGpioInfo()
+			gia.Chips = tc.chips
+			gia.ChipsError = tc.chipsError
+			a.revision = "5"
+			// act
+			got, err := a.getGpioChip()
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestBusNumberValidation(t *testing.T) {
+	tests := map[string]struct {
+		revision   string
+		validI2c   []int
+		invalidI2c int
+		validSpi   []int
+		invalidSpi int
+	}{
+		"rev_3": {
+			revision:   "3",
+			validI2c:   []int{0, 1},
+			invalidI2c: 2,
+			validSpi:   []int{0, 1},
+			invalidSpi: 2,
+		},
+		"rev_5": {
+			revision:   "5",
+			validI2c:   []int{0, 1, 2, 3},
+			invalidI2c: 4,
+			validSpi:   []int{0, 1, 2, 3, 4, 5},
+			invalidSpi: 6,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := NewAdaptor()
+			a.revision = tc.revision
+			// act & assert
+			for _, bus := range tc.validI2c {
+				require.NoError(t, a.validateI2cBusNumber(bus))
+			}
+			require.ErrorContains(t, a.validateI2cBusNumber(tc.invalidI2c), "Bus number")
+			for _, bus := range tc.validSpi {
+				require.NoError(t, a.validateSpiBusNumber(bus))
+			}
+			require.ErrorContains(t, a.validateSpiBusNumber(tc.invalidSpi), "Bus number")
+		})
+	}
+}
+
+func TestOwnershipConflictI2cBusAndDigitalPin(t *testing.T) {
+	// arrange
+	a := NewAdaptor()
+	a.sys.UseMockSyscall()
+	a.sys.UseMockFilesystem([]string{"/dev/i2c-1"})
+	a.revision = "2"
+	require.NoError(t, a.Connect())
+	i2cDrv := i2c.NewDriver(a, "Sensor", 0x77)
+	ledDrv := gpio.NewLedDriver(a, "3")
+	require.NoError(t, i2cDrv.Start())
+	// act
+	err := ledDrv.Start()
+	// assert
+	require.EqualError(t, err, fmt.Sprintf("'%s' can not claim pin '3', because it is already owned by '/dev/i2c-1'",
+		ledDrv.Name()))
+	want := []system.Ownership{
+		{
+			OwnershipResource: system.OwnershipResource{Kind: system.OwnershipKindDigitalPin, Resource: "gpiochip0:2"},
+			Owner:             "/dev/i2c-1",
+		},
+		{
+			OwnershipResource: system.OwnershipResource{Kind: system.OwnershipKindDigitalPin, Resource: "gpiochip0:3"},
+			Owner:             "/dev/i2c-1",
+		},
+		{
+			OwnershipResource: system.OwnershipResource{Kind: system.OwnershipKindI2c, Resource: "/dev/i2c-1:0x77"},
+			Owner:             i2cDrv.Name(),
+		},
+		{OwnershipResource: system.OwnershipResource{Kind: system.OwnershipKindPin, Resource: "3"}, Owner: "/dev/i2c-1"},
+		{OwnershipResource: system.OwnershipResource{Kind: system.OwnershipKindPin, Resource: "5"}, Owner: "/dev/i2c-1"},
+	}
+	assert.Equal(t, want, a.Ownerships())
+	// act & assert: after the I2C device is halted, the pin can be used
+	require.NoError(t, i2cDrv.Halt())
+	require.NoError(t, ledDrv.Start())
+}
+
+func TestOwnershipConflictI2cBusAndLineName(t *testing.T) {
+	// arrange
+	a := NewAdaptor()
+	a.sys.UseMockSyscall()
+	a.sys.UseMockFilesystem([]string{"/dev/i2c-1"})
+	gia := a.sys.UseMockGpioInfo()
+	gia.Chips = []gobot.GpioChipInfo{{
+		Name:  "gpiochip0",
+		Label: "pinctrl-bcm2835",
+		Lines: []gobot.GpioLineInfo{{Chip: "gpiochip0", Offset: 2, Name: "GPIO2"}},
+	}}
+	a.revision = "2"
+	require.NoError(t, a.Connect())
+	i2cDrv := i2c.NewDriver(a, "Sensor", 0x77)
+	ledDrv := gpio.NewLedDriver(a, "GPIO2")
+	require.NoError(t, i2cDrv.Start())
+	// act
+	err := ledDrv.Start()
+	// assert
+	require.EqualError(t, err, fmt.Sprintf("'%s' can not claim gpio 'gpiochip0:2', because it is already owned by "+
+		"'/dev/i2c-1'", ledDrv.Name()))
+	// act & assert: after the I2C device is halted, the line can be used
+	require.NoError(t, i2cDrv.Halt())
+	require.NoError(t, ledDrv.Start())
+}
+
+func Test_headerPins(t *testing.T) {
+	tests := map[string]struct {
+		revision string
+		wantI2c0 []string
+		wantI2c1 []string
+		wantSpi0 []string
+		wantSpi1 []string
+	}{
+		"revision_1": {
+			revision: "1",
+			wantI2c0: []string{"3", "5"},
+			wantSpi0: []string{"19", "21", "23", "24", "26"},
+			// SPI1 is not usable with the 26 pin header, but some of its GPIO's are connected
+			wantSpi1: []string{"11", "12", "13"},
+		},
+		"revision_3": {
+			revision: "3",
+			wantI2c1: []string{"3", "5"},
+			wantSpi0: []string{"19", "21", "23", "24", "26"},
+			wantSpi1: []string{"11", "12", "35", "36", "38", "40"},
+		},
+		"rp1": {
+			revision: revisionRP1,
+			wantI2c1: []string{"3", "5"},
+			wantSpi0: []string{"19", "21", "23", "24", "26"},
+			wantSpi1: []string{"11", "12", "35", "36", "38", "40"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := NewAdaptor()
+			a.revision = tc.revision
+			// act & assert
+			assert.Equal(t, tc.wantI2c0, a.headerPins(i2cBusGpios[0]))
+			assert.Equal(t, tc.wantI2c1, a.headerPins(i2cBusGpios[1]))
+			assert.Equal(t, tc.wantSpi0, a.headerPins(spiBusGpios[0]))
+			assert.Equal(t, tc.wantSpi1, a.headerPins(spiBusGpios[1]))
+		})
+	}
+}
+
+func TestUart(t *testing.T) {
+	// arrange
+	a := NewAdaptor()
+	mua := a.sys.UseMockUart()
+	require.NoError(t, a.Connect())
+	con, err := a.GetUartConnection(a.DefaultUartNumber(), 0)
+	require.NoError(t, err)
+	// the connection can be used by the serial drivers
+	drv := megapi.NewMotorDriver(con, 1)
+	assert.Equal(t, con, drv.Connection())
+	require.NoError(t, con.Connect())
+	// act
+	_, err = con.SerialWrite([]byte{0xff, 0x55})
+	// assert
+	require.NoError(t, err)
+	dev := mua.Devices["/dev/serial0"]
+	assert.Equal(t, 115200, dev.BaudRate)
+	assert.Equal(t, []byte{0xff, 0x55}, dev.Written())
+	_, err = a.GetUartConnection(2, 0)
+	require.EqualError(t, err, "UART number 2 out of range [0 1]")
+	require.NoError(t, a.Finalize())
+	assert.True(t, dev.Closed)
+}
@@ -87,15 +87,42 @@ var pins = map[string]map[string]int{
 	"40": {
 		"3": 21,
 	},
-	"pwm0": { // pin 12 (GPIO18) and pin 32 (GPIO12) can be configured for "pwm0"
+	"pwm0": { // pin 12 (GPIO18) and pin 32 (GPIO12) can be configured for "pwm0", RP1: only pin 32 (GPIO12)
 		"*": 0,
 	},
-	"pwm1": { // pin 33 (GPIO13) and pin 35 (GPIO19) can be configured for "pwm1"
+	"pwm1": { // pin 33 (GPIO13) and pin 35 (GPIO19) can be configured for "pwm1", RP1: only pin 33 (GPIO13)
 		"3": 1,
 	},
+	"pwm2": { // RP1 only: pin 12 (GPIO18)
+		"5": 2,
+	},
+	"pwm3": { // RP1 only: pin 35 (GPIO19)
+		"5": 3,
+	},
 }
 
 var analogPinDefinitions = adaptors.AnalogPinDefinitions{
 	// +/-273.200 °C need >=7 characters to read: +/-273200 millidegree Celsius
 	"thermal_zone0": {Path: "/sys/class/thermal/thermal_zone0/temp", W: false, ReadBufLen: 7},
 }
+
+// i2cBusGpios contains the GPIO's (BCM numbering) of each I2C bus, the header pins are derived from the pin
+// definitions, e.g. pin 3 (SDA) and pin 5 (SCL) are used for bus 1 on newer boards and for bus 0 on revision 1
+var i2cBusGpios = map[int][]int{
+	0: {0, 1},
+	1: {2, 3},
+}
+
+// spiBusGpios contains the GPIO's (BCM numbering) of each SPI bus, including all chip select pins, the header pins are
+// derived from the pin definitions
+var spiBusGpios = map[int][]int{
+	0: {7, 8, 9, 10, 11},
+	1: {16, 17, 18, 19, 20, 21},
+}
+
+// uartPaths contains the character devices of the UART's, "/dev/serial0" is the primary UART with pin 8 (TXD) and
+// pin 10 (RXD), "/dev/serial1" is the secondary UART, which is used for bluetooth on boards with wireless
+var uartPaths = map[int]string{
+	0: "/dev/serial0",
+	1: "/dev/serial1",
+}
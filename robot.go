@@ -55,6 +55,7 @@ type Robot struct {
 	workRegistry       *RobotWorkRegistry
 	WorkEveryWaitGroup *sync.WaitGroup
 	WorkAfterWaitGroup *sync.WaitGroup
+	clock              Clock
 	Commander
 	Eventer
 }
@@ -110,6 +111,7 @@ func (r *Robots) Each(f func(*Robot)) {
 //	[]Connection: Connections which are automatically started and stopped with the robot
 //	[]Device: Devices which are automatically started and stopped with the robot
 //	func(): The work routine the robot will execute once all devices and connections have been initialized and started
+//	Clock: The clock used for the work of the robot, e.g. a fake clock for tests, see [NewFakeClock]
 func NewRobot(v ...interface{}) *Robot {
 	r := &Robot{
 		Name:        fmt.Sprintf("%X", Rand(int(^uint(0)>>1))),
@@ -121,6 +123,7 @@ func NewRobot(v ...interface{}) *Robot {
 		},
 		AutoRun:   true,
 		Work:      nil,
+		clock:     NewRealClock(),
 		Eventer:   NewEventer(),
 		Commander: NewCommander(),
 	}
@@ -143,6 +146,8 @@ func NewRobot(v ...interface{}) *Robot {
 			}
 		case func():
 			r.Work = val
+		case Clock:
+			r.clock = val
 		}
 	}
 
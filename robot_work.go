@@ -50,7 +50,7 @@ type RobotWork struct {
 	ctx        context.Context //nolint:containedctx // done by intention
 	cancelFunc context.CancelFunc
 	function   func()
-	ticker     *time.Ticker
+	ticker     Ticker
 	duration   time.Duration
 }
 
@@ -69,8 +69,21 @@ func (rw *RobotWork) CallCancelFunc() {
 	rw.cancelFunc()
 }
 
-// Ticker returns the time.Ticker used in an Every so that calling code can sync on the same channel
+// Ticker returns the time.Ticker used in an Every so that calling code can sync on the same channel. For a robot with
+// another clock than the real one, nil is returned, see [RobotWork.ClockTicker].
 func (rw *RobotWork) Ticker() *time.Ticker {
+	if rw.kind == AfterWorkKind {
+		return nil
+	}
+	if rt, ok := rw.ticker.(*realTicker); ok {
+		return rt.ticker
+	}
+	return nil
+}
+
+// ClockTicker returns the ticker of the robot's clock used in an Every so that calling code can sync on the same
+// channel
+func (rw *RobotWork) ClockTicker() Ticker {
 	if rw.kind == AfterWorkKind {
 		return nil
 	}
@@ -96,6 +109,12 @@ TickCount: %d
 	return fmt.Sprintf(format, rw.id, rw.kind, rw.tickCount)
 }
 
+// Clock returns the clock used for the work of the Robot. The work function can use it e.g. for sleeping, so the work
+// can be tested with a fake clock.
+func (r *Robot) Clock() Clock {
+	return r.clock
+}
+
 // WorkRegistry returns the Robot's WorkRegistry
 func (r *Robot) WorkRegistry() *RobotWorkRegistry {
 	return r.workRegistry
@@ -103,7 +122,7 @@ func (r *Robot) WorkRegistry() *RobotWorkRegistry {
 
 // Every calls the given function for every tick of the provided duration.
 func (r *Robot) Every(ctx context.Context, d time.Duration, f func()) *RobotWork {
-	rw := r.workRegistry.registerEvery(ctx, d, f)
+	rw := r.workRegistry.registerEvery(ctx, d, f, r.clock)
 	r.WorkEveryWaitGroup.Add(1)
 	go func() {
 	EVERYWORK:
@@ -113,7 +132,7 @@ func (r *Robot) Every(ctx context.Context, d time.Duration, f func()) *RobotWork
 				r.workRegistry.delete(rw.id)
 				rw.ticker.Stop()
 				break EVERYWORK
-			case <-rw.ticker.C:
+			case <-rw.ticker.C():
 				f()
 				rw.tickCount++
 			}
@@ -126,7 +145,7 @@ func (r *Robot) Every(ctx context.Context, d time.Duration, f func()) *RobotWork
 // After calls the given function after the provided duration has elapsed
 func (r *Robot) After(ctx context.Context, d time.Duration, f func()) *RobotWork {
 	rw := r.workRegistry.registerAfter(ctx, d, f)
-	ch := time.After(d)
+	ch := r.clock.After(d)
 	r.WorkAfterWaitGroup.Add(1)
 	go func() {
 	AFTERWORK:
@@ -179,7 +198,7 @@ func (rwr *RobotWorkRegistry) registerAfter(ctx context.Context, d time.Duration
 }
 
 // registerEvery creates a new unit of RobotWork and sets up its context/cancellation
-func (rwr *RobotWorkRegistry) registerEvery(ctx context.Context, d time.Duration, f func()) *RobotWork {
+func (rwr *RobotWorkRegistry) registerEvery(ctx context.Context, d time.Duration, f func(), clock Clock) *RobotWork {
 	rwr.Lock()
 	defer rwr.Unlock()
 
@@ -189,7 +208,7 @@ func (rwr *RobotWorkRegistry) registerEvery(ctx context.Context, d time.Duration
 		kind:     EveryWorkKind,
 		function: f,
 		duration: d,
-		ticker:   time.NewTicker(d),
+		ticker:   clock.NewTicker(d),
 	}
 
 	rw.ctx, rw.cancelFunc = context.WithCancel(ctx)
@@ -91,6 +91,44 @@ func TestRobotAutomationFunctions(t *testing.T) {
 	})
 }
 
+func TestRobotAutomationFunctionsWithFakeClock(t *testing.T) {
+	t.Run("Every with cancel", func(t *testing.T) {
+		// arrange
+		clock := NewFakeClock(time.Unix(0, 0))
+		robot := NewRobot("testbot", clock)
+		counter := make(chan int, 10)
+		rw := robot.Every(context.Background(), time.Second, func() { counter <- 1 })
+		assert.Nil(t, rw.Ticker())
+		assert.NotNil(t, rw.ClockTicker())
+		// act
+		for i := 0; i < 3; i++ {
+			clock.Advance(time.Second)
+			<-counter
+		}
+		rw.CallCancelFunc()
+		robot.WorkEveryWaitGroup.Wait()
+		// assert
+		assert.Equal(t, 3, rw.TickCount())
+		assert.Equal(t, 0, clock.WaiterCount())
+		assert.NotContains(t, collectStringKeysFromWorkRegistry(robot.workRegistry), rw.id.String())
+	})
+
+	t.Run("After", func(t *testing.T) {
+		// arrange
+		clock := NewFakeClock(time.Unix(0, 0))
+		robot := NewRobot("testbot", clock)
+		done := make(chan struct{})
+		rw := robot.After(context.Background(), time.Hour, func() { close(done) })
+		// act
+		clock.Advance(time.Hour)
+		// assert
+		<-done
+		assert.Equal(t, time.Unix(3600, 0), robot.Clock().Now())
+		rw.CallCancelFunc()
+		robot.WorkAfterWaitGroup.Wait()
+	})
+}
+
 func collectStringKeysFromWorkRegistry(rwr *RobotWorkRegistry) []string {
 	keys := make([]string, len(rwr.r))
 	var idx int
@@ -277,6 +277,11 @@ func digitalPinCdevReconfigureLine(d *digitalPinCdev, forceInput bool) error {
 		}
 		d.line = nil
 
+		if li, e := gpiodChip.LineInfo(d.pin); e == nil && li.Used {
+			return fmt.Errorf("cdev.reconfigure(%s)-c.RequestLine(%d, %v): %v, line is used by '%s'", id, d.pin, opts,
+				err, li.Consumer)
+		}
+
 		return fmt.Errorf("cdev.reconfigure(%s)-c.RequestLine(%d, %v): %v", id, d.pin, opts, err)
 	}
 	d.line = gpiodLine
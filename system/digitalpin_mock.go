@@ -67,6 +67,17 @@ func (dpa *mockDigitalPinAccess) createPin(chip string, pin int,
 	return dpm
 }
 
+// createPinGroup creates a sequential group of mocked pins, so the values can be checked per pin
+func (dpa *mockDigitalPinAccess) createPinGroup(chip string, pins []int,
+	o ...func(gobot.DigitalPinOptioner) bool,
+) gobot.DigitalPinGrouper {
+	group := make([]gobot.DigitalPinner, len(pins))
+	for i, pin := range pins {
+		group[i] = dpa.createPin(chip, pin, digitalPinGroupMemberOptions(i, o)...)
+	}
+	return newDigitalPinGroupSequential(group)
+}
+
 func (dpa *mockDigitalPinAccess) setFs(fs filesystem) {
 	panic("setFs() for mockDigitalPinAccess not supported")
 }
@@ -32,6 +32,16 @@ func (dpa *sysfsDigitalPinAccess) createPin(chip string, pin int,
 	return newDigitalPinSysfs(dpa.sfa, strconv.Itoa(pin), o...)
 }
 
+func (dpa *sysfsDigitalPinAccess) createPinGroup(chip string, pins []int,
+	o ...func(gobot.DigitalPinOptioner) bool,
+) gobot.DigitalPinGrouper {
+	group := make([]gobot.DigitalPinner, len(pins))
+	for i, pin := range pins {
+		group[i] = newDigitalPinSysfs(dpa.sfa, strconv.Itoa(pin), digitalPinGroupMemberOptions(i, o)...)
+	}
+	return newDigitalPinGroupSequential(group)
+}
+
 func (dpa *sysfsDigitalPinAccess) setFs(fs filesystem) {
 	dpa.sfa = &sysfsFileAccess{fs: fs, readBufLen: 2}
 }
@@ -55,6 +65,12 @@ func (dpa *cdevDigitalPinAccess) createPin(chip string, pin int,
 	return newDigitalPinCdev(chip, pin, o...)
 }
 
+func (dpa *cdevDigitalPinAccess) createPinGroup(chip string, pins []int,
+	o ...func(gobot.DigitalPinOptioner) bool,
+) gobot.DigitalPinGrouper {
+	return newDigitalPinGroupCdev(chip, pins, o...)
+}
+
 func (dpa *cdevDigitalPinAccess) setFs(fs filesystem) {
 	dpa.fs = fs
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_isSupported_sysfs(t *testing.T) {
@@ -101,3 +102,26 @@ func Test_createPin_WithOptions_cdev(t *testing.T) {
 	// test fallback for empty chip
 	assert.Equal(t, "gpiochip0", dpg.chipName)
 }
+
+func Test_createPinGroup_sysfs(t *testing.T) {
+	// arrange
+	dpa := sysfsDigitalPinAccess{}
+	// act
+	g := dpa.createPinGroup("chip", []int{8, 9})
+	// assert
+	dpg := g.(*digitalPinGroupSequential)
+	require.Len(t, dpg.pins, 2)
+	assert.Equal(t, "gpio9", dpg.pins[1].(*digitalPinSysfs).label)
+}
+
+func Test_createPinGroup_cdev(t *testing.T) {
+	// arrange
+	dpa := cdevDigitalPinAccess{}
+	// act
+	g := dpa.createPinGroup("gpiochip1", []int{17, 18, 27})
+	// assert
+	dpg := g.(*digitalPinGroupCdev)
+	assert.Equal(t, "gobotio17_18_27", dpg.label)
+	assert.Equal(t, "gpiochip1", dpg.chipName)
+	assert.Equal(t, []int{17, 18, 27}, dpg.pins)
+}
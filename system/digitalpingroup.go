@@ -0,0 +1,108 @@
+package system
+
+import (
+	"github.com/hashicorp/go-multierror"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// digitalPinGroupMaxPins is the maximum count of pins in a group, limited by the bitmask and the Kernel ABI
+const digitalPinGroupMaxPins = 64
+
+// digitalPinGroupOutputSetter is implemented by groups and members of groups, which can be initialized with a separate
+// value for each pin
+type digitalPinGroupOutputSetter interface {
+	setGroupDirectionOutput(initial uint64) bool
+}
+
+// digitalPinGroupMember wraps the options receiver of a single pin of a sequential group, so the initial value of the
+// pin can be selected from the bitmask by its position
+type digitalPinGroupMember struct {
+	gobot.DigitalPinOptioner
+	index int
+}
+
+func (m digitalPinGroupMember) setGroupDirectionOutput(initial uint64) bool {
+	return m.SetDirectionOutput(int((initial >> m.index) & 0x01))
+}
+
+// digitalPinGroupMemberOptions returns the given options for the pin at the given position of a sequential group
+func digitalPinGroupMemberOptions(
+	index int,
+	options []func(gobot.DigitalPinOptioner) bool,
+) []func(gobot.DigitalPinOptioner) bool {
+	memberOptions := make([]func(gobot.DigitalPinOptioner) bool, len(options))
+	for i, option := range options {
+		memberOptions[i] = func(d gobot.DigitalPinOptioner) bool {
+			return option(digitalPinGroupMember{DigitalPinOptioner: d, index: index})
+		}
+	}
+	return memberOptions
+}
+
+// digitalPinGroupSequential is a group of single digital pins, which are accessed one by one. This is used, if the
+// system driver does not support the access to multiple pins at once (e.g. sysfs).
+type digitalPinGroupSequential struct {
+	pins []gobot.DigitalPinner
+}
+
+// newDigitalPinGroupSequential returns a group of the given pins, with sequential access.
+func newDigitalPinGroupSequential(pins []gobot.DigitalPinner) *digitalPinGroupSequential {
+	return &digitalPinGroupSequential{pins: pins}
+}
+
+// ApplyOptions apply all given options to all pins of the group. Implements interface gobot.DigitalPinOptionApplier.
+func (g *digitalPinGroupSequential) ApplyOptions(options ...func(gobot.DigitalPinOptioner) bool) error {
+	for i, pin := range g.pins {
+		if err := pin.ApplyOptions(digitalPinGroupMemberOptions(i, options)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Export exports all pins of the group. Implements the interface gobot.DigitalPinGrouper.
+func (g *digitalPinGroupSequential) Export() error {
+	for _, pin := range g.pins {
+		if err := pin.Export(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Unexport releases all pins of the group. Implements the interface gobot.DigitalPinGrouper.
+func (g *digitalPinGroupSequential) Unexport() error {
+	var err error
+	for _, pin := range g.pins {
+		if e := pin.Unexport(); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+	return err
+}
+
+// Read reads the values of all pins one by one. Implements the interface gobot.DigitalPinGrouper.
+func (g *digitalPinGroupSequential) Read() (uint64, error) {
+	var values uint64
+	for i, pin := range g.pins {
+		val, err := pin.Read()
+		if err != nil {
+			return 0, err
+		}
+		if val != 0 {
+			values |= 1 << i
+		}
+	}
+	return values, nil
+}
+
+// Write writes the values to all pins one by one. Implements the interface gobot.DigitalPinGrouper.
+func (g *digitalPinGroupSequential) Write(values uint64) error {
+	for i, pin := range g.pins {
+		if err := pin.Write(int((values >> i) & 0x01)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	gpiocdev "github.com/warthog618/go-gpiocdev"
+
+	"gobot.io/x/gobot/v2"
+)
+
+type cdevLines interface {
+	SetValues(values []int) error
+	Values(values []int) error
+	Close() error
+}
+
+// digitalPinGroupCdev is a group of lines of the same gpiochip, which are requested together, so all lines are read
+// and written at once by a single ioctl. Edge detection is not supported for groups.
+type digitalPinGroupCdev struct {
+	chipName string
+	pins     []int
+	*digitalPinConfig
+	outInitialValues uint64 // replaces the initial state of the configuration, the value of the first line is bit 0
+	lines            cdevLines
+}
+
+var digitalPinGroupCdevReconfigure = digitalPinGroupCdevReconfigureLines // to allow unit testing
+
+// newDigitalPinGroupCdev returns a group of digital pins of the given chip, with the label "gobotio" followed by the
+// pin numbers. The label can be modified optionally. The group is handled by the character device Kernel ABI.
+func newDigitalPinGroupCdev(
+	chipName string,
+	pins []int,
+	options ...func(gobot.DigitalPinOptioner) bool,
+) *digitalPinGroupCdev {
+	if chipName == "" {
+		chipName = "gpiochip0"
+	}
+	ids := make([]string, len(pins))
+	for i, pin := range pins {
+		ids[i] = strconv.Itoa(pin)
+	}
+	g := &digitalPinGroupCdev{
+		chipName:         chipName,
+		pins:             pins,
+		digitalPinConfig: newDigitalPinConfig("gobotio" + strings.Join(ids, "_")),
+	}
+	for _, option := range options {
+		option(g)
+	}
+	return g
+}
+
+// SetDirectionOutput sets the direction to output with the same initial state for all lines for next reconfigure.
+func (g *digitalPinGroupCdev) SetDirectionOutput(initial int) bool {
+	var values uint64
+	if initial != 0 {
+		values = ^uint64(0)
+	}
+	return g.setGroupDirectionOutput(values)
+}
+
+// setGroupDirectionOutput sets the direction to output with a separate initial state for each line for next
+// reconfigure, so no line is switched to an intermediate value.
+func (g *digitalPinGroupCdev) setGroupDirectionOutput(initial uint64) bool {
+	if g.direction == OUT {
+		// in this case also the initial values will not be written
+		return false
+	}
+	g.direction = OUT
+	g.outInitialValues = initial
+	return true
+}
+
+// ApplyOptions apply all given options to all lines immediately. Implements interface gobot.DigitalPinOptionApplier.
+func (g *digitalPinGroupCdev) ApplyOptions(options ...func(gobot.DigitalPinOptioner) bool) error {
+	anyChange := false
+	for _, option := range options {
+		anyChange = option(g) || anyChange
+	}
+	if anyChange {
+		return digitalPinGroupCdevReconfigure(g, false)
+	}
+	return nil
+}
+
+// Export requests all lines of the group. Implements the interface gobot.DigitalPinGrouper.
+func (g *digitalPinGroupCdev) Export() error {
+	if len(g.pins) > digitalPinGroupMaxPins {
+		return fmt.Errorf("cdev.Export(): count of lines (%d) exceeds the maximum (%d)", len(g.pins),
+			digitalPinGroupMaxPins)
+	}
+	if err := digitalPinGroupCdevReconfigure(g, false); err != nil {
+		return fmt.Errorf("cdev.Export(): %v", err)
+	}
+	return nil
+}
+
+// Unexport releases all lines of the group as input. Implements the interface gobot.DigitalPinGrouper.
+func (g *digitalPinGroupCdev) Unexport() error {
+	var errs []string
+	if g.lines != nil {
+		if err := digitalPinGroupCdevReconfigure(g, true); err != nil {
+			errs = append(errs, err.Error())
+		}
+		if err := g.lines.Close(); err != nil {
+			err = fmt.Errorf("cdev.Unexport()-lines.Close(): %v", err)
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errors.New(strings.Join(errs, ","))
+}
+
+// Write writes the values to all lines at once. Implements the interface gobot.DigitalPinGrouper.
+func (g *digitalPinGroupCdev) Write(values uint64) error {
+	if g.lines == nil {
+		return fmt.Errorf("cdev.Write(): lines of %s-%v are not exported", g.chipName, g.pins)
+	}
+
+	vals := make([]int, len(g.pins))
+	for i := range vals {
+		vals[i] = int((values >> i) & 0x01)
+	}
+
+	if err := g.lines.SetValues(vals); err != nil {
+		return fmt.Errorf("cdev.Write(): %v", err)
+	}
+	return nil
+}
+
+// Read reads the values of all lines at once. Implements the interface gobot.DigitalPinGrouper.
+func (g *digitalPinGroupCdev) Read() (uint64, error) {
+	if g.lines == nil {
+		return 0, fmt.Errorf("cdev.Read(): lines of %s-%v are not exported", g.chipName, g.pins)
+	}
+
+	vals := make([]int, len(g.pins))
+	if err := g.lines.Values(vals); err != nil {
+		return 0, fmt.Errorf("cdev.Read(): %v", err)
+	}
+
+	var values uint64
+	for i, val := range vals {
+		if val != 0 {
+			values |= 1 << i
+		}
+	}
+	return values, nil
+}
+
+func digitalPinGroupCdevReconfigureLines(g *digitalPinGroupCdev, forceInput bool) error {
+	// cleanup old lines
+	if g.lines != nil {
+		g.lines.Close()
+	}
+	g.lines = nil
+
+	// acquire chip, temporary
+	gpiodChip, err := gpiocdev.NewChip(g.chipName, gpiocdev.WithConsumer(g.label))
+	id := fmt.Sprintf("%s-%v", g.chipName, g.pins)
+	if err != nil {
+		return fmt.Errorf("cdev.reconfigure(%s)-lib.NewChip(%s): %v", id, g.chipName, err)
+	}
+	defer gpiodChip.Close()
+
+	// collect line configuration options, the edge detection is not supported for groups
+	var opts []gpiocdev.LineReqOption
+	if g.direction == IN || forceInput {
+		opts = append(opts, gpiocdev.AsInput)
+		if g.debouncePeriod != 0 {
+			opts = append(opts, gpiocdev.WithDebounce(g.debouncePeriod))
+		}
+	} else {
+		initialValues := make([]int, len(g.pins))
+		for i := range initialValues {
+			initialValues[i] = int((g.outInitialValues >> i) & 0x01)
+		}
+		opts = append(opts, gpiocdev.AsOutput(initialValues...))
+		switch g.drive {
+		case digitalPinDriveOpenDrain:
+			opts = append(opts, gpiocdev.AsOpenDrain)
+		case digitalPinDriveOpenSource:
+			opts = append(opts, gpiocdev.AsOpenSource)
+		default:
+			opts = append(opts, gpiocdev.AsPushPull)
+		}
+	}
+
+	if g.activeLow {
+		opts = append(opts, gpiocdev.AsActiveLow)
+	}
+
+	switch g.bias {
+	case digitalPinBiasPullDown:
+		opts = append(opts, gpiocdev.WithPullDown)
+	case digitalPinBiasPullUp:
+		opts = append(opts, gpiocdev.WithPullUp)
+	default:
+		opts = append(opts, gpiocdev.WithBiasAsIs)
+	}
+
+	// acquire lines with collected options
+	gpiodLines, err := gpiodChip.RequestLines(g.pins, opts...)
+	if err != nil {
+		if gpiodLines != nil {
+			gpiodLines.Close()
+		}
+		g.lines = nil
+
+		return fmt.Errorf("cdev.reconfigure(%s)-c.RequestLines(%v, %v): %v", id, g.pins, opts, err)
+	}
+	g.lines = gpiodLines
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package system
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+var (
+	_ gobot.DigitalPinGrouper  = (*digitalPinGroupCdev)(nil)
+	_ gobot.DigitalPinOptioner = (*digitalPinGroupCdev)(nil)
+)
+
+func Test_newDigitalPinGroupCdev(t *testing.T) {
+	// arrange
+	const chip = "gpiochip2"
+	// act
+	g := newDigitalPinGroupCdev(chip, []int{4, 5}, WithPinLabel("bus"), WithPinDirectionOutput(1))
+	// assert
+	assert.Equal(t, chip, g.chipName)
+	assert.Equal(t, []int{4, 5}, g.pins)
+	assert.Equal(t, "bus", g.label)
+	assert.Equal(t, OUT, g.direction)
+	assert.Equal(t, ^uint64(0), g.outInitialValues)
+	assert.Nil(t, g.lines)
+}
+
+func Test_newDigitalPinGroupCdevWithInitialValues(t *testing.T) {
+	// arrange & act
+	g := newDigitalPinGroupCdev("", []int{4, 5, 6}, WithPinGroupDirectionOutput(0x05))
+	// assert
+	assert.Equal(t, OUT, g.direction)
+	assert.Equal(t, uint64(0x05), g.outInitialValues)
+	// act
+	changed := g.ApplyOptions(WithPinGroupDirectionOutput(0x02))
+	// assert
+	require.NoError(t, changed)
+	assert.Equal(t, uint64(0x05), g.outInitialValues)
+}
+
+func TestApplyOptionsAndExport_cdevGroup(t *testing.T) {
+	// arrange
+	orgReconf := digitalPinGroupCdevReconfigure
+	defer func() { digitalPinGroupCdevReconfigure = orgReconf }()
+	var reconfigured []bool
+	digitalPinGroupCdevReconfigure = func(g *digitalPinGroupCdev, forceInput bool) error {
+		reconfigured = append(reconfigured, forceInput)
+		return nil
+	}
+	g := newDigitalPinGroupCdev("", []int{4, 5})
+	// act
+	errExport := g.Export()
+	errNoChange := g.ApplyOptions(WithPinDirectionInput())
+	errChange := g.ApplyOptions(WithPinDirectionOutput(0))
+	// assert
+	require.NoError(t, errExport)
+	require.NoError(t, errNoChange)
+	require.NoError(t, errChange)
+	assert.Equal(t, []bool{false, false}, reconfigured)
+	assert.Equal(t, "gpiochip0", g.chipName)
+}
+
+func TestExport_cdevGroupTooManyLines(t *testing.T) {
+	// arrange
+	g := newDigitalPinGroupCdev("", make([]int, 65))
+	// act
+	err := g.Export()
+	// assert
+	require.EqualError(t, err, "cdev.Export(): count of lines (65) exceeds the maximum (64)")
+}
+
+func TestUnexport_cdevGroup(t *testing.T) {
+	// arrange
+	orgReconf := digitalPinGroupCdevReconfigure
+	defer func() { digitalPinGroupCdevReconfigure = orgReconf }()
+	var forcedInput bool
+	digitalPinGroupCdevReconfigure = func(g *digitalPinGroupCdev, forceInput bool) error {
+		forcedInput = forceInput
+		return nil
+	}
+	g := newDigitalPinGroupCdev("", []int{4, 5})
+	lm := &linesMock{simCloseErr: fmt.Errorf("a close err")}
+	g.lines = lm
+	// act
+	err := g.Unexport()
+	// assert
+	require.ErrorContains(t, err, "cdev.Unexport()-lines.Close(): a close err")
+	assert.True(t, forcedInput)
+}
+
+func TestWriteRead_cdevGroup(t *testing.T) {
+	// arrange
+	g := newDigitalPinGroupCdev("", []int{4, 5, 6})
+	lm := &linesMock{}
+	g.lines = lm
+	// act
+	err := g.Write(0x05)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 0, 1}, lm.lastVals)
+	// arrange
+	lm.lastVals = []int{0, 1, 1}
+	// act
+	got, err := g.Read()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0x06), got)
+	// arrange
+	lm.simSetErr = fmt.Errorf("a write err")
+	lm.simValuesErr = fmt.Errorf("a read err")
+	// act
+	errWrite := g.Write(0x01)
+	_, errRead := g.Read()
+	// assert
+	require.EqualError(t, errWrite, "cdev.Write(): a write err")
+	require.EqualError(t, errRead, "cdev.Read(): a read err")
+}
+
+type linesMock struct {
+	lastVals     []int
+	simSetErr    error
+	simValuesErr error
+	simCloseErr  error
+}
+
+func (lm *linesMock) SetValues(values []int) error {
+	lm.lastVals = append([]int(nil), values...)
+	return lm.simSetErr
+}
+
+func (lm *linesMock) Values(values []int) error {
+	copy(values, lm.lastVals)
+	return lm.simValuesErr
+}
+
+func (lm *linesMock) Close() error { return lm.simCloseErr }
+
+func TestWriteRead_cdevGroupNotExported(t *testing.T) {
+	// arrange
+	g := newDigitalPinGroupCdev("gpiochip1", []int{4, 5})
+	// act
+	errWrite := g.Write(0x01)
+	_, errRead := g.Read()
+	// assert
+	require.EqualError(t, errWrite, "cdev.Write(): lines of gpiochip1-[4 5] are not exported")
+	require.EqualError(t, errRead, "cdev.Read(): lines of gpiochip1-[4 5] are not exported")
+}
@@ -0,0 +1,74 @@
+package system
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+var _ gobot.DigitalPinGrouper = (*digitalPinGroupSequential)(nil)
+
+func initTestDigitalPinGroupSequential() (*digitalPinGroupSequential, *mockDigitalPinAccess) {
+	dpa := newMockDigitalPinAccess(nil)
+	dpa.UseValues("", "1", []int{1})
+	dpa.UseValues("", "2", []int{0})
+	dpa.UseValues("", "3", []int{1})
+	g := dpa.createPinGroup("", []int{1, 2, 3}).(*digitalPinGroupSequential) //nolint:forcetypeassert // ok here
+	return g, dpa
+}
+
+func TestDigitalPinGroupSequentialWrite(t *testing.T) {
+	// arrange
+	g, dpa := initTestDigitalPinGroupSequential()
+	// act
+	err := g.Write(0x06)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []int{0}, dpa.Written("", "1"))
+	assert.Equal(t, []int{1}, dpa.Written("", "2"))
+	assert.Equal(t, []int{1}, dpa.Written("", "3"))
+}
+
+func TestDigitalPinGroupSequentialRead(t *testing.T) {
+	// arrange
+	g, _ := initTestDigitalPinGroupSequential()
+	// act
+	got, err := g.Read()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0x05), got)
+}
+
+func TestDigitalPinGroupSequentialExportUnexportAndOptions(t *testing.T) {
+	// arrange
+	g, dpa := initTestDigitalPinGroupSequential()
+	dpa.UseUnexportError("", "2")
+	dpa.UseUnexportError("", "3")
+	// act
+	errExport := g.Export()
+	errOpts := g.ApplyOptions(WithPinDirectionOutput(1))
+	errUnexport := g.Unexport()
+	// assert
+	require.NoError(t, errExport)
+	require.NoError(t, errOpts)
+	assert.Equal(t, 1, dpa.AppliedOptions("", "3"))
+	require.ErrorContains(t, errUnexport, "2 errors occurred")
+	assert.Equal(t, 0, dpa.Exported("", "1"))
+}
+
+func TestDigitalPinGroupSequentialInitialValues(t *testing.T) {
+	// arrange
+	dpa := &sysfsDigitalPinAccess{}
+	// act
+	group := dpa.createPinGroup("", []int{1, 2, 3}, WithPinGroupDirectionOutput(0x05))
+	// assert
+	g := group.(*digitalPinGroupSequential) //nolint:forcetypeassert // ok here
+	for i, want := range []int{1, 0, 1} {
+		pin := g.pins[i].(*digitalPinSysfs) //nolint:forcetypeassert // ok here
+		assert.Equal(t, OUT, pin.direction)
+		assert.Equal(t, want, pin.outInitialState)
+	}
+}
@@ -78,6 +78,17 @@ func WithPinDirectionOutput(initial int) func(gobot.DigitalPinOptioner) bool {
 	return func(d gobot.DigitalPinOptioner) bool { return d.SetDirectionOutput(initial) }
 }
 
+// WithPinGroupDirectionOutput initializes all pins of a group as outputs. The initial value of each pin is taken from
+// the given bitmask, the value of the first pin is bit 0. For a single pin, bit 0 is used.
+func WithPinGroupDirectionOutput(initial uint64) func(gobot.DigitalPinOptioner) bool {
+	return func(d gobot.DigitalPinOptioner) bool {
+		if g, ok := d.(digitalPinGroupOutputSetter); ok {
+			return g.setGroupDirectionOutput(initial)
+		}
+		return d.SetDirectionOutput(int(initial & 0x01))
+	}
+}
+
 // WithPinDirectionInput initializes the pin as input.
 func WithPinDirectionInput() func(gobot.DigitalPinOptioner) bool {
 	return func(d gobot.DigitalPinOptioner) bool { return d.SetDirectionInput() }
@@ -100,6 +100,17 @@ func TestWithPinDirectionOutput(t *testing.T) {
 	}
 }
 
+func TestWithPinGroupDirectionOutputForSinglePin(t *testing.T) {
+	// arrange
+	dpc := &digitalPinConfig{direction: "in"}
+	// act
+	got := WithPinGroupDirectionOutput(0x03)(dpc)
+	// assert
+	assert.True(t, got)
+	assert.Equal(t, "out", dpc.direction)
+	assert.Equal(t, 1, dpc.outInitialState)
+}
+
 func TestWithPinDirectionInput(t *testing.T) {
 	tests := map[string]struct {
 		oldDir string
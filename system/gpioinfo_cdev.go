@@ -0,0 +1,137 @@
+package system
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	gpiocdev "github.com/warthog618/go-gpiocdev"
+
+	"gobot.io/x/gobot/v2"
+)
+
+var gpioInfoCdevChangeType = map[gpiocdev.LineInfoChangeType]string{
+	gpiocdev.LineRequested:    "requested",
+	gpiocdev.LineReleased:     "released",
+	gpiocdev.LineReconfigured: "reconfigured",
+}
+
+// cdevGpioInfoAccess reads the information of gpiochips and lines by the line info ioctl's of the character devices
+type cdevGpioInfoAccess struct {
+	fs filesystem
+}
+
+// cdevGpioInfoWatcher stops the watch of line info changes, when closed
+type cdevGpioInfoWatcher struct {
+	chip    *gpiocdev.Chip
+	offsets []int
+}
+
+func (gia *cdevGpioInfoAccess) chips() ([]string, error) {
+	paths, err := gia.fs.find("/dev", "^gpiochip[0-9]+$")
+	if err != nil {
+		return nil, err
+	}
+
+	var chips []string
+	known := make(map[string]bool)
+	for _, path := range paths {
+		chip := filepath.Base(path)
+		if !known[chip] {
+			known[chip] = true
+			chips = append(chips, chip)
+		}
+	}
+	sortGpioChipNames(chips)
+
+	return chips, nil
+}
+
+func (gia *cdevGpioInfoAccess) chipInfo(chip string) (gobot.GpioChipInfo, error) {
+	c, err := gpiocdev.NewChip(chip)
+	if err != nil {
+		return gobot.GpioChipInfo{}, fmt.Errorf("open gpiochip '%s' failed: %w", chip, err)
+	}
+	defer c.Close()
+
+	info := gobot.GpioChipInfo{Name: c.Name, Label: c.Label, Lines: make([]gobot.GpioLineInfo, 0, c.Lines())}
+	for offset := 0; offset < c.Lines(); offset++ {
+		li, err := c.LineInfo(offset)
+		if err != nil {
+			return info, fmt.Errorf("read info of line %d of gpiochip '%s' failed: %w", offset, chip, err)
+		}
+		info.Lines = append(info.Lines, gpioInfoCdevLineInfo(c.Name, li))
+	}
+
+	return info, nil
+}
+
+func (gia *cdevGpioInfoAccess) watchLineInfo(
+	chip string,
+	offsets []int,
+	handler func(gobot.GpioLineInfoEvent),
+) (io.Closer, error) {
+	c, err := gpiocdev.NewChip(chip)
+	if err != nil {
+		return nil, fmt.Errorf("open gpiochip '%s' failed: %w", chip, err)
+	}
+
+	name := c.Name
+	for _, offset := range offsets {
+		if _, err := c.WatchLineInfo(offset, func(evt gpiocdev.LineInfoChangeEvent) {
+			handler(gobot.GpioLineInfoEvent{
+				Type:      gpioInfoCdevChangeType[evt.Type],
+				Timestamp: evt.Timestamp,
+				Info:      gpioInfoCdevLineInfo(name, evt.Info),
+			})
+		}); err != nil {
+			c.Close()
+			return nil, fmt.Errorf("watch info of line %d of gpiochip '%s' failed: %w", offset, chip, err)
+		}
+	}
+
+	return &cdevGpioInfoWatcher{chip: c, offsets: offsets}, nil
+}
+
+// Close stops the watch and releases the chip.
+func (w *cdevGpioInfoWatcher) Close() error {
+	for _, offset := range w.offsets {
+		_ = w.chip.UnwatchLineInfo(offset)
+	}
+
+	return w.chip.Close()
+}
+
+func gpioInfoCdevLineInfo(chip string, li gpiocdev.LineInfo) gobot.GpioLineInfo {
+	direction := digitalPinCdevDirection[li.Config.Direction]
+	if li.Config.Direction == gpiocdev.LineDirectionUnknown {
+		direction = "unknown"
+	}
+
+	return gobot.GpioLineInfo{
+		Chip:      chip,
+		Offset:    li.Offset,
+		Name:      li.Name,
+		Consumer:  li.Consumer,
+		Used:      li.Used,
+		Direction: direction,
+		Drive:     digitalPinCdevDrive[li.Config.Drive],
+		Bias:      digitalPinCdevBias[li.Config.Bias],
+		ActiveLow: li.Config.ActiveLow,
+	}
+}
+
+// sortGpioChipNames sorts the names in numeric order, e.g. "gpiochip2" before "gpiochip10"
+func sortGpioChipNames(chips []string) {
+	sort.Slice(chips, func(i, j int) bool {
+		ni, erri := strconv.Atoi(strings.TrimPrefix(chips[i], "gpiochip"))
+		nj, errj := strconv.Atoi(strings.TrimPrefix(chips[j], "gpiochip"))
+		if erri != nil || errj != nil {
+			return chips[i] < chips[j]
+		}
+		return ni < nj
+	})
+}
@@ -0,0 +1,86 @@
+package system
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gpiocdev "github.com/warthog618/go-gpiocdev"
+
+	"gobot.io/x/gobot/v2"
+)
+
+func TestCdevGpioInfoChips(t *testing.T) {
+	// arrange
+	fs := newMockFilesystem([]string{
+		"/dev/gpiochip10",
+		"/dev/gpiochip2",
+		"/dev/gpiochip0",
+		"/dev/gpiomem",
+		"/dev/i2c-1",
+	})
+	gia := &cdevGpioInfoAccess{fs: fs}
+	// act
+	got, err := gia.chips()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gpiochip0", "gpiochip2", "gpiochip10"}, got)
+}
+
+func TestCdevGpioInfoChipInfoError(t *testing.T) {
+	// arrange
+	gia := &cdevGpioInfoAccess{fs: newMockFilesystem(nil)}
+	// act
+	_, err := gia.chipInfo("gpiochip999")
+	// assert
+	require.ErrorContains(t, err, "open gpiochip 'gpiochip999' failed")
+}
+
+func TestGpioInfoCdevLineInfo(t *testing.T) {
+	tests := map[string]struct {
+		li   gpiocdev.LineInfo
+		want gobot.GpioLineInfo
+	}{
+		"unused_input": {
+			li: gpiocdev.LineInfo{
+				Offset: 4,
+				Name:   "GPIO4",
+				Config: gpiocdev.LineConfig{Direction: gpiocdev.LineDirectionInput, Bias: gpiocdev.LineBiasPullUp},
+			},
+			want: gobot.GpioLineInfo{
+				Chip: "gpiochip0", Offset: 4, Name: "GPIO4", Direction: "input", Drive: "push-pull", Bias: "pull-up",
+			},
+		},
+		"used_output": {
+			li: gpiocdev.LineInfo{
+				Offset:   17,
+				Name:     "GPIO17",
+				Consumer: "gobotio17",
+				Used:     true,
+				Config: gpiocdev.LineConfig{
+					Direction: gpiocdev.LineDirectionOutput,
+					Drive:     gpiocdev.LineDriveOpenDrain,
+					ActiveLow: true,
+				},
+			},
+			want: gobot.GpioLineInfo{
+				Chip: "gpiochip0", Offset: 17, Name: "GPIO17", Consumer: "gobotio17", Used: true, Direction: "output",
+				Drive: "open-drain", Bias: "unknown", ActiveLow: true,
+			},
+		},
+		"unknown_direction": {
+			li: gpiocdev.LineInfo{Offset: 1},
+			want: gobot.GpioLineInfo{
+				Chip: "gpiochip0", Offset: 1, Direction: "unknown", Drive: "push-pull", Bias: "unknown",
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			got := gpioInfoCdevLineInfo("gpiochip0", tc.li)
+			// assert
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
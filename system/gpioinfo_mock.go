@@ -0,0 +1,109 @@
+package system
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// MockGpioInfoAccess is the mock for the information of gpiochips and lines. Used only for tests.
+type MockGpioInfoAccess struct {
+	Chips        []gobot.GpioChipInfo
+	FailingChips []string // listed chips, which can not be read
+	ChipsError   bool
+	WatchError   bool
+	mutex        sync.Mutex
+	watchers     []*mockGpioInfoWatcher
+}
+
+type mockGpioInfoWatcher struct {
+	access  *MockGpioInfoAccess
+	chip    string
+	offsets []int
+	handler func(gobot.GpioLineInfoEvent)
+}
+
+// Emit calls the handlers of all watchers for the chip and line of the given event.
+func (gia *MockGpioInfoAccess) Emit(evt gobot.GpioLineInfoEvent) {
+	gia.mutex.Lock()
+	watchers := append([]*mockGpioInfoWatcher(nil), gia.watchers...)
+	gia.mutex.Unlock()
+
+	for _, w := range watchers {
+		if w.chip != evt.Info.Chip {
+			continue
+		}
+		for _, offset := range w.offsets {
+			if offset == evt.Info.Offset {
+				w.handler(evt)
+			}
+		}
+	}
+}
+
+// WatcherCount returns the count of active watchers.
+func (gia *MockGpioInfoAccess) WatcherCount() int {
+	gia.mutex.Lock()
+	defer gia.mutex.Unlock()
+
+	return len(gia.watchers)
+}
+
+func (gia *MockGpioInfoAccess) chips() ([]string, error) {
+	if gia.ChipsError {
+		return nil, fmt.Errorf("chips error")
+	}
+
+	chips := make([]string, 0, len(gia.Chips)+len(gia.FailingChips))
+	for _, chip := range gia.Chips {
+		chips = append(chips, chip.Name)
+	}
+	chips = append(chips, gia.FailingChips...)
+
+	return chips, nil
+}
+
+func (gia *MockGpioInfoAccess) chipInfo(chip string) (gobot.GpioChipInfo, error) {
+	for _, info := range gia.Chips {
+		if info.Name == chip {
+			return info, nil
+		}
+	}
+
+	return gobot.GpioChipInfo{}, fmt.Errorf("open gpiochip '%s' failed: not found in mock", chip)
+}
+
+func (gia *MockGpioInfoAccess) watchLineInfo(
+	chip string,
+	offsets []int,
+	handler func(gobot.GpioLineInfoEvent),
+) (io.Closer, error) {
+	if gia.WatchError {
+		return nil, fmt.Errorf("watch error")
+	}
+
+	gia.mutex.Lock()
+	defer gia.mutex.Unlock()
+
+	w := &mockGpioInfoWatcher{access: gia, chip: chip, offsets: offsets, handler: handler}
+	gia.watchers = append(gia.watchers, w)
+
+	return w, nil
+}
+
+// Close removes the watcher.
+func (w *mockGpioInfoWatcher) Close() error {
+	w.access.mutex.Lock()
+	defer w.access.mutex.Unlock()
+
+	for i, item := range w.access.watchers {
+		if item == w {
+			w.access.watchers = append(w.access.watchers[:i], w.access.watchers[i+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
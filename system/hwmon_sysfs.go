@@ -0,0 +1,109 @@
+package system
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+const (
+	hwmonSysfsPath       = "/sys/class/hwmon"
+	hwmonDevicePattern   = "^hwmon[0-9]+$"
+	hwmonAttributeFilter = "^(in|curr|power|energy|humidity|temp|fan|pwm)[0-9]+(_[a-z_]+)?$"
+)
+
+// HwmonDeviceInfo contains the identification of a hardware monitoring device.
+type HwmonDeviceInfo struct {
+	ID   string // e.g. "hwmon0"
+	Name string // the name of the Kernel driver, e.g. "cpu_thermal", "pwmfan" or "rpi_volt"
+	Path string // the sysfs path of the device
+}
+
+// HwmonDevice is the implementation of a Linux hardware monitoring device. All values are integers with fixed units,
+// e.g. "temp1_input" in millidegree Celsius, "in0_input" in millivolt, "fan1_input" in RPM and "pwm1" in the range
+// 0..255. See https://docs.kernel.org/hwmon/sysfs-interface.html
+type HwmonDevice struct {
+	HwmonDeviceInfo
+	sfa *sysfsFileAccess
+}
+
+// HwmonDevices returns the identification of all hardware monitoring devices of the system.
+func (a *Accesser) HwmonDevices() ([]HwmonDeviceInfo, error) {
+	items, err := a.fs.find(hwmonSysfsPath, hwmonDevicePattern)
+	if err != nil {
+		return nil, err
+	}
+
+	sfa := &sysfsFileAccess{fs: a.fs, readBufLen: 200}
+	var infos []HwmonDeviceInfo
+	for _, item := range uniqueSorted(items) {
+		info := HwmonDeviceInfo{ID: path.Base(item), Path: item}
+		// the name is mandatory by the Kernel ABI, but read errors are tolerated for robustness
+		if name, err := sfa.read(path.Join(item, "name")); err == nil {
+			info.Name = strings.TrimSpace(string(name))
+		}
+		infos = append(infos, info)
+	}
+
+	return infos, nil
+}
+
+// NewHwmonDevice returns a new hardware monitoring device. The device is searched by the given id (e.g. "hwmon0") or
+// the name of the Kernel driver (e.g. "cpu_thermal").
+func (a *Accesser) NewHwmonDevice(idOrName string) (*HwmonDevice, error) {
+	infos, err := a.HwmonDevices()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, info := range infos {
+		if info.ID == idOrName || info.Name == idOrName {
+			return &HwmonDevice{HwmonDeviceInfo: info, sfa: &sysfsFileAccess{fs: a.fs, readBufLen: 200}}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("hwmon device '%s' not found in '%s'", idOrName, hwmonSysfsPath)
+}
+
+// Attributes returns the names of all sensor and control attributes of the device, e.g. "temp1_input", "fan1_input",
+// "pwm1" or "pwm1_enable", sorted by name.
+func (d *HwmonDevice) Attributes() ([]string, error) {
+	items, err := d.sfa.fs.find(d.Path, hwmonAttributeFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, item := range uniqueSorted(items) {
+		names = append(names, path.Base(item))
+	}
+
+	return names, nil
+}
+
+// Label reads the label of the given channel (e.g. "temp1"), if provided by the Kernel driver.
+func (d *HwmonDevice) Label(channel string) (string, error) {
+	buf, err := d.sfa.read(path.Join(d.Path, channel+"_label"))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(buf)), nil
+}
+
+// HasAttribute returns true, if the given attribute exists, e.g. "pwm1_enable", which is optional for some Kernel
+// drivers.
+func (d *HwmonDevice) HasAttribute(attribute string) bool {
+	_, err := d.sfa.fs.stat(path.Join(d.Path, attribute))
+	return err == nil
+}
+
+// ReadInteger reads the value of the given attribute, e.g. "temp1_input".
+func (d *HwmonDevice) ReadInteger(attribute string) (int, error) {
+	return d.sfa.readInteger(path.Join(d.Path, attribute))
+}
+
+// WriteInteger writes the value of the given attribute, e.g. "pwm1" or "pwm1_enable".
+func (d *HwmonDevice) WriteInteger(attribute string, val int) error {
+	return d.sfa.writeInteger(path.Join(d.Path, attribute), val)
+}
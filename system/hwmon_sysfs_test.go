@@ -0,0 +1,122 @@
+package system
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	hwmonTestThermalPath = "/sys/class/hwmon/hwmon0"
+	hwmonTestFanPath     = "/sys/class/hwmon/hwmon2"
+)
+
+func initTestHwmonAccesserWithMockedFilesystem() (*Accesser, *MockFilesystem) {
+	a := NewAccesser()
+	a.AddHwmonSupport()
+	fs := a.UseMockFilesystem([]string{
+		hwmonTestThermalPath + "/name",
+		hwmonTestThermalPath + "/temp1_input",
+		hwmonTestThermalPath + "/temp1_label",
+		hwmonTestFanPath + "/name",
+		hwmonTestFanPath + "/fan1_input",
+		hwmonTestFanPath + "/pwm1",
+		hwmonTestFanPath + "/pwm1_enable",
+		hwmonTestFanPath + "/uevent",
+	})
+	fs.Files[hwmonTestThermalPath+"/name"].Contents = "cpu_thermal\n"
+	fs.Files[hwmonTestThermalPath+"/temp1_input"].Contents = "48312\n"
+	fs.Files[hwmonTestThermalPath+"/temp1_label"].Contents = "CPU\n"
+	fs.Files[hwmonTestFanPath+"/name"].Contents = "pwmfan\n"
+	fs.Files[hwmonTestFanPath+"/fan1_input"].Contents = "2400\n"
+	return a, fs
+}
+
+func TestHwmonDevices(t *testing.T) {
+	// arrange
+	a, _ := initTestHwmonAccesserWithMockedFilesystem()
+	// act
+	got, err := a.HwmonDevices()
+	// assert
+	require.NoError(t, err)
+	want := []HwmonDeviceInfo{
+		{ID: "hwmon0", Name: "cpu_thermal", Path: hwmonTestThermalPath},
+		{ID: "hwmon2", Name: "pwmfan", Path: hwmonTestFanPath},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestNewHwmonDevice(t *testing.T) {
+	tests := map[string]struct {
+		idOrName string
+		wantPath string
+		wantErr  string
+	}{
+		"by_id":         {idOrName: "hwmon2", wantPath: hwmonTestFanPath},
+		"by_name":       {idOrName: "cpu_thermal", wantPath: hwmonTestThermalPath},
+		"error_unknown": {idOrName: "rpi_volt", wantErr: "hwmon device 'rpi_volt' not found in '/sys/class/hwmon'"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a, _ := initTestHwmonAccesserWithMockedFilesystem()
+			// act
+			d, err := a.NewHwmonDevice(tc.idOrName)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				assert.Nil(t, d)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantPath, d.Path)
+		})
+	}
+}
+
+func TestHwmonDeviceAttributes(t *testing.T) {
+	// arrange
+	a, _ := initTestHwmonAccesserWithMockedFilesystem()
+	d, err := a.NewHwmonDevice("pwmfan")
+	require.NoError(t, err)
+	// act
+	got, err := d.Attributes()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fan1_input", "pwm1", "pwm1_enable"}, got)
+}
+
+func TestHwmonDeviceReadWrite(t *testing.T) {
+	// arrange
+	a, fs := initTestHwmonAccesserWithMockedFilesystem()
+	thermal, err := a.NewHwmonDevice("cpu_thermal")
+	require.NoError(t, err)
+	fan, err := a.NewHwmonDevice("pwmfan")
+	require.NoError(t, err)
+	// act
+	temp, errTemp := thermal.ReadInteger("temp1_input")
+	label, errLabel := thermal.Label("temp1")
+	errPwm := fan.WriteInteger("pwm1", 200)
+	// assert
+	require.NoError(t, errTemp)
+	require.NoError(t, errLabel)
+	require.NoError(t, errPwm)
+	assert.Equal(t, 48312, temp)
+	assert.Equal(t, "CPU", label)
+	assert.Equal(t, "200", fs.Files[hwmonTestFanPath+"/pwm1"].Contents)
+	// act
+	_, err = fan.Label("fan1")
+	// assert
+	require.ErrorContains(t, err, "fan1_label: no such file")
+}
+
+func TestHwmonDeviceHasAttribute(t *testing.T) {
+	// arrange
+	a, _ := initTestHwmonAccesserWithMockedFilesystem()
+	fan, err := a.NewHwmonDevice("pwmfan")
+	require.NoError(t, err)
+	// act & assert
+	assert.True(t, fan.HasAttribute("pwm1_enable"))
+	assert.False(t, fan.HasAttribute("pwm2_enable"))
+}
@@ -1,6 +1,7 @@
 package system
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -25,6 +26,7 @@ const (
 
 	// From  /usr/include/linux/i2c.h:
 	// Adapter functionality
+	I2C_FUNC_SMBUS_QUICK            = 0x00010000
 	I2C_FUNC_SMBUS_READ_BYTE        = 0x00020000
 	I2C_FUNC_SMBUS_WRITE_BYTE       = 0x00040000
 	I2C_FUNC_SMBUS_READ_BYTE_DATA   = 0x00080000
@@ -36,6 +38,7 @@ const (
 	I2C_FUNC_SMBUS_READ_I2C_BLOCK   = 0x04000000 // I2C-like block transfer with 1-byte reg. addr.
 	I2C_FUNC_SMBUS_WRITE_I2C_BLOCK  = 0x08000000 // I2C-like block transfer with 1-byte reg. addr.
 	// Transaction types
+	I2C_SMBUS_QUICK            = 0
 	I2C_SMBUS_BYTE             = 1
 	I2C_SMBUS_BYTE_DATA        = 2
 	I2C_SMBUS_WORD_DATA        = 3
@@ -91,7 +94,9 @@ func (d *i2cDevice) Close() error {
 	d.funcs = 0
 	d.lastAddress = -1
 	if d.file != nil {
-		return d.file.Close()
+		err := d.file.Close()
+		d.file = nil // the file will be opened again on next access
+		return err
 	}
 	return nil
 }
@@ -169,6 +174,19 @@ func (d *i2cDevice) ReadBlockData(address int, reg uint8, data []byte) error {
 	return nil
 }
 
+// WriteQuick sends only the address with the write bit to an i2c device, which is the SMBus "quick write". This is
+// used to detect a device without any data transfer.
+func (d *i2cDevice) WriteQuick(address int) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if err := d.queryFunctionality(I2C_FUNC_SMBUS_QUICK, "quick write"); err != nil {
+		return err
+	}
+
+	return d.smbusAccess(address, I2C_SMBUS_WRITE, 0, I2C_SMBUS_QUICK, nil)
+}
+
 // WriteByte writes the given byte value to the current register of an i2c device.
 func (d *i2cDevice) WriteByte(address int, val byte) error {
 	d.mutex.Lock()
@@ -329,7 +347,7 @@ func (d *i2cDevice) queryFunctionality(requested uint64, sender string) error {
 	}
 
 	if d.funcs&requested == 0 {
-		return fmt.Errorf("SMBus %s not supported", sender)
+		return fmt.Errorf("SMBus %s not supported: %w", sender, errors.ErrUnsupported)
 	}
 
 	return nil
@@ -384,7 +402,7 @@ func (d *i2cDevice) syscallIoctl(signal uintptr, payload unsafe.Pointer, address
 	}
 	//nolint:gosec // TODO: fix later
 	if _, _, errno := d.sys.syscall(Syscall_SYS_IOCTL, d.file, signal, payload, uint16(address)); errno != 0 {
-		return fmt.Errorf("%s failed with syscall.Errno %v", sender, errno)
+		return fmt.Errorf("%s failed with syscall.Errno %w", sender, errno)
 	}
 
 	return nil
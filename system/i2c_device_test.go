@@ -2,7 +2,9 @@
 package system
 
 import (
+	"errors"
 	"os"
+	"syscall"
 	"testing"
 	"unsafe"
 
@@ -102,6 +104,34 @@ func TestClose(t *testing.T) {
 	require.NoError(t, d.Close())
 }
 
+func TestCloseAndReopen(t *testing.T) {
+	// arrange
+	d, _ := initTestI2cDeviceWithMockedSys()
+	_, err := d.Write(1, []byte{0x01})
+	require.NoError(t, err)
+	// act
+	require.NoError(t, d.Close())
+	// assert
+	assert.Nil(t, d.file)
+	_, err = d.Write(1, []byte{0x02})
+	require.NoError(t, err)
+	assert.NotNil(t, d.file)
+}
+
+func TestSyscallErrnoIsWrapped(t *testing.T) {
+	// arrange
+	d, msc := initTestI2cDeviceWithMockedSys()
+	msc.Impl = getSyscallFuncImpl(0x04)
+	d.funcs = I2C_FUNC_SMBUS_READ_BYTE
+	// act
+	_, err := d.ReadByte(2)
+	// assert
+	require.ErrorIs(t, err, syscall.EPERM)
+	var errno syscall.Errno
+	require.ErrorAs(t, err, &errno)
+	assert.Equal(t, syscall.EPERM, errno)
+}
+
 func TestWriteRead(t *testing.T) {
 	// arrange
 	d, _ := initTestI2cDeviceWithMockedSys()
@@ -322,6 +352,51 @@ func TestReadBlockData(t *testing.T) {
 	}
 }
 
+func TestWriteQuick(t *testing.T) {
+	tests := map[string]struct {
+		funcs           uint64
+		syscallImpl     func(trap, a1, a2 uintptr, a3 unsafe.Pointer) (r1, r2 uintptr, err SyscallErrno)
+		wantErr         string
+		wantUnsupported bool
+	}{
+		"write_quick_ok": {
+			funcs: I2C_FUNC_SMBUS_QUICK,
+		},
+		"error_syscall": {
+			funcs:       I2C_FUNC_SMBUS_QUICK,
+			syscallImpl: getSyscallFuncImpl(0x04),
+			wantErr: "SMBus access r/w: 0, command: 0, protocol: 0, address: 6 " +
+				"failed with syscall.Errno operation not permitted",
+		},
+		"error_not_supported": {
+			funcs:           I2C_FUNC_SMBUS_READ_BYTE,
+			wantErr:         "SMBus quick write not supported",
+			wantUnsupported: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, msc := initTestI2cDeviceWithMockedSys()
+			msc.Impl = tc.syscallImpl
+			d.funcs = tc.funcs
+			// act
+			err := d.WriteQuick(6)
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				assert.Equal(t, tc.wantUnsupported, errors.Is(err, errors.ErrUnsupported))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, uintptr(I2C_SMBUS), msc.lastSignal)
+				assert.Equal(t, byte(I2C_SMBUS_WRITE), msc.smbus.readWrite)
+				assert.Equal(t, uint32(I2C_SMBUS_QUICK), msc.smbus.protocol)
+				assert.Nil(t, msc.smbus.data)
+			}
+		})
+	}
+}
+
 func TestWriteByte(t *testing.T) {
 	tests := map[string]struct {
 		funcs       uint64
@@ -0,0 +1,533 @@
+package system
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+)
+
+const (
+	iioSysfsDevicesPath = "/sys/bus/iio/devices"
+	iioDevicePattern    = "^iio:device[0-9]+$"
+	iioTriggerPattern   = "^trigger[0-9]+$"
+	iioTimestampChannel = "in_timestamp"
+)
+
+var (
+	iioTypeRegexp       = regexp.MustCompile(`^(be|le):([su])(\d+)/(\d+)(?:X(\d+))?>>(\d+)$`)
+	iioSharedIndexRegex = regexp.MustCompile(`(\d+|_[xyz])$`)
+)
+
+// IIOChannelType describes the storage format of a channel inside the buffer of an IIO device. It is parsed from the
+// "scan_elements/<channel>_type" file, e.g. "le:s12/16>>4", see https://docs.kernel.org/driver-api/iio/buffers.html
+type IIOChannelType struct {
+	BigEndian   bool
+	Signed      bool
+	Bits        uint8 // count of relevant bits
+	StorageBits uint8 // count of bits used to store the value in the buffer, including padding
+	Repeat      uint8 // count of repetitions of the value, 0 and 1 means one value
+	Shift       uint8 // count of bits to shift right before masking the relevant bits
+}
+
+// IIOChannel describes a single channel of an IIO device, which can be used in buffered mode.
+type IIOChannel struct {
+	Name    string // name without suffix, e.g. "in_voltage0" or "in_accel_x"
+	Index   int    // the position of the channel inside the scan
+	Type    IIOChannelType
+	Scale   float64
+	Offset  float64
+	Enabled bool
+}
+
+// IIODeviceInfo contains the identification of an IIO device.
+type IIODeviceInfo struct {
+	ID   string // e.g. "iio:device0"
+	Name string // the name of the Kernel driver, e.g. "ads1015"
+	Path string // the sysfs path of the device
+}
+
+// IIOSample contains the values of all enabled channels of one scan, read from the IIO character device.
+// For channels with repetitions (type "X<n>"), Raw and Scaled contain the first value and Repeated contains all values.
+type IIOSample struct {
+	Raw      map[string]int64   // the decoded raw values, key is the channel name
+	Scaled   map[string]float64 // the calibrated values (raw + offset) * scale, key is the channel name
+	Repeated map[string][]int64 // all decoded raw values of channels with repetitions, key is the channel name
+}
+
+// IIODevice is the implementation of a Linux Industrial I/O device, using sysfs for configuration and the character
+// device for buffered capture. See https://docs.kernel.org/driver-api/iio/index.html
+type IIODevice struct {
+	IIODeviceInfo
+	sfa      *sysfsFileAccess
+	devPath  string
+	channels []IIOChannel // enabled channels of the running buffer, sorted by index
+	file     File
+}
+
+// IIODevices returns the identification of all IIO devices of the system.
+func (a *Accesser) IIODevices() ([]IIODeviceInfo, error) {
+	items, err := a.fs.find(iioSysfsDevicesPath, iioDevicePattern)
+	if err != nil {
+		return nil, err
+	}
+	items = uniqueSorted(items)
+
+	sfa := &sysfsFileAccess{fs: a.fs, readBufLen: 200}
+	var infos []IIODeviceInfo
+	for _, item := range items {
+		infos = append(infos, iioReadDeviceInfo(sfa, item))
+	}
+
+	return infos, nil
+}
+
+// IIOTriggers returns the names of all available IIO triggers of the system.
+func (a *Accesser) IIOTriggers() ([]string, error) {
+	items, err := a.fs.find(iioSysfsDevicesPath, iioTriggerPattern)
+	if err != nil {
+		return nil, err
+	}
+	items = uniqueSorted(items)
+
+	sfa := &sysfsFileAccess{fs: a.fs, readBufLen: 200}
+	var names []string
+	for _, item := range items {
+		name, err := sfa.read(path.Join(item, "name"))
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, strings.TrimSpace(string(name)))
+	}
+
+	return names, nil
+}
+
+// NewIIODevice returns a new IIO device. The device is searched by the given id (e.g. "iio:device0") or the name of
+// the Kernel driver (e.g. "ads1015").
+func (a *Accesser) NewIIODevice(idOrName string) (*IIODevice, error) {
+	infos, err := a.IIODevices()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, info := range infos {
+		if info.ID == idOrName || info.Name == idOrName {
+			d := IIODevice{
+				IIODeviceInfo: info,
+				sfa:           &sysfsFileAccess{fs: a.fs, readBufLen: 200},
+				devPath:       path.Join("/dev", info.ID),
+			}
+			return &d, nil
+		}
+	}
+
+	return nil, fmt.Errorf("IIO device '%s' not found in '%s'", idOrName, iioSysfsDevicesPath)
+}
+
+// Channels returns all channels, which can be used in buffered mode, sorted by its index.
+func (d *IIODevice) Channels() ([]IIOChannel, error) {
+	scanPath := path.Join(d.Path, "scan_elements")
+	items, err := d.sfa.fs.find(scanPath, "_en$")
+	if err != nil {
+		return nil, err
+	}
+	items = uniqueSorted(items)
+
+	var channels []IIOChannel
+	for _, item := range items {
+		name := strings.TrimSuffix(path.Base(item), "_en")
+		ch, err := d.readChannel(name)
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, ch)
+	}
+	sort.Slice(channels, func(i, j int) bool { return channels[i].Index < channels[j].Index })
+
+	return channels, nil
+}
+
+// ReadRaw reads the raw value of the given channel (e.g. "in_voltage0") by the sysfs one-shot interface.
+func (d *IIODevice) ReadRaw(channel string) (int, error) {
+	return d.sfa.readInteger(path.Join(d.Path, channel+"_raw"))
+}
+
+// Read reads the calibrated value of the given channel (e.g. "in_voltage0") by the sysfs one-shot interface. The
+// value is calculated by (raw + offset) * scale, where the scale and offset are taken from the channel specific or the
+// shared file, if present.
+func (d *IIODevice) Read(channel string) (float64, error) {
+	raw, err := d.ReadRaw(channel)
+	if err != nil {
+		return 0, err
+	}
+
+	scale, offset, err := d.readScaleAndOffset(channel)
+	if err != nil {
+		return 0, err
+	}
+
+	return (float64(raw) + offset) * scale, nil
+}
+
+// SetTrigger writes the trigger name, which is used to fill the buffer. An empty name removes the current trigger.
+func (d *IIODevice) SetTrigger(name string) error {
+	return d.sfa.write(path.Join(d.Path, "trigger", "current_trigger"), []byte(name))
+}
+
+// SetSamplingFrequency writes the sampling frequency in Hz, if supported by the device.
+func (d *IIODevice) SetSamplingFrequency(hz int) error {
+	return d.sfa.writeInteger(path.Join(d.Path, "sampling_frequency"), hz)
+}
+
+// StartBuffer enables the given channels (e.g. "in_voltage0", "in_timestamp") and all other channels will be disabled.
+// Afterwards the buffer with the given length (count of scans) is enabled and the character device is opened. The
+// trigger needs to be set before, if the device does not provide a default trigger.
+func (d *IIODevice) StartBuffer(length int, channels ...string) error {
+	if d.file != nil {
+		return fmt.Errorf("buffer of IIO device '%s' already started", d.ID)
+	}
+	if len(channels) == 0 {
+		return fmt.Errorf("at least one channel is needed for buffered capture of IIO device '%s'", d.ID)
+	}
+
+	available, err := d.Channels()
+	if err != nil {
+		return err
+	}
+
+	wanted := make(map[string]bool)
+	for _, name := range channels {
+		wanted[name] = true
+	}
+
+	var enabled []IIOChannel
+	for _, ch := range available {
+		val := 0
+		if wanted[ch.Name] {
+			val = 1
+			ch.Enabled = true
+			enabled = append(enabled, ch)
+			delete(wanted, ch.Name)
+		}
+		if err := d.sfa.writeInteger(path.Join(d.Path, "scan_elements", ch.Name+"_en"), val); err != nil {
+			return err
+		}
+	}
+	if len(wanted) > 0 {
+		return fmt.Errorf("unknown channels %v for buffered capture of IIO device '%s'", iioKeys(wanted), d.ID)
+	}
+
+	if err := d.sfa.writeInteger(path.Join(d.Path, "buffer", "length"), length); err != nil {
+		return err
+	}
+	if err := d.sfa.writeInteger(path.Join(d.Path, "buffer", "enable"), 1); err != nil {
+		return err
+	}
+
+	f, err := d.sfa.fs.openFile(d.devPath, os.O_RDONLY, 0)
+	if err != nil {
+		_ = d.sfa.writeInteger(path.Join(d.Path, "buffer", "enable"), 0)
+		return err
+	}
+
+	d.file = f
+	d.channels = enabled
+
+	return nil
+}
+
+// ScanSize returns the size of one scan in bytes for the currently enabled channels.
+func (d *IIODevice) ScanSize() int {
+	_, size := iioScanLayout(d.channels)
+	return size
+}
+
+// ReadSamples reads the given count of scans from the character device and decodes it. The call blocks until all
+// requested scans are available.
+func (d *IIODevice) ReadSamples(count int) ([]IIOSample, error) {
+	if d.file == nil {
+		return nil, fmt.Errorf("buffer of IIO device '%s' not started", d.ID)
+	}
+
+	offsets, size := iioScanLayout(d.channels)
+	buf := make([]byte, size*count)
+	for n := 0; n < len(buf); {
+		i, err := d.file.Read(buf[n:])
+		if err != nil {
+			return nil, fmt.Errorf("read %d scans from '%s' failed: %w", count, d.devPath, err)
+		}
+		if i == 0 {
+			return nil, fmt.Errorf("read %d scans from '%s' failed: no data", count, d.devPath)
+		}
+		n += i
+	}
+
+	samples := make([]IIOSample, count)
+	for i := range samples {
+		scan := buf[i*size : (i+1)*size]
+		samples[i] = IIOSample{
+			Raw:      make(map[string]int64),
+			Scaled:   make(map[string]float64),
+			Repeated: make(map[string][]int64),
+		}
+		for j, ch := range d.channels {
+			raw := ch.Type.Decode(scan[offsets[j]:])
+			samples[i].Raw[ch.Name] = raw
+			samples[i].Scaled[ch.Name] = (float64(raw) + ch.Offset) * ch.Scale
+			if ch.Type.repeat() > 1 {
+				samples[i].Repeated[ch.Name] = ch.Type.DecodeAll(scan[offsets[j]:])
+			}
+		}
+	}
+
+	return samples, nil
+}
+
+// StopBuffer closes the character device and disables the buffer.
+func (d *IIODevice) StopBuffer() error {
+	var err error
+	if d.file != nil {
+		if e := d.file.Close(); e != nil {
+			err = multierror.Append(err, e)
+		}
+		d.file = nil
+		if e := d.sfa.writeInteger(path.Join(d.Path, "buffer", "enable"), 0); e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+	d.channels = nil
+
+	return err
+}
+
+// Close stops the buffered capture, if running.
+func (d *IIODevice) Close() error {
+	return d.StopBuffer()
+}
+
+// ParseIIOChannelType parses the type description of a channel, e.g. "le:s12/16>>4" or "be:u10/16X2>>0".
+func ParseIIOChannelType(s string) (IIOChannelType, error) {
+	m := iioTypeRegexp.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return IIOChannelType{}, fmt.Errorf("invalid IIO channel type '%s'", s)
+	}
+
+	var vals [4]uint8
+	for i, str := range []string{m[3], m[4], m[5], m[6]} {
+		if str == "" {
+			continue
+		}
+		v, err := strconv.ParseUint(str, 10, 8)
+		if err != nil {
+			return IIOChannelType{}, fmt.Errorf("invalid IIO channel type '%s': %v", s, err)
+		}
+		vals[i] = uint8(v)
+	}
+
+	t := IIOChannelType{
+		BigEndian:   m[1] == "be",
+		Signed:      m[2] == "s",
+		Bits:        vals[0],
+		StorageBits: vals[1],
+		Repeat:      vals[2],
+		Shift:       vals[3],
+	}
+
+	// only storage sizes with a native integer type can be decoded
+	switch t.StorageBits {
+	case 8, 16, 32, 64:
+	default:
+		return IIOChannelType{}, fmt.Errorf("unsupported IIO channel type '%s'", s)
+	}
+	if t.Bits == 0 || t.Bits+t.Shift > t.StorageBits {
+		return IIOChannelType{}, fmt.Errorf("unsupported IIO channel type '%s'", s)
+	}
+
+	return t, nil
+}
+
+// StorageBytes returns the count of bytes used by the channel in the buffer, including repetitions.
+func (t IIOChannelType) StorageBytes() int {
+	return int(t.StorageBits) / 8 * t.repeat()
+}
+
+// DecodeAll decodes all repetitions of the value from the given buffer according to the type.
+func (t IIOChannelType) DecodeAll(b []byte) []int64 {
+	n := int(t.StorageBits) / 8
+	vals := make([]int64, t.repeat())
+	for i := range vals {
+		vals[i] = t.Decode(b[i*n:])
+	}
+
+	return vals
+}
+
+// Decode decodes the first value from the given buffer according to the type. Repetitions are not considered, see
+// DecodeAll.
+func (t IIOChannelType) Decode(b []byte) int64 {
+	n := int(t.StorageBits) / 8
+	var v uint64
+	switch n {
+	case 1:
+		v = uint64(b[0])
+	case 2:
+		if t.BigEndian {
+			v = uint64(binary.BigEndian.Uint16(b))
+		} else {
+			v = uint64(binary.LittleEndian.Uint16(b))
+		}
+	case 4:
+		if t.BigEndian {
+			v = uint64(binary.BigEndian.Uint32(b))
+		} else {
+			v = uint64(binary.LittleEndian.Uint32(b))
+		}
+	default:
+		if t.BigEndian {
+			v = binary.BigEndian.Uint64(b)
+		} else {
+			v = binary.LittleEndian.Uint64(b)
+		}
+	}
+
+	v >>= t.Shift
+	if t.Bits < 64 {
+		v &= (uint64(1) << t.Bits) - 1
+		if t.Signed && v&(uint64(1)<<(t.Bits-1)) != 0 {
+			v |= ^uint64(0) << t.Bits // sign extension
+		}
+	}
+
+	return int64(v) //nolint:gosec // intended conversion for signed values
+}
+
+func (t IIOChannelType) repeat() int {
+	if t.Repeat == 0 {
+		return 1
+	}
+	return int(t.Repeat)
+}
+
+func (d *IIODevice) readChannel(name string) (IIOChannel, error) {
+	scanPath := path.Join(d.Path, "scan_elements")
+
+	index, err := d.sfa.readInteger(path.Join(scanPath, name+"_index"))
+	if err != nil {
+		return IIOChannel{}, err
+	}
+	typeStr, err := d.sfa.read(path.Join(scanPath, name+"_type"))
+	if err != nil {
+		return IIOChannel{}, err
+	}
+	typ, err := ParseIIOChannelType(string(typeStr))
+	if err != nil {
+		return IIOChannel{}, err
+	}
+	enabled, err := d.sfa.readInteger(path.Join(scanPath, name+"_en"))
+	if err != nil {
+		return IIOChannel{}, err
+	}
+
+	ch := IIOChannel{Name: name, Index: index, Type: typ, Scale: 1, Enabled: enabled == 1}
+	if name != iioTimestampChannel {
+		if ch.Scale, ch.Offset, err = d.readScaleAndOffset(name); err != nil {
+			return IIOChannel{}, err
+		}
+	}
+
+	return ch, nil
+}
+
+// readScaleAndOffset reads the scale and offset for the given channel. If there is no channel specific file, the
+// shared one is used (e.g. "in_voltage_scale" for "in_voltage0" or "in_accel_scale" for "in_accel_x"). Defaults are
+// 1.0 for scale and 0.0 for offset.
+func (d *IIODevice) readScaleAndOffset(channel string) (float64, float64, error) {
+	scale, err := d.readFloatWithFallback(channel, "_scale", 1)
+	if err != nil {
+		return 0, 0, err
+	}
+	offset, err := d.readFloatWithFallback(channel, "_offset", 0)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return scale, offset, nil
+}
+
+func (d *IIODevice) readFloatWithFallback(channel, suffix string, defaultVal float64) (float64, error) {
+	shared := iioSharedIndexRegex.ReplaceAllString(channel, "")
+	for _, name := range []string{channel + suffix, shared + suffix} {
+		p := path.Join(d.Path, name)
+		if _, err := d.sfa.fs.stat(p); err != nil {
+			continue
+		}
+		buf, err := d.sfa.read(p)
+		if err != nil {
+			return 0, err
+		}
+		return strconv.ParseFloat(strings.TrimSpace(string(buf)), 64)
+	}
+
+	return defaultVal, nil
+}
+
+func iioReadDeviceInfo(sfa *sysfsFileAccess, devicePath string) IIODeviceInfo {
+	info := IIODeviceInfo{ID: path.Base(devicePath), Path: devicePath}
+	if name, err := sfa.read(path.Join(devicePath, "name")); err == nil {
+		info.Name = strings.TrimSpace(string(name))
+	}
+	return info
+}
+
+// iioScanLayout returns the byte offset of each given channel inside a scan and the size of the scan. Each value is
+// aligned to its own storage size including the repetitions and the whole scan is padded to the largest of these
+// sizes, like done by the Kernel.
+func iioScanLayout(channels []IIOChannel) ([]int, int) {
+	offsets := make([]int, len(channels))
+	size := 0
+	maxAlign := 1
+	for i, ch := range channels {
+		align := ch.Type.StorageBytes()
+		if align > maxAlign {
+			maxAlign = align
+		}
+		if rem := size % align; rem != 0 {
+			size += align - rem
+		}
+		offsets[i] = size
+		size += ch.Type.StorageBytes()
+	}
+	if rem := size % maxAlign; rem != 0 {
+		size += maxAlign - rem
+	}
+
+	return offsets, size
+}
+
+func iioKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// uniqueSorted sorts the given items and removes duplicates, which can be returned by find() for directories
+func uniqueSorted(items []string) []string {
+	sort.Strings(items)
+	var unique []string
+	for i, item := range items {
+		if i == 0 || item != items[i-1] {
+			unique = append(unique, item)
+		}
+	}
+	return unique
+}
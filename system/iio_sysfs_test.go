@@ -0,0 +1,396 @@
+package system
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	iioTestDevPath  = "/sys/bus/iio/devices/iio:device0"
+	iioTestScanPath = iioTestDevPath + "/scan_elements"
+)
+
+var iioTestMockPaths = []string{
+	iioTestDevPath + "/name",
+	iioTestDevPath + "/in_voltage0_raw",
+	iioTestDevPath + "/in_voltage0_scale",
+	iioTestDevPath + "/in_voltage1_raw",
+	iioTestDevPath + "/in_voltage_scale",
+	iioTestDevPath + "/in_voltage_offset",
+	iioTestDevPath + "/sampling_frequency",
+	iioTestDevPath + "/buffer/length",
+	iioTestDevPath + "/buffer/enable",
+	iioTestDevPath + "/trigger/current_trigger",
+	iioTestScanPath + "/in_voltage0_en",
+	iioTestScanPath + "/in_voltage0_index",
+	iioTestScanPath + "/in_voltage0_type",
+	iioTestScanPath + "/in_voltage1_en",
+	iioTestScanPath + "/in_voltage1_index",
+	iioTestScanPath + "/in_voltage1_type",
+	iioTestScanPath + "/in_timestamp_en",
+	iioTestScanPath + "/in_timestamp_index",
+	iioTestScanPath + "/in_timestamp_type",
+	"/sys/bus/iio/devices/iio:device1/name",
+	"/sys/bus/iio/devices/trigger0/name",
+	"/dev/iio:device0",
+}
+
+func initTestIIODeviceWithMockedFilesystem(t *testing.T) (*IIODevice, *MockFilesystem) {
+	a := NewAccesser()
+	fs := a.UseMockFilesystem(iioTestMockPaths)
+	fs.Files[iioTestDevPath+"/name"].Contents = "ads1015\n"
+	fs.Files[iioTestDevPath+"/in_voltage0_raw"].Contents = "1000\n"
+	fs.Files[iioTestDevPath+"/in_voltage0_scale"].Contents = "0.5\n"
+	fs.Files[iioTestDevPath+"/in_voltage1_raw"].Contents = "-20\n"
+	fs.Files[iioTestDevPath+"/in_voltage_scale"].Contents = "2\n"
+	fs.Files[iioTestDevPath+"/in_voltage_offset"].Contents = "10\n"
+	fs.Files[iioTestScanPath+"/in_voltage0_en"].Contents = "0\n"
+	fs.Files[iioTestScanPath+"/in_voltage0_index"].Contents = "0\n"
+	fs.Files[iioTestScanPath+"/in_voltage0_type"].Contents = "le:s12/16>>4\n"
+	fs.Files[iioTestScanPath+"/in_voltage1_en"].Contents = "1\n"
+	fs.Files[iioTestScanPath+"/in_voltage1_index"].Contents = "1\n"
+	fs.Files[iioTestScanPath+"/in_voltage1_type"].Contents = "be:u8/8>>0\n"
+	fs.Files[iioTestScanPath+"/in_timestamp_en"].Contents = "0\n"
+	fs.Files[iioTestScanPath+"/in_timestamp_index"].Contents = "2\n"
+	fs.Files[iioTestScanPath+"/in_timestamp_type"].Contents = "le:s64/64>>0\n"
+	fs.Files["/sys/bus/iio/devices/iio:device1/name"].Contents = "mpu6050\n"
+	fs.Files["/sys/bus/iio/devices/trigger0/name"].Contents = "sysfstrig0\n"
+
+	d, err := a.NewIIODevice("ads1015")
+	require.NoError(t, err)
+
+	return d, fs
+}
+
+func TestIIODevices(t *testing.T) {
+	// arrange
+	a := NewAccesser()
+	fs := a.UseMockFilesystem(iioTestMockPaths)
+	fs.Files[iioTestDevPath+"/name"].Contents = "ads1015\n"
+	fs.Files["/sys/bus/iio/devices/iio:device1/name"].Contents = "mpu6050\n"
+	fs.Files["/sys/bus/iio/devices/trigger0/name"].Contents = "sysfstrig0\n"
+	// act
+	infos, err := a.IIODevices()
+	triggers, errTrig := a.IIOTriggers()
+	// assert
+	require.NoError(t, err)
+	require.NoError(t, errTrig)
+	want := []IIODeviceInfo{
+		{ID: "iio:device0", Name: "ads1015", Path: iioTestDevPath},
+		{ID: "iio:device1", Name: "mpu6050", Path: "/sys/bus/iio/devices/iio:device1"},
+	}
+	assert.Equal(t, want, infos)
+	assert.Equal(t, []string{"sysfstrig0"}, triggers)
+}
+
+func TestNewIIODevice(t *testing.T) {
+	tests := map[string]struct {
+		idOrName string
+		wantID   string
+		wantErr  string
+	}{
+		"by_id":   {idOrName: "iio:device1", wantID: "iio:device1"},
+		"by_name": {idOrName: "ads1015", wantID: "iio:device0"},
+		"error_not_found": {
+			idOrName: "bmp280",
+			wantErr:  "IIO device 'bmp280' not found in '/sys/bus/iio/devices'",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := NewAccesser()
+			fs := a.UseMockFilesystem(iioTestMockPaths)
+			fs.Files[iioTestDevPath+"/name"].Contents = "ads1015"
+			fs.Files["/sys/bus/iio/devices/iio:device1/name"].Contents = "mpu6050"
+			// act
+			d, err := a.NewIIODevice(tc.idOrName)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				assert.Nil(t, d)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantID, d.ID)
+			assert.Equal(t, "/dev/"+tc.wantID, d.devPath)
+		})
+	}
+}
+
+func TestIIODeviceRead(t *testing.T) {
+	tests := map[string]struct {
+		channel string
+		wantRaw int
+		wantVal float64
+		wantErr string
+	}{
+		"own_scale_shared_offset": {channel: "in_voltage0", wantRaw: 1000, wantVal: 505},
+		"shared_scale_and_offset": {channel: "in_voltage1", wantRaw: -20, wantVal: -20},
+		"error_unknown_channel": {
+			channel: "in_voltage2",
+			wantErr: "/sys/bus/iio/devices/iio:device0/in_voltage2_raw: no such file",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, _ := initTestIIODeviceWithMockedFilesystem(t)
+			// act
+			raw, errRaw := d.ReadRaw(tc.channel)
+			got, err := d.Read(tc.channel)
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, errRaw, tc.wantErr)
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, errRaw)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRaw, raw)
+			assert.InDelta(t, tc.wantVal, got, 0.0)
+		})
+	}
+}
+
+func TestIIODeviceChannels(t *testing.T) {
+	// arrange
+	d, _ := initTestIIODeviceWithMockedFilesystem(t)
+	// act
+	got, err := d.Channels()
+	// assert
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	assert.Equal(t, IIOChannel{
+		Name: "in_voltage0", Index: 0, Scale: 0.5, Offset: 10,
+		Type: IIOChannelType{Signed: true, Bits: 12, StorageBits: 16, Shift: 4},
+	}, got[0])
+	assert.Equal(t, IIOChannel{
+		Name: "in_voltage1", Index: 1, Scale: 2, Offset: 10, Enabled: true,
+		Type: IIOChannelType{BigEndian: true, Bits: 8, StorageBits: 8},
+	}, got[1])
+	assert.Equal(t, IIOChannel{
+		Name: "in_timestamp", Index: 2, Scale: 1,
+		Type: IIOChannelType{Signed: true, Bits: 64, StorageBits: 64},
+	}, got[2])
+}
+
+func TestIIODeviceSetTriggerAndSamplingFrequency(t *testing.T) {
+	// arrange
+	d, fs := initTestIIODeviceWithMockedFilesystem(t)
+	// act
+	err := d.SetTrigger("sysfstrig0")
+	errFreq := d.SetSamplingFrequency(860)
+	// assert
+	require.NoError(t, err)
+	require.NoError(t, errFreq)
+	assert.Equal(t, "sysfstrig0", fs.Files[iioTestDevPath+"/trigger/current_trigger"].Contents)
+	assert.Equal(t, "860", fs.Files[iioTestDevPath+"/sampling_frequency"].Contents)
+}
+
+func TestIIODeviceBuffer(t *testing.T) {
+	// arrange
+	d, fs := initTestIIODeviceWithMockedFilesystem(t)
+	// 2 scans, layout: voltage0 (2 bytes), voltage1 (1 byte), padding (5 bytes), timestamp (8 bytes)
+	fs.Files["/dev/iio:device0"].Contents = string([]byte{
+		0x10, 0xF0, 0x7F, 0, 0, 0, 0, 0, 0x01, 0x02, 0, 0, 0, 0, 0, 0,
+		0xF0, 0x7F, 0x80, 0, 0, 0, 0, 0, 0x03, 0x02, 0, 0, 0, 0, 0, 0,
+	})
+	// act
+	err := d.StartBuffer(64, "in_voltage0", "in_voltage1", "in_timestamp")
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "1", fs.Files[iioTestScanPath+"/in_voltage0_en"].Contents)
+	assert.Equal(t, "1", fs.Files[iioTestScanPath+"/in_timestamp_en"].Contents)
+	assert.Equal(t, "64", fs.Files[iioTestDevPath+"/buffer/length"].Contents)
+	assert.Equal(t, "1", fs.Files[iioTestDevPath+"/buffer/enable"].Contents)
+	assert.True(t, fs.Files["/dev/iio:device0"].Opened)
+	assert.Equal(t, 16, d.ScanSize())
+	// act
+	samples, err := d.ReadSamples(2)
+	// assert
+	require.NoError(t, err)
+	require.Len(t, samples, 2)
+	assert.Equal(t, map[string]int64{"in_voltage0": -255, "in_voltage1": 127, "in_timestamp": 0x0201}, samples[0].Raw)
+	assert.Equal(t, map[string]int64{"in_voltage0": 2047, "in_voltage1": 128, "in_timestamp": 0x0203}, samples[1].Raw)
+	assert.InDelta(t, -122.5, samples[0].Scaled["in_voltage0"], 0.0)
+	assert.InDelta(t, 276.0, samples[1].Scaled["in_voltage1"], 0.0)
+	assert.InDelta(t, 515.0, samples[1].Scaled["in_timestamp"], 0.0)
+	// act
+	err = d.StartBuffer(64, "in_voltage0")
+	// assert
+	require.EqualError(t, err, "buffer of IIO device 'iio:device0' already started")
+	// act
+	err = d.Close()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "0", fs.Files[iioTestDevPath+"/buffer/enable"].Contents)
+	assert.True(t, fs.Files["/dev/iio:device0"].Closed)
+	_, err = d.ReadSamples(1)
+	require.EqualError(t, err, "buffer of IIO device 'iio:device0' not started")
+}
+
+func TestIIODeviceBufferWithRepeatedChannel(t *testing.T) {
+	// arrange
+	d, fs := initTestIIODeviceWithMockedFilesystem(t)
+	fs.Files[iioTestScanPath+"/in_voltage0_type"].Contents = "le:s16/16X2>>0\n"
+	// 1 scan, layout: voltage0 (2x2 bytes), voltage1 (1 byte), padding (3 bytes)
+	fs.Files["/dev/iio:device0"].Contents = string([]byte{0xFE, 0xFF, 0x04, 0x00, 0x7F, 0, 0, 0})
+	require.NoError(t, d.StartBuffer(64, "in_voltage0", "in_voltage1"))
+	// act
+	samples, err := d.ReadSamples(1)
+	// assert
+	require.NoError(t, err)
+	require.Len(t, samples, 1)
+	assert.Equal(t, map[string]int64{"in_voltage0": -2, "in_voltage1": 127}, samples[0].Raw)
+	assert.Equal(t, map[string][]int64{"in_voltage0": {-2, 4}}, samples[0].Repeated)
+	assert.InDelta(t, 4.0, samples[0].Scaled["in_voltage0"], 0.0)
+	require.NoError(t, d.Close())
+}
+
+func TestIIODeviceStartBufferErrors(t *testing.T) {
+	tests := map[string]struct {
+		channels []string
+		wantErr  string
+	}{
+		"error_no_channels": {
+			wantErr: "at least one channel is needed for buffered capture of IIO device 'iio:device0'",
+		},
+		"error_unknown_channel": {
+			channels: []string{"in_voltage0", "in_voltage7"},
+			wantErr:  "unknown channels [in_voltage7] for buffered capture of IIO device 'iio:device0'",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			d, _ := initTestIIODeviceWithMockedFilesystem(t)
+			// act
+			err := d.StartBuffer(10, tc.channels...)
+			// assert
+			require.EqualError(t, err, tc.wantErr)
+			assert.Nil(t, d.file)
+		})
+	}
+}
+
+func TestParseIIOChannelType(t *testing.T) {
+	tests := map[string]struct {
+		typ     string
+		want    IIOChannelType
+		wantErr string
+	}{
+		"le_signed_shift": {
+			typ:  "le:s12/16>>4",
+			want: IIOChannelType{Signed: true, Bits: 12, StorageBits: 16, Shift: 4},
+		},
+		"be_unsigned_repeat": {
+			typ:  "be:u10/16X3>>0",
+			want: IIOChannelType{BigEndian: true, Bits: 10, StorageBits: 16, Repeat: 3},
+		},
+		"error_format":     {typ: "le:s12/16", wantErr: "invalid IIO channel type 'le:s12/16'"},
+		"error_storage":    {typ: "le:s12/12>>0", wantErr: "unsupported IIO channel type 'le:s12/12>>0'"},
+		"error_shift":      {typ: "le:u12/16>>8", wantErr: "unsupported IIO channel type 'le:u12/16>>8'"},
+		"error_storage_24": {typ: "le:s24/24>>0", wantErr: "unsupported IIO channel type 'le:s24/24>>0'"},
+		"error_storage_48": {typ: "be:u40/48>>0", wantErr: "unsupported IIO channel type 'be:u40/48>>0'"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// act
+			got, err := ParseIIOChannelType(tc.typ)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestIIOScanLayout(t *testing.T) {
+	tests := map[string]struct {
+		types       []string
+		wantOffsets []int
+		wantSize    int
+	}{
+		"aligned_to_storage": {
+			types:       []string{"le:u8/8>>0", "le:s16/16>>0", "le:s64/64>>0"},
+			wantOffsets: []int{0, 2, 8},
+			wantSize:    16,
+		},
+		"aligned_to_repeat": {
+			types:       []string{"le:u8/8>>0", "le:s16/16X2>>0", "le:u8/8>>0"},
+			wantOffsets: []int{0, 4, 8},
+			wantSize:    12,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			var channels []IIOChannel
+			for _, typ := range tc.types {
+				ct, err := ParseIIOChannelType(typ)
+				require.NoError(t, err)
+				channels = append(channels, IIOChannel{Type: ct})
+			}
+			// act
+			offsets, size := iioScanLayout(channels)
+			// assert
+			assert.Equal(t, tc.wantOffsets, offsets)
+			assert.Equal(t, tc.wantSize, size)
+		})
+	}
+}
+
+func TestIIOChannelTypeDecodeAll(t *testing.T) {
+	tests := map[string]struct {
+		typ  string
+		data []byte
+		want []int64
+	}{
+		"without_repeat": {typ: "le:s12/16>>4", data: []byte{0x00, 0x80, 0xFF, 0xFF}, want: []int64{-2048}},
+		"le_s16_repeat": {
+			typ:  "le:s16/16X3>>0",
+			data: []byte{0xFE, 0xFF, 0x01, 0x00, 0x00, 0x80},
+			want: []int64{-2, 1, -32768},
+		},
+		"be_u10_repeat": {typ: "be:u10/16X2>>0", data: []byte{0x03, 0xFF, 0x00, 0x01}, want: []int64{1023, 1}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			typ, err := ParseIIOChannelType(tc.typ)
+			require.NoError(t, err)
+			// act & assert
+			assert.Equal(t, tc.want, typ.DecodeAll(tc.data))
+		})
+	}
+}
+
+func TestIIOChannelTypeDecode(t *testing.T) {
+	tests := map[string]struct {
+		typ  string
+		data []byte
+		want int64
+	}{
+		"le_s12_negative":  {typ: "le:s12/16>>4", data: []byte{0x00, 0x80}, want: -2048},
+		"be_u12":           {typ: "be:u12/16>>0", data: []byte{0xFF, 0xFF}, want: 4095},
+		"le_s24_in_32":     {typ: "le:s24/32>>8", data: []byte{0x00, 0xFE, 0xFF, 0xFF}, want: -2},
+		"be_s16":           {typ: "be:s16/16>>0", data: []byte{0xFF, 0xFE}, want: -2},
+		"u8":               {typ: "le:u8/8>>0", data: []byte{0xAB}, want: 0xAB},
+		"le_s64_timestamp": {typ: "le:s64/64>>0", data: []byte{1, 0, 0, 0, 0, 0, 0, 0x80}, want: -9223372036854775807},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			typ, err := ParseIIOChannelType(tc.typ)
+			require.NoError(t, err)
+			// act & assert
+			assert.Equal(t, tc.want, typ.Decode(tc.data))
+		})
+	}
+}
@@ -0,0 +1,316 @@
+package system
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/hashicorp/go-multierror"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// From /usr/include/linux/input-event-codes.h:
+	// event types
+	EV_SYN = 0x00
+	EV_KEY = 0x01
+	EV_REL = 0x02
+	EV_ABS = 0x03
+	EV_MSC = 0x04
+	EV_MAX = 0x1f
+	// some key codes, the complete list is found in "input-event-codes.h"
+	KEY_MAX      = 0x2ff
+	BTN_MISC     = 0x100
+	BTN_JOYSTICK = 0x120
+	// some absolute axes, the complete list is found in "input-event-codes.h"
+	ABS_X   = 0x00
+	ABS_Y   = 0x01
+	ABS_MAX = 0x3f
+	// some relative axes, the complete list is found in "input-event-codes.h"
+	REL_X   = 0x00
+	REL_Y   = 0x01
+	REL_MAX = 0x0f
+)
+
+const (
+	inputEventDevicesPath   = "/dev/input"
+	inputEventDevicePattern = "^event[0-9]+$"
+	inputEventNameMaxLen    = 256
+)
+
+// From /usr/include/linux/input.h:
+// ioctl signals, see also _IOC() in /usr/include/asm-generic/ioctl.h
+var (
+	EVIOCGID   = inputIoc(iocRead, 0x02, unsafe.Sizeof(InputID{}))
+	EVIOCGRAB  = inputIoc(iocWrite, 0x90, unsafe.Sizeof(int32(0)))
+	EVIOCGNAME = inputIoc(iocRead, 0x06, inputEventNameMaxLen)
+)
+
+const (
+	iocWrite = 1
+	iocRead  = 2
+)
+
+// EVIOCGABS returns the ioctl signal to get the absolute info of the given axis.
+func EVIOCGABS(axis uint16) uintptr {
+	return inputIoc(iocRead, 0x40+uintptr(axis), unsafe.Sizeof(InputAbsInfo{}))
+}
+
+// EVIOCGBIT returns the ioctl signal to get the bitmask of supported codes of the given event type.
+func EVIOCGBIT(evType uint16, length uintptr) uintptr {
+	return inputIoc(iocRead, 0x20+uintptr(evType), length)
+}
+
+// InputID contains the identification of an input device, see "struct input_id" in /usr/include/linux/input.h
+type InputID struct {
+	Bustype uint16
+	Vendor  uint16
+	Product uint16
+	Version uint16
+}
+
+// InputAbsInfo contains the range and the current value of an absolute axis, see "struct input_absinfo" in
+// /usr/include/linux/input.h
+type InputAbsInfo struct {
+	Value      int32
+	Minimum    int32
+	Maximum    int32
+	Fuzz       int32
+	Flat       int32
+	Resolution int32
+}
+
+// InputEvent is a decoded event of an input device, see "struct input_event" in /usr/include/linux/input.h
+type InputEvent struct {
+	Time  time.Duration // time since epoch, given by the Kernel
+	Type  uint16        // e.g. EV_KEY, EV_ABS, EV_REL
+	Code  uint16        // e.g. the key code for EV_KEY or the axis for EV_ABS
+	Value int32         // e.g. 0=release, 1=press, 2=autorepeat for EV_KEY
+}
+
+// InputDeviceInfo contains the identification of an input device.
+type InputDeviceInfo struct {
+	Path string // e.g. "/dev/input/event3"
+	Name string // e.g. "Sony Interactive Entertainment Wireless Controller"
+	InputID
+}
+
+// inputEventRaw is the binary layout of "struct input_event" for the architecture
+type inputEventRaw struct {
+	Time  unix.Timeval
+	Type  uint16
+	Code  uint16
+	Value int32
+}
+
+// InputEventDevice is the implementation of a Linux input device (evdev), based on the character device
+// "/dev/input/event*", see https://docs.kernel.org/input/input.html
+type InputEventDevice struct {
+	InputDeviceInfo
+	sys     systemCaller
+	file    File
+	grabbed bool
+	mutex   sync.Mutex
+}
+
+// InputEventDevices returns the identification of all input event devices of the system. A device, which can not be
+// read, e.g. because of missing permissions, is skipped. An error is only returned, if no device can be read.
+func (a *Accesser) InputEventDevices() ([]InputDeviceInfo, error) {
+	items, err := a.fs.find(inputEventDevicesPath, inputEventDevicePattern)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(items)
+
+	var errs error
+	var infos []InputDeviceInfo
+	for _, item := range items {
+		info, err := a.inputEventDeviceInfo(item)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		infos = append(infos, info)
+	}
+
+	if len(infos) == 0 && errs != nil {
+		return nil, errs
+	}
+
+	return infos, nil
+}
+
+// NewInputEventDevice opens the input event device given by its path (e.g. "/dev/input/event3"), its name
+// (e.g. "Logitech USB Keyboard") or its vendor and product ID in hex format (e.g. "046d:c31c"). A path is opened
+// directly, without reading the other devices.
+func (a *Accesser) NewInputEventDevice(pathNameOrID string) (*InputEventDevice, error) {
+	if strings.HasPrefix(pathNameOrID, "/") {
+		return a.openInputEventDevice(pathNameOrID)
+	}
+
+	infos, err := a.InputEventDevices()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, info := range infos {
+		vendorProduct := fmt.Sprintf("%04x:%04x", info.Vendor, info.Product)
+		if info.Name == pathNameOrID || vendorProduct == strings.ToLower(pathNameOrID) {
+			return a.openInputEventDevice(info.Path)
+		}
+	}
+
+	return nil, fmt.Errorf("input event device '%s' not found in '%s'", pathNameOrID, inputEventDevicesPath)
+}
+
+// Grab gets exclusive access to the device, so no other application (e.g. the console) receives the events. With
+// false, the exclusive access is released.
+func (d *InputEventDevice) Grab(grab bool) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	var val uint16
+	if grab {
+		val = 1
+	}
+	if err := d.syscallIoctl(EVIOCGRAB, nil, val, "grab"); err != nil {
+		return err
+	}
+	d.grabbed = grab
+	return nil
+}
+
+// AbsInfo reads the range and the current value of the given absolute axis (e.g. ABS_X).
+func (d *InputEventDevice) AbsInfo(axis uint16) (InputAbsInfo, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	var info InputAbsInfo
+	if err := d.syscallIoctl(EVIOCGABS(axis), unsafe.Pointer(&info), 0, "abs info"); err != nil {
+		return InputAbsInfo{}, err
+	}
+	return info, nil
+}
+
+// SupportedCodes returns all codes of the given event type (e.g. EV_KEY, EV_ABS), which are supported by the device.
+// The result is sorted by code.
+func (d *InputEventDevice) SupportedCodes(evType uint16) ([]uint16, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	var bits [(KEY_MAX + 1) / 8]byte
+	if err := d.syscallIoctl(EVIOCGBIT(evType, uintptr(len(bits))), unsafe.Pointer(&bits[0]), 0,
+		"supported codes"); err != nil {
+		return nil, err
+	}
+
+	var codes []uint16
+	for i, b := range bits {
+		for bit := 0; bit < 8; bit++ {
+			if b&(1<<bit) != 0 {
+				codes = append(codes, uint16(i*8+bit)) //nolint:gosec // no overflow possible
+			}
+		}
+	}
+	return codes, nil
+}
+
+// ReadEvent reads the next event from the device. The call blocks until an event is available.
+func (d *InputEventDevice) ReadEvent() (InputEvent, error) {
+	var raw inputEventRaw
+	buf := make([]byte, binary.Size(raw))
+
+	// no mutex is used here, to allow ioctl's while waiting for events
+	n, err := d.file.Read(buf)
+	if err != nil {
+		return InputEvent{}, err
+	}
+	if n != len(buf) {
+		return InputEvent{}, fmt.Errorf("read %d bytes from '%s', expected %d", n, d.Path, len(buf))
+	}
+
+	if err := binary.Read(bytes.NewReader(buf), binary.NativeEndian, &raw); err != nil {
+		return InputEvent{}, err
+	}
+
+	evt := InputEvent{
+		Time:  time.Duration(raw.Time.Nano()),
+		Type:  raw.Type,
+		Code:  raw.Code,
+		Value: raw.Value,
+	}
+	return evt, nil
+}
+
+// Close releases the exclusive access, if any, and closes the character device.
+func (d *InputEventDevice) Close() error {
+	if d.grabbed {
+		if err := d.Grab(false); err != nil {
+			return err
+		}
+	}
+	return d.file.Close()
+}
+
+func (d *InputEventDevice) syscallIoctl(signal uintptr, payload unsafe.Pointer, value uint16, sender string) error {
+	if _, _, errno := d.sys.syscall(Syscall_SYS_IOCTL, d.file, signal, payload, value); errno != 0 {
+		return fmt.Errorf("%s of input device '%s' failed with syscall.Errno %v", sender, d.Path, errno)
+	}
+	return nil
+}
+
+func (a *Accesser) inputEventDeviceInfo(path string) (InputDeviceInfo, error) {
+	f, err := a.fs.openFile(path, os.O_RDONLY, 0)
+	if err != nil {
+		return InputDeviceInfo{}, err
+	}
+	info, err := inputEventReadInfo(a.sys, f, path)
+	_ = f.Close()
+
+	return info, err
+}
+
+func (a *Accesser) openInputEventDevice(path string) (*InputEventDevice, error) {
+	f, err := a.fs.openFile(path, os.O_RDONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := inputEventReadInfo(a.sys, f, path)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	return &InputEventDevice{InputDeviceInfo: info, sys: a.sys, file: f}, nil
+}
+
+func inputEventReadInfo(sys systemCaller, f File, path string) (InputDeviceInfo, error) {
+	info := InputDeviceInfo{Path: path}
+
+	var name [inputEventNameMaxLen]byte
+	if _, _, errno := sys.syscall(Syscall_SYS_IOCTL, f, EVIOCGNAME, unsafe.Pointer(&name[0]), 0); errno != 0 {
+		return info, fmt.Errorf("read name of input device '%s' failed with syscall.Errno %v", path, errno)
+	}
+	if end := bytes.IndexByte(name[:], 0); end >= 0 {
+		info.Name = string(name[:end])
+	} else {
+		info.Name = string(name[:])
+	}
+
+	if _, _, errno := sys.syscall(Syscall_SYS_IOCTL, f, EVIOCGID, unsafe.Pointer(&info.InputID), 0); errno != 0 {
+		return info, fmt.Errorf("read id of input device '%s' failed with syscall.Errno %v", path, errno)
+	}
+
+	return info, nil
+}
+
+func inputIoc(dir, nr, size uintptr) uintptr {
+	return dir<<30 | size<<16 | 'E'<<8 | nr
+}
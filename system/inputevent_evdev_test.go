@@ -0,0 +1,232 @@
+package system
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	inputTestKeyboardPath = "/dev/input/event0"
+	inputTestGamepadPath  = "/dev/input/event3"
+	// a link, which is not listed as event device
+	inputTestGamepadLinkPath = "/dev/input/by-id/usb-Wireless_Controller-event-joystick"
+)
+
+func inputTestSyscallImpl(errSignal uintptr) func(trap, a1, a2 uintptr, a3 unsafe.Pointer) (uintptr, uintptr,
+	SyscallErrno,
+) {
+	//nolint:nonamedreturns // useful here
+	return func(trap, fd, signal uintptr, payload unsafe.Pointer) (r1, r2 uintptr, err SyscallErrno) {
+		if signal == errSignal {
+			return 0, 0, SyscallErrno(Syscall_EINVAL)
+		}
+		switch signal {
+		case EVIOCGNAME:
+			copy(unsafe.Slice((*byte)(payload), inputEventNameMaxLen), "Wireless Controller\x00garbage")
+		case EVIOCGID:
+			*(*InputID)(payload) = InputID{Bustype: 3, Vendor: 0x054c, Product: 0x09cc, Version: 0x8111}
+		case EVIOCGABS(ABS_Y):
+			*(*InputAbsInfo)(payload) = InputAbsInfo{Value: 128, Maximum: 255, Flat: 15}
+		case EVIOCGBIT(EV_ABS, (KEY_MAX+1)/8):
+			bits := unsafe.Slice((*byte)(payload), (KEY_MAX+1)/8)
+			bits[0] = 0x0B // ABS_X, ABS_Y, ABS_RX
+			bits[2] = 0x03 // ABS_HAT0X, ABS_HAT0Y
+		}
+		return 0, 0, 0
+	}
+}
+
+// inputTestFailingDeviceSyscallImpl fails all ioctl calls of the given file, e.g. because of missing permissions
+func inputTestFailingDeviceSyscallImpl(msc *mockSyscall, failing *MockFile) func(trap, a1, a2 uintptr,
+	a3 unsafe.Pointer) (uintptr, uintptr, SyscallErrno,
+) {
+	impl := inputTestSyscallImpl(0)
+	return func(trap, fd, signal uintptr, payload unsafe.Pointer) (uintptr, uintptr, SyscallErrno) {
+		if msc.lastFile == failing {
+			return 0, 0, SyscallErrno(Syscall_EINVAL)
+		}
+		return impl(trap, fd, signal, payload)
+	}
+}
+
+func initTestInputEventAccesser(errSignal uintptr) (*Accesser, *MockFilesystem, *mockSyscall) {
+	a := NewAccesser()
+	a.AddInputEventSupport()
+	fs := a.UseMockFilesystem([]string{
+		inputTestKeyboardPath, inputTestGamepadPath, inputTestGamepadLinkPath, "/dev/input/mice",
+	})
+	msc := a.UseMockSyscall()
+	msc.Impl = inputTestSyscallImpl(errSignal)
+	return a, fs, msc
+}
+
+func TestInputEventDevices(t *testing.T) {
+	// arrange
+	a, fs, _ := initTestInputEventAccesser(0)
+	// act
+	got, err := a.InputEventDevices()
+	// assert
+	require.NoError(t, err)
+	want := InputDeviceInfo{
+		Name:    "Wireless Controller",
+		InputID: InputID{Bustype: 3, Vendor: 0x054c, Product: 0x09cc, Version: 0x8111},
+	}
+	require.Len(t, got, 2)
+	want.Path = inputTestKeyboardPath
+	assert.Equal(t, want, got[0])
+	want.Path = inputTestGamepadPath
+	assert.Equal(t, want, got[1])
+	assert.True(t, fs.Files[inputTestGamepadPath].Closed)
+}
+
+func TestInputEventDevicesError(t *testing.T) {
+	// arrange
+	a, _, _ := initTestInputEventAccesser(EVIOCGID)
+	// act
+	got, err := a.InputEventDevices()
+	// assert
+	require.ErrorContains(t, err, "read id of input device '/dev/input/event0' failed with syscall.Errno invalid argument")
+	require.ErrorContains(t, err, "read id of input device '/dev/input/event3' failed with syscall.Errno invalid argument")
+	assert.Nil(t, got)
+}
+
+func TestInputEventDevicesSkipsFailingDevice(t *testing.T) {
+	// arrange
+	a, fs, msc := initTestInputEventAccesser(0)
+	msc.Impl = inputTestFailingDeviceSyscallImpl(msc, fs.Files[inputTestKeyboardPath])
+	// act
+	got, err := a.InputEventDevices()
+	// assert
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, inputTestGamepadPath, got[0].Path)
+	assert.True(t, fs.Files[inputTestKeyboardPath].Closed)
+}
+
+func TestNewInputEventDevice(t *testing.T) {
+	tests := map[string]struct {
+		pathNameOrID string
+		wantPath     string
+		wantErr      string
+	}{
+		"by_path":       {pathNameOrID: inputTestGamepadPath, wantPath: inputTestGamepadPath},
+		"by_link_path":  {pathNameOrID: inputTestGamepadLinkPath, wantPath: inputTestGamepadLinkPath},
+		"by_name":       {pathNameOrID: "Wireless Controller", wantPath: inputTestKeyboardPath},
+		"by_vendor_id":  {pathNameOrID: "054C:09CC", wantPath: inputTestKeyboardPath},
+		"error_unknown": {pathNameOrID: "046d:c31c", wantErr: "input event device '046d:c31c' not found in '/dev/input'"},
+		"error_path":    {pathNameOrID: "/dev/input/event7", wantErr: "/dev/input/event7: no such file"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a, fs, _ := initTestInputEventAccesser(0)
+			// act
+			d, err := a.NewInputEventDevice(tc.pathNameOrID)
+			// assert
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				assert.Nil(t, d)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantPath, d.Path)
+			assert.True(t, fs.Files[tc.wantPath].Opened)
+		})
+	}
+}
+
+func TestNewInputEventDeviceByPathWithFailingDevice(t *testing.T) {
+	// arrange
+	a, fs, msc := initTestInputEventAccesser(0)
+	msc.Impl = inputTestFailingDeviceSyscallImpl(msc, fs.Files[inputTestKeyboardPath])
+	// act
+	d, err := a.NewInputEventDevice(inputTestGamepadPath)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, inputTestGamepadPath, d.Path)
+	assert.False(t, fs.Files[inputTestKeyboardPath].Opened)
+	// act
+	d, err = a.NewInputEventDevice(inputTestKeyboardPath)
+	// assert
+	require.EqualError(t, err, "read name of input device '/dev/input/event0' failed with syscall.Errno invalid argument")
+	assert.Nil(t, d)
+	assert.True(t, fs.Files[inputTestKeyboardPath].Closed)
+}
+
+func TestInputEventDeviceGrabAndClose(t *testing.T) {
+	// arrange
+	a, fs, msc := initTestInputEventAccesser(0)
+	d, err := a.NewInputEventDevice(inputTestGamepadPath)
+	require.NoError(t, err)
+	// act
+	err = d.Grab(true)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, EVIOCGRAB, msc.lastSignal)
+	assert.Equal(t, uintptr(1), msc.devAddress)
+	assert.True(t, d.grabbed)
+	// act
+	err = d.Close()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, EVIOCGRAB, msc.lastSignal)
+	assert.Equal(t, uintptr(0), msc.devAddress)
+	assert.False(t, d.grabbed)
+	assert.True(t, fs.Files[inputTestGamepadPath].Closed)
+}
+
+func TestInputEventDeviceGrabError(t *testing.T) {
+	// arrange
+	a, _, _ := initTestInputEventAccesser(EVIOCGRAB)
+	d, err := a.NewInputEventDevice(inputTestGamepadPath)
+	require.NoError(t, err)
+	// act
+	err = d.Grab(true)
+	// assert
+	require.EqualError(t, err, "grab of input device '/dev/input/event3' failed with syscall.Errno invalid argument")
+	assert.False(t, d.grabbed)
+}
+
+func TestInputEventDeviceAbsInfoAndSupportedCodes(t *testing.T) {
+	// arrange
+	a, _, _ := initTestInputEventAccesser(0)
+	d, err := a.NewInputEventDevice(inputTestGamepadPath)
+	require.NoError(t, err)
+	// act
+	info, err := d.AbsInfo(ABS_Y)
+	codes, errCodes := d.SupportedCodes(EV_ABS)
+	// assert
+	require.NoError(t, err)
+	require.NoError(t, errCodes)
+	assert.Equal(t, InputAbsInfo{Value: 128, Maximum: 255, Flat: 15}, info)
+	assert.Equal(t, []uint16{0x00, 0x01, 0x03, 0x10, 0x11}, codes)
+}
+
+func TestInputEventDeviceReadEvent(t *testing.T) {
+	// arrange
+	a, fs, _ := initTestInputEventAccesser(0)
+	d, err := a.NewInputEventDevice(inputTestGamepadPath)
+	require.NoError(t, err)
+	raw := inputEventRaw{Time: unix.NsecToTimeval(1500 * int64(time.Millisecond)), Type: EV_ABS, Code: ABS_Y, Value: -7}
+	var buf bytes.Buffer
+	require.NoError(t, binary.Write(&buf, binary.NativeEndian, raw))
+	fs.Files[inputTestGamepadPath].Contents = buf.String()
+	// act
+	got, err := d.ReadEvent()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, InputEvent{Time: 1500 * time.Millisecond, Type: EV_ABS, Code: ABS_Y, Value: -7}, got)
+	// arrange
+	fs.Files[inputTestGamepadPath].Contents = "short"
+	// act
+	_, err = d.ReadEvent()
+	// assert
+	require.ErrorContains(t, err, "read 5 bytes from '/dev/input/event3', expected")
+}
@@ -0,0 +1,109 @@
+package system
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+const ledSysfsPath = "/sys/class/leds"
+
+// LED is the implementation of a Linux LED class device, e.g. an onboard LED of the board or a LED connected to a
+// GPIO and configured by the device tree. See https://docs.kernel.org/leds/leds-class.html
+type LED struct {
+	name      string
+	sysfsPath string
+	fs        filesystem
+	sfa       *sysfsFileAccess
+}
+
+// LEDs returns the names of all LED class devices of the system, e.g. "ACT", "PWR" or "beaglebone:green:usr0".
+func (a *Accesser) LEDs() ([]string, error) {
+	items, err := a.fs.find(ledSysfsPath, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, item := range uniqueSorted(items) {
+		names = append(names, path.Base(item))
+	}
+
+	return names, nil
+}
+
+// NewLED returns a new LED class device with the given name, e.g. "ACT".
+func (a *Accesser) NewLED(name string) (*LED, error) {
+	l := LED{
+		name:      name,
+		sysfsPath: path.Join(ledSysfsPath, name),
+		fs:        a.fs,
+		sfa:       &sysfsFileAccess{fs: a.fs, readBufLen: 1024},
+	}
+
+	if _, err := a.fs.stat(path.Join(l.sysfsPath, "brightness")); err != nil {
+		return nil, fmt.Errorf("LED '%s' not found in '%s': %w", name, ledSysfsPath, err)
+	}
+
+	return &l, nil
+}
+
+// Name returns the name of the LED.
+func (l *LED) Name() string {
+	return l.name
+}
+
+// Brightness reads the current brightness. For some triggers (e.g. "timer") this reads the brightness of the "on"
+// state.
+func (l *LED) Brightness() (int, error) {
+	return l.sfa.readInteger(path.Join(l.sysfsPath, "brightness"))
+}
+
+// MaxBrightness reads the maximum brightness, which is supported by the LED, often this is "1" or "255".
+func (l *LED) MaxBrightness() (int, error) {
+	return l.sfa.readInteger(path.Join(l.sysfsPath, "max_brightness"))
+}
+
+// SetBrightness writes the brightness. Writing "0" switches off the LED and removes the current trigger.
+func (l *LED) SetBrightness(val int) error {
+	return l.sfa.writeInteger(path.Join(l.sysfsPath, "brightness"), val)
+}
+
+// Triggers reads all available triggers and the currently active one. The active trigger is marked by the Kernel
+// with brackets, e.g. "none [mmc0] timer heartbeat". The whole file is read, because the list can be longer than a page
+// on boards with many CPU's and network interfaces.
+func (l *LED) Triggers() ([]string, string, error) {
+	buf, err := l.fs.readFile(path.Join(l.sysfsPath, "trigger"))
+	if err != nil {
+		return nil, "", err
+	}
+
+	var active string
+	triggers := strings.Fields(string(buf))
+	for i, trigger := range triggers {
+		if strings.HasPrefix(trigger, "[") && strings.HasSuffix(trigger, "]") {
+			triggers[i] = strings.Trim(trigger, "[]")
+			active = triggers[i]
+		}
+	}
+
+	return triggers, active, nil
+}
+
+// SetTrigger activates the given trigger, e.g. "heartbeat", "timer" or "none".
+func (l *LED) SetTrigger(trigger string) error {
+	return l.sfa.write(path.Join(l.sysfsPath, "trigger"), []byte(trigger))
+}
+
+// SetTimer activates the "timer" trigger and writes the given on and off times in milliseconds.
+func (l *LED) SetTimer(delayOnMs, delayOffMs int) error {
+	if err := l.SetTrigger("timer"); err != nil {
+		return err
+	}
+
+	if err := l.sfa.writeInteger(path.Join(l.sysfsPath, "delay_on"), delayOnMs); err != nil {
+		return err
+	}
+
+	return l.sfa.writeInteger(path.Join(l.sysfsPath, "delay_off"), delayOffMs)
+}
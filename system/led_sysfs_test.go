@@ -0,0 +1,124 @@
+package system
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ledTestPath = "/sys/class/leds/ACT"
+
+func initTestLEDWithMockedFilesystem() (*LED, *MockFilesystem) {
+	a := NewAccesser()
+	a.AddLEDSupport()
+	fs := a.UseMockFilesystem([]string{
+		ledTestPath + "/brightness",
+		ledTestPath + "/max_brightness",
+		ledTestPath + "/trigger",
+		ledTestPath + "/delay_on",
+		ledTestPath + "/delay_off",
+		"/sys/class/leds/PWR/brightness",
+	})
+	fs.Files[ledTestPath+"/brightness"].Contents = "0\n"
+	fs.Files[ledTestPath+"/max_brightness"].Contents = "255\n"
+	fs.Files[ledTestPath+"/trigger"].Contents = "none timer heartbeat [mmc0] default-on\n"
+	l, err := a.NewLED("ACT")
+	if err != nil {
+		panic(err)
+	}
+	return l, fs
+}
+
+func TestLEDs(t *testing.T) {
+	// arrange
+	a := NewAccesser()
+	a.AddLEDSupport()
+	_ = a.UseMockFilesystem([]string{ledTestPath + "/brightness", ledTestPath + "/trigger", "/sys/class/leds/PWR/trigger"})
+	// act
+	got, err := a.LEDs()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ACT", "PWR"}, got)
+}
+
+func TestNewLED(t *testing.T) {
+	// arrange
+	a := NewAccesser()
+	a.AddLEDSupport()
+	_ = a.UseMockFilesystem([]string{ledTestPath + "/brightness"})
+	// act
+	l, err := a.NewLED("ACT")
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "ACT", l.Name())
+	assert.Equal(t, ledTestPath, l.sysfsPath)
+	// act
+	l, err = a.NewLED("usr0")
+	// assert
+	require.ErrorContains(t, err, "LED 'usr0' not found in '/sys/class/leds'")
+	assert.Nil(t, l)
+}
+
+func TestLEDBrightness(t *testing.T) {
+	// arrange
+	l, fs := initTestLEDWithMockedFilesystem()
+	// act
+	maxVal, errMax := l.MaxBrightness()
+	errSet := l.SetBrightness(128)
+	got, err := l.Brightness()
+	// assert
+	require.NoError(t, errMax)
+	require.NoError(t, errSet)
+	require.NoError(t, err)
+	assert.Equal(t, 255, maxVal)
+	assert.Equal(t, 128, got)
+	assert.Equal(t, "128", fs.Files[ledTestPath+"/brightness"].Contents)
+}
+
+func TestLEDTriggers(t *testing.T) {
+	// arrange
+	l, fs := initTestLEDWithMockedFilesystem()
+	// act
+	triggers, active, err := l.Triggers()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"none", "timer", "heartbeat", "mmc0", "default-on"}, triggers)
+	assert.Equal(t, "mmc0", active)
+	// act
+	err = l.SetTrigger("heartbeat")
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "heartbeat", fs.Files[ledTestPath+"/trigger"].Contents)
+}
+
+func TestLEDTriggersLongList(t *testing.T) {
+	// arrange
+	l, fs := initTestLEDWithMockedFilesystem()
+	var want []string
+	for i := 0; i < 300; i++ {
+		want = append(want, fmt.Sprintf("phy%dtx", i))
+	}
+	want = append(want, "heartbeat")
+	fs.Files[ledTestPath+"/trigger"].Contents = strings.Join(want[:300], " ") + " [heartbeat]"
+	// act
+	triggers, active, err := l.Triggers()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, want, triggers)
+	assert.Equal(t, "heartbeat", active)
+}
+
+func TestLEDSetTimer(t *testing.T) {
+	// arrange
+	l, fs := initTestLEDWithMockedFilesystem()
+	// act
+	err := l.SetTimer(100, 900)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, "timer", fs.Files[ledTestPath+"/trigger"].Contents)
+	assert.Equal(t, "100", fs.Files[ledTestPath+"/delay_on"].Contents)
+	assert.Equal(t, "900", fs.Files[ledTestPath+"/delay_off"].Contents)
+}
@@ -0,0 +1,136 @@
+package system
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// OwnershipKind is the kind of a resource, which can be owned by a device.
+type OwnershipKind string
+
+const (
+	OwnershipKindPin        OwnershipKind = "pin"  // a pin of the board header, given by the platform specific id
+	OwnershipKindDigitalPin OwnershipKind = "gpio" // a line of a gpiochip, e.g. "gpiochip0:17"
+	OwnershipKindPWMPin     OwnershipKind = "pwm"  // a channel of a pwmchip, e.g. "/sys/class/pwm/pwmchip0/pwm1"
+	OwnershipKindI2c        OwnershipKind = "i2c"  // an address on a bus, e.g. "/dev/i2c-1:0x40"
+	OwnershipKindSpi        OwnershipKind = "spi"  // a chip select on a bus, e.g. "spi0.1"
+)
+
+// OwnershipResource describes a resource, which can be owned by a device.
+type OwnershipResource struct {
+	Kind     OwnershipKind
+	Resource string
+}
+
+// Ownership is an entry of the ownership table.
+type Ownership struct {
+	OwnershipResource
+	Owner string
+}
+
+// OwnershipRegistry tracks which device owns a resource, to detect the usage of the same resource by different
+// devices. The registry is shared by all adaptors, which uses the same system accesser.
+type OwnershipRegistry struct {
+	owners map[OwnershipResource]string
+	mutex  sync.Mutex
+}
+
+func newOwnershipRegistry() *OwnershipRegistry {
+	return &OwnershipRegistry{owners: make(map[OwnershipResource]string)}
+}
+
+func (r OwnershipResource) String() string {
+	return fmt.Sprintf("%s '%s'", r.Kind, r.Resource)
+}
+
+// Claim registers all given resources for the given owner. Claiming an already owned resource again by the same owner
+// is allowed. If any of the resources is owned by another device, nothing is registered and an error is returned.
+func (r *OwnershipRegistry) Claim(owner string, resources ...OwnershipResource) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, res := range resources {
+		if current, ok := r.owners[res]; ok && current != owner {
+			return fmt.Errorf("'%s' can not claim %s, because it is already owned by '%s'", owner, res, current)
+		}
+	}
+
+	for _, res := range resources {
+		r.owners[res] = owner
+	}
+
+	return nil
+}
+
+// Release removes all resources of the given kinds, which are owned by the given owner. Without a given kind all
+// resources of the owner are removed.
+func (r *OwnershipRegistry) Release(owner string, kinds ...OwnershipKind) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for res, current := range r.owners {
+		if current != owner {
+			continue
+		}
+		if len(kinds) > 0 && !containsOwnershipKind(kinds, res.Kind) {
+			continue
+		}
+		delete(r.owners, res)
+	}
+}
+
+// Owner returns the owner of the given resource, if any.
+func (r *OwnershipRegistry) Owner(kind OwnershipKind, resource string) (string, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	owner, ok := r.owners[OwnershipResource{Kind: kind, Resource: resource}]
+	return owner, ok
+}
+
+// HasResourcePrefix returns true, if at least one resource of the given kind starts with the given prefix, e.g. to
+// check whether any address of an I2C bus is still in use.
+func (r *OwnershipRegistry) HasResourcePrefix(kind OwnershipKind, prefix string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for res := range r.owners {
+		if res.Kind == kind && strings.HasPrefix(res.Resource, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Table returns all registered ownerships, sorted by kind and resource.
+func (r *OwnershipRegistry) Table() []Ownership {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	table := make([]Ownership, 0, len(r.owners))
+	for res, owner := range r.owners {
+		table = append(table, Ownership{OwnershipResource: res, Owner: owner})
+	}
+
+	sort.Slice(table, func(i, j int) bool {
+		if table[i].Kind != table[j].Kind {
+			return table[i].Kind < table[j].Kind
+		}
+		return table[i].Resource < table[j].Resource
+	})
+
+	return table
+}
+
+func containsOwnershipKind(kinds []OwnershipKind, kind OwnershipKind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,93 @@
+package system
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOwnershipRegistryClaim(t *testing.T) {
+	pin3 := OwnershipResource{Kind: OwnershipKindPin, Resource: "3"}
+	pin5 := OwnershipResource{Kind: OwnershipKindPin, Resource: "5"}
+	tests := map[string]struct {
+		owner     string
+		resources []OwnershipResource
+		wantErr   string
+		wantTable []Ownership
+	}{
+		"claim_new": {
+			owner:     "LED",
+			resources: []OwnershipResource{pin5},
+			wantTable: []Ownership{
+				{OwnershipResource: pin3, Owner: "Button"},
+				{OwnershipResource: pin5, Owner: "LED"},
+			},
+		},
+		"claim_again_by_same_owner": {
+			owner:     "Button",
+			resources: []OwnershipResource{pin3},
+			wantTable: []Ownership{{OwnershipResource: pin3, Owner: "Button"}},
+		},
+		"error_owned_by_other": {
+			owner:     "LED",
+			resources: []OwnershipResource{pin5, pin3},
+			wantErr:   "'LED' can not claim pin '3', because it is already owned by 'Button'",
+			wantTable: []Ownership{{OwnershipResource: pin3, Owner: "Button"}},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			r := newOwnershipRegistry()
+			require.NoError(t, r.Claim("Button", pin3))
+			// act
+			err := r.Claim(tc.owner, tc.resources...)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantTable, r.Table())
+		})
+	}
+}
+
+func TestOwnershipRegistryRelease(t *testing.T) {
+	// arrange
+	r := newOwnershipRegistry()
+	require.NoError(t, r.Claim("LED",
+		OwnershipResource{Kind: OwnershipKindPin, Resource: "7"},
+		OwnershipResource{Kind: OwnershipKindDigitalPin, Resource: "gpiochip0:4"}))
+	require.NoError(t, r.Claim("BMP280", OwnershipResource{Kind: OwnershipKindI2c, Resource: "/dev/i2c-1:0x77"}))
+	// act & assert: only the given kind is released
+	r.Release("LED", OwnershipKindDigitalPin)
+	owner, ok := r.Owner(OwnershipKindPin, "7")
+	assert.True(t, ok)
+	assert.Equal(t, "LED", owner)
+	_, ok = r.Owner(OwnershipKindDigitalPin, "gpiochip0:4")
+	assert.False(t, ok)
+	// act & assert: all kinds are released
+	r.Release("LED")
+	_, ok = r.Owner(OwnershipKindPin, "7")
+	assert.False(t, ok)
+	assert.True(t, r.HasResourcePrefix(OwnershipKindI2c, "/dev/i2c-1:"))
+	assert.False(t, r.HasResourcePrefix(OwnershipKindI2c, "/dev/i2c-0:"))
+	assert.Len(t, r.Table(), 1)
+}
+
+func TestOwnershipRegistryTableSorted(t *testing.T) {
+	// arrange
+	r := newOwnershipRegistry()
+	require.NoError(t, r.Claim("b", OwnershipResource{Kind: OwnershipKindSpi, Resource: "spi0.0"}))
+	require.NoError(t, r.Claim("a", OwnershipResource{Kind: OwnershipKindPin, Resource: "5"}))
+	require.NoError(t, r.Claim("c", OwnershipResource{Kind: OwnershipKindPin, Resource: "3"}))
+	// act
+	got := r.Table()
+	// assert
+	require.Len(t, got, 3)
+	assert.Equal(t, "3", got[0].Resource)
+	assert.Equal(t, "5", got[1].Resource)
+	assert.Equal(t, OwnershipKindSpi, got[2].Kind)
+}
@@ -0,0 +1,318 @@
+package system
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// The jitter budget of the software PWM:
+//
+// All software PWM pins of an accesser are serviced by a single goroutine. This goroutine sleeps by a timer until the
+// next edge is due, so no CPU time is consumed between the edges. On an idle Linux system the timer fires typically
+// less than 100 us late. Each edge which is due at the same time adds the duration of the write of the digital pin
+// (about 5 us for cdev, about 20 us for sysfs). Under high load the Go runtime and the Kernel scheduler can cause
+// outliers of some milliseconds. This is suitable for LED brightness and hobby servos
+// (period 20 ms, duty 0.5..2.5 ms, 1° is about 11 us), but not for e.g. motor drivers with high frequencies. Therefore
+// the period is limited to a minimum of 1 ms (1 kHz).
+const (
+	pwmSoftPeriodMinimum  = 1000000 // 1 ms = 1 kHz
+	pwmSoftPinErrorPrefix = "software PWM"
+)
+
+// pwmSoftScheduler services all enabled software PWM pins by one goroutine.
+type pwmSoftScheduler struct {
+	mutex   sync.Mutex
+	pins    map[*pwmPinSoft]struct{}
+	running bool
+	wakeup  chan struct{}
+	now     func() time.Time // to allow unit testing
+}
+
+// pwmPinSoft is the software implementation of a PWM pin, based on a digital pin.
+type pwmPinSoft struct {
+	scheduler  *pwmSoftScheduler
+	pin        gobot.DigitalPinner
+	enabled    bool
+	normal     bool
+	period     uint32
+	duty       uint32
+	cycleStart time.Time
+	nextEdge   time.Time
+	active     bool
+	written    bool
+	err        error
+}
+
+func newPWMSoftScheduler() *pwmSoftScheduler {
+	return &pwmSoftScheduler{
+		pins:   make(map[*pwmPinSoft]struct{}),
+		wakeup: make(chan struct{}, 1),
+		now:    time.Now,
+	}
+}
+
+// newPWMPinSoft returns a new software PWM pin, based on the given digital pin. The pin is serviced by the given
+// scheduler, when enabled.
+func newPWMPinSoft(scheduler *pwmSoftScheduler, pin gobot.DigitalPinner) *pwmPinSoft {
+	return &pwmPinSoft{scheduler: scheduler, pin: pin, normal: true}
+}
+
+// Export configures the digital pin as output with inactive level. The digital pin itself needs to be exported before
+// by its owner, e.g. the digital pins adaptor.
+func (p *pwmPinSoft) Export() error {
+	if err := p.pin.ApplyOptions(WithPinDirectionOutput(p.inactiveLevel())); err != nil {
+		return fmt.Errorf("%s Export() failed with %v", pwmSoftPinErrorPrefix, err)
+	}
+	return nil
+}
+
+// Unexport stops the servicing of the pin. The digital pin is not released, this needs to be done by its owner.
+func (p *pwmPinSoft) Unexport() error {
+	p.scheduler.remove(p)
+	return nil
+}
+
+// Enabled returns the enabled state of the pin
+func (p *pwmPinSoft) Enabled() (bool, error) {
+	p.scheduler.mutex.Lock()
+	defer p.scheduler.mutex.Unlock()
+
+	return p.enabled, nil
+}
+
+// SetEnabled starts or stops the servicing of the pin. On stop the inactive level is written. The period needs to be
+// set before enabling.
+func (p *pwmPinSoft) SetEnabled(enable bool) error {
+	if !enable {
+		p.scheduler.remove(p)
+		p.scheduler.mutex.Lock()
+		defer p.scheduler.mutex.Unlock()
+
+		return p.takeError(p.pin.Write(p.inactiveLevel()))
+	}
+
+	p.scheduler.mutex.Lock()
+	if p.period == 0 {
+		p.scheduler.mutex.Unlock()
+		return fmt.Errorf("%s SetEnabled(true) failed, the period needs to be set before", pwmSoftPinErrorPrefix)
+	}
+	err := p.takeError(nil)
+	p.scheduler.mutex.Unlock()
+
+	p.scheduler.add(p)
+	return err
+}
+
+// Polarity returns true if the polarity is normal, otherwise false
+func (p *pwmPinSoft) Polarity() (bool, error) {
+	p.scheduler.mutex.Lock()
+	defer p.scheduler.mutex.Unlock()
+
+	return p.normal, nil
+}
+
+// SetPolarity sets the polarity to normal if called with true and to inverted if called with false
+func (p *pwmPinSoft) SetPolarity(normal bool) error {
+	p.scheduler.mutex.Lock()
+	defer p.scheduler.mutex.Unlock()
+
+	if p.enabled {
+		return fmt.Errorf("%s SetPolarity() failed, cannot set polarity when enabled", pwmSoftPinErrorPrefix)
+	}
+	p.normal = normal
+	return nil
+}
+
+// Period returns the current period in nanoseconds
+func (p *pwmPinSoft) Period() (uint32, error) {
+	p.scheduler.mutex.Lock()
+	defer p.scheduler.mutex.Unlock()
+
+	return p.period, nil
+}
+
+// SetPeriod sets the period in nanoseconds, which needs to be at least 1 ms and not smaller than the duty cycle. The
+// change takes effect with the next cycle.
+func (p *pwmPinSoft) SetPeriod(period uint32) error {
+	p.scheduler.mutex.Lock()
+	defer p.scheduler.mutex.Unlock()
+
+	if period < pwmSoftPeriodMinimum {
+		return fmt.Errorf("%s SetPeriod(%d) failed, the minimum period is %d", pwmSoftPinErrorPrefix, period,
+			pwmSoftPeriodMinimum)
+	}
+	if period < p.duty {
+		return fmt.Errorf("%s SetPeriod(%d) failed, the period must not be smaller than the duty cycle (%d)",
+			pwmSoftPinErrorPrefix, period, p.duty)
+	}
+	p.period = period
+	return nil
+}
+
+// DutyCycle returns the duty cycle in nanoseconds
+func (p *pwmPinSoft) DutyCycle() (uint32, error) {
+	p.scheduler.mutex.Lock()
+	defer p.scheduler.mutex.Unlock()
+
+	return p.duty, nil
+}
+
+// SetDutyCycle sets the duty cycle in nanoseconds, which must not exceed the period. The change takes effect within
+// the current cycle. An error of a previous write to the digital pin is returned here.
+func (p *pwmPinSoft) SetDutyCycle(duty uint32) error {
+	p.scheduler.mutex.Lock()
+	defer p.scheduler.mutex.Unlock()
+
+	if duty > p.period {
+		return fmt.Errorf("%s SetDutyCycle(%d) failed, the duty cycle must not exceed the period (%d)",
+			pwmSoftPinErrorPrefix, duty, p.period)
+	}
+	p.duty = duty
+	if p.enabled {
+		// re-evaluate the current cycle immediately
+		p.nextEdge = p.scheduler.now()
+		p.scheduler.wake()
+	}
+	return p.takeError(nil)
+}
+
+func (p *pwmPinSoft) activeLevel() int {
+	if p.normal {
+		return 1
+	}
+	return 0
+}
+
+func (p *pwmPinSoft) inactiveLevel() int {
+	return 1 - p.activeLevel()
+}
+
+// takeError returns the given error or the stored error of the servicing and resets the stored error
+func (p *pwmPinSoft) takeError(err error) error {
+	if err == nil {
+		err = p.err
+	}
+	p.err = nil
+	if err != nil {
+		return fmt.Errorf("%s failed with %v", pwmSoftPinErrorPrefix, err)
+	}
+	return nil
+}
+
+// service writes the level of the pin according to the given time and calculates the time of the next edge. Must be
+// called with locked mutex of the scheduler.
+func (p *pwmPinSoft) service(now time.Time) {
+	period := time.Duration(p.period)
+	if !now.Before(p.cycleStart.Add(period)) {
+		p.cycleStart = p.cycleStart.Add(period)
+		// prevent a burst of cycles, e.g. after a delay by the system
+		if !now.Before(p.cycleStart.Add(period)) {
+			p.cycleStart = now
+		}
+	}
+
+	duty := time.Duration(p.duty)
+	active := now.Sub(p.cycleStart) < duty
+	if !p.written || active != p.active {
+		level := p.inactiveLevel()
+		if active {
+			level = p.activeLevel()
+		}
+		if err := p.pin.Write(level); err != nil {
+			p.err = err
+		}
+		p.active = active
+		p.written = true
+	}
+
+	if active {
+		p.nextEdge = p.cycleStart.Add(duty)
+	} else {
+		p.nextEdge = p.cycleStart.Add(period)
+	}
+}
+
+// add starts the servicing of the pin and the goroutine of the scheduler, if not already running
+func (s *pwmSoftScheduler) add(p *pwmPinSoft) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	p.enabled = true
+	p.cycleStart = now
+	p.nextEdge = now
+	p.written = false
+	s.pins[p] = struct{}{}
+
+	if !s.running {
+		s.running = true
+		go s.run()
+	}
+	s.wake()
+}
+
+// remove stops the servicing of the pin, the goroutine of the scheduler ends, if no pin is left
+func (s *pwmSoftScheduler) remove(p *pwmPinSoft) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	p.enabled = false
+	delete(s.pins, p)
+	s.wake()
+}
+
+func (s *pwmSoftScheduler) wake() {
+	select {
+	case s.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+// serviceDue services all pins with due edges and returns the time of the next edge of all pins. Must be called with
+// locked mutex.
+func (s *pwmSoftScheduler) serviceDue(now time.Time) time.Time {
+	var next time.Time
+	for p := range s.pins {
+		if !p.nextEdge.After(now) {
+			p.service(now)
+		}
+		if next.IsZero() || p.nextEdge.Before(next) {
+			next = p.nextEdge
+		}
+	}
+	return next
+}
+
+func (s *pwmSoftScheduler) run() {
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+
+	for {
+		s.mutex.Lock()
+		if len(s.pins) == 0 {
+			s.running = false
+			s.mutex.Unlock()
+			return
+		}
+		next := s.serviceDue(s.now())
+		s.mutex.Unlock()
+
+		// sleep until the next edge, but wake up on changes
+		if wait := next.Sub(s.now()); wait > 0 {
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(wait)
+			select {
+			case <-timer.C:
+			case <-s.wakeup:
+			}
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package system
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+var _ gobot.PWMPinner = (*pwmPinSoft)(nil)
+
+func initTestPWMPinSoft() (*pwmPinSoft, *digitalPinMock) {
+	dpm := &digitalPinMock{readIdx: -1}
+	return newPWMPinSoft(newPWMSoftScheduler(), dpm), dpm
+}
+
+func TestPWMPinSoftExport(t *testing.T) {
+	// arrange
+	p, dpm := initTestPWMPinSoft()
+	// act
+	err := p.Export()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, dpm.appliedOptions)
+	// arrange
+	dpm.simulateErrors.applyOption = true
+	// act
+	err = p.Export()
+	// assert
+	require.EqualError(t, err, "software PWM Export() failed with applyOption error")
+}
+
+func TestPWMPinSoftSettersAndGetters(t *testing.T) {
+	// arrange
+	p, dpm := initTestPWMPinSoft()
+	// act & assert
+	require.EqualError(t, p.SetEnabled(true), "software PWM SetEnabled(true) failed, the period needs to be set before")
+	require.EqualError(t, p.SetPeriod(999999), "software PWM SetPeriod(999999) failed, the minimum period is 1000000")
+	require.NoError(t, p.SetPeriod(20000000))
+	require.EqualError(t, p.SetDutyCycle(20000001),
+		"software PWM SetDutyCycle(20000001) failed, the duty cycle must not exceed the period (20000000)")
+	require.NoError(t, p.SetDutyCycle(1500000))
+	require.EqualError(t, p.SetPeriod(1000000),
+		"software PWM SetPeriod(1000000) failed, the period must not be smaller than the duty cycle (1500000)")
+	require.NoError(t, p.SetPolarity(false))
+	period, _ := p.Period()
+	assert.Equal(t, uint32(20000000), period)
+	duty, _ := p.DutyCycle()
+	assert.Equal(t, uint32(1500000), duty)
+	normal, _ := p.Polarity()
+	assert.False(t, normal)
+	enabled, _ := p.Enabled()
+	assert.False(t, enabled)
+	// act: disable writes the inactive level of the inverted polarity
+	require.NoError(t, p.SetEnabled(false))
+	assert.Equal(t, []int{1}, dpm.written)
+	// act: polarity can not be changed when enabled
+	p.enabled = true
+	require.EqualError(t, p.SetPolarity(true), "software PWM SetPolarity() failed, cannot set polarity when enabled")
+}
+
+func TestPWMPinSoftService(t *testing.T) {
+	const ms = time.Millisecond
+	tests := map[string]struct {
+		duty        uint32
+		normal      bool
+		steps       []time.Duration
+		wantWritten []int
+		wantEdges   []time.Duration
+	}{
+		"duty_25_percent": {
+			duty:        1000000,
+			normal:      true,
+			steps:       []time.Duration{0, 1 * ms, 4 * ms, 5 * ms},
+			wantWritten: []int{1, 0, 1, 0},
+			wantEdges:   []time.Duration{1 * ms, 4 * ms, 5 * ms, 8 * ms},
+		},
+		"duty_25_percent_inverted": {
+			duty:        1000000,
+			steps:       []time.Duration{0, 1 * ms},
+			wantWritten: []int{0, 1},
+			wantEdges:   []time.Duration{1 * ms, 4 * ms},
+		},
+		"duty_0_percent": {
+			duty:        0,
+			normal:      true,
+			steps:       []time.Duration{0, 4 * ms, 8 * ms},
+			wantWritten: []int{0},
+			wantEdges:   []time.Duration{4 * ms, 8 * ms, 12 * ms},
+		},
+		"duty_100_percent": {
+			duty:        4000000,
+			normal:      true,
+			steps:       []time.Duration{0, 4 * ms, 8 * ms},
+			wantWritten: []int{1},
+			wantEdges:   []time.Duration{4 * ms, 8 * ms, 12 * ms},
+		},
+		"skip_lost_cycles": {
+			duty:        1000000,
+			normal:      true,
+			steps:       []time.Duration{0, 1 * ms, 10*ms + 500*time.Microsecond},
+			wantWritten: []int{1, 0, 1},
+			wantEdges:   []time.Duration{1 * ms, 4 * ms, 11*ms + 500*time.Microsecond},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			p, dpm := initTestPWMPinSoft()
+			p.period = 4000000
+			p.duty = tc.duty
+			p.normal = tc.normal
+			start := time.Now()
+			p.cycleStart = start
+			var gotEdges []time.Duration
+			// act
+			for _, step := range tc.steps {
+				p.service(start.Add(step))
+				gotEdges = append(gotEdges, p.nextEdge.Sub(start))
+			}
+			// assert
+			assert.Equal(t, tc.wantWritten, dpm.written)
+			assert.Equal(t, tc.wantEdges, gotEdges)
+		})
+	}
+}
+
+func TestPWMPinSoftServiceWriteError(t *testing.T) {
+	// arrange
+	p, dpm := initTestPWMPinSoft()
+	require.NoError(t, p.SetPeriod(1000000))
+	dpm.simulateErrors.write = true
+	// act
+	p.service(time.Now())
+	err := p.SetDutyCycle(500000)
+	// assert
+	require.EqualError(t, err, "software PWM failed with write error")
+	require.NoError(t, p.SetDutyCycle(500000))
+}
+
+func TestPWMSoftSchedulerServiceDue(t *testing.T) {
+	// arrange
+	s := newPWMSoftScheduler()
+	dpm1 := &digitalPinMock{}
+	dpm2 := &digitalPinMock{}
+	p1 := newPWMPinSoft(s, dpm1)
+	p2 := newPWMPinSoft(s, dpm2)
+	start := time.Now()
+	p1.period, p1.duty, p1.cycleStart, p1.nextEdge = 4000000, 1000000, start, start
+	p2.period, p2.duty, p2.cycleStart, p2.nextEdge = 4000000, 3000000, start, start.Add(2*time.Millisecond)
+	s.pins[p1] = struct{}{}
+	s.pins[p2] = struct{}{}
+	// act
+	next := s.serviceDue(start)
+	// assert: only the first pin was due
+	assert.Equal(t, []int{1}, dpm1.written)
+	assert.Empty(t, dpm2.written)
+	assert.Equal(t, start.Add(time.Millisecond), next)
+}
+
+func TestPWMSoftSchedulerRun(t *testing.T) {
+	// arrange
+	s := newPWMSoftScheduler()
+	dpm1 := &digitalPinMock{}
+	dpm2 := &digitalPinMock{}
+	p1 := newPWMPinSoft(s, dpm1)
+	p2 := newPWMPinSoft(s, dpm2)
+	for _, p := range []*pwmPinSoft{p1, p2} {
+		require.NoError(t, p.SetPeriod(1000000))
+		require.NoError(t, p.SetDutyCycle(500000))
+	}
+	// act
+	require.NoError(t, p1.SetEnabled(true))
+	require.NoError(t, p2.SetEnabled(true))
+	// the pins are written by the scheduler with locked mutex
+	assert.Eventually(t, func() bool {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		return len(dpm1.written) > 4 && len(dpm2.written) > 4
+	}, 5*time.Second, time.Millisecond)
+	require.NoError(t, p1.SetEnabled(false))
+	require.NoError(t, p2.Unexport())
+	// assert: both pins are toggled by the same goroutine, which stops without pins
+	assert.Greater(t, len(dpm1.written), 4)
+	assert.Greater(t, len(dpm2.written), 4)
+	assert.Equal(t, []int{1, 0, 1, 0}, dpm1.written[:4])
+	assert.Eventually(t, func() bool {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		return !s.running
+	}, time.Second, time.Millisecond)
+}
@@ -0,0 +1,204 @@
+package system
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"github.com/hashicorp/go-multierror"
+
+	"gobot.io/x/gobot/v2"
+)
+
+const spiCdevMaxSegments = 511 // the size of the message is limited by the 14 bit size field of the ioctl signal
+
+// From /usr/include/linux/spi/spidev.h:
+// ioctl signals, see also _IOC() in /usr/include/asm-generic/ioctl.h
+var (
+	SPI_IOC_WR_BITS_PER_WORD = spiIoc(iocWrite, 3, unsafe.Sizeof(uint8(0)))
+	SPI_IOC_WR_MAX_SPEED_HZ  = spiIoc(iocWrite, 4, unsafe.Sizeof(uint32(0)))
+	SPI_IOC_WR_MODE32        = spiIoc(iocWrite, 5, unsafe.Sizeof(uint32(0)))
+)
+
+// From /usr/include/linux/spi/spi.h:
+// mode bits for dual and quad transfers
+const (
+	SPI_TX_DUAL = 0x100
+	SPI_TX_QUAD = 0x200
+	SPI_RX_DUAL = 0x400
+	SPI_RX_QUAD = 0x800
+)
+
+// SPI_IOC_MESSAGE returns the ioctl signal to transfer a message with the given count of segments.
+func SPI_IOC_MESSAGE(n int) uintptr {
+	return spiIoc(iocWrite, 0, uintptr(n)*unsafe.Sizeof(spiIocTransfer{}))
+}
+
+// spiIocTransfer is the "struct spi_ioc_transfer" in /usr/include/linux/spi/spidev.h
+type spiIocTransfer struct {
+	txBuf          uint64
+	rxBuf          uint64
+	length         uint32
+	speedHz        uint32
+	delayUsecs     uint16
+	bitsPerWord    uint8
+	csChange       uint8
+	txNbits        uint8
+	rxNbits        uint8
+	wordDelayUsecs uint8
+	pad            uint8
+}
+
+// spiCdev is the implementation of the SPI interface using the Linux Kernel spidev character device.
+type spiCdev struct {
+	location string
+	sys      systemCaller
+	file     File
+	mode     uint32
+	mutex    sync.Mutex
+}
+
+// newSpiCdev creates and returns a new connection to a specific SPI device on a bus/chip using the character device,
+// e.g. "/dev/spidev0.1".
+func newSpiCdev(sys systemCaller, fs filesystem, busNum, chipNum, mode, bits int, maxSpeed int64) (*spiCdev, error) {
+	c := &spiCdev{
+		location: fmt.Sprintf("/dev/spidev%d.%d", busNum, chipNum),
+		sys:      sys,
+		mode:     uint32(mode), //nolint:gosec // mode is small
+	}
+
+	var err error
+	if c.file, err = fs.openFile(c.location, os.O_RDWR, 0); err != nil {
+		return nil, err
+	}
+
+	bitsPerWord := uint8(bits)  //nolint:gosec // bits is small
+	speedHz := uint32(maxSpeed) //nolint:gosec // speed fits in 32 bit
+	if err := c.syscallIoctl(SPI_IOC_WR_MODE32, unsafe.Pointer(&c.mode), "set mode"); err != nil {
+		return nil, c.closeOnError(err)
+	}
+	if err := c.syscallIoctl(SPI_IOC_WR_BITS_PER_WORD, unsafe.Pointer(&bitsPerWord), "set bits per word"); err != nil {
+		return nil, c.closeOnError(err)
+	}
+	if err := c.syscallIoctl(SPI_IOC_WR_MAX_SPEED_HZ, unsafe.Pointer(&speedHz), "set max speed"); err != nil {
+		return nil, c.closeOnError(err)
+	}
+
+	return c, nil
+}
+
+// TxRx uses the SPI device to send/receive data. Implements gobot.SpiSystemDevicer.
+func (c *spiCdev) TxRx(tx []byte, rx []byte) error {
+	return c.Transfer(gobot.SpiTransferSegment{Tx: tx, Rx: rx})
+}
+
+// Transfer sends/receives all given segments as one message by a single ioctl. Dual and quad transfers are only
+// possible, if supported by the Kernel driver of the SPI controller. Implements gobot.SpiTransferer.
+func (c *spiCdev) Transfer(segments ...gobot.SpiTransferSegment) error {
+	if len(segments) == 0 {
+		return nil
+	}
+	if len(segments) > spiCdevMaxSegments {
+		return fmt.Errorf("count of segments (%d) exceeds the maximum (%d)", len(segments), spiCdevMaxSegments)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	xfers := make([]spiIocTransfer, len(segments))
+	var modeBits uint32
+	for i, s := range segments {
+		length := len(s.Tx)
+		if len(s.Rx) > length {
+			length = len(s.Rx)
+		}
+		if s.Tx != nil && s.Rx != nil && len(s.Tx) != len(s.Rx) {
+			return fmt.Errorf("length of tx (%d) must be the same as length of rx (%d) in segment %d", len(s.Tx),
+				len(s.Rx), i)
+		}
+
+		txModeBits, err := spiNbitsModeBits(s.TxNbits, SPI_TX_DUAL, SPI_TX_QUAD)
+		if err != nil {
+			return fmt.Errorf("tx of segment %d: %w", i, err)
+		}
+		rxModeBits, err := spiNbitsModeBits(s.RxNbits, SPI_RX_DUAL, SPI_RX_QUAD)
+		if err != nil {
+			return fmt.Errorf("rx of segment %d: %w", i, err)
+		}
+		modeBits |= txModeBits | rxModeBits
+
+		xfers[i] = spiIocTransfer{
+			length:      uint32(length), //nolint:gosec // length is limited by the Kernel buffer size
+			speedHz:     s.SpeedHz,
+			delayUsecs:  s.DelayUsecs,
+			bitsPerWord: s.BitsPerWord,
+			txNbits:     s.TxNbits,
+			rxNbits:     s.RxNbits,
+		}
+		if len(s.Tx) > 0 {
+			xfers[i].txBuf = uint64(uintptr(unsafe.Pointer(&s.Tx[0])))
+		}
+		if len(s.Rx) > 0 {
+			xfers[i].rxBuf = uint64(uintptr(unsafe.Pointer(&s.Rx[0])))
+		}
+		if s.CsChange {
+			xfers[i].csChange = 1
+		}
+	}
+
+	// the Kernel rejects dual and quad transfers, if not activated in the mode before
+	if c.mode|modeBits != c.mode {
+		mode := c.mode | modeBits
+		if err := c.syscallIoctl(SPI_IOC_WR_MODE32, unsafe.Pointer(&mode), "set mode for dual/quad transfer"); err != nil {
+			return err
+		}
+		c.mode = mode
+	}
+
+	err := c.syscallIoctl(SPI_IOC_MESSAGE(len(xfers)), unsafe.Pointer(&xfers[0]), "transfer message")
+	// the buffers are referenced only by address in the transfer structures
+	runtime.KeepAlive(segments)
+
+	return err
+}
+
+// Close the SPI connection. Implements gobot.SpiSystemDevicer.
+func (c *spiCdev) Close() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.file.Close()
+}
+
+func (c *spiCdev) syscallIoctl(signal uintptr, payload unsafe.Pointer, sender string) error {
+	if _, _, errno := c.sys.syscall(Syscall_SYS_IOCTL, c.file, signal, payload, 0); errno != 0 {
+		return fmt.Errorf("%s of SPI device '%s' failed with syscall.Errno %v", sender, c.location, errno)
+	}
+	return nil
+}
+
+func (c *spiCdev) closeOnError(err error) error {
+	if e := c.file.Close(); e != nil {
+		err = multierror.Append(err, e)
+	}
+	return err
+}
+
+func spiNbitsModeBits(nbits uint8, dualBit, quadBit uint32) (uint32, error) {
+	switch nbits {
+	case 0, 1:
+		return 0, nil
+	case 2:
+		return dualBit, nil
+	case 4:
+		return quadBit, nil
+	default:
+		return 0, fmt.Errorf("count of data lines (%d) not supported, use 1, 2 or 4", nbits)
+	}
+}
+
+func spiIoc(dir, nr, size uintptr) uintptr {
+	return dir<<30 | size<<16 | 'k'<<8 | nr
+}
@@ -0,0 +1,220 @@
+package system
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+const spiTestCdevPath = "/dev/spidev1.2"
+
+func initTestSpiCdevWithMockedSystem(t *testing.T) (*spiCdev, *MockFilesystem, *mockSyscall) {
+	a := NewAccesser()
+	fs := a.UseMockFilesystem([]string{spiTestCdevPath})
+	msc := a.UseMockSyscall()
+	c, err := newSpiCdev(a.sys, a.fs, 1, 2, 3, 8, 500000)
+	require.NoError(t, err)
+	return c, fs, msc
+}
+
+func Test_newSpiCdev(t *testing.T) {
+	// arrange
+	a := NewAccesser()
+	fs := a.UseMockFilesystem([]string{spiTestCdevPath})
+	msc := a.UseMockSyscall()
+	var signals []uintptr
+	var values []uint32
+	msc.Impl = func(trap, fd, signal uintptr, payload unsafe.Pointer) (uintptr, uintptr, SyscallErrno) {
+		signals = append(signals, signal)
+		if signal == SPI_IOC_WR_BITS_PER_WORD {
+			values = append(values, uint32(*(*uint8)(payload)))
+		} else {
+			values = append(values, *(*uint32)(payload))
+		}
+		return 0, 0, 0
+	}
+	// act
+	c, err := newSpiCdev(a.sys, a.fs, 1, 2, 3, 8, 500000)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, spiTestCdevPath, c.location)
+	assert.True(t, fs.Files[spiTestCdevPath].Opened)
+	assert.Equal(t, []uintptr{SPI_IOC_WR_MODE32, SPI_IOC_WR_BITS_PER_WORD, SPI_IOC_WR_MAX_SPEED_HZ}, signals)
+	assert.Equal(t, []uint32{3, 8, 500000}, values)
+}
+
+func Test_newSpiCdevError(t *testing.T) {
+	tests := map[string]struct {
+		location  string
+		errSignal uintptr
+		wantErr   string
+	}{
+		"error_not_found": {
+			location: "/dev/spidev0.0",
+			wantErr:  "/dev/spidev1.2: no such file",
+		},
+		"error_mode": {
+			location:  spiTestCdevPath,
+			errSignal: SPI_IOC_WR_MODE32,
+			wantErr:   "set mode of SPI device '/dev/spidev1.2' failed with syscall.Errno invalid argument",
+		},
+		"error_speed": {
+			location:  spiTestCdevPath,
+			errSignal: SPI_IOC_WR_MAX_SPEED_HZ,
+			wantErr:   "set max speed of SPI device '/dev/spidev1.2' failed with syscall.Errno invalid argument",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := NewAccesser()
+			fs := a.UseMockFilesystem([]string{tc.location})
+			msc := a.UseMockSyscall()
+			msc.Impl = func(trap, fd, signal uintptr, payload unsafe.Pointer) (uintptr, uintptr, SyscallErrno) {
+				if signal == tc.errSignal {
+					return 0, 0, SyscallErrno(Syscall_EINVAL)
+				}
+				return 0, 0, 0
+			}
+			// act
+			c, err := newSpiCdev(a.sys, a.fs, 1, 2, 0, 8, 1000)
+			// assert
+			require.ErrorContains(t, err, tc.wantErr)
+			assert.Nil(t, c)
+			if tc.errSignal != 0 {
+				assert.True(t, fs.Files[tc.location].Closed)
+			}
+		})
+	}
+}
+
+func TestSpiCdevTransfer(t *testing.T) {
+	// arrange
+	c, _, msc := initTestSpiCdevWithMockedSystem(t)
+	command := []byte{0x42, 0x00}
+	rx := make([]byte, 3)
+	var got []spiIocTransfer
+	msc.Impl = func(trap, fd, signal uintptr, payload unsafe.Pointer) (uintptr, uintptr, SyscallErrno) {
+		if signal == SPI_IOC_MESSAGE(2) {
+			got = append(got, unsafe.Slice((*spiIocTransfer)(payload), 2)...)
+			// simulate the reading
+			if got[1].rxBuf == uint64(uintptr(unsafe.Pointer(&rx[0]))) {
+				copy(rx, []byte{0x12, 0x34, 0x56})
+			}
+		}
+		return 0, 0, 0
+	}
+	// act
+	err := c.Transfer(
+		gobot.SpiTransferSegment{Tx: command, DelayUsecs: 10},
+		gobot.SpiTransferSegment{Rx: rx, SpeedHz: 1000000, BitsPerWord: 16, CsChange: true},
+	)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, SPI_IOC_MESSAGE(2), msc.lastSignal)
+	require.Len(t, got, 2)
+	assert.Equal(t, uint64(uintptr(unsafe.Pointer(&command[0]))), got[0].txBuf)
+	assert.Equal(t, uint64(0), got[0].rxBuf)
+	assert.Equal(t, uint32(2), got[0].length)
+	assert.Equal(t, uint16(10), got[0].delayUsecs)
+	assert.Equal(t, uint8(0), got[0].csChange)
+	assert.Equal(t, uint64(0), got[1].txBuf)
+	assert.Equal(t, uint32(3), got[1].length)
+	assert.Equal(t, uint32(1000000), got[1].speedHz)
+	assert.Equal(t, uint8(16), got[1].bitsPerWord)
+	assert.Equal(t, uint8(1), got[1].csChange)
+	assert.Equal(t, []byte{0x12, 0x34, 0x56}, rx)
+}
+
+func TestSpiCdevTransferDualQuad(t *testing.T) {
+	// arrange
+	c, _, msc := initTestSpiCdevWithMockedSystem(t)
+	var modes []uint32
+	msc.Impl = func(trap, fd, signal uintptr, payload unsafe.Pointer) (uintptr, uintptr, SyscallErrno) {
+		if signal == SPI_IOC_WR_MODE32 {
+			modes = append(modes, *(*uint32)(payload))
+		}
+		return 0, 0, 0
+	}
+	// act
+	err := c.Transfer(gobot.SpiTransferSegment{Tx: []byte{0x6b}},
+		gobot.SpiTransferSegment{Rx: make([]byte, 4), RxNbits: 4})
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{3 | SPI_RX_QUAD}, modes)
+	assert.Equal(t, uint32(3|SPI_RX_QUAD), c.mode)
+	// act, mode is already set
+	err = c.Transfer(gobot.SpiTransferSegment{Rx: make([]byte, 4), RxNbits: 4})
+	// assert
+	require.NoError(t, err)
+	assert.Len(t, modes, 1)
+}
+
+func TestSpiCdevTransferError(t *testing.T) {
+	tests := map[string]struct {
+		segments  []gobot.SpiTransferSegment
+		errSignal uintptr
+		wantErr   string
+	}{
+		"error_length": {
+			segments: []gobot.SpiTransferSegment{{Tx: []byte{1, 2}, Rx: []byte{0}}},
+			wantErr:  "length of tx (2) must be the same as length of rx (1) in segment 0",
+		},
+		"error_nbits": {
+			segments: []gobot.SpiTransferSegment{{Tx: []byte{1}}, {Tx: []byte{1}, TxNbits: 3}},
+			wantErr:  "tx of segment 1: count of data lines (3) not supported, use 1, 2 or 4",
+		},
+		"error_dual_not_supported": {
+			segments:  []gobot.SpiTransferSegment{{Tx: []byte{1}, TxNbits: 2}},
+			errSignal: SPI_IOC_WR_MODE32,
+			wantErr: "set mode for dual/quad transfer of SPI device '/dev/spidev1.2' failed with syscall.Errno " +
+				"invalid argument",
+		},
+		"error_message": {
+			segments:  []gobot.SpiTransferSegment{{Tx: []byte{1}}},
+			errSignal: SPI_IOC_MESSAGE(1),
+			wantErr:   "transfer message of SPI device '/dev/spidev1.2' failed with syscall.Errno invalid argument",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			c, _, msc := initTestSpiCdevWithMockedSystem(t)
+			msc.Impl = func(trap, fd, signal uintptr, payload unsafe.Pointer) (uintptr, uintptr, SyscallErrno) {
+				if signal == tc.errSignal {
+					return 0, 0, SyscallErrno(Syscall_EINVAL)
+				}
+				return 0, 0, 0
+			}
+			// act
+			err := c.Transfer(tc.segments...)
+			// assert
+			require.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestSpiCdevTxRxAndClose(t *testing.T) {
+	// arrange
+	c, fs, msc := initTestSpiCdevWithMockedSystem(t)
+	// act
+	err := c.TxRx([]byte{0x01, 0x02}, make([]byte, 2))
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, SPI_IOC_MESSAGE(1), msc.lastSignal)
+	// act
+	err = c.Close()
+	// assert
+	require.NoError(t, err)
+	assert.True(t, fs.Files[spiTestCdevPath].Closed)
+}
+
+func TestSPI_IOC_MESSAGE(t *testing.T) {
+	// the value of SPI_IOC_MESSAGE(1) is well known from the C headers
+	assert.Equal(t, uintptr(0x40206b00), SPI_IOC_MESSAGE(1))
+	assert.Equal(t, uintptr(32), unsafe.Sizeof(spiIocTransfer{}))
+}
@@ -78,6 +78,65 @@ func (s *spiGpio) TxRx(tx []byte, rx []byte) error {
 	return s.ncsPin.Write(1)
 }
 
+// Transfer sends/receives all given segments as one message, so the device keeps selected between the segments, except
+// "CsChange" is set. The speed can not be changed per segment and only 8 bits per word and single data lines are
+// supported. Implements gobot.SpiTransferer.
+func (s *spiGpio) Transfer(segments ...gobot.SpiTransferSegment) error {
+	for i, seg := range segments {
+		if (seg.BitsPerWord != 0 && seg.BitsPerWord != 8) || seg.TxNbits > 1 || seg.RxNbits > 1 {
+			return fmt.Errorf("bits per word (%d) or dual/quad transfer of segment %d not supported by GPIO access",
+				seg.BitsPerWord, i)
+		}
+		if seg.Tx != nil && seg.Rx != nil && len(seg.Tx) != len(seg.Rx) {
+			return fmt.Errorf("length of tx (%d) must be the same as length of rx (%d) in segment %d", len(seg.Tx),
+				len(seg.Rx), i)
+		}
+	}
+
+	selected := false
+	for i, seg := range segments {
+		if !selected {
+			if err := s.ncsPin.Write(0); err != nil {
+				return err
+			}
+			selected = true
+		}
+
+		length := len(seg.Tx)
+		if len(seg.Rx) > length {
+			length = len(seg.Rx)
+		}
+		for idx := 0; idx < length; idx++ {
+			var txByte byte
+			if seg.Tx != nil {
+				txByte = seg.Tx[idx]
+			}
+			val, err := s.transferByte(txByte)
+			if err != nil {
+				return err
+			}
+			if seg.Rx != nil {
+				seg.Rx[idx] = val
+			}
+		}
+
+		if seg.DelayUsecs > 0 {
+			time.Sleep(time.Duration(seg.DelayUsecs) * time.Microsecond)
+		}
+
+		// like the Kernel, the "CsChange" of the last segment keeps the device selected after the message
+		last := i == len(segments)-1
+		if seg.CsChange != last {
+			if err := s.ncsPin.Write(1); err != nil {
+				return err
+			}
+			selected = false
+		}
+	}
+
+	return nil
+}
+
 // Close the SPI connection. Implements gobot.SpiSystemDevicer.
 func (s *spiGpio) Close() error {
 	var err error
@@ -6,6 +6,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
 )
 
 func Test_newSpiGpio(t *testing.T) {
@@ -59,6 +61,54 @@ func TestSpiGpioTxRx(t *testing.T) {
 	assert.Equal(t, []byte{0x95}, rx)
 }
 
+func TestSpiGpioTransfer(t *testing.T) {
+	// arrange
+	dpa := newMockDigitalPinAccess(nil)
+	cfg := spiGpioConfig{
+		pinProvider: dpa,
+		sclkPinID:   "1",
+		ncsPinID:    "2",
+		sdoPinID:    "3",
+		sdiPinID:    "4",
+	}
+	dpa.UseValues("", cfg.sdiPinID, []int{0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 1, 0, 1, 0, 1})
+	d, err := newSpiGpio(cfg, 10001)
+	require.NoError(t, err)
+	rx := []byte{0x00}
+	// act
+	err = d.Transfer(gobot.SpiTransferSegment{Tx: []byte{0x80}}, gobot.SpiTransferSegment{Rx: rx, DelayUsecs: 1})
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, dpa.Written("", cfg.ncsPinID)) // keeps selected between the segments
+	assert.Equal(t, []int{128, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, dpa.Written("", cfg.sdoPinID))
+	assert.Equal(t, []byte{0x95}, rx)
+}
+
+func TestSpiGpioTransferCsChange(t *testing.T) {
+	// arrange
+	dpa := newMockDigitalPinAccess(nil)
+	cfg := spiGpioConfig{
+		pinProvider: dpa,
+		sclkPinID:   "1",
+		ncsPinID:    "2",
+		sdoPinID:    "3",
+		sdiPinID:    "4",
+	}
+	dpa.UseValues("", cfg.sdiPinID, make([]int, 16))
+	d, err := newSpiGpio(cfg, 10001)
+	require.NoError(t, err)
+	// act
+	err = d.Transfer(gobot.SpiTransferSegment{Tx: []byte{0x01}, CsChange: true},
+		gobot.SpiTransferSegment{Tx: []byte{0x02}})
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 0, 1}, dpa.Written("", cfg.ncsPinID))
+	// act
+	err = d.Transfer(gobot.SpiTransferSegment{Tx: []byte{0x01}, BitsPerWord: 9})
+	// assert
+	require.EqualError(t, err, "bits per word (9) or dual/quad transfer of segment 0 not supported by GPIO access")
+}
+
 func TestSpiGpioClose(t *testing.T) {
 	// arrange
 	dpa := newMockDigitalPinAccess(nil)
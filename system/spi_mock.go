@@ -72,9 +72,15 @@ func (spi *MockSpiAccess) Written() []byte {
 	return spi.sysdev.written
 }
 
+// Segments returns the segments of all calls to Transfer(), the data buffers are not copied.
+func (spi *MockSpiAccess) Segments() []gobot.SpiTransferSegment {
+	return spi.sysdev.segments
+}
+
 // Reset resets the last written values.
 func (spi *MockSpiAccess) Reset() {
 	spi.sysdev.written = []byte{}
+	spi.sysdev.segments = nil
 }
 
 // spiMock is the a mock implementation, used in tests
@@ -85,6 +91,7 @@ type spiMock struct {
 	simCloseErr bool
 	written     []byte
 	simRead     []byte
+	segments    []gobot.SpiTransferSegment
 }
 
 // newSpiMock creates and returns a new connection to a specific
@@ -111,3 +118,19 @@ func (c *spiMock) TxRx(tx []byte, rx []byte) error {
 	copy(rx, c.simRead)
 	return nil
 }
+
+// Transfer sends/receives all given segments as one message, the read data are taken in sequence from the simulated
+// read data. Implements gobot.SpiTransferer.
+func (c *spiMock) Transfer(segments ...gobot.SpiTransferSegment) error {
+	if c.simReadErr {
+		return fmt.Errorf("error while SPI transfer in mock")
+	}
+	c.segments = append(c.segments, segments...)
+	simRead := c.simRead
+	for _, s := range segments {
+		c.written = append(c.written, s.Tx...)
+		n := copy(s.Rx, simRead)
+		simRead = simRead[n:]
+	}
+	return nil
+}
@@ -3,6 +3,8 @@ package system
 import (
 	"fmt"
 
+	"gobot.io/x/gobot/v2"
+
 	"periph.io/x/conn/v3/physic"
 	xspi "periph.io/x/conn/v3/spi"
 	xsysfs "periph.io/x/host/v3/sysfs"
@@ -40,6 +42,27 @@ func (c *spiPeriphIo) TxRx(tx []byte, rx []byte) error {
 	return nil
 }
 
+// Transfer sends/receives all given segments as one message. Only the bits per word and the chip select behavior can
+// be changed per segment with periph.io, so other settings lead to an error. Implements gobot.SpiTransferer.
+func (c *spiPeriphIo) Transfer(segments ...gobot.SpiTransferSegment) error {
+	if len(segments) == 0 {
+		return nil
+	}
+
+	packets := make([]xspi.Packet, len(segments))
+	for i, s := range segments {
+		if s.SpeedHz != 0 || s.DelayUsecs != 0 || s.TxNbits > 1 || s.RxNbits > 1 {
+			return fmt.Errorf("speed, delay and dual/quad transfer of segment %d not supported by periph.io, "+
+				"use the character device access instead", i)
+		}
+		last := i == len(segments)-1
+		// periph.io keeps CS active between packets, except "KeepCS" is false, but inverse for the last packet
+		packets[i] = xspi.Packet{W: s.Tx, R: s.Rx, BitsPerWord: s.BitsPerWord, KeepCS: s.CsChange == last}
+	}
+
+	return c.dev.TxPackets(packets)
+}
+
 // Close the SPI connection. Implements gobot.SpiSystemDevicer.
 func (c *spiPeriphIo) Close() error {
 	return c.port.Close()
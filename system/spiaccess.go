@@ -12,6 +12,11 @@ type gpioSpiAccess struct {
 	cfg spiGpioConfig
 }
 
+type cdevSpiAccess struct {
+	sys systemCaller
+	fs  filesystem
+}
+
 func (psa *periphioSpiAccess) isType(accesserType spiBusAccesserType) bool {
 	return accesserType == spiBusAccesserTypePeriphio
 }
@@ -45,3 +50,22 @@ func (gsa *gpioSpiAccess) createDevice(
 ) (gobot.SpiSystemDevicer, error) {
 	return newSpiGpio(gsa.cfg, maxSpeed)
 }
+
+func (csa *cdevSpiAccess) isType(accesserType spiBusAccesserType) bool {
+	return accesserType == spiBusAccesserTypeCdev
+}
+
+func (csa *cdevSpiAccess) isSupported() bool {
+	devices, err := csa.fs.find("/dev", "spidev")
+	if err != nil || len(devices) == 0 {
+		return false
+	}
+	return true
+}
+
+func (csa *cdevSpiAccess) createDevice(
+	busNum, chipNum, mode, bits int,
+	maxSpeed int64,
+) (gobot.SpiSystemDevicer, error) {
+	return newSpiCdev(csa.sys, csa.fs, busNum, chipNum, mode, bits, maxSpeed)
+}
@@ -24,6 +24,15 @@ func TestPeriphioSpi_isType(t *testing.T) {
 	assert.False(t, psa.isType(spiBusAccesserTypeGPIO))
 }
 
+func TestCdevSpi_isType(t *testing.T) {
+	// arrange
+	csa := cdevSpiAccess{}
+	// act & assert character device
+	assert.True(t, csa.isType(spiBusAccesserTypeCdev))
+	// act & assert Periphio
+	assert.False(t, csa.isType(spiBusAccesserTypePeriphio))
+}
+
 func TestGpioSpi_isSupported(t *testing.T) {
 	// arrange
 	gsa := gpioSpiAccess{}
@@ -59,3 +68,30 @@ func TestPeriphioSpi_isSupported(t *testing.T) {
 		})
 	}
 }
+
+func TestCdevSpi_isSupported(t *testing.T) {
+	tests := map[string]struct {
+		mockPaths []string
+		want      bool
+	}{
+		"supported": {
+			mockPaths: []string{"/dev/spidev0.0"},
+			want:      true,
+		},
+		"not_supported": {
+			mockPaths: []string{"/dev/i2c-1"},
+			want:      false,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			fs := newMockFilesystem(tc.mockPaths)
+			csa := cdevSpiAccess{fs: fs}
+			// act
+			got := csa.isSupported()
+			// assert
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package system
 
 import (
+	"syscall"
 	"unsafe"
 
 	"golang.org/x/sys/unix"
@@ -39,8 +40,8 @@ func (sys *nativeSyscall) syscall(
 	address uint16,
 ) (r1, r2 uintptr, err SyscallErrno) {
 	var errNo unix.Errno
-	if signal == I2C_TARGET {
-		// this is the setup for the address, it just needs to be converted to an uintptr,
+	if signal == I2C_TARGET || signal == EVIOCGRAB {
+		// this is the setup for the address (or a plain value), it just needs to be converted to an uintptr,
 		// the given payload is not used in this case, see the comment on the function
 		r1, r2, errNo = unix.Syscall(trap, f.Fd(), signal, uintptr(address))
 	} else {
@@ -54,3 +55,8 @@ func (sys *nativeSyscall) syscall(
 func (e SyscallErrno) Error() string {
 	return unix.Errno(e).Error()
 }
+
+// Unwrap returns the "syscall.Errno", so the error can be checked by errors.Is() or errors.As().
+func (e SyscallErrno) Unwrap() error {
+	return syscall.Errno(e)
+}
@@ -2,9 +2,14 @@ package system
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strconv"
+	"strings"
 	"unsafe"
 
+	"github.com/hashicorp/go-multierror"
+
 	"gobot.io/x/gobot/v2"
 )
 
@@ -20,6 +25,7 @@ type spiBusAccesserType int
 const (
 	spiBusAccesserTypePeriphio spiBusAccesserType = iota
 	spiBusAccesserTypeGPIO
+	spiBusAccesserTypeCdev
 )
 
 // A File represents basic IO interactions with the underlying file system
@@ -61,9 +67,18 @@ type digitalPinAccesser interface {
 	isType(accesserType digitalPinAccesserType) bool
 	isSupported() bool
 	createPin(chip string, pin int, o ...func(gobot.DigitalPinOptioner) bool) gobot.DigitalPinner
+	createPinGroup(chip string, pins []int, o ...func(gobot.DigitalPinOptioner) bool) gobot.DigitalPinGrouper
 	setFs(fs filesystem)
 }
 
+// gpioInfoAccesser represents unexposed interface to allow the switch between the character device implementation and
+// a mocked one
+type gpioInfoAccesser interface {
+	chips() ([]string, error)
+	chipInfo(chip string) (gobot.GpioChipInfo, error)
+	watchLineInfo(chip string, offsets []int, handler func(gobot.GpioLineInfoEvent)) (io.Closer, error)
+}
+
 // spiAccesser represents unexposed interface to allow the switch between different implementations and a mocked one
 type spiAccesser interface {
 	isType(accesserType spiBusAccesserType) bool
@@ -77,6 +92,8 @@ type accesserConfiguration struct {
 	debugDigitalPin bool
 	useGpioSysfs    *bool
 	spiGpioConfig   *spiGpioConfig
+	useSpiCdev      bool
+	tracer          *Tracer
 }
 
 // Accesser provides access to system calls, filesystem, implementation for digital pin and SPI
@@ -85,7 +102,11 @@ type Accesser struct {
 	sys              systemCaller
 	fs               filesystem
 	digitalPinAccess digitalPinAccesser
+	gpioInfoAccess   gpioInfoAccesser
 	spiAccess        spiAccesser
+	uartAccess       uartAccesser
+	pwmSoftScheduler *pwmSoftScheduler
+	ownerships       *OwnershipRegistry
 }
 
 // NewAccesser returns a accesser to native system call, native file system and the chosen digital pin access.
@@ -93,6 +114,7 @@ type Accesser struct {
 func NewAccesser(options ...AccesserOptionApplier) *Accesser {
 	a := &Accesser{
 		accesserCfg: &accesserConfiguration{},
+		ownerships:  newOwnershipRegistry(),
 	}
 
 	for _, o := range options {
@@ -104,6 +126,11 @@ func NewAccesser(options ...AccesserOptionApplier) *Accesser {
 	return a
 }
 
+// Ownerships returns the registry of all resources owned by devices, which is shared by all users of the accesser.
+func (a *Accesser) Ownerships() *OwnershipRegistry {
+	return a.ownerships
+}
+
 // AddAnalogSupport adds the support to access the analog features of the system, usually by sysfs.
 func (a *Accesser) AddAnalogSupport() {
 	if a.fs == nil {
@@ -111,11 +138,15 @@ func (a *Accesser) AddAnalogSupport() {
 	}
 }
 
-// AddPWMSupport adds the support to access the PWM features of the system, usually by sysfs.
+// AddPWMSupport adds the support to access the PWM features of the system, usually by sysfs. The software PWM on
+// digital pins is added, too.
 func (a *Accesser) AddPWMSupport() {
 	if a.fs == nil {
 		a.fs = &nativeFilesystem{} // for sysfs access
 	}
+	if a.pwmSoftScheduler == nil {
+		a.pwmSoftScheduler = newPWMSoftScheduler()
+	}
 }
 
 // AddDigitalPinSupport adds the support to access the GPIO features of the system. Usually by character device or
@@ -132,6 +163,10 @@ func (a *Accesser) AddDigitalPinSupport(options ...AccesserOptionApplier) {
 		a.fs = &nativeFilesystem{} // for sysfs access or check for /dev/gpiochip* in cdev
 	}
 
+	if a.gpioInfoAccess == nil {
+		a.gpioInfoAccess = &cdevGpioInfoAccess{fs: a.fs}
+	}
+
 	if a.accesserCfg.useGpioSysfs == nil || !*a.accesserCfg.useGpioSysfs {
 		dpa := &cdevDigitalPinAccess{fs: a.fs}
 
@@ -170,6 +205,99 @@ func (a *Accesser) HasDigitalPinCdevAccess() bool {
 	return a.digitalPinAccess != nil && a.digitalPinAccess.isType(digitalPinAccesserTypeCdev)
 }
 
+// GpioChips returns the information of all gpiochips of the system including the information of each line, like the
+// name, the consumer (requester), direction, drive, bias and active-low state. This is similar to "gpioinfo". A chip,
+// which can not be read, e.g. because of missing permissions, is skipped. An error is only returned, if no chip can be
+// read.
+func (a *Accesser) GpioChips() ([]gobot.GpioChipInfo, error) {
+	if a.gpioInfoAccess == nil {
+		return nil, fmt.Errorf("GPIO line info is not supported, please add the digital pin support")
+	}
+
+	chips, err := a.gpioInfoAccess.chips()
+	if err != nil {
+		return nil, err
+	}
+
+	var errs error
+	infos := make([]gobot.GpioChipInfo, 0, len(chips))
+	for _, chip := range chips {
+		info, err := a.gpioInfoAccess.chipInfo(chip)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		infos = append(infos, info)
+	}
+
+	if len(infos) == 0 && errs != nil {
+		return nil, errs
+	}
+
+	return infos, nil
+}
+
+// GpioLineInfo returns the information of the given line of the given gpiochip, e.g. "gpiochip0". An empty chip
+// means "gpiochip0".
+func (a *Accesser) GpioLineInfo(chip string, offset int) (gobot.GpioLineInfo, error) {
+	if a.gpioInfoAccess == nil {
+		return gobot.GpioLineInfo{}, fmt.Errorf("GPIO line info is not supported, please add the digital pin support")
+	}
+
+	if chip == "" {
+		chip = "gpiochip0"
+	}
+
+	info, err := a.gpioInfoAccess.chipInfo(chip)
+	if err != nil {
+		return gobot.GpioLineInfo{}, err
+	}
+
+	if offset < 0 || offset >= len(info.Lines) {
+		return gobot.GpioLineInfo{}, fmt.Errorf("line %d out of range [0..%d] of gpiochip '%s'", offset,
+			len(info.Lines)-1, chip)
+	}
+
+	return info.Lines[offset], nil
+}
+
+// FindGpioLine returns the gpiochip and the offset of the line with the given name, e.g. "GPIO17". If the name is
+// used by multiple lines, the first line of the gpiochip with the lowest number is returned.
+func (a *Accesser) FindGpioLine(name string) (string, int, error) {
+	chips, err := a.GpioChips()
+	if err != nil {
+		return "", -1, err
+	}
+
+	for _, chip := range chips {
+		for _, line := range chip.Lines {
+			if line.Name == name {
+				return chip.Name, line.Offset, nil
+			}
+		}
+	}
+
+	return "", -1, fmt.Errorf("no GPIO line found with name '%s'", name)
+}
+
+// WatchGpioLineInfo calls the handler for each change of the information of the given lines, e.g. when a line is
+// requested, released or reconfigured by another process. The watch is stopped by closing the returned watcher.
+func (a *Accesser) WatchGpioLineInfo(
+	chip string,
+	offsets []int,
+	handler func(gobot.GpioLineInfoEvent),
+) (io.Closer, error) {
+	if a.gpioInfoAccess == nil {
+		return nil, fmt.Errorf("GPIO line info is not supported, please add the digital pin support")
+	}
+
+	if chip == "" {
+		chip = "gpiochip0"
+	}
+
+	return a.gpioInfoAccess.watchLineInfo(chip, offsets, handler)
+}
+
 // AddI2CSupport adds the support to access the I2C features of the system, usually by syscall with character device.
 func (a *Accesser) AddI2CSupport() {
 	if a.fs == nil {
@@ -205,6 +333,24 @@ func (a *Accesser) AddSPISupport(options ...AccesserOptionApplier) {
 		return
 	}
 
+	if a.accesserCfg.useSpiCdev {
+		a.sys = &nativeSyscall{}
+		csa := &cdevSpiAccess{sys: a.sys, fs: a.fs}
+		if !csa.isSupported() {
+			if a.accesserCfg.debug || a.accesserCfg.debugSpi {
+				fmt.Println("character device driver not supported for SPI, please activate SPI or try to use GPIOs")
+			}
+			return
+		}
+
+		a.spiAccess = csa
+		if a.accesserCfg.debug || a.accesserCfg.debugSpi {
+			fmt.Println("use character device driver for SPI")
+		}
+
+		return
+	}
+
 	gsa := &periphioSpiAccess{fs: a.fs}
 	if !gsa.isSupported() {
 		if a.accesserCfg.debug || a.accesserCfg.debugSpi {
@@ -225,6 +371,12 @@ func (a *Accesser) HasSpiPeriphioAccess() bool {
 	return a.spiAccess != nil && a.spiAccess.isType(spiBusAccesserTypePeriphio)
 }
 
+// HasSpiCdevAccess returns whether the used SPI accesser is based on the native character device.
+// If SPI accesser is defined, returns false.
+func (a *Accesser) HasSpiCdevAccess() bool {
+	return a.spiAccess != nil && a.spiAccess.isType(spiBusAccesserTypeCdev)
+}
+
 // HasSpiGpioAccess returns whether the used SPI accesser is GPIO based.
 // If SPI accesser is defined, returns false.
 func (a *Accesser) HasSpiGpioAccess() bool {
@@ -238,6 +390,46 @@ func (a *Accesser) AddOneWireSupport() {
 	}
 }
 
+// AddUartSupport adds the support to access the UART's of the system, by the character devices, e.g. "/dev/ttyS0".
+func (a *Accesser) AddUartSupport() {
+	if a.uartAccess == nil {
+		a.uartAccess = &serialUartAccess{}
+	}
+}
+
+// AddIIOSupport adds the support to access the Industrial I/O devices of the system, by sysfs for configuration and
+// by character device for buffered capture.
+func (a *Accesser) AddIIOSupport() {
+	if a.fs == nil {
+		a.fs = &nativeFilesystem{} // for sysfs access and /dev/iio:device*
+	}
+}
+
+// AddLEDSupport adds the support to access the LED class devices of the system, by sysfs.
+func (a *Accesser) AddLEDSupport() {
+	if a.fs == nil {
+		a.fs = &nativeFilesystem{} // for sysfs access
+	}
+}
+
+// AddHwmonSupport adds the support to access the hardware monitoring devices of the system (e.g. temperatures, fans),
+// by sysfs.
+func (a *Accesser) AddHwmonSupport() {
+	if a.fs == nil {
+		a.fs = &nativeFilesystem{} // for sysfs access
+	}
+}
+
+// AddInputEventSupport adds the support to access the input devices of the system (e.g. keyboards, gamepads), by
+// syscall with the character devices "/dev/input/event*".
+func (a *Accesser) AddInputEventSupport() {
+	if a.fs == nil {
+		a.fs = &nativeFilesystem{} // for access to the input character devices
+	}
+
+	a.sys = &nativeSyscall{}
+}
+
 // UseMockDigitalPinAccess sets the digital pin handler accesser to the chosen one. Used only for tests.
 func (a *Accesser) UseMockDigitalPinAccess() *mockDigitalPinAccess {
 	dpa := newMockDigitalPinAccess(a.digitalPinAccess)
@@ -245,6 +437,13 @@ func (a *Accesser) UseMockDigitalPinAccess() *mockDigitalPinAccess {
 	return dpa
 }
 
+// UseMockGpioInfo sets the accesser for the information of gpiochips and lines to the mocked one. Used only for tests.
+func (a *Accesser) UseMockGpioInfo() *MockGpioInfoAccess {
+	gia := &MockGpioInfoAccess{}
+	a.gpioInfoAccess = gia
+	return gia
+}
+
 // UseMockSyscall sets the Syscall implementation of the accesser to the mocked one. Used only for tests.
 func (a *Accesser) UseMockSyscall() *mockSyscall {
 	msc := &mockSyscall{}
@@ -270,17 +469,90 @@ func (a *Accesser) UseMockSpi() *MockSpiAccess {
 	return msc
 }
 
+// UseMockUart sets the UART implementation of the accesser to the mocked one. Used only for tests.
+func (a *Accesser) UseMockUart() *MockUartAccess {
+	mua := newMockUartAccess()
+	a.uartAccess = mua
+	return mua
+}
+
 // NewDigitalPin returns a new system digital pin, according to the given pin number.
 func (a *Accesser) NewDigitalPin(chip string, pin int,
 	options ...func(gobot.DigitalPinOptioner) bool,
 ) gobot.DigitalPinner {
-	return a.digitalPinAccess.createPin(chip, pin, options...)
+	dp := a.digitalPinAccess.createPin(chip, pin, options...)
+	if a.accesserCfg.tracer == nil {
+		return dp
+	}
+
+	if chip == "" {
+		chip = "gpio"
+	}
+	return newDigitalPinTrace(a.accesserCfg.tracer, fmt.Sprintf("%s:%d", chip, pin), dp)
+}
+
+// NewDigitalPinGroup returns a new group of system digital pins, according to the given pin numbers. With the character
+// device driver all pins of the group needs to be on the same chip and are accessed at once, with the sysfs driver the
+// pins are accessed one by one. For pins on different chips use NewDigitalPinGroupSequential().
+func (a *Accesser) NewDigitalPinGroup(chip string, pins []int,
+	options ...func(gobot.DigitalPinOptioner) bool,
+) gobot.DigitalPinGrouper {
+	g := a.digitalPinAccess.createPinGroup(chip, pins, options...)
+	if a.accesserCfg.tracer == nil {
+		return g
+	}
+
+	if chip == "" {
+		chip = "gpio"
+	}
+	lines := make([]string, len(pins))
+	for i, pin := range pins {
+		lines[i] = strconv.Itoa(pin)
+	}
+	return newDigitalPinGroupTrace(a.accesserCfg.tracer, fmt.Sprintf("%s:%s", chip, strings.Join(lines, ",")), g)
+}
+
+// NewDigitalPinGroupSequential returns a new group of single system digital pins, which are accessed one by one in the
+// given order. This is used for pins on different chips, which can not be accessed at once by the character device
+// driver. The chip of each pin is given by the same position.
+func (a *Accesser) NewDigitalPinGroupSequential(chips []string, pins []int,
+	options ...func(gobot.DigitalPinOptioner) bool,
+) gobot.DigitalPinGrouper {
+	group := make([]gobot.DigitalPinner, len(pins))
+	for i, pin := range pins {
+		group[i] = a.NewDigitalPin(chips[i], pin, digitalPinGroupMemberOptions(i, options)...)
+	}
+	return newDigitalPinGroupSequential(group)
 }
 
 // NewPWMPin returns a new system PWM pin, according to the given pin number.
 func (a *Accesser) NewPWMPin(path string, pin int, polNormIdent string, polInvIdent string) gobot.PWMPinner {
 	sfa := &sysfsFileAccess{fs: a.fs, readBufLen: 200}
-	return newPWMPinSysfs(sfa, path, pin, polNormIdent, polInvIdent)
+	p := newPWMPinSysfs(sfa, path, pin, polNormIdent, polInvIdent)
+	if a.accesserCfg.tracer == nil {
+		return p
+	}
+
+	return newPWMPinTrace(a.accesserCfg.tracer, fmt.Sprintf("%s/pwm%d", strings.TrimRight(path, "/"), pin), p)
+}
+
+// NewPWMPinSoft returns a new software PWM pin, based on the given digital pin. All software PWM pins of the accesser
+// are serviced by one goroutine. The digital pin needs to be exported by its owner before, see also the jitter budget
+// in "pwmpin_soft.go".
+func (a *Accesser) NewPWMPinSoft(pin gobot.DigitalPinner) gobot.PWMPinner {
+	if a.pwmSoftScheduler == nil {
+		a.pwmSoftScheduler = newPWMSoftScheduler()
+	}
+	p := newPWMPinSoft(a.pwmSoftScheduler, pin)
+	if a.accesserCfg.tracer == nil {
+		return p
+	}
+
+	name := "soft"
+	if tp, ok := pin.(*digitalPinTrace); ok {
+		name = name + ":" + tp.name
+	}
+	return newPWMPinTrace(a.accesserCfg.tracer, name, p)
 }
 
 func (a *Accesser) NewAnalogPin(path string, w bool, readBufLen uint16) gobot.AnalogPinner {
@@ -294,7 +566,22 @@ func (a *Accesser) NewAnalogPin(path string, w bool, readBufLen uint16) gobot.An
 
 // NewSpiDevice returns a new connection to SPI with the given parameters.
 func (a *Accesser) NewSpiDevice(busNum, chipNum, mode, bits int, maxSpeed int64) (gobot.SpiSystemDevicer, error) {
-	return a.spiAccess.createDevice(busNum, chipNum, mode, bits, maxSpeed)
+	dev, err := a.spiAccess.createDevice(busNum, chipNum, mode, bits, maxSpeed)
+	if err != nil || a.accesserCfg.tracer == nil {
+		return dev, err
+	}
+
+	return newSpiDeviceTrace(a.accesserCfg.tracer, fmt.Sprintf("spi%d.%d", busNum, chipNum), dev), nil
+}
+
+// TraceI2cDevice returns the given I2C device decorated for tracing, if the tracing is activated by "WithTracer()".
+// Otherwise the given device is returned.
+func (a *Accesser) TraceI2cDevice(location string, dev gobot.I2cSystemDevicer) gobot.I2cSystemDevicer {
+	if a.accesserCfg.tracer == nil {
+		return dev
+	}
+
+	return newI2cDeviceTrace(a.accesserCfg.tracer, location, dev)
 }
 
 // NewOneWireDevice returns a new 1-wire device with the given parameters.
@@ -306,6 +593,12 @@ func (a *Accesser) NewOneWireDevice(familyCode byte, serialNumber uint64) (gobot
 	return newOneWireDeviceSysfs(sfa, deviceID), nil
 }
 
+// NewUartDevice opens the UART character device with the given path and baud rate, using 8 data bits, no parity and
+// one stop bit.
+func (a *Accesser) NewUartDevice(path string, baudRate int) (io.ReadWriteCloser, error) {
+	return a.uartAccess.openDevice(path, baudRate)
+}
+
 // OpenFile opens file of given name from native or the mocked file system
 func (a *Accesser) OpenFile(name string, flag int, perm os.FileMode) (File, error) {
 	return a.fs.openFile(name, flag, perm)
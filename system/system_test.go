@@ -6,6 +6,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
 )
 
 func TestNewAccesser(t *testing.T) {
@@ -58,6 +60,7 @@ func TestAccesserAddDigitalPinSupport(t *testing.T) {
 	assert.IsType(t, &nativeFilesystem{}, a.fs)
 	require.NotNil(t, a.digitalPinAccess)
 	assert.IsType(t, &cdevDigitalPinAccess{}, a.digitalPinAccess)
+	assert.IsType(t, &cdevGpioInfoAccess{}, a.gpioInfoAccess)
 }
 
 func TestAccesserAddI2CSupport(t *testing.T) {
@@ -93,6 +96,20 @@ func TestAccesserAddSPISupport(t *testing.T) {
 	assert.IsType(t, &periphioSpiAccess{}, a.spiAccess)
 }
 
+func TestAccesserAddSPISupportWithCdev(t *testing.T) {
+	// arrange
+	a := NewAccesser()
+	a.UseMockFilesystem([]string{"/dev/spidev0.1"})
+	// act
+	a.AddSPISupport(WithSpiCdevAccess())
+	// assert
+	assert.IsType(t, &nativeSyscall{}, a.sys)
+	require.NotNil(t, a.spiAccess)
+	assert.IsType(t, &cdevSpiAccess{}, a.spiAccess)
+	assert.True(t, a.HasSpiCdevAccess())
+	assert.False(t, a.HasSpiPeriphioAccess())
+}
+
 func TestAccesserAddOneWireSupport(t *testing.T) {
 	// arrange
 	a := NewAccesser()
@@ -205,3 +222,148 @@ func TestAccesserNewOneWireDevice(t *testing.T) {
 	assert.Equal(t, "/sys/bus/w1/devices/"+wantID, con.(*onewireDeviceSysfs).sysfsPath)
 	assert.IsType(t, &sysfsFileAccess{}, con.(*onewireDeviceSysfs).sfa)
 }
+
+var testGpioChips = []gobot.GpioChipInfo{
+	{
+		Name:  "gpiochip0",
+		Label: "pinctrl-bcm2711",
+		Lines: []gobot.GpioLineInfo{
+			{Chip: "gpiochip0", Offset: 0, Name: "ID_SDA", Direction: "input"},
+			{Chip: "gpiochip0", Offset: 1, Name: "GPIO17", Consumer: "gobotio1", Used: true, Direction: "output"},
+		},
+	},
+	{
+		Name:  "gpiochip1",
+		Label: "raspberrypi-exp-gpio",
+		Lines: []gobot.GpioLineInfo{
+			{Chip: "gpiochip1", Offset: 0, Name: "BT_ON", Direction: "output"},
+			{Chip: "gpiochip1", Offset: 1, Name: "GPIO17", Direction: "input"},
+		},
+	},
+}
+
+func TestAccesserGpioChips(t *testing.T) {
+	// arrange
+	a := NewAccesser()
+	a.AddDigitalPinSupport()
+	gia := a.UseMockGpioInfo()
+	gia.Chips = testGpioChips
+	// act
+	got, err := a.GpioChips()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, testGpioChips, got)
+	// act & assert error
+	gia.ChipsError = true
+	_, err = a.GpioChips()
+	require.EqualError(t, err, "chips error")
+}
+
+func TestAccesserGpioChipsSkipsFailingChips(t *testing.T) {
+	// arrange
+	a := NewAccesser()
+	a.AddDigitalPinSupport()
+	gia := a.UseMockGpioInfo()
+	gia.Chips = testGpioChips[1:]
+	gia.FailingChips = []string{"gpiochip2"}
+	// act
+	got, err := a.GpioChips()
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, testGpioChips[1:], got)
+	// act & assert: an error is returned, if no chip can be read
+	gia.Chips = nil
+	_, err = a.GpioChips()
+	require.ErrorContains(t, err, "open gpiochip 'gpiochip2' failed: not found in mock")
+}
+
+func TestAccesserGpioChipsNotSupported(t *testing.T) {
+	// arrange
+	a := NewAccesser()
+	// act
+	_, err := a.GpioChips()
+	// assert
+	require.EqualError(t, err, "GPIO line info is not supported, please add the digital pin support")
+}
+
+func TestAccesserGpioLineInfo(t *testing.T) {
+	tests := map[string]struct {
+		chip    string
+		offset  int
+		want    gobot.GpioLineInfo
+		wantErr string
+	}{
+		"default_chip": {
+			offset: 1,
+			want:   testGpioChips[0].Lines[1],
+		},
+		"given_chip": {
+			chip: "gpiochip1",
+			want: testGpioChips[1].Lines[0],
+		},
+		"error_offset": {
+			chip:    "gpiochip1",
+			offset:  2,
+			wantErr: "line 2 out of range [0..1] of gpiochip 'gpiochip1'",
+		},
+		"error_chip": {
+			chip:    "gpiochip2",
+			wantErr: "open gpiochip 'gpiochip2' failed: not found in mock",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			a := NewAccesser()
+			a.UseMockGpioInfo().Chips = testGpioChips
+			// act
+			got, err := a.GpioLineInfo(tc.chip, tc.offset)
+			// assert
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestAccesserFindGpioLine(t *testing.T) {
+	// arrange
+	a := NewAccesser()
+	a.UseMockGpioInfo().Chips = testGpioChips
+	// act
+	chip, offset, err := a.FindGpioLine("GPIO17")
+	// assert: the line of the first chip is found
+	require.NoError(t, err)
+	assert.Equal(t, "gpiochip0", chip)
+	assert.Equal(t, 1, offset)
+	// act & assert
+	chip, offset, err = a.FindGpioLine("BT_ON")
+	require.NoError(t, err)
+	assert.Equal(t, "gpiochip1", chip)
+	assert.Equal(t, 0, offset)
+	_, _, err = a.FindGpioLine("GPIO99")
+	require.EqualError(t, err, "no GPIO line found with name 'GPIO99'")
+}
+
+func TestAccesserWatchGpioLineInfo(t *testing.T) {
+	// arrange
+	a := NewAccesser()
+	gia := a.UseMockGpioInfo()
+	var got []gobot.GpioLineInfoEvent
+	watcher, err := a.WatchGpioLineInfo("", []int{1}, func(evt gobot.GpioLineInfoEvent) { got = append(got, evt) })
+	require.NoError(t, err)
+	evt := gobot.GpioLineInfoEvent{Type: "requested", Info: gobot.GpioLineInfo{Chip: "gpiochip0", Offset: 1}}
+	// act
+	gia.Emit(evt)
+	gia.Emit(gobot.GpioLineInfoEvent{Type: "requested", Info: gobot.GpioLineInfo{Chip: "gpiochip0", Offset: 2}})
+	gia.Emit(gobot.GpioLineInfoEvent{Type: "requested", Info: gobot.GpioLineInfo{Chip: "gpiochip1", Offset: 1}})
+	// assert
+	assert.Equal(t, []gobot.GpioLineInfoEvent{evt}, got)
+	require.NoError(t, watcher.Close())
+	assert.Equal(t, 0, gia.WatcherCount())
+	gia.Emit(evt)
+	assert.Len(t, got, 1)
+}
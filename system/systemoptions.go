@@ -21,6 +21,12 @@ type systemUseDigitalPinSysfsOption bool
 
 type systemUseSpiGpioOption spiGpioConfig
 
+type systemUseSpiCdevOption bool
+
+type systemTracerOption struct {
+	tracer *Tracer
+}
+
 // WithSystemAccesserDebug can be used to switch on debug messages.
 func WithSystemAccesserDebug() systemAccesserDebugOption {
 	return systemAccesserDebugOption(true)
@@ -61,6 +67,18 @@ func WithSpiGpioAccess(p gobot.DigitalPinnerProvider, sclkPin, ncsPin, sdoPin, s
 	return o
 }
 
+// WithSpiCdevAccess can be used to change the default periph.io implementation for SPI to the native character device
+// implementation, which supports multi-segment transfers with per segment settings.
+func WithSpiCdevAccess() systemUseSpiCdevOption {
+	return systemUseSpiCdevOption(true)
+}
+
+// WithTracer can be used to activate the tracing of all transactions of digital pins, PWM pins, I2C and SPI devices,
+// which are created afterwards by the accesser.
+func WithTracer(tracer *Tracer) systemTracerOption {
+	return systemTracerOption{tracer: tracer}
+}
+
 func (o systemAccesserDebugOption) String() string {
 	return "switch on system accesser debugging option"
 }
@@ -81,6 +99,14 @@ func (o systemUseSpiGpioOption) String() string {
 	return "system accesser use discrete GPIOs for SPI option"
 }
 
+func (o systemUseSpiCdevOption) String() string {
+	return "system accesser use native character device for SPI option"
+}
+
+func (o systemTracerOption) String() string {
+	return "system accesser tracing option"
+}
+
 func (o systemAccesserDebugOption) apply(cfg *accesserConfiguration) {
 	cfg.debug = bool(o)
 }
@@ -102,3 +128,11 @@ func (o systemUseSpiGpioOption) apply(cfg *accesserConfiguration) {
 	c := spiGpioConfig(o)
 	cfg.spiGpioConfig = &c
 }
+
+func (o systemUseSpiCdevOption) apply(cfg *accesserConfiguration) {
+	cfg.useSpiCdev = bool(o)
+}
+
+func (o systemTracerOption) apply(cfg *accesserConfiguration) {
+	cfg.tracer = o.tracer
+}
@@ -94,3 +94,38 @@ func TestWithSpiOptionAccess_HasSpiPeriphioAccess_HasSpiGpioAccess(t *testing.T)
 		})
 	}
 }
+
+func TestWithTracer(t *testing.T) {
+	tests := map[string]struct {
+		tracer    *Tracer
+		wantTrace bool
+	}{
+		"without_tracer": {},
+		"with_tracer": {
+			tracer:    NewTracer(0),
+			wantTrace: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			var options []AccesserOptionApplier
+			if tc.tracer != nil {
+				options = append(options, WithTracer(tc.tracer))
+			}
+			a := NewAccesser(options...)
+			a.UseMockDigitalPinAccess()
+			// act
+			pin := a.NewDigitalPin("", 1)
+			dev := a.TraceI2cDevice("/dev/i2c-1", &i2cTraceTestDevice{})
+			// assert
+			if tc.wantTrace {
+				assert.IsType(t, &digitalPinTrace{}, pin)
+				assert.IsType(t, &i2cDeviceTrace{}, dev)
+			} else {
+				assert.IsType(t, &digitalPinMock{}, pin)
+				assert.IsType(t, &i2cTraceTestDevice{}, dev)
+			}
+		})
+	}
+}
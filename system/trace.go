@@ -0,0 +1,378 @@
+package system
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TraceKind is the kind of the traced device.
+type TraceKind string
+
+// TraceDirection is the direction of a traced transaction.
+type TraceDirection string
+
+const (
+	TraceKindI2c        TraceKind = "i2c"
+	TraceKindSpi        TraceKind = "spi"
+	TraceKindDigitalPin TraceKind = "gpio"
+	TraceKindPWMPin     TraceKind = "pwm"
+	// TraceKindDigitalPinGroup is used for a group of digital pins, bit 0 of the value belongs to the first pin.
+	TraceKindDigitalPinGroup TraceKind = "gpiogroup"
+)
+
+const (
+	TraceDirectionRead     TraceDirection = "read"
+	TraceDirectionWrite    TraceDirection = "write"
+	TraceDirectionTransfer TraceDirection = "transfer" // write and read at once, e.g. for SPI
+	TraceDirectionConfig   TraceDirection = "config"   // e.g. export, apply options, close
+)
+
+// vcdByteStep is the time in nanoseconds between bytes of the same transaction in the VCD output, because only the
+// start of the transaction is known.
+const vcdByteStep = 1000
+
+// TraceRecord is one traced transaction of a device.
+type TraceRecord struct {
+	// Time is the start of the transaction.
+	Time time.Time
+	Kind TraceKind
+	// Device is the location of the bus, e.g. "/dev/i2c-1", "spi0.1" or the pin, e.g. "gpiochip0:17". For a group of
+	// pins, all pins are listed, e.g. "gpiochip0:17,18,27".
+	Device string
+	// Address is the I2C address, only used for I2C.
+	Address   int
+	Operation string
+	Direction TraceDirection
+	// Tx contains the written bytes of a bus transaction, e.g. the register and data.
+	Tx []byte
+	// Rx contains the read bytes of a bus transaction.
+	Rx []byte
+	// Value is the read or written value of a pin, e.g. the level of a digital pin or the duty cycle of a PWM pin.
+	Value int64
+	// Err is the text of the error of the transaction, empty on success.
+	Err string
+}
+
+// Tracer records the transactions of all traced devices in memory. The records can be exported as JSON lines or
+// as VCD (value change dump), which can be opened e.g. by PulseView or GTKWave.
+type Tracer struct {
+	maxRecords int
+	records    []TraceRecord
+	mutex      sync.Mutex
+	now        func() time.Time // to allow unit testing
+}
+
+// NewTracer creates a new tracer, which keeps the latest given count of records. A count of 0 means unlimited.
+func NewTracer(maxRecords int) *Tracer {
+	return &Tracer{maxRecords: maxRecords, now: time.Now}
+}
+
+// Records returns a copy of all recorded transactions.
+func (t *Tracer) Records() []TraceRecord {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	records := make([]TraceRecord, len(t.records))
+	copy(records, t.records)
+	return records
+}
+
+// Reset removes all recorded transactions.
+func (t *Tracer) Reset() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.records = nil
+}
+
+// WriteJSONL writes all recorded transactions as JSON lines, one record per line. The byte payloads are written as hex
+// strings.
+func (t *Tracer) WriteJSONL(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for _, r := range t.Records() {
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteVCD writes all recorded transactions as value change dump with a timescale of 1 ns. Each digital pin is a
+// 1 bit wire, each PWM pin is represented by the wires "enabled", "period" and "duty". The buses have the 8 bit wires
+// "tx" and "rx" (I2C additionally "addr"), where the bytes of a transaction follow each other with a distance of
+// 1 us. An error of a transaction is shown by the wire "error" of the device.
+func (t *Tracer) WriteVCD(w io.Writer) error {
+	records := t.Records()
+
+	vcd := newVCDBuilder()
+	for _, r := range records {
+		vcd.add(r)
+	}
+
+	bw := bufio.NewWriter(w)
+	vcd.write(bw)
+	return bw.Flush()
+}
+
+func (t *Tracer) record(r TraceRecord) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.records = append(t.records, r)
+	if t.maxRecords > 0 && len(t.records) > t.maxRecords {
+		t.records = t.records[len(t.records)-t.maxRecords:]
+	}
+}
+
+// MarshalJSON implements the json.Marshaler interface. The address is only written for I2C and the value is only
+// written for read or write transactions of pins.
+func (r TraceRecord) MarshalJSON() ([]byte, error) {
+	type jsonRecord struct {
+		Time      string         `json:"time"`
+		Kind      TraceKind      `json:"kind"`
+		Device    string         `json:"device"`
+		Address   *int           `json:"address,omitempty"`
+		Operation string         `json:"op"`
+		Direction TraceDirection `json:"dir"`
+		Tx        string         `json:"tx,omitempty"`
+		Rx        string         `json:"rx,omitempty"`
+		Value     *int64         `json:"value,omitempty"`
+		Err       string         `json:"err,omitempty"`
+	}
+
+	jr := jsonRecord{
+		Time:      r.Time.Format(time.RFC3339Nano),
+		Kind:      r.Kind,
+		Device:    r.Device,
+		Operation: r.Operation,
+		Direction: r.Direction,
+		Tx:        hex.EncodeToString(r.Tx),
+		Rx:        hex.EncodeToString(r.Rx),
+		Err:       r.Err,
+	}
+	if r.Kind == TraceKindI2c {
+		address := r.Address
+		jr.Address = &address
+	}
+	if r.isPinValue() {
+		value := r.Value
+		jr.Value = &value
+	}
+
+	return json.Marshal(jr)
+}
+
+func (r TraceRecord) isPinValue() bool {
+	return (r.Kind == TraceKindDigitalPin || r.Kind == TraceKindDigitalPinGroup || r.Kind == TraceKindPWMPin) &&
+		(r.Direction == TraceDirectionRead || r.Direction == TraceDirectionWrite)
+}
+
+type vcdVariable struct {
+	id    string
+	name  string
+	width int
+}
+
+type vcdEvent struct {
+	time  int64
+	value string
+}
+
+type vcdBuilder struct {
+	start     time.Time
+	variables []*vcdVariable
+	byName    map[string]*vcdVariable
+	events    []vcdEvent
+	failed    map[string]bool // the key is the device, true if the error wire is set
+}
+
+func newVCDBuilder() *vcdBuilder {
+	return &vcdBuilder{byName: make(map[string]*vcdVariable), failed: make(map[string]bool)}
+}
+
+func (b *vcdBuilder) add(r TraceRecord) {
+	if b.start.IsZero() {
+		b.start = r.Time
+	}
+	ts := r.Time.Sub(b.start).Nanoseconds()
+	if ts < 0 {
+		ts = 0
+	}
+
+	// the error wire is only created, if an error occurs for the device
+	if r.Err != "" {
+		b.change(ts, r.Device, "error", 1, 1)
+		b.failed[r.Device] = true
+		return
+	}
+	if b.failed[r.Device] {
+		b.change(ts, r.Device, "error", 1, 0)
+		b.failed[r.Device] = false
+	}
+
+	switch r.Kind {
+	case TraceKindDigitalPin:
+		if r.isPinValue() {
+			b.change(ts, r.Device, "", 1, r.Value)
+		}
+	case TraceKindDigitalPinGroup:
+		// each pin of the group gets its own wire, which is shared with the single access of the same pin
+		if r.isPinValue() {
+			for i, pin := range vcdGroupPins(r.Device) {
+				b.change(ts, pin, "", 1, r.Value>>i)
+			}
+		}
+	case TraceKindPWMPin:
+		if !r.isPinValue() {
+			return
+		}
+		switch r.Operation {
+		case "Enabled", "SetEnabled":
+			b.change(ts, r.Device, "enabled", 1, r.Value)
+		case "Period", "SetPeriod":
+			b.change(ts, r.Device, "period", 32, r.Value)
+		case "DutyCycle", "SetDutyCycle":
+			b.change(ts, r.Device, "duty", 32, r.Value)
+		}
+	case TraceKindI2c, TraceKindSpi:
+		if len(r.Tx) == 0 && len(r.Rx) == 0 {
+			return
+		}
+		if r.Kind == TraceKindI2c {
+			b.change(ts, r.Device, "addr", 7, int64(r.Address))
+		}
+		b.bytes(ts, r.Device, "tx", r.Tx)
+		// for I2C the read bytes follows the written bytes (e.g. register), for SPI both are transferred at once
+		rxStart := ts
+		if r.Kind == TraceKindI2c {
+			rxStart = ts + int64(len(r.Tx))*vcdByteStep
+		}
+		b.bytes(rxStart, r.Device, "rx", r.Rx)
+	}
+}
+
+// bytes adds a value change for each byte and sets the wire to unknown after the last byte
+func (b *vcdBuilder) bytes(ts int64, device, signal string, data []byte) {
+	if len(data) == 0 {
+		return
+	}
+	for i, v := range data {
+		b.change(ts+int64(i)*vcdByteStep, device, signal, 8, int64(v))
+	}
+	b.changeUnknown(ts+int64(len(data))*vcdByteStep, device, signal, 8)
+}
+
+func (b *vcdBuilder) change(ts int64, device, signal string, width int, value int64) {
+	v := b.variable(device, signal, width)
+	var val string
+	if width == 1 {
+		val = strconv.FormatInt(value&1, 2) + v.id
+	} else {
+		val = "b" + strconv.FormatUint(uint64(value), 2) + " " + v.id //nolint:gosec // negative values are not expected
+	}
+	b.events = append(b.events, vcdEvent{time: ts, value: val})
+}
+
+func (b *vcdBuilder) changeUnknown(ts int64, device, signal string, width int) {
+	v := b.variable(device, signal, width)
+	b.events = append(b.events, vcdEvent{time: ts, value: "bx " + v.id})
+}
+
+func (b *vcdBuilder) variable(device, signal string, width int) *vcdVariable {
+	name := vcdName(device)
+	if signal != "" {
+		name = name + "_" + signal
+	}
+	if v, ok := b.byName[name]; ok {
+		return v
+	}
+	v := &vcdVariable{id: vcdIdentifier(len(b.variables)), name: name, width: width}
+	b.variables = append(b.variables, v)
+	b.byName[name] = v
+	return v
+}
+
+func (b *vcdBuilder) write(w io.Writer) {
+	date := b.start
+	if date.IsZero() {
+		date = time.Now()
+	}
+	fmt.Fprintf(w, "$date %s $end\n", date.Format(time.RFC3339Nano))
+	fmt.Fprintln(w, "$version gobot trace $end")
+	fmt.Fprintln(w, "$timescale 1ns $end")
+	fmt.Fprintln(w, "$scope module gobot $end")
+	for _, v := range b.variables {
+		fmt.Fprintf(w, "$var wire %d %s %s $end\n", v.width, v.id, v.name)
+	}
+	fmt.Fprintln(w, "$upscope $end")
+	fmt.Fprintln(w, "$enddefinitions $end")
+
+	// all values are unknown until the first change
+	fmt.Fprintln(w, "#0")
+	fmt.Fprintln(w, "$dumpvars")
+	for _, v := range b.variables {
+		if v.width == 1 {
+			fmt.Fprintf(w, "x%s\n", v.id)
+		} else {
+			fmt.Fprintf(w, "bx %s\n", v.id)
+		}
+	}
+	fmt.Fprintln(w, "$end")
+
+	sort.SliceStable(b.events, func(i, j int) bool { return b.events[i].time < b.events[j].time })
+	lastTime := int64(0)
+	for _, e := range b.events {
+		if e.time != lastTime {
+			fmt.Fprintf(w, "#%d\n", e.time)
+			lastTime = e.time
+		}
+		fmt.Fprintln(w, e.value)
+	}
+}
+
+// vcdName replaces all characters, which are not allowed in a VCD variable name
+// vcdGroupPins splits the device of a pin group, e.g. "gpiochip0:17,18" into the devices of the single pins, e.g.
+// "gpiochip0:17" and "gpiochip0:18"
+func vcdGroupPins(device string) []string {
+	chip, pins, found := strings.Cut(device, ":")
+	if !found {
+		return []string{device}
+	}
+	var devices []string
+	for _, pin := range strings.Split(pins, ",") {
+		devices = append(devices, chip+":"+pin)
+	}
+	return devices
+}
+
+func vcdName(device string) string {
+	name := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, device)
+	return strings.Trim(name, "_")
+}
+
+// vcdIdentifier creates the short identifier of a VCD variable, by the printable ASCII characters '!' to '~'
+func vcdIdentifier(idx int) string {
+	const first, count = '!', '~' - '!' + 1
+	var id []byte
+	for {
+		id = append(id, byte(first+idx%count))
+		idx = idx/count - 1
+		if idx < 0 {
+			break
+		}
+	}
+	return string(id)
+}
@@ -0,0 +1,472 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gobot.io/x/gobot/v2"
+)
+
+// i2cDeviceTrace decorates an I2C device and records all transactions.
+type i2cDeviceTrace struct {
+	tracer   *Tracer
+	location string
+	dev      gobot.I2cSystemDevicer
+}
+
+// spiDeviceTrace decorates a SPI device and records all transactions.
+type spiDeviceTrace struct {
+	tracer *Tracer
+	name   string
+	dev    gobot.SpiSystemDevicer
+}
+
+// digitalPinTrace decorates a digital pin and records all transactions.
+type digitalPinTrace struct {
+	tracer *Tracer
+	name   string
+	pin    gobot.DigitalPinner
+}
+
+// digitalPinGroupTrace decorates a group of digital pins and records all transactions.
+type digitalPinGroupTrace struct {
+	tracer *Tracer
+	name   string
+	group  gobot.DigitalPinGrouper
+}
+
+// pwmPinTrace decorates a PWM pin and records all transactions.
+type pwmPinTrace struct {
+	tracer *Tracer
+	name   string
+	pin    gobot.PWMPinner
+}
+
+func newI2cDeviceTrace(tracer *Tracer, location string, dev gobot.I2cSystemDevicer) *i2cDeviceTrace {
+	return &i2cDeviceTrace{tracer: tracer, location: location, dev: dev}
+}
+
+func newSpiDeviceTrace(tracer *Tracer, name string, dev gobot.SpiSystemDevicer) *spiDeviceTrace {
+	return &spiDeviceTrace{tracer: tracer, name: name, dev: dev}
+}
+
+func newDigitalPinTrace(tracer *Tracer, name string, pin gobot.DigitalPinner) *digitalPinTrace {
+	return &digitalPinTrace{tracer: tracer, name: name, pin: pin}
+}
+
+func newDigitalPinGroupTrace(tracer *Tracer, name string, group gobot.DigitalPinGrouper) *digitalPinGroupTrace {
+	return &digitalPinGroupTrace{tracer: tracer, name: name, group: group}
+}
+
+func newPWMPinTrace(tracer *Tracer, name string, pin gobot.PWMPinner) *pwmPinTrace {
+	return &pwmPinTrace{tracer: tracer, name: name, pin: pin}
+}
+
+// ReadByte reads a byte from the device and records the transaction.
+func (d *i2cDeviceTrace) ReadByte(address int) (byte, error) {
+	start := d.tracer.now()
+	val, err := d.dev.ReadByte(address)
+	d.trace(start, "ReadByte", address, TraceDirectionRead, nil, []byte{val}, err)
+	return val, err
+}
+
+// WriteQuick sends only the address to the device, if supported, and records the transaction.
+func (d *i2cDeviceTrace) WriteQuick(address int) error {
+	qw, ok := d.dev.(gobot.I2cQuickWriter)
+	if !ok {
+		return fmt.Errorf("%w: quick write for '%s'", errors.ErrUnsupported, d.location)
+	}
+
+	start := d.tracer.now()
+	err := qw.WriteQuick(address)
+	d.trace(start, "WriteQuick", address, TraceDirectionWrite, nil, nil, err)
+	return err
+}
+
+// ReadByteData reads a byte from the register of the device and records the transaction.
+func (d *i2cDeviceTrace) ReadByteData(address int, reg uint8) (uint8, error) {
+	start := d.tracer.now()
+	val, err := d.dev.ReadByteData(address, reg)
+	d.trace(start, "ReadByteData", address, TraceDirectionRead, []byte{reg}, []byte{val}, err)
+	return val, err
+}
+
+// ReadWordData reads a 16 bit value from the register of the device and records the transaction.
+func (d *i2cDeviceTrace) ReadWordData(address int, reg uint8) (uint16, error) {
+	start := d.tracer.now()
+	val, err := d.dev.ReadWordData(address, reg)
+	d.trace(start, "ReadWordData", address, TraceDirectionRead, []byte{reg}, []byte{byte(val), byte(val >> 8)}, err)
+	return val, err
+}
+
+// ReadBlockData fills the data from the register of the device and records the transaction.
+func (d *i2cDeviceTrace) ReadBlockData(address int, reg uint8, data []byte) error {
+	start := d.tracer.now()
+	err := d.dev.ReadBlockData(address, reg, data)
+	d.trace(start, "ReadBlockData", address, TraceDirectionRead, []byte{reg}, data, err)
+	return err
+}
+
+// WriteByte writes a byte to the device and records the transaction.
+func (d *i2cDeviceTrace) WriteByte(address int, val byte) error {
+	start := d.tracer.now()
+	err := d.dev.WriteByte(address, val)
+	d.trace(start, "WriteByte", address, TraceDirectionWrite, []byte{val}, nil, err)
+	return err
+}
+
+// WriteByteData writes a byte to the register of the device and records the transaction.
+func (d *i2cDeviceTrace) WriteByteData(address int, reg uint8, val uint8) error {
+	start := d.tracer.now()
+	err := d.dev.WriteByteData(address, reg, val)
+	d.trace(start, "WriteByteData", address, TraceDirectionWrite, []byte{reg, val}, nil, err)
+	return err
+}
+
+// WriteBlockData writes the data to the register of the device and records the transaction.
+func (d *i2cDeviceTrace) WriteBlockData(address int, reg uint8, data []byte) error {
+	start := d.tracer.now()
+	err := d.dev.WriteBlockData(address, reg, data)
+	d.trace(start, "WriteBlockData", address, TraceDirectionWrite, append([]byte{reg}, data...), nil, err)
+	return err
+}
+
+// WriteWordData writes a 16 bit value to the register of the device and records the transaction.
+func (d *i2cDeviceTrace) WriteWordData(address int, reg uint8, val uint16) error {
+	start := d.tracer.now()
+	err := d.dev.WriteWordData(address, reg, val)
+	d.trace(start, "WriteWordData", address, TraceDirectionWrite, []byte{reg, byte(val), byte(val >> 8)}, nil, err)
+	return err
+}
+
+// WriteBytes writes the data to the device and records the transaction.
+func (d *i2cDeviceTrace) WriteBytes(address int, data []byte) error {
+	start := d.tracer.now()
+	err := d.dev.WriteBytes(address, data)
+	d.trace(start, "WriteBytes", address, TraceDirectionWrite, data, nil, err)
+	return err
+}
+
+// Read reads data from the device and records the transaction.
+func (d *i2cDeviceTrace) Read(address int, b []byte) (int, error) {
+	start := d.tracer.now()
+	n, err := d.dev.Read(address, b)
+	rx := b
+	if n >= 0 && n < len(b) {
+		rx = b[:n]
+	}
+	d.trace(start, "Read", address, TraceDirectionRead, nil, rx, err)
+	return n, err
+}
+
+// Write writes data to the device and records the transaction.
+func (d *i2cDeviceTrace) Write(address int, b []byte) (int, error) {
+	start := d.tracer.now()
+	n, err := d.dev.Write(address, b)
+	d.trace(start, "Write", address, TraceDirectionWrite, b, nil, err)
+	return n, err
+}
+
+// Close closes the device and records the transaction.
+func (d *i2cDeviceTrace) Close() error {
+	start := d.tracer.now()
+	err := d.dev.Close()
+	d.trace(start, "Close", 0, TraceDirectionConfig, nil, nil, err)
+	return err
+}
+
+func (d *i2cDeviceTrace) trace(start time.Time, op string, address int, dir TraceDirection, tx, rx []byte,
+	err error,
+) {
+	r := TraceRecord{
+		Time:      start,
+		Kind:      TraceKindI2c,
+		Device:    d.location,
+		Address:   address,
+		Operation: op,
+		Direction: dir,
+		Tx:        cloneBytes(tx),
+	}
+	if err != nil {
+		r.Err = err.Error()
+	} else {
+		r.Rx = cloneBytes(rx)
+	}
+	d.tracer.record(r)
+}
+
+// TxRx transfers the data and records the transaction.
+func (d *spiDeviceTrace) TxRx(tx []byte, rx []byte) error {
+	start := d.tracer.now()
+	err := d.dev.TxRx(tx, rx)
+	d.trace(start, "TxRx", tx, rx, err)
+	return err
+}
+
+// Transfer transfers all segments and records them as one transaction. A missing tx or rx of a segment is recorded as
+// zeros, so the bytes of tx and rx are aligned.
+func (d *spiDeviceTrace) Transfer(segments ...gobot.SpiTransferSegment) error {
+	t, ok := d.dev.(gobot.SpiTransferer)
+	if !ok {
+		return fmt.Errorf("%w: transfer of segments for '%s'", errors.ErrUnsupported, d.name)
+	}
+
+	start := d.tracer.now()
+	err := t.Transfer(segments...)
+
+	var tx, rx []byte
+	for _, s := range segments {
+		length := len(s.Tx)
+		if len(s.Rx) > length {
+			length = len(s.Rx)
+		}
+		tx = append(tx, s.Tx...)
+		tx = append(tx, make([]byte, length-len(s.Tx))...)
+		rx = append(rx, s.Rx...)
+		rx = append(rx, make([]byte, length-len(s.Rx))...)
+	}
+	d.trace(start, "Transfer", tx, rx, err)
+	return err
+}
+
+// Close closes the device and records the transaction.
+func (d *spiDeviceTrace) Close() error {
+	start := d.tracer.now()
+	err := d.dev.Close()
+	r := TraceRecord{Time: start, Kind: TraceKindSpi, Device: d.name, Operation: "Close", Direction: TraceDirectionConfig}
+	if err != nil {
+		r.Err = err.Error()
+	}
+	d.tracer.record(r)
+	return err
+}
+
+func (d *spiDeviceTrace) trace(start time.Time, op string, tx, rx []byte, err error) {
+	r := TraceRecord{
+		Time:      start,
+		Kind:      TraceKindSpi,
+		Device:    d.name,
+		Operation: op,
+		Direction: TraceDirectionTransfer,
+		Tx:        cloneBytes(tx),
+	}
+	if err != nil {
+		r.Err = err.Error()
+	} else {
+		r.Rx = cloneBytes(rx)
+	}
+	d.tracer.record(r)
+}
+
+// ApplyOptions applies the options to the pin and records the transaction.
+func (p *digitalPinTrace) ApplyOptions(options ...func(gobot.DigitalPinOptioner) bool) error {
+	start := p.tracer.now()
+	err := p.pin.ApplyOptions(options...)
+	p.trace(start, "ApplyOptions", TraceDirectionConfig, 0, err)
+	return err
+}
+
+// DirectionBehavior returns the direction behavior of the decorated pin, if supported. Implements the interface
+// gobot.DigitalPinValuer.
+func (p *digitalPinTrace) DirectionBehavior() string {
+	if vpin, ok := p.pin.(gobot.DigitalPinValuer); ok {
+		return vpin.DirectionBehavior()
+	}
+	return ""
+}
+
+// Export exports the pin and records the transaction.
+func (p *digitalPinTrace) Export() error {
+	start := p.tracer.now()
+	err := p.pin.Export()
+	p.trace(start, "Export", TraceDirectionConfig, 0, err)
+	return err
+}
+
+// Unexport releases the pin and records the transaction.
+func (p *digitalPinTrace) Unexport() error {
+	start := p.tracer.now()
+	err := p.pin.Unexport()
+	p.trace(start, "Unexport", TraceDirectionConfig, 0, err)
+	return err
+}
+
+// Read reads the value of the pin and records the transaction.
+func (p *digitalPinTrace) Read() (int, error) {
+	start := p.tracer.now()
+	val, err := p.pin.Read()
+	p.trace(start, "Read", TraceDirectionRead, int64(val), err)
+	return val, err
+}
+
+// Write writes the value to the pin and records the transaction.
+func (p *digitalPinTrace) Write(val int) error {
+	start := p.tracer.now()
+	err := p.pin.Write(val)
+	p.trace(start, "Write", TraceDirectionWrite, int64(val), err)
+	return err
+}
+
+func (p *digitalPinTrace) trace(start time.Time, op string, dir TraceDirection, value int64, err error) {
+	r := TraceRecord{Time: start, Kind: TraceKindDigitalPin, Device: p.name, Operation: op, Direction: dir, Value: value}
+	if err != nil {
+		r.Err = err.Error()
+	}
+	p.tracer.record(r)
+}
+
+// ApplyOptions applies the options to all pins of the group and records the transaction.
+func (g *digitalPinGroupTrace) ApplyOptions(options ...func(gobot.DigitalPinOptioner) bool) error {
+	start := g.tracer.now()
+	err := g.group.ApplyOptions(options...)
+	g.trace(start, "ApplyOptions", TraceDirectionConfig, 0, err)
+	return err
+}
+
+// Export exports all pins of the group and records the transaction.
+func (g *digitalPinGroupTrace) Export() error {
+	start := g.tracer.now()
+	err := g.group.Export()
+	g.trace(start, "Export", TraceDirectionConfig, 0, err)
+	return err
+}
+
+// Unexport releases all pins of the group and records the transaction.
+func (g *digitalPinGroupTrace) Unexport() error {
+	start := g.tracer.now()
+	err := g.group.Unexport()
+	g.trace(start, "Unexport", TraceDirectionConfig, 0, err)
+	return err
+}
+
+// Read reads the values of all pins of the group and records the transaction.
+func (g *digitalPinGroupTrace) Read() (uint64, error) {
+	start := g.tracer.now()
+	values, err := g.group.Read()
+	g.trace(start, "Read", TraceDirectionRead, int64(values), err) //nolint:gosec // the bits are kept as they are
+	return values, err
+}
+
+// Write writes the values to all pins of the group and records the transaction.
+func (g *digitalPinGroupTrace) Write(values uint64) error {
+	start := g.tracer.now()
+	err := g.group.Write(values)
+	g.trace(start, "Write", TraceDirectionWrite, int64(values), err) //nolint:gosec // the bits are kept as they are
+	return err
+}
+
+func (g *digitalPinGroupTrace) trace(start time.Time, op string, dir TraceDirection, value int64, err error) {
+	r := TraceRecord{
+		Time: start, Kind: TraceKindDigitalPinGroup, Device: g.name, Operation: op, Direction: dir,
+		Value: value,
+	}
+	if err != nil {
+		r.Err = err.Error()
+	}
+	g.tracer.record(r)
+}
+
+// Export exports the pin and records the transaction.
+func (p *pwmPinTrace) Export() error {
+	start := p.tracer.now()
+	err := p.pin.Export()
+	p.trace(start, "Export", TraceDirectionConfig, 0, err)
+	return err
+}
+
+// Unexport releases the pin and records the transaction.
+func (p *pwmPinTrace) Unexport() error {
+	start := p.tracer.now()
+	err := p.pin.Unexport()
+	p.trace(start, "Unexport", TraceDirectionConfig, 0, err)
+	return err
+}
+
+// Enabled reads the enabled state of the pin and records the transaction.
+func (p *pwmPinTrace) Enabled() (bool, error) {
+	start := p.tracer.now()
+	enabled, err := p.pin.Enabled()
+	p.trace(start, "Enabled", TraceDirectionRead, boolToInt64(enabled), err)
+	return enabled, err
+}
+
+// SetEnabled writes the enabled state of the pin and records the transaction.
+func (p *pwmPinTrace) SetEnabled(val bool) error {
+	start := p.tracer.now()
+	err := p.pin.SetEnabled(val)
+	p.trace(start, "SetEnabled", TraceDirectionWrite, boolToInt64(val), err)
+	return err
+}
+
+// Polarity reads the polarity of the pin (1 for normal) and records the transaction.
+func (p *pwmPinTrace) Polarity() (bool, error) {
+	start := p.tracer.now()
+	normal, err := p.pin.Polarity()
+	p.trace(start, "Polarity", TraceDirectionRead, boolToInt64(normal), err)
+	return normal, err
+}
+
+// SetPolarity writes the polarity of the pin (1 for normal) and records the transaction.
+func (p *pwmPinTrace) SetPolarity(normal bool) error {
+	start := p.tracer.now()
+	err := p.pin.SetPolarity(normal)
+	p.trace(start, "SetPolarity", TraceDirectionWrite, boolToInt64(normal), err)
+	return err
+}
+
+// Period reads the period of the pin and records the transaction.
+func (p *pwmPinTrace) Period() (uint32, error) {
+	start := p.tracer.now()
+	period, err := p.pin.Period()
+	p.trace(start, "Period", TraceDirectionRead, int64(period), err)
+	return period, err
+}
+
+// SetPeriod writes the period of the pin and records the transaction.
+func (p *pwmPinTrace) SetPeriod(period uint32) error {
+	start := p.tracer.now()
+	err := p.pin.SetPeriod(period)
+	p.trace(start, "SetPeriod", TraceDirectionWrite, int64(period), err)
+	return err
+}
+
+// DutyCycle reads the duty cycle of the pin and records the transaction.
+func (p *pwmPinTrace) DutyCycle() (uint32, error) {
+	start := p.tracer.now()
+	duty, err := p.pin.DutyCycle()
+	p.trace(start, "DutyCycle", TraceDirectionRead, int64(duty), err)
+	return duty, err
+}
+
+// SetDutyCycle writes the duty cycle of the pin and records the transaction.
+func (p *pwmPinTrace) SetDutyCycle(duty uint32) error {
+	start := p.tracer.now()
+	err := p.pin.SetDutyCycle(duty)
+	p.trace(start, "SetDutyCycle", TraceDirectionWrite, int64(duty), err)
+	return err
+}
+
+func (p *pwmPinTrace) trace(start time.Time, op string, dir TraceDirection, value int64, err error) {
+	r := TraceRecord{Time: start, Kind: TraceKindPWMPin, Device: p.name, Operation: op, Direction: dir, Value: value}
+	if err != nil {
+		r.Err = err.Error()
+	}
+	p.tracer.record(r)
+}
+
+func cloneBytes(data []byte) []byte {
+	if data == nil {
+		return nil
+	}
+	c := make([]byte, len(data))
+	copy(c, data)
+	return c
+}
+
+func boolToInt64(val bool) int64 {
+	if val {
+		return 1
+	}
+	return 0
+}
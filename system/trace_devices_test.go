@@ -0,0 +1,252 @@
+package system
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gobot.io/x/gobot/v2"
+)
+
+var (
+	_ gobot.I2cSystemDevicer  = (*i2cDeviceTrace)(nil)
+	_ gobot.SpiSystemDevicer  = (*spiDeviceTrace)(nil)
+	_ gobot.DigitalPinner     = (*digitalPinTrace)(nil)
+	_ gobot.DigitalPinValuer  = (*digitalPinTrace)(nil)
+	_ gobot.DigitalPinGrouper = (*digitalPinGroupTrace)(nil)
+	_ gobot.PWMPinner         = (*pwmPinTrace)(nil)
+)
+
+// i2cTraceTestDevice returns the register value for all reads and fails, if an error is given
+type i2cTraceTestDevice struct {
+	err error
+}
+
+func (d *i2cTraceTestDevice) ReadByte(int) (byte, error)                   { return 0x11, d.err }
+func (d *i2cTraceTestDevice) ReadByteData(_ int, reg uint8) (uint8, error) { return reg, d.err }
+func (d *i2cTraceTestDevice) ReadWordData(int, uint8) (uint16, error)      { return 0x1234, d.err }
+func (d *i2cTraceTestDevice) ReadBlockData(_ int, _ uint8, data []byte) error {
+	copy(data, []byte{1, 2, 3})
+	return d.err
+}
+func (d *i2cTraceTestDevice) WriteByte(int, byte) error               { return d.err }
+func (d *i2cTraceTestDevice) WriteByteData(int, uint8, uint8) error   { return d.err }
+func (d *i2cTraceTestDevice) WriteBlockData(int, uint8, []byte) error { return d.err }
+func (d *i2cTraceTestDevice) WriteWordData(int, uint8, uint16) error  { return d.err }
+func (d *i2cTraceTestDevice) WriteBytes(int, []byte) error            { return d.err }
+func (d *i2cTraceTestDevice) Read(_ int, b []byte) (int, error)       { return copy(b, []byte{9, 8}), d.err }
+func (d *i2cTraceTestDevice) Write(_ int, b []byte) (int, error)      { return len(b), d.err }
+func (d *i2cTraceTestDevice) Close() error                            { return d.err }
+
+func newTestTracer() *Tracer {
+	tr := NewTracer(0)
+	tr.now = func() time.Time { return traceTestStart }
+	return tr
+}
+
+func TestI2cDeviceTrace(t *testing.T) {
+	// arrange
+	tr := newTestTracer()
+	d := newI2cDeviceTrace(tr, "/dev/i2c-1", &i2cTraceTestDevice{})
+	// act
+	_, _ = d.ReadByte(0x10)
+	_, _ = d.ReadByteData(0x10, 0x22)
+	_, _ = d.ReadWordData(0x10, 0x23)
+	_ = d.ReadBlockData(0x10, 0x24, make([]byte, 3))
+	_ = d.WriteByte(0x10, 0x01)
+	_ = d.WriteByteData(0x10, 0x25, 0x02)
+	_ = d.WriteBlockData(0x10, 0x26, []byte{0x03, 0x04})
+	_ = d.WriteWordData(0x10, 0x27, 0x0506)
+	_ = d.WriteBytes(0x10, []byte{0x07})
+	_, _ = d.Read(0x10, make([]byte, 4))
+	_, _ = d.Write(0x10, []byte{0x08, 0x09})
+	_ = d.Close()
+	// assert
+	type want struct {
+		op  string
+		dir TraceDirection
+		tx  []byte
+		rx  []byte
+	}
+	wants := []want{
+		{op: "ReadByte", dir: TraceDirectionRead, rx: []byte{0x11}},
+		{op: "ReadByteData", dir: TraceDirectionRead, tx: []byte{0x22}, rx: []byte{0x22}},
+		{op: "ReadWordData", dir: TraceDirectionRead, tx: []byte{0x23}, rx: []byte{0x34, 0x12}},
+		{op: "ReadBlockData", dir: TraceDirectionRead, tx: []byte{0x24}, rx: []byte{1, 2, 3}},
+		{op: "WriteByte", dir: TraceDirectionWrite, tx: []byte{0x01}},
+		{op: "WriteByteData", dir: TraceDirectionWrite, tx: []byte{0x25, 0x02}},
+		{op: "WriteBlockData", dir: TraceDirectionWrite, tx: []byte{0x26, 0x03, 0x04}},
+		{op: "WriteWordData", dir: TraceDirectionWrite, tx: []byte{0x27, 0x06, 0x05}},
+		{op: "WriteBytes", dir: TraceDirectionWrite, tx: []byte{0x07}},
+		{op: "Read", dir: TraceDirectionRead, rx: []byte{9, 8}},
+		{op: "Write", dir: TraceDirectionWrite, tx: []byte{0x08, 0x09}},
+		{op: "Close", dir: TraceDirectionConfig},
+	}
+	got := tr.Records()
+	require.Len(t, got, len(wants))
+	for i, w := range wants {
+		assert.Equal(t, traceTestStart, got[i].Time)
+		assert.Equal(t, TraceKindI2c, got[i].Kind)
+		assert.Equal(t, "/dev/i2c-1", got[i].Device)
+		assert.Equal(t, w.op, got[i].Operation)
+		assert.Equal(t, w.dir, got[i].Direction, w.op)
+		assert.Equal(t, w.tx, got[i].Tx, w.op)
+		assert.Equal(t, w.rx, got[i].Rx, w.op)
+		if w.op != "Close" {
+			assert.Equal(t, 0x10, got[i].Address)
+		}
+	}
+}
+
+func TestI2cDeviceTraceError(t *testing.T) {
+	// arrange
+	tr := newTestTracer()
+	d := newI2cDeviceTrace(tr, "/dev/i2c-1", &i2cTraceTestDevice{err: errors.New("read error")})
+	// act
+	_, err := d.ReadByteData(0x10, 0x22)
+	// assert
+	require.EqualError(t, err, "read error")
+	got := tr.Records()
+	require.Len(t, got, 1)
+	assert.Equal(t, "read error", got[0].Err)
+	assert.Equal(t, []byte{0x22}, got[0].Tx)
+	assert.Nil(t, got[0].Rx)
+}
+
+func TestSpiDeviceTrace(t *testing.T) {
+	// arrange
+	tr := newTestTracer()
+	a := NewAccesser(WithTracer(tr))
+	spi := a.UseMockSpi()
+	dev, err := a.NewSpiDevice(0, 1, 0, 8, 1000)
+	require.NoError(t, err)
+	spi.SetSimRead([]byte{0xa1, 0xa2, 0xa3})
+	// act
+	err = dev.TxRx([]byte{0x01}, make([]byte, 1))
+	require.NoError(t, err)
+	err = dev.(gobot.SpiTransferer).Transfer(gobot.SpiTransferSegment{Tx: []byte{0x02}},
+		gobot.SpiTransferSegment{Rx: make([]byte, 2)})
+	require.NoError(t, err)
+	spi.SetCloseError(true)
+	err = dev.Close()
+	// assert
+	require.Error(t, err)
+	got := tr.Records()
+	require.Len(t, got, 3)
+	assert.Equal(t, "spi0.1", got[0].Device)
+	assert.Equal(t, TraceKindSpi, got[0].Kind)
+	assert.Equal(t, TraceDirectionTransfer, got[0].Direction)
+	assert.Equal(t, []byte{0x01}, got[0].Tx)
+	assert.Equal(t, []byte{0xa1}, got[0].Rx)
+	// missing tx or rx is recorded as zeros
+	assert.Equal(t, "Transfer", got[1].Operation)
+	assert.Equal(t, []byte{0x02, 0x00, 0x00}, got[1].Tx)
+	assert.Equal(t, []byte{0x00, 0xa1, 0xa2}, got[1].Rx)
+	assert.Equal(t, "Close", got[2].Operation)
+	assert.NotEmpty(t, got[2].Err)
+}
+
+func TestDigitalPinTrace(t *testing.T) {
+	// arrange
+	tr := newTestTracer()
+	a := NewAccesser(WithTracer(tr))
+	dpa := a.UseMockDigitalPinAccess()
+	dpa.UseValues("gpiochip1", "17", []int{1})
+	pin := a.NewDigitalPin("gpiochip1", 17)
+	// act
+	require.NoError(t, pin.Export())
+	require.NoError(t, pin.ApplyOptions(WithPinDirectionOutput(0)))
+	require.NoError(t, pin.Write(1))
+	val, err := pin.Read()
+	require.NoError(t, err)
+	require.NoError(t, pin.Unexport())
+	// assert
+	assert.Equal(t, 1, val)
+	assert.Equal(t, []int{1}, dpa.Written("gpiochip1", "17"))
+	got := tr.Records()
+	require.Len(t, got, 5)
+	for _, r := range got {
+		assert.Equal(t, TraceKindDigitalPin, r.Kind)
+		assert.Equal(t, "gpiochip1:17", r.Device)
+	}
+	assert.Equal(t, "Export", got[0].Operation)
+	assert.Equal(t, TraceDirectionConfig, got[1].Direction)
+	assert.Equal(t, TraceDirectionWrite, got[2].Direction)
+	assert.Equal(t, int64(1), got[2].Value)
+	assert.Equal(t, TraceDirectionRead, got[3].Direction)
+	assert.Equal(t, int64(1), got[3].Value)
+	assert.Equal(t, "Unexport", got[4].Operation)
+}
+
+func TestDigitalPinGroupTrace(t *testing.T) {
+	// arrange
+	tr := newTestTracer()
+	a := NewAccesser(WithTracer(tr))
+	dpa := a.UseMockDigitalPinAccess()
+	dpa.UseValues("gpiochip1", "17", []int{0})
+	dpa.UseValues("gpiochip1", "18", []int{1})
+	group := a.NewDigitalPinGroup("gpiochip1", []int{17, 18})
+	// act
+	require.NoError(t, group.Export())
+	require.NoError(t, group.ApplyOptions(WithPinGroupDirectionOutput(0)))
+	require.NoError(t, group.Write(0x01))
+	val, err := group.Read()
+	require.NoError(t, err)
+	require.NoError(t, group.Unexport())
+	// assert
+	assert.Equal(t, uint64(0x02), val)
+	assert.Equal(t, []int{1}, dpa.Written("gpiochip1", "17"))
+	got := tr.Records()
+	require.Len(t, got, 5)
+	for _, r := range got {
+		assert.Equal(t, TraceKindDigitalPinGroup, r.Kind)
+		assert.Equal(t, "gpiochip1:17,18", r.Device)
+	}
+	assert.Equal(t, "Export", got[0].Operation)
+	assert.Equal(t, TraceDirectionConfig, got[1].Direction)
+	assert.Equal(t, TraceDirectionWrite, got[2].Direction)
+	assert.Equal(t, int64(0x01), got[2].Value)
+	assert.Equal(t, TraceDirectionRead, got[3].Direction)
+	assert.Equal(t, int64(0x02), got[3].Value)
+	assert.Equal(t, "Unexport", got[4].Operation)
+}
+
+func TestPWMPinTrace(t *testing.T) {
+	// arrange
+	tr := newTestTracer()
+	a := NewAccesser(WithTracer(tr))
+	a.UseMockDigitalPinAccess()
+	pin := a.NewPWMPinSoft(a.NewDigitalPin("", 4))
+	tr.Reset()
+	// act
+	require.NoError(t, pin.SetPolarity(true))
+	require.NoError(t, pin.SetPeriod(1000000))
+	require.NoError(t, pin.SetDutyCycle(250000))
+	period, _ := pin.Period()
+	duty, _ := pin.DutyCycle()
+	normal, _ := pin.Polarity()
+	enabled, _ := pin.Enabled()
+	err := pin.SetPeriod(10)
+	// assert
+	require.Error(t, err)
+	assert.Equal(t, uint32(1000000), period)
+	assert.Equal(t, uint32(250000), duty)
+	assert.True(t, normal)
+	assert.False(t, enabled)
+	got := tr.Records()
+	require.Len(t, got, 8)
+	wantOps := []string{
+		"SetPolarity", "SetPeriod", "SetDutyCycle", "Period", "DutyCycle", "Polarity", "Enabled", "SetPeriod",
+	}
+	wantValues := []int64{1, 1000000, 250000, 1000000, 250000, 1, 0, 10}
+	for i, r := range got {
+		assert.Equal(t, TraceKindPWMPin, r.Kind)
+		assert.Equal(t, "soft:gpio:4", r.Device)
+		assert.Equal(t, wantOps[i], r.Operation)
+		assert.Equal(t, wantValues[i], r.Value, wantOps[i])
+	}
+	assert.NotEmpty(t, got[7].Err)
+}
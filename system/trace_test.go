@@ -0,0 +1,160 @@
+package system
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var traceTestStart = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func TestTracerRecords(t *testing.T) {
+	// arrange
+	tr := NewTracer(2)
+	// act
+	for i := 0; i < 3; i++ {
+		tr.record(TraceRecord{Kind: TraceKindDigitalPin, Device: "gpio:1", Value: int64(i)})
+	}
+	got := tr.Records()
+	// assert: only the latest records are kept
+	require.Len(t, got, 2)
+	assert.Equal(t, int64(1), got[0].Value)
+	assert.Equal(t, int64(2), got[1].Value)
+	// act
+	tr.Reset()
+	// assert
+	assert.Empty(t, tr.Records())
+}
+
+func TestTracerWriteJSONL(t *testing.T) {
+	// arrange
+	tr := NewTracer(0)
+	tr.record(TraceRecord{
+		Time: traceTestStart, Kind: TraceKindI2c, Device: "/dev/i2c-1", Address: 0x40, Operation: "ReadByteData",
+		Direction: TraceDirectionRead, Tx: []byte{0x0a}, Rx: []byte{0xff},
+	})
+	tr.record(TraceRecord{
+		Time: traceTestStart.Add(time.Millisecond), Kind: TraceKindDigitalPin, Device: "gpiochip0:17", Operation: "Write",
+		Direction: TraceDirectionWrite, Value: 0,
+	})
+	tr.record(TraceRecord{
+		Time: traceTestStart.Add(2 * time.Millisecond), Kind: TraceKindSpi, Device: "spi0.1", Operation: "Close",
+		Direction: TraceDirectionConfig, Err: "close error",
+	})
+	var buf bytes.Buffer
+	// act
+	err := tr.WriteJSONL(&buf)
+	// assert
+	require.NoError(t, err)
+	want := `{"time":"2024-05-01T12:00:00Z","kind":"i2c","device":"/dev/i2c-1","address":64,"op":"ReadByteData",` +
+		`"dir":"read","tx":"0a","rx":"ff"}` + "\n" +
+		`{"time":"2024-05-01T12:00:00.001Z","kind":"gpio","device":"gpiochip0:17","op":"Write","dir":"write",` +
+		`"value":0}` + "\n" +
+		`{"time":"2024-05-01T12:00:00.002Z","kind":"spi","device":"spi0.1","op":"Close","dir":"config",` +
+		`"err":"close error"}` + "\n"
+	assert.Equal(t, want, buf.String())
+}
+
+func TestTracerWriteVCD(t *testing.T) {
+	// arrange
+	tr := NewTracer(0)
+	tr.record(TraceRecord{
+		Time: traceTestStart, Kind: TraceKindDigitalPin, Device: "gpiochip0:17", Operation: "Write",
+		Direction: TraceDirectionWrite, Value: 1,
+	})
+	tr.record(TraceRecord{
+		Time: traceTestStart.Add(10 * time.Microsecond), Kind: TraceKindI2c, Device: "/dev/i2c-1", Address: 0x40,
+		Operation: "ReadByteData", Direction: TraceDirectionRead, Tx: []byte{0x0a}, Rx: []byte{0x05},
+	})
+	tr.record(TraceRecord{
+		Time: traceTestStart.Add(20 * time.Microsecond), Kind: TraceKindPWMPin, Device: "soft", Operation: "SetDutyCycle",
+		Direction: TraceDirectionWrite, Value: 3,
+	})
+	tr.record(TraceRecord{
+		Time: traceTestStart.Add(30 * time.Microsecond), Kind: TraceKindDigitalPin, Device: "gpiochip0:17",
+		Operation: "Write", Direction: TraceDirectionWrite, Err: "write error",
+	})
+	var buf bytes.Buffer
+	// act
+	err := tr.WriteVCD(&buf)
+	// assert
+	require.NoError(t, err)
+	want := []string{
+		"$date 2024-05-01T12:00:00Z $end",
+		"$version gobot trace $end",
+		"$timescale 1ns $end",
+		"$scope module gobot $end",
+		"$var wire 1 ! gpiochip0_17 $end",
+		"$var wire 7 \" dev_i2c_1_addr $end",
+		"$var wire 8 # dev_i2c_1_tx $end",
+		"$var wire 8 $ dev_i2c_1_rx $end",
+		"$var wire 32 % soft_duty $end",
+		"$var wire 1 & gpiochip0_17_error $end",
+		"$upscope $end",
+		"$enddefinitions $end",
+		"#0",
+		"$dumpvars",
+		"x!", "bx \"", "bx #", "bx $", "bx %", "x&",
+		"$end",
+		"1!",
+		"#10000",
+		"b1000000 \"",
+		"b1010 #",
+		"#11000",
+		"bx #",
+		"b101 $",
+		"#12000",
+		"bx $",
+		"#20000",
+		"b11 %",
+		"#30000",
+		"1&",
+		"",
+	}
+	assert.Equal(t, strings.Join(want, "\n"), buf.String())
+}
+
+func TestTracerWriteVCDPinGroup(t *testing.T) {
+	// arrange
+	tr := NewTracer(0)
+	tr.record(TraceRecord{
+		Time: traceTestStart, Kind: TraceKindDigitalPin, Device: "gpiochip0:18", Operation: "Write",
+		Direction: TraceDirectionWrite, Value: 1,
+	})
+	tr.record(TraceRecord{
+		Time: traceTestStart.Add(10 * time.Microsecond), Kind: TraceKindDigitalPinGroup, Device: "gpiochip0:17,18",
+		Operation: "Write", Direction: TraceDirectionWrite, Value: 0x01,
+	})
+	var buf bytes.Buffer
+	// act
+	err := tr.WriteVCD(&buf)
+	// assert: the group shares the wire of the single pin
+	require.NoError(t, err)
+	want := []string{
+		"$var wire 1 ! gpiochip0_18 $end",
+		"$var wire 1 \" gpiochip0_17 $end",
+		"$upscope $end",
+		"$enddefinitions $end",
+		"#0",
+		"$dumpvars",
+		"x!", "x\"",
+		"$end",
+		"1!",
+		"#10000",
+		"1\"",
+		"0!",
+		"",
+	}
+	assert.Contains(t, buf.String(), strings.Join(want, "\n"))
+}
+
+func Test_vcdIdentifier(t *testing.T) {
+	assert.Equal(t, "!", vcdIdentifier(0))
+	assert.Equal(t, "~", vcdIdentifier(93))
+	assert.Equal(t, "!!", vcdIdentifier(94))
+	assert.Equal(t, "\"!", vcdIdentifier(95))
+}
@@ -0,0 +1,84 @@
+package system
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// MockUartAccess contains all opened mocked UART devices, accessible by the path.
+type MockUartAccess struct {
+	OpenError bool
+	Devices   map[string]*MockUartDevice
+}
+
+// MockUartDevice is a mocked UART device, which records all written data and returns the prepared read data.
+type MockUartDevice struct {
+	Path       string
+	BaudRate   int
+	Closed     bool
+	CloseError bool
+	mutex      sync.Mutex
+	written    []byte
+	readData   bytes.Buffer
+}
+
+func newMockUartAccess() *MockUartAccess {
+	return &MockUartAccess{Devices: make(map[string]*MockUartDevice)}
+}
+
+func (mua *MockUartAccess) openDevice(path string, baudRate int) (io.ReadWriteCloser, error) {
+	if mua.OpenError {
+		return nil, fmt.Errorf("error while open UART '%s' in mock", path)
+	}
+
+	dev := &MockUartDevice{Path: path, BaudRate: baudRate}
+	mua.Devices[path] = dev
+	return dev, nil
+}
+
+// UseReadData appends the given data to be returned by the next reads.
+func (d *MockUartDevice) UseReadData(data []byte) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.readData.Write(data)
+}
+
+// Written returns all data written to the device.
+func (d *MockUartDevice) Written() []byte {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return append([]byte(nil), d.written...)
+}
+
+// Read implements the io.Reader interface. It returns io.EOF, if no read data is available anymore.
+func (d *MockUartDevice) Read(b []byte) (int, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.readData.Read(b)
+}
+
+// Write implements the io.Writer interface.
+func (d *MockUartDevice) Write(b []byte) (int, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.written = append(d.written, b...)
+	return len(b), nil
+}
+
+// Close implements the io.Closer interface.
+func (d *MockUartDevice) Close() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.CloseError {
+		return errClose
+	}
+	d.Closed = true
+	return nil
+}
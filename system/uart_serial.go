@@ -0,0 +1,19 @@
+package system
+
+import (
+	"io"
+
+	"go.bug.st/serial"
+)
+
+// uartAccesser represents unexposed interface to allow the switch between the native implementation and a mocked one
+type uartAccesser interface {
+	openDevice(path string, baudRate int) (io.ReadWriteCloser, error)
+}
+
+// serialUartAccess opens the UART character devices, e.g. "/dev/ttyAMA0", by the serial package
+type serialUartAccess struct{}
+
+func (*serialUartAccess) openDevice(path string, baudRate int) (io.ReadWriteCloser, error) {
+	return serial.Open(path, &serial.Mode{BaudRate: baudRate})
+}
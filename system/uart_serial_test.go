@@ -0,0 +1,42 @@
+package system
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUartDevice(t *testing.T) {
+	// arrange
+	a := NewAccesser()
+	a.AddUartSupport()
+	mua := a.UseMockUart()
+	// act
+	dev, err := a.NewUartDevice("/dev/ttyAMA0", 9600)
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 9600, mua.Devices["/dev/ttyAMA0"].BaudRate)
+	n, err := dev.Write([]byte{0x01, 0x02})
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []byte{0x01, 0x02}, mua.Devices["/dev/ttyAMA0"].Written())
+	mua.Devices["/dev/ttyAMA0"].UseReadData([]byte{0x03})
+	buf := make([]byte, 2)
+	n, err = dev.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x03}, buf[:n])
+	require.NoError(t, dev.Close())
+	assert.True(t, mua.Devices["/dev/ttyAMA0"].Closed)
+}
+
+func TestNewUartDeviceNative(t *testing.T) {
+	// arrange
+	a := NewAccesser()
+	a.AddUartSupport()
+	// act
+	_, err := a.NewUartDevice(filepath.Join(t.TempDir(), "ttyNotExistent"), 9600)
+	// assert
+	require.Error(t, err)
+}
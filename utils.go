@@ -30,6 +30,30 @@ func After(t time.Duration, f func()) {
 	time.AfterFunc(t, f)
 }
 
+// EveryWithClock is the variant of [Every] for an injectable [Clock], e.g. of a robot or driver. The period t is
+// measured by the ticker of the clock, so with a [FakeClock] f is only called when the clock is advanced by at least t.
+// The calls of f are done one after the other in a single goroutine. While f is running, at most one further tick is
+// kept and all others are dropped, like for a time.Ticker. Stop() on the returned Ticker prevents further ticks, but a
+// running call of f is not interrupted and an already kept tick can still lead to one more call.
+func EveryWithClock(c Clock, t time.Duration, f func()) Ticker {
+	ticker := c.NewTicker(t)
+
+	go func() {
+		for {
+			<-ticker.C()
+			f()
+		}
+	}()
+
+	return ticker
+}
+
+// AfterWithClock triggers f after t duration of the given clock. The execution can be prevented by calling Stop() on
+// the returned Timer.
+func AfterWithClock(c Clock, t time.Duration, f func()) Timer {
+	return c.AfterFunc(t, f)
+}
+
 // Rand returns a positive random int up to maximum
 func Rand(maximum int) int {
 	i, _ := rand.Int(rand.Reader, big.NewInt(int64(maximum)))
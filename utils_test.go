@@ -64,6 +64,33 @@ func TestAfter(t *testing.T) {
 	assert.Equal(t, 1, i)
 }
 
+func TestEveryWithClock(t *testing.T) {
+	// arrange
+	clock := NewFakeClock(time.Unix(0, 0))
+	sem := make(chan time.Time)
+	ticker := EveryWithClock(clock, time.Minute, func() { sem <- clock.Now() })
+	// act & assert
+	clock.Advance(time.Minute)
+	assert.Equal(t, time.Unix(60, 0), <-sem)
+	clock.Advance(time.Minute)
+	assert.Equal(t, time.Unix(120, 0), <-sem)
+	ticker.Stop()
+	assert.Equal(t, 0, clock.WaiterCount())
+}
+
+func TestAfterWithClock(t *testing.T) {
+	// arrange
+	clock := NewFakeClock(time.Unix(0, 0))
+	sem := make(chan bool)
+	AfterWithClock(clock, time.Hour, func() { sem <- true })
+	stopped := AfterWithClock(clock, time.Hour, func() { require.Fail(t, "After was called after stop") })
+	require.True(t, stopped.Stop())
+	// act
+	clock.Advance(time.Hour)
+	// assert
+	assert.True(t, <-sem)
+}
+
 func TestFromScale(t *testing.T) {
 	assert.InDelta(t, 0.5, FromScale(5, 0, 10), 0.0)
 }